@@ -5,14 +5,36 @@ CREATE DATABASE `melifresh`;
 
 USE `melifresh`;
 
+-- table `countries`
+CREATE TABLE `countries`
+(
+    `id`   int(11) NOT NULL AUTO_INCREMENT,
+    `name` varchar(255) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE (`name`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `provinces`
+CREATE TABLE `provinces`
+(
+    `id`         int(11) NOT NULL AUTO_INCREMENT,
+    `name`       varchar(255) NOT NULL,
+    `country_id` int(11) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE (`country_id`, `name`),
+    FOREIGN KEY (`country_id`) REFERENCES countries (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
 -- table `localities`
 CREATE TABLE `localities`
 (
     `id` int(11) NOT NULL,
     `name` varchar(255) NOT NULL,
-    `province_name` varchar(255) NOT NULL,
-    `country_name` varchar(255) NOT NULL,
-    PRIMARY KEY (`id`)
+    `province_id` int(11) NOT NULL,
+    `latitude` decimal(9, 6) NULL,
+    `longitude` decimal(9, 6) NULL,
+    PRIMARY KEY (`id`),
+    FOREIGN KEY (`province_id`) REFERENCES provinces (id)
 ) ENGINE = InnoDB DEFAULT CHARSET = utf8;
 
 -- table `sellers`
@@ -24,6 +46,7 @@ CREATE TABLE `sellers`
     `address`      varchar(255) NOT NULL,
     `telephone`    varchar(15)  NOT NULL,
     `locality_id`  int(11) NOT NULL,
+    `status`       varchar(20)  NOT NULL DEFAULT 'active',
     FOREIGN KEY (`locality_id`) REFERENCES localities (id) ON DELETE CASCADE,
     PRIMARY KEY (`id`)
 ) ENGINE = InnoDB DEFAULT CHARSET = utf8;
@@ -37,17 +60,32 @@ CREATE TABLE `warehouses`
     `telephone`           varchar(15)  NOT NULL,
     `minimum_capacity`    int          NOT NULL,
     `minimum_temperature` float        NOT NULL,
-    PRIMARY KEY (`id`)
+    `locality_id`         int(11) NULL,
+    `latitude`            decimal(9, 6) NULL,
+    `longitude`           decimal(9, 6) NULL,
+    PRIMARY KEY (`id`),
+    FOREIGN KEY (`locality_id`) REFERENCES localities (id)
 ) ENGINE = InnoDB DEFAULT CHARSET = utf8;
 
 -- table `product_type`
 CREATE TABLE `product_type`
 (
     `id` int(11) NOT NULL AUTO_INCREMENT,
+    `name` varchar(255) UNIQUE NOT NULL,
     `description` varchar(255) NOT NULL,
     PRIMARY KEY (`id`)
 ) ENGINE = InnoDB DEFAULT CHARSET = utf8;
 
+-- table `product_type_compatibilities`
+CREATE TABLE `product_type_compatibilities`
+(
+    `product_type_id`         int(11) NOT NULL,
+    `section_product_type_id` int(11) NOT NULL,
+    FOREIGN KEY (`product_type_id`) REFERENCES product_type (id) ON DELETE CASCADE,
+    FOREIGN KEY (`section_product_type_id`) REFERENCES product_type (id) ON DELETE CASCADE,
+    PRIMARY KEY (`product_type_id`, `section_product_type_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
 -- table `sections`
 CREATE TABLE `sections`
 (
@@ -80,7 +118,8 @@ CREATE TABLE `products`
     width                            float       NOT NULL,
     seller_id                        int(11) NOT NULL,
     product_type_id                  int(11) NOT NULL,
-    FOREIGN KEY (`seller_id`) REFERENCES sellers(id) ON DELETE CASCADE,
+    status                           varchar(20) NOT NULL DEFAULT 'active',
+    FOREIGN KEY (`seller_id`) REFERENCES sellers(id),
     FOREIGN KEY (`product_type_id`) REFERENCES product_type(id) ON DELETE CASCADE,
     PRIMARY KEY (id)
 
@@ -117,6 +156,7 @@ CREATE TABLE product_records
 	sale_price        float NOT NULL,
 	product_id        int NOT NULL,
     FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE CASCADE,
+	KEY product_records_product_date (product_id, last_update_date),
 	PRIMARY KEY (id)
 ) ENGINE = InnoDB DEFAULT CHARSET = utf8;
 
@@ -129,11 +169,38 @@ CREATE TABLE `purchase_orders`
     `tracking_code` varchar(255) NOT NULL,
     `buyer_id` int(11) NULL,
     `product_record_id` int(11) NULL,
+    `status` varchar(20) NOT NULL DEFAULT 'created',
     FOREIGN KEY (`buyer_id`) REFERENCES buyers (id) ON DELETE CASCADE,
     FOREIGN KEY (`product_record_id`) REFERENCES product_records (id) ON DELETE CASCADE,
     PRIMARY KEY (`id`)
 ) ENGINE = InnoDB DEFAULT CHARSET = utf8;
 
+-- table `purchase_order_status_history`
+CREATE TABLE `purchase_order_status_history`
+(
+    `id`                int(11) NOT NULL AUTO_INCREMENT,
+    `purchase_order_id` int(11) NOT NULL,
+    `from_status`       varchar(20) NOT NULL,
+    `to_status`         varchar(20) NOT NULL,
+    `changed_by`        varchar(255) NOT NULL,
+    `changed_at`        datetime NOT NULL,
+    FOREIGN KEY (`purchase_order_id`) REFERENCES purchase_orders (id) ON DELETE CASCADE,
+    PRIMARY KEY (`id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `purchase_order_lines`
+CREATE TABLE `purchase_order_lines`
+(
+    `id`                int(11) NOT NULL AUTO_INCREMENT,
+    `purchase_order_id` int(11) NOT NULL,
+    `product_id`        int(11) NOT NULL,
+    `quantity`          int(11) NOT NULL,
+    `unit_price`        decimal(19, 2) NOT NULL,
+    FOREIGN KEY (`purchase_order_id`) REFERENCES purchase_orders (id) ON DELETE CASCADE,
+    FOREIGN KEY (`product_id`) REFERENCES products (id) ON DELETE CASCADE,
+    PRIMARY KEY (`id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
 -- table `carries`
 CREATE TABLE `carries`
 (
@@ -147,6 +214,20 @@ CREATE TABLE `carries`
 	PRIMARY KEY (`id`)
 ) ENGINE = InnoDB DEFAULT CHARSET = utf8;
 
+-- table `carry_coverages`
+CREATE TABLE `carry_coverages`
+(
+    `id`             int(11) NOT NULL AUTO_INCREMENT,
+    `carry_id`       int(11) NOT NULL,
+    `locality_id`    int(11) NOT NULL,
+    `lead_time_days` int(11) NOT NULL,
+    `cost_per_kg`    decimal(10, 2) NOT NULL,
+    UNIQUE (`carry_id`, `locality_id`),
+    FOREIGN KEY (`carry_id`) REFERENCES carries (id) ON DELETE CASCADE,
+    FOREIGN KEY (`locality_id`) REFERENCES localities (id) ON DELETE CASCADE,
+    PRIMARY KEY (`id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
 -- table `product_batches`
 CREATE TABLE `product_batches` (
     `id`                 INT(11) NOT NULL AUTO_INCREMENT,
@@ -160,11 +241,132 @@ CREATE TABLE `product_batches` (
     `minumum_temperature` FLOAT NOT NULL,
     `product_id`        INT(11) NOT NULL,
     `section_id`        INT(11) NOT NULL,
+    `status`            VARCHAR(20) NOT NULL DEFAULT 'released',
     FOREIGN KEY (`product_id`) REFERENCES products(id) ON DELETE CASCADE,
     FOREIGN KEY (`section_id`) REFERENCES sections(id) ON DELETE CASCADE,
     PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8;
 
+-- table `product_batch_holds`
+CREATE TABLE `product_batch_holds`
+(
+    `id`               int(11) NOT NULL AUTO_INCREMENT,
+    `product_batch_id` int(11) NOT NULL,
+    `from_status`      varchar(20)  NOT NULL,
+    `to_status`        varchar(20)  NOT NULL,
+    `reason`           varchar(255) NOT NULL,
+    `employee_id`      int(11) NOT NULL,
+    `created_at`       datetime     NOT NULL,
+    FOREIGN KEY (`product_batch_id`) REFERENCES product_batches (id) ON DELETE CASCADE,
+    FOREIGN KEY (`employee_id`) REFERENCES employees (id),
+    PRIMARY KEY (`id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `stock_movements`
+CREATE TABLE `stock_movements`
+(
+    `id`               int(11) NOT NULL AUTO_INCREMENT,
+    `product_batch_id` int(11) NOT NULL,
+    `type`             varchar(20)  NOT NULL,
+    `quantity`         int(11) NOT NULL,
+    `reason`           varchar(255) NOT NULL DEFAULT '',
+    `quantity_after`   int(11) NOT NULL,
+    `created_at`       datetime NOT NULL,
+    FOREIGN KEY (`product_batch_id`) REFERENCES product_batches (id) ON DELETE CASCADE,
+    PRIMARY KEY (`id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `transfers`
+CREATE TABLE `transfers`
+(
+    `id`                           int(11) NOT NULL AUTO_INCREMENT,
+    `product_batch_id`             int(11) NOT NULL,
+    `destination_product_batch_id` int(11) NULL,
+    `from_section_id`              int(11) NOT NULL,
+    `to_section_id`                int(11) NOT NULL,
+    `quantity`                     int(11) NOT NULL,
+    `status`                       varchar(20) NOT NULL,
+    `employee_id`                  int(11) NOT NULL,
+    `dispatched_by`                int(11) NULL,
+    `received_by`                  int(11) NULL,
+    `created_at`                   datetime NOT NULL,
+    `dispatched_at`                datetime NULL,
+    `received_at`                  datetime NULL,
+    FOREIGN KEY (`product_batch_id`) REFERENCES product_batches (id) ON DELETE CASCADE,
+    FOREIGN KEY (`destination_product_batch_id`) REFERENCES product_batches (id) ON DELETE SET NULL,
+    FOREIGN KEY (`from_section_id`) REFERENCES sections (id) ON DELETE CASCADE,
+    FOREIGN KEY (`to_section_id`) REFERENCES sections (id) ON DELETE CASCADE,
+    FOREIGN KEY (`employee_id`) REFERENCES employees (id),
+    FOREIGN KEY (`dispatched_by`) REFERENCES employees (id),
+    FOREIGN KEY (`received_by`) REFERENCES employees (id),
+    PRIMARY KEY (`id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `temperature_readings`
+CREATE TABLE `temperature_readings`
+(
+    `id`          int(11) NOT NULL AUTO_INCREMENT,
+    `section_id`  int(11) NOT NULL,
+    `recorded_at` datetime NOT NULL,
+    `celsius`     decimal(19, 2) NOT NULL,
+    FOREIGN KEY (`section_id`) REFERENCES sections (id) ON DELETE CASCADE,
+    INDEX `idx_temperature_readings_section_recorded_at` (`section_id`, `recorded_at`),
+    PRIMARY KEY (`id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `temperature_excursions`
+CREATE TABLE `temperature_excursions`
+(
+    `id`              int(11) NOT NULL AUTO_INCREMENT,
+    `section_id`      int(11) NOT NULL,
+    `status`          varchar(20)    NOT NULL DEFAULT 'open',
+    `started_at`      datetime       NOT NULL,
+    `ended_at`        datetime       NULL,
+    `peak_celsius`    decimal(19, 2) NOT NULL,
+    `peak_deviation`  decimal(19, 2) NOT NULL,
+    `limit_celsius`   decimal(19, 2) NOT NULL,
+    `acknowledged_by` varchar(255)   NOT NULL DEFAULT '',
+    `acknowledged_at` datetime       NULL,
+    `closed_by`       varchar(255)   NOT NULL DEFAULT '',
+    FOREIGN KEY (`section_id`) REFERENCES sections (id) ON DELETE CASCADE,
+    INDEX `idx_temperature_excursions_section_ended_at` (`section_id`, `ended_at`),
+    PRIMARY KEY (`id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `recalls`
+CREATE TABLE `recalls`
+(
+    `id`         int(11) NOT NULL AUTO_INCREMENT,
+    `product_id` int(11) NULL,
+    `reason`     varchar(255) NOT NULL,
+    `created_at` datetime     NOT NULL,
+    FOREIGN KEY (`product_id`) REFERENCES products (id) ON DELETE CASCADE,
+    PRIMARY KEY (`id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `recall_batches`
+CREATE TABLE `recall_batches`
+(
+    `recall_id`        int(11) NOT NULL,
+    `product_batch_id` int(11) NOT NULL,
+    FOREIGN KEY (`recall_id`) REFERENCES recalls (id) ON DELETE CASCADE,
+    FOREIGN KEY (`product_batch_id`) REFERENCES product_batches (id) ON DELETE CASCADE,
+    PRIMARY KEY (`recall_id`, `product_batch_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `purchase_order_reservations`
+CREATE TABLE `purchase_order_reservations`
+(
+    `id`                     int(11) NOT NULL AUTO_INCREMENT,
+    `purchase_order_line_id` int(11) NOT NULL,
+    `product_batch_id`       int(11) NOT NULL,
+    `quantity`               int(11) NOT NULL,
+    `consumed`               tinyint(1) NOT NULL DEFAULT 0,
+    FOREIGN KEY (`purchase_order_line_id`) REFERENCES purchase_order_lines (id) ON DELETE CASCADE,
+    FOREIGN KEY (`product_batch_id`) REFERENCES product_batches (id) ON DELETE CASCADE,
+    PRIMARY KEY (`id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
 -- table `inbound_orders`
 CREATE TABLE `inbound_orders`
 (
@@ -172,27 +374,78 @@ CREATE TABLE `inbound_orders`
     `order_date`       date NOT NULL,
     `order_number`     varchar(255) UNIQUE NOT NULL,
     `employee_id`      int(11) NOT NULL,
-    `product_batch_id` int(11) NOT NULL,
+    `product_batch_id` int(11),
     `warehouse_id`     int(11) NOT NULL,
+    `quantity`         int(11) NOT NULL,
     FOREIGN KEY (`employee_id`) REFERENCES employees (id) ON DELETE CASCADE,
     FOREIGN KEY (`product_batch_id`) REFERENCES product_batches (id) ON DELETE CASCADE,
     FOREIGN KEY (`warehouse_id`) REFERENCES warehouses (id) ON DELETE CASCADE,
     PRIMARY KEY (`id`)
 ) ENGINE = InnoDB DEFAULT CHARSET = utf8;
 
+-- table `inbound_order_lines`
+CREATE TABLE `inbound_order_lines`
+(
+    `id`                int(11) NOT NULL AUTO_INCREMENT,
+    `inbound_order_id`  int(11) NOT NULL,
+    `product_batch_id`  int(11) NOT NULL,
+    `expected_quantity` int(11) NOT NULL,
+    `received_quantity` int(11) NOT NULL,
+    FOREIGN KEY (`inbound_order_id`) REFERENCES inbound_orders (id) ON DELETE CASCADE,
+    FOREIGN KEY (`product_batch_id`) REFERENCES product_batches (id) ON DELETE CASCADE,
+    PRIMARY KEY (`id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `shipments`
+CREATE TABLE `shipments`
+(
+    `id`            int(11) NOT NULL AUTO_INCREMENT,
+    `tracking_code` varchar(50) UNIQUE NOT NULL,
+    `carry_id`      int(11) NOT NULL,
+    `warehouse_id`  int(11) NOT NULL,
+    `status`        varchar(20) NOT NULL,
+    `created_at`    datetime NOT NULL,
+    `dispatched_at` datetime NULL,
+    `delivered_at`  datetime NULL,
+    FOREIGN KEY (`carry_id`) REFERENCES carries (id),
+    FOREIGN KEY (`warehouse_id`) REFERENCES warehouses (id),
+    PRIMARY KEY (`id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `shipment_purchase_orders`
+CREATE TABLE `shipment_purchase_orders`
+(
+    `shipment_id`       int(11) NOT NULL,
+    `purchase_order_id` int(11) UNIQUE NOT NULL,
+    FOREIGN KEY (`shipment_id`) REFERENCES shipments (id) ON DELETE CASCADE,
+    FOREIGN KEY (`purchase_order_id`) REFERENCES purchase_orders (id) ON DELETE CASCADE,
+    PRIMARY KEY (`shipment_id`, `purchase_order_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
 
 -- DML
-INSERT INTO localities (id, name, province_name, country_name)
-VALUES (1, 'New York City', 'New York', 'United States'),
-       (2, 'Los Angeles', 'California', 'United States'),
-       (3, 'Chicago', 'Illinois', 'United States'),
-       (4, 'Houston', 'Texas', 'United States'),
-       (5, 'Phoenix', 'Arizona', 'United States'),
-       (6, 'Philadelphia', 'Pennsylvania', 'United States'),
-       (7, 'San Antonio', 'Texas', 'United States'),
-       (8, 'San Diego', 'California', 'United States'),
-       (9, 'Dallas', 'Texas', 'United States'),
-       (10, 'San Jose', 'California', 'United States');
+INSERT INTO countries (id, name)
+VALUES (1, 'United States');
+
+INSERT INTO provinces (id, name, country_id)
+VALUES (1, 'New York', 1),
+       (2, 'California', 1),
+       (3, 'Illinois', 1),
+       (4, 'Texas', 1),
+       (5, 'Arizona', 1),
+       (6, 'Pennsylvania', 1);
+
+INSERT INTO localities (id, name, province_id, latitude, longitude)
+VALUES (1, 'New York City', 1, 40.712800, -74.006000),
+       (2, 'Los Angeles', 2, 34.052200, -118.243700),
+       (3, 'Chicago', 3, 41.878100, -87.629800),
+       (4, 'Houston', 4, 29.760400, -95.369800),
+       (5, 'Phoenix', 5, 33.448400, -112.074000),
+       (6, 'Philadelphia', 6, 39.952600, -75.165200),
+       (7, 'San Antonio', 4, 29.424100, -98.493600),
+       (8, 'San Diego', 2, 32.715700, -117.161100),
+       (9, 'Dallas', 4, 32.776700, -96.797000),
+       (10, 'San Jose', 2, 37.338200, -121.886300);
 
 INSERT INTO sellers (cid, company_name, address, telephone, locality_id)
 VALUES (1, 'Company A', '123 Main St', '123-456-7890', 1),
@@ -206,29 +459,33 @@ VALUES (1, 'Company A', '123 Main St', '123-456-7890', 1),
        (9, 'Company I', '106 Cherry St', '123-456-7898', 9),
        (10, 'Company J', '107 Walnut St', '123-456-7899', 10);
 
-INSERT INTO warehouses (warehouse_code, address, telephone, minimum_capacity, minimum_temperature)
-VALUES ('WH01', '200 Warehouse Rd', '234-567-8901', 100, 0),
-       ('WH02', '201 Warehouse Ln', '234-567-8902', 150, -5),
-       ('WH03', '202 Storage Blvd', '234-567-8903', 120, 2),
-       ('WH04', '203 Distribution Ave', '234-567-8904', 200, -2),
-       ('WH05', '204 Inventory St', '234-567-8905', 180, 0),
-       ('WH06', '205 Logistics Way', '234-567-8906', 160, -3),
-       ('WH07', '206 Depot Dr', '234-567-8907', 140, 1),
-       ('WH08', '207 Supply Ct', '234-567-8908', 170, -4),
-       ('WH09', '208 Goods Rd', '234-567-8909', 130, 3),
-       ('WH10', '209 Freight St', '234-567-8910', 190, -1);
-
-INSERT INTO product_type (description)
-VALUES  ('Dairy'),
-        ('Meat'),
-        ('Vegetables'),
-        ('Fruits'),
-        ('Bakery'),
-        ('Seafood'),
-        ('Beverages'),
-        ('Snacks'),
-        ('Condiments'),
-        ('Frozen Foods');
+INSERT INTO warehouses (warehouse_code, address, telephone, minimum_capacity, minimum_temperature, locality_id, latitude, longitude)
+VALUES ('WH01', '200 Warehouse Rd', '234-567-8901', 100, 0, 1, 40.735700, -74.172400),
+       ('WH02', '201 Warehouse Ln', '234-567-8902', 150, -5, 2, 33.953300, -117.396200),
+       ('WH03', '202 Storage Blvd', '234-567-8903', 120, 2, 3, 41.525000, -88.081700),
+       ('WH04', '203 Distribution Ave', '234-567-8904', 200, -2, 4, 29.785800, -95.824400),
+       ('WH05', '204 Inventory St', '234-567-8905', 180, 0, 5, 33.306200, -111.841300),
+       ('WH06', '205 Logistics Way', '234-567-8906', 160, -3, 6, 40.608400, -75.490200),
+       ('WH07', '206 Depot Dr', '234-567-8907', 140, 1, 7, 29.562500, -98.310800),
+       ('WH08', '207 Supply Ct', '234-567-8908', 170, -4, 8, 32.640100, -117.084200),
+       ('WH09', '208 Goods Rd', '234-567-8909', 130, 3, 9, 32.735700, -97.108100),
+       ('WH10', '209 Freight St', '234-567-8910', 190, -1, 10, 37.548500, -121.988600);
+
+INSERT INTO product_type (name, description)
+VALUES  ('Dairy', 'Milk, cheese, yogurt and other dairy products'),
+        ('Meat', 'Beef, chicken, pork and other meats'),
+        ('Vegetables', 'Fresh vegetables'),
+        ('Fruits', 'Fresh fruits'),
+        ('Bakery', 'Bread, cakes and pastries'),
+        ('Seafood', 'Fish and shellfish'),
+        ('Beverages', 'Juices, sodas and other drinks'),
+        ('Snacks', 'Chips, cookies and other snacks'),
+        ('Condiments', 'Sauces, spices and dressings'),
+        ('Frozen Foods', 'Frozen meals and ingredients');
+
+-- Frozen Foods sections may also hold Seafood
+INSERT INTO product_type_compatibilities (product_type_id, section_product_type_id)
+VALUES  (6, 10);
 
 INSERT INTO sections (section_number, current_temperature, minimum_temperature, current_capacity,
                       minimum_capacity, maximum_capacity, warehouse_id, product_type_id)
@@ -282,11 +539,21 @@ VALUES ('B1001', 'Alice', 'Brown'),
        ('B1010', 'Edward', 'Gonzalez');
 
 INSERT INTO carries (cid, company_name, address, phone_number, locality_id)
-VALUES  (1, 'Meli Fresh Logistics', '123 Fresh St', '555-1001', 1),
-        (2, 'Quick Delivery Services', '456 Fast Ave', '555-1002', 2),
-        (3, 'Fresh Express', '789 Speed Blvd', '555-1003', 3),
-        (4, 'Swift Transport Co.', '101 Pine St', '555-1004', 4),
-        (5, 'Rapid Freight Solutions', '202 Oak Dr', '555-1005', 5);
+VALUES  (1, 'Meli Fresh Logistics', '123 Fresh St', '11 5555-1001', 1),
+        (2, 'Quick Delivery Services', '456 Fast Ave', '11 5555-1002', 2),
+        (3, 'Fresh Express', '789 Speed Blvd', '11 5555-1003', 3),
+        (4, 'Swift Transport Co.', '101 Pine St', '11 5555-1004', 4),
+        (5, 'Rapid Freight Solutions', '202 Oak Dr', '11 5555-1005', 5);
+
+INSERT INTO carry_coverages (carry_id, locality_id, lead_time_days, cost_per_kg)
+VALUES  (1, 1, 1, 1.50),
+        (1, 2, 3, 2.25),
+        (2, 2, 1, 1.75),
+        (2, 3, 2, 2.00),
+        (3, 3, 1, 1.20),
+        (3, 1, 2, 1.40),
+        (4, 4, 1, 1.10),
+        (5, 5, 2, 0.95);
 
 INSERT INTO product_records (id, last_update_date, purchase_price, sale_price, product_id)
 VALUES (1, '2025-01-01 10:00:00', 50.00, 70.00, 1),
@@ -302,6 +569,13 @@ VALUES  ('PO1001', '2021-01-01', 'T1001', 1, 1),
         ('PO1004', '2021-01-04', 'T1004', 4, 4),
         ('PO1005', '2021-01-05', 'T1005', 5, 5);
 
+INSERT INTO purchase_order_lines (purchase_order_id, product_id, quantity, unit_price)
+VALUES  (1, 1, 1, 70.00),
+        (2, 2, 1, 45.00),
+        (3, 3, 1, 150.00),
+        (4, 4, 1, 35.00),
+        (5, 5, 1, 110.00);
+
 INSERT INTO product_batches (batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minumum_temperature, product_id, section_id)
 VALUES  (1, 100, 20.0, '2022-01-08', 150, '2022-01-01', 10, -5.0, 1, 1),
         (2, 200, 18.5, '2022-02-04', 250, '2022-01-02', 11, -4.0, 2, 1),
@@ -314,14 +588,14 @@ VALUES  (1, 100, 20.0, '2022-01-08', 150, '2022-01-01', 10, -5.0, 1, 1),
         (9, 350, 12.3, '2022-07-01', 400, '2022-01-09', 4, -3.2, 5, 1),
         (10, 450, 16.4, '2022-07-15', 250, '2022-01-10', 3, -7.5, 5, 3);
 
-INSERT INTO inbound_orders (order_date, order_number, employee_id, product_batch_id, warehouse_id)
-VALUES ('2025-01-01', 'ORD001', 1, 1, 1),
-       ('2025-01-02', 'ORD002', 2, 2, 2),
-       ('2025-01-03', 'ORD003', 3, 3, 3),
-       ('2025-01-04', 'ORD004', 4, 4, 4),
-       ('2025-01-05', 'ORD005', 5, 5, 5),
-       ('2025-01-06', 'ORD006', 6, 6, 6),
-       ('2025-01-07', 'ORD007', 7, 7, 7),
-       ('2025-01-08', 'ORD008', 8, 8, 8),
-       ('2025-01-09', 'ORD009', 9, 9, 9),
-       ('2025-01-10', 'ORD010', 10, 10, 10);
\ No newline at end of file
+INSERT INTO inbound_orders (order_date, order_number, employee_id, product_batch_id, warehouse_id, quantity)
+VALUES ('2025-01-01', 'ORD001', 1, 1, 1, 100),
+       ('2025-01-02', 'ORD002', 2, 2, 2, 100),
+       ('2025-01-03', 'ORD003', 3, 3, 3, 100),
+       ('2025-01-04', 'ORD004', 4, 4, 4, 100),
+       ('2025-01-05', 'ORD005', 5, 5, 5, 100),
+       ('2025-01-06', 'ORD006', 6, 6, 6, 100),
+       ('2025-01-07', 'ORD007', 7, 7, 7, 100),
+       ('2025-01-08', 'ORD008', 8, 8, 8, 100),
+       ('2025-01-09', 'ORD009', 9, 9, 9, 100),
+       ('2025-01-10', 'ORD010', 10, 10, 10, 100);
\ No newline at end of file
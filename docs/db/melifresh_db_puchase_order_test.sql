@@ -55,11 +55,38 @@ CREATE TABLE `purchase_orders`
     `tracking_code` varchar(255) NOT NULL,
     `buyer_id` int(11) NULL,
     `product_record_id` int(11) NULL,
+    `status` varchar(20) NOT NULL DEFAULT 'created',
     FOREIGN KEY (`buyer_id`) REFERENCES buyers (id) ON DELETE CASCADE,
     FOREIGN KEY (`product_record_id`) REFERENCES product_records (id) ON DELETE CASCADE,
     PRIMARY KEY (`id`)
 ) ENGINE = InnoDB DEFAULT CHARSET = utf8;
 
+-- table `purchase_order_status_history`
+CREATE TABLE `purchase_order_status_history`
+(
+    `id`                int(11) NOT NULL AUTO_INCREMENT,
+    `purchase_order_id` int(11) NOT NULL,
+    `from_status`       varchar(20) NOT NULL,
+    `to_status`         varchar(20) NOT NULL,
+    `changed_by`        varchar(255) NOT NULL,
+    `changed_at`        datetime NOT NULL,
+    FOREIGN KEY (`purchase_order_id`) REFERENCES purchase_orders (id) ON DELETE CASCADE,
+    PRIMARY KEY (`id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `purchase_order_lines`
+CREATE TABLE `purchase_order_lines`
+(
+    `id`                int(11) NOT NULL AUTO_INCREMENT,
+    `purchase_order_id` int(11) NOT NULL,
+    `product_id`        int(11) NOT NULL,
+    `quantity`          int(11) NOT NULL,
+    `unit_price`        decimal(19, 2) NOT NULL,
+    FOREIGN KEY (`purchase_order_id`) REFERENCES purchase_orders (id) ON DELETE CASCADE,
+    FOREIGN KEY (`product_id`) REFERENCES products (id) ON DELETE CASCADE,
+    PRIMARY KEY (`id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
 
 INSERT INTO products (product_code, description, height, lenght, width, weight, expiration_rate,
                       freezing_rate, recommended_freezing_temperature, seller_id, product_type_id)
@@ -0,0 +1,49 @@
+-- Moves the free text province and country names of `localities` to the `provinces` and `countries` tables.
+-- Names are trimmed and the case insensitive collation of the unique keys merges spellings such as
+-- 'Texas' and 'texas' into a single row, the first spelling found is kept.
+
+USE `melifresh`;
+
+-- table `countries`
+CREATE TABLE `countries`
+(
+    `id`   int(11) NOT NULL AUTO_INCREMENT,
+    `name` varchar(255) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE (`name`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `provinces`
+CREATE TABLE `provinces`
+(
+    `id`         int(11) NOT NULL AUTO_INCREMENT,
+    `name`       varchar(255) NOT NULL,
+    `country_id` int(11) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE (`country_id`, `name`),
+    FOREIGN KEY (`country_id`) REFERENCES countries (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+INSERT IGNORE INTO countries (name)
+SELECT TRIM(l.country_name)
+FROM localities AS l
+ORDER BY l.id;
+
+INSERT IGNORE INTO provinces (name, country_id)
+SELECT TRIM(l.province_name), c.id
+FROM localities AS l
+INNER JOIN countries AS c ON c.name = TRIM(l.country_name)
+ORDER BY l.id;
+
+ALTER TABLE `localities` ADD COLUMN `province_id` int(11) NULL AFTER `name`;
+
+UPDATE localities AS l
+INNER JOIN countries AS c ON c.name = TRIM(l.country_name)
+INNER JOIN provinces AS p ON p.country_id = c.id AND p.name = TRIM(l.province_name)
+SET l.province_id = p.id;
+
+ALTER TABLE `localities`
+    MODIFY COLUMN `province_id` int(11) NOT NULL,
+    ADD FOREIGN KEY (`province_id`) REFERENCES provinces (id),
+    DROP COLUMN `province_name`,
+    DROP COLUMN `country_name`;
@@ -0,0 +1,12 @@
+-- Adds the coordinates used to rank warehouses by distance to a locality.
+-- Rows keep NULL coordinates until they are set and are left out of the nearest warehouse lookup.
+
+USE `melifresh`;
+
+ALTER TABLE `localities`
+    ADD COLUMN `latitude`  decimal(9, 6) NULL AFTER `province_id`,
+    ADD COLUMN `longitude` decimal(9, 6) NULL AFTER `latitude`;
+
+ALTER TABLE `warehouses`
+    ADD COLUMN `latitude`  decimal(9, 6) NULL AFTER `minimum_temperature`,
+    ADD COLUMN `longitude` decimal(9, 6) NULL AFTER `latitude`;
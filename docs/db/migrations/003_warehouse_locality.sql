@@ -0,0 +1,8 @@
+-- Links warehouses to the locality they are in, used by the stock summary of a locality.
+-- Existing warehouses keep a NULL locality until they are updated with one.
+
+USE `melifresh`;
+
+ALTER TABLE `warehouses`
+    ADD COLUMN `locality_id` int(11) NULL AFTER `minimum_temperature`,
+    ADD FOREIGN KEY (`locality_id`) REFERENCES localities (id);
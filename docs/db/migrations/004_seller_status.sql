@@ -0,0 +1,15 @@
+-- Adds the active, suspended and offboarded statuses of sellers and products.
+-- Deleting a seller offboards it, so products no longer cascade from their seller.
+-- The seller foreign key is the first one of `products`, MySQL named it `products_ibfk_1`.
+
+USE `melifresh`;
+
+ALTER TABLE `sellers`
+    ADD COLUMN `status` varchar(20) NOT NULL DEFAULT 'active' AFTER `locality_id`;
+
+ALTER TABLE `products`
+    ADD COLUMN `status` varchar(20) NOT NULL DEFAULT 'active' AFTER `product_type_id`,
+    DROP FOREIGN KEY `products_ibfk_1`;
+
+ALTER TABLE `products`
+    ADD FOREIGN KEY (`seller_id`) REFERENCES sellers (id);
@@ -0,0 +1,7 @@
+-- Indexes the records of a product by date, used by the price timeline and the effective price lookup
+-- that also prices the lines of new purchase orders.
+
+USE `melifresh`;
+
+ALTER TABLE `product_records`
+    ADD KEY `product_records_product_date` (`product_id`, `last_update_date`);
@@ -75,6 +75,8 @@ func (a *ServerChi) Run() (err error) {
 	whRepository := repository.NewWarehouseMysqlRepository(db)
 	slRepository := repository.NewSellerMysql(db)
 	lcRepository := repository.NewLocalityMysql(db)
+	ctRepository := repository.NewCountryMysql(db)
+	pvRepository := repository.NewProvinceMysql(db)
 	pdRepository := repository.NewProductSQL(db)
 	prodRecRepository := repository.NewProductRecordsSQL(db)
 	emRepository := repository.NewEmployeeMysql(db)
@@ -83,39 +85,62 @@ func (a *ServerChi) Run() (err error) {
 	pbRepository := repository.NewProductBatchMysql(db)
 	ptRepository := repository.NewProductTypeMysql(db)
 	poMysqlRepository := repository.NewPurchaseOrderMysqlRepository(db)
+	smRepository := repository.NewStockMovementMysql(db)
+	polRepository := repository.NewPurchaseOrderLineMysql(db)
+	trRepository := repository.NewTemperatureReadingMysql(db)
+	exRepository := repository.NewTemperatureExcursionMysql(db)
+	pbhRepository := repository.NewProductBatchHoldMysql(db)
+	rcRepository := repository.NewRecallMysql(db)
+	ptcRepository := repository.NewProductTypeCompatibilityMysql(db)
+	tfRepository := repository.NewTransferMysql(db)
+	crRepository := repository.NewCarriesMysql(db)
+	shRepository := repository.NewShipmentMysql(db)
 	buyerService := service.NewBuyerService(buMysqlRepository)
+	poService := service.NewPurchaseOrderService(poMysqlRepository, polRepository, prodRecRepository, pdRepository, buyerService)
+	exService := service.NewTemperatureExcursionService(exRepository, pbRepository)
+	trService := service.NewTemperatureReadingService(trRepository, scRepository, exService)
+	inService := service.NewInboundOrderService(inRepository, emRepository, pbRepository, whRepository, scRepository, pdRepository, ptcRepository)
 
 	rt.Route("/api/v1", func(r chi.Router) {
 		r.Route("/employees", func(r chi.Router) {
 			employeeRouter(r, whRepository, db)
 		})
 		r.Route("/buyers", func(r chi.Router) {
-			buyerRouter(r, buMysqlRepository)
+			buyerRouter(r, buMysqlRepository, poService)
 		})
 		r.Route("/sections", func(r chi.Router) {
-			sectionsRoutes(r, scRepository, ptRepository, whRepository, pdRepository)
+			sectionsRoutes(r, scRepository, ptRepository, whRepository, pdRepository, pbRepository, ptcRepository, trService)
 		})
 		r.Route("/product-batches", func(r chi.Router) {
-			productBatchRoutes(r, pbRepository, scRepository, pdRepository)
+			productBatchRoutes(r, pbRepository, scRepository, pdRepository, smRepository, pbhRepository, emRepository, ptcRepository)
 		})
 		r.Route("/warehouses", func(r chi.Router) {
-			warehouseRoute(r, whRepository)
+			warehouseRoute(r, whRepository, lcRepository, pdRepository, inService)
 		})
 		r.Route("/sellers", func(r chi.Router) {
 			sellerRoutes(r, slRepository, lcRepository)
 		})
+		r.Route("/admin/sellers", func(r chi.Router) {
+			adminSellerRoutes(r, slRepository, lcRepository)
+		})
 		r.Route("/localities", func(r chi.Router) {
-			localitiesRoutes(r, lcRepository)
+			localitiesRoutes(r, lcRepository, pvRepository)
+		})
+		r.Route("/countries", func(r chi.Router) {
+			countriesRoutes(r, ctRepository)
+		})
+		r.Route("/provinces", func(r chi.Router) {
+			provincesRoutes(r, pvRepository, ctRepository)
 		})
 
 		r.Route("/products", func(r chi.Router) {
-			productRoutes(r, pdRepository, slRepository, ptRepository)
+			productRoutes(r, pdRepository, slRepository, ptRepository, pbRepository, scRepository, ptcRepository, prodRecRepository)
 		})
 		r.Route("/purchase-orders", func(r chi.Router) {
-			purchaseOrderRouter(r, poMysqlRepository, prodRecRepository, buyerService)
+			purchaseOrderRouter(r, poService)
 		})
 		r.Route("/carries", func(r chi.Router) {
-			carriesRoutes(r, db)
+			carriesRoutes(r, crRepository, lcRepository, whRepository, polRepository, pdRepository)
 		})
 
 		r.Route("/productRecords", func(r chi.Router) {
@@ -123,7 +148,31 @@ func (a *ServerChi) Run() (err error) {
 		})
 
 		r.Route("/inbound-orders", func(r chi.Router) {
-			inboundOrdersRoutes(r, inRepository, emRepository, pbRepository, whRepository)
+			inboundOrdersRoutes(r, inService)
+		})
+
+		r.Route("/telemetry", func(r chi.Router) {
+			telemetryRoutes(r, trService)
+		})
+
+		r.Route("/excursions", func(r chi.Router) {
+			excursionRoutes(r, exService)
+		})
+
+		r.Route("/product-types", func(r chi.Router) {
+			productTypeRoutes(r, ptRepository, ptcRepository)
+		})
+
+		r.Route("/recalls", func(r chi.Router) {
+			recallRoutes(r, rcRepository, pdRepository)
+		})
+
+		r.Route("/transfers", func(r chi.Router) {
+			transferRoutes(r, tfRepository, pbRepository, scRepository, pdRepository, ptcRepository, emRepository)
+		})
+
+		r.Route("/shipments", func(r chi.Router) {
+			shipmentRoutes(r, shRepository, crRepository, whRepository, poMysqlRepository)
 		})
 	})
 
@@ -132,40 +181,75 @@ func (a *ServerChi) Run() (err error) {
 	return err
 }
 
-func localitiesRoutes(r chi.Router, lcRepository internal.LocalityRepository) {
-	sv := service.NewLocalityDefault(lcRepository)
+func localitiesRoutes(r chi.Router, lcRepository internal.LocalityRepository, pvRepository internal.ProvinceRepository) {
+	sv := service.NewLocalityDefault(lcRepository, pvRepository)
 	hd := handler.NewLocalityDefault(sv)
 
 	r.Get("/report-sellers", hd.ReportSellers())
 	r.Get("/report-carries", hd.ReportCarries())
+	r.Get("/{id}/stock-summary", hd.StockSummary())
 	r.Post("/", hd.Save())
 }
 
+func countriesRoutes(r chi.Router, ctRepository internal.CountryRepository) {
+	sv := service.NewCountryService(ctRepository)
+	hd := handler.NewCountryHandler(sv)
+
+	r.Get("/", hd.GetAll())
+	r.Get("/report", hd.Report())
+	r.Get("/{id}", hd.GetByID())
+	r.Post("/", hd.Create())
+}
+
+func provincesRoutes(r chi.Router, pvRepository internal.ProvinceRepository, ctRepository internal.CountryRepository) {
+	sv := service.NewProvinceService(pvRepository, ctRepository)
+	hd := handler.NewProvinceHandler(sv)
+
+	r.Get("/", hd.GetAll())
+	r.Get("/report", hd.Report())
+	r.Get("/{id}", hd.GetByID())
+	r.Post("/", hd.Create())
+}
+
 func sellerRoutes(r chi.Router, slRepository internal.SellerRepository, lcRepository internal.LocalityRepository) {
 	sv := service.NewSellerServiceDefault(slRepository, lcRepository)
 	hd := handler.NewSellerDefault(sv)
 
 	r.Get("/", hd.GetAll())
+	r.Get("/report", hd.Report())
 	r.Get("/{id}", hd.GetByID())
+	r.Get("/{id}/products", hd.GetProducts())
+	r.Get("/{id}/report", hd.ReportByID())
 	r.Post("/", hd.Save())
 	r.Patch("/{id}", hd.Update())
 	r.Delete("/{id}", hd.Delete())
 }
 
-func warehouseRoute(r chi.Router, whRepository internal.WarehouseRepository) {
-	warehouseService := service.NewWarehouseDefault(whRepository)
+func adminSellerRoutes(r chi.Router, slRepository internal.SellerRepository, lcRepository internal.LocalityRepository) {
+	sv := service.NewSellerServiceDefault(slRepository, lcRepository)
+	hd := handler.NewSellerDefault(sv)
+
+	r.Delete("/{id}", hd.HardDelete())
+}
+
+func warehouseRoute(r chi.Router, whRepository internal.WarehouseRepository, lcRepository internal.LocalityRepository, pdRepository internal.ProductRepository, inService internal.InboundOrderService) {
+	warehouseService := service.NewWarehouseDefault(whRepository, lcRepository, pdRepository)
 	warehouseHandler := handler.NewWarehouseDefault(warehouseService)
+	inboundHandler := handler.NewInboundOrdersHandler(inService)
 
 	r.Get("/", warehouseHandler.GetAll())
+	r.Get("/nearest", warehouseHandler.GetNearest())
 	r.Get("/{id}", warehouseHandler.GetByID())
 	r.Post("/", warehouseHandler.Create())
 	r.Patch("/{id}", warehouseHandler.Update())
 	r.Delete("/{id}", warehouseHandler.Delete())
+	r.Get("/{id}/inbound-summary", inboundHandler.GetSummary)
 }
 
-func sectionsRoutes(r chi.Router, scRepository internal.SectionRepository, ptRepository internal.ProductTypeRepository, whRepository internal.WarehouseRepository, pdRepository internal.ProductRepository) {
-	sv := service.NewServiceSection(scRepository, ptRepository, pdRepository, whRepository)
+func sectionsRoutes(r chi.Router, scRepository internal.SectionRepository, ptRepository internal.ProductTypeRepository, whRepository internal.WarehouseRepository, pdRepository internal.ProductRepository, pbRepository internal.ProductBatchRepository, ptcRepository internal.ProductTypeCompatibilityRepository, trService internal.TemperatureReadingService) {
+	sv := service.NewServiceSection(scRepository, ptRepository, pdRepository, whRepository, pbRepository, ptcRepository)
 	hd := handler.NewHandlerSection(sv)
+	trHd := handler.NewTemperatureReadingHandler(trService)
 
 	r.Get("/", hd.GetAll)
 	r.Get("/{id}", hd.GetByID)
@@ -173,14 +257,73 @@ func sectionsRoutes(r chi.Router, scRepository internal.SectionRepository, ptRep
 	r.Post("/", hd.Create)
 	r.Patch("/{id}", hd.Update)
 	r.Delete("/{id}", hd.Delete)
+	r.Get("/{id}/occupancy", hd.GetOccupancy)
+	r.Get("/{id}/temperatures", trHd.GetBySection())
+}
+
+func telemetryRoutes(r chi.Router, sv internal.TemperatureReadingService) {
+	hd := handler.NewTemperatureReadingHandler(sv)
+
+	r.Post("/temperature", hd.Ingest())
+}
+
+func excursionRoutes(r chi.Router, sv internal.TemperatureExcursionService) {
+	hd := handler.NewTemperatureExcursionHandler(sv)
+
+	r.Get("/", hd.GetAll())
+	r.Get("/{id}", hd.GetByID())
+	r.Get("/{id}/product-batches", hd.GetProductBatches())
+	r.Patch("/{id}/status", hd.UpdateStatus())
+}
+
+func productTypeRoutes(r chi.Router, ptRepository internal.ProductTypeRepository, ptcRepository internal.ProductTypeCompatibilityRepository) {
+	sv := service.NewProductTypeService(ptRepository)
+	hd := handler.NewProductTypeHandler(sv)
+
+	ptcSv := service.NewProductTypeCompatibilityService(ptcRepository, ptRepository)
+	ptcHd := handler.NewProductTypeCompatibilityHandler(ptcSv)
+
+	r.Get("/", hd.GetAll())
+	r.Get("/{id}", hd.GetByID())
+	r.Post("/", hd.Create())
+	r.Patch("/{id}", hd.Update())
+	r.Delete("/{id}", hd.Delete())
+
+	r.Get("/{id}/compatible-sections", ptcHd.GetAll())
+	r.Post("/{id}/compatible-sections", ptcHd.Create())
+	r.Delete("/{id}/compatible-sections/{sectionProductTypeId}", ptcHd.Delete())
+}
+
+func recallRoutes(r chi.Router, rcRepository internal.RecallRepository, pdRepository internal.ProductRepository) {
+	sv := service.NewRecallService(rcRepository, pdRepository)
+	hd := handler.NewRecallHandler(sv)
+
+	r.Get("/", hd.GetAll())
+	r.Get("/{id}", hd.GetByID())
+	r.Post("/", hd.Create())
 }
 
-func productBatchRoutes(r chi.Router, pbRepository internal.ProductBatchRepository, scRepository internal.SectionRepository, ptRepository internal.ProductRepository) {
-	sv := service.NewServiceProductBatch(pbRepository, scRepository, ptRepository)
+func productBatchRoutes(r chi.Router, pbRepository internal.ProductBatchRepository, scRepository internal.SectionRepository, ptRepository internal.ProductRepository, smRepository internal.StockMovementRepository, pbhRepository internal.ProductBatchHoldRepository, emRepository internal.EmployeeRepository, ptcRepository internal.ProductTypeCompatibilityRepository) {
+	sv := service.NewServiceProductBatch(pbRepository, scRepository, ptRepository, ptcRepository)
 	hd := handler.NewHandlerProductBatch(sv)
 
+	smSv := service.NewStockMovementService(smRepository, pbRepository)
+	smHd := handler.NewStockMovementHandler(smSv)
+
+	pbhSv := service.NewProductBatchHoldService(pbhRepository, pbRepository, emRepository)
+	pbhHd := handler.NewProductBatchHoldHandler(pbhSv)
+
+	r.Get("/expiring", hd.GetExpiring)
+	r.Get("/expired", hd.GetExpired)
 	r.Get("/{id}", hd.GetByID)
 	r.Post("/", hd.Create)
+	r.Patch("/{id}/section", hd.Move)
+	r.Delete("/{id}", hd.Delete)
+	r.Get("/{id}/movements", smHd.GetAll())
+	r.Post("/{id}/movements", smHd.Create())
+	r.Get("/{id}/holds", pbhHd.GetAll())
+	r.Post("/{id}/hold", pbhHd.Hold())
+	r.Post("/{id}/release", pbhHd.Release())
 }
 
 func employeeRouter(r chi.Router, whRepository internal.WarehouseRepository, db *sql.DB) {
@@ -196,9 +339,10 @@ func employeeRouter(r chi.Router, whRepository internal.WarehouseRepository, db
 	r.Get("/report-inbound-orders", hd.ReportInboundOrders)
 }
 
-func buyerRouter(r chi.Router, buRepository internal.BuyerRepository) {
+func buyerRouter(r chi.Router, buRepository internal.BuyerRepository, poService internal.PurchaseOrderService) {
 	svc := service.NewBuyerService(buRepository)
 	hd := handler.NewBuyerHandlerDefault(svc)
+	poHandler := handler.NewPurchaseOrderHandler(poService)
 
 	r.Get("/", hd.GetAll)
 	r.Get("/{id}", hd.GetByID)
@@ -206,45 +350,85 @@ func buyerRouter(r chi.Router, buRepository internal.BuyerRepository) {
 	r.Patch("/{id}", hd.Update)
 	r.Delete("/{id}", hd.Delete)
 	r.Get("/report-purchase-orders", hd.ReportPurchaseOrders)
+	r.Get("/{id}/purchase-orders", poHandler.GetByBuyer())
 }
 
-func productRoutes(r chi.Router, pdRepository internal.ProductRepository, slRepository internal.SellerRepository, ptRepository internal.ProductTypeRepository) {
-	svc := service.NewProductService(pdRepository, slRepository, ptRepository)
+func productRoutes(r chi.Router, pdRepository internal.ProductRepository, slRepository internal.SellerRepository, ptRepository internal.ProductTypeRepository, pbRepository internal.ProductBatchRepository, scRepository internal.SectionRepository, ptcRepository internal.ProductTypeCompatibilityRepository, prodRecRepository internal.ProductRecordsRepository) {
+	svc := service.NewProductService(pdRepository, slRepository, ptRepository, pbRepository, scRepository, ptcRepository)
 	hd := handler.NewProductHandlerDefault(svc)
 
+	prSvc := service.NewProductRecordsDefault(prodRecRepository, pdRepository)
+	prHd := handler.NewProductRecordsDefault(prSvc)
+
 	r.Get("/", hd.GetAll)
 	r.Get("/{id}", hd.GetByID)
 	r.Post("/", hd.Create)
 	r.Patch("/{id}", hd.Update)
 	r.Delete("/{id}", hd.Delete)
 	r.Get("/report-records", hd.ReportRecords)
+	r.Get("/{id}/prices", prHd.GetPrices)
+	r.Get("/{id}/price", prHd.GetPrice)
 }
 
-func inboundOrdersRoutes(r chi.Router, inRepository internal.InboundOrdersRepository, emRepository internal.EmployeeRepository, pbRepository internal.ProductBatchRepository, whRepository internal.WarehouseRepository) {
-	sv := service.NewInboundOrderService(inRepository, emRepository, pbRepository, whRepository)
+func inboundOrdersRoutes(r chi.Router, sv internal.InboundOrderService) {
 	hd := handler.NewInboundOrdersHandler(sv)
 
 	r.Post("/", hd.Create)
 	r.Get("/", hd.GetAll)
+	r.Get("/{id}", hd.GetByID)
+	r.Get("/{id}/discrepancies", hd.GetDiscrepancies)
 }
 
-func purchaseOrderRouter(r chi.Router, poRepository internal.PurchaseOrderRepository, prodRecRepository internal.ProductRecordsRepository, buyerService internal.BuyerService) {
-	sv := service.NewPurchaseOrderService(poRepository, prodRecRepository, buyerService)
+func purchaseOrderRouter(r chi.Router, sv internal.PurchaseOrderService) {
 	hd := handler.NewPurchaseOrderHandler(sv)
 
+	r.Get("/", hd.GetAll())
+	r.Get("/{id}", hd.GetByID())
 	r.Post("/", hd.Create())
+	r.Delete("/{id}", hd.Cancel())
+	r.Post("/{id}/reserve", hd.Reserve())
+	r.Post("/{id}/fulfil", hd.Fulfil())
+	r.Patch("/{id}/status", hd.UpdateStatus())
+	r.Get("/{id}/history", hd.History())
 }
 
-func carriesRoutes(r chi.Router, db *sql.DB) {
-	rp := repository.NewCarriesMysql(db)
-	sv := service.NewCarriesService(rp)
+func carriesRoutes(r chi.Router, crRepository internal.CarriesRepository, lcRepository internal.LocalityRepository, whRepository internal.WarehouseRepository, polRepository internal.PurchaseOrderLineRepository, pdRepository internal.ProductRepository) {
+	sv := service.NewCarriesService(crRepository, lcRepository, whRepository, polRepository, pdRepository)
 	hd := handler.NewCarriesHandlerDefault(sv)
 
 	r.Get("/", hd.GetAll)
 	r.Post("/", hd.Create)
+	r.Get("/quote", hd.Quote)
+	r.Get("/{id}", hd.GetByID)
+	r.Patch("/{id}", hd.Update)
+	r.Delete("/{id}", hd.Delete)
+	r.Get("/{id}/coverages", hd.GetCoverages)
+	r.Post("/{id}/coverages", hd.CreateCoverage)
 }
 func productRecordsRoutes(r chi.Router, prodRecRepository internal.ProductRecordsRepository, prodRepository internal.ProductRepository) {
 	svc := service.NewProductRecordsDefault(prodRecRepository, prodRepository)
 	hd := handler.NewProductRecordsDefault(svc)
 	r.Post("/", hd.Create)
 }
+
+func transferRoutes(r chi.Router, tfRepository internal.TransferRepository, pbRepository internal.ProductBatchRepository, scRepository internal.SectionRepository, pdRepository internal.ProductRepository, ptcRepository internal.ProductTypeCompatibilityRepository, emRepository internal.EmployeeRepository) {
+	sv := service.NewTransferService(tfRepository, pbRepository, scRepository, pdRepository, ptcRepository, emRepository)
+	hd := handler.NewTransferHandler(sv)
+
+	r.Get("/", hd.GetAll())
+	r.Get("/{id}", hd.GetByID())
+	r.Post("/", hd.Create())
+	r.Post("/{id}/dispatch", hd.Dispatch())
+	r.Post("/{id}/receive", hd.Receive())
+}
+
+func shipmentRoutes(r chi.Router, shRepository internal.ShipmentRepository, crRepository internal.CarriesRepository, whRepository internal.WarehouseRepository, poRepository internal.PurchaseOrderRepository) {
+	sv := service.NewShipmentService(shRepository, crRepository, whRepository, poRepository)
+	hd := handler.NewShipmentHandler(sv)
+
+	r.Get("/", hd.GetAll())
+	r.Get("/{id}", hd.GetByID())
+	r.Post("/", hd.Create())
+	r.Post("/{id}/dispatch", hd.Dispatch())
+	r.Post("/{id}/deliver", hd.Deliver())
+}
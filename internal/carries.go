@@ -1,5 +1,28 @@
 package internal
 
+import (
+	"errors"
+
+	"github.com/meli-fresh-products-api-backend-t1/utils/validator"
+)
+
+var (
+	// ErrCarryNotFound is returned when the carry does not exist
+	ErrCarryNotFound = errors.New("carry not found")
+	// ErrCarryCidConflict is returned when another carry already has the cid
+	ErrCarryCidConflict = errors.New("carry with this cid already exists")
+	// ErrCarryInUse is returned when a carry with shipments is deleted
+	ErrCarryInUse = errors.New("carry has shipments and cannot be deleted")
+	// ErrCarryBadRequest is returned when the carry breaks a business rule
+	ErrCarryBadRequest = errors.New("carry inputs are invalid")
+	// ErrCarryCoverageConflict is returned when the carry already serves the locality
+	ErrCarryCoverageConflict = errors.New("carry already serves this locality")
+	// ErrCarryCoverageBadRequest is returned when the coverage breaks a business rule
+	ErrCarryCoverageBadRequest = errors.New("carry coverage inputs are invalid")
+	// ErrCarryQuoteBadRequest is returned when the quote request breaks a business rule
+	ErrCarryQuoteBadRequest = errors.New("carry quote inputs are invalid")
+)
+
 type Carries struct {
 	ID          int    `json:"id"`
 	Cid         string `json:"cid"`
@@ -9,20 +32,193 @@ type Carries struct {
 	LocalityID  int    `json:"locality_id"`
 }
 
+// CarriesPatchUpdate is a struct to use in a patch request
+type CarriesPatchUpdate struct {
+	Cid         *string `json:"cid"`
+	CompanyName *string `json:"company_name"`
+	Address     *string `json:"address"`
+	PhoneNumber *string `json:"phone_number"`
+	LocalityID  *int    `json:"locality_id"`
+}
+
+// CarryCoverage is a struct that represents a locality served by a carry
+type CarryCoverage struct {
+	ID         int `json:"id"`
+	CarryID    int `json:"carry_id"`
+	LocalityID int `json:"locality_id"`
+	// LeadTimeDays is the number of days the carry takes to deliver in the locality
+	LeadTimeDays int     `json:"lead_time_days"`
+	CostPerKg    float64 `json:"cost_per_kg"`
+}
+
+// CarryQuoteRequest is a struct that represents a request to quote the delivery of a weight to a locality.
+// The weight is computed from the products of the purchase order when PurchaseOrderID is set.
+type CarryQuoteRequest struct {
+	OriginWarehouseID     int
+	DestinationLocalityID int
+	Weight                float64
+	PurchaseOrderID       int
+}
+
+// CarryQuote is a struct that represents the offer of a carry serving the destination locality
+type CarryQuote struct {
+	CarryID      int     `json:"carry_id"`
+	Cid          string  `json:"cid"`
+	CompanyName  string  `json:"company_name"`
+	LeadTimeDays int     `json:"lead_time_days"`
+	CostPerKg    float64 `json:"cost_per_kg"`
+	Weight       float64 `json:"weight"`
+	Cost         float64 `json:"cost"`
+}
+
 type CarriesService interface {
 	FindAll() (carries []Carries, e error)
+	// FindByID returns the carry with the given ID or ErrCarryNotFound
+	FindByID(id int) (Carries, error)
+	// Create validates the carry and its locality and records it
 	Create(carry Carries) (lastID int64, e error)
+	// Update applies the patch to the carry and validates the result
+	Update(id int, patch CarriesPatchUpdate) (Carries, error)
+	// Delete removes the carry or returns ErrCarryInUse when it has shipments
+	Delete(id int) error
+	// FindCoverages returns the localities served by the carry
+	FindCoverages(carryID int) ([]CarryCoverage, error)
+	// CreateCoverage declares a locality served by the carry
+	CreateCoverage(coverage *CarryCoverage) error
+	// Quote returns the carries serving the destination locality that can pick up at the origin warehouse, cheapest first
+	Quote(request CarryQuoteRequest) ([]CarryQuote, error)
 }
 
 type CarriesRepository interface {
 	FindAll() ([]Carries, error)
+	// FindByID returns the carry with the given ID or ErrCarryNotFound
+	FindByID(id int) (Carries, error)
+	// Create records the carry or returns ErrCarryCidConflict
 	Create(carry Carries) (lastID int64, e error)
+	// Update records the carry or returns ErrCarryCidConflict
+	Update(carry Carries) error
+	// Delete removes the carry or returns ErrCarryNotFound or ErrCarryInUse
+	Delete(id int) error
+	// FindCoverages returns the localities served by the carry
+	FindCoverages(carryID int) ([]CarryCoverage, error)
+	// CreateCoverage records a locality served by the carry or returns ErrCarryCoverageConflict
+	CreateCoverage(coverage *CarryCoverage) error
+	// FindByLocality returns the carries serving the locality with their lead time and cost per kg
+	FindByLocality(localityID int) ([]CarryQuote, error)
+	// FindByRoute returns the carries serving the destination locality that can pick up in the origin locality,
+	// either because they are based there or serve it
+	FindByRoute(originLocalityID int, destinationLocalityID int) ([]CarryQuote, error)
 }
 
 func (c *Carries) Ok() bool {
-	if c.Cid == "" || c.CompanyName == "" || c.Address == "" || c.PhoneNumber == "" || c.LocalityID < 0 {
+	if c.Cid == "" || c.CompanyName == "" || c.Address == "" || c.PhoneNumber == "" || c.LocalityID <= 0 {
 		return false
 	}
 
 	return true
 }
+
+// Validate validates the business rules of the carry
+func (c *Carries) Validate() (causes []Causes) {
+	if !validator.String(c.Cid, 1, 10) {
+		causes = append(causes, Causes{
+			Field:   "cid",
+			Message: "cid must have between 1 and 10 characters",
+		})
+	}
+
+	if !validator.String(c.CompanyName, 1, 100) {
+		causes = append(causes, Causes{
+			Field:   "company_name",
+			Message: "company name must have between 1 and 100 characters",
+		})
+	}
+
+	if !validator.String(c.Address, 1, 100) {
+		causes = append(causes, Causes{
+			Field:   "address",
+			Message: "address must have between 1 and 100 characters",
+		})
+	}
+
+	if !validator.IsTelephone(c.PhoneNumber) {
+		causes = append(causes, Causes{
+			Field:   "phone_number",
+			Message: "phone number is invalid, should be formatted as XX XXXXX-XXXX",
+		})
+	}
+
+	if !validator.IntIsPositive(c.LocalityID) {
+		causes = append(causes, Causes{
+			Field:   "locality_id",
+			Message: "locality ID must be greater than zero",
+		})
+	}
+
+	return causes
+}
+
+// Validate validates the business rules of the carry coverage
+func (c *CarryCoverage) Validate() (causes []Causes) {
+	if !validator.IntIsPositive(c.LocalityID) {
+		causes = append(causes, Causes{
+			Field:   "locality_id",
+			Message: "locality ID must be greater than zero",
+		})
+	}
+
+	if validator.IntIsNegative(c.LeadTimeDays) {
+		causes = append(causes, Causes{
+			Field:   "lead_time_days",
+			Message: "lead time days cannot be negative",
+		})
+	}
+
+	if !validator.FloatIsPositive(c.CostPerKg) {
+		causes = append(causes, Causes{
+			Field:   "cost_per_kg",
+			Message: "cost per kg must be greater than zero",
+		})
+	}
+
+	return causes
+}
+
+// Validate validates the business rules of the quote request
+func (q *CarryQuoteRequest) Validate() (causes []Causes) {
+	if !validator.IntIsPositive(q.OriginWarehouseID) {
+		causes = append(causes, Causes{
+			Field:   "origin_warehouse",
+			Message: "origin warehouse must be greater than zero",
+		})
+	}
+
+	if !validator.IntIsPositive(q.DestinationLocalityID) {
+		causes = append(causes, Causes{
+			Field:   "destination_locality",
+			Message: "destination locality must be greater than zero",
+		})
+	}
+
+	switch {
+	case q.PurchaseOrderID != 0 && q.Weight != 0:
+		causes = append(causes, Causes{
+			Field:   "weight",
+			Message: "weight cannot be given along with a purchase order",
+		})
+	case q.PurchaseOrderID != 0:
+		if !validator.IntIsPositive(q.PurchaseOrderID) {
+			causes = append(causes, Causes{
+				Field:   "purchase_order_id",
+				Message: "purchase order ID must be greater than zero",
+			})
+		}
+	case !validator.FloatIsPositive(q.Weight):
+		causes = append(causes, Causes{
+			Field:   "weight",
+			Message: "weight must be greater than zero",
+		})
+	}
+
+	return causes
+}
@@ -77,6 +77,19 @@ func TestCarries_Ok(t *testing.T) {
 			},
 			expectedOutput: false,
 		},
+		{
+			name: "Should return false when LocalityID is zero",
+			setup: func(t *testing.T) *internal.Carries {
+				return &internal.Carries{
+					Cid:         "Test Cid",
+					CompanyName: "Test Company",
+					Address:     "Test Address",
+					PhoneNumber: "123-456-7890",
+					LocalityID:  0,
+				}
+			},
+			expectedOutput: false,
+		},
 		{
 			name: "Should return true when all fields are valid",
 			setup: func(t *testing.T) *internal.Carries {
@@ -102,3 +115,58 @@ func TestCarries_Ok(t *testing.T) {
 		})
 	}
 }
+
+func TestCarryQuoteRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        internal.CarryQuoteRequest
+		expectedOutput []internal.Causes
+	}{
+		{
+			name:    "Should accept a weight",
+			request: internal.CarryQuoteRequest{OriginWarehouseID: 1, DestinationLocalityID: 2, Weight: 1.5},
+		},
+		{
+			name:    "Should accept a purchase order",
+			request: internal.CarryQuoteRequest{OriginWarehouseID: 1, DestinationLocalityID: 2, PurchaseOrderID: 3},
+		},
+		{
+			name:    "Should require a weight or a purchase order",
+			request: internal.CarryQuoteRequest{OriginWarehouseID: 0, DestinationLocalityID: 2},
+			expectedOutput: []internal.Causes{
+				{Field: "origin_warehouse", Message: "origin warehouse must be greater than zero"},
+				{Field: "weight", Message: "weight must be greater than zero"},
+			},
+		},
+		{
+			name:    "Should refuse a weight along with a purchase order",
+			request: internal.CarryQuoteRequest{OriginWarehouseID: 1, DestinationLocalityID: 2, Weight: 1.5, PurchaseOrderID: 3},
+			expectedOutput: []internal.Causes{
+				{Field: "weight", Message: "weight cannot be given along with a purchase order"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedOutput, tt.request.Validate())
+		})
+	}
+}
+
+func TestCarries_Validate(t *testing.T) {
+	t.Run("Should accept a valid carry", func(t *testing.T) {
+		c := internal.Carries{Cid: "CID001", CompanyName: "Meli", Address: "Address 1", PhoneNumber: "11 91892-1912", LocalityID: 1}
+
+		assert.Empty(t, c.Validate())
+	})
+
+	t.Run("Should refuse a long cid and an invalid phone number", func(t *testing.T) {
+		c := internal.Carries{Cid: "CID00000001", CompanyName: "Meli", Address: "Address 1", PhoneNumber: "555-1001", LocalityID: 1}
+
+		assert.Equal(t, []internal.Causes{
+			{Field: "cid", Message: "cid must have between 1 and 10 characters"},
+			{Field: "phone_number", Message: "phone number is invalid, should be formatted as XX XXXXX-XXXX"},
+		}, c.Validate())
+	})
+}
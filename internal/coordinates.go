@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"math"
+
+	"github.com/meli-fresh-products-api-backend-t1/utils/validator"
+)
+
+// earthRadiusKm is the mean radius of the Earth used to compute great-circle distances
+const earthRadiusKm = 6371.0
+
+// Coordinates is a point on the Earth in decimal degrees
+type Coordinates struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Validate validates the latitude and longitude ranges
+func (c *Coordinates) Validate() (causes []Causes) {
+	if !validator.FloatBetween(c.Latitude, -90, 90) {
+		causes = append(causes, Causes{
+			Field:   "latitude",
+			Message: "latitude must be between -90 and 90",
+		})
+	}
+
+	if !validator.FloatBetween(c.Longitude, -180, 180) {
+		causes = append(causes, Causes{
+			Field:   "longitude",
+			Message: "longitude must be between -180 and 180",
+		})
+	}
+
+	return causes
+}
+
+// DistanceTo returns the great-circle distance in kilometers to the other point with the haversine formula
+func (c Coordinates) DistanceTo(other Coordinates) float64 {
+	lat1 := c.Latitude * math.Pi / 180
+	lat2 := other.Latitude * math.Pi / 180
+	deltaLat := (other.Latitude - c.Latitude) * math.Pi / 180
+	deltaLon := (other.Longitude - c.Longitude) * math.Pi / 180
+
+	h := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(deltaLon/2)*math.Sin(deltaLon/2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
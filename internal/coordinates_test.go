@@ -0,0 +1,34 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoordinates_Validate(t *testing.T) {
+	t.Run("valid coordinates", func(t *testing.T) {
+		c := internal.Coordinates{Latitude: -90, Longitude: 180}
+
+		require.Empty(t, c.Validate())
+	})
+
+	t.Run("coordinates out of range", func(t *testing.T) {
+		c := internal.Coordinates{Latitude: 90.5, Longitude: -180.5}
+
+		require.Equal(t, []internal.Causes{
+			{Field: "latitude", Message: "latitude must be between -90 and 90"},
+			{Field: "longitude", Message: "longitude must be between -180 and 180"},
+		}, c.Validate())
+	})
+}
+
+func TestCoordinates_DistanceTo(t *testing.T) {
+	newYork := internal.Coordinates{Latitude: 40.7128, Longitude: -74.0060}
+	losAngeles := internal.Coordinates{Latitude: 34.0522, Longitude: -118.2437}
+
+	require.InDelta(t, 3935.7, newYork.DistanceTo(losAngeles), 1)
+	require.InDelta(t, newYork.DistanceTo(losAngeles), losAngeles.DistanceTo(newYork), 1e-9)
+	require.Zero(t, newYork.DistanceTo(newYork))
+}
@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"errors"
+
+	"github.com/meli-fresh-products-api-backend-t1/utils/validator"
+)
+
+var (
+	// ErrCountryNotFound is returned when the country is not found
+	ErrCountryNotFound = errors.New("country not found")
+	// ErrCountryConflict is returned when a country with the same name already exists
+	ErrCountryConflict = errors.New("country already exists")
+	// ErrCountryBadRequest is returned when the country breaks a business rule
+	ErrCountryBadRequest = errors.New("country inputs are invalid")
+	// ErrCountryUnprocessableEntity is returned when the country inputs are missing
+	ErrCountryUnprocessableEntity = errors.New("country inputs are missing")
+)
+
+// Country is a country localities are grouped by through their provinces
+type Country struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// CountryReport holds the provinces, localities, sellers and carries registered in a country
+type CountryReport struct {
+	CountryID       int    `json:"country_id"`
+	CountryName     string `json:"country_name"`
+	ProvincesCount  int    `json:"provinces_count"`
+	LocalitiesCount int    `json:"localities_count"`
+	SellersCount    int    `json:"sellers_count"`
+	CarriesCount    int    `json:"carries_count"`
+}
+
+// Validate validates the business rules of the country
+func (c *Country) Validate() (causes []Causes) {
+	if !validator.String(c.Name, 1, 255) {
+		causes = append(causes, Causes{
+			Field:   "name",
+			Message: "name must have between 1 and 255 characters",
+		})
+	}
+
+	return causes
+}
+
+type CountryRepository interface {
+	FindAll() ([]Country, error)
+	FindByID(id int) (Country, error)
+	// Save records the country, names are unique regardless of their case
+	Save(c *Country) error
+	Report() ([]CountryReport, error)
+	ReportByID(id int) (CountryReport, error)
+}
+
+type CountryService interface {
+	FindAll() ([]Country, error)
+	FindByID(id int) (Country, error)
+	Save(c *Country) error
+	Report() ([]CountryReport, error)
+	ReportByID(id int) (CountryReport, error)
+}
@@ -2,9 +2,12 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
 )
@@ -49,7 +52,9 @@ func (h *CarriesHandlerDefault) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Success 201 {object} map[string]interface{} "Created carry with Id"
 // @Failure 400 {object} resterr.RestErr "Failed to parse body"
 // @Failure 422 {object} resterr.RestErr "Missing fields"
-// @Failure 409 {object} resterr.RestErr "carry with this cid already exists" or "there's no such locality id"
+// @Failure 400 {object} resterr.RestErr "Invalid phone number or locality not found"
+// @Failure 409 {object} resterr.RestErr "carry with this cid already exists"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
 // @Router /api/v1/carries [post]
 func (h *CarriesHandlerDefault) Create(w http.ResponseWriter, r *http.Request) {
 	var carry internal.Carries
@@ -68,7 +73,7 @@ func (h *CarriesHandlerDefault) Create(w http.ResponseWriter, r *http.Request) {
 
 	lastID, err := h.sv.Create(carry)
 	if err != nil {
-		response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+		h.handleError(w, err)
 
 		return
 	}
@@ -81,3 +86,318 @@ func (h *CarriesHandlerDefault) Create(w http.ResponseWriter, r *http.Request) {
 		},
 	})
 }
+
+// GetByID godoc
+// @Summary Get a carry by ID
+// @Description Retrieve the carry with the given ID
+// @Tags Carries
+// @Produce json
+// @Param id path int true "Carry ID"
+// @Success 200 {object} internal.Carries "Carry"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Carry not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/carries/{id} [get]
+func (h *CarriesHandlerDefault) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+		return
+	}
+
+	carry, err := h.sv.FindByID(id)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]any{
+		"data": carry,
+	})
+}
+
+// Update godoc
+// @Summary Update a carry
+// @Description Modify the given fields of an existing carry, the phone number is validated only when it changes
+// @Tags Carries
+// @Accept json
+// @Produce json
+// @Param id path int true "Carry ID"
+// @Param carry body internal.CarriesPatchUpdate true "Updated carry data"
+// @Success 200 {object} internal.Carries "Updated carry"
+// @Failure 400 {object} resterr.RestErr "Invalid data, invalid phone number or locality not found"
+// @Failure 404 {object} resterr.RestErr "Carry not found"
+// @Failure 409 {object} resterr.RestErr "carry with this cid already exists"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/carries/{id} [patch]
+func (h *CarriesHandlerDefault) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+		return
+	}
+
+	var patch internal.CarriesPatchUpdate
+
+	err = json.NewDecoder(r.Body).Decode(&patch)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+		return
+	}
+
+	carry, err := h.sv.Update(id, patch)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]any{
+		"data": carry,
+	})
+}
+
+// Delete godoc
+// @Summary Delete a carry
+// @Description Remove a carry without shipments
+// @Tags Carries
+// @Param id path int true "Carry ID"
+// @Success 204 {object} nil "No Content"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Carry not found"
+// @Failure 409 {object} resterr.RestErr "Carry has shipments"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/carries/{id} [delete]
+func (h *CarriesHandlerDefault) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+		return
+	}
+
+	err = h.sv.Delete(id)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusNoContent, nil)
+}
+
+// CarryCoverageCreateRequest is a struct that represents a request to declare a locality served by a carry
+type CarryCoverageCreateRequest struct {
+	LocalityID   *int     `json:"locality_id"`
+	LeadTimeDays *int     `json:"lead_time_days"`
+	CostPerKg    *float64 `json:"cost_per_kg"`
+}
+
+// GetCoverages godoc
+// @Summary Get the localities served by a carry
+// @Description Retrieve the localities a carry delivers to with their lead time and cost per kg
+// @Tags Carries
+// @Produce json
+// @Param id path int true "Carry ID"
+// @Success 200 {object} []internal.CarryCoverage "List of coverages"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Carry not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/carries/{id}/coverages [get]
+func (h *CarriesHandlerDefault) GetCoverages(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+		return
+	}
+
+	coverages, err := h.sv.FindCoverages(id)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if coverages == nil {
+		coverages = []internal.CarryCoverage{}
+	}
+
+	response.JSON(w, http.StatusOK, map[string]any{
+		"data": coverages,
+	})
+}
+
+// CreateCoverage godoc
+// @Summary Declare a locality served by a carry
+// @Description Record a locality the carry delivers to with its lead time and cost per kg
+// @Tags Carries
+// @Accept json
+// @Produce json
+// @Param id path int true "Carry ID"
+// @Param request body handler.CarryCoverageCreateRequest true "Locality, lead time and cost per kg"
+// @Success 201 {object} internal.CarryCoverage "Created coverage"
+// @Failure 400 {object} resterr.RestErr "Invalid data"
+// @Failure 404 {object} resterr.RestErr "Carry not found"
+// @Failure 409 {object} resterr.RestErr "Locality not found or already served by the carry"
+// @Failure 422 {object} resterr.RestErr "Missing fields"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/carries/{id}/coverages [post]
+func (h *CarriesHandlerDefault) CreateCoverage(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+		return
+	}
+
+	var requestInput CarryCoverageCreateRequest
+
+	err = json.NewDecoder(r.Body).Decode(&requestInput)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+		return
+	}
+
+	causes := requestInput.ValidateRequiredFields()
+	if len(causes) > 0 {
+		response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityWithCausesError("missing fields", causes))
+		return
+	}
+
+	coverage := internal.CarryCoverage{
+		CarryID:      id,
+		LocalityID:   *requestInput.LocalityID,
+		LeadTimeDays: *requestInput.LeadTimeDays,
+		CostPerKg:    *requestInput.CostPerKg,
+	}
+
+	err = h.sv.CreateCoverage(&coverage)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, map[string]any{
+		"data": coverage,
+	})
+}
+
+// Quote godoc
+// @Summary Quote the delivery to a locality
+// @Description Rank the carries serving the destination locality that can pick up in the locality of the origin warehouse by cost and lead time.
+// @Description The weight is given in kg or computed from the net weight of the products of a purchase order.
+// @Tags Carries
+// @Produce json
+// @Param origin_warehouse query int true "Warehouse the delivery leaves from"
+// @Param destination_locality query int true "Locality the delivery goes to"
+// @Param weight query number false "Weight in kg"
+// @Param purchase_order_id query int false "Purchase order whose products are delivered"
+// @Success 200 {object} []internal.CarryQuote "Quotes, cheapest first"
+// @Failure 400 {object} resterr.RestErr "Invalid data"
+// @Failure 404 {object} resterr.RestErr "Warehouse or locality not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/carries/quote [get]
+func (h *CarriesHandlerDefault) Quote(w http.ResponseWriter, r *http.Request) {
+	request, err := parseCarryQuoteRequest(r)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+		return
+	}
+
+	quotes, err := h.sv.Quote(request)
+	if err != nil {
+		switch {
+		case errors.Is(err, internal.ErrWarehouseRepositoryNotFound),
+			errors.Is(err, internal.ErrLocalityNotFound):
+			response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+		default:
+			h.handleError(w, err)
+		}
+
+		return
+	}
+
+	if quotes == nil {
+		quotes = []internal.CarryQuote{}
+	}
+
+	response.JSON(w, http.StatusOK, map[string]any{
+		"data": quotes,
+	})
+}
+
+func (h *CarriesHandlerDefault) handleError(w http.ResponseWriter, err error) {
+	var domainError internal.DomainError
+
+	switch {
+	case errors.As(err, &domainError):
+		var restCauses []resterr.Causes
+		for _, cause := range domainError.Causes {
+			restCauses = append(restCauses, resterr.Causes{
+				Field:   cause.Field,
+				Message: cause.Message,
+			})
+		}
+
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+	case errors.Is(err, internal.ErrCarryNotFound):
+		response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+	case errors.Is(err, internal.ErrLocalityNotFound),
+		errors.Is(err, internal.ErrProductNotFound),
+		errors.Is(err, internal.ErrCarryCoverageConflict),
+		errors.Is(err, internal.ErrCarryCidConflict),
+		errors.Is(err, internal.ErrCarryInUse):
+		response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+	default:
+		response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+	}
+}
+
+// Validating the CarryCoverageCreateRequest required fields
+func (p *CarryCoverageCreateRequest) ValidateRequiredFields() (causes []resterr.Causes) {
+	if p.LocalityID == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "locality_id",
+			Message: "locality id is required",
+		})
+	}
+	if p.LeadTimeDays == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "lead_time_days",
+			Message: "lead time days is required",
+		})
+	}
+	if p.CostPerKg == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "cost_per_kg",
+			Message: "cost per kg is required",
+		})
+	}
+	return
+}
+
+// parseCarryQuoteRequest reads the quote request from the query string, the business rules are checked by the service
+func parseCarryQuoteRequest(r *http.Request) (request internal.CarryQuoteRequest, err error) {
+	query := r.URL.Query()
+
+	request.OriginWarehouseID, err = strconv.Atoi(query.Get("origin_warehouse"))
+	if err != nil {
+		return
+	}
+
+	request.DestinationLocalityID, err = strconv.Atoi(query.Get("destination_locality"))
+	if err != nil {
+		return
+	}
+
+	if weight := query.Get("weight"); weight != "" {
+		request.Weight, err = strconv.ParseFloat(weight, 64)
+		if err != nil {
+			return
+		}
+	}
+
+	if purchaseOrderID := query.Get("purchase_order_id"); purchaseOrderID != "" {
+		request.PurchaseOrderID, err = strconv.Atoi(purchaseOrderID)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -1,12 +1,14 @@
 package handler_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 	"github.com/meli-fresh-products-api-backend-t1/internal/handler"
 	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
@@ -35,6 +37,36 @@ func (m *MockCarriesService) Create(carry internal.Carries) (int64, error) {
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockCarriesService) FindByID(id int) (internal.Carries, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.Carries), args.Error(1)
+}
+
+func (m *MockCarriesService) Update(id int, patch internal.CarriesPatchUpdate) (internal.Carries, error) {
+	args := m.Called(id, patch)
+	return args.Get(0).(internal.Carries), args.Error(1)
+}
+
+func (m *MockCarriesService) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockCarriesService) FindCoverages(carryID int) ([]internal.CarryCoverage, error) {
+	args := m.Called(carryID)
+	return args.Get(0).([]internal.CarryCoverage), args.Error(1)
+}
+
+func (m *MockCarriesService) CreateCoverage(coverage *internal.CarryCoverage) error {
+	args := m.Called(coverage)
+	return args.Error(0)
+}
+
+func (m *MockCarriesService) Quote(request internal.CarryQuoteRequest) ([]internal.CarryQuote, error) {
+	args := m.Called(request)
+	return args.Get(0).([]internal.CarryQuote), args.Error(1)
+}
+
 type UnitTestCases struct {
 	name               string
 	mockService        func(*MockCarriesService)
@@ -213,12 +245,12 @@ func TestHandler_CarriesCreate(t *testing.T) {
 			"locality_id": 2
 			}`,
 
-			expectedBody: `{"message":"carries already exists","error":"conflict","code":409,"causes":null}`,
+			expectedBody: `{"message":"carry with this cid already exists","error":"conflict","code":409,"causes":null}`,
 			mockService: func(sv *MockCarriesService) {
-				sv.On("Create", mock.Anything).Return(int64(1), errors.New("carries already exists"))
+				sv.On("Create", mock.Anything).Return(int64(0), internal.ErrCarryCidConflict)
 			},
 			expectedStatusCode: http.StatusConflict,
-			expectedResponse:   resterr.NewConflictError("carries already exists"),
+			expectedResponse:   resterr.NewConflictError(internal.ErrCarryCidConflict.Error()),
 			expectedMockCalls:  1,
 		},
 	}
@@ -245,3 +277,160 @@ func TestHandler_CarriesCreate(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_CarriesQuote(t *testing.T) {
+	testCases := []*UnitTestCases{
+		{
+			name:         "status code 200 (success) - Carries ranked by cost",
+			bodyRequest:  "?origin_warehouse=1&destination_locality=2&weight=10",
+			expectedBody: `{"data":[{"carry_id":3,"cid":"CID003","company_name":"Fresh Express","lead_time_days":1,"cost_per_kg":1.5,"weight":10,"cost":15}]}`,
+			mockService: func(sv *MockCarriesService) {
+				sv.On("Quote", internal.CarryQuoteRequest{OriginWarehouseID: 1, DestinationLocalityID: 2, Weight: 10}).Return([]internal.CarryQuote{
+					{CarryID: 3, Cid: "CID003", CompanyName: "Fresh Express", LeadTimeDays: 1, CostPerKg: 1.5, Weight: 10, Cost: 15},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMockCalls:  1,
+		},
+		{
+			name:               "status code 400 (fail) - Missing destination locality",
+			bodyRequest:        "?origin_warehouse=1&weight=10",
+			expectedBody:       `{"message":"Invalid data","error":"bad_request","code":400,"causes":null}`,
+			mockService:        func(sv *MockCarriesService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMockCalls:  0,
+		},
+		{
+			name:         "status code 404 (fail) - Locality not found",
+			bodyRequest:  "?origin_warehouse=1&destination_locality=9&purchase_order_id=5",
+			expectedBody: `{"message":"locality not found","error":"not_found","code":404,"causes":null}`,
+			mockService: func(sv *MockCarriesService) {
+				sv.On("Quote", internal.CarryQuoteRequest{OriginWarehouseID: 1, DestinationLocalityID: 9, PurchaseOrderID: 5}).Return([]internal.CarryQuote(nil), internal.ErrLocalityNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMockCalls:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sv := NewMockCarriesService()
+			hd := handler.NewCarriesHandlerDefault(sv)
+			tc.mockService(sv)
+
+			req := httptest.NewRequest(http.MethodGet, endpoint+"/quote"+tc.bodyRequest, nil)
+			res := httptest.NewRecorder()
+
+			hd.Quote(res, req)
+
+			require.Equal(t, tc.expectedStatusCode, res.Code)
+			require.JSONEq(t, tc.expectedBody, res.Body.String())
+			sv.AssertNumberOfCalls(t, "Quote", tc.expectedMockCalls)
+		})
+	}
+}
+
+func TestHandler_CarriesUpdate(t *testing.T) {
+	testCases := []*UnitTestCases{
+		{
+			name:         "status code 200 (success) - Carry updated",
+			bodyRequest:  `{"company_name": "Meli Fresh"}`,
+			expectedBody: `{"data":{"id":1,"cid":"CID001","company_name":"Meli Fresh","address":"Address 1","phone_number":"11 91892-1912","locality_id":1}}`,
+			mockService: func(sv *MockCarriesService) {
+				companyName := "Meli Fresh"
+				sv.On("Update", 1, internal.CarriesPatchUpdate{CompanyName: &companyName}).Return(internal.Carries{
+					ID: 1, Cid: "CID001", CompanyName: "Meli Fresh", Address: "Address 1", PhoneNumber: "11 91892-1912", LocalityID: 1,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMockCalls:  1,
+		},
+		{
+			name:         "status code 400 (fail) - Invalid phone number",
+			bodyRequest:  `{"phone_number": "123"}`,
+			expectedBody: `{"message":"carry inputs are invalid","error":"bad_request","code":400,"causes":[{"field":"phone_number","message":"phone number is invalid, should be formatted as XX XXXXX-XXXX"}]}`,
+			mockService: func(sv *MockCarriesService) {
+				sv.On("Update", 1, mock.Anything).Return(internal.Carries{}, internal.DomainError{
+					Message: internal.ErrCarryBadRequest.Error(),
+					Causes:  []internal.Causes{{Field: "phone_number", Message: "phone number is invalid, should be formatted as XX XXXXX-XXXX"}},
+				})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMockCalls:  1,
+		},
+		{
+			name:         "status code 404 (fail) - Carry not found",
+			bodyRequest:  `{}`,
+			expectedBody: `{"message":"carry not found","error":"not_found","code":404,"causes":null}`,
+			mockService: func(sv *MockCarriesService) {
+				sv.On("Update", 1, mock.Anything).Return(internal.Carries{}, internal.ErrCarryNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMockCalls:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sv := NewMockCarriesService()
+			hd := handler.NewCarriesHandlerDefault(sv)
+			tc.mockService(sv)
+
+			req := httptest.NewRequest(http.MethodPatch, endpoint+"/1", strings.NewReader(tc.bodyRequest))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			res := httptest.NewRecorder()
+
+			hd.Update(res, req)
+
+			require.Equal(t, tc.expectedStatusCode, res.Code)
+			require.JSONEq(t, tc.expectedBody, res.Body.String())
+			sv.AssertNumberOfCalls(t, "Update", tc.expectedMockCalls)
+		})
+	}
+}
+
+func TestHandler_CarriesDelete(t *testing.T) {
+	testCases := []*UnitTestCases{
+		{
+			name: "status code 204 (success) - Carry deleted",
+			mockService: func(sv *MockCarriesService) {
+				sv.On("Delete", 1).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+			expectedMockCalls:  1,
+		},
+		{
+			name:         "status code 409 (fail) - Carry has shipments",
+			expectedBody: `{"message":"carry has shipments and cannot be deleted","error":"conflict","code":409,"causes":null}`,
+			mockService: func(sv *MockCarriesService) {
+				sv.On("Delete", 1).Return(internal.ErrCarryInUse)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedMockCalls:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sv := NewMockCarriesService()
+			hd := handler.NewCarriesHandlerDefault(sv)
+			tc.mockService(sv)
+
+			req := httptest.NewRequest(http.MethodDelete, endpoint+"/1", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			res := httptest.NewRecorder()
+
+			hd.Delete(res, req)
+
+			require.Equal(t, tc.expectedStatusCode, res.Code)
+			if tc.expectedBody != "" {
+				require.JSONEq(t, tc.expectedBody, res.Body.String())
+			}
+			sv.AssertNumberOfCalls(t, "Delete", tc.expectedMockCalls)
+		})
+	}
+}
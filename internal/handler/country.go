@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
+)
+
+// CountryCreateRequest is a struct that represents a request to create a country
+type CountryCreateRequest struct {
+	Name *string `json:"name"`
+}
+
+// NewCountryHandler creates a new instance of the country handler
+func NewCountryHandler(sv internal.CountryService) *CountryHandler {
+	return &CountryHandler{
+		sv: sv,
+	}
+}
+
+// CountryHandler is the default implementation of the country handler
+type CountryHandler struct {
+	sv internal.CountryService
+}
+
+// GetAll returns all countries
+// @Summary Get all countries
+// @Description Retrieve the list of countries
+// @Tags Country
+// @Produce json
+// @Success 200 {object} []internal.Country "List of countries"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/countries [get]
+func (h *CountryHandler) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		countries, err := h.sv.FindAll()
+		if err != nil {
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			return
+		}
+
+		if countries == nil {
+			countries = []internal.Country{}
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": countries,
+		})
+	}
+}
+
+// GetByID returns a country by ID
+// @Summary Get a country by ID
+// @Description Retrieve the country with the given ID
+// @Tags Country
+// @Produce json
+// @Param id path int true "Country ID"
+// @Success 200 {object} internal.Country "Country"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Country not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/countries/{id} [get]
+func (h *CountryHandler) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		c, err := h.sv.FindByID(id)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": c,
+		})
+	}
+}
+
+// Create creates a new country
+// @Summary Create a country
+// @Description Register a new country. Names are unique regardless of their case.
+// @Tags Country
+// @Accept json
+// @Produce json
+// @Param request body handler.CountryCreateRequest true "Country"
+// @Success 201 {object} internal.Country "Created country"
+// @Failure 400 {object} resterr.RestErr "Invalid data"
+// @Failure 409 {object} resterr.RestErr "Country already exists"
+// @Failure 422 {object} resterr.RestErr "Country inputs are missing"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/countries [post]
+func (h *CountryHandler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var requestInput CountryCreateRequest
+
+		// decoding the request
+		if err := json.NewDecoder(r.Body).Decode(&requestInput); err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+			return
+		}
+
+		// validating the request input required fields
+		causes := requestInput.ValidateRequiredFields()
+		if len(causes) > 0 {
+			response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityWithCausesError(internal.ErrCountryUnprocessableEntity.Error(), causes))
+			return
+		}
+
+		c := internal.Country{
+			Name: *requestInput.Name,
+		}
+
+		if err := h.sv.Save(&c); err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"data": c,
+		})
+	}
+}
+
+// Report returns the provinces, localities, sellers and carries of the countries
+// @Summary Report countries
+// @Description Roll up the provinces, localities, sellers and carries of every country or of a specific one by ID
+// @Tags Country
+// @Produce json
+// @Param id query int false "Country ID"
+// @Success 200 {object} []internal.CountryReport "Countries report"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Country not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/countries/report [get]
+func (h *CountryHandler) Report() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := r.URL.Query().Get("id")
+
+		if idStr == "" {
+			reports, err := h.sv.Report()
+			if err != nil {
+				h.handleError(w, err)
+				return
+			}
+
+			if reports == nil {
+				reports = []internal.CountryReport{}
+			}
+
+			response.JSON(w, http.StatusOK, map[string]any{
+				"data": reports,
+			})
+
+			return
+		}
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		report, err := h.sv.ReportByID(id)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": []internal.CountryReport{report},
+		})
+	}
+}
+
+func (h *CountryHandler) handleError(w http.ResponseWriter, err error) {
+	var domainError internal.DomainError
+
+	switch {
+	case errors.As(err, &domainError):
+		var restCauses []resterr.Causes
+		for _, cause := range domainError.Causes {
+			restCauses = append(restCauses, resterr.Causes{
+				Field:   cause.Field,
+				Message: cause.Message,
+			})
+		}
+
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+	case errors.Is(err, internal.ErrCountryNotFound):
+		response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+	case errors.Is(err, internal.ErrCountryConflict):
+		response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+	default:
+		response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+	}
+}
+
+// Validating the CountryCreateRequest required fields
+func (p *CountryCreateRequest) ValidateRequiredFields() (causes []resterr.Causes) {
+	if p.Name == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "name",
+			Message: "name is required",
+		})
+	}
+	return
+}
@@ -0,0 +1,199 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/handler"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewCountryServiceMock() *CountryServiceMock {
+	return &CountryServiceMock{}
+}
+
+type CountryServiceMock struct {
+	mock.Mock
+}
+
+func (m *CountryServiceMock) FindAll() ([]internal.Country, error) {
+	args := m.Called()
+	return args.Get(0).([]internal.Country), args.Error(1)
+}
+
+func (m *CountryServiceMock) FindByID(id int) (internal.Country, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.Country), args.Error(1)
+}
+
+func (m *CountryServiceMock) Save(c *internal.Country) error {
+	args := m.Called(c)
+	return args.Error(0)
+}
+
+func (m *CountryServiceMock) Report() ([]internal.CountryReport, error) {
+	args := m.Called()
+	return args.Get(0).([]internal.CountryReport), args.Error(1)
+}
+
+func (m *CountryServiceMock) ReportByID(id int) (internal.CountryReport, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.CountryReport), args.Error(1)
+}
+
+func TestCountry_GetAll(t *testing.T) {
+	sv := NewCountryServiceMock()
+	sv.On("FindAll").Return([]internal.Country(nil), nil)
+	hd := handler.NewCountryHandler(sv)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/countries", nil)
+	response := httptest.NewRecorder()
+
+	hd.GetAll()(response, request)
+
+	require.Equal(t, http.StatusOK, response.Code)
+	require.JSONEq(t, `{"data":[]}`, response.Body.String())
+}
+
+func TestCountry_GetByID(t *testing.T) {
+	sv := NewCountryServiceMock()
+	sv.On("FindByID", 99).Return(internal.Country{}, internal.ErrCountryNotFound)
+	hd := handler.NewCountryHandler(sv)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/countries/99", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "99")
+	request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+	response := httptest.NewRecorder()
+
+	hd.GetByID()(response, request)
+
+	require.Equal(t, http.StatusNotFound, response.Code)
+	require.JSONEq(t, `{"message":"country not found","error":"not_found","code":404,"causes":null}`, response.Body.String())
+}
+
+func TestCountry_Create(t *testing.T) {
+	testCases := []struct {
+		description       string
+		body              string
+		expectedBody      string
+		expectedCode      int
+		mock              func() *CountryServiceMock
+		expectedMockCalls int
+	}{
+		{
+			description:  "case 1 - success: Country created",
+			body:         `{"name": "Argentina"}`,
+			expectedBody: `{"data":{"id":3,"name":"Argentina"}}`,
+			expectedCode: http.StatusCreated,
+			mock: func() *CountryServiceMock {
+				mk := NewCountryServiceMock()
+				mk.On("Save", mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(0).(*internal.Country).ID = 3
+				}).Return(nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 2 - error: Missing required fields",
+			body:         `{}`,
+			expectedBody: `{"message":"country inputs are missing","error":"unprocessable_entity","code":422,"causes":[{"field":"name","message":"name is required"}]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			mock: func() *CountryServiceMock {
+				return NewCountryServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+		{
+			description:  "case 3 - error: Name already in use",
+			body:         `{"name": "argentina"}`,
+			expectedBody: `{"message":"country already exists","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *CountryServiceMock {
+				mk := NewCountryServiceMock()
+				mk.On("Save", mock.Anything).Return(internal.ErrCountryConflict)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewCountryHandler(sv)
+
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/countries", strings.NewReader(tc.body))
+			response := httptest.NewRecorder()
+
+			hd.Create()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+			sv.AssertNumberOfCalls(t, "Save", tc.expectedMockCalls)
+		})
+	}
+}
+
+func TestCountry_Report(t *testing.T) {
+	testCases := []struct {
+		description  string
+		query        string
+		expectedBody string
+		expectedCode int
+		mock         func() *CountryServiceMock
+	}{
+		{
+			description:  "case 1 - success: Report of every country",
+			query:        "",
+			expectedBody: `{"data":[{"country_id":1,"country_name":"United States","provinces_count":5,"localities_count":10,"sellers_count":7,"carries_count":3}]}`,
+			expectedCode: http.StatusOK,
+			mock: func() *CountryServiceMock {
+				mk := NewCountryServiceMock()
+				mk.On("Report").Return([]internal.CountryReport{{CountryID: 1, CountryName: "United States", ProvincesCount: 5, LocalitiesCount: 10, SellersCount: 7, CarriesCount: 3}}, nil)
+				return mk
+			},
+		},
+		{
+			description:  "case 2 - error: Invalid ID",
+			query:        "?id=abc",
+			expectedBody: `{"message":"Invalid ID format","error":"bad_request","code":400,"causes":null}`,
+			expectedCode: http.StatusBadRequest,
+			mock: func() *CountryServiceMock {
+				return NewCountryServiceMock()
+			},
+		},
+		{
+			description:  "case 3 - error: Country not found",
+			query:        "?id=99",
+			expectedBody: `{"message":"country not found","error":"not_found","code":404,"causes":null}`,
+			expectedCode: http.StatusNotFound,
+			mock: func() *CountryServiceMock {
+				mk := NewCountryServiceMock()
+				mk.On("ReportByID", 99).Return(internal.CountryReport{}, internal.ErrCountryNotFound)
+				return mk
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			hd := handler.NewCountryHandler(tc.mock())
+
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/countries/report"+tc.query, nil)
+			response := httptest.NewRecorder()
+
+			hd.Report()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+		})
+	}
+}
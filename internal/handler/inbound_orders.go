@@ -2,9 +2,13 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
 )
@@ -21,15 +25,17 @@ func NewInboundOrdersHandler(sv internal.InboundOrderService) *InboundOrdersHand
 
 // Create godoc
 // @Summary Create a new inbound order
-// @Description Create a new inbound order with the provided details
+// @Description Create a new inbound order with the provided details.
+// @Description Orders with lines create a product batch per line with its received quantity.
 // @Tags InboundOrders
 // @Accept json
 // @Produce json
 // @Param inbound body internal.InboundOrders true "Inbound order data"
 // @Success 201 {object} map[string]interface{} "Created inbound order with ID"
-// @Failure 400 {object} map[string]interface{} "Invalid body format"
+// @Failure 400 {object} map[string]interface{} "Invalid body format or the employee, batch or warehouse do not match"
 // @Failure 422 {object} map[string]interface{} "Required fields are missing"
 // @Failure 409 {object} map[string]interface{} "Order number already exists" or "Employee not exists"
+// @Failure 500 {object} resterr.RestErr "Internal server error"
 // @Router /api/v1/inbound-orders [post]
 func (h *InboundOrdersHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var inbound internal.InboundOrders
@@ -53,21 +59,32 @@ func (h *InboundOrdersHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	lastID, err := h.sv.Create(inbound)
 	if err != nil {
-		if err == internal.ErrOrderNumberAlreadyExists {
+		var domainError internal.DomainError
+
+		switch {
+		case errors.As(err, &domainError):
+			var restCauses []resterr.Causes
+			for _, cause := range domainError.Causes {
+				restCauses = append(restCauses, resterr.Causes{
+					Field:   cause.Field,
+					Message: cause.Message,
+				})
+			}
+
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+		case errors.Is(err, internal.ErrOrderNumberAlreadyExists):
 			response.JSON(w, http.StatusConflict, map[string]any{
 				"error": "order number already exists", //status code 409
 			})
-
-			return
-		}
-
-		if err == internal.ErrEmployeeNotFound {
+		case errors.Is(err, internal.ErrEmployeeNotFound):
 			response.JSON(w, http.StatusConflict, map[string]any{
 				"error": "employee not exists", //status code 409
 			})
-
-			return
+		default:
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
 		}
+
+		return
 	}
 
 	response.JSON(w, http.StatusCreated, map[string]any{
@@ -81,15 +98,27 @@ func (h *InboundOrdersHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 // GetAll godoc
 // @Summary Get all inbound orders
-// @Description Retrieve a list of all inbound orders from the database
+// @Description Retrieve the inbound orders from the database, optionally filtered by warehouse, employee and order date
 // @Tags InboundOrders
 // @Accept json
 // @Produce json
+// @Param warehouse_id query int false "Warehouse ID"
+// @Param employee_id query int false "Employee ID"
+// @Param from query string false "First order date (YYYY-MM-DD)"
+// @Param to query string false "Last order date (YYYY-MM-DD)"
 // @Success 200 {object} map[string]interface{} "List of inbound orders"
+// @Failure 400 {object} resterr.RestErr "Invalid filters"
 // @Failure 500 {object} resterr.RestErr "Failed to fetch inbounds orders"
 // @Router /api/v1/inbound-orders [get]
 func (h *InboundOrdersHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	allInbounds, err := h.sv.FindAll()
+	filter, causes := parseInboundOrderFilter(r)
+	if len(causes) > 0 {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(ErrInvalidData, causes))
+
+		return
+	}
+
+	allInbounds, err := h.sv.FindAll(filter)
 	if err != nil {
 		response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError("failed to fetch inbounds orders"))
 
@@ -100,3 +129,187 @@ func (h *InboundOrdersHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 		"data": allInbounds,
 	})
 }
+
+// GetByID godoc
+// @Summary Get an inbound order
+// @Description Retrieve the inbound order with the given ID
+// @Tags InboundOrders
+// @Produce json
+// @Param id path int true "Inbound order ID"
+// @Success 200 {object} map[string]interface{} "Inbound order"
+// @Failure 400 {object} resterr.RestErr "Invalid ID"
+// @Failure 404 {object} resterr.RestErr "Inbound order not found"
+// @Failure 500 {object} resterr.RestErr "Internal server error"
+// @Router /api/v1/inbound-orders/{id} [get]
+func (h *InboundOrdersHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+
+		return
+	}
+
+	inbound, err := h.sv.FindByID(id)
+	if err != nil {
+		if errors.Is(err, internal.ErrInboundOrderNotFound) {
+			response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+
+			return
+		}
+
+		response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]any{
+		"data": inbound,
+	})
+}
+
+// GetDiscrepancies godoc
+// @Summary Get the discrepancies of an inbound order
+// @Description Retrieve the lines of an inbound order whose received quantity differs from the expected one
+// @Tags InboundOrders
+// @Produce json
+// @Param id path int true "Inbound order ID"
+// @Success 200 {object} map[string]interface{} "Lines received with a different quantity"
+// @Failure 400 {object} resterr.RestErr "Invalid ID"
+// @Failure 404 {object} resterr.RestErr "Inbound order not found"
+// @Failure 500 {object} resterr.RestErr "Internal server error"
+// @Router /api/v1/inbound-orders/{id}/discrepancies [get]
+func (h *InboundOrdersHandler) GetDiscrepancies(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+
+		return
+	}
+
+	discrepancies, err := h.sv.FindDiscrepancies(id)
+	if err != nil {
+		if errors.Is(err, internal.ErrInboundOrderNotFound) {
+			response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+
+			return
+		}
+
+		response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]any{
+		"data": discrepancies,
+	})
+}
+
+// GetSummary godoc
+// @Summary Get the inbound summary of a warehouse
+// @Description Retrieve the quantities received in a warehouse grouped by day and product type
+// @Tags InboundOrders
+// @Produce json
+// @Param id path int true "Warehouse ID"
+// @Param from query string false "First order date (YYYY-MM-DD)"
+// @Param to query string false "Last order date (YYYY-MM-DD)"
+// @Success 200 {object} map[string]interface{} "Daily received quantities per product type"
+// @Failure 400 {object} resterr.RestErr "Invalid ID or filters"
+// @Failure 404 {object} resterr.RestErr "Warehouse not found"
+// @Failure 500 {object} resterr.RestErr "Internal server error"
+// @Router /api/v1/warehouses/{id}/inbound-summary [get]
+func (h *InboundOrdersHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	warehouseID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+
+		return
+	}
+
+	filter, causes := parseInboundOrderFilter(r)
+	if len(causes) > 0 {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(ErrInvalidData, causes))
+
+		return
+	}
+
+	summary, err := h.sv.FindSummary(warehouseID, filter)
+	if err != nil {
+		if errors.Is(err, internal.ErrWarehouseRepositoryNotFound) {
+			response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+
+			return
+		}
+
+		response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+
+		return
+	}
+
+	if summary == nil {
+		summary = []internal.InboundOrderSummary{}
+	}
+
+	response.JSON(w, http.StatusOK, map[string]any{
+		"data": summary,
+	})
+}
+
+// parseInboundOrderFilter reads the inbound order filter from the query string
+func parseInboundOrderFilter(r *http.Request) (filter internal.InboundOrderFilter, causes []resterr.Causes) {
+	query := r.URL.Query()
+
+	for _, param := range []struct {
+		name  string
+		value *int
+	}{
+		{name: "warehouse_id", value: &filter.WarehouseID},
+		{name: "employee_id", value: &filter.EmployeeID},
+	} {
+		raw := query.Get(param.name)
+		if raw == "" {
+			continue
+		}
+
+		id, err := strconv.Atoi(raw)
+		if err != nil || id <= 0 {
+			causes = append(causes, resterr.Causes{
+				Field:   param.name,
+				Message: param.name + " must be a positive number",
+			})
+			continue
+		}
+		*param.value = id
+	}
+
+	for _, param := range []struct {
+		name  string
+		value *time.Time
+	}{
+		{name: "from", value: &filter.From},
+		{name: "to", value: &filter.To},
+	} {
+		raw := query.Get(param.name)
+		if raw == "" {
+			continue
+		}
+
+		date, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			causes = append(causes, resterr.Causes{
+				Field:   param.name,
+				Message: "invalid date format",
+			})
+			continue
+		}
+		*param.value = date
+	}
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		causes = append(causes, resterr.Causes{
+			Field:   "from",
+			Message: "from must not be after to",
+		})
+	}
+
+	return
+}
@@ -1,13 +1,16 @@
 package handler_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 	"github.com/meli-fresh-products-api-backend-t1/internal/handler"
 	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
@@ -25,12 +28,27 @@ func (inb *InboundOrdersServiceMock) Create(internal.InboundOrders) (int64, erro
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (inb *InboundOrdersServiceMock) FindAll() ([]internal.InboundOrders, error) {
-	args := inb.Called()
+func (inb *InboundOrdersServiceMock) FindAll(filter internal.InboundOrderFilter) ([]internal.InboundOrders, error) {
+	args := inb.Called(filter)
 	return args.Get(0).([]internal.InboundOrders), args.Error(1)
 
 }
 
+func (inb *InboundOrdersServiceMock) FindByID(id int) (internal.InboundOrders, error) {
+	args := inb.Called(id)
+	return args.Get(0).(internal.InboundOrders), args.Error(1)
+}
+
+func (inb *InboundOrdersServiceMock) FindDiscrepancies(id int) ([]internal.InboundOrderDiscrepancy, error) {
+	args := inb.Called(id)
+	return args.Get(0).([]internal.InboundOrderDiscrepancy), args.Error(1)
+}
+
+func (inb *InboundOrdersServiceMock) FindSummary(warehouseID int, filter internal.InboundOrderFilter) ([]internal.InboundOrderSummary, error) {
+	args := inb.Called(warehouseID, filter)
+	return args.Get(0).([]internal.InboundOrderSummary), args.Error(1)
+}
+
 type TestUnitCases struct {
 	name               string
 	mockService        func(*InboundOrdersServiceMock)
@@ -50,7 +68,8 @@ func TestInboundCreate(t *testing.T) {
 				"order_number": "ORD11111",
 				"employee_id": 1,
 				"product_batch_id": 1,
-				"warehouse_id": 1
+				"warehouse_id": 1,
+				"quantity": 10
 			}`,
 			expectedBody: `{"data":{"id":1}}`,
 			mockService: func(inb *InboundOrdersServiceMock) {
@@ -60,6 +79,59 @@ func TestInboundCreate(t *testing.T) {
 			expectedResponse:   internal.InboundOrders{},
 			expectedMockCalls:  1,
 		},
+		{
+			name: "status code 201 (success) - Create a new Inbound Order with lines",
+			body: `{
+				"order_date": "2123-01-01",
+				"order_number": "ASN11111",
+				"employee_id": 1,
+				"warehouse_id": 1,
+				"lines": [
+					{
+						"expected_quantity": 10,
+						"received_quantity": 8,
+						"product_batch": {
+							"batch_number": 100,
+							"due_date": "2123-06-01",
+							"manufacturing_date": "2123-01-01",
+							"product_id": 5,
+							"section_id": 4
+						}
+					}
+				]
+			}`,
+			expectedBody: `{"data":{"id":2}}`,
+			mockService: func(inb *InboundOrdersServiceMock) {
+				inb.On("Create", mock.Anything).Return(int64(2), nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedMockCalls:  1,
+		},
+		{
+			name: "status code 422 (fail) - Attempt to create a new Inbound Order with a line missing its section",
+			body: `{
+				"order_date": "2123-01-01",
+				"order_number": "ASN11111",
+				"employee_id": 1,
+				"warehouse_id": 1,
+				"lines": [
+					{
+						"expected_quantity": 10,
+						"received_quantity": 8,
+						"product_batch": {
+							"batch_number": 100,
+							"due_date": "2123-06-01",
+							"manufacturing_date": "2123-01-01",
+							"product_id": 5
+						}
+					}
+				]
+			}`,
+			expectedBody:       `{"error":"required fields are missing"}`,
+			mockService:        func(inb *InboundOrdersServiceMock) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedMockCalls:  0,
+		},
 		{
 			name: "status code 400 (fail) - Attempt to create a new Inbound Order with invalid input",
 			body: `{
@@ -101,7 +173,8 @@ func TestInboundCreate(t *testing.T) {
 				"order_number": "ORD11111",
 				"employee_id": 1,
 				"product_batch_id": 3,
-				"warehouse_id": 2
+				"warehouse_id": 2,
+				"quantity": 10
 			}`,
 			expectedBody: `{"error":"order number already exists"}`,
 			mockService: func(inb *InboundOrdersServiceMock) {
@@ -118,7 +191,8 @@ func TestInboundCreate(t *testing.T) {
 				"order_number": "88080",
 				"employee_id": 155,
 				"product_batch_id": 3,
-				"warehouse_id": 2
+				"warehouse_id": 2,
+				"quantity": 10
 			}`,
 			expectedBody: `{"error":"employee not exists"}`,
 			mockService: func(inb *InboundOrdersServiceMock) {
@@ -128,6 +202,45 @@ func TestInboundCreate(t *testing.T) {
 			expectedResponse:   errors.New("employee not exists"),
 			expectedMockCalls:  1,
 		},
+		{
+			name: "status code 400 (fail) - Attempt to create a new Inbound Order with an employee from another warehouse",
+			body: `{
+				"order_date": "2021-03-04",
+				"order_number": "ORD3333",
+				"employee_id": 1,
+				"product_batch_id": 3,
+				"warehouse_id": 2,
+				"quantity": 10
+			}`,
+			expectedBody: `{"message":"inbound order inputs are invalid","error":"bad_request","code":400,"causes":[{"field":"employee_id","message":"employee 1 works in warehouse 5, not in warehouse 2"}]}`,
+			mockService: func(inb *InboundOrdersServiceMock) {
+				inb.On("Create", mock.Anything).Return(int64(0), internal.DomainError{
+					Message: internal.ErrInboundOrderBadRequest.Error(),
+					Causes: []internal.Causes{
+						{Field: "employee_id", Message: "employee 1 works in warehouse 5, not in warehouse 2"},
+					},
+				})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMockCalls:  1,
+		},
+		{
+			name: "status code 500 (fail) - Attempt to create a new Inbound Order with an unexpected error",
+			body: `{
+				"order_date": "2021-03-04",
+				"order_number": "ORD4444",
+				"employee_id": 1,
+				"product_batch_id": 3,
+				"warehouse_id": 2,
+				"quantity": 10
+			}`,
+			expectedBody: `{"message":"Internal Server Error","error":"internal_server_error","code":500,"causes":null}`,
+			mockService: func(inb *InboundOrdersServiceMock) {
+				inb.On("Create", mock.Anything).Return(int64(0), errors.New("db is down"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedMockCalls:  1,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -166,7 +279,8 @@ func TestInboundGetAll(t *testing.T) {
 								"order_number": "ORD11111",
 								"employee_id": 1,
 								"product_batch_id": 3,
-								"warehouse_id": 2
+								"warehouse_id": 2,
+								"quantity": 10
 								},
 								{
 								"id": 2,
@@ -174,13 +288,14 @@ func TestInboundGetAll(t *testing.T) {
 								"order_number": "ORD2222",
 								"employee_id": 1,
 								"product_batch_id": 3,
-								"warehouse_id": 2
+								"warehouse_id": 2,
+								"quantity": 10
 								}
 							]
 							}`,
 
 			mockService: func(inb *InboundOrdersServiceMock) {
-				inb.On(("FindAll")).Return([]internal.InboundOrders{
+				inb.On("FindAll", internal.InboundOrderFilter{}).Return([]internal.InboundOrders{
 					{
 						ID:             1,
 						OrderDate:      "2021-03-04",
@@ -188,6 +303,7 @@ func TestInboundGetAll(t *testing.T) {
 						EmployeeID:     1,
 						ProductBatchID: 3,
 						WarehouseID:    2,
+						Quantity:       10,
 					},
 					{
 						ID:             2,
@@ -196,6 +312,7 @@ func TestInboundGetAll(t *testing.T) {
 						EmployeeID:     1,
 						ProductBatchID: 3,
 						WarehouseID:    2,
+						Quantity:       10,
 					},
 				}, nil)
 			},
@@ -211,7 +328,7 @@ func TestInboundGetAll(t *testing.T) {
 			expectedBody: `{"message":"failed to fetch inbounds orders","error":"internal_server_error","code":500,"causes":null}`,
 
 			mockService: func(inb *InboundOrdersServiceMock) {
-				inb.On(("FindAll")).Return([]internal.InboundOrders{}, errors.New("failed to fetch inbounds orders"))
+				inb.On("FindAll", internal.InboundOrderFilter{}).Return([]internal.InboundOrders{}, errors.New("failed to fetch inbounds orders"))
 			},
 
 			expectedStatusCode: http.StatusInternalServerError,
@@ -244,6 +361,238 @@ func TestInboundGetAll(t *testing.T) {
 	}
 }
 
+func TestInboundGetAllFilters(t *testing.T) {
+	t.Run("status code 200 (success) - Filter the Inbound Orders by warehouse, employee and date", func(t *testing.T) {
+		sv := new(InboundOrdersServiceMock)
+		hd := handler.NewInboundOrdersHandler(sv)
+		sv.On("FindAll", internal.InboundOrderFilter{
+			WarehouseID: 2,
+			EmployeeID:  1,
+			From:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:          time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+		}).Return([]internal.InboundOrders{{ID: 1, WarehouseID: 2, EmployeeID: 1}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/inbound-orders?warehouse_id=2&employee_id=1&from=2025-01-01&to=2025-01-31", nil)
+		res := httptest.NewRecorder()
+
+		hd.GetAll(res, req)
+
+		require.Equal(t, http.StatusOK, res.Code)
+		sv.AssertNumberOfCalls(t, "FindAll", 1)
+	})
+	t.Run("status code 400 (fail) - Filter the Inbound Orders with invalid values", func(t *testing.T) {
+		sv := new(InboundOrdersServiceMock)
+		hd := handler.NewInboundOrdersHandler(sv)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/inbound-orders?warehouse_id=abc&from=2025-02-01&to=2025-01-01", nil)
+		res := httptest.NewRecorder()
+
+		hd.GetAll(res, req)
+
+		require.Equal(t, http.StatusBadRequest, res.Code)
+		assert.JSONEq(t, `{"message":"Invalid data","error":"bad_request","code":400,"causes":[
+			{"field":"warehouse_id","message":"warehouse_id must be a positive number"},
+			{"field":"from","message":"from must not be after to"}]}`, res.Body.String())
+		sv.AssertNumberOfCalls(t, "FindAll", 0)
+	})
+}
+
+func TestInboundGetByID(t *testing.T) {
+	testCases := []struct {
+		name               string
+		id                 string
+		mockService        func(*InboundOrdersServiceMock)
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name: "status code 200 (success) - Get an Inbound Order",
+			id:   "1",
+			mockService: func(inb *InboundOrdersServiceMock) {
+				inb.On("FindByID", 1).Return(internal.InboundOrders{
+					ID:             1,
+					OrderDate:      "2021-03-04",
+					OrderNumber:    "ORD11111",
+					EmployeeID:     1,
+					ProductBatchID: 3,
+					WarehouseID:    2,
+					Quantity:       10,
+				}, nil)
+			},
+			expectedBody:       `{"data":{"id":1,"order_date":"2021-03-04","order_number":"ORD11111","employee_id":1,"product_batch_id":3,"warehouse_id":2,"quantity":10}}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "status code 400 (fail) - Get an Inbound Order with an invalid id",
+			id:                 "abc",
+			mockService:        func(inb *InboundOrdersServiceMock) {},
+			expectedBody:       `{"message":"Invalid ID format","error":"bad_request","code":400,"causes":null}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "status code 404 (fail) - Get an Inbound Order that does not exist",
+			id:   "99",
+			mockService: func(inb *InboundOrdersServiceMock) {
+				inb.On("FindByID", 99).Return(internal.InboundOrders{}, internal.ErrInboundOrderNotFound)
+			},
+			expectedBody:       `{"message":"inbound order not found","error":"not_found","code":404,"causes":null}`,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "status code 500 (fail) - Get an Inbound Order with an unexpected error",
+			id:   "1",
+			mockService: func(inb *InboundOrdersServiceMock) {
+				inb.On("FindByID", 1).Return(internal.InboundOrders{}, errors.New("db is down"))
+			},
+			expectedBody:       `{"message":"Internal Server Error","error":"internal_server_error","code":500,"causes":null}`,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sv := new(InboundOrdersServiceMock)
+			hd := handler.NewInboundOrdersHandler(sv)
+			tc.mockService(sv)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/inbound-orders/"+tc.id, nil)
+			chiCtx := chi.NewRouteContext()
+			chiCtx.URLParams.Add("id", tc.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx))
+			res := httptest.NewRecorder()
+
+			hd.GetByID(res, req)
+
+			require.Equal(t, tc.expectedStatusCode, res.Code)
+			assert.JSONEq(t, tc.expectedBody, res.Body.String())
+		})
+	}
+}
+
+func TestInboundGetDiscrepancies(t *testing.T) {
+	testCases := []struct {
+		name               string
+		id                 string
+		mockService        func(*InboundOrdersServiceMock)
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name: "status code 200 (success) - Get the discrepancies of an Inbound Order",
+			id:   "1",
+			mockService: func(inb *InboundOrdersServiceMock) {
+				inb.On("FindDiscrepancies", 1).Return([]internal.InboundOrderDiscrepancy{
+					{InboundOrderLineID: 2, ProductBatchID: 8, BatchNumber: 80, ExpectedQuantity: 10, ReceivedQuantity: 4, Difference: -6},
+				}, nil)
+			},
+			expectedBody:       `{"data":[{"inbound_order_line_id":2,"product_batch_id":8,"batch_number":80,"expected_quantity":10,"received_quantity":4,"difference":-6}]}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "status code 400 (fail) - Get the discrepancies with an invalid id",
+			id:                 "abc",
+			mockService:        func(inb *InboundOrdersServiceMock) {},
+			expectedBody:       `{"message":"Invalid ID format","error":"bad_request","code":400,"causes":null}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "status code 404 (fail) - Get the discrepancies of an Inbound Order that does not exist",
+			id:   "99",
+			mockService: func(inb *InboundOrdersServiceMock) {
+				inb.On("FindDiscrepancies", 99).Return([]internal.InboundOrderDiscrepancy(nil), internal.ErrInboundOrderNotFound)
+			},
+			expectedBody:       `{"message":"inbound order not found","error":"not_found","code":404,"causes":null}`,
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sv := new(InboundOrdersServiceMock)
+			hd := handler.NewInboundOrdersHandler(sv)
+			tc.mockService(sv)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/inbound-orders/"+tc.id+"/discrepancies", nil)
+			chiCtx := chi.NewRouteContext()
+			chiCtx.URLParams.Add("id", tc.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx))
+			res := httptest.NewRecorder()
+
+			hd.GetDiscrepancies(res, req)
+
+			require.Equal(t, tc.expectedStatusCode, res.Code)
+			assert.JSONEq(t, tc.expectedBody, res.Body.String())
+		})
+	}
+}
+
+func TestInboundGetSummary(t *testing.T) {
+	testCases := []struct {
+		name               string
+		id                 string
+		query              string
+		mockService        func(*InboundOrdersServiceMock)
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name:  "status code 200 (success) - Get the inbound summary of a warehouse",
+			id:    "2",
+			query: "?from=2025-01-01",
+			mockService: func(inb *InboundOrdersServiceMock) {
+				inb.On("FindSummary", 2, internal.InboundOrderFilter{From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}).
+					Return([]internal.InboundOrderSummary{
+						{OrderDate: "2025-01-02", ProductTypeID: 1, ProductTypeName: "Dairy", OrdersCount: 2, Quantity: 150},
+					}, nil)
+			},
+			expectedBody:       `{"data":[{"order_date":"2025-01-02","product_type_id":1,"product_type_name":"Dairy","orders_count":2,"received_quantity":150}]}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "status code 200 (success) - Get an empty inbound summary",
+			id:   "2",
+			mockService: func(inb *InboundOrdersServiceMock) {
+				inb.On("FindSummary", 2, internal.InboundOrderFilter{}).Return([]internal.InboundOrderSummary(nil), nil)
+			},
+			expectedBody:       `{"data":[]}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "status code 400 (fail) - Get the inbound summary with an invalid date",
+			id:                 "2",
+			query:              "?to=01-01-2025",
+			mockService:        func(inb *InboundOrdersServiceMock) {},
+			expectedBody:       `{"message":"Invalid data","error":"bad_request","code":400,"causes":[{"field":"to","message":"invalid date format"}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "status code 404 (fail) - Get the inbound summary of a warehouse that does not exist",
+			id:   "99",
+			mockService: func(inb *InboundOrdersServiceMock) {
+				inb.On("FindSummary", 99, internal.InboundOrderFilter{}).Return([]internal.InboundOrderSummary(nil), internal.ErrWarehouseRepositoryNotFound)
+			},
+			expectedBody:       `{"message":"` + internal.ErrWarehouseRepositoryNotFound.Error() + `","error":"not_found","code":404,"causes":null}`,
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sv := new(InboundOrdersServiceMock)
+			hd := handler.NewInboundOrdersHandler(sv)
+			tc.mockService(sv)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses/"+tc.id+"/inbound-summary"+tc.query, nil)
+			chiCtx := chi.NewRouteContext()
+			chiCtx.URLParams.Add("id", tc.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx))
+			res := httptest.NewRecorder()
+
+			hd.GetSummary(res, req)
+
+			require.Equal(t, tc.expectedStatusCode, res.Code)
+			assert.JSONEq(t, tc.expectedBody, res.Body.String())
+		})
+	}
+}
+
 func NormalizeJSON(jsonStr string) string {
 	var obj interface{}
 	if err := json.Unmarshal([]byte(jsonStr), &obj); err != nil {
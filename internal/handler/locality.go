@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 	"github.com/meli-fresh-products-api-backend-t1/utils/logger"
 	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
@@ -30,16 +31,27 @@ type LocalityDefault struct {
 type LocalityGetJSON struct {
 	ID           int    `json:"id"`
 	LocalityName string `json:"locality_name"`
+	ProvinceID   int    `json:"province_id"`
 	ProvinceName string `json:"province_name"`
+	CountryID    int    `json:"country_id"`
 	CountryName  string `json:"country_name"`
 	SellersCount int    `json:"sellers_count"`
 }
 
+type LocalityStockSummaryJSON struct {
+	ProductTypeID   int    `json:"product_type_id"`
+	ProductTypeName string `json:"product_type_name"`
+	WarehousesCount int    `json:"warehouses_count"`
+	BatchesCount    int    `json:"batches_count"`
+	CurrentQuantity int    `json:"current_quantity"`
+}
+
 type LocalityPostJSON struct {
-	LocalityID   int    `json:"locality_id"`
-	LocalityName string `json:"locality_name"`
-	ProvinceName string `json:"province_name"`
-	CountryName  string `json:"country_name"`
+	LocalityID   int      `json:"locality_id"`
+	LocalityName string   `json:"locality_name"`
+	ProvinceID   int      `json:"province_id"`
+	Latitude     *float64 `json:"latitude,omitempty"`
+	Longitude    *float64 `json:"longitude,omitempty"`
 }
 
 // ReportCarries godoc
@@ -164,7 +176,9 @@ func (h *LocalityDefault) ReportSellers() http.HandlerFunc {
 			localitiesJSON = append(localitiesJSON, LocalityGetJSON{
 				ID:           locality.ID,
 				LocalityName: locality.LocalityName,
+				ProvinceID:   locality.ProvinceID,
 				ProvinceName: locality.ProvinceName,
+				CountryID:    locality.CountryID,
 				CountryName:  locality.CountryName,
 				SellersCount: locality.Sellers,
 			})
@@ -185,7 +199,7 @@ func (h *LocalityDefault) ReportSellers() http.HandlerFunc {
 // @Param locality body LocalityPostJSON true "Locality data"
 // @Success 200 {object} map[string]any "Saved locality data"
 // @Failure 400 {object} resterr.RestErr "Locality inputs are Invalid"
-// @Failure 409 {object} resterr.RestErr "Locality conflict"
+// @Failure 409 {object} resterr.RestErr "Locality conflict or province not found"
 // @Failure 500 {object} resterr.RestErr "Internal server error"
 // @Router /api/v1/localities [post]
 func (h *LocalityDefault) Save() http.HandlerFunc {
@@ -202,13 +216,25 @@ func (h *LocalityDefault) Save() http.HandlerFunc {
 		locality := &internal.Locality{
 			ID:           localityJSON.LocalityID,
 			LocalityName: localityJSON.LocalityName,
-			ProvinceName: localityJSON.ProvinceName,
-			CountryName:  localityJSON.CountryName,
+			ProvinceID:   localityJSON.ProvinceID,
+		}
+
+		if (localityJSON.Latitude == nil) != (localityJSON.Longitude == nil) {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError("latitude and longitude are required together"))
+
+			return
+		}
+
+		if localityJSON.Latitude != nil {
+			locality.Coordinates = &internal.Coordinates{
+				Latitude:  *localityJSON.Latitude,
+				Longitude: *localityJSON.Longitude,
+			}
 		}
 
 		err = h.sv.Save(locality)
 		if err != nil {
-			if errors.Is(err, internal.ErrLocalityConflict) {
+			if errors.Is(err, internal.ErrLocalityConflict) || errors.Is(err, internal.ErrProvinceNotFound) {
 				response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
 
 				return
@@ -242,3 +268,57 @@ func (h *LocalityDefault) Save() http.HandlerFunc {
 		})
 	}
 }
+
+// StockSummary godoc
+// @Summary Stock summary of a locality
+// @Description Sum the current quantity of the product batches per product type across the warehouses in the locality
+// @Tags Locality
+// @Produce json
+// @Param id path int true "Locality ID"
+// @Success 200 {object} []LocalityStockSummaryJSON "Stock per product type"
+// @Failure 400 {object} resterr.RestErr "Id should be a number"
+// @Failure 404 {object} resterr.RestErr "Locality not found"
+// @Failure 500 {object} resterr.RestErr "Internal server error"
+// @Router /api/v1/localities/{id}/stock-summary [get]
+func (h *LocalityDefault) StockSummary() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError("id should be a number"))
+
+			return
+		}
+
+		summary, err := h.sv.StockSummary(id)
+		if err != nil {
+			if errors.Is(err, internal.ErrLocalityNotFound) {
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+
+				return
+			}
+
+			logger.Error(err.Error(), err,
+				zap.Int("id", id),
+			)
+
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+
+			return
+		}
+
+		data := make([]LocalityStockSummaryJSON, 0, len(summary))
+		for _, s := range summary {
+			data = append(data, LocalityStockSummaryJSON{
+				ProductTypeID:   s.ProductTypeID,
+				ProductTypeName: s.ProductTypeName,
+				WarehousesCount: s.WarehousesCount,
+				BatchesCount:    s.BatchesCount,
+				CurrentQuantity: s.CurrentQuantity,
+			})
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": data,
+		})
+	}
+}
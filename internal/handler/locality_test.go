@@ -55,6 +55,11 @@ func (m *MockLocalityService) FindByID(id int) (locality internal.Locality, err
 	return args.Get(0).(internal.Locality), args.Error(1)
 }
 
+func (m *MockLocalityService) StockSummary(localityID int) ([]internal.LocalityStockSummary, error) {
+	args := m.Called(localityID)
+	return args.Get(0).([]internal.LocalityStockSummary), args.Error(1)
+}
+
 func TestLocalityDefault_ReportSellers(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -70,14 +75,18 @@ func TestLocalityDefault_ReportSellers(t *testing.T) {
 					{
 						ID:           1,
 						LocalityName: "Test Locality 1",
+						ProvinceID:   1,
 						ProvinceName: "Test Province 1",
+						CountryID:    1,
 						CountryName:  "Test Country 1",
 						Sellers:      10,
 					},
 					{
 						ID:           2,
 						LocalityName: "Test Locality 2",
+						ProvinceID:   2,
 						ProvinceName: "Test Province 2",
+						CountryID:    1,
 						CountryName:  "Test Country 2",
 						Sellers:      5,
 					},
@@ -91,14 +100,18 @@ func TestLocalityDefault_ReportSellers(t *testing.T) {
 					{
 						ID:           1,
 						LocalityName: "Test Locality 1",
+						ProvinceID:   1,
 						ProvinceName: "Test Province 1",
+						CountryID:    1,
 						CountryName:  "Test Country 1",
 						SellersCount: 10,
 					},
 					{
 						ID:           2,
 						LocalityName: "Test Locality 2",
+						ProvinceID:   2,
 						ProvinceName: "Test Province 2",
+						CountryID:    1,
 						CountryName:  "Test Country 2",
 						SellersCount: 5,
 					},
@@ -112,7 +125,9 @@ func TestLocalityDefault_ReportSellers(t *testing.T) {
 					{
 						ID:           1,
 						LocalityName: "Test Locality 1",
+						ProvinceID:   1,
 						ProvinceName: "Test Province 1",
+						CountryID:    1,
 						CountryName:  "Test Country 1",
 						Sellers:      10,
 					},
@@ -126,7 +141,9 @@ func TestLocalityDefault_ReportSellers(t *testing.T) {
 					{
 						ID:           1,
 						LocalityName: "Test Locality 1",
+						ProvinceID:   1,
 						ProvinceName: "Test Province 1",
+						CountryID:    1,
 						CountryName:  "Test Country 1",
 						SellersCount: 10,
 					},
@@ -242,16 +259,14 @@ func TestLocalityDefault_Save(t *testing.T) {
 			requestBody: handler.LocalityPostJSON{
 				LocalityID:   123,
 				LocalityName: "Test Locality",
-				ProvinceName: "Test Province",
-				CountryName:  "Test Country",
+				ProvinceID:   1,
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedResponse: map[string]interface{}{
 				"data": handler.LocalityPostJSON{
 					LocalityID:   123,
 					LocalityName: "Test Locality",
-					ProvinceName: "Test Province",
-					CountryName:  "Test Country",
+					ProvinceID:   1,
 				},
 			},
 		},
@@ -263,12 +278,24 @@ func TestLocalityDefault_Save(t *testing.T) {
 			requestBody: handler.LocalityPostJSON{
 				LocalityID:   123,
 				LocalityName: "Test Locality",
-				ProvinceName: "Test Province",
-				CountryName:  "Test Country",
+				ProvinceID:   1,
 			},
 			expectedStatusCode: http.StatusConflict,
 			expectedResponse:   *resterr.NewConflictError("locality conflict"),
 		},
+		{
+			name: "should return conflict error when the province does not exist",
+			mockSetup: func(m *MockLocalityService) {
+				m.On("Save", mock.Anything).Return(internal.ErrProvinceNotFound)
+			},
+			requestBody: handler.LocalityPostJSON{
+				LocalityID:   123,
+				LocalityName: "Test Locality",
+				ProvinceID:   99,
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   *resterr.NewConflictError("province not found"),
+		},
 		{
 			name: "should return internal server error",
 			mockSetup: func(m *MockLocalityService) {
@@ -277,8 +304,7 @@ func TestLocalityDefault_Save(t *testing.T) {
 			requestBody: handler.LocalityPostJSON{
 				LocalityID:   123,
 				LocalityName: "Test Locality",
-				ProvinceName: "Test Province",
-				CountryName:  "Test Country",
+				ProvinceID:   1,
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedResponse:   nil,
@@ -308,8 +334,7 @@ func TestLocalityDefault_Save(t *testing.T) {
 			requestBody: handler.LocalityPostJSON{
 				LocalityID:   123,
 				LocalityName: "",
-				ProvinceName: "Test Province",
-				CountryName:  "Test Country",
+				ProvinceID:   1,
 			},
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse: *resterr.NewBadRequestValidationError("locality validation error", []resterr.Causes{
@@ -509,3 +534,70 @@ func TestReportCarries(t *testing.T) {
 		})
 	}
 }
+
+func TestLocalityDefault_StockSummary(t *testing.T) {
+	tests := []struct {
+		name               string
+		mockSetup          func(*MockLocalityService)
+		id                 string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "should return the stock per product type",
+			mockSetup: func(m *MockLocalityService) {
+				m.On("StockSummary", 1).Return([]internal.LocalityStockSummary{
+					{ProductTypeID: 1, ProductTypeName: "Dairy", WarehousesCount: 2, BatchesCount: 3, CurrentQuantity: 150},
+				}, nil)
+			},
+			id:                 "1",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":[{"product_type_id":1,"product_type_name":"Dairy","warehouses_count":2,"batches_count":3,"current_quantity":150}]}`,
+		},
+		{
+			name: "should return an empty list for a locality without stock",
+			mockSetup: func(m *MockLocalityService) {
+				m.On("StockSummary", 2).Return([]internal.LocalityStockSummary(nil), nil)
+			},
+			id:                 "2",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":[]}`,
+		},
+		{
+			name:               "id is not a int, should return bad request",
+			mockSetup:          func(m *MockLocalityService) {},
+			id:                 "abc",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"message":"id should be a number","error":"bad_request","code":400,"causes":null}`,
+		},
+		{
+			name: "should return not found",
+			mockSetup: func(m *MockLocalityService) {
+				m.On("StockSummary", 99).Return([]internal.LocalityStockSummary(nil), internal.ErrLocalityNotFound)
+			},
+			id:                 "99",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"message":"locality not found","error":"not_found","code":404,"causes":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockLocalityService)
+			tt.mockSetup(mockService)
+			hd := handler.NewLocalityDefault(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/localities/"+tt.id+"/stock-summary", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			res := httptest.NewRecorder()
+
+			hd.StockSummary()(res, req)
+
+			assert.Equal(t, tt.expectedStatusCode, res.Code)
+			assert.JSONEq(t, tt.expectedBody, res.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -160,7 +160,7 @@ func (h *ProductHandlerDefault) Create(w http.ResponseWriter, r *http.Request) {
 // @Failure 409 {object} resterr.RestErr "Product code already exists"
 // @Failure 422 {object} resterr.RestErr "All fields must be valid and filled"
 // @Failure 500 {object} resterr.RestErr "Internal server error"
-// @Failure 400 {object} resterr.RestErr "Invalid request body"
+// @Failure 400 {object} resterr.RestErr "Invalid request body or stored batches not compatible with the product type"
 // @Failure 404 {object} resterr.RestErr "Seller or Product Type not exists"
 // @Failure 409 {object} resterr.RestErr "Product code already exists"
 // @Failure 422 {object} resterr.RestErr "All fields must be valid and filled"
@@ -186,7 +186,19 @@ func (h *ProductHandlerDefault) Update(w http.ResponseWriter, r *http.Request) {
 	updatedProduct, err := h.s.Update(product)
 
 	if err != nil {
-		if errors.Is(err, internal.ErrSellerIdNotFound) || errors.Is(err, internal.ErrProductTypeIDNotFound) || errors.Is(err, internal.ErrProductNotFound) {
+		var domainError internal.DomainError
+
+		if errors.As(err, &domainError) {
+			var restCauses []resterr.Causes
+			for _, cause := range domainError.Causes {
+				restCauses = append(restCauses, resterr.Causes{
+					Field:   cause.Field,
+					Message: cause.Message,
+				})
+			}
+
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+		} else if errors.Is(err, internal.ErrSellerIdNotFound) || errors.Is(err, internal.ErrProductTypeIDNotFound) || errors.Is(err, internal.ErrProductNotFound) {
 			response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
 		} else if errors.Is(err, internal.ErrProductCodeAlreadyExists) || errors.Is(err, internal.ErrProductConflit) || errors.Is(err, internal.ErrProductConflitEntity) {
 			response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
@@ -205,8 +217,8 @@ func (h *ProductHandlerDefault) Update(w http.ResponseWriter, r *http.Request) {
 }
 
 // Delete godoc
-// @Summary Delete a product
-// @Description Deletes a product by itsID
+// @Summary Offboard a product
+// @Description Offboards a product by itsID, its records, batches and orders are kept
 // @Tags Product
 // @Accept json
 // @Produce json
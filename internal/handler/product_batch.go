@@ -5,6 +5,8 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/bootcamp-go/web/response"
 	"github.com/go-chi/chi/v5"
@@ -22,6 +24,11 @@ type ProductBatchHandler struct {
 	sv internal.ProductBatchService
 }
 
+// RequestProductBatchMoveJSON is a struct that represents a request to store a product batch in another section
+type RequestProductBatchMoveJSON struct {
+	SectionID *int `json:"section_id"`
+}
+
 type RequestProductBatchJSON struct {
 	BatchNumber        int     `json:"batch_number"`
 	CurrentQuantity    int     `json:"current_quantity"`
@@ -83,7 +90,8 @@ func (h *ProductBatchHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 // @Param product_batch body RequestProductBatchJSON true "Product batch details"
 // @Success 201 {object} map[string]any "Created product batch"
 // @Failure 400 {object} resterr.RestErr "Invalid input format"
-// @Failure 409 {object} resterr.RestErr "Product-batch with given product-batch number already registered" or "Product-batch already exists"
+// @Failure 400 {object} resterr.RestErr "Section capacity exceeded"
+// @Failure 409 {object} resterr.RestErr "Product-batch with given product-batch number already registered" or "Product-batch already exists" or "Product or seller is not active"
 // @Failure 422 {object} resterr.RestErr "Couldn't parse product-batch"
 // @Router /api/v1/product_batches [post]
 func (h *ProductBatchHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -122,9 +130,23 @@ func (h *ProductBatchHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	err := h.sv.Save(&prodBatch)
 	if err != nil {
-		if errors.Is(err, internal.ErrProductBatchAlreadyExists) || errors.Is(err, internal.ErrProductBatchNumberAlreadyInUse) {
+		var domainError internal.DomainError
+
+		switch {
+		case errors.As(err, &domainError):
+			var restCauses []resterr.Causes
+			for _, cause := range domainError.Causes {
+				restCauses = append(restCauses, resterr.Causes{
+					Field:   cause.Field,
+					Message: cause.Message,
+				})
+			}
+
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+		case errors.Is(err, internal.ErrProductBatchAlreadyExists) || errors.Is(err, internal.ErrProductBatchNumberAlreadyInUse),
+			errors.Is(err, internal.ErrProductNotActive) || errors.Is(err, internal.ErrSellerNotActive):
 			response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
-		} else {
+		default:
 			response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityError(err.Error()))
 		}
 
@@ -135,3 +157,221 @@ func (h *ProductBatchHandler) Create(w http.ResponseWriter, r *http.Request) {
 		"data": prodBatch,
 	})
 }
+
+// Move godoc
+// @Summary Move a product batch to another section
+// @Description Store the batch in another section. Its quantity is moved between the section capacities and the target section must have room for it.
+// @Tags ProductBatch
+// @Accept json
+// @Produce json
+// @Param id path int true "Product Batch ID"
+// @Param request body RequestProductBatchMoveJSON true "Target section"
+// @Success 200 {object} map[string]any "Moved product batch"
+// @Failure 400 {object} resterr.RestErr "Invalid data or section capacity exceeded"
+// @Failure 404 {object} resterr.RestErr "Product-batch not found"
+// @Failure 409 {object} resterr.RestErr "Section not found"
+// @Failure 422 {object} resterr.RestErr "section_id is required"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/product-batches/{id}/section [patch]
+func (h *ProductBatchHandler) Move(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+		return
+	}
+
+	var requestInput RequestProductBatchMoveJSON
+	if err := json.NewDecoder(r.Body).Decode(&requestInput); err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+		return
+	}
+
+	if requestInput.SectionID == nil {
+		response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityError("section_id is required"))
+		return
+	}
+
+	prodBatch, err := h.sv.Move(id, *requestInput.SectionID)
+	if err != nil {
+		var domainError internal.DomainError
+
+		switch {
+		case errors.As(err, &domainError):
+			var restCauses []resterr.Causes
+			for _, cause := range domainError.Causes {
+				restCauses = append(restCauses, resterr.Causes{
+					Field:   cause.Field,
+					Message: cause.Message,
+				})
+			}
+
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+		case errors.Is(err, internal.ErrProductBatchNotFound):
+			response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+		case errors.Is(err, internal.ErrSectionNotFound):
+			response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+		default:
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+		}
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]any{
+		"data": prodBatch,
+	})
+}
+
+// Delete godoc
+// @Summary Delete a product batch
+// @Description Delete a product batch without history and free its quantity from the section capacity
+// @Tags ProductBatch
+// @Produce json
+// @Param id path int true "Product Batch ID"
+// @Success 204 {object} nil "No Content"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Product-batch not found"
+// @Failure 409 {object} resterr.RestErr "Product-batch has stock movements, holds, reservations, transfers or recalls"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/product-batches/{id} [delete]
+func (h *ProductBatchHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+		return
+	}
+
+	err = h.sv.Delete(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, internal.ErrProductBatchNotFound):
+			response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+		case errors.Is(err, internal.ErrProductBatchHasHistory):
+			response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+		default:
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+		}
+
+		return
+	}
+
+	response.JSON(w, http.StatusNoContent, nil)
+}
+
+// GetExpiring godoc
+// @Summary Get product batches close to their due date
+// @Description List the batches with stock left that expire within the given window, grouped by warehouse and section
+// @Tags ProductBatch
+// @Produce json
+// @Param within query string false "Window ahead of today, in days (7d) or as a duration (36h). Defaults to 7d"
+// @Param warehouse_id query int false "Warehouse Id"
+// @Success 200 {object} []internal.ProductBatchExpiryGroup "Expiring batches by section"
+// @Failure 400 {object} resterr.RestErr "Invalid within or warehouse_id"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/product-batches/expiring [get]
+func (h *ProductBatchHandler) GetExpiring(w http.ResponseWriter, r *http.Request) {
+	within := internal.ProductBatchNearExpiryWindow
+	if raw := r.URL.Query().Get("within"); raw != "" {
+		var err error
+
+		within, err = parseWithin(raw)
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError("within must be a positive number of days (7d) or a duration (36h)"))
+			return
+		}
+	}
+
+	warehouseID, err := parseOptionalWarehouseID(r)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(err.Error()))
+		return
+	}
+
+	groups, err := h.sv.FindExpiring(within, warehouseID)
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+		return
+	}
+
+	if groups == nil {
+		groups = []internal.ProductBatchExpiryGroup{}
+	}
+
+	response.JSON(w, http.StatusOK, map[string]any{
+		"data": groups,
+	})
+}
+
+// GetExpired godoc
+// @Summary Get expired product batches
+// @Description List the batches past their due date that still have stock, grouped by warehouse and section
+// @Tags ProductBatch
+// @Produce json
+// @Param warehouse_id query int false "Warehouse Id"
+// @Success 200 {object} []internal.ProductBatchExpiryGroup "Expired batches by section"
+// @Failure 400 {object} resterr.RestErr "Invalid warehouse_id"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/product-batches/expired [get]
+func (h *ProductBatchHandler) GetExpired(w http.ResponseWriter, r *http.Request) {
+	warehouseID, err := parseOptionalWarehouseID(r)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(err.Error()))
+		return
+	}
+
+	groups, err := h.sv.FindExpired(warehouseID)
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+		return
+	}
+
+	if groups == nil {
+		groups = []internal.ProductBatchExpiryGroup{}
+	}
+
+	response.JSON(w, http.StatusOK, map[string]any{
+		"data": groups,
+	})
+}
+
+// parseWithin reads a window given in days ("7d") or as a Go duration ("36h")
+func parseWithin(raw string) (time.Duration, error) {
+	var within time.Duration
+
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+
+		within = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+
+		within, err = time.ParseDuration(raw)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if within <= 0 {
+		return 0, errors.New("within must be positive")
+	}
+
+	return within, nil
+}
+
+// parseOptionalWarehouseID reads the warehouse_id query param, 0 when absent
+func parseOptionalWarehouseID(r *http.Request) (int, error) {
+	raw := r.URL.Query().Get("warehouse_id")
+	if raw == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.Atoi(raw)
+	if err != nil || id <= 0 {
+		return 0, errors.New("warehouse_id must be a positive number")
+	}
+
+	return id, nil
+}
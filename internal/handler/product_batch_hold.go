@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
+)
+
+// ProductBatchHoldJSON is a struct that represents a product batch status change in JSON format
+type ProductBatchHoldJSON struct {
+	ID             int    `json:"id"`
+	ProductBatchID int    `json:"product_batch_id"`
+	FromStatus     string `json:"from_status"`
+	ToStatus       string `json:"to_status"`
+	Reason         string `json:"reason"`
+	EmployeeID     int    `json:"employee_id"`
+	CreatedAt      string `json:"created_at"`
+}
+
+// ProductBatchHoldRequest is a struct that represents a request to hold or release a product batch.
+// Status is only read when holding and defaults to on_hold.
+type ProductBatchHoldRequest struct {
+	Status     string  `json:"status"`
+	Reason     *string `json:"reason"`
+	EmployeeID *int    `json:"employee_id"`
+}
+
+// NewProductBatchHoldHandler creates a new instance of the product batch hold handler
+func NewProductBatchHoldHandler(sv internal.ProductBatchHoldService) *ProductBatchHoldHandler {
+	return &ProductBatchHoldHandler{
+		sv: sv,
+	}
+}
+
+// ProductBatchHoldHandler is the default implementation of the product batch hold handler
+type ProductBatchHoldHandler struct {
+	sv internal.ProductBatchHoldService
+}
+
+// GetAll returns the status changes of a product batch
+// @Summary Get the status changes of a product batch
+// @Description Retrieve the holds, quarantines and releases of a product batch
+// @Tags ProductBatchHold
+// @Produce json
+// @Param id path int true "Product Batch ID"
+// @Success 200 {object} []handler.ProductBatchHoldJSON "List of status changes"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Product-batch not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/product-batches/{id}/holds [get]
+func (h *ProductBatchHoldHandler) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		holds, err := h.sv.FindByProductBatchID(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrProductBatchNotFound):
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+			default:
+				response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			}
+
+			return
+		}
+
+		data := make([]ProductBatchHoldJSON, 0, len(holds))
+		for _, hold := range holds {
+			data = append(data, newProductBatchHoldJSON(hold))
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": data,
+		})
+	}
+}
+
+// Hold puts a product batch on hold or in quarantine
+// @Summary Hold a product batch
+// @Description Put a product batch on hold or in quarantine. Held batches cannot be picked and their open reservations are dropped.
+// @Tags ProductBatchHold
+// @Accept json
+// @Produce json
+// @Param id path int true "Product Batch ID"
+// @Param request body handler.ProductBatchHoldRequest true "Status (on_hold or quarantined), reason and employee"
+// @Success 201 {object} handler.ProductBatchHoldJSON "Recorded status change"
+// @Failure 400 {object} resterr.RestErr "Invalid data"
+// @Failure 404 {object} resterr.RestErr "Product-batch not found"
+// @Failure 409 {object} resterr.RestErr "Status transition is not allowed or employee not found"
+// @Failure 422 {object} resterr.RestErr "Product-batch hold inputs are missing"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/product-batches/{id}/hold [post]
+func (h *ProductBatchHoldHandler) Hold() http.HandlerFunc {
+	return h.change(func(hold *internal.ProductBatchHold, request *ProductBatchHoldRequest) error {
+		hold.ToStatus = request.Status
+		if hold.ToStatus == "" {
+			hold.ToStatus = internal.ProductBatchStatusOnHold
+		}
+
+		return h.sv.Hold(hold)
+	})
+}
+
+// Release makes a product batch available for picking again
+// @Summary Release a product batch
+// @Description Release a product batch that is on hold or quarantined
+// @Tags ProductBatchHold
+// @Accept json
+// @Produce json
+// @Param id path int true "Product Batch ID"
+// @Param request body handler.ProductBatchHoldRequest true "Reason and employee"
+// @Success 201 {object} handler.ProductBatchHoldJSON "Recorded status change"
+// @Failure 400 {object} resterr.RestErr "Invalid data"
+// @Failure 404 {object} resterr.RestErr "Product-batch not found"
+// @Failure 409 {object} resterr.RestErr "Status transition is not allowed or employee not found"
+// @Failure 422 {object} resterr.RestErr "Product-batch hold inputs are missing"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/product-batches/{id}/release [post]
+func (h *ProductBatchHoldHandler) Release() http.HandlerFunc {
+	return h.change(func(hold *internal.ProductBatchHold, _ *ProductBatchHoldRequest) error {
+		return h.sv.Release(hold)
+	})
+}
+
+// change decodes and validates a status change request and applies it with the given function
+func (h *ProductBatchHoldHandler) change(apply func(hold *internal.ProductBatchHold, request *ProductBatchHoldRequest) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		var requestInput ProductBatchHoldRequest
+
+		// decoding the request
+		if err := json.NewDecoder(r.Body).Decode(&requestInput); err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+			return
+		}
+
+		// validating the request input required fields
+		causes := requestInput.ValidateRequiredFields()
+		if len(causes) > 0 {
+			response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityWithCausesError(internal.ErrProductBatchHoldUnprocessableEntity.Error(), causes))
+			return
+		}
+
+		hold := &internal.ProductBatchHold{
+			ProductBatchID: id,
+			Reason:         *requestInput.Reason,
+			EmployeeID:     *requestInput.EmployeeID,
+		}
+
+		if err := apply(hold, &requestInput); err != nil {
+			var domainError internal.DomainError
+
+			switch {
+			case errors.As(err, &domainError):
+				var restCauses []resterr.Causes
+				for _, cause := range domainError.Causes {
+					restCauses = append(restCauses, resterr.Causes{
+						Field:   cause.Field,
+						Message: cause.Message,
+					})
+				}
+
+				response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+			case errors.Is(err, internal.ErrProductBatchNotFound):
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+			case errors.Is(err, internal.ErrProductBatchHoldInvalidTransition), errors.Is(err, internal.ErrEmployeeNotFound):
+				response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+			default:
+				response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			}
+
+			return
+		}
+
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"data": newProductBatchHoldJSON(*hold),
+		})
+	}
+}
+
+// Validating the ProductBatchHoldRequest required fields
+func (p *ProductBatchHoldRequest) ValidateRequiredFields() (causes []resterr.Causes) {
+	if p.Reason == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "reason",
+			Message: "reason is required",
+		})
+	}
+	if p.EmployeeID == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "employee_id",
+			Message: "employee id is required",
+		})
+	}
+	return
+}
+
+func newProductBatchHoldJSON(h internal.ProductBatchHold) ProductBatchHoldJSON {
+	return ProductBatchHoldJSON{
+		ID:             h.ID,
+		ProductBatchID: h.ProductBatchID,
+		FromStatus:     h.FromStatus,
+		ToStatus:       h.ToStatus,
+		Reason:         h.Reason,
+		EmployeeID:     h.EmployeeID,
+		CreatedAt:      h.CreatedAt.Format(time.DateTime),
+	}
+}
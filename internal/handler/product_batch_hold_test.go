@@ -0,0 +1,219 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/handler"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewProductBatchHoldServiceMock() *ProductBatchHoldServiceMock {
+	return &ProductBatchHoldServiceMock{}
+}
+
+type ProductBatchHoldServiceMock struct {
+	mock.Mock
+}
+
+func (m *ProductBatchHoldServiceMock) FindByProductBatchID(productBatchID int) ([]internal.ProductBatchHold, error) {
+	args := m.Called(productBatchID)
+	return args.Get(0).([]internal.ProductBatchHold), args.Error(1)
+}
+
+func (m *ProductBatchHoldServiceMock) Hold(h *internal.ProductBatchHold) error {
+	args := m.Called(h)
+	return args.Error(0)
+}
+
+func (m *ProductBatchHoldServiceMock) Release(h *internal.ProductBatchHold) error {
+	args := m.Called(h)
+	return args.Error(0)
+}
+
+func TestProductBatchHold_Hold(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		description       string
+		id                string
+		body              string
+		expectedBody      string
+		expectedCode      int
+		mock              func() *ProductBatchHoldServiceMock
+		expectedMockCalls int
+	}{
+		{
+			description:  "case 1 - success: Put a batch on hold by default",
+			id:           "1",
+			body:         `{"reason": "supplier recall", "employee_id": 2}`,
+			expectedBody: `{"data":{"id":1,"product_batch_id":1,"from_status":"released","to_status":"on_hold","reason":"supplier recall","employee_id":2,"created_at":"2025-01-01 10:00:00"}}`,
+			expectedCode: http.StatusCreated,
+			mock: func() *ProductBatchHoldServiceMock {
+				mk := NewProductBatchHoldServiceMock()
+				mk.On("Hold", mock.MatchedBy(func(h *internal.ProductBatchHold) bool {
+					return h.ToStatus == internal.ProductBatchStatusOnHold
+				})).Run(func(args mock.Arguments) {
+					h := args.Get(0).(*internal.ProductBatchHold)
+					h.ID = 1
+					h.FromStatus = internal.ProductBatchStatusReleased
+					h.CreatedAt = createdAt
+				}).Return(nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 2 - error: Missing required fields",
+			id:           "1",
+			body:         `{"status": "quarantined"}`,
+			expectedBody: `{"message":"product-batch hold inputs are missing","error":"unprocessable_entity","code":422,"causes":[{"field":"reason","message":"reason is required"},{"field":"employee_id","message":"employee id is required"}]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			mock: func() *ProductBatchHoldServiceMock {
+				return NewProductBatchHoldServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+		{
+			description:  "case 3 - error: Transition not allowed",
+			id:           "1",
+			body:         `{"status": "on_hold", "reason": "supplier recall", "employee_id": 2}`,
+			expectedBody: `{"message":"product-batch status transition is not allowed","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *ProductBatchHoldServiceMock {
+				mk := NewProductBatchHoldServiceMock()
+				mk.On("Hold", mock.Anything).Return(internal.ErrProductBatchHoldInvalidTransition)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 4 - error: Product batch not found",
+			id:           "99",
+			body:         `{"reason": "supplier recall", "employee_id": 2}`,
+			expectedBody: `{"message":"product-batch not found","error":"not_found","code":404,"causes":null}`,
+			expectedCode: http.StatusNotFound,
+			mock: func() *ProductBatchHoldServiceMock {
+				mk := NewProductBatchHoldServiceMock()
+				mk.On("Hold", mock.Anything).Return(internal.ErrProductBatchNotFound)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 5 - error: Invalid id",
+			id:           "abc",
+			body:         `{"reason": "supplier recall", "employee_id": 2}`,
+			expectedBody: `{"message":"Invalid ID format","error":"bad_request","code":400,"causes":null}`,
+			expectedCode: http.StatusBadRequest,
+			mock: func() *ProductBatchHoldServiceMock {
+				return NewProductBatchHoldServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewProductBatchHoldHandler(sv)
+
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/product-batches/"+tc.id+"/hold", strings.NewReader(tc.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+			response := httptest.NewRecorder()
+
+			hd.Hold()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+			sv.AssertNumberOfCalls(t, "Hold", tc.expectedMockCalls)
+		})
+	}
+}
+
+func TestProductBatchHold_Release(t *testing.T) {
+	t.Run("case 1 - success: Release a quarantined batch", func(t *testing.T) {
+		sv := NewProductBatchHoldServiceMock()
+		sv.On("Release", mock.AnythingOfType("*internal.ProductBatchHold")).Run(func(args mock.Arguments) {
+			h := args.Get(0).(*internal.ProductBatchHold)
+			h.ID = 2
+			h.FromStatus = internal.ProductBatchStatusQuarantined
+			h.ToStatus = internal.ProductBatchStatusReleased
+			h.CreatedAt = time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+		}).Return(nil)
+		hd := handler.NewProductBatchHoldHandler(sv)
+
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/product-batches/1/release", strings.NewReader(`{"reason": "lab results ok", "employee_id": 2}`))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.Release()(response, request)
+
+		require.Equal(t, http.StatusCreated, response.Code)
+		require.JSONEq(t, `{"data":{"id":2,"product_batch_id":1,"from_status":"quarantined","to_status":"released","reason":"lab results ok","employee_id":2,"created_at":"2025-01-02 10:00:00"}}`, response.Body.String())
+	})
+
+	t.Run("case 2 - error: Employee not found", func(t *testing.T) {
+		sv := NewProductBatchHoldServiceMock()
+		sv.On("Release", mock.Anything).Return(internal.ErrEmployeeNotFound)
+		hd := handler.NewProductBatchHoldHandler(sv)
+
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/product-batches/1/release", strings.NewReader(`{"reason": "lab results ok", "employee_id": 99}`))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.Release()(response, request)
+
+		require.Equal(t, http.StatusConflict, response.Code)
+	})
+}
+
+func TestProductBatchHold_GetAll(t *testing.T) {
+	t.Run("case 1 - success: List the status changes of a batch", func(t *testing.T) {
+		sv := NewProductBatchHoldServiceMock()
+		sv.On("FindByProductBatchID", 1).Return([]internal.ProductBatchHold{
+			{ID: 1, ProductBatchID: 1, FromStatus: "released", ToStatus: "on_hold", Reason: "supplier recall", EmployeeID: 2, CreatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)},
+		}, nil)
+		hd := handler.NewProductBatchHoldHandler(sv)
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/product-batches/1/holds", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.GetAll()(response, request)
+
+		require.Equal(t, http.StatusOK, response.Code)
+		require.JSONEq(t, `{"data":[{"id":1,"product_batch_id":1,"from_status":"released","to_status":"on_hold","reason":"supplier recall","employee_id":2,"created_at":"2025-01-01 10:00:00"}]}`, response.Body.String())
+	})
+
+	t.Run("case 2 - error: Product batch not found", func(t *testing.T) {
+		sv := NewProductBatchHoldServiceMock()
+		sv.On("FindByProductBatchID", 1).Return([]internal.ProductBatchHold{}, internal.ErrProductBatchNotFound)
+		hd := handler.NewProductBatchHoldHandler(sv)
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/product-batches/1/holds", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.GetAll()(response, request)
+
+		require.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
@@ -7,7 +7,9 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/meli-fresh-products-api-backend-t1/internal"
@@ -48,6 +50,26 @@ func (m *MockProductBatchService) Save(prodBatch *internal.ProductBatch) error {
 	return args.Error(0)
 }
 
+func (m *MockProductBatchService) FindExpiring(within time.Duration, warehouseID int) ([]internal.ProductBatchExpiryGroup, error) {
+	args := m.Called(within, warehouseID)
+	return args.Get(0).([]internal.ProductBatchExpiryGroup), args.Error(1)
+}
+
+func (m *MockProductBatchService) FindExpired(warehouseID int) ([]internal.ProductBatchExpiryGroup, error) {
+	args := m.Called(warehouseID)
+	return args.Get(0).([]internal.ProductBatchExpiryGroup), args.Error(1)
+}
+
+func (m *MockProductBatchService) Move(id int, sectionID int) (internal.ProductBatch, error) {
+	args := m.Called(id, sectionID)
+	return args.Get(0).(internal.ProductBatch), args.Error(1)
+}
+
+func (m *MockProductBatchService) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 func TestHandler_CreateProductBatchUnitTest(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -155,6 +177,26 @@ func TestHandler_CreateProductBatchUnitTest(t *testing.T) {
 			expectedStatusCode: http.StatusConflict,
 			expectedResponse:   *resterr.NewConflictError("product-batch with given product-batch number already registered"),
 		},
+		{
+			name: "return conflict error when the seller of the product is not active",
+			mockSetup: func(m *MockProductBatchService) {
+				m.On("Save", mock.Anything).Return(internal.ErrSellerNotActive)
+			},
+			requestBody: handler.RequestProductBatchJSON{
+				BatchNumber:        1234,
+				CurrentQuantity:    100,
+				CurrentTemperature: 40.5,
+				DueDate:            "2022-01-08",
+				InitialQuantity:    120,
+				ManufacturingDate:  "2022-01-01 ",
+				ManufacturingHour:  15,
+				MinumumTemperature: -8,
+				ProductID:          1,
+				SectionID:          3,
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   *resterr.NewConflictError("seller is not active"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -324,3 +366,232 @@ func TestHandler_ReadByIdProductBatchUnitTest(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_ExpiringProductBatchUnitTest(t *testing.T) {
+	group := internal.ProductBatchExpiryGroup{
+		WarehouseID:       1,
+		WarehouseCode:     "WH-1",
+		SectionID:         2,
+		SectionNumber:     10,
+		RemainingQuantity: 40,
+		Batches: []internal.ProductBatch{
+			{ID: 3, BatchNumber: 7, CurrentQuantity: 40, DueDate: "2025-01-03", ProductID: 1, SectionID: 2, Status: internal.ProductBatchStatusReleased, ExpiryStatus: internal.ProductBatchExpiryNearExpiry},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		url                string
+		mockSetup          func(*MockProductBatchService)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "should list the batches expiring within the default window",
+			url:  "/api/v1/product-batches/expiring",
+			mockSetup: func(m *MockProductBatchService) {
+				m.On("FindExpiring", 7*24*time.Hour, 0).Return([]internal.ProductBatchExpiryGroup{group}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":[{"warehouse_id":1,"warehouse_code":"WH-1","section_id":2,"section_number":10,"remaining_quantity":40,"batches":[{"id":3,"batch_number":7,"current_quantity":40,"current_temperature":0,"due_date":"2025-01-03","initial_quantity":0,"manufacturing_date":"","manufacturing_hour":0,"minumum_temperature":0,"product_id":1,"section_id":2,"status":"released","expiry_status":"near-expiry"}]}]}`,
+		},
+		{
+			name: "should filter by window and warehouse",
+			url:  "/api/v1/product-batches/expiring?within=3d&warehouse_id=1",
+			mockSetup: func(m *MockProductBatchService) {
+				m.On("FindExpiring", 3*24*time.Hour, 1).Return([]internal.ProductBatchExpiryGroup{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":[]}`,
+		},
+		{
+			name:               "should reject an invalid window",
+			url:                "/api/v1/product-batches/expiring?within=-2d",
+			mockSetup:          func(m *MockProductBatchService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"message":"within must be a positive number of days (7d) or a duration (36h)","error":"bad_request","code":400,"causes":null}`,
+		},
+		{
+			name:               "should reject an invalid warehouse",
+			url:                "/api/v1/product-batches/expiring?warehouse_id=abc",
+			mockSetup:          func(m *MockProductBatchService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"message":"warehouse_id must be a positive number","error":"bad_request","code":400,"causes":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockProductBatchService)
+			tt.mockSetup(mockService)
+			hd := handler.NewHandlerProductBatch(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			rr := httptest.NewRecorder()
+
+			hd.GetExpiring(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestHandler_ExpiredProductBatchUnitTest(t *testing.T) {
+	t.Run("should list the expired batches of a warehouse", func(t *testing.T) {
+		mockService := new(MockProductBatchService)
+		mockService.On("FindExpired", 2).Return([]internal.ProductBatchExpiryGroup{
+			{WarehouseID: 2, WarehouseCode: "WH-2", SectionID: 5, SectionNumber: 1, RemainingQuantity: 0},
+		}, nil)
+		hd := handler.NewHandlerProductBatch(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/product-batches/expired?warehouse_id=2", nil)
+		rr := httptest.NewRecorder()
+
+		hd.GetExpired(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"data":[{"warehouse_id":2,"warehouse_code":"WH-2","section_id":5,"section_number":1,"remaining_quantity":0,"batches":null}]}`, rr.Body.String())
+	})
+
+	t.Run("should return internal server error", func(t *testing.T) {
+		mockService := new(MockProductBatchService)
+		mockService.On("FindExpired", 0).Return([]internal.ProductBatchExpiryGroup{}, errors.New("internal server error"))
+		hd := handler.NewHandlerProductBatch(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/product-batches/expired", nil)
+		rr := httptest.NewRecorder()
+
+		hd.GetExpired(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestHandler_MoveProductBatchUnitTest(t *testing.T) {
+	tests := []struct {
+		name               string
+		id                 string
+		body               string
+		mockSetup          func(*MockProductBatchService)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "successfully move the batch",
+			id:   "1",
+			body: `{"section_id": 4}`,
+			mockSetup: func(m *MockProductBatchService) {
+				m.On("Move", 1, 4).Return(internal.ProductBatch{ID: 1, BatchNumber: 10, CurrentQuantity: 40, DueDate: "2022-01-08", SectionID: 4, Status: "released"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":{"id":1,"batch_number":10,"current_quantity":40,"current_temperature":0,"due_date":"2022-01-08","initial_quantity":0,"manufacturing_date":"","manufacturing_hour":0,"minumum_temperature":0,"product_id":0,"section_id":4,"status":"released","expiry_status":""}}`,
+		},
+		{
+			name: "fail when the section is full",
+			id:   "1",
+			body: `{"section_id": 4}`,
+			mockSetup: func(m *MockProductBatchService) {
+				m.On("Move", 1, 4).Return(internal.ProductBatch{}, internal.NewSectionCapacityExceededError(4, 10, 40))
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"message":"section capacity exceeded","error":"bad_request","code":400,"causes":[{"field":"section_id","message":"section 4 has room for 10 units but 40 were requested"}]}`,
+		},
+		{
+			name: "fail when the section does not exist",
+			id:   "1",
+			body: `{"section_id": 99}`,
+			mockSetup: func(m *MockProductBatchService) {
+				m.On("Move", 1, 99).Return(internal.ProductBatch{}, internal.ErrSectionNotFound)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedBody:       `{"message":"section not found","error":"conflict","code":409,"causes":null}`,
+		},
+		{
+			name:               "fail when section_id is missing",
+			id:                 "1",
+			body:               `{}`,
+			mockSetup:          func(m *MockProductBatchService) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedBody:       `{"message":"section_id is required","error":"unprocessable_entity","code":422,"causes":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockProductBatchService)
+			tt.mockSetup(mockService)
+			hd := handler.NewHandlerProductBatch(mockService)
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/product-batches/"+tt.id+"/section", strings.NewReader(tt.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rr := httptest.NewRecorder()
+
+			hd.Move(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestHandler_DeleteProductBatchUnitTest(t *testing.T) {
+	tests := []struct {
+		name               string
+		id                 string
+		mockSetup          func(*MockProductBatchService)
+		expectedStatusCode int
+	}{
+		{
+			name: "successfully delete the batch",
+			id:   "1",
+			mockSetup: func(m *MockProductBatchService) {
+				m.On("Delete", 1).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name: "fail when the batch does not exist",
+			id:   "99",
+			mockSetup: func(m *MockProductBatchService) {
+				m.On("Delete", 99).Return(internal.ErrProductBatchNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "fail when the batch has history",
+			id:   "1",
+			mockSetup: func(m *MockProductBatchService) {
+				m.On("Delete", 1).Return(internal.ErrProductBatchHasHistory)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:               "fail with an invalid id",
+			id:                 "abc",
+			mockSetup:          func(m *MockProductBatchService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockProductBatchService)
+			tt.mockSetup(mockService)
+			hd := handler.NewHandlerProductBatch(mockService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/product-batches/"+tt.id, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rr := httptest.NewRecorder()
+
+			hd.Delete(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
 )
@@ -68,3 +71,99 @@ func (h *ProductRecordsHandlerDefault) Create(w http.ResponseWriter, r *http.Req
 		"data": productRecJSON,
 	})
 }
+
+// GetPrices returns the price timeline of a product.
+// GetPrices godoc
+// @Summary Get the price history of a product
+// @Description Lists the purchase and sale prices recorded for the product with their margin, oldest first
+// @Tags ProductRecords
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param from query string false "Prices recorded at or after this moment (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "Prices recorded at or before this moment (RFC 3339 or YYYY-MM-DD, the whole day is included)"
+// @Success 200 {object} []internal.ProductPrice "Price timeline"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format or time range"
+// @Failure 404 {object} resterr.RestErr "Product not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/products/{id}/prices [get]
+func (h *ProductRecordsHandlerDefault) GetPrices(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+		return
+	}
+
+	from, to, causes := parseTimeRange(r)
+	if len(causes) > 0 {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(ErrInvalidData, causes))
+		return
+	}
+
+	prices, err := h.s.GetPrices(id, from, to)
+	if err != nil {
+		if errors.Is(err, internal.ErrProductNotFound) {
+			response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+			return
+		}
+
+		response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]any{
+		"data": prices,
+	})
+}
+
+// GetPrice returns the price of a product effective at a moment.
+// GetPrice godoc
+// @Summary Get the effective price of a product
+// @Description Returns the latest price recorded for the product at or before the moment, with its margin
+// @Tags ProductRecords
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param at query string false "Moment of the price (RFC 3339 or YYYY-MM-DD for the end of that day), defaults to now"
+// @Success 200 {object} internal.ProductPrice "Effective price"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format or moment"
+// @Failure 404 {object} resterr.RestErr "Product not found or no price effective at the moment"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/products/{id}/price [get]
+func (h *ProductRecordsHandlerDefault) GetPrice(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+		return
+	}
+
+	at := time.Now()
+	if raw := r.URL.Query().Get("at"); raw != "" {
+		at, err = time.Parse(time.RFC3339, raw)
+		if err != nil {
+			at, err = time.Parse(time.DateOnly, raw)
+			if err != nil {
+				response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(ErrInvalidData, []resterr.Causes{{
+					Field:   "at",
+					Message: "invalid date format, expected RFC 3339 or YYYY-MM-DD",
+				}}))
+				return
+			}
+
+			at = at.Add(24*time.Hour - time.Second)
+		}
+	}
+
+	price, err := h.s.GetEffectivePrice(id, at)
+	if err != nil {
+		if errors.Is(err, internal.ErrProductNotFound) || errors.Is(err, internal.ErrProductPriceNotFound) {
+			response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+			return
+		}
+
+		response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]any{
+		"data": price,
+	})
+}
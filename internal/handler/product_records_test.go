@@ -2,8 +2,10 @@ package handler_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/go-chi/chi/v5"
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 	"github.com/meli-fresh-products-api-backend-t1/internal/handler"
 	"github.com/stretchr/testify/assert"
@@ -35,6 +37,16 @@ func (m *MockProductRecordsService) GetByID(id int) (internal.ProductRecords, er
 	return args.Get(0).(internal.ProductRecords), args.Error(1)
 }
 
+func (m *MockProductRecordsService) GetPrices(productID int, from time.Time, to time.Time) ([]internal.ProductPrice, error) {
+	args := m.Called(productID, from, to)
+	return args.Get(0).([]internal.ProductPrice), args.Error(1)
+}
+
+func (m *MockProductRecordsService) GetEffectivePrice(productID int, at time.Time) (internal.ProductPrice, error) {
+	args := m.Called(productID, at)
+	return args.Get(0).(internal.ProductPrice), args.Error(1)
+}
+
 func Test_ProductRecordsHandler_Create(t *testing.T) {
 	type ResponseCreate struct {
 		Data internal.ProductRecords `json:"data"`
@@ -145,3 +157,161 @@ func Test_ProductRecordsHandler_Create(t *testing.T) {
 		})
 	}
 }
+
+func Test_ProductRecordsHandler_GetPrices(t *testing.T) {
+	price := internal.ProductPrice{
+		RecordID:      1,
+		ProductID:     1,
+		EffectiveFrom: time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC),
+		PurchasePrice: 10,
+		SalePrice:     15,
+		Margin:        5,
+		MarginPercent: 33.33,
+	}
+
+	tests := []struct {
+		name           string
+		id             string
+		query          string
+		mockSetup      func(*MockProductRecordsService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "success - prices of a month",
+			id:    "1",
+			query: "?from=2024-01-01&to=2024-01-31",
+			mockSetup: func(p *MockProductRecordsService) {
+				from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+				to := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+				p.On("GetPrices", 1, from, to).Return([]internal.ProductPrice{price}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[{"record_id":1,"product_id":1,"effective_from":"2024-01-10T08:00:00Z","purchase_price":10,"sale_price":15,"margin":5,"margin_percent":33.33}]}`,
+		},
+		{
+			name:           "error - from after to",
+			id:             "1",
+			query:          "?from=2024-02-01&to=2024-01-01",
+			mockSetup:      func(p *MockProductRecordsService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Invalid data","error":"bad_request","code":400,"causes":[{"field":"from","message":"from must not be after to"}]}`,
+		},
+		{
+			name: "error - product not found",
+			id:   "99",
+			mockSetup: func(p *MockProductRecordsService) {
+				p.On("GetPrices", 99, time.Time{}, time.Time{}).Return([]internal.ProductPrice{}, internal.ErrProductNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"product not found","error":"not_found","code":404,"causes":null}`,
+		},
+		{
+			name:           "error - invalid id",
+			id:             "abc",
+			mockSetup:      func(p *MockProductRecordsService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Invalid ID format","error":"bad_request","code":400,"causes":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockProductRecordsService)
+			productHandler := handler.NewProductRecordsDefault(mockService)
+			tt.mockSetup(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/products/"+tt.id+"/prices"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rec := httptest.NewRecorder()
+
+			productHandler.GetPrices(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_ProductRecordsHandler_GetPrice(t *testing.T) {
+	price := internal.ProductPrice{
+		RecordID:      2,
+		ProductID:     1,
+		EffectiveFrom: time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC),
+		PurchasePrice: 10,
+		SalePrice:     12.5,
+		Margin:        2.5,
+		MarginPercent: 20,
+	}
+
+	tests := []struct {
+		name           string
+		id             string
+		query          string
+		mockSetup      func(*MockProductRecordsService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "success - price at a moment",
+			id:    "1",
+			query: "?at=2024-01-15T12:00:00Z",
+			mockSetup: func(p *MockProductRecordsService) {
+				p.On("GetEffectivePrice", 1, time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)).Return(price, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"record_id":2,"product_id":1,"effective_from":"2024-01-10T08:00:00Z","purchase_price":10,"sale_price":12.5,"margin":2.5,"margin_percent":20}}`,
+		},
+		{
+			name:  "success - a date is the end of the day",
+			id:    "1",
+			query: "?at=2024-01-15",
+			mockSetup: func(p *MockProductRecordsService) {
+				p.On("GetEffectivePrice", 1, time.Date(2024, 1, 15, 23, 59, 59, 0, time.UTC)).Return(price, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"record_id":2,"product_id":1,"effective_from":"2024-01-10T08:00:00Z","purchase_price":10,"sale_price":12.5,"margin":2.5,"margin_percent":20}}`,
+		},
+		{
+			name:           "error - invalid moment",
+			id:             "1",
+			query:          "?at=yesterday",
+			mockSetup:      func(p *MockProductRecordsService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Invalid data","error":"bad_request","code":400,"causes":[{"field":"at","message":"invalid date format, expected RFC 3339 or YYYY-MM-DD"}]}`,
+		},
+		{
+			name:  "error - no price effective at the moment",
+			id:    "1",
+			query: "?at=2020-01-01T00:00:00Z",
+			mockSetup: func(p *MockProductRecordsService) {
+				p.On("GetEffectivePrice", 1, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)).Return(internal.ProductPrice{}, internal.ErrProductPriceNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"product has no price effective at the requested time","error":"not_found","code":404,"causes":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockProductRecordsService)
+			productHandler := handler.NewProductRecordsDefault(mockService)
+			tt.mockSetup(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/products/"+tt.id+"/price"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rec := httptest.NewRecorder()
+
+			productHandler.GetPrice(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -526,6 +526,16 @@ func Test_Update(t *testing.T) {
 			expectedStatus:   http.StatusNotFound,
 			expectedResponse: ResponseCreate{},
 		},
+		{
+			name: "update_incompatible_batches_status_400",
+			mockSetup: func(m *MockProductService) {
+				m.On("Update", mock.Anything).Return(internal.Product{ID: 1}, internal.NewProductTypeIncompatibleError(internal.Section{ID: 3, ProductTypeID: 1}, 2))
+			},
+			requestBody:      internal.Product{ProductTypeID: 2},
+			id:               "1",
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: ResponseCreate{},
+		},
 		{
 			name: "update_conflito_status_409",
 			mockSetup: func(m *MockProductService) {
@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
+)
+
+// ProductTypeJSON is a struct that represents a product type in JSON format
+type ProductTypeJSON struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ProductTypeCreateRequest is a struct that represents a request to create a product type
+type ProductTypeCreateRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+// ProductTypeUpdateRequest is a struct that represents a request to update a product type
+type ProductTypeUpdateRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+// NewProductTypeHandler creates a new instance of the product type handler
+func NewProductTypeHandler(sv internal.ProductTypeService) *ProductTypeHandler {
+	return &ProductTypeHandler{
+		sv: sv,
+	}
+}
+
+// ProductTypeHandler is the default implementation of the product type handler
+type ProductTypeHandler struct {
+	sv internal.ProductTypeService
+}
+
+// GetAll returns all product types
+// @Summary Get all product types
+// @Description Retrieve the list of product types
+// @Tags ProductType
+// @Produce json
+// @Success 200 {object} []handler.ProductTypeJSON "List of product types"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/product-types [get]
+func (h *ProductTypeHandler) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		productTypes, err := h.sv.FindAll()
+		if err != nil {
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			return
+		}
+
+		data := []ProductTypeJSON{}
+		for _, pt := range productTypes {
+			data = append(data, productTypeToJSON(pt))
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": data,
+		})
+	}
+}
+
+// GetByID returns a product type by ID
+// @Summary Get a product type by ID
+// @Description Retrieve the product type with the given ID
+// @Tags ProductType
+// @Produce json
+// @Param id path int true "Product Type ID"
+// @Success 200 {object} handler.ProductTypeJSON "Product type"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Product-type not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/product-types/{id} [get]
+func (h *ProductTypeHandler) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		pt, err := h.sv.FindByID(id)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": productTypeToJSON(pt),
+		})
+	}
+}
+
+// Create creates a new product type
+// @Summary Create a product type
+// @Description Register a new product type
+// @Tags ProductType
+// @Accept json
+// @Produce json
+// @Param request body handler.ProductTypeCreateRequest true "Product type"
+// @Success 201 {object} handler.ProductTypeJSON "Created product type"
+// @Failure 400 {object} resterr.RestErr "Invalid data"
+// @Failure 409 {object} resterr.RestErr "Product-type already exists"
+// @Failure 422 {object} resterr.RestErr "Product-type inputs are missing"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/product-types [post]
+func (h *ProductTypeHandler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var requestInput ProductTypeCreateRequest
+
+		// decoding the request
+		if err := json.NewDecoder(r.Body).Decode(&requestInput); err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+			return
+		}
+
+		// validating the request input required fields
+		var causes []resterr.Causes
+
+		if requestInput.Name == nil {
+			causes = append(causes, resterr.Causes{
+				Field:   "name",
+				Message: "name is required",
+			})
+		}
+
+		if requestInput.Description == nil {
+			causes = append(causes, resterr.Causes{
+				Field:   "description",
+				Message: "description is required",
+			})
+		}
+
+		if len(causes) > 0 {
+			response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityWithCausesError(internal.ErrProductTypeUnprocessableEntity.Error(), causes))
+			return
+		}
+
+		pt := internal.ProductType{
+			Name:        *requestInput.Name,
+			Description: *requestInput.Description,
+		}
+
+		if err := h.sv.Save(&pt); err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"data": productTypeToJSON(pt),
+		})
+	}
+}
+
+// Update updates a product type
+// @Summary Update a product type
+// @Description Update the name or description of a product type
+// @Tags ProductType
+// @Accept json
+// @Produce json
+// @Param id path int true "Product Type ID"
+// @Param request body handler.ProductTypeUpdateRequest true "Product type fields to update"
+// @Success 200 {object} handler.ProductTypeJSON "Updated product type"
+// @Failure 400 {object} resterr.RestErr "Invalid data"
+// @Failure 404 {object} resterr.RestErr "Product-type not found"
+// @Failure 409 {object} resterr.RestErr "Product-type already exists"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/product-types/{id} [patch]
+func (h *ProductTypeHandler) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		var requestInput ProductTypeUpdateRequest
+
+		// decoding the request
+		if err := json.NewDecoder(r.Body).Decode(&requestInput); err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+			return
+		}
+
+		pt, err := h.sv.Update(id, internal.ProductTypePatch{
+			Name:        requestInput.Name,
+			Description: requestInput.Description,
+		})
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": productTypeToJSON(pt),
+		})
+	}
+}
+
+// Delete deletes a product type
+// @Summary Delete a product type
+// @Description Remove a product type that is no longer referenced by sections or products
+// @Tags ProductType
+// @Produce json
+// @Param id path int true "Product Type ID"
+// @Success 204 {object} nil "No Content"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Product-type not found"
+// @Failure 409 {object} resterr.RestErr "Product-type is still referenced by sections or products"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/product-types/{id} [delete]
+func (h *ProductTypeHandler) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		if err := h.sv.Delete(id); err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusNoContent, nil)
+	}
+}
+
+func (h *ProductTypeHandler) handleError(w http.ResponseWriter, err error) {
+	var domainError internal.DomainError
+
+	switch {
+	case errors.As(err, &domainError):
+		var restCauses []resterr.Causes
+		for _, cause := range domainError.Causes {
+			restCauses = append(restCauses, resterr.Causes{
+				Field:   cause.Field,
+				Message: cause.Message,
+			})
+		}
+
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+	case errors.Is(err, internal.ErrProductTypeNotFound):
+		response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+	case errors.Is(err, internal.ErrProductTypeAlreadyExists), errors.Is(err, internal.ErrProductTypeInUse):
+		response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+	default:
+		response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+	}
+}
+
+func productTypeToJSON(pt internal.ProductType) ProductTypeJSON {
+	return ProductTypeJSON{
+		ID:          pt.ID,
+		Name:        pt.Name,
+		Description: pt.Description,
+	}
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
+)
+
+// ProductTypeCompatibilityJSON is a struct that represents a compatibility rule in JSON format
+type ProductTypeCompatibilityJSON struct {
+	ProductTypeID        int `json:"product_type_id"`
+	SectionProductTypeID int `json:"section_product_type_id"`
+}
+
+// ProductTypeCompatibleSectionsJSON is a struct that represents the sections that can store a product type in JSON format
+type ProductTypeCompatibleSectionsJSON struct {
+	ProductTypeID         int                `json:"product_type_id"`
+	SectionProductTypeIDs []int              `json:"section_product_type_ids"`
+	Sections              []internal.Section `json:"sections"`
+}
+
+// ProductTypeCompatibilityCreateRequest is a struct that represents a request to create a compatibility rule
+type ProductTypeCompatibilityCreateRequest struct {
+	SectionProductTypeID *int `json:"section_product_type_id"`
+}
+
+// NewProductTypeCompatibilityHandler creates a new instance of the product type compatibility handler
+func NewProductTypeCompatibilityHandler(sv internal.ProductTypeCompatibilityService) *ProductTypeCompatibilityHandler {
+	return &ProductTypeCompatibilityHandler{
+		sv: sv,
+	}
+}
+
+// ProductTypeCompatibilityHandler is the default implementation of the product type compatibility handler
+type ProductTypeCompatibilityHandler struct {
+	sv internal.ProductTypeCompatibilityService
+}
+
+// GetAll returns the sections that can store products of a type
+// @Summary Get the sections compatible with a product type
+// @Description Retrieve the section product types that can hold products of the type and the matching sections
+// @Tags ProductTypeCompatibility
+// @Produce json
+// @Param id path int true "Product Type ID"
+// @Success 200 {object} handler.ProductTypeCompatibleSectionsJSON "Compatible sections"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Product-type not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/product-types/{id}/compatible-sections [get]
+func (h *ProductTypeCompatibilityHandler) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		compatible, err := h.sv.FindCompatibleSections(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrProductTypeNotFound):
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+			default:
+				response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			}
+
+			return
+		}
+
+		sections := compatible.Sections
+		if sections == nil {
+			sections = []internal.Section{}
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": ProductTypeCompatibleSectionsJSON{
+				ProductTypeID:         compatible.ProductTypeID,
+				SectionProductTypeIDs: compatible.SectionProductTypeIDs,
+				Sections:              sections,
+			},
+		})
+	}
+}
+
+// Create lets sections of another product type hold products of the type
+// @Summary Create a product type compatibility rule
+// @Description Allow the sections declared for another product type to store products of the type
+// @Tags ProductTypeCompatibility
+// @Accept json
+// @Produce json
+// @Param id path int true "Product Type ID"
+// @Param request body handler.ProductTypeCompatibilityCreateRequest true "Section product type"
+// @Success 201 {object} handler.ProductTypeCompatibilityJSON "Created rule"
+// @Failure 400 {object} resterr.RestErr "Invalid data"
+// @Failure 404 {object} resterr.RestErr "Product-type not found"
+// @Failure 409 {object} resterr.RestErr "Rule already exists or section product-type not found"
+// @Failure 422 {object} resterr.RestErr "Product-type compatibility inputs are missing"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/product-types/{id}/compatible-sections [post]
+func (h *ProductTypeCompatibilityHandler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		var requestInput ProductTypeCompatibilityCreateRequest
+
+		// decoding the request
+		if err := json.NewDecoder(r.Body).Decode(&requestInput); err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+			return
+		}
+
+		// validating the request input required fields
+		if requestInput.SectionProductTypeID == nil {
+			response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityWithCausesError(internal.ErrProductTypeCompatibilityUnprocessableEntity.Error(), []resterr.Causes{{
+				Field:   "section_product_type_id",
+				Message: "section product type id is required",
+			}}))
+			return
+		}
+
+		compatibility := internal.ProductTypeCompatibility{
+			ProductTypeID:        id,
+			SectionProductTypeID: *requestInput.SectionProductTypeID,
+		}
+
+		if err := h.sv.Save(compatibility); err != nil {
+			var domainError internal.DomainError
+
+			switch {
+			case errors.As(err, &domainError):
+				var restCauses []resterr.Causes
+				for _, cause := range domainError.Causes {
+					restCauses = append(restCauses, resterr.Causes{
+						Field:   cause.Field,
+						Message: cause.Message,
+					})
+				}
+
+				response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+			case errors.Is(err, internal.ErrProductTypeNotFound):
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+			case errors.Is(err, internal.ErrProductTypeCompatibilityAlreadyExists), errors.Is(err, internal.ErrProductTypeCompatibilitySectionTypeNotFound):
+				response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+			default:
+				response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			}
+
+			return
+		}
+
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"data": ProductTypeCompatibilityJSON{
+				ProductTypeID:        compatibility.ProductTypeID,
+				SectionProductTypeID: compatibility.SectionProductTypeID,
+			},
+		})
+	}
+}
+
+// Delete stops the sections of another product type from holding products of the type
+// @Summary Delete a product type compatibility rule
+// @Description Remove the rule letting the sections of another product type store products of the type
+// @Tags ProductTypeCompatibility
+// @Produce json
+// @Param id path int true "Product Type ID"
+// @Param sectionProductTypeId path int true "Section Product Type ID"
+// @Success 204 {object} nil "No Content"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Product-type compatibility not found"
+// @Failure 409 {object} resterr.RestErr "Product-type compatibility in use by stored batches"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/product-types/{id}/compatible-sections/{sectionProductTypeId} [delete]
+func (h *ProductTypeCompatibilityHandler) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		sectionProductTypeID, err := strconv.Atoi(chi.URLParam(r, "sectionProductTypeId"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		err = h.sv.Delete(internal.ProductTypeCompatibility{
+			ProductTypeID:        id,
+			SectionProductTypeID: sectionProductTypeID,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrProductTypeCompatibilityNotFound):
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+			case errors.Is(err, internal.ErrProductTypeCompatibilityInUse):
+				response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+			default:
+				response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			}
+
+			return
+		}
+
+		response.JSON(w, http.StatusNoContent, nil)
+	}
+}
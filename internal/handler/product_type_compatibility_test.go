@@ -0,0 +1,196 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/handler"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewProductTypeCompatibilityServiceMock() *ProductTypeCompatibilityServiceMock {
+	return &ProductTypeCompatibilityServiceMock{}
+}
+
+type ProductTypeCompatibilityServiceMock struct {
+	mock.Mock
+}
+
+func (m *ProductTypeCompatibilityServiceMock) FindCompatibleSections(productTypeID int) (internal.ProductTypeCompatibleSections, error) {
+	args := m.Called(productTypeID)
+	return args.Get(0).(internal.ProductTypeCompatibleSections), args.Error(1)
+}
+
+func (m *ProductTypeCompatibilityServiceMock) Save(c internal.ProductTypeCompatibility) error {
+	args := m.Called(c)
+	return args.Error(0)
+}
+
+func (m *ProductTypeCompatibilityServiceMock) Delete(c internal.ProductTypeCompatibility) error {
+	args := m.Called(c)
+	return args.Error(0)
+}
+
+func TestProductTypeCompatibility_GetAll(t *testing.T) {
+	testCases := []struct {
+		description  string
+		id           string
+		expectedBody string
+		expectedCode int
+		mock         func() *ProductTypeCompatibilityServiceMock
+	}{
+		{
+			description:  "case 1 - success: Compatible sections",
+			id:           "6",
+			expectedBody: `{"data":{"product_type_id":6,"section_product_type_ids":[6,10],"sections":[{"id":4,"section_number":40,"current_temperature":-18,"minimum_temperature":-22,"current_capacity":0,"minimum_capacity":10,"maximum_capacity":300,"warehouse_id":1,"product_type_id":10}]}}`,
+			expectedCode: http.StatusOK,
+			mock: func() *ProductTypeCompatibilityServiceMock {
+				mk := NewProductTypeCompatibilityServiceMock()
+				mk.On("FindCompatibleSections", 6).Return(internal.ProductTypeCompatibleSections{
+					ProductTypeID:         6,
+					SectionProductTypeIDs: []int{6, 10},
+					Sections:              []internal.Section{{ID: 4, SectionNumber: 40, CurrentTemperature: -18, MinimumTemperature: -22, MinimumCapacity: 10, MaximumCapacity: 300, WarehouseID: 1, ProductTypeID: 10}},
+				}, nil)
+				return mk
+			},
+		},
+		{
+			description:  "case 2 - error: Product type not found",
+			id:           "99",
+			expectedBody: `{"message":"product-type not found","error":"not_found","code":404,"causes":null}`,
+			expectedCode: http.StatusNotFound,
+			mock: func() *ProductTypeCompatibilityServiceMock {
+				mk := NewProductTypeCompatibilityServiceMock()
+				mk.On("FindCompatibleSections", 99).Return(internal.ProductTypeCompatibleSections{}, internal.ErrProductTypeNotFound)
+				return mk
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewProductTypeCompatibilityHandler(sv)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/product-types/"+tc.id+"/compatible-sections", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+			response := httptest.NewRecorder()
+
+			hd.GetAll()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+		})
+	}
+}
+
+func TestProductTypeCompatibility_Create(t *testing.T) {
+	testCases := []struct {
+		description       string
+		body              string
+		expectedBody      string
+		expectedCode      int
+		mock              func() *ProductTypeCompatibilityServiceMock
+		expectedMockCalls int
+	}{
+		{
+			description:  "case 1 - success: Rule created",
+			body:         `{"section_product_type_id": 10}`,
+			expectedBody: `{"data":{"product_type_id":6,"section_product_type_id":10}}`,
+			expectedCode: http.StatusCreated,
+			mock: func() *ProductTypeCompatibilityServiceMock {
+				mk := NewProductTypeCompatibilityServiceMock()
+				mk.On("Save", internal.ProductTypeCompatibility{ProductTypeID: 6, SectionProductTypeID: 10}).Return(nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 2 - error: Missing section product type",
+			body:         `{}`,
+			expectedBody: `{"message":"product-type compatibility inputs are missing","error":"unprocessable_entity","code":422,"causes":[{"field":"section_product_type_id","message":"section product type id is required"}]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			mock: func() *ProductTypeCompatibilityServiceMock {
+				return NewProductTypeCompatibilityServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+		{
+			description:  "case 3 - error: Rule already exists",
+			body:         `{"section_product_type_id": 10}`,
+			expectedBody: `{"message":"product-type compatibility already exists","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *ProductTypeCompatibilityServiceMock {
+				mk := NewProductTypeCompatibilityServiceMock()
+				mk.On("Save", mock.Anything).Return(internal.ErrProductTypeCompatibilityAlreadyExists)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewProductTypeCompatibilityHandler(sv)
+
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/product-types/6/compatible-sections", strings.NewReader(tc.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "6")
+			request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+			response := httptest.NewRecorder()
+
+			hd.Create()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+			sv.AssertNumberOfCalls(t, "Save", tc.expectedMockCalls)
+		})
+	}
+}
+
+func TestProductTypeCompatibility_Delete(t *testing.T) {
+	t.Run("case 1 - error: Rule not found", func(t *testing.T) {
+		sv := NewProductTypeCompatibilityServiceMock()
+		sv.On("Delete", internal.ProductTypeCompatibility{ProductTypeID: 6, SectionProductTypeID: 9}).Return(internal.ErrProductTypeCompatibilityNotFound)
+		hd := handler.NewProductTypeCompatibilityHandler(sv)
+
+		request := httptest.NewRequest(http.MethodDelete, "/api/v1/product-types/6/compatible-sections/9", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "6")
+		rctx.URLParams.Add("sectionProductTypeId", "9")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.Delete()(response, request)
+
+		require.Equal(t, http.StatusNotFound, response.Code)
+		require.JSONEq(t, `{"message":"product-type compatibility not found","error":"not_found","code":404,"causes":null}`, response.Body.String())
+	})
+
+	t.Run("case 2 - error: Rule in use by stored batches", func(t *testing.T) {
+		sv := NewProductTypeCompatibilityServiceMock()
+		sv.On("Delete", internal.ProductTypeCompatibility{ProductTypeID: 6, SectionProductTypeID: 9}).Return(internal.ErrProductTypeCompatibilityInUse)
+		hd := handler.NewProductTypeCompatibilityHandler(sv)
+
+		request := httptest.NewRequest(http.MethodDelete, "/api/v1/product-types/6/compatible-sections/9", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "6")
+		rctx.URLParams.Add("sectionProductTypeId", "9")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.Delete()(response, request)
+
+		require.Equal(t, http.StatusConflict, response.Code)
+		require.JSONEq(t, `{"message":"product-type compatibility is in use by stored batches","error":"conflict","code":409,"causes":null}`, response.Body.String())
+	})
+}
@@ -0,0 +1,207 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/handler"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewProductTypeServiceMock() *ProductTypeServiceMock {
+	return &ProductTypeServiceMock{}
+}
+
+type ProductTypeServiceMock struct {
+	mock.Mock
+}
+
+func (m *ProductTypeServiceMock) FindAll() ([]internal.ProductType, error) {
+	args := m.Called()
+	return args.Get(0).([]internal.ProductType), args.Error(1)
+}
+
+func (m *ProductTypeServiceMock) FindByID(id int) (internal.ProductType, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.ProductType), args.Error(1)
+}
+
+func (m *ProductTypeServiceMock) Save(pt *internal.ProductType) error {
+	args := m.Called(pt)
+	return args.Error(0)
+}
+
+func (m *ProductTypeServiceMock) Update(id int, patch internal.ProductTypePatch) (internal.ProductType, error) {
+	args := m.Called(id, patch)
+	return args.Get(0).(internal.ProductType), args.Error(1)
+}
+
+func (m *ProductTypeServiceMock) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestProductType_GetAll(t *testing.T) {
+	sv := NewProductTypeServiceMock()
+	sv.On("FindAll").Return([]internal.ProductType{{ID: 1, Name: "Dairy", Description: "Milk and cheese"}}, nil)
+	hd := handler.NewProductTypeHandler(sv)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/product-types", nil)
+	response := httptest.NewRecorder()
+
+	hd.GetAll()(response, request)
+
+	require.Equal(t, http.StatusOK, response.Code)
+	require.JSONEq(t, `{"data":[{"id":1,"name":"Dairy","description":"Milk and cheese"}]}`, response.Body.String())
+}
+
+func TestProductType_Create(t *testing.T) {
+	testCases := []struct {
+		description       string
+		body              string
+		expectedBody      string
+		expectedCode      int
+		mock              func() *ProductTypeServiceMock
+		expectedMockCalls int
+	}{
+		{
+			description:  "case 1 - success: Product type created",
+			body:         `{"name": "Dairy", "description": "Milk and cheese"}`,
+			expectedBody: `{"data":{"id":11,"name":"Dairy","description":"Milk and cheese"}}`,
+			expectedCode: http.StatusCreated,
+			mock: func() *ProductTypeServiceMock {
+				mk := NewProductTypeServiceMock()
+				mk.On("Save", mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(0).(*internal.ProductType).ID = 11
+				}).Return(nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 2 - error: Missing required fields",
+			body:         `{}`,
+			expectedBody: `{"message":"product-type inputs are missing","error":"unprocessable_entity","code":422,"causes":[{"field":"name","message":"name is required"},{"field":"description","message":"description is required"}]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			mock: func() *ProductTypeServiceMock {
+				return NewProductTypeServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+		{
+			description:  "case 3 - error: Name already in use",
+			body:         `{"name": "Dairy", "description": "Milk and cheese"}`,
+			expectedBody: `{"message":"product-type already exists","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *ProductTypeServiceMock {
+				mk := NewProductTypeServiceMock()
+				mk.On("Save", mock.Anything).Return(internal.ErrProductTypeAlreadyExists)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewProductTypeHandler(sv)
+
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/product-types", strings.NewReader(tc.body))
+			response := httptest.NewRecorder()
+
+			hd.Create()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+			sv.AssertNumberOfCalls(t, "Save", tc.expectedMockCalls)
+		})
+	}
+}
+
+func TestProductType_Update(t *testing.T) {
+	sv := NewProductTypeServiceMock()
+	description := "Frozen meals"
+	sv.On("Update", 10, internal.ProductTypePatch{Description: &description}).Return(internal.ProductType{ID: 10, Name: "Frozen Foods", Description: "Frozen meals"}, nil)
+	hd := handler.NewProductTypeHandler(sv)
+
+	request := httptest.NewRequest(http.MethodPatch, "/api/v1/product-types/10", strings.NewReader(`{"description": "Frozen meals"}`))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "10")
+	request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+	response := httptest.NewRecorder()
+
+	hd.Update()(response, request)
+
+	require.Equal(t, http.StatusOK, response.Code)
+	require.JSONEq(t, `{"data":{"id":10,"name":"Frozen Foods","description":"Frozen meals"}}`, response.Body.String())
+}
+
+func TestProductType_Delete(t *testing.T) {
+	testCases := []struct {
+		description  string
+		id           string
+		expectedBody string
+		expectedCode int
+		mock         func() *ProductTypeServiceMock
+	}{
+		{
+			description:  "case 1 - success: Product type deleted",
+			id:           "11",
+			expectedCode: http.StatusNoContent,
+			mock: func() *ProductTypeServiceMock {
+				mk := NewProductTypeServiceMock()
+				mk.On("Delete", 11).Return(nil)
+				return mk
+			},
+		},
+		{
+			description:  "case 2 - error: Product type still in use",
+			id:           "1",
+			expectedBody: `{"message":"product-type is still referenced by sections or products","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *ProductTypeServiceMock {
+				mk := NewProductTypeServiceMock()
+				mk.On("Delete", 1).Return(internal.ErrProductTypeInUse)
+				return mk
+			},
+		},
+		{
+			description:  "case 3 - error: Product type not found",
+			id:           "99",
+			expectedBody: `{"message":"product-type not found","error":"not_found","code":404,"causes":null}`,
+			expectedCode: http.StatusNotFound,
+			mock: func() *ProductTypeServiceMock {
+				mk := NewProductTypeServiceMock()
+				mk.On("Delete", 99).Return(internal.ErrProductTypeNotFound)
+				return mk
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewProductTypeHandler(sv)
+
+			request := httptest.NewRequest(http.MethodDelete, "/api/v1/product-types/"+tc.id, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+			response := httptest.NewRecorder()
+
+			hd.Delete()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			if tc.expectedBody != "" {
+				require.JSONEq(t, tc.expectedBody, response.Body.String())
+			}
+		})
+	}
+}
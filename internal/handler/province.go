@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
+)
+
+// ProvinceCreateRequest is a struct that represents a request to create a province
+type ProvinceCreateRequest struct {
+	Name      *string `json:"name"`
+	CountryID *int    `json:"country_id"`
+}
+
+// NewProvinceHandler creates a new instance of the province handler
+func NewProvinceHandler(sv internal.ProvinceService) *ProvinceHandler {
+	return &ProvinceHandler{
+		sv: sv,
+	}
+}
+
+// ProvinceHandler is the default implementation of the province handler
+type ProvinceHandler struct {
+	sv internal.ProvinceService
+}
+
+// GetAll returns the provinces
+// @Summary Get all provinces
+// @Description Retrieve the provinces, optionally only the ones of a country
+// @Tags Province
+// @Produce json
+// @Param country_id query int false "Country ID"
+// @Success 200 {object} []internal.Province "List of provinces"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/provinces [get]
+func (h *ProvinceHandler) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var countryID int
+
+		if countryIDStr := r.URL.Query().Get("country_id"); countryIDStr != "" {
+			var err error
+
+			countryID, err = strconv.Atoi(countryIDStr)
+			if err != nil {
+				response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+				return
+			}
+		}
+
+		provinces, err := h.sv.FindAll(countryID)
+		if err != nil {
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			return
+		}
+
+		if provinces == nil {
+			provinces = []internal.Province{}
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": provinces,
+		})
+	}
+}
+
+// GetByID returns a province by ID
+// @Summary Get a province by ID
+// @Description Retrieve the province with the given ID
+// @Tags Province
+// @Produce json
+// @Param id path int true "Province ID"
+// @Success 200 {object} internal.Province "Province"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Province not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/provinces/{id} [get]
+func (h *ProvinceHandler) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		p, err := h.sv.FindByID(id)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": p,
+		})
+	}
+}
+
+// Create creates a new province
+// @Summary Create a province
+// @Description Register a new province of a country. Names are unique in a country regardless of their case.
+// @Tags Province
+// @Accept json
+// @Produce json
+// @Param request body handler.ProvinceCreateRequest true "Province"
+// @Success 201 {object} internal.Province "Created province"
+// @Failure 400 {object} resterr.RestErr "Invalid data"
+// @Failure 409 {object} resterr.RestErr "Country not found or province already exists in the country"
+// @Failure 422 {object} resterr.RestErr "Province inputs are missing"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/provinces [post]
+func (h *ProvinceHandler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var requestInput ProvinceCreateRequest
+
+		// decoding the request
+		if err := json.NewDecoder(r.Body).Decode(&requestInput); err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+			return
+		}
+
+		// validating the request input required fields
+		causes := requestInput.ValidateRequiredFields()
+		if len(causes) > 0 {
+			response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityWithCausesError(internal.ErrProvinceUnprocessableEntity.Error(), causes))
+			return
+		}
+
+		p := internal.Province{
+			Name:      *requestInput.Name,
+			CountryID: *requestInput.CountryID,
+		}
+
+		if err := h.sv.Save(&p); err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"data": p,
+		})
+	}
+}
+
+// Report returns the localities, sellers and carries of the provinces
+// @Summary Report provinces
+// @Description Roll up the localities, sellers and carries of every province or of a specific one by ID
+// @Tags Province
+// @Produce json
+// @Param id query int false "Province ID"
+// @Success 200 {object} []internal.ProvinceReport "Provinces report"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Province not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/provinces/report [get]
+func (h *ProvinceHandler) Report() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := r.URL.Query().Get("id")
+
+		if idStr == "" {
+			reports, err := h.sv.Report()
+			if err != nil {
+				h.handleError(w, err)
+				return
+			}
+
+			if reports == nil {
+				reports = []internal.ProvinceReport{}
+			}
+
+			response.JSON(w, http.StatusOK, map[string]any{
+				"data": reports,
+			})
+
+			return
+		}
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		report, err := h.sv.ReportByID(id)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": []internal.ProvinceReport{report},
+		})
+	}
+}
+
+func (h *ProvinceHandler) handleError(w http.ResponseWriter, err error) {
+	var domainError internal.DomainError
+
+	switch {
+	case errors.As(err, &domainError):
+		var restCauses []resterr.Causes
+		for _, cause := range domainError.Causes {
+			restCauses = append(restCauses, resterr.Causes{
+				Field:   cause.Field,
+				Message: cause.Message,
+			})
+		}
+
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+	case errors.Is(err, internal.ErrProvinceNotFound):
+		response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+	case errors.Is(err, internal.ErrCountryNotFound), errors.Is(err, internal.ErrProvinceConflict):
+		response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+	default:
+		response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+	}
+}
+
+// Validating the ProvinceCreateRequest required fields
+func (p *ProvinceCreateRequest) ValidateRequiredFields() (causes []resterr.Causes) {
+	if p.Name == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "name",
+			Message: "name is required",
+		})
+	}
+	if p.CountryID == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "country_id",
+			Message: "country id is required",
+		})
+	}
+	return
+}
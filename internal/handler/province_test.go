@@ -0,0 +1,181 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/handler"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewProvinceServiceMock() *ProvinceServiceMock {
+	return &ProvinceServiceMock{}
+}
+
+type ProvinceServiceMock struct {
+	mock.Mock
+}
+
+func (m *ProvinceServiceMock) FindAll(countryID int) ([]internal.Province, error) {
+	args := m.Called(countryID)
+	return args.Get(0).([]internal.Province), args.Error(1)
+}
+
+func (m *ProvinceServiceMock) FindByID(id int) (internal.Province, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.Province), args.Error(1)
+}
+
+func (m *ProvinceServiceMock) Save(p *internal.Province) error {
+	args := m.Called(p)
+	return args.Error(0)
+}
+
+func (m *ProvinceServiceMock) Report() ([]internal.ProvinceReport, error) {
+	args := m.Called()
+	return args.Get(0).([]internal.ProvinceReport), args.Error(1)
+}
+
+func (m *ProvinceServiceMock) ReportByID(id int) (internal.ProvinceReport, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.ProvinceReport), args.Error(1)
+}
+
+func TestProvince_GetAll(t *testing.T) {
+	testCases := []struct {
+		description  string
+		query        string
+		expectedBody string
+		expectedCode int
+		mock         func() *ProvinceServiceMock
+	}{
+		{
+			description:  "case 1 - success: Provinces of a country",
+			query:        "?country_id=1",
+			expectedBody: `{"data":[{"id":4,"name":"Texas","country_id":1}]}`,
+			expectedCode: http.StatusOK,
+			mock: func() *ProvinceServiceMock {
+				mk := NewProvinceServiceMock()
+				mk.On("FindAll", 1).Return([]internal.Province{{ID: 4, Name: "Texas", CountryID: 1}}, nil)
+				return mk
+			},
+		},
+		{
+			description:  "case 2 - error: Invalid country ID",
+			query:        "?country_id=abc",
+			expectedBody: `{"message":"Invalid ID format","error":"bad_request","code":400,"causes":null}`,
+			expectedCode: http.StatusBadRequest,
+			mock: func() *ProvinceServiceMock {
+				return NewProvinceServiceMock()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			hd := handler.NewProvinceHandler(tc.mock())
+
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/provinces"+tc.query, nil)
+			response := httptest.NewRecorder()
+
+			hd.GetAll()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+		})
+	}
+}
+
+func TestProvince_Create(t *testing.T) {
+	testCases := []struct {
+		description       string
+		body              string
+		expectedBody      string
+		expectedCode      int
+		mock              func() *ProvinceServiceMock
+		expectedMockCalls int
+	}{
+		{
+			description:  "case 1 - success: Province created",
+			body:         `{"name": "Texas", "country_id": 1}`,
+			expectedBody: `{"data":{"id":4,"name":"Texas","country_id":1}}`,
+			expectedCode: http.StatusCreated,
+			mock: func() *ProvinceServiceMock {
+				mk := NewProvinceServiceMock()
+				mk.On("Save", mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(0).(*internal.Province).ID = 4
+				}).Return(nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 2 - error: Missing required fields",
+			body:         `{}`,
+			expectedBody: `{"message":"province inputs are missing","error":"unprocessable_entity","code":422,"causes":[{"field":"name","message":"name is required"},{"field":"country_id","message":"country id is required"}]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			mock: func() *ProvinceServiceMock {
+				return NewProvinceServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+		{
+			description:  "case 3 - error: Country not found",
+			body:         `{"name": "Texas", "country_id": 99}`,
+			expectedBody: `{"message":"country not found","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *ProvinceServiceMock {
+				mk := NewProvinceServiceMock()
+				mk.On("Save", mock.Anything).Return(internal.ErrCountryNotFound)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 4 - error: Province already exists in the country",
+			body:         `{"name": "texas", "country_id": 1}`,
+			expectedBody: `{"message":"province already exists in the country","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *ProvinceServiceMock {
+				mk := NewProvinceServiceMock()
+				mk.On("Save", mock.Anything).Return(internal.ErrProvinceConflict)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewProvinceHandler(sv)
+
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/provinces", strings.NewReader(tc.body))
+			response := httptest.NewRecorder()
+
+			hd.Create()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+			sv.AssertNumberOfCalls(t, "Save", tc.expectedMockCalls)
+		})
+	}
+}
+
+func TestProvince_Report(t *testing.T) {
+	sv := NewProvinceServiceMock()
+	sv.On("ReportByID", 4).Return(internal.ProvinceReport{ProvinceID: 4, ProvinceName: "Texas", CountryID: 1, CountryName: "United States", LocalitiesCount: 3, SellersCount: 3, CarriesCount: 2}, nil)
+	hd := handler.NewProvinceHandler(sv)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/provinces/report?id=4", nil)
+	response := httptest.NewRecorder()
+
+	hd.Report()(response, request)
+
+	require.Equal(t, http.StatusOK, response.Code)
+	require.JSONEq(t, `{"data":[{"province_id":4,"province_name":"Texas","country_id":1,"country_name":"United States","localities_count":3,"sellers_count":3,"carries_count":2}]}`, response.Body.String())
+}
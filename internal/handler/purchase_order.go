@@ -3,10 +3,13 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 	"github.com/meli-fresh-products-api-backend-t1/internal/service"
 	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
@@ -20,17 +23,55 @@ type PurchaseOrderJSON struct {
 	TrackingCode    string `json:"tracking_code"`
 	BuyerID         int    `json:"buyer_id"`
 	ProductRecordID int    `json:"product_record_id"`
+	Status          string `json:"status"`
+
+	Lines []PurchaseOrderLineJSON `json:"lines,omitempty"`
+}
+
+// PurchaseOrderLineJSON is a struct that represents a purchase order line in JSON format
+type PurchaseOrderLineJSON struct {
+	ID                int     `json:"id"`
+	ProductID         int     `json:"product_id"`
+	Quantity          int     `json:"quantity"`
+	UnitPrice         float64 `json:"unit_price"`
+	ReservedQuantity  int     `json:"reserved_quantity"`
+	FulfilledQuantity int     `json:"fulfilled_quantity"`
+}
+
+// PurchaseOrderStatusHistoryJSON is a struct that represents a status change of a purchase order in JSON format
+type PurchaseOrderStatusHistoryJSON struct {
+	ID         int    `json:"id"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	ChangedBy  string `json:"changed_by"`
+	ChangedAt  string `json:"changed_at"`
 }
 
 // PurchaseOrderCreateRequest is a struct that represents a purchase order create request
 type PurchaseOrderCreateRequest struct {
 	OrderNumber     *string `json:"order_number"`
 	OrderDate       *string `json:"order_date"`
-	TrackingCode    *string `json:"tracking_code"`
 	BuyerID         *int    `json:"buyer_id"`
 	ProductRecordID *int    `json:"product_record_id"`
+
+	Lines []PurchaseOrderLineCreateRequest `json:"lines"`
+}
+
+// PurchaseOrderLineCreateRequest is a struct that represents a purchase order line create request
+type PurchaseOrderLineCreateRequest struct {
+	ProductID *int `json:"product_id"`
+	Quantity  *int `json:"quantity"`
 }
 
+// PurchaseOrderStatusRequest is a struct that represents a purchase order status change request
+type PurchaseOrderStatusRequest struct {
+	Status    *string `json:"status"`
+	ChangedBy *string `json:"changed_by"`
+}
+
+// purchaseOrderDefaultChangedBy is recorded as the author of a cancellation when the request does not name one
+const purchaseOrderDefaultChangedBy = "api"
+
 // NewPurchaseOrderHandler creates a new instance of the purchase order handler
 func NewPurchaseOrderHandler(sv internal.PurchaseOrderService) *PurchaseOrderHandler {
 	return &PurchaseOrderHandler{
@@ -43,18 +84,177 @@ type PurchaseOrderHandler struct {
 	sv internal.PurchaseOrderService
 }
 
+// GetAll returns the purchase orders
+// @Summary Get purchase orders
+// @Description Lists the purchase orders, the most recent first, optionally filtered by order date range, buyer, tracking code and status
+// @Tags PurchaseOrder
+// @Produce json
+// @Param from query string false "Orders placed on or after this date (YYYY-MM-DD)"
+// @Param to query string false "Orders placed on or before this date (YYYY-MM-DD)"
+// @Param buyer_id query int false "Buyer ID"
+// @Param tracking_code query string false "Tracking code"
+// @Param status query string false "Purchase order status"
+// @Success 200 {object} []handler.PurchaseOrderJSON "Purchase orders"
+// @Failure 400 {object} resterr.RestErr "Invalid filters"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/purchase-orders [get]
+func (h *PurchaseOrderHandler) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filter, causes := parsePurchaseOrderFilter(r)
+		if len(causes) > 0 {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(ErrInvalidData, causes))
+			return
+		}
+
+		purchaseOrders, err := h.sv.FindAll(filter)
+		if err != nil {
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": newPurchaseOrdersJSON(purchaseOrders),
+		})
+	}
+}
+
+// GetByID returns a purchase order
+// @Summary Get a purchase order
+// @Description Returns the purchase order with its status and lines
+// @Tags PurchaseOrder
+// @Produce json
+// @Param id path int true "Purchase Order ID"
+// @Success 200 {object} handler.PurchaseOrderJSON "Purchase order"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Purchase order not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/purchase-orders/{id} [get]
+func (h *PurchaseOrderHandler) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		purchaseOrder, err := h.sv.FindByID(id)
+		if err != nil {
+			if errors.Is(err, internal.ErrPurchaseOrderNotFound) {
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+				return
+			}
+
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": newPurchaseOrderJSON(purchaseOrder),
+		})
+	}
+}
+
+// GetByBuyer returns the purchase orders of a buyer
+// @Summary Get the purchase orders of a buyer
+// @Description Lists the purchase orders of the buyer, the most recent first, optionally filtered by order date range, tracking code and status
+// @Tags PurchaseOrder
+// @Produce json
+// @Param id path int true "Buyer ID"
+// @Param from query string false "Orders placed on or after this date (YYYY-MM-DD)"
+// @Param to query string false "Orders placed on or before this date (YYYY-MM-DD)"
+// @Param tracking_code query string false "Tracking code"
+// @Param status query string false "Purchase order status"
+// @Success 200 {object} []handler.PurchaseOrderJSON "Purchase orders"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format or filters"
+// @Failure 404 {object} resterr.RestErr "Buyer not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/buyers/{id}/purchase-orders [get]
+func (h *PurchaseOrderHandler) GetByBuyer() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		buyerID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		filter, causes := parsePurchaseOrderFilter(r)
+		if len(causes) > 0 {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(ErrInvalidData, causes))
+			return
+		}
+
+		purchaseOrders, err := h.sv.FindByBuyerID(buyerID, filter)
+		if err != nil {
+			if errors.Is(err, service.ErrBuyerNotFound) {
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+				return
+			}
+
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": newPurchaseOrdersJSON(purchaseOrders),
+		})
+	}
+}
+
+// Cancel cancels a purchase order
+// @Summary Cancel a purchase order
+// @Description Moves the purchase order to cancelled and releases its reserved stock, the order and its history are kept
+// @Tags PurchaseOrder
+// @Param id path int true "Purchase Order ID"
+// @Param changed_by query string false "Who cancels the order"
+// @Success 204 "Purchase order cancelled"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Purchase order not found"
+// @Failure 409 {object} resterr.RestErr "Purchase order can no longer be cancelled"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/purchase-orders/{id} [delete]
+func (h *PurchaseOrderHandler) Cancel() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		changedBy := r.URL.Query().Get("changed_by")
+		if changedBy == "" {
+			changedBy = purchaseOrderDefaultChangedBy
+		}
+
+		_, err = h.sv.Cancel(id, changedBy)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrPurchaseOrderNotFound):
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+			case errors.Is(err, internal.ErrPurchaseOrderInvalidTransition):
+				response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+			default:
+				response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			}
+
+			return
+		}
+
+		response.JSON(w, http.StatusNoContent, nil)
+	}
+}
+
 // Create creates a new purchase order
 // @Summary Create a new purchase order
 // @Description Handles the creation of a new purchase order to the database
 // @Tags PurchaseOrder
 // @Accept json
 // @Produce json
-// @Param request body handler.PurchaseOrderCreateRequest true "Purchase Order Create Request"
+// @Param request body handler.PurchaseOrderCreateRequest true "Purchase Order Create Request, lines are priced with the sale price effective on the order date, product_record_id must be the price effective on the order date"
 // @Success 201 {object} handler.PurchaseOrderJSON "Created Purchase Order"
 // @Failure 400 {object} resterr.RestErr "Invalid data"
 // @Failure 422 {object} resterr.RestErr "Purchase Order inputs are Invalid"
 // @Failure 404 {object} resterr.RestErr "Product records or Buyer not found"
-// @Failure 409 {object} resterr.RestErr "Purchase order number already exists"
+// @Failure 409 {object} resterr.RestErr "Purchase order number already exists, a product has no price effective on the order date or the product record is not that price"
 // @Failure 500 {object} resterr.RestErr "Internal Server Error"
 // @Router /api/v1/purchase-orders [post]
 func (h *PurchaseOrderHandler) Create() http.HandlerFunc {
@@ -86,14 +286,23 @@ func (h *PurchaseOrderHandler) Create() http.HandlerFunc {
 			return
 		}
 
-		// creating the purchase order
+		// creating the purchase order, the tracking code is assigned when the order is shipped
 		purchaseOrder := &internal.PurchaseOrder{
-			ID:              0,
-			OrderNumber:     *requestInput.OrderNumber,
-			OrderDate:       orderDate,
-			TrackingCode:    *requestInput.TrackingCode,
-			BuyerID:         *requestInput.BuyerID,
-			ProductRecordID: *requestInput.ProductRecordID,
+			ID:          0,
+			OrderNumber: *requestInput.OrderNumber,
+			OrderDate:   orderDate,
+			BuyerID:     *requestInput.BuyerID,
+		}
+
+		if requestInput.ProductRecordID != nil {
+			purchaseOrder.ProductRecordID = *requestInput.ProductRecordID
+		}
+
+		for _, line := range requestInput.Lines {
+			purchaseOrder.Lines = append(purchaseOrder.Lines, internal.PurchaseOrderLine{
+				ProductID: *line.ProductID,
+				Quantity:  *line.Quantity,
+			})
 		}
 
 		// saving the purchase order
@@ -113,10 +322,16 @@ func (h *PurchaseOrderHandler) Create() http.HandlerFunc {
 				response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
 			case errors.Is(err, internal.ErrPurchaseOrderConflict):
 				response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
-			case errors.Is(err, internal.ErrProductRecordsNotFound):
+			case errors.Is(err, internal.ErrProductRecordsNotFound),
+				errors.Is(err, internal.ErrProductPriceNotFound),
+				errors.Is(err, internal.ErrPurchaseOrderProductRecordNotEffective):
 				response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
 			case errors.Is(err, service.ErrBuyerNotFound):
 				response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+			case errors.Is(err, internal.ErrProductNotFound),
+				errors.Is(err, internal.ErrProductNotActive),
+				errors.Is(err, internal.ErrSellerNotActive):
+				response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
 			default:
 				response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
 			}
@@ -124,19 +339,9 @@ func (h *PurchaseOrderHandler) Create() http.HandlerFunc {
 			return
 		}
 
-		// creating the response
-		purchaseOrderJSON := &PurchaseOrderJSON{
-			ID:              purchaseOrder.ID,
-			OrderNumber:     purchaseOrder.OrderNumber,
-			OrderDate:       purchaseOrder.OrderDate.Format(time.DateOnly),
-			TrackingCode:    purchaseOrder.TrackingCode,
-			BuyerID:         purchaseOrder.BuyerID,
-			ProductRecordID: purchaseOrder.ProductRecordID,
-		}
-
 		// sending the response
 		response.JSON(w, http.StatusCreated, map[string]any{
-			"data": purchaseOrderJSON,
+			"data": newPurchaseOrderJSON(*purchaseOrder),
 		})
 	}
 }
@@ -155,23 +360,339 @@ func (p *PurchaseOrderCreateRequest) ValidateRequiredFields() (causes []resterr.
 			Message: "order date is required",
 		})
 	}
-	if p.TrackingCode == nil {
-		causes = append(causes, resterr.Causes{
-			Field:   "tracking_code",
-			Message: "tracking code is required",
-		})
-	}
 	if p.BuyerID == nil {
 		causes = append(causes, resterr.Causes{
 			Field:   "buyer_id",
 			Message: "buyer id is required",
 		})
 	}
-	if p.ProductRecordID == nil {
+	if p.ProductRecordID == nil && len(p.Lines) == 0 {
 		causes = append(causes, resterr.Causes{
 			Field:   "product_record_id",
 			Message: "product record id is required",
 		})
 	}
+	for i, line := range p.Lines {
+		if line.ProductID == nil {
+			causes = append(causes, resterr.Causes{
+				Field:   fmt.Sprintf("lines[%d].product_id", i),
+				Message: "product id is required",
+			})
+		}
+		if line.Quantity == nil {
+			causes = append(causes, resterr.Causes{
+				Field:   fmt.Sprintf("lines[%d].quantity", i),
+				Message: "quantity is required",
+			})
+		}
+	}
+	return
+}
+
+// Reserve holds stock for the lines of a purchase order
+// @Summary Reserve stock for a purchase order
+// @Description Holds stock from the product batches for every line of the purchase order, first expiring first out
+// @Tags PurchaseOrder
+// @Produce json
+// @Param id path int true "Purchase Order ID"
+// @Success 200 {object} []handler.PurchaseOrderLineJSON "Purchase order lines"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Purchase order not found"
+// @Failure 409 {object} resterr.RestErr "Not enough stock to reserve the purchase order, or the order is cancelled or already shipped"
+// @Failure 422 {object} resterr.RestErr "Purchase order has no lines"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/purchase-orders/{id}/reserve [post]
+func (h *PurchaseOrderHandler) Reserve() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		lines, err := h.sv.Reserve(id)
+		if err != nil {
+			h.handleFulfilmentError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": newPurchaseOrderLinesJSON(lines),
+		})
+	}
+}
+
+// Fulfil picks the stock of a purchase order from the product batches
+// @Summary Fulfil a purchase order
+// @Description Reserves any missing stock and consumes the reservations of the purchase order from the product batches
+// @Tags PurchaseOrder
+// @Produce json
+// @Param id path int true "Purchase Order ID"
+// @Success 200 {object} []handler.PurchaseOrderLineJSON "Purchase order lines"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Purchase order not found"
+// @Failure 409 {object} resterr.RestErr "Not enough stock to reserve the purchase order, or the order is cancelled or already shipped"
+// @Failure 422 {object} resterr.RestErr "Purchase order has no lines"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/purchase-orders/{id}/fulfil [post]
+func (h *PurchaseOrderHandler) Fulfil() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		lines, err := h.sv.Fulfil(id)
+		if err != nil {
+			h.handleFulfilmentError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": newPurchaseOrderLinesJSON(lines),
+		})
+	}
+}
+
+func (h *PurchaseOrderHandler) handleFulfilmentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, internal.ErrPurchaseOrderNotFound):
+		response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+	case errors.Is(err, internal.ErrPurchaseOrderInsufficientStock), errors.Is(err, internal.ErrStockMovementInsufficientStock), errors.Is(err, internal.ErrPurchaseOrderCancelled), errors.Is(err, internal.ErrPurchaseOrderInvalidTransition), errors.Is(err, internal.ErrProductBatchOnHold):
+		response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+	case errors.Is(err, internal.ErrPurchaseOrderWithoutLines):
+		response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityError(err.Error()))
+	default:
+		response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+	}
+}
+
+// UpdateStatus moves a purchase order to a new status
+// @Summary Update the status of a purchase order
+// @Description Moves the purchase order through created, confirmed and picking, or cancels it, recording who changed it. Shipped and delivered are set by its shipment
+// @Tags PurchaseOrder
+// @Accept json
+// @Produce json
+// @Param id path int true "Purchase Order ID"
+// @Param request body handler.PurchaseOrderStatusRequest true "Purchase Order Status Request"
+// @Success 200 {object} handler.PurchaseOrderJSON "Updated Purchase Order"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format or unknown status"
+// @Failure 404 {object} resterr.RestErr "Purchase order not found"
+// @Failure 409 {object} resterr.RestErr "Purchase order status transition is not allowed or the status is set by the shipment"
+// @Failure 422 {object} resterr.RestErr "Purchase order status inputs are missing"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/purchase-orders/{id}/status [patch]
+func (h *PurchaseOrderHandler) UpdateStatus() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		var requestInput PurchaseOrderStatusRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&requestInput); err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+			return
+		}
+
+		causes := requestInput.ValidateRequiredFields()
+		if len(causes) > 0 {
+			response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityWithCausesError(internal.ErrPurchaseOrderStatusUnprocessableEntity.Error(), causes))
+			return
+		}
+
+		purchaseOrder, err := h.sv.UpdateStatus(id, *requestInput.Status, *requestInput.ChangedBy)
+		if err != nil {
+			var domainError internal.DomainError
+
+			switch {
+			case errors.As(err, &domainError):
+				var restCauses []resterr.Causes
+				for _, cause := range domainError.Causes {
+					restCauses = append(restCauses, resterr.Causes{
+						Field:   cause.Field,
+						Message: cause.Message,
+					})
+				}
+
+				response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+			case errors.Is(err, internal.ErrPurchaseOrderNotFound):
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+			case errors.Is(err, internal.ErrPurchaseOrderInvalidTransition),
+				errors.Is(err, internal.ErrPurchaseOrderStatusSetByShipment):
+				response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+			default:
+				response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			}
+
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": newPurchaseOrderJSON(purchaseOrder),
+		})
+	}
+}
+
+// ValidateRequiredFields validates the PurchaseOrderStatusRequest required fields
+func (p *PurchaseOrderStatusRequest) ValidateRequiredFields() (causes []resterr.Causes) {
+	if p.Status == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "status",
+			Message: "status is required",
+		})
+	}
+	if p.ChangedBy == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "changed_by",
+			Message: "changed by is required",
+		})
+	}
+	return
+}
+
+// History returns the status changes of a purchase order
+// @Summary Get the status history of a purchase order
+// @Description Lists the status changes of the purchase order with who changed it and when
+// @Tags PurchaseOrder
+// @Produce json
+// @Param id path int true "Purchase Order ID"
+// @Success 200 {object} []handler.PurchaseOrderStatusHistoryJSON "Status history"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Purchase order not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/purchase-orders/{id}/history [get]
+func (h *PurchaseOrderHandler) History() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		history, err := h.sv.FindHistory(id)
+		if err != nil {
+			if errors.Is(err, internal.ErrPurchaseOrderNotFound) {
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+				return
+			}
+
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			return
+		}
+
+		historyJSON := []PurchaseOrderStatusHistoryJSON{}
+		for _, change := range history {
+			historyJSON = append(historyJSON, PurchaseOrderStatusHistoryJSON{
+				ID:         change.ID,
+				FromStatus: change.FromStatus,
+				ToStatus:   change.ToStatus,
+				ChangedBy:  change.ChangedBy,
+				ChangedAt:  change.ChangedAt.Format(time.DateTime),
+			})
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": historyJSON,
+		})
+	}
+}
+
+// parsePurchaseOrderFilter reads the purchase order filter from the query string
+func parsePurchaseOrderFilter(r *http.Request) (filter internal.PurchaseOrderFilter, causes []resterr.Causes) {
+	query := r.URL.Query()
+
+	filter.TrackingCode = query.Get("tracking_code")
+	filter.Status = query.Get("status")
+
+	if filter.Status != "" && !internal.IsPurchaseOrderStatus(filter.Status) {
+		causes = append(causes, resterr.Causes{
+			Field:   "status",
+			Message: "unknown purchase order status",
+		})
+	}
+
+	if buyerID := query.Get("buyer_id"); buyerID != "" {
+		id, err := strconv.Atoi(buyerID)
+		if err != nil || id <= 0 {
+			causes = append(causes, resterr.Causes{
+				Field:   "buyer_id",
+				Message: "buyer id must be a positive number",
+			})
+		}
+		filter.BuyerID = id
+	}
+
+	for _, param := range []struct {
+		name  string
+		value *time.Time
+	}{
+		{name: "from", value: &filter.From},
+		{name: "to", value: &filter.To},
+	} {
+		raw := query.Get(param.name)
+		if raw == "" {
+			continue
+		}
+
+		date, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			causes = append(causes, resterr.Causes{
+				Field:   param.name,
+				Message: "invalid date format",
+			})
+			continue
+		}
+		*param.value = date
+	}
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		causes = append(causes, resterr.Causes{
+			Field:   "from",
+			Message: "from must not be after to",
+		})
+	}
+
 	return
 }
+
+func newPurchaseOrdersJSON(purchaseOrders []internal.PurchaseOrder) []PurchaseOrderJSON {
+	purchaseOrdersJSON := []PurchaseOrderJSON{}
+	for _, p := range purchaseOrders {
+		purchaseOrdersJSON = append(purchaseOrdersJSON, newPurchaseOrderJSON(p))
+	}
+
+	return purchaseOrdersJSON
+}
+
+func newPurchaseOrderJSON(p internal.PurchaseOrder) PurchaseOrderJSON {
+	return PurchaseOrderJSON{
+		ID:              p.ID,
+		OrderNumber:     p.OrderNumber,
+		OrderDate:       p.OrderDate.Format(time.DateOnly),
+		TrackingCode:    p.TrackingCode,
+		BuyerID:         p.BuyerID,
+		ProductRecordID: p.ProductRecordID,
+		Status:          p.Status,
+		Lines:           newPurchaseOrderLinesJSON(p.Lines),
+	}
+}
+
+func newPurchaseOrderLinesJSON(lines []internal.PurchaseOrderLine) []PurchaseOrderLineJSON {
+	var linesJSON []PurchaseOrderLineJSON
+	for _, line := range lines {
+		linesJSON = append(linesJSON, PurchaseOrderLineJSON{
+			ID:                line.ID,
+			ProductID:         line.ProductID,
+			Quantity:          line.Quantity,
+			UnitPrice:         line.UnitPrice,
+			ReservedQuantity:  line.ReservedQuantity,
+			FulfilledQuantity: line.FulfilledQuantity,
+		})
+	}
+
+	return linesJSON
+}
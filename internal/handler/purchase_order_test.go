@@ -1,12 +1,15 @@
 package handler_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 	"github.com/meli-fresh-products-api-backend-t1/internal/handler"
 	"github.com/meli-fresh-products-api-backend-t1/internal/service"
@@ -32,6 +35,41 @@ func (m *PurchaseOrderServiceMock) Save(p *internal.PurchaseOrder) error {
 	return args.Error(0)
 }
 
+func (m *PurchaseOrderServiceMock) Reserve(id int) ([]internal.PurchaseOrderLine, error) {
+	args := m.Called(id)
+	return args.Get(0).([]internal.PurchaseOrderLine), args.Error(1)
+}
+
+func (m *PurchaseOrderServiceMock) Fulfil(id int) ([]internal.PurchaseOrderLine, error) {
+	args := m.Called(id)
+	return args.Get(0).([]internal.PurchaseOrderLine), args.Error(1)
+}
+
+func (m *PurchaseOrderServiceMock) FindAll(filter internal.PurchaseOrderFilter) ([]internal.PurchaseOrder, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]internal.PurchaseOrder), args.Error(1)
+}
+
+func (m *PurchaseOrderServiceMock) FindByBuyerID(buyerID int, filter internal.PurchaseOrderFilter) ([]internal.PurchaseOrder, error) {
+	args := m.Called(buyerID, filter)
+	return args.Get(0).([]internal.PurchaseOrder), args.Error(1)
+}
+
+func (m *PurchaseOrderServiceMock) Cancel(id int, changedBy string) (internal.PurchaseOrder, error) {
+	args := m.Called(id, changedBy)
+	return args.Get(0).(internal.PurchaseOrder), args.Error(1)
+}
+
+func (m *PurchaseOrderServiceMock) UpdateStatus(id int, status string, changedBy string) (internal.PurchaseOrder, error) {
+	args := m.Called(id, status, changedBy)
+	return args.Get(0).(internal.PurchaseOrder), args.Error(1)
+}
+
+func (m *PurchaseOrderServiceMock) FindHistory(id int) ([]internal.PurchaseOrderStatusHistory, error) {
+	args := m.Called(id)
+	return args.Get(0).([]internal.PurchaseOrderStatusHistory), args.Error(1)
+}
+
 var (
 	endpointPurchaseOrder = "/api/v1/purchase-orders"
 )
@@ -55,11 +93,10 @@ func TestPurchaseOrder_Create(t *testing.T) {
 			body: `{
 				"order_number": "123456",
 				"order_date": "2021-01-01",
-				"tracking_code": "123456",
 				"buyer_id": 1,
 				"product_record_id": 1
 			}`,
-			expectedBody:   `{"data":{"id":1,"order_number":"123456","order_date":"2021-01-01","tracking_code":"123456","buyer_id":1,"product_record_id":1}}`,
+			expectedBody:   `{"data":{"id":1,"order_number":"123456","order_date":"2021-01-01","tracking_code":"","buyer_id":1,"product_record_id":1,"status":"created"}}`,
 			expectedCode:   http.StatusCreated,
 			expectedHeader: jsonHeader,
 			mock: func() *PurchaseOrderServiceMock {
@@ -67,6 +104,7 @@ func TestPurchaseOrder_Create(t *testing.T) {
 				mk.On("Save", mock.AnythingOfType("*internal.PurchaseOrder")).Run(func(args mock.Arguments) {
 					w := args.Get(0).(*internal.PurchaseOrder)
 					w.ID = 1
+					w.Status = internal.PurchaseOrderStatusCreated
 				}).Return(nil)
 				return mk
 			},
@@ -92,10 +130,6 @@ func TestPurchaseOrder_Create(t *testing.T) {
 							"field": "order_date",
 							"message": "order date is required"
 						},
-						{
-							"field": "tracking_code",
-							"message": "tracking code is required"
-						},
 						{
 							"field": "buyer_id",
 							"message": "buyer id is required"
@@ -121,7 +155,6 @@ func TestPurchaseOrder_Create(t *testing.T) {
 			body: `{
 				"order_number": "123ABC",
 				"order_date": "2023-10-05invalid",
-				"tracking_code": "123456",
 				"buyer_id": 1,
 				"product_record_id": 1
 			}`,
@@ -152,7 +185,6 @@ func TestPurchaseOrder_Create(t *testing.T) {
 			body: `{
 				"order_number": "123ABC",
 				"order_date": "2023-10-05",
-				"tracking_code": "123456",
 				"buyer_id": 10,
 				"product_record_id": 1
 			}`,
@@ -180,7 +212,6 @@ func TestPurchaseOrder_Create(t *testing.T) {
 			body: `{
 				"order_number": "123ABC",
 				"order_date": "2023-10-05",
-				"tracking_code": "123456",
 				"buyer_id": 1,
 				"product_record_id": 10
 			}`,
@@ -208,7 +239,6 @@ func TestPurchaseOrder_Create(t *testing.T) {
 			body: `{
 				"order_number": "123ABC",
 				"order_date": "2023-10-05",
-				"tracking_code": "123456",
 				"buyer_id": 1,
 				"product_record_id": 1
 			}`,
@@ -236,7 +266,6 @@ func TestPurchaseOrder_Create(t *testing.T) {
 			body: `{
 				"order_number": "123456",
 				"order_date": "2023-10-05",
-				"tracking_code": "123456",
 				"buyer_id": 1,
 				"product_record_id": 1
 			}`,
@@ -264,7 +293,6 @@ func TestPurchaseOrder_Create(t *testing.T) {
 			body: `{
 				"order_number": "123456",
 				"order_date": "2023-10-05",
-				"tracking_code": "123456",
 				"buyer_id": -1,
 				"product_record_id": 1
 			}`,
@@ -305,7 +333,6 @@ func TestPurchaseOrder_Create(t *testing.T) {
 			body: `{
 				"order_number": 123456,
 				"order_date": "2023-10-05",
-				"tracking_code": "123456",
 				"buyer_id": 1,
 				"product_record_id": 1
 			}`,
@@ -348,3 +375,463 @@ func TestPurchaseOrder_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestPurchaseOrder_Fulfilment(t *testing.T) {
+	lines := []internal.PurchaseOrderLine{{ID: 1, PurchaseOrderID: 1, ProductID: 1, Quantity: 5, UnitPrice: 10, ReservedQuantity: 5, FulfilledQuantity: 5}}
+
+	testCases := []struct {
+		description  string
+		action       string
+		id           string
+		expectedBody string
+		expectedCode int
+		mock         func() *PurchaseOrderServiceMock
+	}{
+		{
+			description:  "case 1 - success: Reserve a purchase order",
+			action:       "Reserve",
+			id:           "1",
+			expectedBody: `{"data":[{"id":1,"product_id":1,"quantity":5,"unit_price":10,"reserved_quantity":5,"fulfilled_quantity":5}]}`,
+			expectedCode: http.StatusOK,
+			mock: func() *PurchaseOrderServiceMock {
+				mk := NewPurchaseOrderMock()
+				mk.On("Reserve", 1).Return(lines, nil)
+				return mk
+			},
+		},
+		{
+			description:  "case 2 - success: Fulfil a purchase order",
+			action:       "Fulfil",
+			id:           "1",
+			expectedBody: `{"data":[{"id":1,"product_id":1,"quantity":5,"unit_price":10,"reserved_quantity":5,"fulfilled_quantity":5}]}`,
+			expectedCode: http.StatusOK,
+			mock: func() *PurchaseOrderServiceMock {
+				mk := NewPurchaseOrderMock()
+				mk.On("Fulfil", 1).Return(lines, nil)
+				return mk
+			},
+		},
+		{
+			description:  "case 3 - error: Not enough stock",
+			action:       "Fulfil",
+			id:           "1",
+			expectedBody: `{"message":"not enough stock to reserve the purchase order","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *PurchaseOrderServiceMock {
+				mk := NewPurchaseOrderMock()
+				mk.On("Fulfil", 1).Return([]internal.PurchaseOrderLine{}, internal.ErrPurchaseOrderInsufficientStock)
+				return mk
+			},
+		},
+		{
+			description:  "case 4 - error: Purchase order not found",
+			action:       "Reserve",
+			id:           "99",
+			expectedBody: `{"message":"purchase order not found","error":"not_found","code":404,"causes":null}`,
+			expectedCode: http.StatusNotFound,
+			mock: func() *PurchaseOrderServiceMock {
+				mk := NewPurchaseOrderMock()
+				mk.On("Reserve", 99).Return([]internal.PurchaseOrderLine{}, internal.ErrPurchaseOrderNotFound)
+				return mk
+			},
+		},
+		{
+			description:  "case 5 - error: Purchase order without lines",
+			action:       "Reserve",
+			id:           "1",
+			expectedBody: `{"message":"purchase order has no lines","error":"unprocessable_entity","code":422,"causes":null}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			mock: func() *PurchaseOrderServiceMock {
+				mk := NewPurchaseOrderMock()
+				mk.On("Reserve", 1).Return([]internal.PurchaseOrderLine{}, internal.ErrPurchaseOrderWithoutLines)
+				return mk
+			},
+		},
+		{
+			description:  "case 6 - error: Purchase order already shipped",
+			action:       "Fulfil",
+			id:           "1",
+			expectedBody: `{"message":"purchase order status transition is not allowed","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *PurchaseOrderServiceMock {
+				mk := NewPurchaseOrderMock()
+				mk.On("Fulfil", 1).Return([]internal.PurchaseOrderLine{}, internal.ErrPurchaseOrderInvalidTransition)
+				return mk
+			},
+		},
+		{
+			description:  "case 7 - error: Invalid id",
+			action:       "Fulfil",
+			id:           "abc",
+			expectedBody: `{"message":"Invalid ID format","error":"bad_request","code":400,"causes":null}`,
+			expectedCode: http.StatusBadRequest,
+			mock: func() *PurchaseOrderServiceMock {
+				return NewPurchaseOrderMock()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewPurchaseOrderHandler(sv)
+			hdFunc := hd.Reserve()
+			if tc.action == "Fulfil" {
+				hdFunc = hd.Fulfil()
+			}
+
+			request := httptest.NewRequest(http.MethodPost, endpointPurchaseOrder+"/"+tc.id+"/"+strings.ToLower(tc.action), nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+			response := httptest.NewRecorder()
+
+			hdFunc(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+		})
+	}
+}
+
+func TestPurchaseOrder_UpdateStatus(t *testing.T) {
+	testCases := []struct {
+		description  string
+		id           string
+		body         string
+		expectedBody string
+		expectedCode int
+		mock         func() *PurchaseOrderServiceMock
+	}{
+		{
+			description:  "case 1 - success: Confirm a purchase order",
+			id:           "1",
+			body:         `{"status": "confirmed", "changed_by": "jdoe"}`,
+			expectedBody: `{"data":{"id":1,"order_number":"PO1001","order_date":"2021-01-01","tracking_code":"T1001","buyer_id":1,"product_record_id":1,"status":"confirmed"}}`,
+			expectedCode: http.StatusOK,
+			mock: func() *PurchaseOrderServiceMock {
+				mk := NewPurchaseOrderMock()
+				mk.On("UpdateStatus", 1, "confirmed", "jdoe").Return(internal.PurchaseOrder{
+					ID: 1, OrderNumber: "PO1001", OrderDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), TrackingCode: "T1001", BuyerID: 1, ProductRecordID: 1, Status: "confirmed",
+				}, nil)
+				return mk
+			},
+		},
+		{
+			description:  "case 2 - error: Illegal transition",
+			id:           "1",
+			body:         `{"status": "picking", "changed_by": "jdoe"}`,
+			expectedBody: `{"message":"purchase order status transition is not allowed","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *PurchaseOrderServiceMock {
+				mk := NewPurchaseOrderMock()
+				mk.On("UpdateStatus", 1, "picking", "jdoe").Return(internal.PurchaseOrder{}, internal.ErrPurchaseOrderInvalidTransition)
+				return mk
+			},
+		},
+		{
+			description:  "case 3 - error: Missing required fields",
+			id:           "1",
+			body:         `{}`,
+			expectedBody: `{"message":"purchase order status inputs are missing","error":"unprocessable_entity","code":422,"causes":[{"field":"status","message":"status is required"},{"field":"changed_by","message":"changed by is required"}]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			mock: func() *PurchaseOrderServiceMock {
+				return NewPurchaseOrderMock()
+			},
+		},
+		{
+			description:  "case 4 - error: Unknown status",
+			id:           "1",
+			body:         `{"status": "lost", "changed_by": "jdoe"}`,
+			expectedBody: `{"message":"purchase order inputs are invalid","error":"bad_request","code":400,"causes":[{"field":"status","message":"status must be one of created, confirmed, picking, shipped, delivered or cancelled"}]}`,
+			expectedCode: http.StatusBadRequest,
+			mock: func() *PurchaseOrderServiceMock {
+				mk := NewPurchaseOrderMock()
+				mk.On("UpdateStatus", 1, "lost", "jdoe").Return(internal.PurchaseOrder{}, internal.DomainError{
+					Message: internal.ErrPurchaseOrderBadRequest.Error(),
+					Causes: []internal.Causes{
+						{Field: "status", Message: "status must be one of created, confirmed, picking, shipped, delivered or cancelled"},
+					},
+				})
+				return mk
+			},
+		},
+		{
+			description:  "case 5 - error: Purchase order not found",
+			id:           "99",
+			body:         `{"status": "confirmed", "changed_by": "jdoe"}`,
+			expectedBody: `{"message":"purchase order not found","error":"not_found","code":404,"causes":null}`,
+			expectedCode: http.StatusNotFound,
+			mock: func() *PurchaseOrderServiceMock {
+				mk := NewPurchaseOrderMock()
+				mk.On("UpdateStatus", 99, "confirmed", "jdoe").Return(internal.PurchaseOrder{}, internal.ErrPurchaseOrderNotFound)
+				return mk
+			},
+		},
+		{
+			description:  "case 6 - error: Shipped is set by the shipment",
+			id:           "1",
+			body:         `{"status": "shipped", "changed_by": "jdoe"}`,
+			expectedBody: `{"message":"purchase order is shipped and delivered through its shipment","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *PurchaseOrderServiceMock {
+				mk := NewPurchaseOrderMock()
+				mk.On("UpdateStatus", 1, "shipped", "jdoe").Return(internal.PurchaseOrder{}, internal.ErrPurchaseOrderStatusSetByShipment)
+				return mk
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewPurchaseOrderHandler(sv)
+
+			request := httptest.NewRequest(http.MethodPatch, endpointPurchaseOrder+"/"+tc.id+"/status", strings.NewReader(tc.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+			response := httptest.NewRecorder()
+
+			hd.UpdateStatus()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+		})
+	}
+}
+
+func TestPurchaseOrder_History(t *testing.T) {
+	t.Run("case 1 - success: List the status history", func(t *testing.T) {
+		sv := NewPurchaseOrderMock()
+		sv.On("FindHistory", 1).Return([]internal.PurchaseOrderStatusHistory{
+			{ID: 1, PurchaseOrderID: 1, FromStatus: "created", ToStatus: "confirmed", ChangedBy: "jdoe", ChangedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)},
+		}, nil)
+		hd := handler.NewPurchaseOrderHandler(sv)
+
+		request := httptest.NewRequest(http.MethodGet, endpointPurchaseOrder+"/1/history", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.History()(response, request)
+
+		require.Equal(t, http.StatusOK, response.Code)
+		require.JSONEq(t, `{"data":[{"id":1,"from_status":"created","to_status":"confirmed","changed_by":"jdoe","changed_at":"2025-01-01 10:00:00"}]}`, response.Body.String())
+	})
+
+	t.Run("case 2 - error: Purchase order not found", func(t *testing.T) {
+		sv := NewPurchaseOrderMock()
+		sv.On("FindHistory", 1).Return([]internal.PurchaseOrderStatusHistory{}, internal.ErrPurchaseOrderNotFound)
+		hd := handler.NewPurchaseOrderHandler(sv)
+
+		request := httptest.NewRequest(http.MethodGet, endpointPurchaseOrder+"/1/history", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.History()(response, request)
+
+		require.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
+
+func TestPurchaseOrder_GetAll(t *testing.T) {
+	orderDate := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		description  string
+		query        string
+		expectedBody string
+		expectedCode int
+		mock         func() *PurchaseOrderServiceMock
+	}{
+		{
+			description:  "case 1 - success: List purchase orders filtered by date range and tracking code",
+			query:        "?from=2021-01-01&to=2021-01-31&tracking_code=T1001",
+			expectedBody: `{"data":[{"id":1,"order_number":"PO1001","order_date":"2021-01-01","tracking_code":"T1001","buyer_id":1,"product_record_id":1,"status":"created"}]}`,
+			expectedCode: http.StatusOK,
+			mock: func() *PurchaseOrderServiceMock {
+				mk := NewPurchaseOrderMock()
+				mk.On("FindAll", internal.PurchaseOrderFilter{
+					TrackingCode: "T1001",
+					From:         orderDate,
+					To:           time.Date(2021, 1, 31, 0, 0, 0, 0, time.UTC),
+				}).Return([]internal.PurchaseOrder{
+					{ID: 1, OrderNumber: "PO1001", OrderDate: orderDate, TrackingCode: "T1001", BuyerID: 1, ProductRecordID: 1, Status: "created"},
+				}, nil)
+				return mk
+			},
+		},
+		{
+			description:  "case 2 - success: No purchase orders found",
+			query:        "?buyer_id=3",
+			expectedBody: `{"data":[]}`,
+			expectedCode: http.StatusOK,
+			mock: func() *PurchaseOrderServiceMock {
+				mk := NewPurchaseOrderMock()
+				mk.On("FindAll", internal.PurchaseOrderFilter{BuyerID: 3}).Return([]internal.PurchaseOrder{}, nil)
+				return mk
+			},
+		},
+		{
+			description:  "case 3 - error: Invalid filters",
+			query:        "?from=2021-02-01&to=2021-01-01&buyer_id=abc",
+			expectedBody: `{"message":"Invalid data","error":"bad_request","code":400,"causes":[{"field":"buyer_id","message":"buyer id must be a positive number"},{"field":"from","message":"from must not be after to"}]}`,
+			expectedCode: http.StatusBadRequest,
+			mock: func() *PurchaseOrderServiceMock {
+				return NewPurchaseOrderMock()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewPurchaseOrderHandler(sv)
+
+			request := httptest.NewRequest(http.MethodGet, endpointPurchaseOrder+tc.query, nil)
+			response := httptest.NewRecorder()
+
+			hd.GetAll()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+		})
+	}
+}
+
+func TestPurchaseOrder_GetByID(t *testing.T) {
+	t.Run("case 1 - success: Get a purchase order with its lines", func(t *testing.T) {
+		sv := NewPurchaseOrderMock()
+		sv.On("FindByID", 1).Return(internal.PurchaseOrder{
+			ID: 1, OrderNumber: "PO1001", OrderDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), TrackingCode: "T1001", BuyerID: 1, Status: "picking",
+			Lines: []internal.PurchaseOrderLine{{ID: 1, PurchaseOrderID: 1, ProductID: 2, Quantity: 3, UnitPrice: 45}},
+		}, nil)
+		hd := handler.NewPurchaseOrderHandler(sv)
+
+		request := httptest.NewRequest(http.MethodGet, endpointPurchaseOrder+"/1", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.GetByID()(response, request)
+
+		require.Equal(t, http.StatusOK, response.Code)
+		require.JSONEq(t, `{"data":{"id":1,"order_number":"PO1001","order_date":"2021-01-01","tracking_code":"T1001","buyer_id":1,"product_record_id":0,"status":"picking","lines":[{"id":1,"product_id":2,"quantity":3,"unit_price":45,"reserved_quantity":0,"fulfilled_quantity":0}]}}`, response.Body.String())
+	})
+
+	t.Run("case 2 - error: Purchase order not found", func(t *testing.T) {
+		sv := NewPurchaseOrderMock()
+		sv.On("FindByID", 99).Return(internal.PurchaseOrder{}, internal.ErrPurchaseOrderNotFound)
+		hd := handler.NewPurchaseOrderHandler(sv)
+
+		request := httptest.NewRequest(http.MethodGet, endpointPurchaseOrder+"/99", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "99")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.GetByID()(response, request)
+
+		require.Equal(t, http.StatusNotFound, response.Code)
+		require.JSONEq(t, `{"message":"purchase order not found","error":"not_found","code":404,"causes":null}`, response.Body.String())
+	})
+}
+
+func TestPurchaseOrder_GetByBuyer(t *testing.T) {
+	t.Run("case 1 - success: List the purchase orders of a buyer", func(t *testing.T) {
+		sv := NewPurchaseOrderMock()
+		sv.On("FindByBuyerID", 1, internal.PurchaseOrderFilter{Status: "shipped"}).Return([]internal.PurchaseOrder{
+			{ID: 1, OrderNumber: "PO1001", OrderDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), TrackingCode: "T1001", BuyerID: 1, Status: "shipped"},
+		}, nil)
+		hd := handler.NewPurchaseOrderHandler(sv)
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/buyers/1/purchase-orders?status=shipped", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.GetByBuyer()(response, request)
+
+		require.Equal(t, http.StatusOK, response.Code)
+		require.JSONEq(t, `{"data":[{"id":1,"order_number":"PO1001","order_date":"2021-01-01","tracking_code":"T1001","buyer_id":1,"product_record_id":0,"status":"shipped"}]}`, response.Body.String())
+	})
+
+	t.Run("case 2 - error: Buyer not found", func(t *testing.T) {
+		sv := NewPurchaseOrderMock()
+		sv.On("FindByBuyerID", 99, internal.PurchaseOrderFilter{}).Return([]internal.PurchaseOrder{}, service.ErrBuyerNotFound)
+		hd := handler.NewPurchaseOrderHandler(sv)
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/buyers/99/purchase-orders", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "99")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.GetByBuyer()(response, request)
+
+		require.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
+
+func TestPurchaseOrder_Cancel(t *testing.T) {
+	testCases := []struct {
+		description  string
+		url          string
+		expectedCode int
+		mock         func() *PurchaseOrderServiceMock
+	}{
+		{
+			description:  "case 1 - success: Cancel a purchase order",
+			url:          endpointPurchaseOrder + "/1?changed_by=support",
+			expectedCode: http.StatusNoContent,
+			mock: func() *PurchaseOrderServiceMock {
+				mk := NewPurchaseOrderMock()
+				mk.On("Cancel", 1, "support").Return(internal.PurchaseOrder{ID: 1, Status: "cancelled"}, nil)
+				return mk
+			},
+		},
+		{
+			description:  "case 2 - success: Cancel without naming who cancels",
+			url:          endpointPurchaseOrder + "/1",
+			expectedCode: http.StatusNoContent,
+			mock: func() *PurchaseOrderServiceMock {
+				mk := NewPurchaseOrderMock()
+				mk.On("Cancel", 1, "api").Return(internal.PurchaseOrder{ID: 1, Status: "cancelled"}, nil)
+				return mk
+			},
+		},
+		{
+			description:  "case 3 - error: Purchase order already shipped",
+			url:          endpointPurchaseOrder + "/1",
+			expectedCode: http.StatusConflict,
+			mock: func() *PurchaseOrderServiceMock {
+				mk := NewPurchaseOrderMock()
+				mk.On("Cancel", 1, "api").Return(internal.PurchaseOrder{}, internal.ErrPurchaseOrderInvalidTransition)
+				return mk
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewPurchaseOrderHandler(sv)
+
+			request := httptest.NewRequest(http.MethodDelete, tc.url, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "1")
+			request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+			response := httptest.NewRecorder()
+
+			hd.Cancel()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
+)
+
+// RecallCSVHeader is the header of the recall report in CSV format.
+// Every row is either a batch or a buyer, as told by the type column, and leaves the other columns empty.
+var RecallCSVHeader = []string{
+	"type", "recall_id",
+	"product_batch_id", "batch_number", "product_id", "current_quantity", "due_date", "status",
+	"section_id", "section_number", "warehouse_id", "warehouse_code",
+	"buyer_id", "card_number_id", "first_name", "last_name", "purchase_order_id", "order_number", "order_date",
+}
+
+// RecallJSON is a struct that represents a recall in JSON format
+type RecallJSON struct {
+	ID           int    `json:"id"`
+	ProductID    int    `json:"product_id,omitempty"`
+	BatchNumbers []int  `json:"batch_numbers,omitempty"`
+	Reason       string `json:"reason"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// RecallBatchJSON is a struct that represents a recalled batch and its location in JSON format
+type RecallBatchJSON struct {
+	ProductBatchID  int    `json:"product_batch_id"`
+	BatchNumber     int    `json:"batch_number"`
+	ProductID       int    `json:"product_id"`
+	CurrentQuantity int    `json:"current_quantity"`
+	DueDate         string `json:"due_date"`
+	Status          string `json:"status"`
+	SectionID       int    `json:"section_id"`
+	SectionNumber   int    `json:"section_number"`
+	WarehouseID     int    `json:"warehouse_id"`
+	WarehouseCode   string `json:"warehouse_code"`
+}
+
+// RecallBuyerJSON is a struct that represents an order of a buyer affected by a recall in JSON format
+type RecallBuyerJSON struct {
+	BuyerID         int    `json:"buyer_id"`
+	CardNumberID    string `json:"card_number_id"`
+	FirstName       string `json:"first_name"`
+	LastName        string `json:"last_name"`
+	PurchaseOrderID int    `json:"purchase_order_id"`
+	OrderNumber     string `json:"order_number"`
+	OrderDate       string `json:"order_date"`
+}
+
+// RecallCreatedJSON is a struct that represents a created recall and the batches it affects in JSON format
+type RecallCreatedJSON struct {
+	RecallJSON
+	Batches []RecallBatchJSON `json:"batches"`
+}
+
+// RecallReportJSON is a struct that represents the recall report in JSON format
+type RecallReportJSON struct {
+	Recall  RecallJSON        `json:"recall"`
+	Batches []RecallBatchJSON `json:"batches"`
+	Buyers  []RecallBuyerJSON `json:"buyers"`
+}
+
+// RecallCreateRequest is a struct that represents a request to recall a product or some batches
+type RecallCreateRequest struct {
+	ProductID    int     `json:"product_id"`
+	BatchNumbers []int   `json:"batch_numbers"`
+	Reason       *string `json:"reason"`
+}
+
+// NewRecallHandler creates a new instance of the recall handler
+func NewRecallHandler(sv internal.RecallService) *RecallHandler {
+	return &RecallHandler{
+		sv: sv,
+	}
+}
+
+// RecallHandler is the default implementation of the recall handler
+type RecallHandler struct {
+	sv internal.RecallService
+}
+
+// GetAll returns all the recalls
+// @Summary Get all recalls
+// @Description Retrieve the recalls, the most recent first
+// @Tags Recall
+// @Produce json
+// @Success 200 {object} []handler.RecallJSON "List of recalls"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/recalls [get]
+func (h *RecallHandler) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		recalls, err := h.sv.FindAll()
+		if err != nil {
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			return
+		}
+
+		data := make([]RecallJSON, 0, len(recalls))
+		for _, recall := range recalls {
+			data = append(data, newRecallJSON(recall))
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": data,
+		})
+	}
+}
+
+// GetByID returns the recall report
+// @Summary Get a recall report
+// @Description Retrieve the recalled batches with their sections and warehouses and the buyers who may have received them, as JSON or CSV
+// @Tags Recall
+// @Produce json
+// @Produce text/csv
+// @Param id path int true "Recall ID"
+// @Param format query string false "Report format, json (default) or csv"
+// @Success 200 {object} handler.RecallReportJSON "Recall report"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format or report format"
+// @Failure 404 {object} resterr.RestErr "Recall not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/recalls/{id} [get]
+func (h *RecallHandler) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		format := r.URL.Query().Get("format")
+		if format != "" && format != "json" && format != "csv" {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError("format must be json or csv"))
+			return
+		}
+
+		report, err := h.sv.FindReport(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrRecallNotFound):
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+			default:
+				response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			}
+
+			return
+		}
+
+		if format == "csv" {
+			writeRecallCSV(w, report)
+			return
+		}
+
+		data := RecallReportJSON{
+			Recall:  newRecallJSON(report.Recall),
+			Batches: newRecallBatchesJSON(report.Batches),
+			Buyers:  make([]RecallBuyerJSON, 0, len(report.Buyers)),
+		}
+		for _, buyer := range report.Buyers {
+			data.Buyers = append(data.Buyers, RecallBuyerJSON{
+				BuyerID:         buyer.BuyerID,
+				CardNumberID:    buyer.CardNumberID,
+				FirstName:       buyer.FirstName,
+				LastName:        buyer.LastName,
+				PurchaseOrderID: buyer.PurchaseOrderID,
+				OrderNumber:     buyer.OrderNumber,
+				OrderDate:       buyer.OrderDate.Format(time.DateOnly),
+			})
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": data,
+		})
+	}
+}
+
+// Create creates a recall
+// @Summary Create a recall
+// @Description Recall every batch of a product or the batches with the given numbers
+// @Tags Recall
+// @Accept json
+// @Produce json
+// @Param request body handler.RecallCreateRequest true "Product ID or batch numbers, and the reason"
+// @Success 201 {object} handler.RecallCreatedJSON "Created recall and affected batches"
+// @Failure 400 {object} resterr.RestErr "Invalid data"
+// @Failure 409 {object} resterr.RestErr "Product not found or no batch matches the recall"
+// @Failure 422 {object} resterr.RestErr "Recall inputs are missing"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/recalls [post]
+func (h *RecallHandler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var requestInput RecallCreateRequest
+
+		// decoding the request
+		if err := json.NewDecoder(r.Body).Decode(&requestInput); err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+			return
+		}
+
+		// validating the request input required fields
+		causes := requestInput.ValidateRequiredFields()
+		if len(causes) > 0 {
+			response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityWithCausesError(internal.ErrRecallUnprocessableEntity.Error(), causes))
+			return
+		}
+
+		recall := &internal.Recall{
+			ProductID:    requestInput.ProductID,
+			BatchNumbers: requestInput.BatchNumbers,
+			Reason:       *requestInput.Reason,
+		}
+
+		if err := h.sv.Save(recall); err != nil {
+			var domainError internal.DomainError
+
+			switch {
+			case errors.As(err, &domainError):
+				var restCauses []resterr.Causes
+				for _, cause := range domainError.Causes {
+					restCauses = append(restCauses, resterr.Causes{
+						Field:   cause.Field,
+						Message: cause.Message,
+					})
+				}
+
+				response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+			case errors.Is(err, internal.ErrProductNotFound), errors.Is(err, internal.ErrRecallWithoutBatches):
+				response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+			default:
+				response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			}
+
+			return
+		}
+
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"data": RecallCreatedJSON{
+				RecallJSON: newRecallJSON(*recall),
+				Batches:    newRecallBatchesJSON(recall.Batches),
+			},
+		})
+	}
+}
+
+// Validating the RecallCreateRequest required fields
+func (p *RecallCreateRequest) ValidateRequiredFields() (causes []resterr.Causes) {
+	if p.ProductID == 0 && len(p.BatchNumbers) == 0 {
+		causes = append(causes, resterr.Causes{
+			Field:   "product_id",
+			Message: "product id or batch numbers are required",
+		})
+	}
+	if p.Reason == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "reason",
+			Message: "reason is required",
+		})
+	}
+	return
+}
+
+// writeRecallCSV writes the recall report as a CSV attachment with a row per batch and per buyer
+func writeRecallCSV(w http.ResponseWriter, report internal.RecallReport) {
+	recallID := strconv.Itoa(report.Recall.ID)
+
+	rows := [][]string{RecallCSVHeader}
+	for _, b := range report.Batches {
+		rows = append(rows, []string{
+			"batch", recallID,
+			strconv.Itoa(b.ProductBatchID), strconv.Itoa(b.BatchNumber), strconv.Itoa(b.ProductID),
+			strconv.Itoa(b.CurrentQuantity), b.DueDate, b.Status,
+			strconv.Itoa(b.SectionID), strconv.Itoa(b.SectionNumber), strconv.Itoa(b.WarehouseID), b.WarehouseCode,
+			"", "", "", "", "", "", "",
+		})
+	}
+	for _, b := range report.Buyers {
+		rows = append(rows, []string{
+			"buyer", recallID,
+			"", "", "", "", "", "",
+			"", "", "", "",
+			strconv.Itoa(b.BuyerID), b.CardNumberID, b.FirstName, b.LastName,
+			strconv.Itoa(b.PurchaseOrderID), b.OrderNumber, b.OrderDate.Format(time.DateOnly),
+		})
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=recall-%d.csv", report.Recall.ID))
+	w.WriteHeader(http.StatusOK)
+
+	_ = csv.NewWriter(w).WriteAll(rows)
+}
+
+func newRecallJSON(r internal.Recall) RecallJSON {
+	return RecallJSON{
+		ID:           r.ID,
+		ProductID:    r.ProductID,
+		BatchNumbers: r.BatchNumbers,
+		Reason:       r.Reason,
+		CreatedAt:    r.CreatedAt.Format(time.DateTime),
+	}
+}
+
+func newRecallBatchesJSON(batches []internal.RecallBatch) []RecallBatchJSON {
+	data := make([]RecallBatchJSON, 0, len(batches))
+	for _, b := range batches {
+		data = append(data, RecallBatchJSON{
+			ProductBatchID:  b.ProductBatchID,
+			BatchNumber:     b.BatchNumber,
+			ProductID:       b.ProductID,
+			CurrentQuantity: b.CurrentQuantity,
+			DueDate:         b.DueDate,
+			Status:          b.Status,
+			SectionID:       b.SectionID,
+			SectionNumber:   b.SectionNumber,
+			WarehouseID:     b.WarehouseID,
+			WarehouseCode:   b.WarehouseCode,
+		})
+	}
+
+	return data
+}
@@ -0,0 +1,226 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/handler"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewRecallServiceMock() *RecallServiceMock {
+	return &RecallServiceMock{}
+}
+
+type RecallServiceMock struct {
+	mock.Mock
+}
+
+func (m *RecallServiceMock) FindAll() ([]internal.Recall, error) {
+	args := m.Called()
+	return args.Get(0).([]internal.Recall), args.Error(1)
+}
+
+func (m *RecallServiceMock) FindReport(id int) (internal.RecallReport, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.RecallReport), args.Error(1)
+}
+
+func (m *RecallServiceMock) Save(recall *internal.Recall) error {
+	args := m.Called(recall)
+	return args.Error(0)
+}
+
+func TestRecall_Create(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		description       string
+		body              string
+		expectedBody      string
+		expectedCode      int
+		mock              func() *RecallServiceMock
+		expectedMockCalls int
+	}{
+		{
+			description:  "case 1 - success: Recall some batches",
+			body:         `{"batch_numbers": [10], "reason": "listeria"}`,
+			expectedBody: `{"data":{"id":1,"batch_numbers":[10],"reason":"listeria","created_at":"2025-01-01 10:00:00","batches":[{"product_batch_id":3,"batch_number":10,"product_id":1,"current_quantity":50,"due_date":"2025-02-01","status":"released","section_id":2,"section_number":5,"warehouse_id":1,"warehouse_code":"WH1"}]}}`,
+			expectedCode: http.StatusCreated,
+			mock: func() *RecallServiceMock {
+				mk := NewRecallServiceMock()
+				mk.On("Save", mock.Anything).Run(func(args mock.Arguments) {
+					recall := args.Get(0).(*internal.Recall)
+					recall.ID = 1
+					recall.CreatedAt = createdAt
+					recall.Batches = []internal.RecallBatch{
+						{ProductBatchID: 3, BatchNumber: 10, ProductID: 1, CurrentQuantity: 50, DueDate: "2025-02-01", Status: "released", SectionID: 2, SectionNumber: 5, WarehouseID: 1, WarehouseCode: "WH1"},
+					}
+				}).Return(nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 2 - error: Missing required fields",
+			body:         `{}`,
+			expectedBody: `{"message":"recall inputs are missing","error":"unprocessable_entity","code":422,"causes":[{"field":"product_id","message":"product id or batch numbers are required"},{"field":"reason","message":"reason is required"}]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			mock: func() *RecallServiceMock {
+				return NewRecallServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+		{
+			description:  "case 3 - error: Product without batches",
+			body:         `{"product_id": 1, "reason": "listeria"}`,
+			expectedBody: `{"message":"recall does not match any product batch","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *RecallServiceMock {
+				mk := NewRecallServiceMock()
+				mk.On("Save", mock.Anything).Return(internal.ErrRecallWithoutBatches)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 4 - error: Invalid data",
+			body:         `{"product_id": "one"}`,
+			expectedBody: `{"message":"Invalid data","error":"bad_request","code":400,"causes":null}`,
+			expectedCode: http.StatusBadRequest,
+			mock: func() *RecallServiceMock {
+				return NewRecallServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewRecallHandler(sv)
+
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/recalls", strings.NewReader(tc.body))
+			response := httptest.NewRecorder()
+
+			hd.Create()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+			sv.AssertNumberOfCalls(t, "Save", tc.expectedMockCalls)
+		})
+	}
+}
+
+func TestRecall_GetByID(t *testing.T) {
+	report := internal.RecallReport{
+		Recall: internal.Recall{ID: 1, ProductID: 1, Reason: "listeria", CreatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)},
+		Batches: []internal.RecallBatch{
+			{ProductBatchID: 3, BatchNumber: 10, ProductID: 1, CurrentQuantity: 50, DueDate: "2025-02-01", Status: "released", SectionID: 2, SectionNumber: 5, WarehouseID: 1, WarehouseCode: "WH1"},
+		},
+		Buyers: []internal.RecallBuyer{
+			{BuyerID: 1, CardNumberID: "C-1", FirstName: "Ana", LastName: "Silva", PurchaseOrderID: 4, OrderNumber: "PO-4", OrderDate: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	testCases := []struct {
+		description        string
+		id                 string
+		query              string
+		expectedBody       string
+		expectedCode       int
+		expectedHeader     string
+		mock               func() *RecallServiceMock
+		expectedMockCalls  int
+		expectedBodyIsJSON bool
+	}{
+		{
+			description:        "case 1 - success: JSON report",
+			id:                 "1",
+			expectedBody:       `{"data":{"recall":{"id":1,"product_id":1,"reason":"listeria","created_at":"2025-01-01 10:00:00"},"batches":[{"product_batch_id":3,"batch_number":10,"product_id":1,"current_quantity":50,"due_date":"2025-02-01","status":"released","section_id":2,"section_number":5,"warehouse_id":1,"warehouse_code":"WH1"}],"buyers":[{"buyer_id":1,"card_number_id":"C-1","first_name":"Ana","last_name":"Silva","purchase_order_id":4,"order_number":"PO-4","order_date":"2025-01-15"}]}}`,
+			expectedCode:       http.StatusOK,
+			expectedHeader:     "application/json",
+			expectedBodyIsJSON: true,
+			mock: func() *RecallServiceMock {
+				mk := NewRecallServiceMock()
+				mk.On("FindReport", 1).Return(report, nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description: "case 2 - success: CSV report",
+			id:          "1",
+			query:       "?format=csv",
+			expectedBody: "type,recall_id,product_batch_id,batch_number,product_id,current_quantity,due_date,status,section_id,section_number,warehouse_id,warehouse_code,buyer_id,card_number_id,first_name,last_name,purchase_order_id,order_number,order_date\n" +
+				"batch,1,3,10,1,50,2025-02-01,released,2,5,1,WH1,,,,,,,\n" +
+				"buyer,1,,,,,,,,,,,1,C-1,Ana,Silva,4,PO-4,2025-01-15\n",
+			expectedCode:   http.StatusOK,
+			expectedHeader: "text/csv",
+			mock: func() *RecallServiceMock {
+				mk := NewRecallServiceMock()
+				mk.On("FindReport", 1).Return(report, nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:        "case 3 - error: Recall not found",
+			id:                 "99",
+			expectedBody:       `{"message":"recall not found","error":"not_found","code":404,"causes":null}`,
+			expectedCode:       http.StatusNotFound,
+			expectedHeader:     "application/json",
+			expectedBodyIsJSON: true,
+			mock: func() *RecallServiceMock {
+				mk := NewRecallServiceMock()
+				mk.On("FindReport", 99).Return(internal.RecallReport{}, internal.ErrRecallNotFound)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:        "case 4 - error: Unknown format",
+			id:                 "1",
+			query:              "?format=xml",
+			expectedBody:       `{"message":"format must be json or csv","error":"bad_request","code":400,"causes":null}`,
+			expectedCode:       http.StatusBadRequest,
+			expectedHeader:     "application/json",
+			expectedBodyIsJSON: true,
+			mock: func() *RecallServiceMock {
+				return NewRecallServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewRecallHandler(sv)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/recalls/"+tc.id+tc.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+			response := httptest.NewRecorder()
+
+			hd.GetByID()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.Equal(t, tc.expectedHeader, response.Header().Get("Content-Type"))
+			if tc.expectedBodyIsJSON {
+				require.JSONEq(t, tc.expectedBody, response.Body.String())
+			} else {
+				require.Equal(t, tc.expectedBody, response.Body.String())
+			}
+			sv.AssertNumberOfCalls(t, "FindReport", tc.expectedMockCalls)
+		})
+	}
+}
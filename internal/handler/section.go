@@ -237,9 +237,10 @@ func (h *SectionHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Param id path int true "Section ID"
 // @Param updates body map[string]interface{} true "Updated section data"
 // @Success 200 {object} internal.Section "Updated Section"
-// @Failure 400 {object} resterr.RestErr "Bad Request"
+// @Failure 400 {object} resterr.RestErr "Bad Request or stored batches not compatible with the product type"
 // @Failure 404 {object} resterr.RestErr "Section not found"
-// @Failure 409 {object} resterr.RestErr "Section with given section number already registered"
+// @Failure 409 {object} resterr.RestErr "Section with given section number already registered or maximum capacity below the current capacity"
+// @Failure 422 {object} resterr.RestErr "Couldn't parse section or current capacity patched"
 // @Router /api/v1/sections/{id} [patch]
 func (h *SectionHandler) Update(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(chi.URLParam(r, "id"))
@@ -267,16 +268,31 @@ func (h *SectionHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	section, err := h.sv.Update(id, stPatch)
 	if err != nil {
-		if errors.Is(err, internal.ErrSectionNotFound) {
+		var domainError internal.DomainError
+
+		switch {
+		case errors.As(err, &domainError):
+			var restCauses []resterr.Causes
+			for _, cause := range domainError.Causes {
+				restCauses = append(restCauses, resterr.Causes{
+					Field:   cause.Field,
+					Message: cause.Message,
+				})
+			}
+
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+		case errors.Is(err, internal.ErrSectionNotFound):
 			response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
-		} else if errors.Is(err, internal.ErrSectionUnprocessableEntity) {
+		case errors.Is(err, internal.ErrSectionUnprocessableEntity),
+			errors.Is(err, internal.ErrSectionCurrentCapacityReadOnly):
 			response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityError(err.Error()))
-		} else if errors.Is(err, internal.ErrSectionNumberAlreadyInUse) {
+		case errors.Is(err, internal.ErrSectionNumberAlreadyInUse),
+			errors.Is(err, internal.ErrSectionMaximumCapacityBelowCurrent):
 			response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+		default:
+			response.JSON(w, http.StatusInternalServerError, nil)
 		}
 
-		response.JSON(w, http.StatusInternalServerError, nil)
-
 		return
 	}
 
@@ -285,6 +301,41 @@ func (h *SectionHandler) Update(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GetOccupancy returns how much of a section capacity is in use
+// @Summary Retrieve the occupancy of a section
+// @Description Fetches the capacities of a section, the room left and the number of batches stored in it. Capacities are counted in product units.
+// @Tags Section
+// @Produce json
+// @Param id path int true "Section ID"
+// @Success 200 {object} internal.SectionOccupancy "Section occupancy"
+// @Failure 400 {object} resterr.RestErr "Bad Request"
+// @Failure 404 {object} resterr.RestErr "Section not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/sections/{id}/occupancy [get]
+func (h *SectionHandler) GetOccupancy(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(err.Error()))
+		return
+	}
+
+	occupancy, err := h.sv.FindOccupancy(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, internal.ErrSectionNotFound):
+			response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+		default:
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+		}
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]any{
+		"data": occupancy,
+	})
+}
+
 // Delete deletes a section
 // @Summary Delete a section
 // @Description Deletes a section identified by its Id
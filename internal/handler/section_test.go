@@ -72,6 +72,11 @@ func (m *MockSectionService) Delete(id int) error {
 	return args.Error(0)
 }
 
+func (m *MockSectionService) FindOccupancy(id int) (internal.SectionOccupancy, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.SectionOccupancy), args.Error(1)
+}
+
 func float64Ptr(f float64) *float64 {
 	return &f
 }
@@ -624,7 +629,6 @@ func TestHandler_UpdateSectionUnitTest(t *testing.T) {
 					SectionNumber:      intPtr(123),
 					CurrentTemperature: float64Ptr(22.5),
 					MinimumTemperature: float64Ptr(15.0),
-					CurrentCapacity:    intPtr(50),
 					MinimumCapacity:    intPtr(30),
 					MaximumCapacity:    intPtr(100),
 					WarehouseID:        intPtr(1),
@@ -636,7 +640,6 @@ func TestHandler_UpdateSectionUnitTest(t *testing.T) {
 				SectionNumber:      intPtr(123),
 				CurrentTemperature: float64Ptr(22.5),
 				MinimumTemperature: float64Ptr(15.0),
-				CurrentCapacity:    intPtr(50),
 				MinimumCapacity:    intPtr(30),
 				MaximumCapacity:    intPtr(100),
 				WarehouseID:        intPtr(1),
@@ -713,6 +716,45 @@ func TestHandler_UpdateSectionUnitTest(t *testing.T) {
 			expectedStatusCode: http.StatusConflict,
 			expectedResponse:   *resterr.NewConflictError("section with given section number already registered"),
 		},
+		{
+			name: "should return unprocessable entity error when current capacity is patched",
+			mockSetup: func(m *MockSectionService) {
+				m.On("Update", 1, mock.Anything).Return(internal.Section{}, internal.ErrSectionCurrentCapacityReadOnly)
+			},
+			id: "1",
+			requestBody: handler.SectionsUpdateJSON{
+				CurrentCapacity: intPtr(150),
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse:   *resterr.NewUnprocessableEntityError("section current capacity is kept by its stock and cannot be patched"),
+		},
+		{
+			name: "should return conflict error when maximum capacity is below the current capacity",
+			mockSetup: func(m *MockSectionService) {
+				m.On("Update", 1, mock.Anything).Return(internal.Section{}, internal.ErrSectionMaximumCapacityBelowCurrent)
+			},
+			id: "1",
+			requestBody: handler.SectionsUpdateJSON{
+				MaximumCapacity: intPtr(10),
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   *resterr.NewConflictError("section maximum capacity cannot be below its current capacity"),
+		},
+		{
+			name: "should return bad request error when the stored batches cannot keep the product type",
+			mockSetup: func(m *MockSectionService) {
+				m.On("Update", 1, mock.Anything).Return(internal.Section{}, internal.NewProductTypeIncompatibleError(internal.Section{ID: 1, ProductTypeID: 8}, 7))
+			},
+			id: "1",
+			requestBody: handler.SectionsUpdateJSON{
+				ProductTypeID: intPtr(8),
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: *resterr.NewBadRequestValidationError("product-type is not compatible with the section", []resterr.Causes{{
+				Field:   "section_id",
+				Message: "section 1 stores product type 8 and cannot hold product type 7",
+			}}),
+		},
 		{
 			name: "should return not found error",
 			mockSetup: func(m *MockSectionService) {
@@ -889,3 +931,54 @@ func TestHandler_DeleteSectionUnitTest(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_GetOccupancySectionUnitTest(t *testing.T) {
+	tests := []struct {
+		name               string
+		id                 string
+		mockSetup          func(*MockSectionService)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "successfully get the occupancy",
+			id:   "1",
+			mockSetup: func(m *MockSectionService) {
+				m.On("FindOccupancy", 1).Return(internal.SectionOccupancy{
+					SectionID: 1, SectionNumber: 10, CurrentCapacity: 150, MinimumCapacity: 20, MaximumCapacity: 200,
+					AvailableCapacity: 50, OccupancyPercentage: 75, BatchesCount: 3,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":{"section_id":1,"section_number":10,"current_capacity":150,"minimum_capacity":20,"maximum_capacity":200,"available_capacity":50,"occupancy_percentage":75,"below_minimum":false,"batches_count":3}}`,
+		},
+		{
+			name: "fail when the section does not exist",
+			id:   "99",
+			mockSetup: func(m *MockSectionService) {
+				m.On("FindOccupancy", 99).Return(internal.SectionOccupancy{}, internal.ErrSectionNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"message":"section not found","error":"not_found","code":404,"causes":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSectionService)
+			tt.mockSetup(mockService)
+			hd := handler.NewHandlerSection(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/sections/"+tt.id+"/occupancy", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rr := httptest.NewRecorder()
+
+			hd.GetOccupancy(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
@@ -32,6 +32,7 @@ type SellersGetJSON struct {
 	Address     string `json:"address"`
 	Telephone   string `json:"telephone"`
 	Locality    int    `json:"locality_id"`
+	Status      string `json:"status"`
 }
 
 type SellersPostJSON struct {
@@ -40,6 +41,19 @@ type SellersPostJSON struct {
 	Address     string `json:"address"`
 	Telephone   string `json:"telephone"`
 	Locality    int    `json:"locality_id"`
+	Status      string `json:"status"`
+}
+
+type SellerReportJSON struct {
+	SellerID           int     `json:"seller_id"`
+	CID                int     `json:"cid"`
+	CompanyName        string  `json:"company_name"`
+	ProductsCount      int     `json:"products_count"`
+	LiveQuantity       int     `json:"live_quantity"`
+	UnitsSold          int     `json:"units_sold"`
+	Revenue            float64 `json:"revenue"`
+	ExpiredQuantity    int     `json:"expired_quantity"`
+	WrittenOffQuantity int     `json:"written_off_quantity"`
 }
 
 type SellersUpdateJSON struct {
@@ -48,6 +62,7 @@ type SellersUpdateJSON struct {
 	Address     *string `json:"address"`
 	Telephone   *string `json:"telephone"`
 	Locality    *int    `json:"locality_id"`
+	Status      *string `json:"status"`
 }
 
 // GetAll returns all sellers
@@ -75,6 +90,7 @@ func (h *SellerDefault) GetAll() http.HandlerFunc {
 				CompanyName: all[i].CompanyName,
 				Address:     all[i].Address,
 				Telephone:   all[i].Telephone,
+				Status:      all[i].Status,
 			})
 		}
 
@@ -118,6 +134,7 @@ func (h *SellerDefault) GetByID() http.HandlerFunc {
 			Address:     seller.Address,
 			Telephone:   seller.Telephone,
 			Locality:    seller.Locality,
+			Status:      seller.Status,
 		}
 
 		response.JSON(w, http.StatusOK, map[string]any{
@@ -156,6 +173,7 @@ func (h *SellerDefault) Save() http.HandlerFunc {
 			Address:     body.Address,
 			Telephone:   body.Telephone,
 			Locality:    body.Locality,
+			Status:      body.Status,
 		}
 
 		err = h.sv.Save(sl)
@@ -210,6 +228,7 @@ func (h *SellerDefault) Update() http.HandlerFunc {
 			Address:     body.Address,
 			Telephone:   body.Telephone,
 			Locality:    body.Locality,
+			Status:      body.Status,
 		}
 
 		seller, err := h.sv.Update(id, slPatch)
@@ -226,14 +245,15 @@ func (h *SellerDefault) Update() http.HandlerFunc {
 				Address:     seller.Address,
 				Telephone:   seller.Telephone,
 				Locality:    seller.Locality,
+				Status:      seller.Status,
 			},
 		})
 	}
 }
 
-// Delete deletes a seller
-// @Summary Delete a seller
-// @Description Removes a seller from the system based on the provided Id
+// Delete offboards a seller
+// @Summary Offboard a seller
+// @Description Deactivates the seller with the provided Id, its products and their history are kept
 // @Tags Seller
 // @Produce json
 // @Param id path int true "Seller ID"
@@ -262,6 +282,196 @@ func (h *SellerDefault) Delete() http.HandlerFunc {
 	}
 }
 
+// HardDelete deletes a seller and its products
+// @Summary Delete a seller for good
+// @Description Removes the seller with the provided Id and its products, refused when the products have batches, records, orders or recalls
+// @Tags Seller
+// @Produce json
+// @Param id path int true "Seller ID"
+// @Success 204 {object} nil "No Content"
+// @Failure 400 {object} resterr.RestErr "Bad Request"
+// @Failure 404 {object} resterr.RestErr "Seller not found"
+// @Failure 409 {object} resterr.RestErr "Seller has products with batches, records or orders"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/admin/sellers/{id} [delete]
+func (h *SellerDefault) HardDelete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(err.Error()))
+			return
+		}
+
+		err = h.sv.HardDelete(id)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusNoContent, nil)
+	}
+}
+
+// GetProducts returns the products of a seller
+// @Summary Retrieve the products of a seller
+// @Description Fetches the catalogue of the seller with the provided ID
+// @Tags Seller
+// @Produce json
+// @Param id path int true "Seller ID"
+// @Success 200 {object} []internal.Product "Products of the seller"
+// @Failure 400 {object} resterr.RestErr "Bad Request"
+// @Failure 404 {object} resterr.RestErr "Seller not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/sellers/{id}/products [get]
+func (h *SellerDefault) GetProducts() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(err.Error()))
+			return
+		}
+
+		products, err := h.sv.FindProducts(id)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		if products == nil {
+			products = []internal.Product{}
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": products,
+		})
+	}
+}
+
+// ReportByID returns the performance report of a seller
+// @Summary Report a seller
+// @Description Reports the product count, live batch quantity, units sold, revenue and expired and written-off units of a seller
+// @Tags Seller
+// @Produce json
+// @Param id path int true "Seller ID"
+// @Success 200 {object} SellerReportJSON "Seller report"
+// @Failure 400 {object} resterr.RestErr "Bad Request"
+// @Failure 404 {object} resterr.RestErr "Seller not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/sellers/{id}/report [get]
+func (h *SellerDefault) ReportByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(err.Error()))
+			return
+		}
+
+		report, err := h.sv.ReportByID(id)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": newSellerReportJSON(report),
+		})
+	}
+}
+
+// Report returns the performance report of every seller
+// @Summary Report every seller
+// @Description Reports the performance of every seller, sorted and paginated
+// @Tags Seller
+// @Produce json
+// @Param sort query string false "Sort field: id, company_name, products_count, live_quantity, units_sold, revenue, expired_quantity or written_off_quantity" default(id)
+// @Param order query string false "asc or desc" default(asc)
+// @Param limit query int false "Page size, at most 100" default(20)
+// @Param offset query int false "Sellers to skip" default(0)
+// @Success 200 {object} map[string]any "Sellers reports and pagination"
+// @Failure 400 {object} resterr.RestErr "Bad Request"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/sellers/report [get]
+func (h *SellerDefault) Report() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := internal.SellerReportQuery{
+			SortBy: "id",
+			Order:  "asc",
+			Limit:  internal.SellerReportDefaultLimit,
+		}
+
+		if sort := r.URL.Query().Get("sort"); sort != "" {
+			query.SortBy = sort
+		}
+
+		if order := r.URL.Query().Get("order"); order != "" {
+			query.Order = order
+		}
+
+		params := []struct {
+			name  string
+			value *int
+		}{
+			{"limit", &query.Limit},
+			{"offset", &query.Offset},
+		}
+
+		for _, param := range params {
+			valueStr := r.URL.Query().Get(param.name)
+			if valueStr == "" {
+				continue
+			}
+
+			value, err := strconv.Atoi(valueStr)
+			if err != nil {
+				response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(param.name+" should be a number"))
+				return
+			}
+
+			*param.value = value
+		}
+
+		reports, total, err := h.sv.Report(query)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		data := make([]SellerReportJSON, 0, len(reports))
+		for _, report := range reports {
+			data = append(data, newSellerReportJSON(report))
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": data,
+			"pagination": map[string]int{
+				"total":  total,
+				"limit":  query.Limit,
+				"offset": query.Offset,
+			},
+		})
+	}
+}
+
+func newSellerReportJSON(report internal.SellerReport) SellerReportJSON {
+	return SellerReportJSON{
+		SellerID:           report.SellerID,
+		CID:                report.CID,
+		CompanyName:        report.CompanyName,
+		ProductsCount:      report.ProductsCount,
+		LiveQuantity:       report.LiveQuantity,
+		UnitsSold:          report.UnitsSold,
+		Revenue:            report.Revenue,
+		ExpiredQuantity:    report.ExpiredQuantity,
+		WrittenOffQuantity: report.WrittenOffQuantity,
+	}
+}
+
 func (h *SellerDefault) handleError(w http.ResponseWriter, err error) {
 	if errors.As(err, &internal.DomainError{}) {
 		var domainError internal.DomainError
@@ -280,14 +490,14 @@ func (h *SellerDefault) handleError(w http.ResponseWriter, err error) {
 		return
 	}
 
-	if errors.Is(err, internal.ErrSellerInvalidFields) {
+	if errors.Is(err, internal.ErrSellerInvalidFields) || errors.Is(err, internal.ErrSellerReportBadRequest) {
 		restErr := resterr.NewBadRequestError(err.Error())
 		response.JSON(w, restErr.Code, restErr)
 
 		return
 	}
 
-	if errors.Is(err, internal.ErrSellerConflict) || errors.Is(err, internal.ErrSellerCIDAlreadyExists) {
+	if errors.Is(err, internal.ErrSellerConflict) || errors.Is(err, internal.ErrSellerCIDAlreadyExists) || errors.Is(err, internal.ErrSellerHasHistory) {
 		response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
 
 		return
@@ -53,6 +53,30 @@ func (m *MockSellerService) Delete(id int) error {
 	return args.Error(0)
 }
 
+// HardDelete mock
+func (m *MockSellerService) HardDelete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// FindProducts mock
+func (m *MockSellerService) FindProducts(id int) ([]internal.Product, error) {
+	args := m.Called(id)
+	return args.Get(0).([]internal.Product), args.Error(1)
+}
+
+// Report mock
+func (m *MockSellerService) Report(query internal.SellerReportQuery) ([]internal.SellerReport, int, error) {
+	args := m.Called(query)
+	return args.Get(0).([]internal.SellerReport), args.Int(1), args.Error(2)
+}
+
+// ReportByID mock
+func (m *MockSellerService) ReportByID(id int) (internal.SellerReport, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.SellerReport), args.Error(1)
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -706,3 +730,202 @@ func TestSellerDefault_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestSellerDefault_HardDelete(t *testing.T) {
+	tests := []struct {
+		name               string
+		mockSetup          func(*MockSellerService)
+		id                 string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "should delete a seller without history",
+			mockSetup: func(m *MockSellerService) {
+				m.On("HardDelete", 1).Return(nil)
+			},
+			id:                 "1",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name: "should return conflict for a seller with history",
+			mockSetup: func(m *MockSellerService) {
+				m.On("HardDelete", 1).Return(internal.ErrSellerHasHistory)
+			},
+			id:                 "1",
+			expectedStatusCode: http.StatusConflict,
+			expectedBody:       `{"message":"seller has products with batches, records or orders","error":"conflict","code":409,"causes":null}`,
+		},
+		{
+			name: "should return not found for an unknown seller",
+			mockSetup: func(m *MockSellerService) {
+				m.On("HardDelete", 99).Return(internal.ErrSellerNotFound)
+			},
+			id:                 "99",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"message":"seller not found","error":"not_found","code":404,"causes":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSellerService)
+			tt.mockSetup(mockService)
+			hd := handler.NewSellerDefault(mockService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/sellers/"+tt.id, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			res := httptest.NewRecorder()
+
+			hd.HardDelete()(res, req)
+
+			assert.Equal(t, tt.expectedStatusCode, res.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, res.Body.String())
+			}
+		})
+	}
+}
+
+func TestSellerDefault_GetProducts(t *testing.T) {
+	tests := []struct {
+		name               string
+		mockSetup          func(*MockSellerService)
+		id                 string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "should return an empty catalogue",
+			mockSetup: func(m *MockSellerService) {
+				m.On("FindProducts", 1).Return([]internal.Product(nil), nil)
+			},
+			id:                 "1",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":[]}`,
+		},
+		{
+			name: "should return not found for an unknown seller",
+			mockSetup: func(m *MockSellerService) {
+				m.On("FindProducts", 99).Return([]internal.Product(nil), internal.ErrSellerNotFound)
+			},
+			id:                 "99",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"message":"seller not found","error":"not_found","code":404,"causes":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSellerService)
+			tt.mockSetup(mockService)
+			hd := handler.NewSellerDefault(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/sellers/"+tt.id+"/products", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			res := httptest.NewRecorder()
+
+			hd.GetProducts()(res, req)
+
+			assert.Equal(t, tt.expectedStatusCode, res.Code)
+			assert.JSONEq(t, tt.expectedBody, res.Body.String())
+		})
+	}
+}
+
+func TestSellerDefault_ReportByID(t *testing.T) {
+	mockService := new(MockSellerService)
+	mockService.On("ReportByID", 1).Return(internal.SellerReport{
+		SellerID:           1,
+		CID:                101,
+		CompanyName:        "Fresh Farms",
+		ProductsCount:      3,
+		LiveQuantity:       120,
+		UnitsSold:          15,
+		Revenue:            1050.5,
+		ExpiredQuantity:    10,
+		WrittenOffQuantity: 4,
+	}, nil)
+	hd := handler.NewSellerDefault(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/sellers/1/report", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	res := httptest.NewRecorder()
+
+	hd.ReportByID()(res, req)
+
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.JSONEq(t, `{"data":{"seller_id":1,"cid":101,"company_name":"Fresh Farms","products_count":3,"live_quantity":120,"units_sold":15,"revenue":1050.5,"expired_quantity":10,"written_off_quantity":4}}`, res.Body.String())
+}
+
+func TestSellerDefault_Report(t *testing.T) {
+	tests := []struct {
+		name               string
+		mockSetup          func(*MockSellerService)
+		query              string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "should use the default sort and page",
+			mockSetup: func(m *MockSellerService) {
+				m.On("Report", internal.SellerReportQuery{SortBy: "id", Order: "asc", Limit: 20}).Return([]internal.SellerReport(nil), 0, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":[],"pagination":{"total":0,"limit":20,"offset":0}}`,
+		},
+		{
+			name: "should sort and paginate the report",
+			mockSetup: func(m *MockSellerService) {
+				m.On("Report", internal.SellerReportQuery{SortBy: "revenue", Order: "desc", Limit: 1, Offset: 1}).Return([]internal.SellerReport{
+					{SellerID: 2, CID: 102, CompanyName: "Green Valley", Revenue: 300},
+				}, 3, nil)
+			},
+			query:              "?sort=revenue&order=desc&limit=1&offset=1",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":[{"seller_id":2,"cid":102,"company_name":"Green Valley","products_count":0,"live_quantity":0,"units_sold":0,"revenue":300,"expired_quantity":0,"written_off_quantity":0}],"pagination":{"total":3,"limit":1,"offset":1}}`,
+		},
+		{
+			name:               "should return bad request for a limit that is not a number",
+			mockSetup:          func(m *MockSellerService) {},
+			query:              "?limit=all",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"message":"limit should be a number","error":"bad_request","code":400,"causes":null}`,
+		},
+		{
+			name: "should return bad request for an invalid order",
+			mockSetup: func(m *MockSellerService) {
+				m.On("Report", mock.Anything).Return([]internal.SellerReport(nil), 0, internal.DomainError{
+					Message: internal.ErrSellerReportBadRequest.Error(),
+					Causes:  []internal.Causes{{Field: "order", Message: "order must be asc or desc"}},
+				})
+			},
+			query:              "?order=up",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"message":"seller report inputs are invalid","error":"bad_request","code":400,"causes":[{"field":"order","message":"order must be asc or desc"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSellerService)
+			tt.mockSetup(mockService)
+			hd := handler.NewSellerDefault(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/sellers/report"+tt.query, nil)
+			res := httptest.NewRecorder()
+
+			hd.Report()(res, req)
+
+			assert.Equal(t, tt.expectedStatusCode, res.Code)
+			assert.JSONEq(t, tt.expectedBody, res.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
+)
+
+// ShipmentJSON is a struct that represents a shipment in JSON format
+type ShipmentJSON struct {
+	ID               int     `json:"id"`
+	TrackingCode     string  `json:"tracking_code"`
+	CarryID          int     `json:"carry_id"`
+	WarehouseID      int     `json:"warehouse_id"`
+	Status           string  `json:"status"`
+	PurchaseOrderIDs []int   `json:"purchase_order_ids"`
+	CreatedAt        string  `json:"created_at"`
+	DispatchedAt     *string `json:"dispatched_at"`
+	DeliveredAt      *string `json:"delivered_at"`
+}
+
+// ShipmentCreateRequest is a struct that represents a request to ship purchase orders with a carry
+type ShipmentCreateRequest struct {
+	CarryID          *int  `json:"carry_id"`
+	WarehouseID      *int  `json:"warehouse_id"`
+	PurchaseOrderIDs []int `json:"purchase_order_ids"`
+}
+
+// NewShipmentHandler creates a new instance of the shipment handler
+func NewShipmentHandler(sv internal.ShipmentService) *ShipmentHandler {
+	return &ShipmentHandler{
+		sv: sv,
+	}
+}
+
+// ShipmentHandler is the default implementation of the shipment handler
+type ShipmentHandler struct {
+	sv internal.ShipmentService
+}
+
+// GetAll returns the shipments
+// @Summary Get all shipments
+// @Description Retrieve the shipments, optionally only the ones in a status such as dispatched
+// @Tags Shipment
+// @Produce json
+// @Param status query string false "Status (created, dispatched or delivered)"
+// @Success 200 {object} []handler.ShipmentJSON "List of shipments"
+// @Failure 400 {object} resterr.RestErr "Invalid status"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/shipments [get]
+func (h *ShipmentHandler) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := r.URL.Query().Get("status")
+
+		switch status {
+		case "", internal.ShipmentStatusCreated, internal.ShipmentStatusDispatched, internal.ShipmentStatusDelivered:
+		default:
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError("status must be created, dispatched or delivered"))
+			return
+		}
+
+		shipments, err := h.sv.FindAll(status)
+		if err != nil {
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			return
+		}
+
+		data := make([]ShipmentJSON, 0, len(shipments))
+		for _, s := range shipments {
+			data = append(data, newShipmentJSON(s))
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": data,
+		})
+	}
+}
+
+// GetByID returns a shipment by ID
+// @Summary Get a shipment by ID
+// @Description Retrieve the shipment with the given ID and its purchase orders
+// @Tags Shipment
+// @Produce json
+// @Param id path int true "Shipment ID"
+// @Success 200 {object} handler.ShipmentJSON "Shipment"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Shipment not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/shipments/{id} [get]
+func (h *ShipmentHandler) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		s, err := h.sv.FindByID(id)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": newShipmentJSON(s),
+		})
+	}
+}
+
+// Create assigns purchase orders of a warehouse to a carry
+// @Summary Create a shipment
+// @Description Assign picked purchase orders of a warehouse to a carry. The tracking code is generated and copied to the purchase orders.
+// @Tags Shipment
+// @Accept json
+// @Produce json
+// @Param request body handler.ShipmentCreateRequest true "Carry, warehouse and purchase orders"
+// @Success 201 {object} handler.ShipmentJSON "Created shipment"
+// @Failure 400 {object} resterr.RestErr "Invalid data, purchase order not picked, not fulfilled or stored in another warehouse"
+// @Failure 409 {object} resterr.RestErr "Carry, warehouse or purchase order not found or purchase order already shipped"
+// @Failure 422 {object} resterr.RestErr "Shipment inputs are missing"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/shipments [post]
+func (h *ShipmentHandler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var requestInput ShipmentCreateRequest
+
+		// decoding the request
+		if err := json.NewDecoder(r.Body).Decode(&requestInput); err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+			return
+		}
+
+		// validating the request input required fields
+		causes := requestInput.ValidateRequiredFields()
+		if len(causes) > 0 {
+			response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityWithCausesError(internal.ErrShipmentUnprocessableEntity.Error(), causes))
+			return
+		}
+
+		s := internal.Shipment{
+			CarryID:          *requestInput.CarryID,
+			WarehouseID:      *requestInput.WarehouseID,
+			PurchaseOrderIDs: requestInput.PurchaseOrderIDs,
+		}
+
+		if err := h.sv.Save(&s); err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"data": newShipmentJSON(s),
+		})
+	}
+}
+
+// Dispatch hands a shipment over to its carry
+// @Summary Dispatch a shipment
+// @Description Mark a created shipment as dispatched and its purchase orders as shipped
+// @Tags Shipment
+// @Produce json
+// @Param id path int true "Shipment ID"
+// @Success 200 {object} handler.ShipmentJSON "Dispatched shipment"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Shipment not found"
+// @Failure 409 {object} resterr.RestErr "Shipment is not created or a purchase order is not picked or has stock left in the batches"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/shipments/{id}/dispatch [post]
+func (h *ShipmentHandler) Dispatch() http.HandlerFunc {
+	return h.change(h.sv.Dispatch)
+}
+
+// Deliver marks a shipment as delivered
+// @Summary Deliver a shipment
+// @Description Mark a dispatched shipment and its purchase orders as delivered
+// @Tags Shipment
+// @Produce json
+// @Param id path int true "Shipment ID"
+// @Success 200 {object} handler.ShipmentJSON "Delivered shipment"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Shipment not found"
+// @Failure 409 {object} resterr.RestErr "Shipment is not dispatched or a purchase order is not shipped"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/shipments/{id}/deliver [post]
+func (h *ShipmentHandler) Deliver() http.HandlerFunc {
+	return h.change(h.sv.Deliver)
+}
+
+// change applies a status change to the shipment of the URL with the given function
+func (h *ShipmentHandler) change(apply func(id int) (internal.Shipment, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		s, err := apply(id)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": newShipmentJSON(s),
+		})
+	}
+}
+
+func (h *ShipmentHandler) handleError(w http.ResponseWriter, err error) {
+	var domainError internal.DomainError
+
+	switch {
+	case errors.As(err, &domainError):
+		var restCauses []resterr.Causes
+		for _, cause := range domainError.Causes {
+			restCauses = append(restCauses, resterr.Causes{
+				Field:   cause.Field,
+				Message: cause.Message,
+			})
+		}
+
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+	case errors.Is(err, internal.ErrShipmentNotFound):
+		response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+	case errors.Is(err, internal.ErrCarryNotFound),
+		errors.Is(err, internal.ErrWarehouseRepositoryNotFound),
+		errors.Is(err, internal.ErrPurchaseOrderNotFound),
+		errors.Is(err, internal.ErrShipmentPurchaseOrderAssigned),
+		errors.Is(err, internal.ErrShipmentInvalidTransition),
+		errors.Is(err, internal.ErrShipmentStockNotPicked),
+		errors.Is(err, internal.ErrPurchaseOrderInvalidTransition):
+		response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+	default:
+		response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+	}
+}
+
+// Validating the ShipmentCreateRequest required fields
+func (p *ShipmentCreateRequest) ValidateRequiredFields() (causes []resterr.Causes) {
+	if p.CarryID == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "carry_id",
+			Message: "carry id is required",
+		})
+	}
+	if p.WarehouseID == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "warehouse_id",
+			Message: "warehouse id is required",
+		})
+	}
+	if p.PurchaseOrderIDs == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "purchase_order_ids",
+			Message: "purchase order ids are required",
+		})
+	}
+	return
+}
+
+func newShipmentJSON(s internal.Shipment) ShipmentJSON {
+	data := ShipmentJSON{
+		ID:               s.ID,
+		TrackingCode:     s.TrackingCode,
+		CarryID:          s.CarryID,
+		WarehouseID:      s.WarehouseID,
+		Status:           s.Status,
+		PurchaseOrderIDs: s.PurchaseOrderIDs,
+		CreatedAt:        s.CreatedAt.Format(time.DateTime),
+	}
+
+	if data.PurchaseOrderIDs == nil {
+		data.PurchaseOrderIDs = []int{}
+	}
+
+	if s.DispatchedAt != nil {
+		dispatchedAt := s.DispatchedAt.Format(time.DateTime)
+		data.DispatchedAt = &dispatchedAt
+	}
+
+	if s.DeliveredAt != nil {
+		deliveredAt := s.DeliveredAt.Format(time.DateTime)
+		data.DeliveredAt = &deliveredAt
+	}
+
+	return data
+}
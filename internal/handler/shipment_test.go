@@ -0,0 +1,198 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/handler"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewShipmentServiceMock() *ShipmentServiceMock {
+	return &ShipmentServiceMock{}
+}
+
+type ShipmentServiceMock struct {
+	mock.Mock
+}
+
+func (m *ShipmentServiceMock) FindAll(status string) ([]internal.Shipment, error) {
+	args := m.Called(status)
+	return args.Get(0).([]internal.Shipment), args.Error(1)
+}
+
+func (m *ShipmentServiceMock) FindByID(id int) (internal.Shipment, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.Shipment), args.Error(1)
+}
+
+func (m *ShipmentServiceMock) Save(s *internal.Shipment) error {
+	args := m.Called(s)
+	return args.Error(0)
+}
+
+func (m *ShipmentServiceMock) Dispatch(id int) (internal.Shipment, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.Shipment), args.Error(1)
+}
+
+func (m *ShipmentServiceMock) Deliver(id int) (internal.Shipment, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.Shipment), args.Error(1)
+}
+
+func TestShipment_Create(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		description       string
+		body              string
+		expectedBody      string
+		expectedCode      int
+		mock              func() *ShipmentServiceMock
+		expectedMockCalls int
+	}{
+		{
+			description:  "case 1 - success: Shipment created with a tracking code",
+			body:         `{"carry_id": 1, "warehouse_id": 2, "purchase_order_ids": [3, 4]}`,
+			expectedBody: `{"data":{"id":1,"tracking_code":"SHP0A","carry_id":1,"warehouse_id":2,"status":"created","purchase_order_ids":[3,4],"created_at":"2025-01-01 10:00:00","dispatched_at":null,"delivered_at":null}}`,
+			expectedCode: http.StatusCreated,
+			mock: func() *ShipmentServiceMock {
+				mk := NewShipmentServiceMock()
+				mk.On("Save", mock.Anything).Run(func(args mock.Arguments) {
+					s := args.Get(0).(*internal.Shipment)
+					s.ID = 1
+					s.TrackingCode = "SHP0A"
+					s.Status = internal.ShipmentStatusCreated
+					s.CreatedAt = createdAt
+				}).Return(nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 2 - error: Missing required fields",
+			body:         `{"carry_id": 1}`,
+			expectedBody: `{"message":"shipment inputs are missing","error":"unprocessable_entity","code":422,"causes":[{"field":"warehouse_id","message":"warehouse id is required"},{"field":"purchase_order_ids","message":"purchase order ids are required"}]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			mock: func() *ShipmentServiceMock {
+				return NewShipmentServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+		{
+			description:  "case 3 - error: Purchase order not picked",
+			body:         `{"carry_id": 1, "warehouse_id": 2, "purchase_order_ids": [3]}`,
+			expectedBody: `{"message":"shipment inputs are invalid","error":"bad_request","code":400,"causes":[{"field":"purchase_order_ids[0]","message":"purchase order 3 is confirmed, only picking orders can be shipped"}]}`,
+			expectedCode: http.StatusBadRequest,
+			mock: func() *ShipmentServiceMock {
+				mk := NewShipmentServiceMock()
+				mk.On("Save", mock.Anything).Return(internal.DomainError{
+					Message: internal.ErrShipmentBadRequest.Error(),
+					Causes:  []internal.Causes{{Field: "purchase_order_ids[0]", Message: "purchase order 3 is confirmed, only picking orders can be shipped"}},
+				})
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 4 - error: Carry not found",
+			body:         `{"carry_id": 9, "warehouse_id": 2, "purchase_order_ids": [3]}`,
+			expectedBody: `{"message":"carry not found","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *ShipmentServiceMock {
+				mk := NewShipmentServiceMock()
+				mk.On("Save", mock.Anything).Return(internal.ErrCarryNotFound)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewShipmentHandler(sv)
+
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/shipments", strings.NewReader(tc.body))
+			response := httptest.NewRecorder()
+
+			hd.Create()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+			sv.AssertNumberOfCalls(t, "Save", tc.expectedMockCalls)
+		})
+	}
+}
+
+func TestShipment_Dispatch(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	dispatchedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		description  string
+		expectedBody string
+		expectedCode int
+		mock         func() *ShipmentServiceMock
+	}{
+		{
+			description:  "case 1 - success: Shipment dispatched",
+			expectedBody: `{"data":{"id":1,"tracking_code":"SHP0A","carry_id":1,"warehouse_id":2,"status":"dispatched","purchase_order_ids":[3],"created_at":"2025-01-01 10:00:00","dispatched_at":"2025-01-01 12:00:00","delivered_at":null}}`,
+			expectedCode: http.StatusOK,
+			mock: func() *ShipmentServiceMock {
+				mk := NewShipmentServiceMock()
+				mk.On("Dispatch", 1).Return(internal.Shipment{
+					ID: 1, TrackingCode: "SHP0A", CarryID: 1, WarehouseID: 2, Status: internal.ShipmentStatusDispatched,
+					PurchaseOrderIDs: []int{3}, CreatedAt: createdAt, DispatchedAt: &dispatchedAt,
+				}, nil)
+				return mk
+			},
+		},
+		{
+			description:  "case 2 - error: Shipment already dispatched",
+			expectedBody: `{"message":"shipment status transition is not allowed","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *ShipmentServiceMock {
+				mk := NewShipmentServiceMock()
+				mk.On("Dispatch", 1).Return(internal.Shipment{}, internal.ErrShipmentInvalidTransition)
+				return mk
+			},
+		},
+		{
+			description:  "case 3 - error: Shipment not found",
+			expectedBody: `{"message":"shipment not found","error":"not_found","code":404,"causes":null}`,
+			expectedCode: http.StatusNotFound,
+			mock: func() *ShipmentServiceMock {
+				mk := NewShipmentServiceMock()
+				mk.On("Dispatch", 1).Return(internal.Shipment{}, internal.ErrShipmentNotFound)
+				return mk
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewShipmentHandler(sv)
+
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/shipments/1/dispatch", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "1")
+			request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+			response := httptest.NewRecorder()
+
+			hd.Dispatch()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+		})
+	}
+}
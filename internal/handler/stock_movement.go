@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
+)
+
+// StockMovementJSON is a struct that represents a stock movement in JSON format
+type StockMovementJSON struct {
+	ID             int    `json:"id"`
+	ProductBatchID int    `json:"product_batch_id"`
+	Type           string `json:"type"`
+	Quantity       int    `json:"quantity"`
+	Reason         string `json:"reason"`
+	QuantityAfter  int    `json:"quantity_after"`
+	CreatedAt      string `json:"created_at"`
+}
+
+// StockMovementCreateRequest is a struct that represents a stock movement create request
+type StockMovementCreateRequest struct {
+	Type     *string `json:"type"`
+	Quantity *int    `json:"quantity"`
+	Reason   string  `json:"reason"`
+}
+
+// NewStockMovementHandler creates a new instance of the stock movement handler
+func NewStockMovementHandler(sv internal.StockMovementService) *StockMovementHandler {
+	return &StockMovementHandler{
+		sv: sv,
+	}
+}
+
+// StockMovementHandler is the default implementation of the stock movement handler
+type StockMovementHandler struct {
+	sv internal.StockMovementService
+}
+
+// GetAll returns the movements of a product batch
+// @Summary Get the movements of a product batch
+// @Description Retrieve the stock movement ledger of a product batch
+// @Tags StockMovement
+// @Produce json
+// @Param id path int true "Product Batch ID"
+// @Success 200 {object} []handler.StockMovementJSON "List of stock movements"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Product-batch not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/product-batches/{id}/movements [get]
+func (h *StockMovementHandler) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		movements, err := h.sv.FindByProductBatchID(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrProductBatchNotFound):
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+			default:
+				response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			}
+
+			return
+		}
+
+		data := make([]StockMovementJSON, 0, len(movements))
+		for _, m := range movements {
+			data = append(data, newStockMovementJSON(m))
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": data,
+		})
+	}
+}
+
+// Create registers a new movement on a product batch
+// @Summary Register a stock movement
+// @Description Apply a receipt, pick, adjustment or write-off to a product batch current quantity
+// @Tags StockMovement
+// @Accept json
+// @Produce json
+// @Param id path int true "Product Batch ID"
+// @Param request body handler.StockMovementCreateRequest true "Stock Movement Create Request"
+// @Success 201 {object} handler.StockMovementJSON "Created stock movement"
+// @Failure 400 {object} resterr.RestErr "Invalid data"
+// @Failure 404 {object} resterr.RestErr "Product-batch not found"
+// @Failure 409 {object} resterr.RestErr "Stock movement would make the batch quantity negative or the batch is on hold"
+// @Failure 422 {object} resterr.RestErr "Stock movement inputs are missing"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/product-batches/{id}/movements [post]
+func (h *StockMovementHandler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		var requestInput StockMovementCreateRequest
+
+		// decoding the request
+		if err := json.NewDecoder(r.Body).Decode(&requestInput); err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+			return
+		}
+
+		// validating the request input required fields
+		causes := requestInput.ValidateRequiredFields()
+		if len(causes) > 0 {
+			response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityWithCausesError(internal.ErrStockMovementUnprocessableEntity.Error(), causes))
+			return
+		}
+
+		movement := &internal.StockMovement{
+			ProductBatchID: id,
+			Type:           *requestInput.Type,
+			Quantity:       *requestInput.Quantity,
+			Reason:         requestInput.Reason,
+		}
+
+		// saving the movement
+		if err := h.sv.Save(movement); err != nil {
+			switch {
+			case errors.As(err, &internal.DomainError{}):
+				var domainError internal.DomainError
+				errors.As(err, &domainError)
+				var restCauses []resterr.Causes
+				for _, cause := range domainError.Causes {
+					restCauses = append(restCauses, resterr.Causes{
+						Field:   cause.Field,
+						Message: cause.Message,
+					})
+				}
+
+				response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+			case errors.Is(err, internal.ErrProductBatchNotFound):
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+			case errors.Is(err, internal.ErrStockMovementInsufficientStock), errors.Is(err, internal.ErrProductBatchOnHold):
+				response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+			default:
+				response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			}
+
+			return
+		}
+
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"data": newStockMovementJSON(*movement),
+		})
+	}
+}
+
+// Validating the StockMovementCreateRequest required fields
+func (p *StockMovementCreateRequest) ValidateRequiredFields() (causes []resterr.Causes) {
+	if p.Type == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "type",
+			Message: "type is required",
+		})
+	}
+	if p.Quantity == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "quantity",
+			Message: "quantity is required",
+		})
+	}
+	return
+}
+
+func newStockMovementJSON(m internal.StockMovement) StockMovementJSON {
+	return StockMovementJSON{
+		ID:             m.ID,
+		ProductBatchID: m.ProductBatchID,
+		Type:           m.Type,
+		Quantity:       m.Quantity,
+		Reason:         m.Reason,
+		QuantityAfter:  m.QuantityAfter,
+		CreatedAt:      m.CreatedAt.Format(time.DateTime),
+	}
+}
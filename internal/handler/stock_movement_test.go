@@ -0,0 +1,182 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/handler"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewStockMovementServiceMock() *StockMovementServiceMock {
+	return &StockMovementServiceMock{}
+}
+
+type StockMovementServiceMock struct {
+	mock.Mock
+}
+
+func (m *StockMovementServiceMock) FindByProductBatchID(productBatchID int) ([]internal.StockMovement, error) {
+	args := m.Called(productBatchID)
+	return args.Get(0).([]internal.StockMovement), args.Error(1)
+}
+
+func (m *StockMovementServiceMock) Save(sm *internal.StockMovement) error {
+	args := m.Called(sm)
+	return args.Error(0)
+}
+
+func TestStockMovement_Create(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		description       string
+		id                string
+		body              string
+		expectedBody      string
+		expectedCode      int
+		mock              func() *StockMovementServiceMock
+		expectedMockCalls int
+	}{
+		{
+			description:  "case 1 - success: Register a pick on a batch",
+			id:           "1",
+			body:         `{"type": "pick", "quantity": 10, "reason": "order PO1001"}`,
+			expectedBody: `{"data":{"id":1,"product_batch_id":1,"type":"pick","quantity":10,"reason":"order PO1001","quantity_after":90,"created_at":"2025-01-01 10:00:00"}}`,
+			expectedCode: http.StatusCreated,
+			mock: func() *StockMovementServiceMock {
+				mk := NewStockMovementServiceMock()
+				mk.On("Save", mock.AnythingOfType("*internal.StockMovement")).Run(func(args mock.Arguments) {
+					m := args.Get(0).(*internal.StockMovement)
+					m.ID = 1
+					m.QuantityAfter = 90
+					m.CreatedAt = createdAt
+				}).Return(nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 2 - error: Missing required fields",
+			id:           "1",
+			body:         `{}`,
+			expectedBody: `{"message":"stock movement inputs are missing","error":"unprocessable_entity","code":422,"causes":[{"field":"type","message":"type is required"},{"field":"quantity","message":"quantity is required"}]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			mock: func() *StockMovementServiceMock {
+				return NewStockMovementServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+		{
+			description:  "case 3 - error: Movement would make the quantity negative",
+			id:           "1",
+			body:         `{"type": "write_off", "quantity": 1000}`,
+			expectedBody: `{"message":"stock movement would make the batch quantity negative","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *StockMovementServiceMock {
+				mk := NewStockMovementServiceMock()
+				mk.On("Save", mock.Anything).Return(internal.ErrStockMovementInsufficientStock)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 4 - error: Product batch not found",
+			id:           "99",
+			body:         `{"type": "receipt", "quantity": 10}`,
+			expectedBody: `{"message":"product-batch not found","error":"not_found","code":404,"causes":null}`,
+			expectedCode: http.StatusNotFound,
+			mock: func() *StockMovementServiceMock {
+				mk := NewStockMovementServiceMock()
+				mk.On("Save", mock.Anything).Return(internal.ErrProductBatchNotFound)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 5 - error: Invalid id",
+			id:           "abc",
+			body:         `{"type": "receipt", "quantity": 10}`,
+			expectedBody: `{"message":"Invalid ID format","error":"bad_request","code":400,"causes":null}`,
+			expectedCode: http.StatusBadRequest,
+			mock: func() *StockMovementServiceMock {
+				return NewStockMovementServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+		{
+			description:  "case 6 - error: Null body",
+			id:           "1",
+			body:         `null`,
+			expectedBody: `{"message":"stock movement inputs are missing","error":"unprocessable_entity","code":422,"causes":[{"field":"type","message":"type is required"},{"field":"quantity","message":"quantity is required"}]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			mock: func() *StockMovementServiceMock {
+				return NewStockMovementServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewStockMovementHandler(sv)
+
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/product-batches/"+tc.id+"/movements", strings.NewReader(tc.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+			response := httptest.NewRecorder()
+
+			hd.Create()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+			sv.AssertNumberOfCalls(t, "Save", tc.expectedMockCalls)
+		})
+	}
+}
+
+func TestStockMovement_GetAll(t *testing.T) {
+	t.Run("case 1 - success: List the movements of a batch", func(t *testing.T) {
+		sv := NewStockMovementServiceMock()
+		sv.On("FindByProductBatchID", 1).Return([]internal.StockMovement{
+			{ID: 1, ProductBatchID: 1, Type: internal.StockMovementReceipt, Quantity: 10, QuantityAfter: 110, CreatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)},
+		}, nil)
+		hd := handler.NewStockMovementHandler(sv)
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/product-batches/1/movements", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.GetAll()(response, request)
+
+		require.Equal(t, http.StatusOK, response.Code)
+		require.JSONEq(t, `{"data":[{"id":1,"product_batch_id":1,"type":"receipt","quantity":10,"reason":"","quantity_after":110,"created_at":"2025-01-01 10:00:00"}]}`, response.Body.String())
+	})
+
+	t.Run("case 2 - error: Product batch not found", func(t *testing.T) {
+		sv := NewStockMovementServiceMock()
+		sv.On("FindByProductBatchID", 1).Return([]internal.StockMovement{}, internal.ErrProductBatchNotFound)
+		hd := handler.NewStockMovementHandler(sv)
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/product-batches/1/movements", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.GetAll()(response, request)
+
+		require.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
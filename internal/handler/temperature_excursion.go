@@ -0,0 +1,309 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
+)
+
+// TemperatureExcursionJSON is a struct that represents a temperature excursion in JSON format
+type TemperatureExcursionJSON struct {
+	ID             int     `json:"id"`
+	SectionID      int     `json:"section_id"`
+	Status         string  `json:"status"`
+	StartedAt      string  `json:"started_at"`
+	EndedAt        *string `json:"ended_at"`
+	PeakCelsius    float64 `json:"peak_celsius"`
+	PeakDeviation  float64 `json:"peak_deviation"`
+	LimitCelsius   float64 `json:"limit_celsius"`
+	AcknowledgedBy string  `json:"acknowledged_by"`
+	AcknowledgedAt *string `json:"acknowledged_at"`
+	ClosedBy       string  `json:"closed_by"`
+}
+
+// TemperatureExcursionStatusRequest is a struct that represents the request to change the status of an excursion
+type TemperatureExcursionStatusRequest struct {
+	Status    *string `json:"status"`
+	ChangedBy *string `json:"changed_by"`
+}
+
+// NewTemperatureExcursionHandler creates a new instance of the temperature excursion handler
+func NewTemperatureExcursionHandler(sv internal.TemperatureExcursionService) *TemperatureExcursionHandler {
+	return &TemperatureExcursionHandler{
+		sv: sv,
+	}
+}
+
+// TemperatureExcursionHandler is the default implementation of the temperature excursion handler
+type TemperatureExcursionHandler struct {
+	sv internal.TemperatureExcursionService
+}
+
+// GetAll returns the temperature excursions
+// @Summary Get temperature excursions
+// @Description Lists the temperature excursions, the most recent first, optionally filtered by status, section or warehouse
+// @Tags Excursions
+// @Produce json
+// @Param status query string false "Excursion status (open, acknowledged or closed)"
+// @Param section_id query int false "Section ID"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Success 200 {object} []handler.TemperatureExcursionJSON "Temperature excursions"
+// @Failure 400 {object} resterr.RestErr "Invalid filter"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/excursions [get]
+func (h *TemperatureExcursionHandler) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filter, causes := parseTemperatureExcursionFilter(r)
+		if len(causes) > 0 {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(ErrInvalidData, causes))
+			return
+		}
+
+		excursions, err := h.sv.FindAll(filter)
+		if err != nil {
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			return
+		}
+
+		excursionsJSON := []TemperatureExcursionJSON{}
+		for _, excursion := range excursions {
+			excursionsJSON = append(excursionsJSON, newTemperatureExcursionJSON(excursion))
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": excursionsJSON,
+		})
+	}
+}
+
+// GetByID returns a temperature excursion
+// @Summary Get a temperature excursion
+// @Description Get the details of a temperature excursion
+// @Tags Excursions
+// @Produce json
+// @Param id path int true "Excursion ID"
+// @Success 200 {object} handler.TemperatureExcursionJSON "Temperature excursion"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Temperature excursion not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/excursions/{id} [get]
+func (h *TemperatureExcursionHandler) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		excursion, err := h.sv.FindByID(id)
+		if err != nil {
+			if errors.Is(err, internal.ErrTemperatureExcursionNotFound) {
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+				return
+			}
+
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": newTemperatureExcursionJSON(excursion),
+		})
+	}
+}
+
+// GetProductBatches returns the batches affected by a temperature excursion
+// @Summary Get the batches affected by a temperature excursion
+// @Description Lists the batches with stock left in the section of the excursion, so they can be reviewed for quarantine
+// @Tags Excursions
+// @Produce json
+// @Param id path int true "Excursion ID"
+// @Success 200 {object} []internal.ProductBatch "Affected product batches"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Temperature excursion not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/excursions/{id}/product-batches [get]
+func (h *TemperatureExcursionHandler) GetProductBatches() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		prodBatches, err := h.sv.FindProductBatches(id)
+		if err != nil {
+			if errors.Is(err, internal.ErrTemperatureExcursionNotFound) {
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+				return
+			}
+
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			return
+		}
+
+		if prodBatches == nil {
+			prodBatches = []internal.ProductBatch{}
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": prodBatches,
+		})
+	}
+}
+
+// UpdateStatus acknowledges or closes a temperature excursion
+// @Summary Update the status of a temperature excursion
+// @Description Acknowledges or closes a temperature excursion. Closed excursions cannot change anymore.
+// @Tags Excursions
+// @Accept json
+// @Produce json
+// @Param id path int true "Excursion ID"
+// @Param request body handler.TemperatureExcursionStatusRequest true "New status and who requested it"
+// @Success 200 {object} handler.TemperatureExcursionJSON "Updated temperature excursion"
+// @Failure 400 {object} resterr.RestErr "Invalid status"
+// @Failure 404 {object} resterr.RestErr "Temperature excursion not found"
+// @Failure 409 {object} resterr.RestErr "Status transition is not allowed"
+// @Failure 422 {object} resterr.RestErr "Temperature excursion status inputs are missing"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/excursions/{id}/status [patch]
+func (h *TemperatureExcursionHandler) UpdateStatus() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		var requestInput TemperatureExcursionStatusRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&requestInput); err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+			return
+		}
+
+		causes := requestInput.ValidateRequiredFields()
+		if len(causes) > 0 {
+			response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityWithCausesError(internal.ErrTemperatureExcursionUnprocessableEntity.Error(), causes))
+			return
+		}
+
+		excursion, err := h.sv.UpdateStatus(id, *requestInput.Status, *requestInput.ChangedBy)
+		if err != nil {
+			var domainError internal.DomainError
+
+			switch {
+			case errors.As(err, &domainError):
+				var restCauses []resterr.Causes
+				for _, cause := range domainError.Causes {
+					restCauses = append(restCauses, resterr.Causes{
+						Field:   cause.Field,
+						Message: cause.Message,
+					})
+				}
+
+				response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+			case errors.Is(err, internal.ErrTemperatureExcursionNotFound):
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+			case errors.Is(err, internal.ErrTemperatureExcursionInvalidTransition):
+				response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+			default:
+				response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			}
+
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": newTemperatureExcursionJSON(excursion),
+		})
+	}
+}
+
+// ValidateRequiredFields validates the required fields of the status change
+func (t *TemperatureExcursionStatusRequest) ValidateRequiredFields() (causes []resterr.Causes) {
+	if t.Status == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "status",
+			Message: "status is required",
+		})
+	}
+	if t.ChangedBy == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "changed_by",
+			Message: "changed by is required",
+		})
+	}
+	return
+}
+
+func parseTemperatureExcursionFilter(r *http.Request) (filter internal.TemperatureExcursionFilter, causes []resterr.Causes) {
+	query := r.URL.Query()
+
+	filter.Status = query.Get("status")
+
+	if filter.Status != "" && !internal.IsTemperatureExcursionStatus(filter.Status) {
+		causes = append(causes, resterr.Causes{
+			Field:   "status",
+			Message: "unknown temperature excursion status",
+		})
+	}
+
+	for _, param := range []struct {
+		name  string
+		value *int
+	}{
+		{name: "section_id", value: &filter.SectionID},
+		{name: "warehouse_id", value: &filter.WarehouseID},
+	} {
+		raw := query.Get(param.name)
+		if raw == "" {
+			continue
+		}
+
+		id, err := strconv.Atoi(raw)
+		if err != nil || id <= 0 {
+			causes = append(causes, resterr.Causes{
+				Field:   param.name,
+				Message: "id must be a positive number",
+			})
+			continue
+		}
+		*param.value = id
+	}
+
+	return
+}
+
+func newTemperatureExcursionJSON(e internal.TemperatureExcursion) TemperatureExcursionJSON {
+	excursionJSON := TemperatureExcursionJSON{
+		ID:             e.ID,
+		SectionID:      e.SectionID,
+		Status:         e.Status,
+		StartedAt:      e.StartedAt.UTC().Format(time.RFC3339),
+		PeakCelsius:    e.PeakCelsius,
+		PeakDeviation:  e.PeakDeviation,
+		LimitCelsius:   e.LimitCelsius,
+		AcknowledgedBy: e.AcknowledgedBy,
+		ClosedBy:       e.ClosedBy,
+	}
+
+	if e.EndedAt != nil {
+		endedAt := e.EndedAt.UTC().Format(time.RFC3339)
+		excursionJSON.EndedAt = &endedAt
+	}
+
+	if e.AcknowledgedAt != nil {
+		acknowledgedAt := e.AcknowledgedAt.UTC().Format(time.RFC3339)
+		excursionJSON.AcknowledgedAt = &acknowledgedAt
+	}
+
+	return excursionJSON
+}
@@ -0,0 +1,264 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/handler"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewTemperatureExcursionServiceMock() *TemperatureExcursionServiceMock {
+	return &TemperatureExcursionServiceMock{}
+}
+
+type TemperatureExcursionServiceMock struct {
+	mock.Mock
+}
+
+func (m *TemperatureExcursionServiceMock) FindAll(filter internal.TemperatureExcursionFilter) ([]internal.TemperatureExcursion, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]internal.TemperatureExcursion), args.Error(1)
+}
+
+func (m *TemperatureExcursionServiceMock) FindByID(id int) (internal.TemperatureExcursion, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.TemperatureExcursion), args.Error(1)
+}
+
+func (m *TemperatureExcursionServiceMock) FindProductBatches(id int) ([]internal.ProductBatch, error) {
+	args := m.Called(id)
+	return args.Get(0).([]internal.ProductBatch), args.Error(1)
+}
+
+func (m *TemperatureExcursionServiceMock) Evaluate(readings []internal.TemperatureReading) error {
+	args := m.Called(readings)
+	return args.Error(0)
+}
+
+func (m *TemperatureExcursionServiceMock) UpdateStatus(id int, status string, changedBy string) (internal.TemperatureExcursion, error) {
+	args := m.Called(id, status, changedBy)
+	return args.Get(0).(internal.TemperatureExcursion), args.Error(1)
+}
+
+func TestTemperatureExcursion_GetAll(t *testing.T) {
+	startedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(time.Hour)
+
+	testCases := []struct {
+		description       string
+		query             string
+		expectedBody      string
+		expectedCode      int
+		mock              func() *TemperatureExcursionServiceMock
+		expectedMockCalls int
+	}{
+		{
+			description:  "case 1 - success: List the excursions of a warehouse",
+			query:        "?status=closed&warehouse_id=2",
+			expectedBody: `{"data":[{"id":1,"section_id":1,"status":"closed","started_at":"2025-01-01T10:00:00Z","ended_at":"2025-01-01T11:00:00Z","peak_celsius":-10,"peak_deviation":5,"limit_celsius":-15,"acknowledged_by":"","acknowledged_at":null,"closed_by":""}]}`,
+			expectedCode: http.StatusOK,
+			mock: func() *TemperatureExcursionServiceMock {
+				mk := NewTemperatureExcursionServiceMock()
+				mk.On("FindAll", internal.TemperatureExcursionFilter{Status: "closed", WarehouseID: 2}).Return([]internal.TemperatureExcursion{
+					{ID: 1, SectionID: 1, Status: "closed", StartedAt: startedAt, EndedAt: &endedAt, PeakCelsius: -10, PeakDeviation: 5, LimitCelsius: -15},
+				}, nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 2 - error: Invalid filter",
+			query:        "?status=pending&section_id=abc",
+			expectedBody: `{"message":"Invalid data","error":"bad_request","code":400,"causes":[{"field":"status","message":"unknown temperature excursion status"},{"field":"section_id","message":"id must be a positive number"}]}`,
+			expectedCode: http.StatusBadRequest,
+			mock: func() *TemperatureExcursionServiceMock {
+				return NewTemperatureExcursionServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewTemperatureExcursionHandler(sv)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/excursions"+tc.query, nil)
+			response := httptest.NewRecorder()
+
+			hd.GetAll()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+			sv.AssertNumberOfCalls(t, "FindAll", tc.expectedMockCalls)
+		})
+	}
+}
+
+func TestTemperatureExcursion_GetByID(t *testing.T) {
+	t.Run("case 1 - success: Get an open excursion", func(t *testing.T) {
+		sv := NewTemperatureExcursionServiceMock()
+		sv.On("FindByID", 1).Return(internal.TemperatureExcursion{
+			ID: 1, SectionID: 1, Status: "open", StartedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC), PeakCelsius: -30, PeakDeviation: 5, LimitCelsius: -25,
+		}, nil)
+		hd := handler.NewTemperatureExcursionHandler(sv)
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/excursions/1", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.GetByID()(response, request)
+
+		require.Equal(t, http.StatusOK, response.Code)
+		require.JSONEq(t, `{"data":{"id":1,"section_id":1,"status":"open","started_at":"2025-01-01T10:00:00Z","ended_at":null,"peak_celsius":-30,"peak_deviation":5,"limit_celsius":-25,"acknowledged_by":"","acknowledged_at":null,"closed_by":""}}`, response.Body.String())
+	})
+
+	t.Run("case 2 - error: Excursion not found", func(t *testing.T) {
+		sv := NewTemperatureExcursionServiceMock()
+		sv.On("FindByID", 99).Return(internal.TemperatureExcursion{}, internal.ErrTemperatureExcursionNotFound)
+		hd := handler.NewTemperatureExcursionHandler(sv)
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/excursions/99", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "99")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.GetByID()(response, request)
+
+		require.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
+
+func TestTemperatureExcursion_GetProductBatches(t *testing.T) {
+	t.Run("case 1 - success: List the affected batches", func(t *testing.T) {
+		sv := NewTemperatureExcursionServiceMock()
+		sv.On("FindProductBatches", 1).Return([]internal.ProductBatch{}, nil)
+		hd := handler.NewTemperatureExcursionHandler(sv)
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/excursions/1/product-batches", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.GetProductBatches()(response, request)
+
+		require.Equal(t, http.StatusOK, response.Code)
+		require.JSONEq(t, `{"data":[]}`, response.Body.String())
+	})
+
+	t.Run("case 2 - error: Excursion not found", func(t *testing.T) {
+		sv := NewTemperatureExcursionServiceMock()
+		sv.On("FindProductBatches", 99).Return([]internal.ProductBatch{}, internal.ErrTemperatureExcursionNotFound)
+		hd := handler.NewTemperatureExcursionHandler(sv)
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/excursions/99/product-batches", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "99")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+		response := httptest.NewRecorder()
+
+		hd.GetProductBatches()(response, request)
+
+		require.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
+
+func TestTemperatureExcursion_UpdateStatus(t *testing.T) {
+	startedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	acknowledgedAt := startedAt.Add(time.Minute)
+
+	testCases := []struct {
+		description       string
+		id                string
+		body              string
+		expectedBody      string
+		expectedCode      int
+		mock              func() *TemperatureExcursionServiceMock
+		expectedMockCalls int
+	}{
+		{
+			description:  "case 1 - success: Acknowledge an excursion",
+			id:           "1",
+			body:         `{"status": "acknowledged", "changed_by": "qa"}`,
+			expectedBody: `{"data":{"id":1,"section_id":1,"status":"acknowledged","started_at":"2025-01-01T10:00:00Z","ended_at":null,"peak_celsius":-10,"peak_deviation":5,"limit_celsius":-15,"acknowledged_by":"qa","acknowledged_at":"2025-01-01T10:01:00Z","closed_by":""}}`,
+			expectedCode: http.StatusOK,
+			mock: func() *TemperatureExcursionServiceMock {
+				mk := NewTemperatureExcursionServiceMock()
+				mk.On("UpdateStatus", 1, "acknowledged", "qa").Return(internal.TemperatureExcursion{
+					ID: 1, SectionID: 1, Status: "acknowledged", StartedAt: startedAt, PeakCelsius: -10, PeakDeviation: 5,
+					LimitCelsius: -15, AcknowledgedBy: "qa", AcknowledgedAt: &acknowledgedAt,
+				}, nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 2 - error: Missing required fields",
+			id:           "1",
+			body:         `{}`,
+			expectedBody: `{"message":"temperature excursion status inputs are missing","error":"unprocessable_entity","code":422,"causes":[{"field":"status","message":"status is required"},{"field":"changed_by","message":"changed by is required"}]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			mock: func() *TemperatureExcursionServiceMock {
+				return NewTemperatureExcursionServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+		{
+			description:  "case 3 - error: Closed excursion",
+			id:           "1",
+			body:         `{"status": "acknowledged", "changed_by": "qa"}`,
+			expectedBody: `{"message":"temperature excursion status transition is not allowed","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *TemperatureExcursionServiceMock {
+				mk := NewTemperatureExcursionServiceMock()
+				mk.On("UpdateStatus", 1, "acknowledged", "qa").Return(internal.TemperatureExcursion{}, internal.ErrTemperatureExcursionInvalidTransition)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 4 - error: Excursion not found",
+			id:           "99",
+			body:         `{"status": "closed", "changed_by": "qa"}`,
+			expectedBody: `{"message":"temperature excursion not found","error":"not_found","code":404,"causes":null}`,
+			expectedCode: http.StatusNotFound,
+			mock: func() *TemperatureExcursionServiceMock {
+				mk := NewTemperatureExcursionServiceMock()
+				mk.On("UpdateStatus", 99, "closed", "qa").Return(internal.TemperatureExcursion{}, internal.ErrTemperatureExcursionNotFound)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewTemperatureExcursionHandler(sv)
+
+			request := httptest.NewRequest(http.MethodPatch, "/api/v1/excursions/"+tc.id+"/status", strings.NewReader(tc.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+			response := httptest.NewRecorder()
+
+			hd.UpdateStatus()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+			sv.AssertNumberOfCalls(t, "UpdateStatus", tc.expectedMockCalls)
+		})
+	}
+}
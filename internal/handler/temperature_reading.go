@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
+)
+
+// TemperatureReadingJSON is a struct that represents a temperature reading in JSON format
+type TemperatureReadingJSON struct {
+	ID         int     `json:"id"`
+	SectionID  int     `json:"section_id"`
+	RecordedAt string  `json:"recorded_at"`
+	Celsius    float64 `json:"celsius"`
+}
+
+// TemperatureReadingCreateRequest is a struct that represents a single reading of an ingestion request
+type TemperatureReadingCreateRequest struct {
+	SectionID  *int     `json:"section_id"`
+	RecordedAt *string  `json:"recorded_at"`
+	Celsius    *float64 `json:"celsius"`
+}
+
+// NewTemperatureReadingHandler creates a new instance of the temperature reading handler
+func NewTemperatureReadingHandler(sv internal.TemperatureReadingService) *TemperatureReadingHandler {
+	return &TemperatureReadingHandler{
+		sv: sv,
+	}
+}
+
+// TemperatureReadingHandler is the default implementation of the temperature reading handler
+type TemperatureReadingHandler struct {
+	sv internal.TemperatureReadingService
+}
+
+// Ingest stores a batch of temperature readings
+// @Summary Ingest temperature readings
+// @Description Stores a batch of section temperature readings and refreshes the current temperature of the sections and their batches
+// @Tags Telemetry
+// @Accept json
+// @Produce json
+// @Param request body []handler.TemperatureReadingCreateRequest true "Readings, recorded_at in RFC 3339 format"
+// @Success 201 {object} map[string]int "Number of readings stored"
+// @Failure 400 {object} resterr.RestErr "Invalid readings"
+// @Failure 409 {object} resterr.RestErr "Section not found, or the readings were stored but a section has temperature limits that do not form a range"
+// @Failure 422 {object} resterr.RestErr "Temperature reading inputs are missing"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/telemetry/temperature [post]
+func (h *TemperatureReadingHandler) Ingest() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var requestInput []TemperatureReadingCreateRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&requestInput); err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+			return
+		}
+
+		var causes []resterr.Causes
+		for i, reading := range requestInput {
+			causes = append(causes, reading.ValidateRequiredFields(i)...)
+		}
+
+		if len(causes) > 0 {
+			response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityWithCausesError(internal.ErrTemperatureReadingUnprocessableEntity.Error(), causes))
+			return
+		}
+
+		readings := make([]internal.TemperatureReading, 0, len(requestInput))
+		for i, reading := range requestInput {
+			recordedAt, err := time.Parse(time.RFC3339, *reading.RecordedAt)
+			if err != nil {
+				causes = append(causes, resterr.Causes{
+					Field:   fmt.Sprintf("[%d].recorded_at", i),
+					Message: "invalid date format, expected RFC 3339",
+				})
+				continue
+			}
+
+			readings = append(readings, internal.TemperatureReading{
+				SectionID:  *reading.SectionID,
+				RecordedAt: recordedAt,
+				Celsius:    *reading.Celsius,
+			})
+		}
+
+		if len(causes) > 0 {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(ErrInvalidData, causes))
+			return
+		}
+
+		if err := h.sv.Ingest(readings); err != nil {
+			var domainError internal.DomainError
+
+			switch {
+			case errors.As(err, &domainError):
+				var restCauses []resterr.Causes
+				for _, cause := range domainError.Causes {
+					restCauses = append(restCauses, resterr.Causes{
+						Field:   cause.Field,
+						Message: cause.Message,
+					})
+				}
+
+				response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+			case errors.Is(err, internal.ErrSectionNotFound):
+				response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+			case errors.Is(err, internal.ErrTemperatureLimitsInvalid):
+				response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+			default:
+				response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			}
+
+			return
+		}
+
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"data": map[string]int{"ingested": len(readings)},
+		})
+	}
+}
+
+// ValidateRequiredFields validates the required fields of the reading at the given position
+func (t *TemperatureReadingCreateRequest) ValidateRequiredFields(index int) (causes []resterr.Causes) {
+	if t.SectionID == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   fmt.Sprintf("[%d].section_id", index),
+			Message: "section id is required",
+		})
+	}
+	if t.RecordedAt == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   fmt.Sprintf("[%d].recorded_at", index),
+			Message: "recorded at is required",
+		})
+	}
+	if t.Celsius == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   fmt.Sprintf("[%d].celsius", index),
+			Message: "celsius is required",
+		})
+	}
+	return
+}
+
+// GetBySection returns the temperature history of a section
+// @Summary Get the temperature history of a section
+// @Description Lists the temperature readings of the section ordered by the time they were recorded
+// @Tags Telemetry
+// @Produce json
+// @Param id path int true "Section ID"
+// @Param from query string false "Readings recorded at or after this moment (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "Readings recorded at or before this moment (RFC 3339 or YYYY-MM-DD, the whole day is included)"
+// @Success 200 {object} []handler.TemperatureReadingJSON "Temperature readings"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format or time range"
+// @Failure 404 {object} resterr.RestErr "Section not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/sections/{id}/temperatures [get]
+func (h *TemperatureReadingHandler) GetBySection() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		from, to, causes := parseTimeRange(r)
+		if len(causes) > 0 {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(ErrInvalidData, causes))
+			return
+		}
+
+		readings, err := h.sv.FindBySectionID(id, from, to)
+		if err != nil {
+			if errors.Is(err, internal.ErrSectionNotFound) {
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+				return
+			}
+
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			return
+		}
+
+		readingsJSON := []TemperatureReadingJSON{}
+		for _, reading := range readings {
+			readingsJSON = append(readingsJSON, TemperatureReadingJSON{
+				ID:         reading.ID,
+				SectionID:  reading.SectionID,
+				RecordedAt: reading.RecordedAt.UTC().Format(time.RFC3339),
+				Celsius:    reading.Celsius,
+			})
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": readingsJSON,
+		})
+	}
+}
+
+// parseTimeRange reads the from and to query params. A date without time covers the whole day.
+func parseTimeRange(r *http.Request) (from time.Time, to time.Time, causes []resterr.Causes) {
+	for _, param := range []struct {
+		name   string
+		value  *time.Time
+		endDay bool
+	}{
+		{name: "from", value: &from},
+		{name: "to", value: &to, endDay: true},
+	} {
+		raw := r.URL.Query().Get(param.name)
+		if raw == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			parsed, err = time.Parse(time.DateOnly, raw)
+			if err != nil {
+				causes = append(causes, resterr.Causes{
+					Field:   param.name,
+					Message: "invalid date format, expected RFC 3339 or YYYY-MM-DD",
+				})
+				continue
+			}
+
+			if param.endDay {
+				parsed = parsed.Add(24*time.Hour - time.Second)
+			}
+		}
+
+		*param.value = parsed
+	}
+
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		causes = append(causes, resterr.Causes{
+			Field:   "from",
+			Message: "from must not be after to",
+		})
+	}
+
+	return
+}
@@ -0,0 +1,230 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/handler"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewTemperatureReadingServiceMock() *TemperatureReadingServiceMock {
+	return &TemperatureReadingServiceMock{}
+}
+
+type TemperatureReadingServiceMock struct {
+	mock.Mock
+}
+
+func (m *TemperatureReadingServiceMock) FindBySectionID(sectionID int, from time.Time, to time.Time) ([]internal.TemperatureReading, error) {
+	args := m.Called(sectionID, from, to)
+	return args.Get(0).([]internal.TemperatureReading), args.Error(1)
+}
+
+func (m *TemperatureReadingServiceMock) Ingest(readings []internal.TemperatureReading) error {
+	args := m.Called(readings)
+	return args.Error(0)
+}
+
+func TestTemperatureReading_Ingest(t *testing.T) {
+	testCases := []struct {
+		description       string
+		body              string
+		expectedBody      string
+		expectedCode      int
+		mock              func() *TemperatureReadingServiceMock
+		expectedMockCalls int
+	}{
+		{
+			description:  "case 1 - success: Ingest a batch of readings",
+			body:         `[{"section_id": 1, "recorded_at": "2025-01-01T10:00:00Z", "celsius": 4.5}, {"section_id": 2, "recorded_at": "2025-01-01T10:00:00Z", "celsius": -18}]`,
+			expectedBody: `{"data":{"ingested":2}}`,
+			expectedCode: http.StatusCreated,
+			mock: func() *TemperatureReadingServiceMock {
+				mk := NewTemperatureReadingServiceMock()
+				mk.On("Ingest", mock.AnythingOfType("[]internal.TemperatureReading")).Return(nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 2 - error: Missing required fields",
+			body:         `[{"section_id": 1, "recorded_at": "2025-01-01T10:00:00Z", "celsius": 4.5}, {"section_id": 1}]`,
+			expectedBody: `{"message":"temperature reading inputs are missing","error":"unprocessable_entity","code":422,"causes":[{"field":"[1].recorded_at","message":"recorded at is required"},{"field":"[1].celsius","message":"celsius is required"}]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			mock: func() *TemperatureReadingServiceMock {
+				return NewTemperatureReadingServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+		{
+			description:  "case 3 - error: Invalid recorded at",
+			body:         `[{"section_id": 1, "recorded_at": "2025-01-01 10:00", "celsius": 4.5}]`,
+			expectedBody: `{"message":"Invalid data","error":"bad_request","code":400,"causes":[{"field":"[0].recorded_at","message":"invalid date format, expected RFC 3339"}]}`,
+			expectedCode: http.StatusBadRequest,
+			mock: func() *TemperatureReadingServiceMock {
+				return NewTemperatureReadingServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+		{
+			description:  "case 4 - error: Reading breaks a business rule",
+			body:         `[{"section_id": 1, "recorded_at": "2025-01-01T10:00:00Z", "celsius": -300}]`,
+			expectedBody: `{"message":"temperature reading inputs are invalid","error":"bad_request","code":400,"causes":[{"field":"[0].celsius","message":"celsius cannot be below absolute zero"}]}`,
+			expectedCode: http.StatusBadRequest,
+			mock: func() *TemperatureReadingServiceMock {
+				mk := NewTemperatureReadingServiceMock()
+				mk.On("Ingest", mock.Anything).Return(internal.DomainError{
+					Message: internal.ErrTemperatureReadingBadRequest.Error(),
+					Causes:  []internal.Causes{{Field: "[0].celsius", Message: "celsius cannot be below absolute zero"}},
+				})
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 5 - error: Section not found",
+			body:         `[{"section_id": 99, "recorded_at": "2025-01-01T10:00:00Z", "celsius": 4.5}]`,
+			expectedBody: `{"message":"section not found","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *TemperatureReadingServiceMock {
+				mk := NewTemperatureReadingServiceMock()
+				mk.On("Ingest", mock.Anything).Return(internal.ErrSectionNotFound)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 6 - error: Section limits do not form a range",
+			body:         `[{"section_id": 3, "recorded_at": "2025-01-01T10:00:00Z", "celsius": 4.5}]`,
+			expectedBody: `{"message":"temperature limits of the section do not form a range: section 3","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *TemperatureReadingServiceMock {
+				mk := NewTemperatureReadingServiceMock()
+				mk.On("Ingest", mock.Anything).Return(fmt.Errorf("%w: section 3", internal.ErrTemperatureLimitsInvalid))
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewTemperatureReadingHandler(sv)
+
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/telemetry/temperature", strings.NewReader(tc.body))
+			response := httptest.NewRecorder()
+
+			hd.Ingest()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+			sv.AssertNumberOfCalls(t, "Ingest", tc.expectedMockCalls)
+		})
+	}
+}
+
+func TestTemperatureReading_GetBySection(t *testing.T) {
+	recordedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		description       string
+		id                string
+		query             string
+		expectedBody      string
+		expectedCode      int
+		mock              func() *TemperatureReadingServiceMock
+		expectedMockCalls int
+	}{
+		{
+			description:  "case 1 - success: List the readings of a section in a day",
+			id:           "1",
+			query:        "?from=2025-01-01&to=2025-01-01",
+			expectedBody: `{"data":[{"id":1,"section_id":1,"recorded_at":"2025-01-01T10:00:00Z","celsius":4.5}]}`,
+			expectedCode: http.StatusOK,
+			mock: func() *TemperatureReadingServiceMock {
+				mk := NewTemperatureReadingServiceMock()
+				from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+				to := time.Date(2025, 1, 1, 23, 59, 59, 0, time.UTC)
+				mk.On("FindBySectionID", 1, from, to).Return([]internal.TemperatureReading{
+					{ID: 1, SectionID: 1, RecordedAt: recordedAt, Celsius: 4.5},
+				}, nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 2 - success: List every reading without a time range",
+			id:           "1",
+			expectedBody: `{"data":[]}`,
+			expectedCode: http.StatusOK,
+			mock: func() *TemperatureReadingServiceMock {
+				mk := NewTemperatureReadingServiceMock()
+				mk.On("FindBySectionID", 1, time.Time{}, time.Time{}).Return([]internal.TemperatureReading{}, nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 3 - error: From after to",
+			id:           "1",
+			query:        "?from=2025-01-02T00:00:00Z&to=2025-01-01T00:00:00Z",
+			expectedBody: `{"message":"Invalid data","error":"bad_request","code":400,"causes":[{"field":"from","message":"from must not be after to"}]}`,
+			expectedCode: http.StatusBadRequest,
+			mock: func() *TemperatureReadingServiceMock {
+				return NewTemperatureReadingServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+		{
+			description:  "case 4 - error: Section not found",
+			id:           "99",
+			expectedBody: `{"message":"section not found","error":"not_found","code":404,"causes":null}`,
+			expectedCode: http.StatusNotFound,
+			mock: func() *TemperatureReadingServiceMock {
+				mk := NewTemperatureReadingServiceMock()
+				mk.On("FindBySectionID", 99, time.Time{}, time.Time{}).Return([]internal.TemperatureReading{}, internal.ErrSectionNotFound)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 5 - error: Invalid id",
+			id:           "abc",
+			expectedBody: `{"message":"Invalid ID format","error":"bad_request","code":400,"causes":null}`,
+			expectedCode: http.StatusBadRequest,
+			mock: func() *TemperatureReadingServiceMock {
+				return NewTemperatureReadingServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewTemperatureReadingHandler(sv)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/sections/"+tc.id+"/temperatures"+tc.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+			response := httptest.NewRecorder()
+
+			hd.GetBySection()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+			sv.AssertNumberOfCalls(t, "FindBySectionID", tc.expectedMockCalls)
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/utils/resterr"
+)
+
+// TransferJSON is a struct that represents a transfer in JSON format
+type TransferJSON struct {
+	ID                        int     `json:"id"`
+	ProductBatchID            int     `json:"product_batch_id"`
+	DestinationProductBatchID *int    `json:"destination_product_batch_id"`
+	FromSectionID             int     `json:"from_section_id"`
+	ToSectionID               int     `json:"to_section_id"`
+	Quantity                  int     `json:"quantity"`
+	Status                    string  `json:"status"`
+	EmployeeID                int     `json:"employee_id"`
+	DispatchedBy              *int    `json:"dispatched_by"`
+	ReceivedBy                *int    `json:"received_by"`
+	CreatedAt                 string  `json:"created_at"`
+	DispatchedAt              *string `json:"dispatched_at"`
+	ReceivedAt                *string `json:"received_at"`
+}
+
+// TransferCreateRequest is a struct that represents a request to transfer a quantity of a product batch
+type TransferCreateRequest struct {
+	ProductBatchID *int `json:"product_batch_id"`
+	SectionID      *int `json:"section_id"`
+	Quantity       *int `json:"quantity"`
+	EmployeeID     *int `json:"employee_id"`
+}
+
+// TransferStatusRequest is a struct that represents a request to dispatch or receive a transfer
+type TransferStatusRequest struct {
+	EmployeeID *int `json:"employee_id"`
+}
+
+// NewTransferHandler creates a new instance of the transfer handler
+func NewTransferHandler(sv internal.TransferService) *TransferHandler {
+	return &TransferHandler{
+		sv: sv,
+	}
+}
+
+// TransferHandler is the default implementation of the transfer handler
+type TransferHandler struct {
+	sv internal.TransferService
+}
+
+// GetAll returns the transfers
+// @Summary Get all transfers
+// @Description Retrieve the transfers, optionally only the ones in a status such as in_transit
+// @Tags Transfer
+// @Produce json
+// @Param status query string false "Status (pending, in_transit or received)"
+// @Success 200 {object} []handler.TransferJSON "List of transfers"
+// @Failure 400 {object} resterr.RestErr "Invalid status"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/transfers [get]
+func (h *TransferHandler) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := r.URL.Query().Get("status")
+
+		switch status {
+		case "", internal.TransferStatusPending, internal.TransferStatusInTransit, internal.TransferStatusReceived:
+		default:
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError("status must be pending, in_transit or received"))
+			return
+		}
+
+		transfers, err := h.sv.FindAll(status)
+		if err != nil {
+			response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			return
+		}
+
+		data := make([]TransferJSON, 0, len(transfers))
+		for _, t := range transfers {
+			data = append(data, newTransferJSON(t))
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": data,
+		})
+	}
+}
+
+// GetByID returns a transfer by ID
+// @Summary Get a transfer by ID
+// @Description Retrieve the transfer with the given ID
+// @Tags Transfer
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} handler.TransferJSON "Transfer"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format"
+// @Failure 404 {object} resterr.RestErr "Transfer not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/transfers/{id} [get]
+func (h *TransferHandler) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		t, err := h.sv.FindByID(id)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": newTransferJSON(t),
+		})
+	}
+}
+
+// Create transfers a quantity of a product batch to another section
+// @Summary Create a transfer
+// @Description Move a quantity of a product batch to another section. The batch is split when only part of it is moved.
+// @Description Transfers inside a warehouse are received right away, transfers between warehouses start as pending.
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param request body handler.TransferCreateRequest true "Batch, destination section, quantity and employee"
+// @Success 201 {object} handler.TransferJSON "Created transfer"
+// @Failure 400 {object} resterr.RestErr "Invalid data, incompatible section or not enough room in the section"
+// @Failure 409 {object} resterr.RestErr "Batch, section or employee not found, batch on hold or its stock reserved"
+// @Failure 422 {object} resterr.RestErr "Transfer inputs are missing"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/transfers [post]
+func (h *TransferHandler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var requestInput TransferCreateRequest
+
+		// decoding the request
+		if err := json.NewDecoder(r.Body).Decode(&requestInput); err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+			return
+		}
+
+		// validating the request input required fields
+		causes := requestInput.ValidateRequiredFields()
+		if len(causes) > 0 {
+			response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityWithCausesError(internal.ErrTransferUnprocessableEntity.Error(), causes))
+			return
+		}
+
+		t := internal.Transfer{
+			ProductBatchID: *requestInput.ProductBatchID,
+			ToSectionID:    *requestInput.SectionID,
+			Quantity:       *requestInput.Quantity,
+			EmployeeID:     *requestInput.EmployeeID,
+		}
+
+		if err := h.sv.Save(&t); err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"data": newTransferJSON(t),
+		})
+	}
+}
+
+// Dispatch sends the stock of a pending transfer
+// @Summary Dispatch a transfer
+// @Description Take the quantity of a pending transfer out of its batch and put it in transit
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Param request body handler.TransferStatusRequest true "Employee"
+// @Success 200 {object} handler.TransferJSON "Dispatched transfer"
+// @Failure 400 {object} resterr.RestErr "Invalid data"
+// @Failure 404 {object} resterr.RestErr "Transfer not found"
+// @Failure 409 {object} resterr.RestErr "Transfer is not pending, batch on hold or its stock reserved or employee not found"
+// @Failure 422 {object} resterr.RestErr "Transfer inputs are missing"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/transfers/{id}/dispatch [post]
+func (h *TransferHandler) Dispatch() http.HandlerFunc {
+	return h.change(h.sv.Dispatch)
+}
+
+// Receive stores the stock of a transfer in transit in its destination section
+// @Summary Receive a transfer
+// @Description Store the quantity of a transfer in transit in a split of its batch in the destination section
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Param request body handler.TransferStatusRequest true "Employee"
+// @Success 200 {object} handler.TransferJSON "Received transfer"
+// @Failure 400 {object} resterr.RestErr "Invalid data or not enough room in the section"
+// @Failure 404 {object} resterr.RestErr "Transfer not found"
+// @Failure 409 {object} resterr.RestErr "Transfer is not in transit or employee not found"
+// @Failure 422 {object} resterr.RestErr "Transfer inputs are missing"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/transfers/{id}/receive [post]
+func (h *TransferHandler) Receive() http.HandlerFunc {
+	return h.change(h.sv.Receive)
+}
+
+// change decodes a status change request and applies it with the given function
+func (h *TransferHandler) change(apply func(id int, employeeID int) (internal.Transfer, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidID))
+			return
+		}
+
+		var requestInput TransferStatusRequest
+
+		// decoding the request
+		if err := json.NewDecoder(r.Body).Decode(&requestInput); err != nil {
+			response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(ErrInvalidData))
+			return
+		}
+
+		// validating the request input required fields
+		if requestInput.EmployeeID == nil {
+			response.JSON(w, http.StatusUnprocessableEntity, resterr.NewUnprocessableEntityWithCausesError(internal.ErrTransferUnprocessableEntity.Error(), []resterr.Causes{{
+				Field:   "employee_id",
+				Message: "employee id is required",
+			}}))
+			return
+		}
+
+		t, err := apply(id, *requestInput.EmployeeID)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": newTransferJSON(t),
+		})
+	}
+}
+
+func (h *TransferHandler) handleError(w http.ResponseWriter, err error) {
+	var domainError internal.DomainError
+
+	switch {
+	case errors.As(err, &domainError):
+		var restCauses []resterr.Causes
+		for _, cause := range domainError.Causes {
+			restCauses = append(restCauses, resterr.Causes{
+				Field:   cause.Field,
+				Message: cause.Message,
+			})
+		}
+
+		response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+	case errors.Is(err, internal.ErrTransferNotFound):
+		response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+	case errors.Is(err, internal.ErrProductBatchNotFound),
+		errors.Is(err, internal.ErrSectionNotFound),
+		errors.Is(err, internal.ErrProductNotFound),
+		errors.Is(err, internal.ErrEmployeeNotFound),
+		errors.Is(err, internal.ErrProductBatchOnHold),
+		errors.Is(err, internal.ErrStockMovementInsufficientStock),
+		errors.Is(err, internal.ErrTransferStockReserved),
+		errors.Is(err, internal.ErrTransferInvalidTransition):
+		response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
+	default:
+		response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+	}
+}
+
+// Validating the TransferCreateRequest required fields
+func (p *TransferCreateRequest) ValidateRequiredFields() (causes []resterr.Causes) {
+	if p.ProductBatchID == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "product_batch_id",
+			Message: "product batch id is required",
+		})
+	}
+	if p.SectionID == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "section_id",
+			Message: "section id is required",
+		})
+	}
+	if p.Quantity == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "quantity",
+			Message: "quantity is required",
+		})
+	}
+	if p.EmployeeID == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "employee_id",
+			Message: "employee id is required",
+		})
+	}
+	return
+}
+
+func newTransferJSON(t internal.Transfer) TransferJSON {
+	data := TransferJSON{
+		ID:             t.ID,
+		ProductBatchID: t.ProductBatchID,
+		FromSectionID:  t.FromSectionID,
+		ToSectionID:    t.ToSectionID,
+		Quantity:       t.Quantity,
+		Status:         t.Status,
+		EmployeeID:     t.EmployeeID,
+		CreatedAt:      t.CreatedAt.Format(time.DateTime),
+	}
+
+	if t.DestinationProductBatchID != 0 {
+		data.DestinationProductBatchID = &t.DestinationProductBatchID
+	}
+
+	if t.DispatchedBy != 0 {
+		data.DispatchedBy = &t.DispatchedBy
+	}
+
+	if t.ReceivedBy != 0 {
+		data.ReceivedBy = &t.ReceivedBy
+	}
+
+	if t.DispatchedAt != nil {
+		dispatchedAt := t.DispatchedAt.Format(time.DateTime)
+		data.DispatchedAt = &dispatchedAt
+	}
+
+	if t.ReceivedAt != nil {
+		receivedAt := t.ReceivedAt.Format(time.DateTime)
+		data.ReceivedAt = &receivedAt
+	}
+
+	return data
+}
@@ -0,0 +1,251 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/handler"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewTransferServiceMock() *TransferServiceMock {
+	return &TransferServiceMock{}
+}
+
+type TransferServiceMock struct {
+	mock.Mock
+}
+
+func (m *TransferServiceMock) FindAll(status string) ([]internal.Transfer, error) {
+	args := m.Called(status)
+	return args.Get(0).([]internal.Transfer), args.Error(1)
+}
+
+func (m *TransferServiceMock) FindByID(id int) (internal.Transfer, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.Transfer), args.Error(1)
+}
+
+func (m *TransferServiceMock) Save(t *internal.Transfer) error {
+	args := m.Called(t)
+	return args.Error(0)
+}
+
+func (m *TransferServiceMock) Dispatch(id int, employeeID int) (internal.Transfer, error) {
+	args := m.Called(id, employeeID)
+	return args.Get(0).(internal.Transfer), args.Error(1)
+}
+
+func (m *TransferServiceMock) Receive(id int, employeeID int) (internal.Transfer, error) {
+	args := m.Called(id, employeeID)
+	return args.Get(0).(internal.Transfer), args.Error(1)
+}
+
+func TestTransfer_GetAll(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		description       string
+		query             string
+		expectedBody      string
+		expectedCode      int
+		mock              func() *TransferServiceMock
+		expectedMockCalls int
+	}{
+		{
+			description:  "case 1 - success: Transfers in transit",
+			query:        "?status=in_transit",
+			expectedBody: `{"data":[{"id":1,"product_batch_id":1,"destination_product_batch_id":null,"from_section_id":3,"to_section_id":9,"quantity":40,"status":"in_transit","employee_id":2,"dispatched_by":2,"received_by":null,"created_at":"2025-01-01 10:00:00","dispatched_at":"2025-01-01 10:00:00","received_at":null}]}`,
+			expectedCode: http.StatusOK,
+			mock: func() *TransferServiceMock {
+				mk := NewTransferServiceMock()
+				mk.On("FindAll", internal.TransferStatusInTransit).Return([]internal.Transfer{
+					{ID: 1, ProductBatchID: 1, FromSectionID: 3, ToSectionID: 9, Quantity: 40, Status: internal.TransferStatusInTransit, EmployeeID: 2, DispatchedBy: 2, CreatedAt: createdAt, DispatchedAt: &createdAt},
+				}, nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 2 - error: Unknown status",
+			query:        "?status=lost",
+			expectedBody: `{"message":"status must be pending, in_transit or received","error":"bad_request","code":400,"causes":null}`,
+			expectedCode: http.StatusBadRequest,
+			mock: func() *TransferServiceMock {
+				return NewTransferServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewTransferHandler(sv)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/transfers"+tc.query, nil)
+			response := httptest.NewRecorder()
+
+			hd.GetAll()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+			sv.AssertNumberOfCalls(t, "FindAll", tc.expectedMockCalls)
+		})
+	}
+}
+
+func TestTransfer_Create(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		description       string
+		body              string
+		expectedBody      string
+		expectedCode      int
+		mock              func() *TransferServiceMock
+		expectedMockCalls int
+	}{
+		{
+			description:  "case 1 - success: Transfer between warehouses is pending",
+			body:         `{"product_batch_id": 1, "section_id": 9, "quantity": 40, "employee_id": 2}`,
+			expectedBody: `{"data":{"id":1,"product_batch_id":1,"destination_product_batch_id":null,"from_section_id":3,"to_section_id":9,"quantity":40,"status":"pending","employee_id":2,"dispatched_by":null,"received_by":null,"created_at":"2025-01-01 10:00:00","dispatched_at":null,"received_at":null}}`,
+			expectedCode: http.StatusCreated,
+			mock: func() *TransferServiceMock {
+				mk := NewTransferServiceMock()
+				mk.On("Save", mock.Anything).Run(func(args mock.Arguments) {
+					tr := args.Get(0).(*internal.Transfer)
+					tr.ID = 1
+					tr.FromSectionID = 3
+					tr.Status = internal.TransferStatusPending
+					tr.CreatedAt = createdAt
+				}).Return(nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 2 - error: Missing required fields",
+			body:         `{"product_batch_id": 1}`,
+			expectedBody: `{"message":"transfer inputs are missing","error":"unprocessable_entity","code":422,"causes":[{"field":"section_id","message":"section id is required"},{"field":"quantity","message":"quantity is required"},{"field":"employee_id","message":"employee id is required"}]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			mock: func() *TransferServiceMock {
+				return NewTransferServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+		{
+			description:  "case 3 - error: Not enough room in the destination section",
+			body:         `{"product_batch_id": 1, "section_id": 5, "quantity": 40, "employee_id": 2}`,
+			expectedBody: `{"message":"section capacity exceeded","error":"bad_request","code":400,"causes":[{"field":"section_id","message":"section 5 has room for 10 units but 40 were requested"}]}`,
+			expectedCode: http.StatusBadRequest,
+			mock: func() *TransferServiceMock {
+				mk := NewTransferServiceMock()
+				mk.On("Save", mock.Anything).Return(internal.NewSectionCapacityExceededError(5, 10, 40))
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			description:  "case 4 - error: Batch on hold",
+			body:         `{"product_batch_id": 1, "section_id": 5, "quantity": 40, "employee_id": 2}`,
+			expectedBody: `{"message":"product-batch is on hold or quarantined","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *TransferServiceMock {
+				mk := NewTransferServiceMock()
+				mk.On("Save", mock.Anything).Return(internal.ErrProductBatchOnHold)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewTransferHandler(sv)
+
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/transfers", strings.NewReader(tc.body))
+			response := httptest.NewRecorder()
+
+			hd.Create()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+			sv.AssertNumberOfCalls(t, "Save", tc.expectedMockCalls)
+		})
+	}
+}
+
+func TestTransfer_Receive(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	receivedAt := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		description  string
+		body         string
+		expectedBody string
+		expectedCode int
+		mock         func() *TransferServiceMock
+	}{
+		{
+			description:  "case 1 - success: Transfer received",
+			body:         `{"employee_id": 3}`,
+			expectedBody: `{"data":{"id":1,"product_batch_id":1,"destination_product_batch_id":8,"from_section_id":3,"to_section_id":9,"quantity":40,"status":"received","employee_id":2,"dispatched_by":2,"received_by":3,"created_at":"2025-01-01 10:00:00","dispatched_at":"2025-01-01 10:00:00","received_at":"2025-01-02 09:00:00"}}`,
+			expectedCode: http.StatusOK,
+			mock: func() *TransferServiceMock {
+				mk := NewTransferServiceMock()
+				mk.On("Receive", 1, 3).Return(internal.Transfer{
+					ID: 1, ProductBatchID: 1, DestinationProductBatchID: 8, FromSectionID: 3, ToSectionID: 9, Quantity: 40, Status: internal.TransferStatusReceived,
+					EmployeeID: 2, DispatchedBy: 2, ReceivedBy: 3, CreatedAt: createdAt, DispatchedAt: &createdAt, ReceivedAt: &receivedAt,
+				}, nil)
+				return mk
+			},
+		},
+		{
+			description:  "case 2 - error: Transfer is still pending",
+			body:         `{"employee_id": 3}`,
+			expectedBody: `{"message":"transfer status transition is not allowed","error":"conflict","code":409,"causes":null}`,
+			expectedCode: http.StatusConflict,
+			mock: func() *TransferServiceMock {
+				mk := NewTransferServiceMock()
+				mk.On("Receive", 1, 3).Return(internal.Transfer{}, internal.ErrTransferInvalidTransition)
+				return mk
+			},
+		},
+		{
+			description:  "case 3 - error: Missing employee",
+			body:         `{}`,
+			expectedBody: `{"message":"transfer inputs are missing","error":"unprocessable_entity","code":422,"causes":[{"field":"employee_id","message":"employee id is required"}]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			mock: func() *TransferServiceMock {
+				return NewTransferServiceMock()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewTransferHandler(sv)
+
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/transfers/1/receive", strings.NewReader(tc.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "1")
+			request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+			response := httptest.NewRecorder()
+
+			hd.Receive()(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+		})
+	}
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"math"
 	"net/http"
 	"strconv"
 
@@ -14,12 +15,22 @@ import (
 
 // WarehouseJSON represents the warehouse in JSON format.
 type WarehouseJSON struct {
-	ID                 int     `json:"id"`
-	WarehouseCode      string  `json:"warehouse_code"`
-	Address            string  `json:"address"`
-	Telephone          string  `json:"telephone"`
-	MinimumCapacity    int     `json:"minimum_capacity"`
-	MinimumTemperature float64 `json:"minimum_temperature"`
+	ID                 int      `json:"id"`
+	WarehouseCode      string   `json:"warehouse_code"`
+	Address            string   `json:"address"`
+	Telephone          string   `json:"telephone"`
+	MinimumCapacity    int      `json:"minimum_capacity"`
+	MinimumTemperature float64  `json:"minimum_temperature"`
+	LocalityID         int      `json:"locality_id,omitempty"`
+	Latitude           *float64 `json:"latitude,omitempty"`
+	Longitude          *float64 `json:"longitude,omitempty"`
+}
+
+// NearestWarehouseJSON represents a warehouse able to deliver a product and its distance to a locality in JSON format.
+type NearestWarehouseJSON struct {
+	WarehouseJSON
+	AvailableQuantity int     `json:"available_quantity"`
+	DistanceKm        float64 `json:"distance_km"`
 }
 
 type WarehouseCreateRequest struct {
@@ -28,6 +39,9 @@ type WarehouseCreateRequest struct {
 	Telephone          *string  `json:"telephone"`
 	MinimumCapacity    *int     `json:"minimum_capacity"`
 	MinimumTemperature *float64 `json:"minimum_temperature"`
+	LocalityID         *int     `json:"locality_id"`
+	Latitude           *float64 `json:"latitude"`
+	Longitude          *float64 `json:"longitude"`
 }
 
 var (
@@ -69,14 +83,7 @@ func (h *WarehouseDefault) GetAll() http.HandlerFunc {
 		// Data to be returned
 		var data []WarehouseJSON
 		for _, warehouse := range warehouses {
-			data = append(data, WarehouseJSON{
-				ID:                 warehouse.ID,
-				WarehouseCode:      warehouse.WarehouseCode,
-				Address:            warehouse.Address,
-				Telephone:          warehouse.Telephone,
-				MinimumCapacity:    warehouse.MinimumCapacity,
-				MinimumTemperature: warehouse.MinimumTemperature,
-			})
+			data = append(data, newWarehouseJSON(warehouse))
 		}
 
 		response.JSON(w, http.StatusOK, map[string]any{
@@ -118,14 +125,7 @@ func (h *WarehouseDefault) GetByID() http.HandlerFunc {
 			return
 		}
 
-		warehouseJSON := WarehouseJSON{
-			ID:                 warehouse.ID,
-			WarehouseCode:      warehouse.WarehouseCode,
-			Address:            warehouse.Address,
-			Telephone:          warehouse.Telephone,
-			MinimumCapacity:    warehouse.MinimumCapacity,
-			MinimumTemperature: warehouse.MinimumTemperature,
-		}
+		warehouseJSON := newWarehouseJSON(warehouse)
 
 		response.JSON(w, http.StatusOK, map[string]any{
 			"data": warehouseJSON,
@@ -142,7 +142,7 @@ func (h *WarehouseDefault) GetByID() http.HandlerFunc {
 // @Param warehouse body WarehouseCreateRequest true "Warehouse data"
 // @Success 201 {object} WarehouseJSON "Created warehouse"
 // @Failure 400 {object} resterr.RestErr "Invalid Data"
-// @Failure 409 {object} resterr.RestErr "Warehouse already exists"
+// @Failure 409 {object} resterr.RestErr "Warehouse already exists or locality not found"
 // @Failure 422 {object} resterr.RestErr "Unprocessable Entity"
 // @Failure 500 {object} resterr.RestErr "Internal Server Error"
 // @Router /api/v1/warehouses [post]
@@ -171,6 +171,14 @@ func (h *WarehouseDefault) Create() http.HandlerFunc {
 			Telephone:          *requestInput.Telephone,
 			MinimumCapacity:    *requestInput.MinimumCapacity,
 			MinimumTemperature: *requestInput.MinimumTemperature,
+			LocalityID:         *requestInput.LocalityID,
+		}
+
+		if requestInput.Latitude != nil {
+			warehouse.Coordinates = &internal.Coordinates{
+				Latitude:  *requestInput.Latitude,
+				Longitude: *requestInput.Longitude,
+			}
 		}
 
 		// save the warehouse
@@ -188,7 +196,7 @@ func (h *WarehouseDefault) Create() http.HandlerFunc {
 					})
 				}
 				response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
-			case errors.Is(err, internal.ErrWarehouseRepositoryDuplicated):
+			case errors.Is(err, internal.ErrWarehouseRepositoryDuplicated), errors.Is(err, internal.ErrLocalityNotFound):
 				response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
 			default:
 				response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
@@ -198,14 +206,7 @@ func (h *WarehouseDefault) Create() http.HandlerFunc {
 		}
 
 		// return the warehouse
-		warehouseJSON := WarehouseJSON{
-			ID:                 warehouse.ID,
-			WarehouseCode:      warehouse.WarehouseCode,
-			Address:            warehouse.Address,
-			Telephone:          warehouse.Telephone,
-			MinimumCapacity:    warehouse.MinimumCapacity,
-			MinimumTemperature: warehouse.MinimumTemperature,
-		}
+		warehouseJSON := newWarehouseJSON(warehouse)
 
 		response.JSON(w, http.StatusCreated, map[string]any{
 			"data": warehouseJSON,
@@ -222,9 +223,9 @@ func (h *WarehouseDefault) Create() http.HandlerFunc {
 // @Param id path int true "Warehouse ID"
 // @Param warehouse body internal.WarehousePatchUpdate true "Updated warehouse data"
 // @Success 200 {object} WarehouseJSON "Updated warehouse"
-// @Failure 400 {object} resterr.RestErr "Invalid ID format" or "Invalid Data"
+// @Failure 400 {object} resterr.RestErr "Invalid ID format" or "Invalid Data" or coordinates out of range
 // @Failure 404 {object} resterr.RestErr "Warehouse not found"
-// @Failure 409 {object} resterr.RestErr "Warehouse already exists"
+// @Failure 409 {object} resterr.RestErr "Warehouse already exists or locality not found"
 // @Failure 500 {object} resterr.RestErr "Internal Server Error"
 // @Router /api/v1/warehouses/{id} [patch]
 func (h *WarehouseDefault) Update() http.HandlerFunc {
@@ -247,8 +248,19 @@ func (h *WarehouseDefault) Update() http.HandlerFunc {
 		// Calling the service to update the warehouse
 		warehouse, err := h.sv.Update(idInt, requestInput)
 		if err != nil {
+			var domainError internal.DomainError
+
 			switch {
-			case errors.Is(err, internal.ErrWarehouseRepositoryDuplicated):
+			case errors.As(err, &domainError):
+				var restCauses []resterr.Causes
+				for _, cause := range domainError.Causes {
+					restCauses = append(restCauses, resterr.Causes{
+						Field:   cause.Field,
+						Message: cause.Message,
+					})
+				}
+				response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+			case errors.Is(err, internal.ErrWarehouseRepositoryDuplicated), errors.Is(err, internal.ErrLocalityNotFound):
 				response.JSON(w, http.StatusConflict, resterr.NewConflictError(err.Error()))
 			case errors.Is(err, internal.ErrWarehouseRepositoryNotFound):
 				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
@@ -260,14 +272,7 @@ func (h *WarehouseDefault) Update() http.HandlerFunc {
 		}
 
 		// Returning the updated warehouse
-		warehouseJSON := WarehouseJSON{
-			ID:                 warehouse.ID,
-			WarehouseCode:      warehouse.WarehouseCode,
-			Address:            warehouse.Address,
-			Telephone:          warehouse.Telephone,
-			MinimumCapacity:    warehouse.MinimumCapacity,
-			MinimumTemperature: warehouse.MinimumTemperature,
-		}
+		warehouseJSON := newWarehouseJSON(warehouse)
 
 		response.JSON(w, http.StatusOK, map[string]any{
 			"data": warehouseJSON,
@@ -311,6 +316,80 @@ func (h *WarehouseDefault) Delete() http.HandlerFunc {
 	}
 }
 
+// GetNearest returns the warehouses able to deliver a product to a locality, nearest first
+// @Summary Get the nearest warehouses holding a product
+// @Description Retrieve the warehouses holding at least qty of released, unexpired and unreserved stock of the product, ordered by great-circle distance to the locality. Warehouses without coordinates are left out.
+// @Tags Warehouse
+// @Produce json
+// @Param locality_id query int true "Locality ID"
+// @Param product_id query int true "Product ID"
+// @Param qty query int true "Quantity"
+// @Success 200 {object} []NearestWarehouseJSON "Warehouses ordered by distance"
+// @Failure 400 {object} resterr.RestErr "Invalid query or locality without coordinates"
+// @Failure 404 {object} resterr.RestErr "Locality or product not found"
+// @Failure 500 {object} resterr.RestErr "Internal Server Error"
+// @Router /api/v1/warehouses/nearest [get]
+func (h *WarehouseDefault) GetNearest() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var request internal.NearestWarehouseRequest
+
+		params := []struct {
+			name  string
+			value *int
+		}{
+			{"locality_id", &request.LocalityID},
+			{"product_id", &request.ProductID},
+			{"qty", &request.Quantity},
+		}
+
+		for _, param := range params {
+			value, err := strconv.Atoi(r.URL.Query().Get(param.name))
+			if err != nil {
+				response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestError(param.name+" should be a number"))
+				return
+			}
+
+			*param.value = value
+		}
+
+		warehouses, err := h.sv.FindNearest(request)
+		if err != nil {
+			var domainError internal.DomainError
+
+			switch {
+			case errors.As(err, &domainError):
+				var restCauses []resterr.Causes
+				for _, cause := range domainError.Causes {
+					restCauses = append(restCauses, resterr.Causes{
+						Field:   cause.Field,
+						Message: cause.Message,
+					})
+				}
+				response.JSON(w, http.StatusBadRequest, resterr.NewBadRequestValidationError(domainError.Message, restCauses))
+			case errors.Is(err, internal.ErrLocalityNotFound), errors.Is(err, internal.ErrProductNotFound):
+				response.JSON(w, http.StatusNotFound, resterr.NewNotFoundError(err.Error()))
+			default:
+				response.JSON(w, http.StatusInternalServerError, resterr.NewInternalServerError(ErrInternalServer))
+			}
+
+			return
+		}
+
+		data := make([]NearestWarehouseJSON, 0, len(warehouses))
+		for _, nearest := range warehouses {
+			data = append(data, NearestWarehouseJSON{
+				WarehouseJSON:     newWarehouseJSON(nearest.Warehouse),
+				AvailableQuantity: nearest.AvailableQuantity,
+				DistanceKm:        math.Round(nearest.DistanceKm*100) / 100,
+			})
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"data": data,
+		})
+	}
+}
+
 // Validating the WarehouseCreateRequest required fields
 func (r *WarehouseCreateRequest) ValidateRequiredFields() (causes []resterr.Causes) {
 	if r.WarehouseCode == nil {
@@ -343,5 +422,36 @@ func (r *WarehouseCreateRequest) ValidateRequiredFields() (causes []resterr.Caus
 			Message: "minimum temperature is required",
 		})
 	}
+	if r.LocalityID == nil {
+		causes = append(causes, resterr.Causes{
+			Field:   "locality_id",
+			Message: "locality id is required",
+		})
+	}
+	if (r.Latitude == nil) != (r.Longitude == nil) {
+		causes = append(causes, resterr.Causes{
+			Field:   "coordinates",
+			Message: "latitude and longitude are required together",
+		})
+	}
 	return causes
 }
+
+func newWarehouseJSON(warehouse internal.Warehouse) WarehouseJSON {
+	data := WarehouseJSON{
+		ID:                 warehouse.ID,
+		WarehouseCode:      warehouse.WarehouseCode,
+		Address:            warehouse.Address,
+		Telephone:          warehouse.Telephone,
+		MinimumCapacity:    warehouse.MinimumCapacity,
+		MinimumTemperature: warehouse.MinimumTemperature,
+		LocalityID:         warehouse.LocalityID,
+	}
+
+	if warehouse.Coordinates != nil {
+		data.Latitude = &warehouse.Coordinates.Latitude
+		data.Longitude = &warehouse.Coordinates.Longitude
+	}
+
+	return data
+}
@@ -54,6 +54,12 @@ func (w *WarehouseServiceMock) Delete(id int) error {
 	return args.Error(0)
 }
 
+// Warehouse Service FindNearest returns the warehouses able to deliver a product ordered by distance
+func (w *WarehouseServiceMock) FindNearest(request internal.NearestWarehouseRequest) ([]internal.NearestWarehouse, error) {
+	args := w.Called(request)
+	return args.Get(0).([]internal.NearestWarehouse), args.Error(1)
+}
+
 var (
 	endpointWarehouse = "/api/v1/warehouses"
 	jsonHeader        = http.Header{"Content-Type": []string{"application/json"}}
@@ -79,8 +85,8 @@ func TestWarehouseHandler_Create(t *testing.T) {
 			name:           "case 1 - success: Create a new warehouse",
 			method:         "POST",
 			url:            endpointWarehouse,
-			body:           `{"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_capacity":300,"minimum_temperature":0}`,
-			expectedBody:   `{"data":{"id":3,"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_capacity":300,"minimum_temperature":0}}`,
+			body:           `{"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_capacity":300,"minimum_temperature":0,"locality_id":1}`,
+			expectedBody:   `{"data":{"id":3,"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_capacity":300,"minimum_temperature":0,"locality_id":1}}`,
 			expectedCode:   http.StatusCreated,
 			expectedHeader: jsonHeader,
 			mock: func() *WarehouseServiceMock {
@@ -97,7 +103,7 @@ func TestWarehouseHandler_Create(t *testing.T) {
 			name:   "case 2 - error: Attempt to create a new warehouse without minimum temperature",
 			method: "POST",
 			url:    endpointWarehouse,
-			body:   `{"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_capacity":300}`,
+			body:   `{"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_capacity":300,"locality_id":1}`,
 			expectedBody: `{
 				"message": "warehouse inputs are missing",
 				"error": "unprocessable_entity",
@@ -118,7 +124,7 @@ func TestWarehouseHandler_Create(t *testing.T) {
 			name:   "case 3 - error: Attempt to create a new warehouse without minimum capacity",
 			method: "POST",
 			url:    endpointWarehouse,
-			body:   `{"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_temperature":5}`,
+			body:   `{"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_temperature":5,"locality_id":1}`,
 			expectedBody: `{
 				"message": "warehouse inputs are missing",
 				"error": "unprocessable_entity",
@@ -139,7 +145,7 @@ func TestWarehouseHandler_Create(t *testing.T) {
 			name:   "case 4 - error: Attempt to create a new warehouse with invalid data",
 			method: "POST",
 			url:    endpointWarehouse,
-			body:   `{"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_capacity":300,"minimum_temperature":"invalid"}`,
+			body:   `{"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_capacity":300,"minimum_temperature":"invalid","locality_id":1}`,
 			expectedBody: `{
 				"message": "Invalid data",
 				"error": "bad_request",
@@ -158,7 +164,7 @@ func TestWarehouseHandler_Create(t *testing.T) {
 			name:   "case 5 - error: Attempt to create a new warehouse with an existing warehouse code",
 			method: "POST",
 			url:    endpointWarehouse,
-			body:   `{"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_capacity":300,"minimum_temperature":5}`,
+			body:   `{"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_capacity":300,"minimum_temperature":5,"locality_id":1}`,
 			expectedBody: `{
 				"message": "warehouse already exists",
 				"error": "conflict",
@@ -178,7 +184,7 @@ func TestWarehouseHandler_Create(t *testing.T) {
 			name:   "case 6 - error: Attempt to create a new warehouse generating an unexpected error",
 			method: "POST",
 			url:    endpointWarehouse,
-			body:   `{"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_capacity":300,"minimum_temperature":5}`,
+			body:   `{"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_capacity":300,"minimum_temperature":5,"locality_id":1}`,
 			expectedBody: `{
 				"message": "Internal Server Error",
 				"error": "internal_server_error",
@@ -198,7 +204,7 @@ func TestWarehouseHandler_Create(t *testing.T) {
 			name:   "case 7 - error: Attempt to create a new warehouse with a negative minimum capacity",
 			method: "POST",
 			url:    endpointWarehouse,
-			body:   `{"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_capacity":-300,"minimum_temperature":5}`,
+			body:   `{"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_capacity":-300,"minimum_temperature":5,"locality_id":1}`,
 			expectedBody: `{
 				"message": "warehouse inputs are missing",
 				"error": "bad_request",
@@ -220,7 +226,7 @@ func TestWarehouseHandler_Create(t *testing.T) {
 			name:   "case 8 - error: Attempt to create a new warehouse with a minimum temperature out of range",
 			method: "POST",
 			url:    endpointWarehouse,
-			body:   `{"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_capacity":100,"minimum_temperature":-300.00}`,
+			body:   `{"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_capacity":100,"minimum_temperature":-300.00,"locality_id":1}`,
 			expectedBody: `{
 				"message": "warehouse inputs are missing",
 				"error": "bad_request",
@@ -242,7 +248,7 @@ func TestWarehouseHandler_Create(t *testing.T) {
 			name:   "case 9 - error: Attempt to create a new warehouse without warehouse code",
 			method: "POST",
 			url:    endpointWarehouse,
-			body:   `{"address":"789 Oak St","telephone":"555-1234","minimum_capacity":100,"minimum_temperature":5}`,
+			body:   `{"address":"789 Oak St","telephone":"555-1234","minimum_capacity":100,"minimum_temperature":5,"locality_id":1}`,
 			expectedBody: `{
 				"message": "warehouse inputs are missing",
 				"error": "unprocessable_entity",
@@ -263,7 +269,7 @@ func TestWarehouseHandler_Create(t *testing.T) {
 			name:   "case 10 - error: Attempt to create a new warehouse without address",
 			method: "POST",
 			url:    endpointWarehouse,
-			body:   `{"warehouse_code":"W3","telephone":"555-1234","minimum_capacity":100,"minimum_temperature":5}`,
+			body:   `{"warehouse_code":"W3","telephone":"555-1234","minimum_capacity":100,"minimum_temperature":5,"locality_id":1}`,
 			expectedBody: `{
 				"message": "warehouse inputs are missing",
 				"error": "unprocessable_entity",
@@ -284,7 +290,7 @@ func TestWarehouseHandler_Create(t *testing.T) {
 			name:   "case 11 - error: Attempt to create a new warehouse without telephone",
 			method: "POST",
 			url:    endpointWarehouse,
-			body:   `{"warehouse_code":"W3","address":"789 Oak St","minimum_capacity":100,"minimum_temperature":5}`,
+			body:   `{"warehouse_code":"W3","address":"789 Oak St","minimum_capacity":100,"minimum_temperature":5,"locality_id":1}`,
 			expectedBody: `{
 				"message": "warehouse inputs are missing",
 				"error": "unprocessable_entity",
@@ -301,6 +307,26 @@ func TestWarehouseHandler_Create(t *testing.T) {
 			},
 			expectedMockCalls: 0,
 		},
+		{
+			name:   "case 12 - error: Attempt to create a new warehouse in a non existent locality",
+			method: "POST",
+			url:    endpointWarehouse,
+			body:   `{"warehouse_code":"W3","address":"789 Oak St","telephone":"555-1234","minimum_capacity":100,"minimum_temperature":5,"locality_id":99}`,
+			expectedBody: `{
+				"message": "locality not found",
+				"error": "conflict",
+				"code": 409,
+				"causes": null
+			}`,
+			expectedCode:   http.StatusConflict,
+			expectedHeader: jsonHeader,
+			mock: func() *WarehouseServiceMock {
+				mk := NewWarehouseServiceMock()
+				mk.On("Save", mock.Anything).Return(internal.ErrLocalityNotFound)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
 	}
 
 	for _, tc := range cases {
@@ -751,3 +777,90 @@ func TestWarehouseHandler_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestWarehouseHandler_GetNearest(t *testing.T) {
+	cases := []*TestCases{
+		{
+			name:           "case 1 - success: Get the nearest warehouses",
+			method:         "GET",
+			url:            endpointWarehouse + "/nearest?locality_id=1&product_id=2&qty=10",
+			expectedCode:   http.StatusOK,
+			expectedHeader: jsonHeader,
+			expectedBody: `{"data":[
+				{"id":2,"warehouse_code":"W2","address":"456 Elm St","telephone":"987-654-3210","minimum_capacity":200,"minimum_temperature":0,"latitude":39.9526,"longitude":-75.1652,"available_quantity":10,"distance_km":129.61}
+			]}`,
+			mock: func() *WarehouseServiceMock {
+				mk := NewWarehouseServiceMock()
+				mk.On("FindNearest", internal.NearestWarehouseRequest{LocalityID: 1, ProductID: 2, Quantity: 10}).Return([]internal.NearestWarehouse{
+					{
+						Warehouse:         internal.Warehouse{ID: 2, WarehouseCode: "W2", Address: "456 Elm St", Telephone: "987-654-3210", MinimumCapacity: 200, Coordinates: &internal.Coordinates{Latitude: 39.9526, Longitude: -75.1652}},
+						AvailableQuantity: 10,
+						DistanceKm:        129.6137,
+					},
+				}, nil)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			name:           "case 2 - error: Quantity is not a number",
+			method:         "GET",
+			url:            endpointWarehouse + "/nearest?locality_id=1&product_id=2&qty=ten",
+			expectedCode:   http.StatusBadRequest,
+			expectedHeader: jsonHeader,
+			expectedBody:   `{"message":"qty should be a number","error":"bad_request","code":400,"causes":null}`,
+			mock: func() *WarehouseServiceMock {
+				return NewWarehouseServiceMock()
+			},
+			expectedMockCalls: 0,
+		},
+		{
+			name:           "case 3 - error: Locality without coordinates",
+			method:         "GET",
+			url:            endpointWarehouse + "/nearest?locality_id=1&product_id=2&qty=10",
+			expectedCode:   http.StatusBadRequest,
+			expectedHeader: jsonHeader,
+			expectedBody:   `{"message":"nearest warehouse inputs are invalid","error":"bad_request","code":400,"causes":[{"field":"locality_id","message":"locality 1 has no coordinates"}]}`,
+			mock: func() *WarehouseServiceMock {
+				mk := NewWarehouseServiceMock()
+				mk.On("FindNearest", mock.Anything).Return([]internal.NearestWarehouse(nil), internal.DomainError{
+					Message: internal.ErrNearestWarehouseBadRequest.Error(),
+					Causes:  []internal.Causes{{Field: "locality_id", Message: "locality 1 has no coordinates"}},
+				})
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+		{
+			name:           "case 4 - error: Locality not found",
+			method:         "GET",
+			url:            endpointWarehouse + "/nearest?locality_id=99&product_id=2&qty=10",
+			expectedCode:   http.StatusNotFound,
+			expectedHeader: jsonHeader,
+			expectedBody:   `{"message":"locality not found","error":"not_found","code":404,"causes":null}`,
+			mock: func() *WarehouseServiceMock {
+				mk := NewWarehouseServiceMock()
+				mk.On("FindNearest", mock.Anything).Return([]internal.NearestWarehouse(nil), internal.ErrLocalityNotFound)
+				return mk
+			},
+			expectedMockCalls: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sv := tc.mock()
+			hd := handler.NewWarehouseDefault(sv)
+			hdFunc := hd.GetNearest()
+
+			request := httptest.NewRequest(tc.method, tc.url, nil)
+			response := httptest.NewRecorder()
+
+			hdFunc(response, request)
+
+			require.Equal(t, tc.expectedCode, response.Code)
+			require.JSONEq(t, tc.expectedBody, response.Body.String())
+			sv.AssertNumberOfCalls(t, "FindNearest", tc.expectedMockCalls)
+		})
+	}
+}
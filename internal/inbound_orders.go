@@ -1,33 +1,188 @@
 package internal
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/utils/validator"
+)
 
 var ErrOrderNumberAlreadyExists = errors.New("order number already exists")
 
+// ErrInboundOrderBadRequest is returned when the inbound order does not match its employee, batch or warehouse
+var ErrInboundOrderBadRequest = errors.New("inbound order inputs are invalid")
+
+// ErrInboundOrderNotFound is returned when the inbound order does not exist
+var ErrInboundOrderNotFound = errors.New("inbound order not found")
+
 type InboundOrders struct {
-	ID             int    `json:"id"`
-	OrderDate      string `json:"order_date"`
-	OrderNumber    string `json:"order_number"`
-	EmployeeID     int    `json:"employee_id"`
-	ProductBatchID int    `json:"product_batch_id"`
-	WarehouseID    int    `json:"warehouse_id"`
+	ID          int    `json:"id"`
+	OrderDate   string `json:"order_date"`
+	OrderNumber string `json:"order_number"`
+	EmployeeID  int    `json:"employee_id"`
+	// ProductBatchID is the batch received by a single batch order, zero when the order has lines
+	ProductBatchID int `json:"product_batch_id"`
+	WarehouseID    int `json:"warehouse_id"`
+	// Quantity is the number of units received, it is added to the batch current quantity.
+	// Orders with lines set it to the sum of the received quantities of their lines.
+	Quantity int `json:"quantity"`
+	// Lines are the batches announced by an advance shipping notice, each one creates a new batch
+	Lines []InboundOrderLine `json:"lines,omitempty"`
+}
+
+// InboundOrderLine is a batch delivered by a multi-line inbound order
+type InboundOrderLine struct {
+	ID               int `json:"id"`
+	InboundOrderID   int `json:"inbound_order_id"`
+	ExpectedQuantity int `json:"expected_quantity"`
+	ReceivedQuantity int `json:"received_quantity"`
+	// ProductBatch is the batch created for the line, its quantities are taken from the received quantity
+	ProductBatch ProductBatch `json:"product_batch"`
+}
+
+// InboundOrderDiscrepancy is a line whose received quantity differs from the expected one
+type InboundOrderDiscrepancy struct {
+	InboundOrderLineID int `json:"inbound_order_line_id"`
+	ProductBatchID     int `json:"product_batch_id"`
+	BatchNumber        int `json:"batch_number"`
+	ExpectedQuantity   int `json:"expected_quantity"`
+	ReceivedQuantity   int `json:"received_quantity"`
+	// Difference is the received minus the expected quantity, negative when the delivery fell short
+	Difference int `json:"difference"`
+}
+
+// InboundOrderFilter is a struct that narrows the inbound orders returned by a search.
+// Zero values are ignored.
+type InboundOrderFilter struct {
+	WarehouseID int
+	EmployeeID  int
+	// From and To bound the order date, both inclusive
+	From time.Time
+	To   time.Time
+}
+
+// InboundOrderSummary is the quantity of a product type received in a warehouse on a day
+type InboundOrderSummary struct {
+	OrderDate       string `json:"order_date"`
+	ProductTypeID   int    `json:"product_type_id"`
+	ProductTypeName string `json:"product_type_name"`
+	OrdersCount     int    `json:"orders_count"`
+	Quantity        int    `json:"received_quantity"`
 }
 
 type InboundOrderService interface {
 	Create(InboundOrders) (int64, error)
-	FindAll() ([]InboundOrders, error)
+	// FindAll returns the inbound orders that match the filter
+	FindAll(filter InboundOrderFilter) ([]InboundOrders, error)
+	FindByID(id int) (InboundOrders, error)
+	// FindSummary returns the daily received quantities per product type of a warehouse,
+	// only the From and To fields of the filter are used
+	FindSummary(warehouseID int, filter InboundOrderFilter) ([]InboundOrderSummary, error)
+	// FindDiscrepancies returns the lines of the inbound order that were not received as expected
+	FindDiscrepancies(id int) ([]InboundOrderDiscrepancy, error)
 }
 
 type InboundOrdersRepository interface {
+	// Create records the inbound order and adds its quantity to the batch.
+	// Orders with lines create a batch per line in the same transaction.
 	Create(InboundOrders) (int64, error)
-	FindAll() ([]InboundOrders, error)
+	// FindAll returns the inbound orders that match the filter, without their lines
+	FindAll(filter InboundOrderFilter) ([]InboundOrders, error)
+	// FindByID returns the inbound order with its lines
+	FindByID(id int) (InboundOrders, error)
+	// FindSummary groups the inbound orders that match the filter by day and product type
+	FindSummary(filter InboundOrderFilter) ([]InboundOrderSummary, error)
 }
 
 // ValidateFieldsOk validates required fields
 func (io *InboundOrders) ValidateFieldsOk() bool {
-	if io.OrderDate == "" || io.OrderNumber == "" || io.EmployeeID == 0 || io.ProductBatchID == 0 || io.WarehouseID == 0 {
+	if io.OrderDate == "" || io.OrderNumber == "" || io.EmployeeID == 0 || io.WarehouseID == 0 {
 		return false
 	}
 
+	if len(io.Lines) == 0 {
+		return io.ProductBatchID != 0 && io.Quantity != 0
+	}
+
+	for _, line := range io.Lines {
+		pb := line.ProductBatch
+		if line.ExpectedQuantity == 0 || pb.BatchNumber == 0 || pb.DueDate == "" || pb.ManufacturingDate == "" || pb.ProductID == 0 || pb.SectionID == 0 {
+			return false
+		}
+	}
+
 	return true
 }
+
+// Validate validates the business rules of the inbound order
+func (io *InboundOrders) Validate() (causes []Causes) {
+	if len(io.Lines) == 0 {
+		if !validator.IntIsPositive(io.Quantity) {
+			causes = append(causes, Causes{
+				Field:   "quantity",
+				Message: "quantity must be greater than zero",
+			})
+		}
+
+		return causes
+	}
+
+	if io.ProductBatchID != 0 {
+		causes = append(causes, Causes{
+			Field:   "product_batch_id",
+			Message: "product batch ID must be empty when the order has lines",
+		})
+	}
+
+	batchNumbers := make(map[int]bool, len(io.Lines))
+
+	for i, line := range io.Lines {
+		if !validator.IntIsPositive(line.ExpectedQuantity) {
+			causes = append(causes, Causes{
+				Field:   fmt.Sprintf("lines[%d].expected_quantity", i),
+				Message: "expected quantity must be greater than zero",
+			})
+		}
+
+		if line.ReceivedQuantity < 0 {
+			causes = append(causes, Causes{
+				Field:   fmt.Sprintf("lines[%d].received_quantity", i),
+				Message: "received quantity must not be negative",
+			})
+		}
+
+		if batchNumbers[line.ProductBatch.BatchNumber] {
+			causes = append(causes, Causes{
+				Field:   fmt.Sprintf("lines[%d].product_batch.batch_number", i),
+				Message: fmt.Sprintf("batch number %d is repeated in the order", line.ProductBatch.BatchNumber),
+			})
+		}
+
+		batchNumbers[line.ProductBatch.BatchNumber] = true
+	}
+
+	return causes
+}
+
+// Discrepancies returns the lines whose received quantity differs from the expected one
+func (io *InboundOrders) Discrepancies() []InboundOrderDiscrepancy {
+	discrepancies := []InboundOrderDiscrepancy{}
+
+	for _, line := range io.Lines {
+		if line.ReceivedQuantity == line.ExpectedQuantity {
+			continue
+		}
+
+		discrepancies = append(discrepancies, InboundOrderDiscrepancy{
+			InboundOrderLineID: line.ID,
+			ProductBatchID:     line.ProductBatch.ID,
+			BatchNumber:        line.ProductBatch.BatchNumber,
+			ExpectedQuantity:   line.ExpectedQuantity,
+			ReceivedQuantity:   line.ReceivedQuantity,
+			Difference:         line.ReceivedQuantity - line.ExpectedQuantity,
+		})
+	}
+
+	return discrepancies
+}
@@ -0,0 +1,96 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func newInboundOrderLine(batchNumber int, expected int, received int) internal.InboundOrderLine {
+	return internal.InboundOrderLine{
+		ExpectedQuantity: expected,
+		ReceivedQuantity: received,
+		ProductBatch: internal.ProductBatch{
+			BatchNumber:       batchNumber,
+			DueDate:           "2025-06-01",
+			ManufacturingDate: "2025-01-01",
+			ProductID:         1,
+			SectionID:         1,
+		},
+	}
+}
+
+func TestInboundOrders_ValidateFieldsOk(t *testing.T) {
+	header := internal.InboundOrders{OrderDate: "2025-01-01", OrderNumber: "ORD1", EmployeeID: 1, WarehouseID: 1}
+
+	t.Run("single batch order", func(t *testing.T) {
+		io := header
+		io.ProductBatchID = 1
+		io.Quantity = 10
+
+		assert.True(t, io.ValidateFieldsOk())
+	})
+
+	t.Run("single batch order without batch", func(t *testing.T) {
+		io := header
+		io.Quantity = 10
+
+		assert.False(t, io.ValidateFieldsOk())
+	})
+
+	t.Run("order with lines", func(t *testing.T) {
+		io := header
+		io.Lines = []internal.InboundOrderLine{newInboundOrderLine(10, 5, 5)}
+
+		assert.True(t, io.ValidateFieldsOk())
+	})
+
+	t.Run("line without section", func(t *testing.T) {
+		line := newInboundOrderLine(10, 5, 5)
+		line.ProductBatch.SectionID = 0
+
+		io := header
+		io.Lines = []internal.InboundOrderLine{line}
+
+		assert.False(t, io.ValidateFieldsOk())
+	})
+}
+
+func TestInboundOrders_Validate(t *testing.T) {
+	t.Run("valid lines", func(t *testing.T) {
+		io := internal.InboundOrders{Lines: []internal.InboundOrderLine{
+			newInboundOrderLine(10, 5, 5),
+			newInboundOrderLine(11, 5, 0),
+		}}
+
+		assert.Empty(t, io.Validate())
+	})
+
+	t.Run("invalid lines", func(t *testing.T) {
+		io := internal.InboundOrders{ProductBatchID: 3, Lines: []internal.InboundOrderLine{
+			newInboundOrderLine(10, 5, 5),
+			newInboundOrderLine(10, -1, -2),
+		}}
+
+		assert.Equal(t, []internal.Causes{
+			{Field: "product_batch_id", Message: "product batch ID must be empty when the order has lines"},
+			{Field: "lines[1].expected_quantity", Message: "expected quantity must be greater than zero"},
+			{Field: "lines[1].received_quantity", Message: "received quantity must not be negative"},
+			{Field: "lines[1].product_batch.batch_number", Message: "batch number 10 is repeated in the order"},
+		}, io.Validate())
+	})
+}
+
+func TestInboundOrders_Discrepancies(t *testing.T) {
+	io := internal.InboundOrders{Lines: []internal.InboundOrderLine{
+		{ID: 1, ExpectedQuantity: 10, ReceivedQuantity: 10, ProductBatch: internal.ProductBatch{ID: 5, BatchNumber: 50}},
+		{ID: 2, ExpectedQuantity: 10, ReceivedQuantity: 7, ProductBatch: internal.ProductBatch{ID: 6, BatchNumber: 60}},
+		{ID: 3, ExpectedQuantity: 10, ReceivedQuantity: 12, ProductBatch: internal.ProductBatch{ID: 7, BatchNumber: 70}},
+	}}
+
+	assert.Equal(t, []internal.InboundOrderDiscrepancy{
+		{InboundOrderLineID: 2, ProductBatchID: 6, BatchNumber: 60, ExpectedQuantity: 10, ReceivedQuantity: 7, Difference: -3},
+		{InboundOrderLineID: 3, ProductBatchID: 7, BatchNumber: 70, ExpectedQuantity: 10, ReceivedQuantity: 12, Difference: 2},
+	}, io.Discrepancies())
+}
@@ -6,12 +6,17 @@ import (
 	"github.com/meli-fresh-products-api-backend-t1/utils/validator"
 )
 
+// Locality is a city of a province. The province and country names are read from their tables.
 type Locality struct {
 	ID           int
 	LocalityName string
+	ProvinceID   int
 	ProvinceName string
+	CountryID    int
 	CountryName  string
-	Sellers      int
+	// Coordinates locate the locality, they are optional
+	Coordinates *Coordinates
+	Sellers     int
 }
 
 type CarriesCountPerLocality struct {
@@ -20,6 +25,17 @@ type CarriesCountPerLocality struct {
 	LocalityName string `json:"locality_name"`
 }
 
+// LocalityStockSummary is the stock of a product type held by the warehouses of a locality
+type LocalityStockSummary struct {
+	ProductTypeID   int
+	ProductTypeName string
+	// WarehousesCount is the number of warehouses of the locality holding batches of the product type
+	WarehousesCount int
+	BatchesCount    int
+	// CurrentQuantity is the sum of the current quantity of the batches
+	CurrentQuantity int
+}
+
 var (
 	// ErrLocalityNotFound is returned when the seller is not found
 	ErrLocalityNotFound = errors.New("locality not found")
@@ -48,18 +64,15 @@ func (l *Locality) Validate() (causes []Causes) {
 		})
 	}
 
-	if !validator.String(l.CountryName, 1, 255) {
+	if !validator.IntIsPositive(l.ProvinceID) {
 		causes = append(causes, Causes{
-			Field:   "country_name",
-			Message: "Country name cannot be empty",
+			Field:   "province_id",
+			Message: "Province ID must be positive",
 		})
 	}
 
-	if !validator.String(l.ProvinceName, 1, 255) {
-		causes = append(causes, Causes{
-			Field:   "province_name",
-			Message: "Province name cannot be empty",
-		})
+	if l.Coordinates != nil {
+		causes = append(causes, l.Coordinates.Validate()...)
 	}
 
 	return causes
@@ -72,6 +85,7 @@ type LocalityRepository interface {
 	FindByID(id int) (locality Locality, err error)
 	ReportCarries(localityID int) (amountOfCarries int, e error)
 	GetAmountOfCarriesForEveryLocality() (c []CarriesCountPerLocality, e error)
+	StockSummary(localityID int) (summary []LocalityStockSummary, err error)
 }
 
 type LocalityService interface {
@@ -81,4 +95,5 @@ type LocalityService interface {
 	FindByID(id int) (locality Locality, err error)
 	ReportCarries(localityID int) (int, error)
 	GetAmountOfCarriesForEveryLocality() ([]CarriesCountPerLocality, error)
+	StockSummary(localityID int) ([]LocalityStockSummary, error)
 }
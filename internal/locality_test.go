@@ -19,8 +19,7 @@ func TestLocality_Validate(t *testing.T) {
 			locality: internal.Locality{
 				ID:           1,
 				LocalityName: "Test Locality",
-				ProvinceName: "Test Province",
-				CountryName:  "Test Country",
+				ProvinceID:   1,
 			},
 			wantErr: false,
 			causes:  nil,
@@ -30,8 +29,7 @@ func TestLocality_Validate(t *testing.T) {
 			locality: internal.Locality{
 				ID:           -1,
 				LocalityName: "Test Locality",
-				ProvinceName: "Test Province",
-				CountryName:  "Test Country",
+				ProvinceID:   1,
 			},
 			wantErr: true,
 			causes: []internal.Causes{
@@ -45,8 +43,7 @@ func TestLocality_Validate(t *testing.T) {
 			name: "invalid locality - missing ID",
 			locality: internal.Locality{
 				LocalityName: "Test Locality",
-				ProvinceName: "Test Province",
-				CountryName:  "Test Country",
+				ProvinceID:   1,
 			},
 			wantErr: true,
 			causes: []internal.Causes{
@@ -59,9 +56,8 @@ func TestLocality_Validate(t *testing.T) {
 		{
 			name: "invalid locality - missing LocalityName",
 			locality: internal.Locality{
-				ID:           1,
-				ProvinceName: "Test Province",
-				CountryName:  "Test Country",
+				ID:         1,
+				ProvinceID: 1,
 			},
 			wantErr: true,
 			causes: []internal.Causes{
@@ -72,32 +68,31 @@ func TestLocality_Validate(t *testing.T) {
 			},
 		},
 		{
-			name: "invalid locality - missing ProvinceName",
+			name: "invalid locality - missing ProvinceID",
 			locality: internal.Locality{
 				ID:           1,
 				LocalityName: "Test Locality",
-				CountryName:  "Test Country",
 			},
 			wantErr: true,
 			causes: []internal.Causes{
 				{
-					Field:   "province_name",
-					Message: "Province name cannot be empty",
+					Field:   "province_id",
+					Message: "Province ID must be positive",
 				},
 			},
 		},
 		{
-			name: "invalid locality - missing CountryName",
+			name: "invalid locality - negative ProvinceID",
 			locality: internal.Locality{
 				ID:           1,
 				LocalityName: "Test Locality",
-				ProvinceName: "Test Province",
+				ProvinceID:   -1,
 			},
 			wantErr: true,
 			causes: []internal.Causes{
 				{
-					Field:   "country_name",
-					Message: "Country name cannot be empty",
+					Field:   "province_id",
+					Message: "Province ID must be positive",
 				},
 			},
 		},
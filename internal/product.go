@@ -2,6 +2,7 @@ package internal
 
 import (
 	"errors"
+	"slices"
 )
 
 var (
@@ -14,8 +15,22 @@ var (
 	ErrProductCodeAlreadyExists   = errors.New("product-type already exists")
 	ErrProductUnprocessableEntity = errors.New("all fields must be valid and filled")
 	ErrProductBadRequest          = errors.New("invalid syntax")
+	// ErrProductNotActive is returned when a suspended or offboarded product gets new batches or orders
+	ErrProductNotActive = errors.New("product is not active")
 )
 
+const (
+	// ProductStatusActive is the status of the products that can get new batches and orders
+	ProductStatusActive = "active"
+	// ProductStatusSuspended is the status of the products that temporarily cannot get new batches nor orders
+	ProductStatusSuspended = "suspended"
+	// ProductStatusOffboarded is the status of the products that left the catalogue
+	ProductStatusOffboarded = "offboarded"
+)
+
+// ProductStatuses are the statuses a product can be in
+var ProductStatuses = []string{ProductStatusActive, ProductStatusSuspended, ProductStatusOffboarded}
+
 type Product struct {
 	ID                             int     `json:"id"`
 	ProductCode                    string  `json:"product_code"`
@@ -29,6 +44,27 @@ type Product struct {
 	FreezingRate                   float64 `json:"freezing_rate"`
 	ProductTypeID                  int     `json:"product_type_id"`
 	SellerID                       int     `json:"seller_id"`
+	Status                         string  `json:"status"`
+	// SellerStatus is the status of the seller, it is read along with the product and never written
+	SellerStatus string `json:"-"`
+}
+
+// IsValidStatus tells whether the status of the product is one of ProductStatuses
+func (p Product) IsValidStatus() bool {
+	return slices.Contains(ProductStatuses, p.Status)
+}
+
+// Sellable returns an error when the product or its seller is not active, their products cannot get new batches nor orders
+func (p Product) Sellable() error {
+	if p.Status != ProductStatusActive {
+		return ErrProductNotActive
+	}
+
+	if p.SellerStatus != SellerStatusActive {
+		return ErrSellerNotActive
+	}
+
+	return nil
 }
 
 type ProductJSONPost struct {
@@ -43,6 +79,7 @@ type ProductJSONPost struct {
 	FreezingRate                   float64 `json:"freezing_rate"`
 	ProductTypeID                  int     `json:"product_type_id"`
 	SellerID                       int     `json:"seller_id"`
+	Status                         string  `json:"status"`
 }
 
 type ProductService interface {
@@ -1,17 +1,30 @@
 package internal
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
+
+const (
+	ProductBatchExpiryOK         = "ok"
+	ProductBatchExpiryNearExpiry = "near-expiry"
+	ProductBatchExpiryExpired    = "expired"
+
+	// ProductBatchNearExpiryWindow is how close to its due date a batch is flagged as near-expiry
+	ProductBatchNearExpiryWindow = 7 * 24 * time.Hour
+)
 
 var (
 	ErrProductBatchNotFound            = errors.New("product-batch not found")
 	ErrProductBatchAlreadyExists       = errors.New("product-batch already exists")
 	ErrProductBatchNumberAlreadyInUse  = errors.New("product-batch with given product-batch number already registered")
 	ErrProductBatchUnprocessableEntity = errors.New("couldn't parse product-batch")
+	ErrProductBatchHasHistory          = errors.New("product-batch has stock movements, holds, reservations, transfers or recalls")
 )
 
 type ProductBatch struct {
 	ID                 int     `json:"id"`
-	BatchNumber        int     `json:"batch_number"`
+	BatchNumber        int     `json:"batch_number"` // the production lot, batches split from it by transfers share it
 	CurrentQuantity    int     `json:"current_quantity"`
 	CurrentTemperature float64 `json:"current_temperature"`
 	DueDate            string  `json:"due_date"`
@@ -21,6 +34,26 @@ type ProductBatch struct {
 	MinumumTemperature float64 `json:"minumum_temperature"`
 	ProductID          int     `json:"product_id"`
 	SectionID          int     `json:"section_id"`
+	// Status tells whether the batch can be picked, see ProductBatchStatusReleased
+	Status       string `json:"status"`
+	ExpiryStatus string `json:"expiry_status"`
+}
+
+// ProductBatchDueDateFilter narrows the batches by due date and warehouse. Zero values are ignored.
+type ProductBatchDueDateFilter struct {
+	From        time.Time
+	To          time.Time
+	WarehouseID int
+}
+
+// ProductBatchExpiryGroup gathers the batches of a section for the expiry reports
+type ProductBatchExpiryGroup struct {
+	WarehouseID       int            `json:"warehouse_id"`
+	WarehouseCode     string         `json:"warehouse_code"`
+	SectionID         int            `json:"section_id"`
+	SectionNumber     int            `json:"section_number"`
+	RemainingQuantity int            `json:"remaining_quantity"`
+	Batches           []ProductBatch `json:"batches"`
 }
 
 type ProductBatchRepository interface {
@@ -29,11 +62,29 @@ type ProductBatchRepository interface {
 	ProductBatchNumberExists(batchNumber int) (bool, error)
 	ReportProducts() (prodBatches []ProductBatch, err error)
 	ReportProductsByID(id int) (prodBatches []ProductBatch, err error)
+	// FindByDueDate returns the batches with stock left that match the filter, grouped by warehouse and section
+	FindByDueDate(filter ProductBatchDueDateFilter) ([]ProductBatchExpiryGroup, error)
+	// FindBySectionID returns the batches with stock left stored in the section
+	FindBySectionID(sectionID int) ([]ProductBatch, error)
+	// FindByProductID returns the batches with stock left of the product
+	FindByProductID(productID int) ([]ProductBatch, error)
+	// Move stores the batch in another section and moves its quantity between the section capacities
+	Move(id int, sectionID int) error
+	// Delete removes the batch and frees its quantity from the section capacity
+	Delete(id int) error
 }
 
 type ProductBatchService interface {
 	FindByID(id int) (ProductBatch, error)
 	Save(prodBatch *ProductBatch) error
+	// FindExpiring returns the batches that expire within the given window, optionally in a single warehouse
+	FindExpiring(within time.Duration, warehouseID int) ([]ProductBatchExpiryGroup, error)
+	// FindExpired returns the batches past their due date that still have stock, optionally in a single warehouse
+	FindExpired(warehouseID int) ([]ProductBatchExpiryGroup, error)
+	// Move stores the batch in another section as long as the section has room for it
+	Move(id int, sectionID int) (ProductBatch, error)
+	// Delete removes the batch and frees its section capacity
+	Delete(id int) error
 }
 
 func (pb *ProductBatch) Ok() bool {
@@ -52,3 +103,39 @@ func (pb *ProductBatch) Ok() bool {
 
 	return true
 }
+
+// SetExpiryStatus flags the batch as ok, near-expiry or expired relative to today.
+// A batch is still sellable on its due date. Batches with an unreadable due date are left unflagged.
+func (pb *ProductBatch) SetExpiryStatus(today time.Time) {
+	dueDate, err := ParseProductBatchDate(pb.DueDate)
+	if err != nil {
+		pb.ExpiryStatus = ""
+		return
+	}
+
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch {
+	case dueDate.Before(today):
+		pb.ExpiryStatus = ProductBatchExpiryExpired
+	case !dueDate.After(today.Add(ProductBatchNearExpiryWindow)):
+		pb.ExpiryStatus = ProductBatchExpiryNearExpiry
+	default:
+		pb.ExpiryStatus = ProductBatchExpiryOK
+	}
+}
+
+// ParseProductBatchDate reads a batch date either as sent by clients (YYYY-MM-DD) or as returned by the database
+func ParseProductBatchDate(date string) (time.Time, error) {
+	parsed, err := time.Parse(time.DateOnly, date)
+	if err == nil {
+		return parsed, nil
+	}
+
+	parsed, err = time.Parse(time.RFC3339, date)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, time.UTC), nil
+}
@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"errors"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/utils/validator"
+)
+
+const (
+	// ProductBatchStatusReleased is the status of a batch that can be picked
+	ProductBatchStatusReleased = "released"
+	// ProductBatchStatusOnHold is the status of a batch kept aside until it is reviewed
+	ProductBatchStatusOnHold = "on_hold"
+	// ProductBatchStatusQuarantined is the status of a batch isolated because it may be unsafe
+	ProductBatchStatusQuarantined = "quarantined"
+)
+
+// productBatchTransitions maps every batch status to the statuses it can move to.
+// A quarantined batch can only be released, it is never downgraded to a hold.
+var productBatchTransitions = map[string][]string{
+	ProductBatchStatusReleased:    {ProductBatchStatusOnHold, ProductBatchStatusQuarantined},
+	ProductBatchStatusOnHold:      {ProductBatchStatusQuarantined, ProductBatchStatusReleased},
+	ProductBatchStatusQuarantined: {ProductBatchStatusReleased},
+}
+
+// ProductBatchHold is a struct that represents a status change of a product batch
+type ProductBatchHold struct {
+	ID             int
+	ProductBatchID int
+	FromStatus     string
+	ToStatus       string
+	Reason         string
+	// EmployeeID is the employee who requested the change
+	EmployeeID int
+	CreatedAt  time.Time
+}
+
+var (
+	// ErrProductBatchOnHold is returned when stock is picked from a batch that is not released
+	ErrProductBatchOnHold = errors.New("product-batch is on hold or quarantined")
+	// ErrProductBatchHoldInvalidTransition is returned when the batch cannot move to the requested status
+	ErrProductBatchHoldInvalidTransition = errors.New("product-batch status transition is not allowed")
+	// ErrProductBatchHoldBadRequest is returned when the status change breaks a business rule
+	ErrProductBatchHoldBadRequest = errors.New("product-batch hold inputs are invalid")
+	// ErrProductBatchHoldUnprocessableEntity is returned when the status change inputs are missing
+	ErrProductBatchHoldUnprocessableEntity = errors.New("product-batch hold inputs are missing")
+)
+
+// CanProductBatchTransition reports whether a batch can move between the given statuses
+func CanProductBatchTransition(from string, to string) bool {
+	for _, next := range productBatchTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate validates the business rules of the status change
+func (h *ProductBatchHold) Validate() (causes []Causes) {
+	if _, ok := productBatchTransitions[h.ToStatus]; !ok {
+		causes = append(causes, Causes{
+			Field:   "status",
+			Message: "status must be one of released, on_hold or quarantined",
+		})
+	}
+
+	if len(h.Reason) == 0 || len(h.Reason) > 255 {
+		causes = append(causes, Causes{
+			Field:   "reason",
+			Message: "reason is required and must have at most 255 characters",
+		})
+	}
+
+	if !validator.IntIsPositive(h.EmployeeID) {
+		causes = append(causes, Causes{
+			Field:   "employee_id",
+			Message: "employee ID must be greater than zero",
+		})
+	}
+
+	return causes
+}
+
+// ProductBatchHoldRepository is an interface that contains the methods that the product batch hold repository should support
+type ProductBatchHoldRepository interface {
+	// FindByProductBatchID returns the status changes of the given product batch
+	FindByProductBatchID(productBatchID int) ([]ProductBatchHold, error)
+	// Save moves the batch to the new status and records the change
+	Save(h *ProductBatchHold) error
+}
+
+// ProductBatchHoldService is an interface that contains the methods that the product batch hold service should support
+type ProductBatchHoldService interface {
+	// FindByProductBatchID returns the status changes of the given product batch
+	FindByProductBatchID(productBatchID int) ([]ProductBatchHold, error)
+	// Hold puts the batch on hold or in quarantine
+	Hold(h *ProductBatchHold) error
+	// Release makes the batch available for picking again
+	Release(h *ProductBatchHold) error
+}
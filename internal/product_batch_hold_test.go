@@ -0,0 +1,29 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanProductBatchTransition(t *testing.T) {
+	tests := []struct {
+		from     string
+		to       string
+		expected bool
+	}{
+		{from: internal.ProductBatchStatusReleased, to: internal.ProductBatchStatusOnHold, expected: true},
+		{from: internal.ProductBatchStatusReleased, to: internal.ProductBatchStatusQuarantined, expected: true},
+		{from: internal.ProductBatchStatusOnHold, to: internal.ProductBatchStatusQuarantined, expected: true},
+		{from: internal.ProductBatchStatusQuarantined, to: internal.ProductBatchStatusReleased, expected: true},
+		{from: internal.ProductBatchStatusQuarantined, to: internal.ProductBatchStatusOnHold, expected: false},
+		{from: internal.ProductBatchStatusReleased, to: internal.ProductBatchStatusReleased, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			assert.Equal(t, tt.expected, internal.CanProductBatchTransition(tt.from, tt.to))
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package internal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductBatch_SetExpiryStatus(t *testing.T) {
+	today := time.Date(2025, 1, 10, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		dueDate  string
+		expected string
+	}{
+		{name: "due yesterday is expired", dueDate: "2025-01-09", expected: internal.ProductBatchExpiryExpired},
+		{name: "due today is near expiry", dueDate: "2025-01-10", expected: internal.ProductBatchExpiryNearExpiry},
+		{name: "due at the end of the window is near expiry", dueDate: "2025-01-17", expected: internal.ProductBatchExpiryNearExpiry},
+		{name: "due after the window is ok", dueDate: "2025-01-18", expected: internal.ProductBatchExpiryOK},
+		{name: "date as returned by the database", dueDate: "2025-01-09T00:00:00Z", expected: internal.ProductBatchExpiryExpired},
+		{name: "unreadable date is left unflagged", dueDate: "soon", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pb := internal.ProductBatch{DueDate: tt.dueDate}
+			pb.SetExpiryStatus(today)
+			assert.Equal(t, tt.expected, pb.ExpiryStatus)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"errors"
+	"math"
 	"time"
 )
 
@@ -10,6 +11,8 @@ var (
 	ErrProductRecordsNotFound = errors.New("product-records not found")
 	ErrProductRecordsConflict = errors.New("product-records conflict")
 	ErrDateInvalid           = errors.New("invalid date type")
+	// ErrProductPriceNotFound is returned when the product has no record effective at the requested time
+	ErrProductPriceNotFound = errors.New("product has no price effective at the requested time")
 )
 
 type ProductRecords struct {
@@ -32,14 +35,59 @@ type ProductRecordsJSONCount struct {
 	RecordsCount int    `json:"records_count"`
 }
 
+// ProductPrice is a product record with the margin it leaves
+type ProductPrice struct {
+	RecordID      int       `json:"record_id"`
+	ProductID     int       `json:"product_id"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	PurchasePrice float64   `json:"purchase_price"`
+	SalePrice     float64   `json:"sale_price"`
+	Margin        float64   `json:"margin"`
+	MarginPercent float64   `json:"margin_percent"`
+}
+
+// NewProductPrice builds the price of a record. The margin is the sale price minus the purchase price
+// and its percentage is taken over the sale price, both rounded to two decimals.
+func NewProductPrice(record ProductRecords) ProductPrice {
+	salePrice := roundPrice(float64(record.SalePrice))
+	margin := roundPrice(salePrice - record.PurchasePrice)
+
+	var marginPercent float64
+	if salePrice != 0 {
+		marginPercent = roundPrice(margin / salePrice * 100)
+	}
+
+	return ProductPrice{
+		RecordID:      record.ID,
+		ProductID:     record.ProductID,
+		EffectiveFrom: record.LastUpdateDate,
+		PurchasePrice: record.PurchasePrice,
+		SalePrice:     salePrice,
+		Margin:        margin,
+		MarginPercent: marginPercent,
+	}
+}
+
+func roundPrice(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
 type ProductRecordsService interface {
 	GetAll() ([]ProductRecords, error)
 	GetByID(int) (ProductRecords, error)
 	Create(ProductRecords) (ProductRecords, error)
+	// GetPrices returns the price timeline of a product, zero times leave the range open
+	GetPrices(productID int, from time.Time, to time.Time) ([]ProductPrice, error)
+	// GetEffectivePrice returns the price of the product effective at the given time
+	GetEffectivePrice(productID int, at time.Time) (ProductPrice, error)
 }
 
 type ProductRecordsRepository interface {
 	FindAll() ([]ProductRecords, error)
 	FindByID(int) (ProductRecords, error)
+	// FindByProductID returns the records of the product updated in the range, oldest first
+	FindByProductID(productID int, from time.Time, to time.Time) ([]ProductRecords, error)
+	// FindEffectiveByProductID returns the latest record of the product updated at or before the given time
+	FindEffectiveByProductID(productID int, at time.Time) (ProductRecords, error)
 	Save(ProductRecords) (ProductRecords, error)
 }
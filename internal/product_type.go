@@ -1,17 +1,66 @@
 package internal
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/meli-fresh-products-api-backend-t1/utils/validator"
+)
 
 var (
 	ErrProductTypeAlreadyExists = errors.New("product-type already exists")
-	ErrProductTypeNotFound         = errors.New("product-type not found")
+	ErrProductTypeNotFound      = errors.New("product-type not found")
+	// ErrProductTypeInUse is returned when a product type still referenced by sections or products is deleted
+	ErrProductTypeInUse = errors.New("product-type is still referenced by sections or products")
+	// ErrProductTypeBadRequest is returned when the product type breaks a business rule
+	ErrProductTypeBadRequest = errors.New("product-type inputs are invalid")
+	// ErrProductTypeUnprocessableEntity is returned when the product type inputs are missing
+	ErrProductTypeUnprocessableEntity = errors.New("product-type inputs are missing")
 )
 
 type ProductType struct {
 	ID          int    `json:"id"`
+	Name        string `json:"name"`
 	Description string `json:"description"`
 }
 
+// ProductTypePatch holds the product type fields to update. Nil fields are left untouched.
+type ProductTypePatch struct {
+	Name        *string
+	Description *string
+}
+
+// Validate validates the business rules of the product type
+func (pt *ProductType) Validate() (causes []Causes) {
+	if !validator.String(pt.Name, 1, 255) {
+		causes = append(causes, Causes{
+			Field:   "name",
+			Message: "name must have between 1 and 255 characters",
+		})
+	}
+
+	if !validator.String(pt.Description, 1, 255) {
+		causes = append(causes, Causes{
+			Field:   "description",
+			Message: "description must have between 1 and 255 characters",
+		})
+	}
+
+	return causes
+}
+
 type ProductTypeRepository interface {
+	FindAll() ([]ProductType, error)
+	FindByID(id int) (ProductType, error)
+	Save(pt *ProductType) error
+	Update(pt *ProductType) error
+	// Delete removes the product type as long as no section or product references it
+	Delete(id int) error
+}
+
+type ProductTypeService interface {
+	FindAll() ([]ProductType, error)
 	FindByID(id int) (ProductType, error)
+	Save(pt *ProductType) error
+	Update(id int, patch ProductTypePatch) (ProductType, error)
+	Delete(id int) error
 }
@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/meli-fresh-products-api-backend-t1/utils/validator"
+)
+
+// ProductTypeCompatibility is a struct that represents a rule allowing products of a type
+// to be stored in sections declared for another type, e.g. Seafood in Frozen Foods sections
+type ProductTypeCompatibility struct {
+	// ProductTypeID is the type of the products
+	ProductTypeID int
+	// SectionProductTypeID is the type declared by the sections that may also hold the products
+	SectionProductTypeID int
+}
+
+// ProductTypeCompatibleSections gathers the sections that can store products of a type
+type ProductTypeCompatibleSections struct {
+	ProductTypeID int
+	// SectionProductTypeIDs are the section types that can hold the products, starting with the product type itself
+	SectionProductTypeIDs []int
+	Sections              []Section
+}
+
+var (
+	// ErrProductTypeCompatibilityNotFound is returned when the compatibility rule does not exist
+	ErrProductTypeCompatibilityNotFound = errors.New("product-type compatibility not found")
+	// ErrProductTypeCompatibilityAlreadyExists is returned when the compatibility rule is already registered
+	ErrProductTypeCompatibilityAlreadyExists = errors.New("product-type compatibility already exists")
+	// ErrProductTypeCompatibilitySectionTypeNotFound is returned when the section product type of the rule does not exist
+	ErrProductTypeCompatibilitySectionTypeNotFound = errors.New("section product-type not found")
+	// ErrProductTypeCompatibilityBadRequest is returned when the compatibility rule breaks a business rule
+	ErrProductTypeCompatibilityBadRequest = errors.New("product-type compatibility inputs are invalid")
+	// ErrProductTypeCompatibilityUnprocessableEntity is returned when the compatibility rule inputs are missing
+	ErrProductTypeCompatibilityUnprocessableEntity = errors.New("product-type compatibility inputs are missing")
+	// ErrProductTypeIncompatible is returned when a batch is stored in a section that cannot hold its product type
+	ErrProductTypeIncompatible = errors.New("product-type is not compatible with the section")
+	// ErrProductTypeCompatibilityInUse is returned when removing the rule would leave stored batches in sections that cannot hold them
+	ErrProductTypeCompatibilityInUse = errors.New("product-type compatibility is in use by stored batches")
+)
+
+// Validate validates the business rules of the compatibility rule
+func (c *ProductTypeCompatibility) Validate() (causes []Causes) {
+	if !validator.IntIsPositive(c.SectionProductTypeID) {
+		causes = append(causes, Causes{
+			Field:   "section_product_type_id",
+			Message: "section product type ID must be greater than zero",
+		})
+	} else if c.SectionProductTypeID == c.ProductTypeID {
+		causes = append(causes, Causes{
+			Field:   "section_product_type_id",
+			Message: "a product type is always compatible with its own sections",
+		})
+	}
+
+	return causes
+}
+
+// NewProductTypeIncompatibleError describes a product that cannot be stored in the section
+func NewProductTypeIncompatibleError(section Section, productTypeID int) DomainError {
+	return DomainError{
+		Message: ErrProductTypeIncompatible.Error(),
+		Causes: []Causes{{
+			Field:   "section_id",
+			Message: fmt.Sprintf("section %d stores product type %d and cannot hold product type %d", section.ID, section.ProductTypeID, productTypeID),
+		}},
+	}
+}
+
+// ProductTypeCompatibilityRepository is an interface that contains the methods that the product type compatibility repository should support
+type ProductTypeCompatibilityRepository interface {
+	// FindSectionProductTypeIDs returns the section types that may also hold products of the type
+	FindSectionProductTypeIDs(productTypeID int) ([]int, error)
+	// FindSections returns the sections of the product type and of its compatible section types
+	FindSections(productTypeID int) ([]Section, error)
+	// IsCompatible reports whether a rule lets sections of a type hold products of another type
+	IsCompatible(productTypeID int, sectionProductTypeID int) (bool, error)
+	// CountStoredBatches counts the batches with stock left that the rule lets sit in sections of another type
+	CountStoredBatches(c ProductTypeCompatibility) (int, error)
+	Save(c ProductTypeCompatibility) error
+	Delete(c ProductTypeCompatibility) error
+}
+
+// ProductTypeCompatibilityService is an interface that contains the methods that the product type compatibility service should support
+type ProductTypeCompatibilityService interface {
+	// FindCompatibleSections returns the sections that can store products of the type
+	FindCompatibleSections(productTypeID int) (ProductTypeCompatibleSections, error)
+	Save(c ProductTypeCompatibility) error
+	Delete(c ProductTypeCompatibility) error
+}
@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"errors"
+
+	"github.com/meli-fresh-products-api-backend-t1/utils/validator"
+)
+
+var (
+	// ErrProvinceNotFound is returned when the province is not found
+	ErrProvinceNotFound = errors.New("province not found")
+	// ErrProvinceConflict is returned when the country already has a province with the same name
+	ErrProvinceConflict = errors.New("province already exists in the country")
+	// ErrProvinceBadRequest is returned when the province breaks a business rule
+	ErrProvinceBadRequest = errors.New("province inputs are invalid")
+	// ErrProvinceUnprocessableEntity is returned when the province inputs are missing
+	ErrProvinceUnprocessableEntity = errors.New("province inputs are missing")
+)
+
+// Province is a province of a country localities belong to
+type Province struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	CountryID int    `json:"country_id"`
+}
+
+// ProvinceReport holds the localities, sellers and carries registered in a province
+type ProvinceReport struct {
+	ProvinceID      int    `json:"province_id"`
+	ProvinceName    string `json:"province_name"`
+	CountryID       int    `json:"country_id"`
+	CountryName     string `json:"country_name"`
+	LocalitiesCount int    `json:"localities_count"`
+	SellersCount    int    `json:"sellers_count"`
+	CarriesCount    int    `json:"carries_count"`
+}
+
+// Validate validates the business rules of the province
+func (p *Province) Validate() (causes []Causes) {
+	if !validator.String(p.Name, 1, 255) {
+		causes = append(causes, Causes{
+			Field:   "name",
+			Message: "name must have between 1 and 255 characters",
+		})
+	}
+
+	if !validator.IntIsPositive(p.CountryID) {
+		causes = append(causes, Causes{
+			Field:   "country_id",
+			Message: "country id must be positive",
+		})
+	}
+
+	return causes
+}
+
+type ProvinceRepository interface {
+	// FindAll returns the provinces, only the ones of the given country when it is not zero
+	FindAll(countryID int) ([]Province, error)
+	FindByID(id int) (Province, error)
+	// Save records the province, names are unique in a country regardless of their case
+	Save(p *Province) error
+	Report() ([]ProvinceReport, error)
+	ReportByID(id int) (ProvinceReport, error)
+}
+
+type ProvinceService interface {
+	FindAll(countryID int) ([]Province, error)
+	FindByID(id int) (Province, error)
+	Save(p *Province) error
+	Report() ([]ProvinceReport, error)
+	ReportByID(id int) (ProvinceReport, error)
+}
@@ -9,12 +9,27 @@ import (
 
 // PurchaseOrder is a struct that represents a purchase order
 type PurchaseOrder struct {
-	ID              int
-	OrderNumber     string
-	OrderDate       time.Time
+	ID          int
+	OrderNumber string
+	OrderDate   time.Time
+	// TrackingCode is empty until a shipment assigns one
 	TrackingCode    string
 	BuyerID         int
 	ProductRecordID int
+	// Status is the lifecycle status of the order, see PurchaseOrderStatusCreated
+	Status string
+	Lines  []PurchaseOrderLine
+}
+
+// PurchaseOrderFilter is a struct that narrows the purchase orders returned by a search.
+// Zero values are ignored.
+type PurchaseOrderFilter struct {
+	BuyerID      int
+	TrackingCode string
+	Status       string
+	// From and To bound the order date, both inclusive
+	From time.Time
+	To   time.Time
 }
 
 var (
@@ -26,6 +41,10 @@ var (
 	ErrPurchaseOrderUnprocessableEntity = errors.New("purchase order inputs are missing")
 	// ErrPurchaseOrderBadRequest is returned when the purchase order request is bad
 	ErrPurchaseOrderBadRequest = errors.New("purchase order inputs are invalid")
+	// ErrPurchaseOrderCancelled is returned when stock is requested for a cancelled purchase order
+	ErrPurchaseOrderCancelled = errors.New("purchase order is cancelled")
+	// ErrPurchaseOrderProductRecordNotEffective is returned when the product record of the order is not the price effective on the order date
+	ErrPurchaseOrderProductRecordNotEffective = errors.New("product record is not the price effective on the order date")
 )
 
 // Validate validates the business rules of the purchase order
@@ -44,13 +63,6 @@ func (p *PurchaseOrder) Validate() (causes []Causes) {
 		})
 	}
 
-	if validator.BlankString(p.TrackingCode) {
-		causes = append(causes, Causes{
-			Field:   "tracking_code",
-			Message: "tracking code is required",
-		})
-	}
-
 	if !validator.String(p.TrackingCode, 1, 50) && !validator.BlankString(p.TrackingCode) {
 		causes = append(causes, Causes{
 			Field:   "tracking_code",
@@ -72,7 +84,7 @@ func (p *PurchaseOrder) Validate() (causes []Causes) {
 		})
 	}
 
-	if validator.IntIsZero(p.ProductRecordID) {
+	if validator.IntIsZero(p.ProductRecordID) && len(p.Lines) == 0 {
 		causes = append(causes, Causes{
 			Field:   "product_record_id",
 			Message: "product record ID is required",
@@ -86,21 +98,46 @@ func (p *PurchaseOrder) Validate() (causes []Causes) {
 		})
 	}
 
+	for i := range p.Lines {
+		causes = append(causes, p.Lines[i].Validate(i)...)
+	}
+
 	return causes
 }
 
 // PurchaseOrderRepository is an interface that contains the methods that the purchase order repository should support
 type PurchaseOrderRepository interface {
+	// FindAll returns the purchase orders that match the given filter
+	FindAll(filter PurchaseOrderFilter) ([]PurchaseOrder, error)
 	// FindByID returns the purchase order with the given ID
 	FindByID(id int) (PurchaseOrder, error)
-	// Save saves the given purchase order
+	// Save saves the given purchase order and its lines
 	Save(p *PurchaseOrder) error
+	// UpdateStatus moves the purchase order from h.FromStatus to h.ToStatus and records the change.
+	// Moving to cancelled releases the stock still reserved for the order.
+	UpdateStatus(h *PurchaseOrderStatusHistory) error
+	// FindHistory returns the status changes of the given purchase order
+	FindHistory(id int) ([]PurchaseOrderStatusHistory, error)
 }
 
 // PurchaseOrderService is an interface that contains the methods that the purchase order service should support
 type PurchaseOrderService interface {
-	// FindByID returns the purchase order with the given ID
+	// FindAll returns the purchase orders that match the given filter
+	FindAll(filter PurchaseOrderFilter) ([]PurchaseOrder, error)
+	// FindByBuyerID returns the purchase orders of the given buyer that match the filter
+	FindByBuyerID(buyerID int, filter PurchaseOrderFilter) ([]PurchaseOrder, error)
+	// FindByID returns the purchase order with the given ID and its lines
 	FindByID(id int) (PurchaseOrder, error)
 	// Save saves the given purchase order
 	Save(p *PurchaseOrder) error
+	// Reserve holds stock for the lines of the given purchase order
+	Reserve(id int) ([]PurchaseOrderLine, error)
+	// Fulfil reserves any missing stock and picks it from the product batches
+	Fulfil(id int) ([]PurchaseOrderLine, error)
+	// UpdateStatus moves the given purchase order to a new status
+	UpdateStatus(id int, status string, changedBy string) (PurchaseOrder, error)
+	// FindHistory returns the status changes of the given purchase order
+	FindHistory(id int) ([]PurchaseOrderStatusHistory, error)
+	// Cancel moves the given purchase order to cancelled, the order is kept for its history
+	Cancel(id int, changedBy string) (PurchaseOrder, error)
 }
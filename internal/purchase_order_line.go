@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/meli-fresh-products-api-backend-t1/utils/validator"
+)
+
+// PurchaseOrderLine is a struct that represents a product ordered in a purchase order
+type PurchaseOrderLine struct {
+	ID              int
+	PurchaseOrderID int
+	ProductID       int
+	Quantity        int
+	// UnitPrice is the sale price of the product when the order was placed
+	UnitPrice float64
+	// ReservedQuantity is the quantity held on product batches for the line
+	ReservedQuantity int
+	// FulfilledQuantity is the reserved quantity already picked from the product batches
+	FulfilledQuantity int
+}
+
+// PurchaseOrderReservation is a struct that represents stock of a product batch held for a purchase order line
+type PurchaseOrderReservation struct {
+	ID                  int
+	PurchaseOrderLineID int
+	ProductBatchID      int
+	Quantity            int
+	Consumed            bool
+}
+
+var (
+	// ErrPurchaseOrderInsufficientStock is returned when there is not enough stock to reserve a purchase order
+	ErrPurchaseOrderInsufficientStock = errors.New("not enough stock to reserve the purchase order")
+	// ErrPurchaseOrderWithoutLines is returned when a purchase order has no lines to fulfil
+	ErrPurchaseOrderWithoutLines = errors.New("purchase order has no lines")
+)
+
+// Validate validates the business rules of the purchase order line
+func (l *PurchaseOrderLine) Validate(index int) (causes []Causes) {
+	if validator.IntIsZero(l.ProductID) || validator.IntIsNegative(l.ProductID) {
+		causes = append(causes, Causes{
+			Field:   fmt.Sprintf("lines[%d].product_id", index),
+			Message: "product ID is required",
+		})
+	}
+
+	if !validator.IntIsPositive(l.Quantity) {
+		causes = append(causes, Causes{
+			Field:   fmt.Sprintf("lines[%d].quantity", index),
+			Message: "quantity must be greater than zero",
+		})
+	}
+
+	return causes
+}
+
+// PurchaseOrderLineRepository is an interface that contains the methods that the purchase order line repository should support
+type PurchaseOrderLineRepository interface {
+	// FindByPurchaseOrderID returns the lines of the given purchase order
+	FindByPurchaseOrderID(purchaseOrderID int) ([]PurchaseOrderLine, error)
+	// Reserve holds stock from the product batches for every line of the purchase order, first expiring first
+	Reserve(purchaseOrderID int) ([]PurchaseOrderReservation, error)
+	// Consume picks the reserved stock of the purchase order from the product batches
+	Consume(purchaseOrderID int) error
+}
@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	PurchaseOrderStatusCreated   = "created"
+	PurchaseOrderStatusConfirmed = "confirmed"
+	PurchaseOrderStatusPicking   = "picking"
+	PurchaseOrderStatusShipped   = "shipped"
+	PurchaseOrderStatusDelivered = "delivered"
+	PurchaseOrderStatusCancelled = "cancelled"
+)
+
+// purchaseOrderTransitions maps every status to the statuses it can move to.
+// Delivered and cancelled orders are final.
+var purchaseOrderTransitions = map[string][]string{
+	PurchaseOrderStatusCreated:   {PurchaseOrderStatusConfirmed, PurchaseOrderStatusCancelled},
+	PurchaseOrderStatusConfirmed: {PurchaseOrderStatusPicking, PurchaseOrderStatusCancelled},
+	PurchaseOrderStatusPicking:   {PurchaseOrderStatusShipped, PurchaseOrderStatusCancelled},
+	PurchaseOrderStatusShipped:   {PurchaseOrderStatusDelivered},
+	PurchaseOrderStatusDelivered: {},
+	PurchaseOrderStatusCancelled: {},
+}
+
+// purchaseOrderStockStatuses are the statuses in which a purchase order can still reserve and pick stock.
+// Once shipped the stock has left the warehouse.
+var purchaseOrderStockStatuses = map[string]bool{
+	PurchaseOrderStatusCreated:   true,
+	PurchaseOrderStatusConfirmed: true,
+	PurchaseOrderStatusPicking:   true,
+}
+
+// PurchaseOrderStatusHistory is a struct that represents a status change of a purchase order
+type PurchaseOrderStatusHistory struct {
+	ID              int
+	PurchaseOrderID int
+	FromStatus      string
+	ToStatus        string
+	// ChangedBy identifies who requested the change
+	ChangedBy string
+	ChangedAt time.Time
+}
+
+var (
+	// ErrPurchaseOrderInvalidTransition is returned when the purchase order cannot move to the requested status
+	ErrPurchaseOrderInvalidTransition = errors.New("purchase order status transition is not allowed")
+	// ErrPurchaseOrderStatusSetByShipment is returned when shipped or delivered is requested outside the shipment flow
+	ErrPurchaseOrderStatusSetByShipment = errors.New("purchase order is shipped and delivered through its shipment")
+	// ErrPurchaseOrderStatusUnprocessableEntity is returned when the status change inputs are missing
+	ErrPurchaseOrderStatusUnprocessableEntity = errors.New("purchase order status inputs are missing")
+)
+
+// IsPurchaseOrderStatus reports whether the given status is a known purchase order status
+func IsPurchaseOrderStatus(status string) bool {
+	_, ok := purchaseOrderTransitions[status]
+	return ok
+}
+
+// IsShipmentPurchaseOrderStatus reports whether the status is only reached through the shipment of the purchase order,
+// which checks the stock was picked and records the tracking code and dates
+func IsShipmentPurchaseOrderStatus(status string) bool {
+	return status == PurchaseOrderStatusShipped || status == PurchaseOrderStatusDelivered
+}
+
+// CanTransitionTo reports whether the purchase order can move from its current status to the given one
+func (p *PurchaseOrder) CanTransitionTo(status string) bool {
+	for _, next := range purchaseOrderTransitions[p.Status] {
+		if next == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanTakeStock reports whether stock can still be reserved or picked for the purchase order
+func (p *PurchaseOrder) CanTakeStock() bool {
+	return purchaseOrderStockStatuses[p.Status]
+}
+
+// Validate validates the business rules of the status change
+func (h *PurchaseOrderStatusHistory) Validate() (causes []Causes) {
+	if !IsPurchaseOrderStatus(h.ToStatus) {
+		causes = append(causes, Causes{
+			Field:   "status",
+			Message: "status must be one of created, confirmed, picking, shipped, delivered or cancelled",
+		})
+	}
+
+	if len(h.ChangedBy) == 0 || len(h.ChangedBy) > 255 {
+		causes = append(causes, Causes{
+			Field:   "changed_by",
+			Message: "changed by is required and must have at most 255 characters",
+		})
+	}
+
+	return causes
+}
@@ -60,20 +60,14 @@ func TestPurchaseOrder_Validate(t *testing.T) {
 			},
 		},
 		{
-			name: "invalid purchase order - missing tracking code",
+			name: "valid purchase order - tracking code is assigned by the shipment",
 			purchaseOrder: &internal.PurchaseOrder{
 				OrderNumber:     "ORDER-123",
 				OrderDate:       time.Now(),
 				BuyerID:         1,
 				ProductRecordID: 1,
 			},
-			wantErr: true,
-			causes: []internal.Causes{
-				{
-					Field:   "tracking_code",
-					Message: "tracking code is required",
-				},
-			},
+			wantErr: false,
 		},
 		{
 			name: "invalid purchase order - tracking code out of range",
@@ -173,3 +167,30 @@ func TestPurchaseOrder_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestPurchaseOrder_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want bool
+	}{
+		{name: "created to confirmed", from: internal.PurchaseOrderStatusCreated, to: internal.PurchaseOrderStatusConfirmed, want: true},
+		{name: "created to cancelled", from: internal.PurchaseOrderStatusCreated, to: internal.PurchaseOrderStatusCancelled, want: true},
+		{name: "confirmed to picking", from: internal.PurchaseOrderStatusConfirmed, to: internal.PurchaseOrderStatusPicking, want: true},
+		{name: "picking to shipped", from: internal.PurchaseOrderStatusPicking, to: internal.PurchaseOrderStatusShipped, want: true},
+		{name: "shipped to delivered", from: internal.PurchaseOrderStatusShipped, to: internal.PurchaseOrderStatusDelivered, want: true},
+		{name: "created to shipped skips steps", from: internal.PurchaseOrderStatusCreated, to: internal.PurchaseOrderStatusShipped, want: false},
+		{name: "shipped cannot be cancelled", from: internal.PurchaseOrderStatusShipped, to: internal.PurchaseOrderStatusCancelled, want: false},
+		{name: "delivered is final", from: internal.PurchaseOrderStatusDelivered, to: internal.PurchaseOrderStatusCreated, want: false},
+		{name: "cancelled is final", from: internal.PurchaseOrderStatusCancelled, to: internal.PurchaseOrderStatusConfirmed, want: false},
+		{name: "unknown status", from: internal.PurchaseOrderStatusCreated, to: "lost", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := internal.PurchaseOrder{Status: tt.from}
+			assert.Equal(t, tt.want, p.CanTransitionTo(tt.to))
+		})
+	}
+}
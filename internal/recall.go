@@ -0,0 +1,125 @@
+package internal
+
+import (
+	"errors"
+	"time"
+)
+
+// Recall is a struct that represents the withdrawal of a product or of some of its batches
+type Recall struct {
+	ID int
+	// ProductID recalls every batch of the product when set
+	ProductID int
+	// BatchNumbers recalls only the given batches when set
+	BatchNumbers []int
+	Reason       string
+	CreatedAt    time.Time
+	// Batches are the product batches affected by the recall
+	Batches []RecallBatch
+}
+
+// RecallBatch is a struct that represents a batch affected by a recall and where it is stored
+type RecallBatch struct {
+	ProductBatchID  int
+	BatchNumber     int
+	ProductID       int
+	CurrentQuantity int
+	DueDate         string
+	Status          string
+	SectionID       int
+	SectionNumber   int
+	WarehouseID     int
+	WarehouseCode   string
+}
+
+// RecallBuyer is a struct that represents a purchase order of a buyer who may have received a recalled batch
+type RecallBuyer struct {
+	BuyerID         int
+	CardNumberID    string
+	FirstName       string
+	LastName        string
+	PurchaseOrderID int
+	OrderNumber     string
+	OrderDate       time.Time
+}
+
+// RecallReport gathers a recall with the batches and buyers it affects
+type RecallReport struct {
+	Recall  Recall
+	Batches []RecallBatch
+	Buyers  []RecallBuyer
+}
+
+var (
+	// ErrRecallNotFound is returned when the recall does not exist
+	ErrRecallNotFound = errors.New("recall not found")
+	// ErrRecallBadRequest is returned when the recall breaks a business rule
+	ErrRecallBadRequest = errors.New("recall inputs are invalid")
+	// ErrRecallUnprocessableEntity is returned when the recall inputs are missing
+	ErrRecallUnprocessableEntity = errors.New("recall inputs are missing")
+	// ErrRecallWithoutBatches is returned when no batch matches the recall
+	ErrRecallWithoutBatches = errors.New("recall does not match any product batch")
+)
+
+// Validate validates the business rules of the recall
+func (r *Recall) Validate() (causes []Causes) {
+	switch {
+	case r.ProductID == 0 && len(r.BatchNumbers) == 0:
+		causes = append(causes, Causes{
+			Field:   "product_id",
+			Message: "either product_id or batch_numbers is required",
+		})
+	case r.ProductID != 0 && len(r.BatchNumbers) != 0:
+		causes = append(causes, Causes{
+			Field:   "product_id",
+			Message: "product_id and batch_numbers cannot be sent together",
+		})
+	case r.ProductID < 0:
+		causes = append(causes, Causes{
+			Field:   "product_id",
+			Message: "product ID must be greater than zero",
+		})
+	}
+
+	for _, batchNumber := range r.BatchNumbers {
+		if batchNumber <= 0 {
+			causes = append(causes, Causes{
+				Field:   "batch_numbers",
+				Message: "batch numbers must be greater than zero",
+			})
+			break
+		}
+	}
+
+	if len(r.Reason) == 0 || len(r.Reason) > 255 {
+		causes = append(causes, Causes{
+			Field:   "reason",
+			Message: "reason is required and must have at most 255 characters",
+		})
+	}
+
+	return causes
+}
+
+// RecallRepository is an interface that contains the methods that the recall repository should support
+type RecallRepository interface {
+	FindAll() ([]Recall, error)
+	FindByID(id int) (Recall, error)
+	// FindMatchingBatches returns the batches of the product or with the batch numbers of the recall
+	FindMatchingBatches(recall Recall) ([]RecallBatch, error)
+	// FindBatches returns the batches affected by the recall with their current stock and location
+	FindBatches(recallID int) ([]RecallBatch, error)
+	// FindBuyers returns the purchase orders that may have delivered a recalled batch
+	FindBuyers(recallID int) ([]RecallBuyer, error)
+	// Save stores the recall and the batches it affects
+	Save(recall *Recall) error
+}
+
+// RecallService is an interface that contains the methods that the recall service should support
+type RecallService interface {
+	FindAll() ([]Recall, error)
+	// FindReport returns the recall with the batches and buyers it affects
+	FindReport(id int) (RecallReport, error)
+	// Save resolves the affected batches and stores the recall
+	Save(recall *Recall) error
+}
@@ -0,0 +1,51 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecall_Validate(t *testing.T) {
+	tests := []struct {
+		description    string
+		recall         internal.Recall
+		expectedFields []string
+	}{
+		{
+			description: "case 1: valid product recall",
+			recall:      internal.Recall{ProductID: 1, Reason: "listeria"},
+		},
+		{
+			description: "case 2: valid batch recall",
+			recall:      internal.Recall{BatchNumbers: []int{10, 11}, Reason: "listeria"},
+		},
+		{
+			description:    "case 3: neither product nor batches",
+			recall:         internal.Recall{Reason: "listeria"},
+			expectedFields: []string{"product_id"},
+		},
+		{
+			description:    "case 4: product and batches together",
+			recall:         internal.Recall{ProductID: 1, BatchNumbers: []int{10}, Reason: "listeria"},
+			expectedFields: []string{"product_id"},
+		},
+		{
+			description:    "case 5: invalid batch number and missing reason",
+			recall:         internal.Recall{BatchNumbers: []int{10, 0}},
+			expectedFields: []string{"batch_numbers", "reason"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			var fields []string
+			for _, cause := range tt.recall.Validate() {
+				fields = append(fields, cause.Field)
+			}
+
+			require.Equal(t, tt.expectedFields, fields)
+		})
+	}
+}
@@ -9,11 +9,33 @@ import (
 )
 
 const (
-	GetAllCarriesQuery = "SELECT * FROM carries"
+	GetAllCarriesQuery = "SELECT `id`, `cid`, `company_name`, `address`, `phone_number`, `locality_id` FROM `carries`"
+	GetCarryByIDQuery  = GetAllCarriesQuery + " WHERE `id` = ?"
+	InsertCarryQuery   = "INSERT INTO `carries` (`cid`, `company_name`, `address`, `phone_number`, `locality_id`) VALUES (?, ?, ?, ?, ?)"
+	UpdateCarryQuery   = "UPDATE `carries` SET `cid` = ?, `company_name` = ?, `address` = ?, `phone_number` = ?, `locality_id` = ? WHERE `id` = ?"
+	DeleteCarryQuery   = "DELETE FROM `carries` WHERE `id` = ?"
+
+	GetCarryCoveragesQuery    = "SELECT `id`, `carry_id`, `locality_id`, `lead_time_days`, `cost_per_kg` FROM `carry_coverages` WHERE `carry_id` = ? ORDER BY `locality_id`"
+	InsertCarryCoverageQuery  = "INSERT INTO `carry_coverages` (`carry_id`, `locality_id`, `lead_time_days`, `cost_per_kg`) VALUES (?, ?, ?, ?)"
+	GetCarriesByLocalityQuery = `
+		SELECT c.id, c.cid, c.company_name, cc.lead_time_days, cc.cost_per_kg
+		FROM carry_coverages AS cc
+		INNER JOIN carries AS c ON c.id = cc.carry_id
+		WHERE cc.locality_id = ?
+		ORDER BY c.id`
+	// GetCarriesByRouteQuery keeps the carries based in the origin locality or serving it
+	GetCarriesByRouteQuery = `
+		SELECT c.id, c.cid, c.company_name, cc.lead_time_days, cc.cost_per_kg
+		FROM carry_coverages AS cc
+		INNER JOIN carries AS c ON c.id = cc.carry_id
+		WHERE cc.locality_id = ? AND (
+			c.locality_id = ?
+			OR EXISTS (SELECT 1 FROM carry_coverages AS oc WHERE oc.carry_id = c.id AND oc.locality_id = ?))
+		ORDER BY c.id`
 )
 
 var (
-	ErrCidAlreadyExists = errors.New("carry with this cid already exists")
+	ErrCidAlreadyExists = internal.ErrCarryCidConflict
 	ErrNoSuchLocalityID = errors.New("there's no such locality id")
 )
 
@@ -30,11 +52,12 @@ func (r *CarriesMysql) FindAll() (carries []internal.Carries, e error) {
 	if e != nil {
 		return
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var carry internal.Carries
 
-		rows.Scan(
+		e = rows.Scan(
 			&carry.ID,
 			&carry.Cid,
 			&carry.CompanyName,
@@ -42,6 +65,9 @@ func (r *CarriesMysql) FindAll() (carries []internal.Carries, e error) {
 			&carry.PhoneNumber,
 			&carry.LocalityID,
 		)
+		if e != nil {
+			return
+		}
 
 		carries = append(carries, carry)
 	}
@@ -50,17 +76,111 @@ func (r *CarriesMysql) FindAll() (carries []internal.Carries, e error) {
 	return
 }
 
-func (r *CarriesMysql) Create(carry internal.Carries) (lastID int64, e error) {
-	res, e := r.db.Exec(
-		"INSERT INTO carries (`cid`, `company_name`, `address`, `phone_number`, `locality_id`) VALUES (?, ?, ?, ?, ?)",
-		carry.Cid, carry.CompanyName, carry.Address, carry.PhoneNumber, carry.LocalityID,
+func (r *CarriesMysql) FindByID(id int) (carry internal.Carries, e error) {
+	e = r.db.QueryRow(GetCarryByIDQuery, id).Scan(
+		&carry.ID,
+		&carry.Cid,
+		&carry.CompanyName,
+		&carry.Address,
+		&carry.PhoneNumber,
+		&carry.LocalityID,
 	)
+	if errors.Is(e, sql.ErrNoRows) {
+		e = internal.ErrCarryNotFound
+	}
+
+	return
+}
+
+func (r *CarriesMysql) Create(carry internal.Carries) (lastID int64, e error) {
+	res, e := r.db.Exec(InsertCarryQuery, carry.Cid, carry.CompanyName, carry.Address, carry.PhoneNumber, carry.LocalityID)
+	if e != nil {
+		e = carryMysqlError(e)
+		return
+	}
+
+	lastID, e = res.LastInsertId()
+
+	return
+}
+
+func (r *CarriesMysql) Update(carry internal.Carries) (e error) {
+	_, e = r.db.Exec(UpdateCarryQuery, carry.Cid, carry.CompanyName, carry.Address, carry.PhoneNumber, carry.LocalityID, carry.ID)
+	if e != nil {
+		e = carryMysqlError(e)
+	}
+
+	return
+}
+
+func (r *CarriesMysql) Delete(id int) (e error) {
+	res, e := r.db.Exec(DeleteCarryQuery, id)
+	if e != nil {
+		mysqlErr, ok := e.(*mysql.MySQLError)
+		if ok && mysqlErr.Number == 1451 {
+			e = internal.ErrCarryInUse
+		}
+
+		return
+	}
+
+	affected, e := res.RowsAffected()
+	if e != nil {
+		return
+	}
+
+	if affected == 0 {
+		e = internal.ErrCarryNotFound
+	}
+
+	return
+}
+
+// carryMysqlError maps the mysql errors of a carry write to the carry errors
+func carryMysqlError(e error) error {
+	mysqlErr, ok := e.(*mysql.MySQLError)
+	if ok {
+		switch mysqlErr.Number {
+		case 1062:
+			return ErrCidAlreadyExists
+		case 1452:
+			return ErrNoSuchLocalityID
+		}
+	}
+
+	return e
+}
+
+func (r *CarriesMysql) FindCoverages(carryID int) (coverages []internal.CarryCoverage, e error) {
+	rows, e := r.db.Query(GetCarryCoveragesQuery, carryID)
+	if e != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var coverage internal.CarryCoverage
+
+		e = rows.Scan(&coverage.ID, &coverage.CarryID, &coverage.LocalityID, &coverage.LeadTimeDays, &coverage.CostPerKg)
+		if e != nil {
+			return
+		}
+
+		coverages = append(coverages, coverage)
+	}
+
+	e = rows.Err()
+	return
+}
+
+func (r *CarriesMysql) CreateCoverage(coverage *internal.CarryCoverage) (e error) {
+	res, e := r.db.Exec(InsertCarryCoverageQuery, coverage.CarryID, coverage.LocalityID, coverage.LeadTimeDays, coverage.CostPerKg)
 	if e != nil {
 		mysqlErr, ok := e.(*mysql.MySQLError)
 		if ok {
 			switch mysqlErr.Number {
 			case 1062:
-				e = ErrCidAlreadyExists
+				e = internal.ErrCarryCoverageConflict
 			case 1452:
 				e = ErrNoSuchLocalityID
 			}
@@ -69,7 +189,43 @@ func (r *CarriesMysql) Create(carry internal.Carries) (lastID int64, e error) {
 		return
 	}
 
-	lastID, e = res.LastInsertId()
+	id, e := res.LastInsertId()
+	if e != nil {
+		return
+	}
+
+	coverage.ID = int(id)
+
+	return
+}
+
+func (r *CarriesMysql) FindByLocality(localityID int) ([]internal.CarryQuote, error) {
+	return r.findQuotes(GetCarriesByLocalityQuery, localityID)
+}
+
+func (r *CarriesMysql) FindByRoute(originLocalityID int, destinationLocalityID int) ([]internal.CarryQuote, error) {
+	return r.findQuotes(GetCarriesByRouteQuery, destinationLocalityID, originLocalityID, originLocalityID)
+}
+
+// findQuotes runs a query returning carries with the lead time and cost per kg of a coverage
+func (r *CarriesMysql) findQuotes(query string, args ...any) (quotes []internal.CarryQuote, e error) {
+	rows, e := r.db.Query(query, args...)
+	if e != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var quote internal.CarryQuote
 
+		e = rows.Scan(&quote.CarryID, &quote.Cid, &quote.CompanyName, &quote.LeadTimeDays, &quote.CostPerKg)
+		if e != nil {
+			return
+		}
+
+		quotes = append(quotes, quote)
+	}
+
+	e = rows.Err()
 	return
 }
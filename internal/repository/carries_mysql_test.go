@@ -151,6 +151,138 @@ func (s *MysqlCarriesTestSuite) TestCreate() {
 	})
 }
 
+func (s *MysqlCarriesTestSuite) TestFindByID() {
+	s.T().Run("success", func(t *testing.T) {
+		s.Setup()
+		rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "phone_number", "locality_id"}).
+			AddRow(1, "CID001", "Go Meli Go", "FourFiveSix", "11977021447", 1)
+		s.mock.ExpectQuery("SELECT").WithArgs(1).WillReturnRows(rows)
+
+		actualCarry, e := s.rp.FindByID(1)
+
+		require.NoError(t, e)
+		require.Equal(t, internal.Carries{
+			ID:          1,
+			Cid:         "CID001",
+			CompanyName: "Go Meli Go",
+			Address:     "FourFiveSix",
+			PhoneNumber: "11977021447",
+			LocalityID:  1,
+		}, actualCarry)
+	})
+	s.T().Run("not found", func(t *testing.T) {
+		s.Setup()
+		s.mock.ExpectQuery("SELECT").WithArgs(99).WillReturnError(sql.ErrNoRows)
+
+		_, e := s.rp.FindByID(99)
+
+		require.ErrorIs(t, e, internal.ErrCarryNotFound)
+	})
+}
+
+func (s *MysqlCarriesTestSuite) TestUpdate() {
+	carry := internal.Carries{ID: 1, Cid: "CID001", CompanyName: "Go Meli Go", Address: "FourFiveSix", PhoneNumber: "11 97702-1447", LocalityID: 1}
+
+	s.T().Run("success", func(t *testing.T) {
+		s.Setup()
+		s.mock.ExpectExec("UPDATE `carries`").WithArgs("CID001", "Go Meli Go", "FourFiveSix", "11 97702-1447", 1, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		e := s.rp.Update(carry)
+
+		require.NoError(t, e)
+	})
+	s.T().Run("duplicated cid", func(t *testing.T) {
+		s.Setup()
+		s.mock.ExpectExec("UPDATE `carries`").WillReturnError(&mysql.MySQLError{Number: 1062})
+
+		e := s.rp.Update(carry)
+
+		require.ErrorIs(t, e, internal.ErrCarryCidConflict)
+	})
+}
+
+func (s *MysqlCarriesTestSuite) TestDelete() {
+	s.T().Run("success", func(t *testing.T) {
+		s.Setup()
+		s.mock.ExpectExec("DELETE FROM `carries`").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		e := s.rp.Delete(1)
+
+		require.NoError(t, e)
+	})
+	s.T().Run("not found", func(t *testing.T) {
+		s.Setup()
+		s.mock.ExpectExec("DELETE FROM `carries`").WithArgs(99).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		e := s.rp.Delete(99)
+
+		require.ErrorIs(t, e, internal.ErrCarryNotFound)
+	})
+	s.T().Run("carry with shipments", func(t *testing.T) {
+		s.Setup()
+		s.mock.ExpectExec("DELETE FROM `carries`").WithArgs(1).WillReturnError(&mysql.MySQLError{Number: 1451})
+
+		e := s.rp.Delete(1)
+
+		require.ErrorIs(t, e, internal.ErrCarryInUse)
+	})
+}
+
+func (s *MysqlCarriesTestSuite) TestCreateCoverage() {
+	s.T().Run("success", func(t *testing.T) {
+		s.Setup()
+		coverage := internal.CarryCoverage{CarryID: 1, LocalityID: 2, LeadTimeDays: 3, CostPerKg: 1.5}
+		s.mock.ExpectExec("INSERT INTO `carry_coverages`").WithArgs(1, 2, 3, 1.5).WillReturnResult(sqlmock.NewResult(4, 1))
+
+		e := s.rp.CreateCoverage(&coverage)
+
+		require.NoError(t, e)
+		require.Equal(t, 4, coverage.ID)
+	})
+	s.T().Run("locality already served", func(t *testing.T) {
+		s.Setup()
+		coverage := internal.CarryCoverage{CarryID: 1, LocalityID: 2, LeadTimeDays: 3, CostPerKg: 1.5}
+		s.mock.ExpectExec("INSERT INTO `carry_coverages`").WillReturnError(&mysql.MySQLError{Number: 1062})
+
+		e := s.rp.CreateCoverage(&coverage)
+
+		require.ErrorIs(t, e, internal.ErrCarryCoverageConflict)
+	})
+}
+
+func (s *MysqlCarriesTestSuite) TestFindByLocality() {
+	s.T().Run("success", func(t *testing.T) {
+		s.Setup()
+		rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "lead_time_days", "cost_per_kg"}).
+			AddRow(1, "CID001", "Go Meli Go", 2, 1.5)
+		s.mock.ExpectQuery("SELECT").WithArgs(2).WillReturnRows(rows)
+
+		quotes, e := s.rp.FindByLocality(2)
+
+		require.NoError(t, e)
+		require.Equal(t, []internal.CarryQuote{
+			{CarryID: 1, Cid: "CID001", CompanyName: "Go Meli Go", LeadTimeDays: 2, CostPerKg: 1.5},
+		}, quotes)
+	})
+}
+
+func (s *MysqlCarriesTestSuite) TestFindByRoute() {
+	s.T().Run("success", func(t *testing.T) {
+		s.Setup()
+		rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "lead_time_days", "cost_per_kg"}).
+			AddRow(2, "CID002", "Log Express", 1, 1.75)
+		s.mock.ExpectQuery("SELECT").WithArgs(2, 3, 3).WillReturnRows(rows)
+
+		quotes, e := s.rp.FindByRoute(3, 2)
+
+		require.NoError(t, e)
+		require.Equal(t, []internal.CarryQuote{
+			{CarryID: 2, Cid: "CID002", CompanyName: "Log Express", LeadTimeDays: 1, CostPerKg: 1.75},
+		}, quotes)
+	})
+}
+
 func TestRepositoryMysqlCarriesUnit(t *testing.T) {
 	suite.Run(t, new(MysqlCarriesTestSuite))
 }
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+const (
+	FindAllCountriesQuery = "SELECT `id`, `name` FROM `countries` ORDER BY `id`"
+	FindCountryByIDQuery  = "SELECT `id`, `name` FROM `countries` WHERE `id` = ?"
+	InsertCountryQuery    = "INSERT INTO `countries` (`name`) VALUES (?)"
+	countryReportQuery    = `
+		SELECT c.id, c.name, COUNT(DISTINCT p.id), COUNT(DISTINCT l.id), COUNT(DISTINCT s.id), COUNT(DISTINCT cr.id)
+		FROM countries AS c
+		LEFT JOIN provinces AS p ON p.country_id = c.id
+		LEFT JOIN localities AS l ON l.province_id = p.id
+		LEFT JOIN sellers AS s ON s.locality_id = l.id
+		LEFT JOIN carries AS cr ON cr.locality_id = l.id`
+	CountryReportQuery     = countryReportQuery + " GROUP BY c.id, c.name ORDER BY c.id"
+	CountryReportByIDQuery = countryReportQuery + " WHERE c.id = ? GROUP BY c.id, c.name"
+)
+
+// NewCountryMysql creates a new instance of the country repository
+func NewCountryMysql(db *sql.DB) *CountryMysql {
+	return &CountryMysql{db}
+}
+
+// CountryMysql is the mysql implementation of the country repository
+type CountryMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// FindAll returns the countries ordered by ID
+func (r *CountryMysql) FindAll() (countries []internal.Country, err error) {
+	rows, err := r.db.Query(FindAllCountriesQuery)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c internal.Country
+
+		err = rows.Scan(&c.ID, &c.Name)
+		if err != nil {
+			return
+		}
+
+		countries = append(countries, c)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// FindByID returns the country with the given ID
+func (r *CountryMysql) FindByID(id int) (c internal.Country, err error) {
+	err = r.db.QueryRow(FindCountryByIDQuery, id).Scan(&c.ID, &c.Name)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = internal.ErrCountryNotFound
+	}
+
+	return
+}
+
+// Save records the country. The name column is unique with a case insensitive collation,
+// so "Argentina" and "argentina" are the same country.
+func (r *CountryMysql) Save(c *internal.Country) error {
+	result, err := r.db.Exec(InsertCountryQuery, c.Name)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return internal.ErrCountryConflict
+		}
+
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	c.ID = int(id)
+
+	return nil
+}
+
+// Report returns the provinces, localities, sellers and carries of every country
+func (r *CountryMysql) Report() (reports []internal.CountryReport, err error) {
+	rows, err := r.db.Query(CountryReportQuery)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var report internal.CountryReport
+
+		report, err = scanCountryReport(rows)
+		if err != nil {
+			return
+		}
+
+		reports = append(reports, report)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// ReportByID returns the provinces, localities, sellers and carries of the country with the given ID
+func (r *CountryMysql) ReportByID(id int) (internal.CountryReport, error) {
+	report, err := scanCountryReport(r.db.QueryRow(CountryReportByIDQuery, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return internal.CountryReport{}, internal.ErrCountryNotFound
+	}
+
+	return report, err
+}
+
+// scanCountryReport reads a country report from a row of countryReportQuery
+func scanCountryReport(row interface{ Scan(dest ...any) error }) (report internal.CountryReport, err error) {
+	err = row.Scan(
+		&report.CountryID,
+		&report.CountryName,
+		&report.ProvincesCount,
+		&report.LocalitiesCount,
+		&report.SellersCount,
+		&report.CarriesCount,
+	)
+
+	return
+}
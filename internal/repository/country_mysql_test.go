@@ -0,0 +1,138 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountryMysql_FindAll(t *testing.T) {
+	t.Run("case 1: success - Should return the countries", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(repository.FindAllCountriesQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "United States").AddRow(2, "Argentina"))
+
+		countries, err := repository.NewCountryMysql(db).FindAll()
+
+		require.NoError(t, err)
+		require.Equal(t, []internal.Country{{ID: 1, Name: "United States"}, {ID: 2, Name: "Argentina"}}, countries)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: error - Should return the error of the query", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(repository.FindAllCountriesQuery).WillReturnError(errors.New("database error"))
+
+		_, err = repository.NewCountryMysql(db).FindAll()
+
+		require.Error(t, err)
+	})
+}
+
+func TestCountryMysql_FindByID(t *testing.T) {
+	t.Run("case 1: success - Should return the country", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(repository.FindCountryByIDQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "United States"))
+
+		c, err := repository.NewCountryMysql(db).FindByID(1)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.Country{ID: 1, Name: "United States"}, c)
+	})
+
+	t.Run("case 2: error - Should return not found for an unknown country", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(repository.FindCountryByIDQuery).WithArgs(99).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+		_, err = repository.NewCountryMysql(db).FindByID(99)
+
+		require.ErrorIs(t, err, internal.ErrCountryNotFound)
+	})
+}
+
+func TestCountryMysql_Save(t *testing.T) {
+	t.Run("case 1: success - Should save the country and set its ID", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(repository.InsertCountryQuery).WithArgs("Argentina").
+			WillReturnResult(sqlmock.NewResult(3, 1))
+
+		c := internal.Country{Name: "Argentina"}
+		err = repository.NewCountryMysql(db).Save(&c)
+
+		require.NoError(t, err)
+		require.Equal(t, 3, c.ID)
+	})
+
+	t.Run("case 2: error - Should return a conflict for a duplicated name", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(repository.InsertCountryQuery).WithArgs("argentina").
+			WillReturnError(&mysql.MySQLError{Number: 1062})
+
+		err = repository.NewCountryMysql(db).Save(&internal.Country{Name: "argentina"})
+
+		require.ErrorIs(t, err, internal.ErrCountryConflict)
+	})
+}
+
+func TestCountryMysql_Report(t *testing.T) {
+	columns := []string{"id", "name", "provinces_count", "localities_count", "sellers_count", "carries_count"}
+
+	t.Run("case 1: success - Should return the report of every country", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(repository.CountryReportQuery).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "United States", 5, 10, 7, 3))
+
+		reports, err := repository.NewCountryMysql(db).Report()
+
+		require.NoError(t, err)
+		require.Equal(t, []internal.CountryReport{{
+			CountryID:       1,
+			CountryName:     "United States",
+			ProvincesCount:  5,
+			LocalitiesCount: 10,
+			SellersCount:    7,
+			CarriesCount:    3,
+		}}, reports)
+	})
+
+	t.Run("case 2: error - Should return not found for an unknown country", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(repository.CountryReportByIDQuery).WithArgs(99).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		_, err = repository.NewCountryMysql(db).ReportByID(99)
+
+		require.ErrorIs(t, err, internal.ErrCountryNotFound)
+	})
+}
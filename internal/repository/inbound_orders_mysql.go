@@ -2,12 +2,36 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 )
 
 const (
-	AllInboundsQuery = "SELECT `id`, `order_date`, `order_number`, `employee_id`, `product_batch_id`, `warehouse_id` FROM `inbound_orders`;"
+	AllInboundsQuery   = "SELECT `id`, `order_date`, `order_number`, `employee_id`, `product_batch_id`, `warehouse_id`, `quantity` FROM `inbound_orders`"
+	AllInboundsOrderBy = " ORDER BY `order_date`, `id`"
+	InboundByIDQuery   = AllInboundsQuery + " WHERE `id` = ?"
+	// InboundSummaryQuery counts single batch orders through their batch and multi-line orders through the batches of their lines
+	InboundSummaryQuery = `
+		SELECT DATE_FORMAT(io.order_date, '%Y-%m-%d'), pt.id, pt.name, COUNT(DISTINCT io.id), SUM(COALESCE(l.received_quantity, io.quantity))
+		FROM inbound_orders AS io
+		LEFT JOIN inbound_order_lines AS l ON l.inbound_order_id = io.id
+		INNER JOIN product_batches AS pb ON pb.id = COALESCE(l.product_batch_id, io.product_batch_id)
+		INNER JOIN products AS p ON p.id = pb.product_id
+		INNER JOIN product_type AS pt ON pt.id = p.product_type_id`
+	InboundSummaryGroupBy       = " GROUP BY io.order_date, pt.id, pt.name ORDER BY io.order_date, pt.id"
+	InsertInboundOrderLineQuery = "INSERT INTO `inbound_order_lines` (`inbound_order_id`, `product_batch_id`, `expected_quantity`, `received_quantity`) VALUES (?, ?, ?, ?)"
+	InboundOrderLinesQuery      = `
+		SELECT l.id, l.inbound_order_id, l.expected_quantity, l.received_quantity,
+			pb.id, pb.batch_number, pb.current_quantity, pb.current_temperature, pb.due_date, pb.initial_quantity,
+			pb.manufacturing_date, pb.manufacturing_hour, pb.minumum_temperature, pb.product_id, pb.section_id, pb.status
+		FROM inbound_order_lines AS l
+		INNER JOIN product_batches AS pb ON pb.id = l.product_batch_id
+		WHERE l.inbound_order_id = ?
+		ORDER BY l.id`
 )
 
 // InboundOrdersMysql create a new instance of the inbound orders repository
@@ -19,10 +43,26 @@ func NewInboundOrderMysql(db *sql.DB) *InboundOrdersMysql {
 	return &InboundOrdersMysql{db}
 }
 
+// Create records the inbound order and adds the received quantity to its batch in the same transaction.
+// The lines of a multi-line order create their batches in that transaction too.
 func (rp *InboundOrdersMysql) Create(io internal.InboundOrders) (id int64, err error) {
+	tx, err := rp.db.Begin()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
 	var exists bool
 
-	rp.db.QueryRow("SELECT 1 FROM `inbound_orders` WHERE `order_number` = ?", io.OrderNumber).Scan(&exists) //check 1 line
+	tx.QueryRow("SELECT 1 FROM `inbound_orders` WHERE `order_number` = ?", io.OrderNumber).Scan(&exists) //check 1 line
 
 	if exists {
 		return 0, internal.ErrOrderNumberAlreadyExists
@@ -30,42 +70,234 @@ func (rp *InboundOrdersMysql) Create(io internal.InboundOrders) (id int64, err e
 
 	var empExists bool
 
-	rp.db.QueryRow("SELECT 1 FROM `employees` WHERE `id` = ?", io.EmployeeID).Scan(&empExists) //check 1 line
+	tx.QueryRow("SELECT 1 FROM `employees` WHERE `id` = ?", io.EmployeeID).Scan(&empExists) //check 1 line
 
 	if !empExists {
 		return 0, internal.ErrEmployeeNotFound
 	}
 
-	res, err := rp.db.Exec(
-		"INSERT INTO `inbound_orders` (`order_date`, `order_number`, `employee_id`, `product_batch_id`, `warehouse_id`) VALUES (?, ?, ?, ?, ?)",
-		io.OrderDate, io.OrderNumber, io.EmployeeID, io.ProductBatchID, io.WarehouseID,
+	res, err := tx.Exec(
+		"INSERT INTO `inbound_orders` (`order_date`, `order_number`, `employee_id`, `product_batch_id`, `warehouse_id`, `quantity`) VALUES (?, ?, ?, ?, ?, ?)",
+		io.OrderDate, io.OrderNumber, io.EmployeeID, nullableID(io.ProductBatchID), io.WarehouseID, io.Quantity,
 	)
 	if err != nil {
-		return id, err
+		return 0, err
 	}
 
 	id, err = res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(io.Lines) > 0 {
+		err = createInboundOrderLines(tx, id, io)
+		if err != nil {
+			return 0, err
+		}
+
+		return id, nil
+	}
+
+	err = applyStockMovement(tx, &internal.StockMovement{
+		ProductBatchID: io.ProductBatchID,
+		Type:           internal.StockMovementReceipt,
+		Quantity:       io.Quantity,
+		Reason:         fmt.Sprintf("inbound order %s", io.OrderNumber),
+		CreatedAt:      time.Now().UTC(),
+	})
+	if err != nil {
+		return 0, err
+	}
 
-	return id, err
+	return id, nil
 }
 
-func (rp *InboundOrdersMysql) FindAll() (inbounds []internal.InboundOrders, err error) {
-	row, err := rp.db.Query(AllInboundsQuery)
+// FindAll returns the inbound orders that match the filter ordered by date
+func (rp *InboundOrdersMysql) FindAll(filter internal.InboundOrderFilter) (inbounds []internal.InboundOrders, err error) {
+	conditions, args := inboundOrderConditions(filter, "")
+
+	query := AllInboundsQuery
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	row, err := rp.db.Query(query+AllInboundsOrderBy, args...)
 
 	if err != nil {
 		return
 	}
+	defer row.Close()
 
 	for row.Next() {
 		var inboundOrder internal.InboundOrders
-		err = row.Scan(&inboundOrder.ID, &inboundOrder.OrderDate, &inboundOrder.OrderNumber, &inboundOrder.EmployeeID, &inboundOrder.ProductBatchID, &inboundOrder.WarehouseID)
+		inboundOrder, err = scanInboundOrder(row)
 
 		if err != nil {
-			return
+			return nil, err
 		}
 
 		inbounds = append(inbounds, inboundOrder)
 	}
 
+	err = row.Err()
+
+	return
+}
+
+// FindByID returns the inbound order with the given ID and its lines
+func (rp *InboundOrdersMysql) FindByID(id int) (inboundOrder internal.InboundOrders, err error) {
+	inboundOrder, err = scanInboundOrder(rp.db.QueryRow(InboundByIDQuery, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = internal.ErrInboundOrderNotFound
+		}
+
+		return internal.InboundOrders{}, err
+	}
+
+	rows, err := rp.db.Query(InboundOrderLinesQuery, id)
+	if err != nil {
+		return internal.InboundOrders{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line internal.InboundOrderLine
+		pb := &line.ProductBatch
+
+		err = rows.Scan(&line.ID, &line.InboundOrderID, &line.ExpectedQuantity, &line.ReceivedQuantity,
+			&pb.ID, &pb.BatchNumber, &pb.CurrentQuantity, &pb.CurrentTemperature, &pb.DueDate, &pb.InitialQuantity,
+			&pb.ManufacturingDate, &pb.ManufacturingHour, &pb.MinumumTemperature, &pb.ProductID, &pb.SectionID, &pb.Status)
+		if err != nil {
+			return internal.InboundOrders{}, err
+		}
+
+		inboundOrder.Lines = append(inboundOrder.Lines, line)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return internal.InboundOrders{}, err
+	}
+
+	return
+}
+
+// FindSummary sums the quantities of the inbound orders that match the filter by day and product type
+func (rp *InboundOrdersMysql) FindSummary(filter internal.InboundOrderFilter) (summary []internal.InboundOrderSummary, err error) {
+	conditions, args := inboundOrderConditions(filter, "io.")
+
+	query := InboundSummaryQuery
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := rp.db.Query(query+InboundSummaryGroupBy, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var day internal.InboundOrderSummary
+
+		err = rows.Scan(&day.OrderDate, &day.ProductTypeID, &day.ProductTypeName, &day.OrdersCount, &day.Quantity)
+		if err != nil {
+			return nil, err
+		}
+
+		summary = append(summary, day)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// createInboundOrderLines creates the batch of every line with its received quantity and records the line
+func createInboundOrderLines(tx *sql.Tx, orderID int64, io internal.InboundOrders) error {
+	for _, line := range io.Lines {
+		pb := line.ProductBatch
+
+		// the batch starts empty, the receipt movement adds the quantity and checks the section capacity
+		result, err := tx.Exec(InsertProductBatchQuery,
+			pb.BatchNumber,
+			0,
+			pb.CurrentTemperature,
+			pb.DueDate,
+			line.ReceivedQuantity,
+			pb.ManufacturingDate,
+			pb.ManufacturingHour,
+			pb.MinumumTemperature,
+			pb.ProductID,
+			pb.SectionID,
+		)
+		if err != nil {
+			return err
+		}
+
+		batchID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		if line.ReceivedQuantity > 0 {
+			err = applyStockMovement(tx, &internal.StockMovement{
+				ProductBatchID: int(batchID),
+				Type:           internal.StockMovementReceipt,
+				Quantity:       line.ReceivedQuantity,
+				Reason:         fmt.Sprintf("inbound order %s", io.OrderNumber),
+				CreatedAt:      time.Now().UTC(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Exec(InsertInboundOrderLineQuery, orderID, batchID, line.ExpectedQuantity, line.ReceivedQuantity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scanInboundOrder reads an inbound order from a row of AllInboundsQuery
+func scanInboundOrder(row interface{ Scan(dest ...any) error }) (inboundOrder internal.InboundOrders, err error) {
+	var productBatchID sql.NullInt64
+
+	err = row.Scan(&inboundOrder.ID, &inboundOrder.OrderDate, &inboundOrder.OrderNumber, &inboundOrder.EmployeeID, &productBatchID, &inboundOrder.WarehouseID, &inboundOrder.Quantity)
+	if err != nil {
+		return
+	}
+
+	inboundOrder.ProductBatchID = int(productBatchID.Int64)
+
+	return
+}
+
+// inboundOrderConditions builds the where conditions of the filter, the columns are qualified with the given prefix
+func inboundOrderConditions(filter internal.InboundOrderFilter, prefix string) (conditions []string, args []any) {
+	if filter.WarehouseID != 0 {
+		conditions = append(conditions, prefix+"warehouse_id = ?")
+		args = append(args, filter.WarehouseID)
+	}
+
+	if filter.EmployeeID != 0 {
+		conditions = append(conditions, prefix+"employee_id = ?")
+		args = append(args, filter.EmployeeID)
+	}
+
+	if !filter.From.IsZero() {
+		conditions = append(conditions, prefix+"order_date >= ?")
+		args = append(args, filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		conditions = append(conditions, prefix+"order_date <= ?")
+		args = append(args, filter.To)
+	}
+
 	return
 }
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/meli-fresh-products-api-backend-t1/internal"
@@ -22,18 +23,33 @@ func TestInboundMysqlCreate_Success(t *testing.T) {
 		EmployeeID:     1,
 		ProductBatchID: 1,
 		WarehouseID:    1,
+		Quantity:       50,
 	}
 
+	mockRep.ExpectBegin()
 	mockRep.ExpectQuery("SELECT 1 FROM `inbound_orders` WHERE `order_number` = ?").
 		WithArgs(inbound.OrderNumber).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
 
 	mockRep.ExpectQuery("SELECT 1 FROM `employees` WHERE `id` = ?").WithArgs(inbound.EmployeeID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
-	mockRep.ExpectExec("INSERT INTO `inbound_orders` (`order_date`, `order_number`, `employee_id`, `product_batch_id`, `warehouse_id`) VALUES (?, ?, ?, ?, ?)").
-		WithArgs(inbound.OrderDate, inbound.OrderNumber, inbound.EmployeeID, inbound.ProductBatchID, inbound.WarehouseID).
+	mockRep.ExpectExec("INSERT INTO `inbound_orders` (`order_date`, `order_number`, `employee_id`, `product_batch_id`, `warehouse_id`, `quantity`) VALUES (?, ?, ?, ?, ?, ?)").
+		WithArgs(inbound.OrderDate, inbound.OrderNumber, inbound.EmployeeID, inbound.ProductBatchID, inbound.WarehouseID, inbound.Quantity).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	mockRep.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(inbound.ProductBatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "status", "section_id"}).AddRow(100, "released", 2))
+	mockRep.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(100, 500))
+	mockRep.ExpectExec(repository.UpdateSectionCapacityQuery).WithArgs(150, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockRep.ExpectExec(repository.UpdateProductBatchQuantityQuery).WithArgs(150, inbound.ProductBatchID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockRep.ExpectExec(repository.InsertStockMovementQuery).
+		WithArgs(inbound.ProductBatchID, internal.StockMovementReceipt, inbound.Quantity, "inbound order 1111111", 150, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mockRep.ExpectCommit()
+
 	//create repository
 	rep := repository.NewInboundOrderMysql(mockDB)
 
@@ -43,6 +59,7 @@ func TestInboundMysqlCreate_Success(t *testing.T) {
 	//assert
 	assert.NoError(t, err)
 	assert.Equal(t, int64(1), id)
+	assert.NoError(t, mockRep.ExpectationsWereMet())
 
 }
 
@@ -59,6 +76,7 @@ func TestInboundMysqlCreate_OrderNumberExists(t *testing.T) {
 		WarehouseID:    7,
 	}
 
+	mockRep.ExpectBegin()
 	mockRep.ExpectQuery("SELECT 1 FROM `inbound_orders` WHERE `order_number` = ?").
 		WithArgs(inbound.OrderNumber).
 		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
@@ -84,8 +102,10 @@ func TestInboundMysqlCreate_EmployeeNotFound(t *testing.T) {
 		EmployeeID:     1,
 		ProductBatchID: 1,
 		WarehouseID:    580,
+		Quantity:       10,
 	}
 
+	mockRep.ExpectBegin()
 	mockRep.ExpectQuery("SELECT 1 FROM `inbound_orders` WHERE `order_number` = ?").
 		WithArgs(inbound.OrderNumber).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
 
@@ -113,8 +133,10 @@ func TestInboundMysqlCreate_InsertError(t *testing.T) {
 		EmployeeID:     1,
 		ProductBatchID: 1,
 		WarehouseID:    580,
+		Quantity:       10,
 	}
 
+	mockRep.ExpectBegin()
 	mockRep.ExpectQuery("SELECT 1 FROM `inbound_orders` WHERE `order_number` = ?").
 		WithArgs(inboundInput.OrderNumber).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
@@ -123,9 +145,10 @@ func TestInboundMysqlCreate_InsertError(t *testing.T) {
 		WithArgs(inboundInput.EmployeeID).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
-	mockRep.ExpectExec("INSERT INTO `inbound_orders` (`order_date`, `order_number`, `employee_id`, `product_batch_id`, `warehouse_id`) VALUES (?, ?, ?, ?, ?)").
-		WithArgs(inboundInput.OrderDate, inboundInput.OrderNumber, inboundInput.EmployeeID, inboundInput.ProductBatchID, inboundInput.WarehouseID).
+	mockRep.ExpectExec("INSERT INTO `inbound_orders` (`order_date`, `order_number`, `employee_id`, `product_batch_id`, `warehouse_id`, `quantity`) VALUES (?, ?, ?, ?, ?, ?)").
+		WithArgs(inboundInput.OrderDate, inboundInput.OrderNumber, inboundInput.EmployeeID, inboundInput.ProductBatchID, inboundInput.WarehouseID, inboundInput.Quantity).
 		WillReturnError(fmt.Errorf("failed to insert inbound order"))
+	mockRep.ExpectRollback()
 
 	//create repository
 	rep := repository.NewInboundOrderMysql(mockDB)
@@ -139,23 +162,182 @@ func TestInboundMysqlCreate_InsertError(t *testing.T) {
 
 }
 
+func TestInboundMysqlCreate_ProductBatchNotFound(t *testing.T) {
+	mockDB, mockRep, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	inbound := internal.InboundOrders{
+		OrderDate:      "2025-01-01",
+		OrderNumber:    "555555",
+		EmployeeID:     1,
+		ProductBatchID: 99,
+		WarehouseID:    1,
+		Quantity:       10,
+	}
+
+	mockRep.ExpectBegin()
+	mockRep.ExpectQuery("SELECT 1 FROM `inbound_orders` WHERE `order_number` = ?").
+		WithArgs(inbound.OrderNumber).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+
+	mockRep.ExpectQuery("SELECT 1 FROM `employees` WHERE `id` = ?").WithArgs(inbound.EmployeeID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	mockRep.ExpectExec("INSERT INTO `inbound_orders` (`order_date`, `order_number`, `employee_id`, `product_batch_id`, `warehouse_id`, `quantity`) VALUES (?, ?, ?, ?, ?, ?)").
+		WithArgs(inbound.OrderDate, inbound.OrderNumber, inbound.EmployeeID, inbound.ProductBatchID, inbound.WarehouseID, inbound.Quantity).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mockRep.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(inbound.ProductBatchID).WillReturnError(sql.ErrNoRows)
+	mockRep.ExpectRollback()
+
+	//create repository
+	rep := repository.NewInboundOrderMysql(mockDB)
+
+	//act
+	id, err := rep.Create(inbound)
+
+	//assert
+	assert.ErrorIs(t, err, internal.ErrProductBatchNotFound)
+	assert.Equal(t, int64(0), id)
+	assert.NoError(t, mockRep.ExpectationsWereMet())
+}
+
+func TestInboundMysqlCreate_Lines(t *testing.T) {
+	mockDB, mockRep, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	inbound := internal.InboundOrders{
+		OrderDate:   "2025-01-01",
+		OrderNumber: "ASN01",
+		EmployeeID:  1,
+		WarehouseID: 1,
+		Quantity:    8,
+		Lines: []internal.InboundOrderLine{
+			{ExpectedQuantity: 10, ReceivedQuantity: 8, ProductBatch: internal.ProductBatch{
+				BatchNumber: 100, CurrentTemperature: 4.5, DueDate: "2025-06-01", ManufacturingDate: "2025-01-01",
+				ManufacturingHour: 10, MinumumTemperature: -2, ProductID: 5, SectionID: 4,
+			}},
+			{ExpectedQuantity: 5, ReceivedQuantity: 0, ProductBatch: internal.ProductBatch{
+				BatchNumber: 101, CurrentTemperature: 4.5, DueDate: "2025-06-01", ManufacturingDate: "2025-01-01",
+				ManufacturingHour: 10, MinumumTemperature: -2, ProductID: 6, SectionID: 4,
+			}},
+		},
+	}
+
+	mockRep.ExpectBegin()
+	mockRep.ExpectQuery("SELECT 1 FROM `inbound_orders` WHERE `order_number` = ?").
+		WithArgs(inbound.OrderNumber).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+	mockRep.ExpectQuery("SELECT 1 FROM `employees` WHERE `id` = ?").WithArgs(inbound.EmployeeID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mockRep.ExpectExec("INSERT INTO `inbound_orders` (`order_date`, `order_number`, `employee_id`, `product_batch_id`, `warehouse_id`, `quantity`) VALUES (?, ?, ?, ?, ?, ?)").
+		WithArgs(inbound.OrderDate, inbound.OrderNumber, inbound.EmployeeID, nil, inbound.WarehouseID, inbound.Quantity).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	// first line: the batch is created empty and the receipt adds the received quantity
+	mockRep.ExpectExec(repository.InsertProductBatchQuery).
+		WithArgs(100, 0, 4.5, "2025-06-01", 8, "2025-01-01", 10, -2.0, 5, 4).
+		WillReturnResult(sqlmock.NewResult(20, 1))
+	mockRep.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(20).
+		WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "status", "section_id"}).AddRow(0, "released", 4))
+	mockRep.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(100, 500))
+	mockRep.ExpectExec(repository.UpdateSectionCapacityQuery).WithArgs(108, 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockRep.ExpectExec(repository.UpdateProductBatchQuantityQuery).WithArgs(8, 20).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockRep.ExpectExec(repository.InsertStockMovementQuery).
+		WithArgs(20, internal.StockMovementReceipt, 8, "inbound order ASN01", 8, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mockRep.ExpectExec(repository.InsertInboundOrderLineQuery).WithArgs(3, 20, 10, 8).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// second line: nothing was received, the empty batch is only linked to the order
+	mockRep.ExpectExec(repository.InsertProductBatchQuery).
+		WithArgs(101, 0, 4.5, "2025-06-01", 0, "2025-01-01", 10, -2.0, 6, 4).
+		WillReturnResult(sqlmock.NewResult(21, 1))
+	mockRep.ExpectExec(repository.InsertInboundOrderLineQuery).WithArgs(3, 21, 5, 0).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mockRep.ExpectCommit()
+
+	//create repository
+	rep := repository.NewInboundOrderMysql(mockDB)
+
+	//act
+	id, err := rep.Create(inbound)
+
+	//assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), id)
+	assert.NoError(t, mockRep.ExpectationsWereMet())
+}
+
+func TestInboundMysqlCreate_LinesCapacityExceeded(t *testing.T) {
+	mockDB, mockRep, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	inbound := internal.InboundOrders{
+		OrderDate:   "2025-01-01",
+		OrderNumber: "ASN02",
+		EmployeeID:  1,
+		WarehouseID: 1,
+		Quantity:    50,
+		Lines: []internal.InboundOrderLine{
+			{ExpectedQuantity: 50, ReceivedQuantity: 50, ProductBatch: internal.ProductBatch{
+				BatchNumber: 100, DueDate: "2025-06-01", ManufacturingDate: "2025-01-01", ProductID: 5, SectionID: 4,
+			}},
+		},
+	}
+
+	mockRep.ExpectBegin()
+	mockRep.ExpectQuery("SELECT 1 FROM `inbound_orders` WHERE `order_number` = ?").
+		WithArgs(inbound.OrderNumber).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+	mockRep.ExpectQuery("SELECT 1 FROM `employees` WHERE `id` = ?").WithArgs(inbound.EmployeeID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mockRep.ExpectExec("INSERT INTO `inbound_orders` (`order_date`, `order_number`, `employee_id`, `product_batch_id`, `warehouse_id`, `quantity`) VALUES (?, ?, ?, ?, ?, ?)").
+		WithArgs(inbound.OrderDate, inbound.OrderNumber, inbound.EmployeeID, nil, inbound.WarehouseID, inbound.Quantity).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mockRep.ExpectExec(repository.InsertProductBatchQuery).
+		WithArgs(100, 0, 0.0, "2025-06-01", 50, "2025-01-01", 0, 0.0, 5, 4).
+		WillReturnResult(sqlmock.NewResult(20, 1))
+	mockRep.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(20).
+		WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "status", "section_id"}).AddRow(0, "released", 4))
+	mockRep.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(480, 500))
+	mockRep.ExpectRollback()
+
+	//create repository
+	rep := repository.NewInboundOrderMysql(mockDB)
+
+	//act
+	id, err := rep.Create(inbound)
+
+	//assert
+	var domainError internal.DomainError
+	assert.ErrorAs(t, err, &domainError)
+	assert.Equal(t, internal.ErrSectionCapacityExceeded.Error(), domainError.Message)
+	assert.Equal(t, int64(0), id)
+	assert.NoError(t, mockRep.ExpectationsWereMet())
+}
+
 func TestInboundMysqlGetAll_Success(t *testing.T) {
 
 	mockDB, mockRep, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	assert.NoError(t, err)
 	defer mockDB.Close()
 
-	row := sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id"}).
-		AddRow(1, "2025-01-01", "1111111", 1, 1, 1).
-		AddRow(2, "2025-02-02", "2222222", 2, 2, 2).
-		AddRow(3, "2025-03-03", "3333333", 3, 3, 3)
-	mockRep.ExpectQuery("SELECT `id`, `order_date`, `order_number`, `employee_id`, `product_batch_id`, `warehouse_id` FROM `inbound_orders`;").WillReturnRows(row)
+	row := sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id", "quantity"}).
+		AddRow(1, "2025-01-01", "1111111", 1, 1, 1, 10).
+		AddRow(2, "2025-02-02", "2222222", 2, 2, 2, 20).
+		AddRow(3, "2025-03-03", "3333333", 3, 3, 3, 30)
+	mockRep.ExpectQuery(repository.AllInboundsQuery + repository.AllInboundsOrderBy).WillReturnRows(row)
 
 	//create repository
 	rep := repository.NewInboundOrderMysql(mockDB)
 
 	//act
-	inboundOrders, err := rep.FindAll()
+	inboundOrders, err := rep.FindAll(internal.InboundOrderFilter{})
 
 	//assert
 	assert.NoError(t, err)
@@ -172,14 +354,14 @@ func TestInboundMysqlGetAll_QueryError(t *testing.T) {
 	assert.NoError(t, err)
 	defer mockDB.Close()
 
-	mock.ExpectQuery("SELECT `id`, `order_date`, `order_number`, `employee_id`, `product_batch_id`, `warehouse_id` FROM `inbound_orders`;").
+	mock.ExpectQuery(repository.AllInboundsQuery + repository.AllInboundsOrderBy).
 		WillReturnError(fmt.Errorf("failed to execute query"))
 
 	//create repository
 	rep := repository.NewInboundOrderMysql(mockDB)
 
 	//act
-	inbound, err := rep.FindAll()
+	inbound, err := rep.FindAll(internal.InboundOrderFilter{})
 
 	//assert
 	assert.Error(t, err)
@@ -193,24 +375,159 @@ func TestInboundMysqlGetAll_ScanError(t *testing.T) {
 	assert.NoError(t, err)
 	defer mockDB.Close()
 
-	row := sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id"}).
-		AddRow(1, "2025-01-01", "1111111", "aaaaaaaaa", 1, "hello world")
+	row := sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id", "quantity"}).
+		AddRow(1, "2025-01-01", "1111111", "aaaaaaaaa", 1, "hello world", 1)
 
-	mockRep.ExpectQuery("SELECT `id`, `order_date`, `order_number`, `employee_id`, `product_batch_id`, `warehouse_id` FROM `inbound_orders`;").
+	mockRep.ExpectQuery(repository.AllInboundsQuery + repository.AllInboundsOrderBy).
 		WillReturnRows(row)
 
-	mockRep.ExpectQuery("SELECT `id`, `order_date`, `order_number`, `employee_id`, `product_batch_id`, `warehouse_id` FROM `inbound_orders`;").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id"}).
-			AddRow(1, "2025-01-01", "1111111", "aaaaaaaaa", 1, "hello world"))
+	mockRep.ExpectQuery(repository.AllInboundsQuery + repository.AllInboundsOrderBy).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id", "quantity"}).
+			AddRow(1, "2025-01-01", "1111111", "aaaaaaaaa", 1, "hello world", 1))
 
 	//create repository
 	rep := repository.NewInboundOrderMysql(mockDB)
 
 	//act
-	inbound, err := rep.FindAll()
+	inbound, err := rep.FindAll(internal.InboundOrderFilter{})
 
 	//assert
 	assert.Error(t, err)
 	assert.Nil(t, inbound)
 
 }
+
+func TestInboundMysqlGetAll_Filter(t *testing.T) {
+	mockDB, mockRep, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	mockRep.ExpectQuery(repository.AllInboundsQuery+" WHERE warehouse_id = ? AND employee_id = ? AND order_date >= ? AND order_date <= ?"+repository.AllInboundsOrderBy).
+		WithArgs(2, 1, from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id", "quantity"}).
+			AddRow(1, "2025-01-10", "1111111", 1, 1, 2, 10))
+
+	//create repository
+	rep := repository.NewInboundOrderMysql(mockDB)
+
+	//act
+	inboundOrders, err := rep.FindAll(internal.InboundOrderFilter{WarehouseID: 2, EmployeeID: 1, From: from, To: to})
+
+	//assert
+	assert.NoError(t, err)
+	assert.Equal(t, []internal.InboundOrders{
+		{ID: 1, OrderDate: "2025-01-10", OrderNumber: "1111111", EmployeeID: 1, ProductBatchID: 1, WarehouseID: 2, Quantity: 10},
+	}, inboundOrders)
+	assert.NoError(t, mockRep.ExpectationsWereMet())
+}
+
+func TestInboundMysqlGetByID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockDB, mockRep, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		assert.NoError(t, err)
+		defer mockDB.Close()
+
+		mockRep.ExpectQuery(repository.InboundByIDQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id", "quantity"}).
+				AddRow(1, "2025-01-10", "1111111", 1, 1, 2, 10))
+		mockRep.ExpectQuery(repository.InboundOrderLinesQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		rep := repository.NewInboundOrderMysql(mockDB)
+
+		inboundOrder, err := rep.FindByID(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, internal.InboundOrders{ID: 1, OrderDate: "2025-01-10", OrderNumber: "1111111", EmployeeID: 1, ProductBatchID: 1, WarehouseID: 2, Quantity: 10}, inboundOrder)
+	})
+	t.Run("order with lines", func(t *testing.T) {
+		mockDB, mockRep, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		assert.NoError(t, err)
+		defer mockDB.Close()
+
+		mockRep.ExpectQuery(repository.InboundByIDQuery).WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id", "quantity"}).
+				AddRow(2, "2025-01-10", "ASN01", 1, nil, 2, 28))
+		mockRep.ExpectQuery(repository.InboundOrderLinesQuery).WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "inbound_order_id", "expected_quantity", "received_quantity",
+				"pb_id", "batch_number", "current_quantity", "current_temperature", "due_date", "initial_quantity",
+				"manufacturing_date", "manufacturing_hour", "minumum_temperature", "product_id", "section_id", "status"}).
+				AddRow(1, 2, 10, 8, 20, 100, 8, 4.5, "2025-06-01", 8, "2025-01-01", 10, -2.0, 5, 4, "released"))
+
+		rep := repository.NewInboundOrderMysql(mockDB)
+
+		inboundOrder, err := rep.FindByID(2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, internal.InboundOrders{
+			ID: 2, OrderDate: "2025-01-10", OrderNumber: "ASN01", EmployeeID: 1, WarehouseID: 2, Quantity: 28,
+			Lines: []internal.InboundOrderLine{{
+				ID: 1, InboundOrderID: 2, ExpectedQuantity: 10, ReceivedQuantity: 8,
+				ProductBatch: internal.ProductBatch{
+					ID: 20, BatchNumber: 100, CurrentQuantity: 8, CurrentTemperature: 4.5, DueDate: "2025-06-01", InitialQuantity: 8,
+					ManufacturingDate: "2025-01-01", ManufacturingHour: 10, MinumumTemperature: -2.0, ProductID: 5, SectionID: 4, Status: "released",
+				},
+			}},
+		}, inboundOrder)
+		assert.NoError(t, mockRep.ExpectationsWereMet())
+	})
+	t.Run("not found", func(t *testing.T) {
+		mockDB, mockRep, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		assert.NoError(t, err)
+		defer mockDB.Close()
+
+		mockRep.ExpectQuery(repository.InboundByIDQuery).WithArgs(99).WillReturnError(sql.ErrNoRows)
+
+		rep := repository.NewInboundOrderMysql(mockDB)
+
+		_, err = rep.FindByID(99)
+
+		assert.ErrorIs(t, err, internal.ErrInboundOrderNotFound)
+	})
+}
+
+func TestInboundMysqlFindSummary(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockDB, mockRep, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		assert.NoError(t, err)
+		defer mockDB.Close()
+
+		from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		mockRep.ExpectQuery(repository.InboundSummaryQuery+" WHERE io.warehouse_id = ? AND io.order_date >= ?"+repository.InboundSummaryGroupBy).
+			WithArgs(2, from).
+			WillReturnRows(sqlmock.NewRows([]string{"order_date", "product_type_id", "product_type_name", "orders_count", "received_quantity"}).
+				AddRow("2025-01-02", 1, "Dairy", 2, 150).
+				AddRow("2025-01-02", 2, "Meat", 1, 30))
+
+		rep := repository.NewInboundOrderMysql(mockDB)
+
+		summary, err := rep.FindSummary(internal.InboundOrderFilter{WarehouseID: 2, From: from})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []internal.InboundOrderSummary{
+			{OrderDate: "2025-01-02", ProductTypeID: 1, ProductTypeName: "Dairy", OrdersCount: 2, Quantity: 150},
+			{OrderDate: "2025-01-02", ProductTypeID: 2, ProductTypeName: "Meat", OrdersCount: 1, Quantity: 30},
+		}, summary)
+		assert.NoError(t, mockRep.ExpectationsWereMet())
+	})
+	t.Run("query error", func(t *testing.T) {
+		mockDB, mockRep, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		assert.NoError(t, err)
+		defer mockDB.Close()
+
+		mockRep.ExpectQuery(repository.InboundSummaryQuery + " WHERE io.warehouse_id = ?" + repository.InboundSummaryGroupBy).
+			WithArgs(2).
+			WillReturnError(fmt.Errorf("failed to execute query"))
+
+		rep := repository.NewInboundOrderMysql(mockDB)
+
+		summary, err := rep.FindSummary(internal.InboundOrderFilter{WarehouseID: 2})
+
+		assert.Error(t, err)
+		assert.Nil(t, summary)
+	})
+}
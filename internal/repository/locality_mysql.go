@@ -16,6 +16,27 @@ const (
 	ON l.id = c.locality_id
 	GROUP BY c.locality_id;
 	`
+	localityColumns = `
+		SELECT l.id, l.name, p.id, p.name, c.id, c.name`
+	localityJoins = `
+		FROM localities AS l
+		INNER JOIN provinces AS p ON p.id = l.province_id
+		INNER JOIN countries AS c ON c.id = p.country_id`
+	InsertLocalityQuery       = "INSERT INTO `localities` (`id`, `name`, `province_id`, `latitude`, `longitude`) VALUES (?, ?, ?, ?, ?)"
+	FindLocalityByIDQuery     = localityColumns + ", l.latitude, l.longitude" + localityJoins + " WHERE l.id = ?"
+	ReportSellersQuery        = localityColumns + ", COUNT(s.id)" + localityJoins + " LEFT JOIN sellers AS s ON l.id = s.locality_id GROUP BY l.id, p.id, c.id"
+	ReportSellersByIDQuery    = localityColumns + ", COUNT(s.id)" + localityJoins + " LEFT JOIN sellers AS s ON l.id = s.locality_id WHERE l.id = ? GROUP BY l.id, p.id, c.id"
+	LocalityStockSummaryQuery = `
+	SELECT pt.id, pt.name, COUNT(DISTINCT w.id), COUNT(pb.id), SUM(pb.current_quantity)
+	FROM warehouses AS w
+	INNER JOIN sections AS s ON s.warehouse_id = w.id
+	INNER JOIN product_batches AS pb ON pb.section_id = s.id
+	INNER JOIN products AS pr ON pr.id = pb.product_id
+	INNER JOIN product_type AS pt ON pt.id = pr.product_type_id
+	WHERE w.locality_id = ?
+	GROUP BY pt.id, pt.name
+	ORDER BY pt.id;
+	`
 )
 
 // NewLocalityMysql creates a new instance of the seller repository
@@ -71,16 +92,17 @@ func (r *LocalityMysql) GetAmountOfCarriesForEveryLocality() (c []internal.Carri
 // Save saves a locality into the database
 func (r *LocalityMysql) Save(locality *internal.Locality) (err error) {
 	// execute the query
-	_, err = r.db.Exec(
-		"INSERT INTO `localities` (`id`, `name`, `province_name`, `country_name`) VALUES (?, ?, ?, ?)",
-		(*locality).ID, (*locality).LocalityName, (*locality).ProvinceName, (*locality).CountryName,
-	)
+	latitude, longitude := coordinatesArgs((*locality).Coordinates)
+
+	_, err = r.db.Exec(InsertLocalityQuery, (*locality).ID, (*locality).LocalityName, (*locality).ProvinceID, latitude, longitude)
 	if err != nil {
 		var mysqlErr *mysql.MySQLError
 		if errors.As(err, &mysqlErr) {
 			switch mysqlErr.Number {
 			case 1062:
 				err = internal.ErrLocalityConflict
+			case 1452:
+				err = internal.ErrProvinceNotFound
 			}
 		}
 	}
@@ -89,7 +111,7 @@ func (r *LocalityMysql) Save(locality *internal.Locality) (err error) {
 }
 
 func (r *LocalityMysql) ReportSellers() (localities []internal.Locality, err error) {
-	rows, err := r.db.Query("SELECT l.id, l.name, l.province_name, l.country_name, COUNT(s.id) FROM localities AS l LEFT JOIN sellers AS s ON l.id = s.locality_id GROUP BY l.id")
+	rows, err := r.db.Query(ReportSellersQuery)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = internal.ErrLocalityNotFound
@@ -101,7 +123,7 @@ func (r *LocalityMysql) ReportSellers() (localities []internal.Locality, err err
 	for rows.Next() {
 		var locality internal.Locality
 
-		err = rows.Scan(&locality.ID, &locality.LocalityName, &locality.ProvinceName, &locality.CountryName, &locality.Sellers)
+		err = rows.Scan(&locality.ID, &locality.LocalityName, &locality.ProvinceID, &locality.ProvinceName, &locality.CountryID, &locality.CountryName, &locality.Sellers)
 		if err != nil {
 			return
 		}
@@ -115,11 +137,11 @@ func (r *LocalityMysql) ReportSellers() (localities []internal.Locality, err err
 // ReportSellersByID returns a seller from the database by its id
 func (r *LocalityMysql) ReportSellersByID(id int) (localities []internal.Locality, err error) {
 	// execute the query
-	row := r.db.QueryRow("SELECT l.id, l.name, l.province_name, l.country_name, COUNT(s.id) FROM localities AS l LEFT JOIN sellers AS s ON l.id = s.locality_id WHERE l.id = ? GROUP BY l.id", id)
+	row := r.db.QueryRow(ReportSellersByIDQuery, id)
 
 	var locality internal.Locality
 	// scan the row into the seller
-	err = row.Scan(&locality.ID, &locality.LocalityName, &locality.ProvinceName, &locality.CountryName, &locality.Sellers)
+	err = row.Scan(&locality.ID, &locality.LocalityName, &locality.ProvinceID, &locality.ProvinceName, &locality.CountryID, &locality.CountryName, &locality.Sellers)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = internal.ErrLocalityNotFound
@@ -134,15 +156,45 @@ func (r *LocalityMysql) ReportSellersByID(id int) (localities []internal.Localit
 
 func (r *LocalityMysql) FindByID(id int) (locality internal.Locality, err error) {
 	// execute the query
-	row := r.db.QueryRow("SELECT `id`, `name`, `province_name`, `country_name` FROM `localities` WHERE `id` = ?", id)
+	row := r.db.QueryRow(FindLocalityByIDQuery, id)
+
+	var latitude, longitude sql.NullFloat64
 
 	// scan the row into the seller
-	err = row.Scan(&locality.ID, &locality.LocalityName, &locality.ProvinceName, &locality.CountryName)
+	err = row.Scan(&locality.ID, &locality.LocalityName, &locality.ProvinceID, &locality.ProvinceName, &locality.CountryID, &locality.CountryName, &latitude, &longitude)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = internal.ErrLocalityNotFound
 		}
+
+		return
 	}
 
+	locality.Coordinates = newCoordinates(latitude, longitude)
+
+	return
+}
+
+// StockSummary returns the current batch quantities per product type of the warehouses in the locality
+func (r *LocalityMysql) StockSummary(localityID int) (summary []internal.LocalityStockSummary, err error) {
+	rows, err := r.db.Query(LocalityStockSummaryQuery, localityID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s internal.LocalityStockSummary
+
+		err = rows.Scan(&s.ProductTypeID, &s.ProductTypeName, &s.WarehousesCount, &s.BatchesCount, &s.CurrentQuantity)
+		if err != nil {
+			return
+		}
+
+		summary = append(summary, s)
+	}
+
+	err = rows.Err()
+
 	return
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/go-sql-driver/mysql"
 	"github.com/meli-fresh-products-api-backend-t1/internal/repository"
+	"strings"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -12,6 +13,19 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// selectedColumns returns the columns listed between SELECT and FROM, so the mocked rows have the shape MySQL returns
+func selectedColumns(query string) []string {
+	start := strings.Index(query, "SELECT") + len("SELECT")
+	end := strings.Index(query, "FROM")
+
+	var columns []string
+	for _, column := range strings.Split(query[start:end], ",") {
+		columns = append(columns, strings.TrimSpace(column))
+	}
+
+	return columns
+}
+
 func TestLocalityMysql_ReportCarries(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
@@ -121,6 +135,9 @@ func TestLocalityMysql_GetAmountOfCarriesForEveryLocality(t *testing.T) {
 }
 
 func TestLocalityMysql_Save(t *testing.T) {
+	// the mock does not count placeholders, the query must take every argument Save passes
+	assert.Equal(t, 5, strings.Count(repository.InsertLocalityQuery, "?"))
+
 	t.Run("Success", func(t *testing.T) {
 		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		assert.NoError(t, err)
@@ -129,12 +146,11 @@ func TestLocalityMysql_Save(t *testing.T) {
 		locality := &internal.Locality{
 			ID:           1,
 			LocalityName: "Locality 1",
-			ProvinceName: "Province 1",
-			CountryName:  "Country 1",
+			ProvinceID:   1,
 		}
 
-		mock.ExpectExec("INSERT INTO `localities` (`id`, `name`, `province_name`, `country_name`) VALUES (?, ?, ?, ?)").
-			WithArgs(locality.ID, locality.LocalityName, locality.ProvinceName, locality.CountryName).
+		mock.ExpectExec(repository.InsertLocalityQuery).
+			WithArgs(locality.ID, locality.LocalityName, locality.ProvinceID, nil, nil).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		r := repository.NewLocalityMysql(db)
@@ -151,12 +167,11 @@ func TestLocalityMysql_Save(t *testing.T) {
 		locality := &internal.Locality{
 			ID:           1,
 			LocalityName: "Locality 1",
-			ProvinceName: "Province 1",
-			CountryName:  "Country 1",
+			ProvinceID:   1,
 		}
 
-		mock.ExpectExec("INSERT INTO `localities` (`id`, `name`, `province_name`, `country_name`) VALUES (?, ?, ?, ?)").
-			WithArgs(locality.ID, locality.LocalityName, locality.ProvinceName, locality.CountryName).
+		mock.ExpectExec(repository.InsertLocalityQuery).
+			WithArgs(locality.ID, locality.LocalityName, locality.ProvinceID, nil, nil).
 			WillReturnError(&mysql.MySQLError{Number: 1062})
 
 		r := repository.NewLocalityMysql(db)
@@ -165,6 +180,27 @@ func TestLocalityMysql_Save(t *testing.T) {
 		assert.ErrorIs(t, err, internal.ErrLocalityConflict)
 	})
 
+	t.Run("Province not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		assert.NoError(t, err)
+		defer db.Close()
+
+		locality := &internal.Locality{
+			ID:           1,
+			LocalityName: "Locality 1",
+			ProvinceID:   99,
+		}
+
+		mock.ExpectExec(repository.InsertLocalityQuery).
+			WithArgs(locality.ID, locality.LocalityName, locality.ProvinceID, nil, nil).
+			WillReturnError(&mysql.MySQLError{Number: 1452})
+
+		r := repository.NewLocalityMysql(db)
+		err = r.Save(locality)
+
+		assert.ErrorIs(t, err, internal.ErrProvinceNotFound)
+	})
+
 	t.Run("Database error", func(t *testing.T) {
 		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		assert.NoError(t, err)
@@ -173,12 +209,11 @@ func TestLocalityMysql_Save(t *testing.T) {
 		locality := &internal.Locality{
 			ID:           1,
 			LocalityName: "Locality 1",
-			ProvinceName: "Province 1",
-			CountryName:  "Country 1",
+			ProvinceID:   1,
 		}
 
-		mock.ExpectExec("INSERT INTO `localities` (`id`, `name`, `province_name`, `country_name`) VALUES (?, ?, ?, ?)").
-			WithArgs(locality.ID, locality.LocalityName, locality.ProvinceName, locality.CountryName).
+		mock.ExpectExec(repository.InsertLocalityQuery).
+			WithArgs(locality.ID, locality.LocalityName, locality.ProvinceID, nil, nil).
 			WillReturnError(errors.New("database error"))
 
 		r := repository.NewLocalityMysql(db)
@@ -194,10 +229,10 @@ func TestLocalityMysql_ReportSellers(t *testing.T) {
 	defer db.Close()
 
 	t.Run("Success", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "name", "province_name", "country_name", "COUNT(s.id)"}).
-			AddRow(1, "Locality 1", "Province 1", "Country 1", 5).
-			AddRow(2, "Locality 2", "Province 2", "Country 2", 10)
-		mock.ExpectQuery("SELECT l.id, l.name, l.province_name, l.country_name, COUNT(s.id) FROM localities AS l LEFT JOIN sellers AS s ON l.id = s.locality_id GROUP BY l.id").
+		rows := sqlmock.NewRows(selectedColumns(repository.ReportSellersQuery)).
+			AddRow(1, "Locality 1", 1, "Province 1", 1, "Country 1", 5).
+			AddRow(2, "Locality 2", 2, "Province 2", 1, "Country 1", 10)
+		mock.ExpectQuery(repository.ReportSellersQuery).
 			WillReturnRows(rows)
 
 		r := repository.NewLocalityMysql(db)
@@ -210,7 +245,7 @@ func TestLocalityMysql_ReportSellers(t *testing.T) {
 	})
 
 	t.Run("No localities found", func(t *testing.T) {
-		mock.ExpectQuery("SELECT l.id, l.name, l.province_name, l.country_name, COUNT(s.id) FROM localities AS l LEFT JOIN sellers AS s ON l.id = s.locality_id GROUP BY l.id").
+		mock.ExpectQuery(repository.ReportSellersQuery).
 			WillReturnError(sql.ErrNoRows)
 
 		r := repository.NewLocalityMysql(db)
@@ -221,7 +256,7 @@ func TestLocalityMysql_ReportSellers(t *testing.T) {
 	})
 
 	t.Run("Database error", func(t *testing.T) {
-		mock.ExpectQuery("SELECT l.id, l.name, l.province_name, l.country_name, COUNT(s.id) FROM localities AS l LEFT JOIN sellers AS s ON l.id = s.locality_id GROUP BY l.id").
+		mock.ExpectQuery(repository.ReportSellersQuery).
 			WillReturnError(errors.New("database error"))
 
 		r := repository.NewLocalityMysql(db)
@@ -232,8 +267,8 @@ func TestLocalityMysql_ReportSellers(t *testing.T) {
 	})
 
 	t.Run("Row Scan Error", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "name", "province_name", "country_name", "COUNT(s.id)"}).AddRow(1, "Locality 1", 5, "Province 1", "Country 1")
-		mock.ExpectQuery("SELECT l.id, l.name, l.province_name, l.country_name, COUNT(s.id) FROM localities AS l LEFT JOIN sellers AS s ON l.id = s.locality_id GROUP BY l.id").WillReturnRows(rows)
+		rows := sqlmock.NewRows(selectedColumns(repository.ReportSellersQuery)).AddRow(1, "Locality 1", "Province 1", 5, "Country 1", 1, 5)
+		mock.ExpectQuery(repository.ReportSellersQuery).WillReturnRows(rows)
 
 		r := repository.NewLocalityMysql(db)
 		localities, err := r.ReportSellers()
@@ -249,9 +284,9 @@ func TestLocalityMysql_ReportSellersByID(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
-		row := sqlmock.NewRows([]string{"id", "name", "province_name", "country_name", "COUNT(s.id)"}).
-			AddRow(1, "Locality 1", "Province 1", "Country 1", 5)
-		mock.ExpectQuery("SELECT l.id, l.name, l.province_name, l.country_name, COUNT(s.id) FROM localities AS l LEFT JOIN sellers AS s ON l.id = s.locality_id WHERE l.id = ? GROUP BY l.id").
+		row := sqlmock.NewRows(selectedColumns(repository.ReportSellersQuery)).
+			AddRow(1, "Locality 1", 1, "Province 1", 1, "Country 1", 5)
+		mock.ExpectQuery(repository.ReportSellersByIDQuery).
 			WithArgs(1).
 			WillReturnRows(row)
 
@@ -268,7 +303,7 @@ func TestLocalityMysql_ReportSellersByID(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
-		mock.ExpectQuery("SELECT l.id, l.name, l.province_name, l.country_name, COUNT(s.id) FROM localities AS l LEFT JOIN sellers AS s ON l.id = s.locality_id WHERE l.id = ? GROUP BY l.id").
+		mock.ExpectQuery(repository.ReportSellersByIDQuery).
 			WithArgs(1).
 			WillReturnError(sql.ErrNoRows)
 
@@ -284,7 +319,7 @@ func TestLocalityMysql_ReportSellersByID(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
-		mock.ExpectQuery("SELECT l.id, l.name, l.province_name, l.country_name, COUNT(s.id) FROM localities AS l LEFT JOIN sellers AS s ON l.id = s.locality_id WHERE l.id = ? GROUP BY l.id").
+		mock.ExpectQuery(repository.ReportSellersByIDQuery).
 			WithArgs(1).
 			WillReturnError(errors.New("database error"))
 
@@ -302,9 +337,9 @@ func TestLocalityMysql_FindByID(t *testing.T) {
 	defer db.Close()
 
 	t.Run("Success", func(t *testing.T) {
-		row := sqlmock.NewRows([]string{"id", "name", "province_name", "country_name"}).
-			AddRow(1, "Locality 1", "Province 1", "Country 1")
-		mock.ExpectQuery("SELECT `id`, `name`, `province_name`, `country_name` FROM `localities` WHERE `id` = ?").
+		row := sqlmock.NewRows(selectedColumns(repository.FindLocalityByIDQuery)).
+			AddRow(1, "Locality 1", 1, "Province 1", 1, "Country 1", 40.7128, -74.006)
+		mock.ExpectQuery(repository.FindLocalityByIDQuery).
 			WithArgs(1).
 			WillReturnRows(row)
 
@@ -313,10 +348,11 @@ func TestLocalityMysql_FindByID(t *testing.T) {
 
 		assert.NoError(t, err)
 		assert.Equal(t, 1, locality.ID)
+		assert.Equal(t, &internal.Coordinates{Latitude: 40.7128, Longitude: -74.006}, locality.Coordinates)
 	})
 
 	t.Run("Locality not found", func(t *testing.T) {
-		mock.ExpectQuery("SELECT `id`, `name`, `province_name`, `country_name` FROM `localities` WHERE `id` = ?").
+		mock.ExpectQuery(repository.FindLocalityByIDQuery).
 			WithArgs(1).
 			WillReturnError(sql.ErrNoRows)
 
@@ -328,7 +364,7 @@ func TestLocalityMysql_FindByID(t *testing.T) {
 	})
 
 	t.Run("Database error", func(t *testing.T) {
-		mock.ExpectQuery("SELECT `id`, `name`, `province_name`, `country_name` FROM `localities` WHERE `id` = ?").
+		mock.ExpectQuery(repository.FindLocalityByIDQuery).
 			WithArgs(1).
 			WillReturnError(errors.New("database error"))
 
@@ -339,3 +375,44 @@ func TestLocalityMysql_FindByID(t *testing.T) {
 		assert.Equal(t, internal.Locality{}, locality)
 	})
 }
+
+func TestLocalityMysql_StockSummary(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "name", "warehouses_count", "batches_count", "current_quantity"}).
+			AddRow(1, "Dairy", 2, 3, 150).
+			AddRow(3, "Frozen", 1, 1, 40)
+		mock.ExpectQuery(repository.LocalityStockSummaryQuery).
+			WithArgs(1).
+			WillReturnRows(rows)
+
+		r := repository.NewLocalityMysql(db)
+		summary, err := r.StockSummary(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []internal.LocalityStockSummary{
+			{ProductTypeID: 1, ProductTypeName: "Dairy", WarehousesCount: 2, BatchesCount: 3, CurrentQuantity: 150},
+			{ProductTypeID: 3, ProductTypeName: "Frozen", WarehousesCount: 1, BatchesCount: 1, CurrentQuantity: 40},
+		}, summary)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(repository.LocalityStockSummaryQuery).
+			WithArgs(1).
+			WillReturnError(errors.New("database error"))
+
+		r := repository.NewLocalityMysql(db)
+		summary, err := r.StockSummary(1)
+
+		assert.Error(t, err)
+		assert.Nil(t, summary)
+	})
+}
@@ -17,14 +17,15 @@ func NewProductSQL(db *sql.DB) *ProductSQL {
 }
 
 const (
-	FindAllString  = "SELECT id, description, expiration_rate, freezing_rate, height, length, net_weight, product_code, recommended_freezing_temperature, width, product_type_id, seller_id FROM products"
-	FindByIDString = "SELECT id, description, expiration_rate, freezing_rate, height, length, net_weight, product_code, recommended_freezing_temperature, width, product_type_id, seller_id FROM products WHERE id = ?"
-	SaveString     = "INSERT INTO products (id, description, expiration_rate, freezing_rate, height, length, net_weight, product_code, recommended_freezing_temperature, width, product_type_id, seller_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	FindAllString = "SELECT id, description, expiration_rate, freezing_rate, height, length, net_weight, product_code, recommended_freezing_temperature, width, product_type_id, seller_id, status FROM products"
+	// FindByIDString reads the status of the seller too, products of inactive sellers cannot get new batches nor orders
+	FindByIDString = "SELECT p.id, p.description, p.expiration_rate, p.freezing_rate, p.height, p.length, p.net_weight, p.product_code, p.recommended_freezing_temperature, p.width, p.product_type_id, p.seller_id, p.status, s.status FROM products AS p INNER JOIN sellers AS s ON s.id = p.seller_id WHERE p.id = ?"
+	SaveString     = "INSERT INTO products (id, description, expiration_rate, freezing_rate, height, length, net_weight, product_code, recommended_freezing_temperature, width, product_type_id, seller_id, status) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
 	UpdateString   = `UPDATE products 
 		 SET description = ?, expiration_rate = ?, freezing_rate = ?, 
 		     height = ?, length = ?, net_weight = ?, 
 		     product_code = ?, recommended_freezing_temperature = ?, 
-		     width = ?, product_type_id = ?, seller_id = ?
+		     width = ?, product_type_id = ?, seller_id = ?, status = ?
 		 WHERE id = ?`
 	DeleteString         = "DELETE FROM products WHERE id = ?"
 	FindAllRecordString  = "SELECT pr.product_id, p.description, COUNT(*) AS records_count FROM product_records pr JOIN products p ON pr.product_id = p.id GROUP BY pr.product_id, p.description;"
@@ -47,7 +48,7 @@ func (psql *ProductSQL) FindAll() (products []internal.Product, err error) {
 
 		err := rows.Scan(&product.ID, &product.Description, &product.ExpirationRate, &product.FreezingRate,
 			&product.Height, &product.Length, &product.NetWeight, &product.ProductCode, &product.RecommendedFreezingTemperature,
-			&product.Width, &product.ProductTypeID, &product.SellerID)
+			&product.Width, &product.ProductTypeID, &product.SellerID, &product.Status)
 		if err != nil {
 			err = internal.ErrProductNotFound
 
@@ -66,7 +67,7 @@ func (psql *ProductSQL) FindByID(id int) (internal.Product, error) {
 	row := psql.db.QueryRow(FindByIDString, id)
 	err := row.Scan(&product.ID, &product.Description, &product.ExpirationRate, &product.FreezingRate,
 		&product.Height, &product.Length, &product.NetWeight, &product.ProductCode, &product.RecommendedFreezingTemperature,
-		&product.Width, &product.ProductTypeID, &product.SellerID)
+		&product.Width, &product.ProductTypeID, &product.SellerID, &product.Status, &product.SellerStatus)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -94,6 +95,7 @@ func (psql *ProductSQL) Save(product internal.Product) (p internal.Product, err
 		product.Width,
 		product.ProductTypeID,
 		product.SellerID,
+		product.Status,
 	)
 
 	if err != nil {
@@ -125,6 +127,7 @@ func (psql *ProductSQL) Update(product internal.Product) (internal.Product, erro
 		product.Width,
 		product.ProductTypeID,
 		product.SellerID,
+		product.Status,
 		product.ID,
 	)
 
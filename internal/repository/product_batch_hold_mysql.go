@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+const (
+	FindProductBatchHoldsQuery = `
+		SELECT h.id, h.product_batch_id, h.from_status, h.to_status, h.reason, h.employee_id, h.created_at
+		FROM product_batch_holds AS h
+		WHERE h.product_batch_id = ?
+		ORDER BY h.created_at, h.id
+	`
+	LockProductBatchStatusQuery          = "SELECT `status` FROM `product_batches` WHERE `id` = ? FOR UPDATE"
+	UpdateProductBatchStatusQuery        = "UPDATE `product_batches` SET `status` = ? WHERE `id` = ?"
+	InsertProductBatchHoldQuery          = "INSERT INTO `product_batch_holds` (`product_batch_id`, `from_status`, `to_status`, `reason`, `employee_id`, `created_at`) VALUES (?, ?, ?, ?, ?, ?)"
+	ReleaseProductBatchReservationsQuery = "DELETE FROM `purchase_order_reservations` WHERE `product_batch_id` = ? AND `consumed` = 0"
+)
+
+// NewProductBatchHoldMysql creates a new instance of the product batch hold repository
+func NewProductBatchHoldMysql(db *sql.DB) *ProductBatchHoldMysql {
+	return &ProductBatchHoldMysql{db}
+}
+
+// ProductBatchHoldMysql is the mysql implementation of the product batch hold repository
+type ProductBatchHoldMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// FindByProductBatchID returns the status changes of a product batch ordered by creation
+func (r *ProductBatchHoldMysql) FindByProductBatchID(productBatchID int) (holds []internal.ProductBatchHold, err error) {
+	rows, err := r.db.Query(FindProductBatchHoldsQuery, productBatchID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var h internal.ProductBatchHold
+
+		err = rows.Scan(&h.ID, &h.ProductBatchID, &h.FromStatus, &h.ToStatus, &h.Reason, &h.EmployeeID, &h.CreatedAt)
+		if err != nil {
+			return
+		}
+
+		holds = append(holds, h)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// Save locks the batch, checks the transition from its current status, updates it and records the change.
+// Holding a batch drops its open purchase order reservations so the orders can be reserved from other batches.
+func (r *ProductBatchHoldMysql) Save(h *internal.ProductBatchHold) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	err = tx.QueryRow(LockProductBatchStatusQuery, h.ProductBatchID).Scan(&h.FromStatus)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = internal.ErrProductBatchNotFound
+		}
+
+		return
+	}
+
+	if !internal.CanProductBatchTransition(h.FromStatus, h.ToStatus) {
+		err = internal.ErrProductBatchHoldInvalidTransition
+		return
+	}
+
+	_, err = tx.Exec(UpdateProductBatchStatusQuery, h.ToStatus, h.ProductBatchID)
+	if err != nil {
+		return
+	}
+
+	result, err := tx.Exec(InsertProductBatchHoldQuery, h.ProductBatchID, h.FromStatus, h.ToStatus, h.Reason, h.EmployeeID, h.CreatedAt)
+	if err != nil {
+		return
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return
+	}
+
+	h.ID = int(id)
+
+	if h.ToStatus != internal.ProductBatchStatusReleased {
+		_, err = tx.Exec(ReleaseProductBatchReservationsQuery, h.ProductBatchID)
+	}
+
+	return
+}
@@ -0,0 +1,140 @@
+package repository_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductBatchHoldMysql_FindByProductBatchID(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Status changes found", func(t *testing.T) {
+		expected := []internal.ProductBatchHold{
+			{ID: 1, ProductBatchID: 1, FromStatus: "released", ToStatus: "quarantined", Reason: "excursion 7", EmployeeID: 2, CreatedAt: createdAt},
+			{ID: 2, ProductBatchID: 1, FromStatus: "quarantined", ToStatus: "released", Reason: "lab results ok", EmployeeID: 2, CreatedAt: createdAt.Add(time.Hour)},
+		}
+
+		rows := sqlmock.NewRows([]string{"id", "product_batch_id", "from_status", "to_status", "reason", "employee_id", "created_at"})
+		for _, h := range expected {
+			rows.AddRow(h.ID, h.ProductBatchID, h.FromStatus, h.ToStatus, h.Reason, h.EmployeeID, h.CreatedAt)
+		}
+
+		mock.ExpectQuery(repository.FindProductBatchHoldsQuery).WithArgs(1).WillReturnRows(rows)
+
+		rp := repository.NewProductBatchHoldMysql(db)
+		holds, err := rp.FindByProductBatchID(1)
+
+		require.NoError(t, err)
+		require.Equal(t, expected, holds)
+	})
+
+	t.Run("case 2: error - Error executing the query", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindProductBatchHoldsQuery).WithArgs(1).WillReturnError(sql.ErrConnDone)
+
+		rp := repository.NewProductBatchHoldMysql(db)
+		_, err := rp.FindByProductBatchID(1)
+
+		require.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
+
+func TestProductBatchHoldMysql_Save(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Hold drops the open reservations of the batch", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		h := internal.ProductBatchHold{ProductBatchID: 1, ToStatus: "on_hold", Reason: "recall", EmployeeID: 2, CreatedAt: createdAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductBatchStatusQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("released"))
+		mock.ExpectExec(repository.UpdateProductBatchStatusQuery).WithArgs("on_hold", 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.InsertProductBatchHoldQuery).WithArgs(1, "released", "on_hold", "recall", 2, createdAt).
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectExec(repository.ReleaseProductBatchReservationsQuery).WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		rp := repository.NewProductBatchHoldMysql(db)
+		err = rp.Save(&h)
+
+		require.NoError(t, err)
+		require.Equal(t, 3, h.ID)
+		require.Equal(t, internal.ProductBatchStatusReleased, h.FromStatus)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: success - Release keeps the reservations", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		h := internal.ProductBatchHold{ProductBatchID: 1, ToStatus: "released", Reason: "lab results ok", EmployeeID: 2, CreatedAt: createdAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductBatchStatusQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("quarantined"))
+		mock.ExpectExec(repository.UpdateProductBatchStatusQuery).WithArgs("released", 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.InsertProductBatchHoldQuery).WithArgs(1, "quarantined", "released", "lab results ok", 2, createdAt).
+			WillReturnResult(sqlmock.NewResult(4, 1))
+		mock.ExpectCommit()
+
+		rp := repository.NewProductBatchHoldMysql(db)
+		err = rp.Save(&h)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 3: error - Quarantined batch cannot be put on hold", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		h := internal.ProductBatchHold{ProductBatchID: 1, ToStatus: "on_hold", Reason: "recall", EmployeeID: 2, CreatedAt: createdAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductBatchStatusQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("quarantined"))
+		mock.ExpectRollback()
+
+		rp := repository.NewProductBatchHoldMysql(db)
+		err = rp.Save(&h)
+
+		require.ErrorIs(t, err, internal.ErrProductBatchHoldInvalidTransition)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 4: error - Product batch not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		h := internal.ProductBatchHold{ProductBatchID: 99, ToStatus: "on_hold", Reason: "recall", EmployeeID: 2, CreatedAt: createdAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductBatchStatusQuery).WithArgs(99).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		rp := repository.NewProductBatchHoldMysql(db)
+		err = rp.Save(&h)
+
+		require.ErrorIs(t, err, internal.ErrProductBatchNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
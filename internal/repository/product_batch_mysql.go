@@ -3,11 +3,46 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"strings"
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 )
 
+const (
+	FindProductBatchesByDueDateQuery = `
+		SELECT w.id, w.warehouse_code, s.id, s.section_number,
+			pb.id, pb.batch_number, pb.current_quantity, pb.current_temperature, pb.due_date, pb.initial_quantity,
+			pb.manufacturing_date, pb.manufacturing_hour, pb.minumum_temperature, pb.product_id, pb.section_id, pb.status
+		FROM product_batches AS pb
+		INNER JOIN sections AS s ON s.id = pb.section_id
+		INNER JOIN warehouses AS w ON w.id = s.warehouse_id
+		WHERE pb.current_quantity > 0`
+	FindProductBatchesByDueDateOrderBy = " ORDER BY w.id, s.id, pb.due_date, pb.id"
+	FindProductBatchesBySectionQuery   = `
+		SELECT pb.id, pb.batch_number, pb.current_quantity, pb.current_temperature, pb.due_date, pb.initial_quantity,
+			pb.manufacturing_date, pb.manufacturing_hour, pb.minumum_temperature, pb.product_id, pb.section_id, pb.status
+		FROM product_batches AS pb
+		WHERE pb.section_id = ? AND pb.current_quantity > 0
+		ORDER BY pb.due_date, pb.id`
+	FindProductBatchesByProductQuery = `
+		SELECT pb.id, pb.batch_number, pb.current_quantity, pb.current_temperature, pb.due_date, pb.initial_quantity,
+			pb.manufacturing_date, pb.manufacturing_hour, pb.minumum_temperature, pb.product_id, pb.section_id, pb.status
+		FROM product_batches AS pb
+		WHERE pb.product_id = ? AND pb.current_quantity > 0
+		ORDER BY pb.section_id, pb.id`
+	InsertProductBatchQuery        = "INSERT INTO product_batches (batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minumum_temperature, product_id, section_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	LockProductBatchSectionQuery   = "SELECT `current_quantity`, `section_id` FROM `product_batches` WHERE `id` = ? FOR UPDATE"
+	UpdateProductBatchSectionQuery = "UPDATE `product_batches` SET `section_id` = ? WHERE `id` = ?"
+	DeleteProductBatchQuery        = "DELETE FROM `product_batches` WHERE `id` = ?"
+	ProductBatchHasHistoryQuery    = `
+		SELECT EXISTS (SELECT 1 FROM stock_movements AS sm WHERE sm.product_batch_id = ?)
+			OR EXISTS (SELECT 1 FROM product_batch_holds AS pbh WHERE pbh.product_batch_id = ?)
+			OR EXISTS (SELECT 1 FROM purchase_order_reservations AS por WHERE por.product_batch_id = ?)
+			OR EXISTS (SELECT 1 FROM transfers AS t WHERE t.product_batch_id = ? OR t.destination_product_batch_id = ?)
+			OR EXISTS (SELECT 1 FROM recall_batches AS rb WHERE rb.product_batch_id = ?)`
+)
+
 func NewProductBatchMysql(db *sql.DB) *ProductBatchMysql {
 	return &ProductBatchMysql{db}
 }
@@ -29,7 +64,8 @@ func (r *ProductBatchMysql) FindByID(id int) (internal.ProductBatch, error) {
 		pb.manufacturing_hour,
 		pb.minumum_temperature,           
 		pb.product_id,           
-		pb.section_id           
+		pb.section_id,
+		pb.status
 	FROM 
 		product_batches pb
 	WHERE 
@@ -49,6 +85,7 @@ func (r *ProductBatchMysql) FindByID(id int) (internal.ProductBatch, error) {
 		&pb.MinumumTemperature,
 		&pb.ProductID,
 		&pb.SectionID,
+		&pb.Status,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -61,9 +98,24 @@ func (r *ProductBatchMysql) FindByID(id int) (internal.ProductBatch, error) {
 	return pb, nil
 }
 
-func (r *ProductBatchMysql) Save(prodBatch *internal.ProductBatch) error {
-	result, err := r.db.Exec(
-		"INSERT INTO product_batches (batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minumum_temperature, product_id, section_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+// Save inserts the batch and adds its quantity to the capacity of its section in a single transaction
+func (r *ProductBatchMysql) Save(prodBatch *internal.ProductBatch) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	result, err := tx.Exec(
+		InsertProductBatchQuery,
 		prodBatch.BatchNumber,
 		prodBatch.CurrentQuantity,
 		prodBatch.CurrentTemperature,
@@ -75,29 +127,135 @@ func (r *ProductBatchMysql) Save(prodBatch *internal.ProductBatch) error {
 		prodBatch.ProductID,
 		prodBatch.SectionID,
 	)
-
 	if err != nil {
 		var mysqlErr *mysql.MySQLError
 		if errors.As(err, &mysqlErr) {
 			switch mysqlErr.Number {
 			case 1062:
-				return internal.ErrProductBatchUnprocessableEntity
+				err = internal.ErrProductBatchUnprocessableEntity
 			}
 		}
 
-		return err
+		return
 	}
 
 	id, err := result.LastInsertId()
 	if err != nil {
-		return err
+		return
 	}
 
 	prodBatch.ID = int(id)
 
-	return nil
+	err = adjustSectionCapacity(tx, prodBatch.SectionID, prodBatch.CurrentQuantity)
+
+	return
+}
+
+// Move stores the batch in another section, moving its quantity between the section capacities in a single transaction
+func (r *ProductBatchMysql) Move(id int, sectionID int) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	err = moveProductBatch(tx, id, sectionID)
+
+	return
+}
+
+// moveProductBatch locks the batch, moves its quantity between the section capacities and stores it in the section.
+// It is shared by the repositories that move whole batches inside their own transaction.
+func moveProductBatch(tx *sql.Tx, id int, sectionID int) error {
+	var currentQuantity, currentSectionID int
+
+	err := tx.QueryRow(LockProductBatchSectionQuery, id).Scan(&currentQuantity, &currentSectionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return internal.ErrProductBatchNotFound
+		}
+
+		return err
+	}
+
+	if currentSectionID == sectionID {
+		return nil
+	}
+
+	err = adjustSectionCapacity(tx, currentSectionID, -currentQuantity)
+	if err != nil {
+		return err
+	}
+
+	err = adjustSectionCapacity(tx, sectionID, currentQuantity)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(UpdateProductBatchSectionQuery, sectionID, id)
+
+	return err
+}
+
+// Delete removes the batch and frees its quantity from the capacity of its section in a single transaction.
+// Batches with stock movements, holds, reservations, transfers or recalls are kept, deleting them would cascade that history away.
+func (r *ProductBatchMysql) Delete(id int) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	var currentQuantity, sectionID int
+
+	err = tx.QueryRow(LockProductBatchSectionQuery, id).Scan(&currentQuantity, &sectionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = internal.ErrProductBatchNotFound
+		}
+
+		return
+	}
+
+	var hasHistory bool
+
+	err = tx.QueryRow(ProductBatchHasHistoryQuery, id, id, id, id, id, id).Scan(&hasHistory)
+	if err != nil {
+		return
+	}
+
+	if hasHistory {
+		err = internal.ErrProductBatchHasHistory
+		return
+	}
+
+	err = adjustSectionCapacity(tx, sectionID, -currentQuantity)
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec(DeleteProductBatchQuery, id)
+
+	return
 }
 
+// ProductBatchNumberExists tells whether a lot with the number is registered, batches split by transfers share it
 func (r *ProductBatchMysql) ProductBatchNumberExists(batchNumber int) (bool, error) {
 	query := "SELECT COUNT(*) FROM product_batches WHERE batch_number = ?"
 
@@ -214,3 +372,129 @@ func (r *ProductBatchMysql) ReportProductsByID(id int) (prodBatches []internal.P
 
 	return prodBatches, nil
 }
+
+// FindByDueDate returns the batches with stock left whose due date is in the filter range,
+// grouped by warehouse and section and ordered by due date inside each group
+func (r *ProductBatchMysql) FindByDueDate(filter internal.ProductBatchDueDateFilter) (groups []internal.ProductBatchExpiryGroup, err error) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "pb.due_date >= ?")
+		args = append(args, filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "pb.due_date <= ?")
+		args = append(args, filter.To)
+	}
+
+	if filter.WarehouseID != 0 {
+		conditions = append(conditions, "w.id = ?")
+		args = append(args, filter.WarehouseID)
+	}
+
+	query := FindProductBatchesByDueDateQuery
+	if len(conditions) > 0 {
+		query += " AND " + strings.Join(conditions, " AND ")
+	}
+
+	query += FindProductBatchesByDueDateOrderBy
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			group internal.ProductBatchExpiryGroup
+			pb    internal.ProductBatch
+		)
+
+		err = rows.Scan(
+			&group.WarehouseID,
+			&group.WarehouseCode,
+			&group.SectionID,
+			&group.SectionNumber,
+			&pb.ID,
+			&pb.BatchNumber,
+			&pb.CurrentQuantity,
+			&pb.CurrentTemperature,
+			&pb.DueDate,
+			&pb.InitialQuantity,
+			&pb.ManufacturingDate,
+			&pb.ManufacturingHour,
+			&pb.MinumumTemperature,
+			&pb.ProductID,
+			&pb.SectionID,
+			&pb.Status,
+		)
+		if err != nil {
+			return
+		}
+
+		// rows come ordered by section, so a new section starts a new group
+		if len(groups) == 0 || groups[len(groups)-1].SectionID != group.SectionID {
+			groups = append(groups, group)
+		}
+
+		last := &groups[len(groups)-1]
+		last.RemainingQuantity += pb.CurrentQuantity
+		last.Batches = append(last.Batches, pb)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// FindBySectionID returns the batches with stock left stored in the section, ordered by due date
+func (r *ProductBatchMysql) FindBySectionID(sectionID int) (prodBatches []internal.ProductBatch, err error) {
+	return r.findProductBatches(FindProductBatchesBySectionQuery, sectionID)
+}
+
+// FindByProductID returns the batches with stock left of the product
+func (r *ProductBatchMysql) FindByProductID(productID int) (prodBatches []internal.ProductBatch, err error) {
+	return r.findProductBatches(FindProductBatchesByProductQuery, productID)
+}
+
+// findProductBatches runs a query selecting the batch columns and scans its rows
+func (r *ProductBatchMysql) findProductBatches(query string, args ...any) (prodBatches []internal.ProductBatch, err error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pb internal.ProductBatch
+
+		err = rows.Scan(
+			&pb.ID,
+			&pb.BatchNumber,
+			&pb.CurrentQuantity,
+			&pb.CurrentTemperature,
+			&pb.DueDate,
+			&pb.InitialQuantity,
+			&pb.ManufacturingDate,
+			&pb.ManufacturingHour,
+			&pb.MinumumTemperature,
+			&pb.ProductID,
+			&pb.SectionID,
+			&pb.Status,
+		)
+		if err != nil {
+			return
+		}
+
+		prodBatches = append(prodBatches, pb)
+	}
+
+	err = rows.Err()
+
+	return
+}
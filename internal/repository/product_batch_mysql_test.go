@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-sql-driver/mysql"
@@ -44,11 +45,17 @@ func (s *MysqlProductBatchTestSuite) TestRepository_CreateProductBatchUnitTest()
 		}
 
 		s.Setup()
+		s.mock.ExpectBegin()
 		s.mock.ExpectExec("INSERT").
 			WithArgs(prodBatch.BatchNumber, prodBatch.CurrentQuantity, prodBatch.CurrentTemperature, prodBatch.DueDate,
 				prodBatch.InitialQuantity, prodBatch.ManufacturingDate, prodBatch.ManufacturingHour, prodBatch.MinumumTemperature,
 				prodBatch.ProductID, prodBatch.SectionID).
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectQuery("SELECT").WithArgs(prodBatch.SectionID).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(50, 500))
+		s.mock.ExpectExec("UPDATE").WithArgs(150, prodBatch.SectionID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
 
 		err := s.rp.Save(&prodBatch)
 
@@ -71,6 +78,7 @@ func (s *MysqlProductBatchTestSuite) TestRepository_CreateProductBatchUnitTest()
 		}
 
 		s.Setup()
+		s.mock.ExpectBegin()
 		s.mock.ExpectExec("INSERT").
 			WithArgs(prodBatch.BatchNumber, prodBatch.CurrentQuantity, prodBatch.CurrentTemperature, prodBatch.DueDate,
 				prodBatch.InitialQuantity, prodBatch.ManufacturingDate, prodBatch.ManufacturingHour, prodBatch.MinumumTemperature,
@@ -78,6 +86,7 @@ func (s *MysqlProductBatchTestSuite) TestRepository_CreateProductBatchUnitTest()
 			WillReturnError(&mysql.MySQLError{
 				Number: 1062,
 			})
+		s.mock.ExpectRollback()
 
 		err := s.rp.Save(&prodBatch)
 
@@ -100,11 +109,13 @@ func (s *MysqlProductBatchTestSuite) TestRepository_CreateProductBatchUnitTest()
 		}
 
 		s.Setup()
+		s.mock.ExpectBegin()
 		s.mock.ExpectExec("INSERT").
 			WithArgs(prodBatch.BatchNumber, prodBatch.CurrentQuantity, prodBatch.CurrentTemperature, prodBatch.DueDate,
 				prodBatch.InitialQuantity, prodBatch.ManufacturingDate, prodBatch.ManufacturingHour, prodBatch.MinumumTemperature,
 				prodBatch.ProductID, prodBatch.SectionID).
 			WillReturnError(errors.New("Error SQL Query"))
+		s.mock.ExpectRollback()
 
 		err := s.rp.Save(&prodBatch)
 
@@ -128,6 +139,7 @@ func (s *MysqlProductBatchTestSuite) TestRepository_ReadProductBatchUnitTest() {
 			MinumumTemperature: -8,
 			ProductID:          1,
 			SectionID:          3,
+			Status:             internal.ProductBatchStatusReleased,
 		}
 
 		rows := sqlmock.NewRows(
@@ -143,9 +155,10 @@ func (s *MysqlProductBatchTestSuite) TestRepository_ReadProductBatchUnitTest() {
 				"minumum_temperature",
 				"product_id",
 				"section_id",
+				"status",
 			},
 		).
-			AddRow(1, 1234, 100, 40.5, "2022-01-08", 120, "2022-01-01", 15, -8, 1, 3)
+			AddRow(1, 1234, 100, 40.5, "2022-01-08", 120, "2022-01-01", 15, -8, 1, 3, "released")
 
 		s.mock.ExpectQuery("SELECT").WillReturnRows(rows)
 
@@ -338,3 +351,244 @@ func (s *MysqlProductBatchTestSuite) TestRepository_ReportProductsByIDProductBat
 func TestRepositoryMysqlProductBatchTestSuite(t *testing.T) {
 	suite.Run(t, new(MysqlProductBatchTestSuite))
 }
+
+func TestProductBatchMysql_FindByDueDate(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	columns := []string{"w.id", "w.warehouse_code", "s.id", "s.section_number", "pb.id", "pb.batch_number", "pb.current_quantity",
+		"pb.current_temperature", "pb.due_date", "pb.initial_quantity", "pb.manufacturing_date", "pb.manufacturing_hour",
+		"pb.minumum_temperature", "pb.product_id", "pb.section_id", "pb.status"}
+
+	t.Run("success - batches are grouped by section", func(t *testing.T) {
+		from := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(1, "WH-1", 1, 10, 1, 100, 20, 4.0, "2025-01-11", 50, "2024-12-01", 8, -2.0, 1, 1, "released").
+			AddRow(1, "WH-1", 1, 10, 2, 101, 5, 4.0, "2025-01-12", 50, "2024-12-01", 8, -2.0, 2, 1, "released").
+			AddRow(1, "WH-1", 2, 11, 3, 102, 7, 4.0, "2025-01-11", 50, "2024-12-01", 8, -2.0, 1, 2, "released")
+		mock.ExpectQuery(repository.FindProductBatchesByDueDateQuery+
+			" AND pb.due_date >= ? AND pb.due_date <= ? AND w.id = ?"+
+			repository.FindProductBatchesByDueDateOrderBy).
+			WithArgs(from, to, 1).
+			WillReturnRows(rows)
+
+		rp := repository.NewProductBatchMysql(db)
+		groups, err := rp.FindByDueDate(internal.ProductBatchDueDateFilter{From: from, To: to, WarehouseID: 1})
+
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+		require.Equal(t, 25, groups[0].RemainingQuantity)
+		require.Len(t, groups[0].Batches, 2)
+		require.Equal(t, 11, groups[1].SectionNumber)
+		require.Equal(t, 7, groups[1].RemainingQuantity)
+	})
+
+	t.Run("fails - error executing the query", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindProductBatchesByDueDateQuery + repository.FindProductBatchesByDueDateOrderBy).
+			WillReturnError(sql.ErrConnDone)
+
+		rp := repository.NewProductBatchMysql(db)
+		_, err := rp.FindByDueDate(internal.ProductBatchDueDateFilter{})
+
+		require.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
+
+func TestProductBatchMysql_FindBySectionID(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	columns := []string{"pb.id", "pb.batch_number", "pb.current_quantity", "pb.current_temperature", "pb.due_date",
+		"pb.initial_quantity", "pb.manufacturing_date", "pb.manufacturing_hour", "pb.minumum_temperature", "pb.product_id", "pb.section_id", "pb.status"}
+
+	t.Run("success - batches with stock in the section", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(1, 100, 20, 4.0, "2025-01-11", 50, "2024-12-01", 8, -2.0, 1, 3, "released").
+			AddRow(2, 101, 5, 4.0, "2025-01-12", 50, "2024-12-01", 8, -2.0, 2, 3, "on_hold")
+		mock.ExpectQuery(repository.FindProductBatchesBySectionQuery).WithArgs(3).WillReturnRows(rows)
+
+		rp := repository.NewProductBatchMysql(db)
+		prodBatches, err := rp.FindBySectionID(3)
+
+		require.NoError(t, err)
+		require.Len(t, prodBatches, 2)
+		require.Equal(t, 101, prodBatches[1].BatchNumber)
+		require.Equal(t, 3, prodBatches[1].SectionID)
+		require.Equal(t, internal.ProductBatchStatusOnHold, prodBatches[1].Status)
+	})
+
+	t.Run("fails - error executing the query", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindProductBatchesBySectionQuery).WithArgs(3).WillReturnError(sql.ErrConnDone)
+
+		rp := repository.NewProductBatchMysql(db)
+		_, err := rp.FindBySectionID(3)
+
+		require.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
+
+func TestProductBatchMysql_FindByProductID(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	columns := []string{"pb.id", "pb.batch_number", "pb.current_quantity", "pb.current_temperature", "pb.due_date",
+		"pb.initial_quantity", "pb.manufacturing_date", "pb.manufacturing_hour", "pb.minumum_temperature", "pb.product_id", "pb.section_id", "pb.status"}
+
+	t.Run("success - batches with stock of the product", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(1, 100, 20, 4.0, "2025-01-11", 50, "2024-12-01", 8, -2.0, 2, 3, "released").
+			AddRow(2, 101, 5, 4.0, "2025-01-12", 50, "2024-12-01", 8, -2.0, 2, 4, "released")
+		mock.ExpectQuery(repository.FindProductBatchesByProductQuery).WithArgs(2).WillReturnRows(rows)
+
+		rp := repository.NewProductBatchMysql(db)
+		prodBatches, err := rp.FindByProductID(2)
+
+		require.NoError(t, err)
+		require.Len(t, prodBatches, 2)
+		require.Equal(t, 4, prodBatches[1].SectionID)
+	})
+
+	t.Run("fails - error executing the query", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindProductBatchesByProductQuery).WithArgs(2).WillReturnError(sql.ErrConnDone)
+
+		rp := repository.NewProductBatchMysql(db)
+		_, err := rp.FindByProductID(2)
+
+		require.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
+
+func TestProductBatchMysql_Move(t *testing.T) {
+	t.Run("case 1: success - Quantity moves between the section capacities", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductBatchSectionQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "section_id"}).AddRow(40, 3))
+		mock.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(100, 500))
+		mock.ExpectExec(repository.UpdateSectionCapacityQuery).WithArgs(60, 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(4).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(10, 50))
+		mock.ExpectExec(repository.UpdateSectionCapacityQuery).WithArgs(50, 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.UpdateProductBatchSectionQuery).WithArgs(4, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		rp := repository.NewProductBatchMysql(db)
+		err = rp.Move(1, 4)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: error - Target section is full", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductBatchSectionQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "section_id"}).AddRow(40, 3))
+		mock.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(100, 500))
+		mock.ExpectExec(repository.UpdateSectionCapacityQuery).WithArgs(60, 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(4).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(30, 50))
+		mock.ExpectRollback()
+
+		rp := repository.NewProductBatchMysql(db)
+		err = rp.Move(1, 4)
+
+		require.ErrorAs(t, err, &internal.DomainError{})
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 3: error - Product batch not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductBatchSectionQuery).WithArgs(99).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		rp := repository.NewProductBatchMysql(db)
+		err = rp.Move(99, 4)
+
+		require.ErrorIs(t, err, internal.ErrProductBatchNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestProductBatchMysql_Delete(t *testing.T) {
+	t.Run("case 1: success - Quantity is freed from the section", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductBatchSectionQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "section_id"}).AddRow(40, 3))
+		mock.ExpectQuery(repository.ProductBatchHasHistoryQuery).WithArgs(1, 1, 1, 1, 1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"has_history"}).AddRow(false))
+		mock.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(30, 500))
+		mock.ExpectExec(repository.UpdateSectionCapacityQuery).WithArgs(0, 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.DeleteProductBatchQuery).WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		rp := repository.NewProductBatchMysql(db)
+		err = rp.Delete(1)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: error - Product batch not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductBatchSectionQuery).WithArgs(99).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		rp := repository.NewProductBatchMysql(db)
+		err = rp.Delete(99)
+
+		require.ErrorIs(t, err, internal.ErrProductBatchNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 3: error - Batch with history is kept", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductBatchSectionQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "section_id"}).AddRow(40, 3))
+		mock.ExpectQuery(repository.ProductBatchHasHistoryQuery).WithArgs(1, 1, 1, 1, 1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"has_history"}).AddRow(true))
+		mock.ExpectRollback()
+
+		rp := repository.NewProductBatchMysql(db)
+		err = rp.Delete(1)
+
+		require.ErrorIs(t, err, internal.ErrProductBatchHasHistory)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
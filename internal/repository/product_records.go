@@ -3,6 +3,7 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/meli-fresh-products-api-backend-t1/internal"
@@ -17,9 +18,12 @@ func NewProductRecordsSQL(db *sql.DB) *ProductRecordsSQL {
 }
 
 const (
-	FindAllProductRecords  = "SELECT `id`, `last_update_date`, `purchase_price`, `sale_price`, `product_id` FROM `product_records`"
-	FindByIDProductRecords = "SELECT `id`, `last_update_date`, `purchase_price`, `sale_price`, `product_id` FROM `product_records` WHERE `id` = ?"
-	SaveProductRecords     = "INSERT INTO `product_records` (`last_update_date`, `purchase_price`, `sale_price`, `product_id`) VALUES (?, ?, ?, ?)"
+	FindAllProductRecords         = "SELECT `id`, `last_update_date`, `purchase_price`, `sale_price`, `product_id` FROM `product_records`"
+	FindByIDProductRecords        = "SELECT `id`, `last_update_date`, `purchase_price`, `sale_price`, `product_id` FROM `product_records` WHERE `id` = ?"
+	FindProductRecordsByProductID = "SELECT `id`, `last_update_date`, `purchase_price`, `sale_price`, `product_id` FROM `product_records` WHERE `product_id` = ?"
+	FindProductRecordsOrderBy     = " ORDER BY `last_update_date`, `id`"
+	FindEffectiveProductRecord    = "SELECT `id`, `last_update_date`, `purchase_price`, `sale_price`, `product_id` FROM `product_records` WHERE `product_id` = ? AND `last_update_date` <= ? ORDER BY `last_update_date` DESC, `id` DESC LIMIT 1"
+	SaveProductRecords            = "INSERT INTO `product_records` (`last_update_date`, `purchase_price`, `sale_price`, `product_id`) VALUES (?, ?, ?, ?)"
 )
 
 func (psql *ProductRecordsSQL) FindAll() (productRecords []internal.ProductRecords, err error) {
@@ -62,6 +66,63 @@ func (psql *ProductRecordsSQL) FindByID(id int) (internal.ProductRecords, error)
 	return productRecord, nil
 }
 
+// FindByProductID returns the records of the product updated in the range, oldest first
+func (psql *ProductRecordsSQL) FindByProductID(productID int, from time.Time, to time.Time) (productRecords []internal.ProductRecords, err error) {
+	query := FindProductRecordsByProductID
+	args := []any{productID}
+
+	if !from.IsZero() {
+		query += " AND `last_update_date` >= ?"
+		args = append(args, from.UTC())
+	}
+
+	if !to.IsZero() {
+		query += " AND `last_update_date` <= ?"
+		args = append(args, to.UTC())
+	}
+
+	query += FindProductRecordsOrderBy
+
+	rows, err := psql.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productRecord internal.ProductRecords
+
+		err = rows.Scan(&productRecord.ID, &productRecord.LastUpdateDate, &productRecord.PurchasePrice, &productRecord.SalePrice, &productRecord.ProductID)
+		if err != nil {
+			return
+		}
+
+		productRecords = append(productRecords, productRecord)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// FindEffectiveByProductID returns the latest record of the product updated at or before the given time
+func (psql *ProductRecordsSQL) FindEffectiveByProductID(productID int, at time.Time) (internal.ProductRecords, error) {
+	var productRecord internal.ProductRecords
+
+	row := psql.db.QueryRow(FindEffectiveProductRecord, productID, at.UTC())
+	err := row.Scan(&productRecord.ID, &productRecord.LastUpdateDate, &productRecord.PurchasePrice, &productRecord.SalePrice, &productRecord.ProductID)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = internal.ErrProductPriceNotFound
+		}
+
+		return productRecord, err
+	}
+
+	return productRecord, nil
+}
+
 func (psql *ProductRecordsSQL) Save(productRec internal.ProductRecords) (internal.ProductRecords, error) {
 	_, err := psql.db.Exec(
 		SaveProductRecords,
@@ -135,6 +135,97 @@ func TestProductRecordsMysql_FinAByID_not_found(t *testing.T) {
 	assert.Empty(t, product)
 }
 
+func TestProductRecordsMysql_FindByProductID_ok(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "last_update_date", "purchase_price", "sale_price", "product_id"}).
+		AddRow(1, from, 10, 15, 1).
+		AddRow(2, from.AddDate(0, 0, 10), 12, 18, 1)
+
+	query := repository.FindProductRecordsByProductID + " AND `last_update_date` >= ? AND `last_update_date` <= ?" + repository.FindProductRecordsOrderBy
+	mock.ExpectQuery(query).WithArgs(1, from, to).WillReturnRows(rows)
+
+	repo := repository.NewProductRecordsSQL(mockDB)
+
+	records, err := repo.FindByProductID(1, from, to)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, 2, records[1].ID)
+	assert.Equal(t, float32(18), records[1].SalePrice)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRecordsMysql_FindByProductID_open_range(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "last_update_date", "purchase_price", "sale_price", "product_id"})
+
+	mock.ExpectQuery(repository.FindProductRecordsByProductID + repository.FindProductRecordsOrderBy).WithArgs(1).WillReturnRows(rows)
+
+	repo := repository.NewProductRecordsSQL(mockDB)
+
+	records, err := repo.FindByProductID(1, time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRecordsMysql_FindByProductID_query_error(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectQuery(repository.FindProductRecordsByProductID + repository.FindProductRecordsOrderBy).WithArgs(1).WillReturnError(errors.New("query error"))
+
+	repo := repository.NewProductRecordsSQL(mockDB)
+
+	records, err := repo.FindByProductID(1, time.Time{}, time.Time{})
+	assert.Error(t, err)
+	assert.Nil(t, records)
+}
+
+func TestProductRecordsMysql_FindEffectiveByProductID_ok(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	at := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	row := sqlmock.NewRows([]string{"id", "last_update_date", "purchase_price", "sale_price", "product_id"}).
+		AddRow(2, at.AddDate(0, 0, -1), 12, 18, 1)
+
+	mock.ExpectQuery(repository.FindEffectiveProductRecord).WithArgs(1, at).WillReturnRows(row)
+
+	repo := repository.NewProductRecordsSQL(mockDB)
+
+	record, err := repo.FindEffectiveByProductID(1, at)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, record.ID)
+	assert.Equal(t, 12.0, record.PurchasePrice)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRecordsMysql_FindEffectiveByProductID_not_found(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	at := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	row := sqlmock.NewRows([]string{"id", "last_update_date", "purchase_price", "sale_price", "product_id"})
+
+	mock.ExpectQuery(repository.FindEffectiveProductRecord).WithArgs(1, at).WillReturnRows(row)
+
+	repo := repository.NewProductRecordsSQL(mockDB)
+
+	_, err = repo.FindEffectiveByProductID(1, at)
+	assert.ErrorIs(t, err, internal.ErrProductPriceNotFound)
+}
+
 func TestProductRecordsMysql_Save_ok(t *testing.T) {
 	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	assert.NoError(t, err)
@@ -26,6 +26,7 @@ var product = internal.Product{
 	FreezingRate:                   1,
 	ProductTypeID:                  1,
 	SellerID:                       1,
+	Status:                         internal.ProductStatusActive,
 }
 
 func TestProductMysql_FinAll(t *testing.T) {
@@ -46,9 +47,10 @@ func TestProductMysql_FinAll(t *testing.T) {
 		"freezing_rate",
 		"product_type_id",
 		"seller_id",
+		"status",
 	}).
-		AddRow(1, "code 1", 1, 1, 1, 1, 1, "desc 1", 1, 1, 1, 1).
-		AddRow(2, "code 2", 2, 2, 2, 2, 2, "desc 2", 2, 2, 2, 2)
+		AddRow(1, "code 1", 1, 1, 1, 1, 1, "desc 1", 1, 1, 1, 1, "active").
+		AddRow(2, "code 2", 2, 2, 2, 2, 2, "desc 2", 2, 2, 2, 2, "suspended")
 
 	mock.ExpectQuery(repository.FindAllString).WillReturnRows(rows)
 
@@ -96,7 +98,8 @@ func TestProductMysql_FindAll_scan_error_no_rows(t *testing.T) {
 		"freezing_rate",
 		"product_type_id",
 		"seller_id",
-	}).AddRow(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		"status",
+	}).AddRow(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	mock.ExpectQuery(repository.FindAllString).WillReturnRows(rows)
 
@@ -127,8 +130,10 @@ func TestProductMysql_FinAByID_ok(t *testing.T) {
 		"freezing_rate",
 		"product_type_id",
 		"seller_id",
+		"status",
+		"seller_status",
 	}).
-		AddRow(1, "code 1", 1, 1, 1, 1, 1, "desc 1", 1, 1, 1, 1)
+		AddRow(1, "code 1", 1, 1, 1, 1, 1, "desc 1", 1, 1, 1, 1, "active", "suspended")
 
 	mock.ExpectQuery(repository.FindByIDString).WithArgs(1).WillReturnRows(row)
 
@@ -137,6 +142,8 @@ func TestProductMysql_FinAByID_ok(t *testing.T) {
 	product, err := repo.FindByID(1)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, product.ID)
+	assert.Equal(t, internal.ProductStatusActive, product.Status)
+	assert.Equal(t, internal.SellerStatusSuspended, product.SellerStatus)
 }
 
 func TestProductMysql_FinAByID_not_found(t *testing.T) {
@@ -157,6 +164,8 @@ func TestProductMysql_FinAByID_not_found(t *testing.T) {
 		"freezing_rate",
 		"product_type_id",
 		"seller_id",
+		"status",
+		"seller_status",
 	})
 
 	mock.ExpectQuery(repository.FindByIDString).WithArgs(1).WillReturnRows(row)
@@ -190,6 +199,7 @@ func TestProductMysql_save_ok(t *testing.T) {
 			product.Width,
 			product.ProductTypeID,
 			product.SellerID,
+			product.Status,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
 
 	// Cria uma instância do repositório ProductSQL passando o mock do banco
@@ -221,6 +231,7 @@ func TestProductMysql_save_error(t *testing.T) {
 			product.Width,
 			product.ProductTypeID,
 			product.SellerID,
+			product.Status,
 		).WillReturnError(fmt.Errorf("some Error"))
 
 	// Cria uma instância do repositório ProductSQL passando o mock do banco
@@ -251,6 +262,7 @@ func TestProductMysql_save_conflict(t *testing.T) {
 			product.Width,
 			product.ProductTypeID,
 			product.SellerID,
+			product.Status,
 		).WillReturnError(&mysql.MySQLError{Number: 1062})
 
 	// Cria uma instância do repositório ProductSQL passando o mock do banco
@@ -280,6 +292,7 @@ func TestProductMysql_Update_ok(t *testing.T) {
 			product.Width,
 			product.ProductTypeID,
 			product.SellerID,
+			product.Status,
 			product.ID,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -307,6 +320,7 @@ func TestProductMysql_Update_not_found(t *testing.T) {
 			product.Width,
 			product.ProductTypeID,
 			product.SellerID,
+			product.Status,
 			product.ID,
 		).WillReturnResult(sqlmock.NewResult(0, 0))
 
@@ -336,6 +350,7 @@ func TestProductMysql_Update_mysql_error(t *testing.T) {
 			product.Width,
 			product.ProductTypeID,
 			product.SellerID,
+			product.Status,
 			product.ID,
 		).WillReturnError(&mysql.MySQLError{Number: 1062})
 
@@ -365,6 +380,7 @@ func TestProductMysql_Update_mysql_generic_error(t *testing.T) {
 			product.Width,
 			product.ProductTypeID,
 			product.SellerID,
+			product.Status,
 			product.ID,
 		).WillReturnError(&mysql.MySQLError{Number: 1234})
 
@@ -393,6 +409,7 @@ func TestProductMysql_Update_rows_affected_error(t *testing.T) {
 			product.Width,
 			product.ProductTypeID,
 			product.SellerID,
+			product.Status,
 			product.ID,
 		).WillReturnResult(sqlmock.NewErrorResult(internal.ErrProductNotFound))
 
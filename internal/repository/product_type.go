@@ -2,18 +2,31 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 )
 
 const FindByIDProductType = `
-SELECT 
+SELECT
 	id,
-	description          
-FROM 
+	name,
+	description
+FROM
 	product_type
-WHERE 
+WHERE
 	id = ?`
 
+const (
+	FindAllProductTypeQuery        = "SELECT `id`, `name`, `description` FROM `product_type` ORDER BY `id`"
+	InsertProductTypeQuery         = "INSERT INTO `product_type` (`name`, `description`) VALUES (?, ?)"
+	UpdateProductTypeQuery         = "UPDATE `product_type` SET `name` = ?, `description` = ? WHERE `id` = ?"
+	LockProductTypeQuery           = "SELECT `id` FROM `product_type` WHERE `id` = ? FOR UPDATE"
+	CountProductTypeReferenceQuery = "SELECT (SELECT COUNT(*) FROM `sections` WHERE `product_type_id` = ?) + (SELECT COUNT(*) FROM `products` WHERE `product_type_id` = ?)"
+	DeleteProductTypeQuery         = "DELETE FROM `product_type` WHERE `id` = ?"
+)
+
 func NewProductTypeMysql(db *sql.DB) *ProductTypeMysql {
 	return &ProductTypeMysql{db}
 }
@@ -22,17 +35,124 @@ type ProductTypeMysql struct {
 	db *sql.DB
 }
 
+func (r *ProductTypeMysql) FindAll() ([]internal.ProductType, error) {
+	rows, err := r.db.Query(FindAllProductTypeQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var productTypes []internal.ProductType
+
+	for rows.Next() {
+		var pt internal.ProductType
+
+		err := rows.Scan(&pt.ID, &pt.Name, &pt.Description)
+		if err != nil {
+			return nil, err
+		}
+
+		productTypes = append(productTypes, pt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return productTypes, nil
+}
+
 func (r *ProductTypeMysql) FindByID(id int) (internal.ProductType, error) {
 	var pt internal.ProductType
 	err := r.db.QueryRow(FindByIDProductType, id).Scan(
 		&pt.ID,
+		&pt.Name,
 		&pt.Description,
 	)
-	
+
 	if err != nil {
 		return pt, internal.ErrProductTypeNotFound
-		
 	}
 
 	return pt, nil
 }
+
+func (r *ProductTypeMysql) Save(pt *internal.ProductType) error {
+	result, err := r.db.Exec(InsertProductTypeQuery, pt.Name, pt.Description)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			err = internal.ErrProductTypeAlreadyExists
+		}
+
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	pt.ID = int(id)
+
+	return nil
+}
+
+func (r *ProductTypeMysql) Update(pt *internal.ProductType) error {
+	_, err := r.db.Exec(UpdateProductTypeQuery, pt.Name, pt.Description, pt.ID)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			err = internal.ErrProductTypeAlreadyExists
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the product type. The foreign keys cascade, so the references are checked
+// under a lock instead of relying on the database to refuse the delete.
+func (r *ProductTypeMysql) Delete(id int) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	var lockedID int
+
+	err = tx.QueryRow(LockProductTypeQuery, id).Scan(&lockedID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = internal.ErrProductTypeNotFound
+		}
+
+		return err
+	}
+
+	var references int
+
+	err = tx.QueryRow(CountProductTypeReferenceQuery, id, id).Scan(&references)
+	if err != nil {
+		return err
+	}
+
+	if references > 0 {
+		err = internal.ErrProductTypeInUse
+		return err
+	}
+
+	_, err = tx.Exec(DeleteProductTypeQuery, id)
+
+	return err
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+const (
+	FindProductTypeCompatibilitiesQuery    = "SELECT `section_product_type_id` FROM `product_type_compatibilities` WHERE `product_type_id` = ? ORDER BY `section_product_type_id`"
+	FindProductTypeCompatibleSectionsQuery = `
+		SELECT s.id, s.section_number, s.current_temperature, s.minimum_temperature, s.current_capacity,
+			s.minimum_capacity, s.maximum_capacity, s.warehouse_id, s.product_type_id
+		FROM sections AS s
+		WHERE s.product_type_id = ? OR s.product_type_id IN (
+			SELECT c.section_product_type_id FROM product_type_compatibilities AS c WHERE c.product_type_id = ?
+		)
+		ORDER BY s.id
+	`
+	CountProductTypeCompatibilityQuery  = "SELECT COUNT(*) FROM `product_type_compatibilities` WHERE `product_type_id` = ? AND `section_product_type_id` = ?"
+	InsertProductTypeCompatibilityQuery = "INSERT INTO `product_type_compatibilities` (`product_type_id`, `section_product_type_id`) VALUES (?, ?)"
+	DeleteProductTypeCompatibilityQuery = "DELETE FROM `product_type_compatibilities` WHERE `product_type_id` = ? AND `section_product_type_id` = ?"
+
+	CountProductTypeCompatibilityBatchesQuery = `
+		SELECT COUNT(*)
+		FROM product_batches AS pb
+		INNER JOIN products AS p ON p.id = pb.product_id
+		INNER JOIN sections AS s ON s.id = pb.section_id
+		WHERE p.product_type_id = ? AND s.product_type_id = ? AND pb.current_quantity > 0
+	`
+)
+
+// NewProductTypeCompatibilityMysql creates a new instance of the product type compatibility repository
+func NewProductTypeCompatibilityMysql(db *sql.DB) *ProductTypeCompatibilityMysql {
+	return &ProductTypeCompatibilityMysql{db}
+}
+
+// ProductTypeCompatibilityMysql is the mysql implementation of the product type compatibility repository
+type ProductTypeCompatibilityMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// FindSectionProductTypeIDs returns the section types that may also hold products of the type
+func (r *ProductTypeCompatibilityMysql) FindSectionProductTypeIDs(productTypeID int) (ids []int, err error) {
+	rows, err := r.db.Query(FindProductTypeCompatibilitiesQuery, productTypeID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+
+		err = rows.Scan(&id)
+		if err != nil {
+			return
+		}
+
+		ids = append(ids, id)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// FindSections returns the sections declared for the product type or for one of its compatible section types
+func (r *ProductTypeCompatibilityMysql) FindSections(productTypeID int) (sections []internal.Section, err error) {
+	rows, err := r.db.Query(FindProductTypeCompatibleSectionsQuery, productTypeID, productTypeID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s internal.Section
+
+		err = rows.Scan(&s.ID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
+		if err != nil {
+			return
+		}
+
+		sections = append(sections, s)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// IsCompatible reports whether a rule lets sections of a type hold products of another type
+func (r *ProductTypeCompatibilityMysql) IsCompatible(productTypeID int, sectionProductTypeID int) (bool, error) {
+	var count int
+
+	err := r.db.QueryRow(CountProductTypeCompatibilityQuery, productTypeID, sectionProductTypeID).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// CountStoredBatches counts the batches with stock left that the rule lets sit in sections of another type
+func (r *ProductTypeCompatibilityMysql) CountStoredBatches(c internal.ProductTypeCompatibility) (int, error) {
+	var count int
+
+	err := r.db.QueryRow(CountProductTypeCompatibilityBatchesQuery, c.ProductTypeID, c.SectionProductTypeID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Save registers the compatibility rule
+func (r *ProductTypeCompatibilityMysql) Save(c internal.ProductTypeCompatibility) error {
+	_, err := r.db.Exec(InsertProductTypeCompatibilityQuery, c.ProductTypeID, c.SectionProductTypeID)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) {
+			switch mysqlErr.Number {
+			case 1062:
+				err = internal.ErrProductTypeCompatibilityAlreadyExists
+			case 1452:
+				err = internal.ErrProductTypeCompatibilitySectionTypeNotFound
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the compatibility rule
+func (r *ProductTypeCompatibilityMysql) Delete(c internal.ProductTypeCompatibility) error {
+	result, err := r.db.Exec(DeleteProductTypeCompatibilityQuery, c.ProductTypeID, c.SectionProductTypeID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return internal.ErrProductTypeCompatibilityNotFound
+	}
+
+	return nil
+}
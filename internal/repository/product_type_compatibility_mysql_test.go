@@ -0,0 +1,136 @@
+package repository_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductTypeCompatibilityMysql_FindSections(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	t.Run("case 1: success - Sections of the type and of its compatible types", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindProductTypeCompatibleSectionsQuery).WithArgs(6, 6).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "section_number", "current_temperature", "minimum_temperature", "current_capacity", "minimum_capacity", "maximum_capacity", "warehouse_id", "product_type_id"}).
+				AddRow(1, 10, -20, -25, 50, 10, 200, 1, 6).
+				AddRow(4, 40, -18, -22, 0, 10, 300, 1, 10))
+
+		rp := repository.NewProductTypeCompatibilityMysql(db)
+		sections, err := rp.FindSections(6)
+
+		require.NoError(t, err)
+		require.Equal(t, []internal.Section{
+			{ID: 1, SectionNumber: 10, CurrentTemperature: -20, MinimumTemperature: -25, CurrentCapacity: 50, MinimumCapacity: 10, MaximumCapacity: 200, WarehouseID: 1, ProductTypeID: 6},
+			{ID: 4, SectionNumber: 40, CurrentTemperature: -18, MinimumTemperature: -22, CurrentCapacity: 0, MinimumCapacity: 10, MaximumCapacity: 300, WarehouseID: 1, ProductTypeID: 10},
+		}, sections)
+	})
+}
+
+func TestProductTypeCompatibilityMysql_IsCompatible(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	t.Run("case 1: success - Rule registered", func(t *testing.T) {
+		mock.ExpectQuery(repository.CountProductTypeCompatibilityQuery).WithArgs(6, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		rp := repository.NewProductTypeCompatibilityMysql(db)
+		compatible, err := rp.IsCompatible(6, 10)
+
+		require.NoError(t, err)
+		require.True(t, compatible)
+	})
+
+	t.Run("case 2: success - No rule", func(t *testing.T) {
+		mock.ExpectQuery(repository.CountProductTypeCompatibilityQuery).WithArgs(1, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		rp := repository.NewProductTypeCompatibilityMysql(db)
+		compatible, err := rp.IsCompatible(1, 10)
+
+		require.NoError(t, err)
+		require.False(t, compatible)
+	})
+}
+
+func TestProductTypeCompatibilityMysql_CountStoredBatches(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	t.Run("case 1: success - Batches stored through the rule", func(t *testing.T) {
+		mock.ExpectQuery(repository.CountProductTypeCompatibilityBatchesQuery).WithArgs(6, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		rp := repository.NewProductTypeCompatibilityMysql(db)
+		stored, err := rp.CountStoredBatches(internal.ProductTypeCompatibility{ProductTypeID: 6, SectionProductTypeID: 10})
+
+		require.NoError(t, err)
+		require.Equal(t, 3, stored)
+	})
+
+	t.Run("case 2: error - Query fails", func(t *testing.T) {
+		mock.ExpectQuery(repository.CountProductTypeCompatibilityBatchesQuery).WithArgs(6, 10).WillReturnError(sql.ErrConnDone)
+
+		rp := repository.NewProductTypeCompatibilityMysql(db)
+		_, err := rp.CountStoredBatches(internal.ProductTypeCompatibility{ProductTypeID: 6, SectionProductTypeID: 10})
+
+		require.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
+
+func TestProductTypeCompatibilityMysql_Save(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	t.Run("case 1: success - Rule registered", func(t *testing.T) {
+		mock.ExpectExec(repository.InsertProductTypeCompatibilityQuery).WithArgs(6, 10).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		rp := repository.NewProductTypeCompatibilityMysql(db)
+		err := rp.Save(internal.ProductTypeCompatibility{ProductTypeID: 6, SectionProductTypeID: 10})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("case 2: error - Rule already exists", func(t *testing.T) {
+		mock.ExpectExec(repository.InsertProductTypeCompatibilityQuery).WithArgs(6, 10).WillReturnError(&mysql.MySQLError{Number: 1062})
+
+		rp := repository.NewProductTypeCompatibilityMysql(db)
+		err := rp.Save(internal.ProductTypeCompatibility{ProductTypeID: 6, SectionProductTypeID: 10})
+
+		require.ErrorIs(t, err, internal.ErrProductTypeCompatibilityAlreadyExists)
+	})
+}
+
+func TestProductTypeCompatibilityMysql_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	t.Run("case 1: success - Rule removed", func(t *testing.T) {
+		mock.ExpectExec(repository.DeleteProductTypeCompatibilityQuery).WithArgs(6, 10).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		rp := repository.NewProductTypeCompatibilityMysql(db)
+		err := rp.Delete(internal.ProductTypeCompatibility{ProductTypeID: 6, SectionProductTypeID: 10})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("case 2: error - Rule not found", func(t *testing.T) {
+		mock.ExpectExec(repository.DeleteProductTypeCompatibilityQuery).WithArgs(6, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		rp := repository.NewProductTypeCompatibilityMysql(db)
+		err := rp.Delete(internal.ProductTypeCompatibility{ProductTypeID: 6, SectionProductTypeID: 9})
+
+		require.ErrorIs(t, err, internal.ErrProductTypeCompatibilityNotFound)
+	})
+}
@@ -1,9 +1,11 @@
 package repository_test
 
 import (
+	"database/sql"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 	"github.com/meli-fresh-products-api-backend-t1/internal/repository"
 	"github.com/stretchr/testify/assert"
@@ -16,9 +18,10 @@ func TestProductTypeMysql_FindByID_ok(t *testing.T) {
 
 	row := sqlmock.NewRows([]string{
 		"id",
+		"name",
 		"description",
 	}).
-		AddRow(1, "product type")
+		AddRow(1, "Dairy", "product type")
 	mock.ExpectQuery(repository.FindByIDProductType).WillReturnRows(row)
 
 	repo := repository.NewProductTypeMysql(mockDB)
@@ -35,6 +38,7 @@ func TestProductTypeMysql_FindByID_not_found(t *testing.T) {
 
 	row := sqlmock.NewRows([]string{
 		"id",
+		"name",
 		"description",
 	})
 
@@ -46,3 +50,93 @@ func TestProductTypeMysql_FindByID_not_found(t *testing.T) {
 	assert.Equal(t, internal.ErrProductTypeNotFound, err)
 	assert.Equal(t, 1, product.ID)
 }
+
+func TestProductTypeMysql_FindAll(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "name", "description"}).
+		AddRow(1, "Dairy", "Milk and cheese").
+		AddRow(2, "Meat", "Beef and pork")
+	mock.ExpectQuery(repository.FindAllProductTypeQuery).WillReturnRows(rows)
+
+	repo := repository.NewProductTypeMysql(mockDB)
+
+	productTypes, err := repo.FindAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []internal.ProductType{
+		{ID: 1, Name: "Dairy", Description: "Milk and cheese"},
+		{ID: 2, Name: "Meat", Description: "Beef and pork"},
+	}, productTypes)
+}
+
+func TestProductTypeMysql_Save(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	repo := repository.NewProductTypeMysql(mockDB)
+
+	t.Run("saves the product type", func(t *testing.T) {
+		mock.ExpectExec(repository.InsertProductTypeQuery).WithArgs("Dairy", "Milk and cheese").WillReturnResult(sqlmock.NewResult(11, 1))
+
+		pt := internal.ProductType{Name: "Dairy", Description: "Milk and cheese"}
+		err := repo.Save(&pt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 11, pt.ID)
+	})
+
+	t.Run("returns already exists on a duplicated name", func(t *testing.T) {
+		mock.ExpectExec(repository.InsertProductTypeQuery).WithArgs("Dairy", "Milk and cheese").WillReturnError(&mysql.MySQLError{Number: 1062})
+
+		err := repo.Save(&internal.ProductType{Name: "Dairy", Description: "Milk and cheese"})
+
+		assert.ErrorIs(t, err, internal.ErrProductTypeAlreadyExists)
+	})
+}
+
+func TestProductTypeMysql_Delete(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	repo := repository.NewProductTypeMysql(mockDB)
+
+	t.Run("deletes an unreferenced product type", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductTypeQuery).WithArgs(11).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+		mock.ExpectQuery(repository.CountProductTypeReferenceQuery).WithArgs(11, 11).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectExec(repository.DeleteProductTypeQuery).WithArgs(11).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.Delete(11)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("refuses to delete a referenced product type", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductTypeQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery(repository.CountProductTypeReferenceQuery).WithArgs(1, 1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectRollback()
+
+		err := repo.Delete(1)
+
+		assert.ErrorIs(t, err, internal.ErrProductTypeInUse)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("returns not found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductTypeQuery).WithArgs(99).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		err := repo.Delete(99)
+
+		assert.ErrorIs(t, err, internal.ErrProductTypeNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+const (
+	FindAllProvincesQuery = "SELECT `id`, `name`, `country_id` FROM `provinces`"
+	FindProvincesOrderBy  = " ORDER BY `id`"
+	FindProvinceByIDQuery = FindAllProvincesQuery + " WHERE `id` = ?"
+	InsertProvinceQuery   = "INSERT INTO `provinces` (`name`, `country_id`) VALUES (?, ?)"
+	provinceReportQuery   = `
+		SELECT p.id, p.name, c.id, c.name, COUNT(DISTINCT l.id), COUNT(DISTINCT s.id), COUNT(DISTINCT cr.id)
+		FROM provinces AS p
+		INNER JOIN countries AS c ON c.id = p.country_id
+		LEFT JOIN localities AS l ON l.province_id = p.id
+		LEFT JOIN sellers AS s ON s.locality_id = l.id
+		LEFT JOIN carries AS cr ON cr.locality_id = l.id`
+	ProvinceReportQuery     = provinceReportQuery + " GROUP BY p.id, p.name, c.id, c.name ORDER BY p.id"
+	ProvinceReportByIDQuery = provinceReportQuery + " WHERE p.id = ? GROUP BY p.id, p.name, c.id, c.name"
+)
+
+// NewProvinceMysql creates a new instance of the province repository
+func NewProvinceMysql(db *sql.DB) *ProvinceMysql {
+	return &ProvinceMysql{db}
+}
+
+// ProvinceMysql is the mysql implementation of the province repository
+type ProvinceMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// FindAll returns the provinces ordered by ID, only the ones of the given country when it is not zero
+func (r *ProvinceMysql) FindAll(countryID int) (provinces []internal.Province, err error) {
+	query := FindAllProvincesQuery
+	var args []any
+
+	if countryID != 0 {
+		query += " WHERE `country_id` = ?"
+		args = append(args, countryID)
+	}
+
+	rows, err := r.db.Query(query+FindProvincesOrderBy, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p internal.Province
+
+		err = rows.Scan(&p.ID, &p.Name, &p.CountryID)
+		if err != nil {
+			return
+		}
+
+		provinces = append(provinces, p)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// FindByID returns the province with the given ID
+func (r *ProvinceMysql) FindByID(id int) (p internal.Province, err error) {
+	err = r.db.QueryRow(FindProvinceByIDQuery, id).Scan(&p.ID, &p.Name, &p.CountryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = internal.ErrProvinceNotFound
+	}
+
+	return
+}
+
+// Save records the province. The (country_id, name) key is unique with a case insensitive collation,
+// so "Texas" and "texas" are the same province of a country.
+func (r *ProvinceMysql) Save(p *internal.Province) error {
+	result, err := r.db.Exec(InsertProvinceQuery, p.Name, p.CountryID)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) {
+			switch mysqlErr.Number {
+			case 1062:
+				return internal.ErrProvinceConflict
+			case 1452:
+				return internal.ErrCountryNotFound
+			}
+		}
+
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	p.ID = int(id)
+
+	return nil
+}
+
+// Report returns the localities, sellers and carries of every province
+func (r *ProvinceMysql) Report() (reports []internal.ProvinceReport, err error) {
+	rows, err := r.db.Query(ProvinceReportQuery)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var report internal.ProvinceReport
+
+		report, err = scanProvinceReport(rows)
+		if err != nil {
+			return
+		}
+
+		reports = append(reports, report)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// ReportByID returns the localities, sellers and carries of the province with the given ID
+func (r *ProvinceMysql) ReportByID(id int) (internal.ProvinceReport, error) {
+	report, err := scanProvinceReport(r.db.QueryRow(ProvinceReportByIDQuery, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return internal.ProvinceReport{}, internal.ErrProvinceNotFound
+	}
+
+	return report, err
+}
+
+// scanProvinceReport reads a province report from a row of provinceReportQuery
+func scanProvinceReport(row interface{ Scan(dest ...any) error }) (report internal.ProvinceReport, err error) {
+	err = row.Scan(
+		&report.ProvinceID,
+		&report.ProvinceName,
+		&report.CountryID,
+		&report.CountryName,
+		&report.LocalitiesCount,
+		&report.SellersCount,
+		&report.CarriesCount,
+	)
+
+	return
+}
@@ -0,0 +1,142 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvinceMysql_FindAll(t *testing.T) {
+	t.Run("case 1: success - Should return every province", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(repository.FindAllProvincesQuery + repository.FindProvincesOrderBy).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "country_id"}).AddRow(1, "Texas", 1).AddRow(2, "Buenos Aires", 2))
+
+		provinces, err := repository.NewProvinceMysql(db).FindAll(0)
+
+		require.NoError(t, err)
+		require.Len(t, provinces, 2)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: success - Should filter the provinces by country", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(repository.FindAllProvincesQuery + " WHERE `country_id` = ?" + repository.FindProvincesOrderBy).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "country_id"}).AddRow(1, "Texas", 1))
+
+		provinces, err := repository.NewProvinceMysql(db).FindAll(1)
+
+		require.NoError(t, err)
+		require.Equal(t, []internal.Province{{ID: 1, Name: "Texas", CountryID: 1}}, provinces)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestProvinceMysql_FindByID(t *testing.T) {
+	t.Run("case 1: error - Should return not found for an unknown province", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(repository.FindProvinceByIDQuery).WithArgs(99).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "country_id"}))
+
+		_, err = repository.NewProvinceMysql(db).FindByID(99)
+
+		require.ErrorIs(t, err, internal.ErrProvinceNotFound)
+	})
+}
+
+func TestProvinceMysql_Save(t *testing.T) {
+	t.Run("case 1: success - Should save the province and set its ID", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(repository.InsertProvinceQuery).WithArgs("Texas", 1).
+			WillReturnResult(sqlmock.NewResult(4, 1))
+
+		p := internal.Province{Name: "Texas", CountryID: 1}
+		err = repository.NewProvinceMysql(db).Save(&p)
+
+		require.NoError(t, err)
+		require.Equal(t, 4, p.ID)
+	})
+
+	t.Run("case 2: error - Should return a conflict for a duplicated name in the country", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(repository.InsertProvinceQuery).WithArgs("texas", 1).
+			WillReturnError(&mysql.MySQLError{Number: 1062})
+
+		err = repository.NewProvinceMysql(db).Save(&internal.Province{Name: "texas", CountryID: 1})
+
+		require.ErrorIs(t, err, internal.ErrProvinceConflict)
+	})
+
+	t.Run("case 3: error - Should return not found for an unknown country", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(repository.InsertProvinceQuery).WithArgs("Texas", 99).
+			WillReturnError(&mysql.MySQLError{Number: 1452})
+
+		err = repository.NewProvinceMysql(db).Save(&internal.Province{Name: "Texas", CountryID: 99})
+
+		require.ErrorIs(t, err, internal.ErrCountryNotFound)
+	})
+}
+
+func TestProvinceMysql_Report(t *testing.T) {
+	columns := []string{"id", "name", "country_id", "country_name", "localities_count", "sellers_count", "carries_count"}
+
+	t.Run("case 1: success - Should return the report of the province", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(repository.ProvinceReportByIDQuery).WithArgs(4).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(4, "Texas", 1, "United States", 3, 3, 2))
+
+		report, err := repository.NewProvinceMysql(db).ReportByID(4)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.ProvinceReport{
+			ProvinceID:      4,
+			ProvinceName:    "Texas",
+			CountryID:       1,
+			CountryName:     "United States",
+			LocalitiesCount: 3,
+			SellersCount:    3,
+			CarriesCount:    2,
+		}, report)
+	})
+
+	t.Run("case 2: success - Should return the report of every province", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(repository.ProvinceReportQuery).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "New York", 1, "United States", 1, 1, 1).AddRow(4, "Texas", 1, "United States", 3, 3, 2))
+
+		reports, err := repository.NewProvinceMysql(db).Report()
+
+		require.NoError(t, err)
+		require.Len(t, reports, 2)
+	})
+}
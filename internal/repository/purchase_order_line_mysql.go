@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+const (
+	InsertPurchaseOrderLineQuery = "INSERT INTO `purchase_order_lines` (`purchase_order_id`, `product_id`, `quantity`, `unit_price`) VALUES (?, ?, ?, ?)"
+	FindPurchaseOrderLinesQuery  = `
+		SELECT pol.id, pol.purchase_order_id, pol.product_id, pol.quantity, pol.unit_price,
+			COALESCE(SUM(por.quantity), 0) AS reserved_quantity,
+			COALESCE(SUM(CASE WHEN por.consumed = 1 THEN por.quantity ELSE 0 END), 0) AS fulfilled_quantity
+		FROM purchase_order_lines AS pol
+		LEFT JOIN purchase_order_reservations AS por ON por.purchase_order_line_id = pol.id
+		WHERE pol.purchase_order_id = ?
+		GROUP BY pol.id, pol.purchase_order_id, pol.product_id, pol.quantity, pol.unit_price
+		ORDER BY pol.id
+	`
+	LockPendingPurchaseOrderLinesQuery = `
+		SELECT pol.id, pol.product_id,
+			pol.quantity - COALESCE((SELECT SUM(por.quantity) FROM purchase_order_reservations AS por WHERE por.purchase_order_line_id = pol.id), 0) AS pending_quantity
+		FROM purchase_order_lines AS pol
+		WHERE pol.purchase_order_id = ?
+		ORDER BY pol.id
+		FOR UPDATE
+	`
+	LockAvailableProductBatchesQuery = `
+		SELECT pb.id,
+			pb.current_quantity - COALESCE((SELECT SUM(por.quantity) FROM purchase_order_reservations AS por WHERE por.product_batch_id = pb.id AND por.consumed = 0), 0) AS available_quantity
+		FROM product_batches AS pb
+		WHERE pb.product_id = ? AND pb.due_date >= CURDATE() AND pb.status = 'released'
+		ORDER BY pb.due_date, pb.id
+		FOR UPDATE
+	`
+	InsertPurchaseOrderReservationQuery    = "INSERT INTO `purchase_order_reservations` (`purchase_order_line_id`, `product_batch_id`, `quantity`, `consumed`) VALUES (?, ?, ?, 0)"
+	LockOpenPurchaseOrderReservationsQuery = `
+		SELECT por.id, por.purchase_order_line_id, por.product_batch_id, por.quantity
+		FROM purchase_order_reservations AS por
+		INNER JOIN purchase_order_lines AS pol ON pol.id = por.purchase_order_line_id
+		WHERE pol.purchase_order_id = ? AND por.consumed = 0
+		ORDER BY por.id
+		FOR UPDATE
+	`
+	ConsumePurchaseOrderReservationQuery = "UPDATE `purchase_order_reservations` SET `consumed` = 1 WHERE `id` = ?"
+)
+
+// NewPurchaseOrderLineMysql creates a new instance of the purchase order line repository
+func NewPurchaseOrderLineMysql(db *sql.DB) *PurchaseOrderLineMysql {
+	return &PurchaseOrderLineMysql{db}
+}
+
+// PurchaseOrderLineMysql is the mysql implementation of the purchase order line repository
+type PurchaseOrderLineMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// pendingLine is a purchase order line with the quantity still to be reserved
+type pendingLine struct {
+	id              int
+	productID       int
+	pendingQuantity int
+}
+
+// availableBatch is a product batch with the quantity not held by other reservations
+type availableBatch struct {
+	id                int
+	availableQuantity int
+}
+
+// FindByPurchaseOrderID returns the lines of a purchase order with their reserved and fulfilled quantities
+func (r *PurchaseOrderLineMysql) FindByPurchaseOrderID(purchaseOrderID int) (lines []internal.PurchaseOrderLine, err error) {
+	rows, err := r.db.Query(FindPurchaseOrderLinesQuery, purchaseOrderID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l internal.PurchaseOrderLine
+
+		err = rows.Scan(&l.ID, &l.PurchaseOrderID, &l.ProductID, &l.Quantity, &l.UnitPrice, &l.ReservedQuantity, &l.FulfilledQuantity)
+		if err != nil {
+			return
+		}
+
+		lines = append(lines, l)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// Reserve holds stock for every pending line of the purchase order, taking the batches that expire first.
+// Nothing is reserved when a line cannot be fully covered.
+func (r *PurchaseOrderLineMysql) Reserve(purchaseOrderID int) (reservations []internal.PurchaseOrderReservation, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	lines, err := lockPendingLines(tx, purchaseOrderID)
+	if err != nil {
+		return
+	}
+
+	if len(lines) == 0 {
+		err = internal.ErrPurchaseOrderWithoutLines
+		return
+	}
+
+	for _, line := range lines {
+		if line.pendingQuantity <= 0 {
+			continue
+		}
+
+		var batches []availableBatch
+
+		batches, err = lockAvailableBatches(tx, line.productID)
+		if err != nil {
+			return
+		}
+
+		remaining := line.pendingQuantity
+
+		for _, batch := range batches {
+			if remaining == 0 {
+				break
+			}
+
+			if batch.availableQuantity <= 0 {
+				continue
+			}
+
+			reservation := internal.PurchaseOrderReservation{
+				PurchaseOrderLineID: line.id,
+				ProductBatchID:      batch.id,
+				Quantity:            min(remaining, batch.availableQuantity),
+			}
+
+			var result sql.Result
+
+			result, err = tx.Exec(InsertPurchaseOrderReservationQuery, reservation.PurchaseOrderLineID, reservation.ProductBatchID, reservation.Quantity)
+			if err != nil {
+				return
+			}
+
+			var id int64
+
+			id, err = result.LastInsertId()
+			if err != nil {
+				return
+			}
+
+			reservation.ID = int(id)
+			reservations = append(reservations, reservation)
+			remaining -= reservation.Quantity
+		}
+
+		if remaining > 0 {
+			err = internal.ErrPurchaseOrderInsufficientStock
+			return
+		}
+	}
+
+	return
+}
+
+// Consume picks every open reservation of the purchase order from its product batch
+func (r *PurchaseOrderLineMysql) Consume(purchaseOrderID int) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	reservations, err := lockOpenReservations(tx, purchaseOrderID)
+	if err != nil {
+		return
+	}
+
+	for _, reservation := range reservations {
+		err = applyStockMovement(tx, &internal.StockMovement{
+			ProductBatchID: reservation.ProductBatchID,
+			Type:           internal.StockMovementPick,
+			Quantity:       reservation.Quantity,
+			Reason:         fmt.Sprintf("purchase order %d", purchaseOrderID),
+			CreatedAt:      time.Now(),
+		})
+		if err != nil {
+			return
+		}
+
+		_, err = tx.Exec(ConsumePurchaseOrderReservationQuery, reservation.ID)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func lockPendingLines(tx *sql.Tx, purchaseOrderID int) (lines []pendingLine, err error) {
+	rows, err := tx.Query(LockPendingPurchaseOrderLinesQuery, purchaseOrderID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l pendingLine
+
+		err = rows.Scan(&l.id, &l.productID, &l.pendingQuantity)
+		if err != nil {
+			return
+		}
+
+		lines = append(lines, l)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+func lockAvailableBatches(tx *sql.Tx, productID int) (batches []availableBatch, err error) {
+	rows, err := tx.Query(LockAvailableProductBatchesQuery, productID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b availableBatch
+
+		err = rows.Scan(&b.id, &b.availableQuantity)
+		if err != nil {
+			return
+		}
+
+		batches = append(batches, b)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+func lockOpenReservations(tx *sql.Tx, purchaseOrderID int) (reservations []internal.PurchaseOrderReservation, err error) {
+	rows, err := tx.Query(LockOpenPurchaseOrderReservationsQuery, purchaseOrderID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r internal.PurchaseOrderReservation
+
+		err = rows.Scan(&r.ID, &r.PurchaseOrderLineID, &r.ProductBatchID, &r.Quantity)
+		if err != nil {
+			return
+		}
+
+		reservations = append(reservations, r)
+	}
+
+	err = rows.Err()
+
+	return
+}
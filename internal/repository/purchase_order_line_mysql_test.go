@@ -0,0 +1,160 @@
+package repository_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurchaseOrderLineMysql_FindByPurchaseOrderID(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	t.Run("case 1: success - Lines found", func(t *testing.T) {
+		expected := []internal.PurchaseOrderLine{
+			{ID: 1, PurchaseOrderID: 1, ProductID: 1, Quantity: 5, UnitPrice: 10, ReservedQuantity: 5, FulfilledQuantity: 0},
+		}
+
+		rows := sqlmock.NewRows([]string{"id", "purchase_order_id", "product_id", "quantity", "unit_price", "reserved_quantity", "fulfilled_quantity"}).
+			AddRow(1, 1, 1, 5, 10.0, 5, 0)
+
+		mock.ExpectQuery(repository.FindPurchaseOrderLinesQuery).WithArgs(1).WillReturnRows(rows)
+
+		rp := repository.NewPurchaseOrderLineMysql(db)
+		lines, err := rp.FindByPurchaseOrderID(1)
+
+		require.NoError(t, err)
+		require.Equal(t, expected, lines)
+	})
+
+	t.Run("case 2: error - Error executing the query", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindPurchaseOrderLinesQuery).WithArgs(1).WillReturnError(sql.ErrConnDone)
+
+		rp := repository.NewPurchaseOrderLineMysql(db)
+		_, err := rp.FindByPurchaseOrderID(1)
+
+		require.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
+
+func TestPurchaseOrderLineMysql_Reserve(t *testing.T) {
+	t.Run("case 1: success - Reserves from the batches that expire first", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockPendingPurchaseOrderLinesQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "pending_quantity"}).AddRow(1, 7, 15))
+		mock.ExpectQuery(repository.LockAvailableProductBatchesQuery).WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "available_quantity"}).AddRow(3, 10).AddRow(4, 0).AddRow(5, 20))
+		mock.ExpectExec(repository.InsertPurchaseOrderReservationQuery).WithArgs(1, 3, 10).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(repository.InsertPurchaseOrderReservationQuery).WithArgs(1, 5, 5).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		rp := repository.NewPurchaseOrderLineMysql(db)
+		reservations, err := rp.Reserve(1)
+
+		require.NoError(t, err)
+		require.Equal(t, []internal.PurchaseOrderReservation{
+			{ID: 1, PurchaseOrderLineID: 1, ProductBatchID: 3, Quantity: 10},
+			{ID: 2, PurchaseOrderLineID: 1, ProductBatchID: 5, Quantity: 5},
+		}, reservations)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: error - Not enough stock rolls back the reservations", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockPendingPurchaseOrderLinesQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "pending_quantity"}).AddRow(1, 7, 15))
+		mock.ExpectQuery(repository.LockAvailableProductBatchesQuery).WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "available_quantity"}).AddRow(3, 10))
+		mock.ExpectExec(repository.InsertPurchaseOrderReservationQuery).WithArgs(1, 3, 10).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectRollback()
+
+		rp := repository.NewPurchaseOrderLineMysql(db)
+		_, err = rp.Reserve(1)
+
+		require.ErrorIs(t, err, internal.ErrPurchaseOrderInsufficientStock)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 3: error - Purchase order without lines", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockPendingPurchaseOrderLinesQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "pending_quantity"}))
+		mock.ExpectRollback()
+
+		rp := repository.NewPurchaseOrderLineMysql(db)
+		_, err = rp.Reserve(1)
+
+		require.ErrorIs(t, err, internal.ErrPurchaseOrderWithoutLines)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPurchaseOrderLineMysql_Consume(t *testing.T) {
+	t.Run("case 1: success - Picks the reserved stock", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockOpenPurchaseOrderReservationsQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "purchase_order_line_id", "product_batch_id", "quantity"}).AddRow(1, 1, 3, 10))
+		mock.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "status", "section_id"}).AddRow(10, "released", 2))
+		mock.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(10, 500))
+		mock.ExpectExec(repository.UpdateSectionCapacityQuery).WithArgs(0, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.UpdateProductBatchQuantityQuery).WithArgs(0, 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.InsertStockMovementQuery).WithArgs(3, internal.StockMovementPick, 10, "purchase order 1", 0, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(repository.ConsumePurchaseOrderReservationQuery).WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		rp := repository.NewPurchaseOrderLineMysql(db)
+		err = rp.Consume(1)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: error - Batch quantity dropped below the reservation", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockOpenPurchaseOrderReservationsQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "purchase_order_line_id", "product_batch_id", "quantity"}).AddRow(1, 1, 3, 10))
+		mock.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "status", "section_id"}).AddRow(4, "released", 2))
+		mock.ExpectRollback()
+
+		rp := repository.NewPurchaseOrderLineMysql(db)
+		err = rp.Consume(1)
+
+		require.ErrorIs(t, err, internal.ErrStockMovementInsufficientStock)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
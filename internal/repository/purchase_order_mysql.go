@@ -2,10 +2,32 @@ package repository
 
 import (
 	"database/sql"
+	"strings"
 
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 )
 
+const (
+	FindPurchaseOrdersQuery = `
+		SELECT po.id, po.order_number, po.order_date, po.tracking_code, po.buyer_id, po.product_record_id, po.status
+		FROM purchase_orders AS po
+	`
+	FindPurchaseOrdersOrderBy             = " ORDER BY po.order_date DESC, po.id DESC"
+	UpdatePurchaseOrderStatusQuery        = "UPDATE `purchase_orders` SET `status` = ? WHERE `id` = ? AND `status` = ?"
+	InsertPurchaseOrderStatusHistoryQuery = "INSERT INTO `purchase_order_status_history` (`purchase_order_id`, `from_status`, `to_status`, `changed_by`, `changed_at`) VALUES (?, ?, ?, ?, ?)"
+	ReleasePurchaseOrderReservationsQuery = `
+		DELETE por FROM purchase_order_reservations AS por
+		INNER JOIN purchase_order_lines AS pol ON pol.id = por.purchase_order_line_id
+		WHERE pol.purchase_order_id = ? AND por.consumed = 0
+	`
+	FindPurchaseOrderStatusHistoryQuery = `
+		SELECT h.id, h.purchase_order_id, h.from_status, h.to_status, h.changed_by, h.changed_at
+		FROM purchase_order_status_history AS h
+		WHERE h.purchase_order_id = ?
+		ORDER BY h.changed_at, h.id
+	`
+)
+
 func NewPurchaseOrderMysqlRepository(db *sql.DB) *PurchaseOrderRepository {
 	return &PurchaseOrderRepository{db}
 }
@@ -14,14 +36,89 @@ type PurchaseOrderRepository struct {
 	db *sql.DB
 }
 
+// FindAll returns the purchase orders that match the filter, the most recent first
+func (r *PurchaseOrderRepository) FindAll(filter internal.PurchaseOrderFilter) (purchaseOrders []internal.PurchaseOrder, err error) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if filter.BuyerID != 0 {
+		conditions = append(conditions, "po.buyer_id = ?")
+		args = append(args, filter.BuyerID)
+	}
+
+	if filter.TrackingCode != "" {
+		conditions = append(conditions, "po.tracking_code = ?")
+		args = append(args, filter.TrackingCode)
+	}
+
+	if filter.Status != "" {
+		conditions = append(conditions, "po.status = ?")
+		args = append(args, filter.Status)
+	}
+
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "po.order_date >= ?")
+		args = append(args, filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "po.order_date <= ?")
+		args = append(args, filter.To)
+	}
+
+	query := FindPurchaseOrdersQuery
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += FindPurchaseOrdersOrderBy
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			purchaseOrder   internal.PurchaseOrder
+			productRecordID sql.NullInt64
+		)
+
+		err = rows.Scan(
+			&purchaseOrder.ID,
+			&purchaseOrder.OrderNumber,
+			&purchaseOrder.OrderDate,
+			&purchaseOrder.TrackingCode,
+			&purchaseOrder.BuyerID,
+			&productRecordID,
+			&purchaseOrder.Status)
+		if err != nil {
+			return
+		}
+
+		purchaseOrder.ProductRecordID = int(productRecordID.Int64)
+		purchaseOrders = append(purchaseOrders, purchaseOrder)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
 func (r *PurchaseOrderRepository) FindByID(id int) (purchaseOrder internal.PurchaseOrder, err error) {
 	query := `
-		SELECT po.id, po.order_number, po.order_date, po.tracking_code, po.buyer_id, po.product_record_id
+		SELECT po.id, po.order_number, po.order_date, po.tracking_code, po.buyer_id, po.product_record_id, po.status
 		FROM purchase_orders as po
 		WHERE po.id = ?
 	`
 	row := r.db.QueryRow(query, id)
 
+	// orders placed with lines have no product record
+	var productRecordID sql.NullInt64
+
 	// scanning the row
 	err = row.Scan(
 		&purchaseOrder.ID,
@@ -29,7 +126,8 @@ func (r *PurchaseOrderRepository) FindByID(id int) (purchaseOrder internal.Purch
 		&purchaseOrder.OrderDate,
 		&purchaseOrder.TrackingCode,
 		&purchaseOrder.BuyerID,
-		&purchaseOrder.ProductRecordID)
+		&productRecordID,
+		&purchaseOrder.Status)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			err = internal.ErrPurchaseOrderNotFound
@@ -38,28 +136,50 @@ func (r *PurchaseOrderRepository) FindByID(id int) (purchaseOrder internal.Purch
 		return
 	}
 
+	purchaseOrder.ProductRecordID = int(productRecordID.Int64)
+
 	return
 }
 
-// Save creates a new purchase order in the database
-func (r *PurchaseOrderRepository) Save(purchaseOrder *internal.PurchaseOrder) error {
+// Save creates a new purchase order and its lines in the database
+func (r *PurchaseOrderRepository) Save(purchaseOrder *internal.PurchaseOrder) (err error) {
 	// Checking if the purchase order already exists
 	row := r.db.QueryRow("SELECT COUNT(*) FROM purchase_orders WHERE order_number = ?", purchaseOrder.OrderNumber)
 
 	var count int
 
-	err := row.Scan(&count)
+	err = row.Scan(&count)
 	if count > 0 || err != nil {
 		return internal.ErrPurchaseOrderConflict
 	}
 
+	// The order and its lines are saved together
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
 	// Inserting the purchase order
 	query := `
-		INSERT INTO purchase_orders (order_number, order_date, tracking_code, buyer_id, product_record_id)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO purchase_orders (order_number, order_date, tracking_code, buyer_id, product_record_id, status)
+		VALUES (?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := r.db.Exec(query, (*purchaseOrder).OrderNumber, (*purchaseOrder).OrderDate, (*purchaseOrder).TrackingCode, (*purchaseOrder).BuyerID, (*purchaseOrder).ProductRecordID)
+	productRecordID := sql.NullInt64{
+		Int64: int64((*purchaseOrder).ProductRecordID),
+		Valid: (*purchaseOrder).ProductRecordID != 0,
+	}
+
+	result, err := tx.Exec(query, (*purchaseOrder).OrderNumber, (*purchaseOrder).OrderDate, (*purchaseOrder).TrackingCode, (*purchaseOrder).BuyerID, productRecordID, (*purchaseOrder).Status)
 	if err != nil {
 		return err
 	}
@@ -73,5 +193,109 @@ func (r *PurchaseOrderRepository) Save(purchaseOrder *internal.PurchaseOrder) er
 	// Set the ID of the purchase order
 	(*purchaseOrder).ID = int(id)
 
+	// Inserting the lines of the purchase order
+	for i := range (*purchaseOrder).Lines {
+		line := &(*purchaseOrder).Lines[i]
+		line.PurchaseOrderID = (*purchaseOrder).ID
+
+		result, err = tx.Exec(InsertPurchaseOrderLineQuery, line.PurchaseOrderID, line.ProductID, line.Quantity, line.UnitPrice)
+		if err != nil {
+			return err
+		}
+
+		id, err = result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		line.ID = int(id)
+	}
+
 	return nil
 }
+
+// UpdateStatus changes the status of a purchase order and records the change in a single transaction.
+// The update only applies while the order is still in h.FromStatus, so concurrent changes are rejected.
+func (r *PurchaseOrderRepository) UpdateStatus(h *internal.PurchaseOrderStatusHistory) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	err = updatePurchaseOrderStatus(tx, h)
+
+	return
+}
+
+// updatePurchaseOrderStatus moves the purchase order from h.FromStatus to h.ToStatus inside the given transaction
+// and records the change. It is shared by every repository that changes purchase orders in its own transaction.
+func updatePurchaseOrderStatus(tx *sql.Tx, h *internal.PurchaseOrderStatusHistory) error {
+	result, err := tx.Exec(UpdatePurchaseOrderStatusQuery, h.ToStatus, h.PurchaseOrderID, h.FromStatus)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return internal.ErrPurchaseOrderInvalidTransition
+	}
+
+	// A cancelled order gives back the stock it was holding
+	if h.ToStatus == internal.PurchaseOrderStatusCancelled {
+		_, err = tx.Exec(ReleasePurchaseOrderReservationsQuery, h.PurchaseOrderID)
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err = tx.Exec(InsertPurchaseOrderStatusHistoryQuery, h.PurchaseOrderID, h.FromStatus, h.ToStatus, h.ChangedBy, h.ChangedAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	h.ID = int(id)
+
+	return nil
+}
+
+// FindHistory returns the status changes of a purchase order ordered by date
+func (r *PurchaseOrderRepository) FindHistory(id int) (history []internal.PurchaseOrderStatusHistory, err error) {
+	rows, err := r.db.Query(FindPurchaseOrderStatusHistoryQuery, id)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var h internal.PurchaseOrderStatusHistory
+
+		err = rows.Scan(&h.ID, &h.PurchaseOrderID, &h.FromStatus, &h.ToStatus, &h.ChangedBy, &h.ChangedAt)
+		if err != nil {
+			return
+		}
+
+		history = append(history, h)
+	}
+
+	err = rows.Err()
+
+	return
+}
@@ -18,7 +18,7 @@ func TestPurchaseOrderMysql_FindByID(t *testing.T) {
 	defer db.Close()
 
 	query := `
-		SELECT po.id, po.order_number, po.order_date, po.tracking_code, po.buyer_id, po.product_record_id
+		SELECT po.id, po.order_number, po.order_date, po.tracking_code, po.buyer_id, po.product_record_id, po.status
 		FROM purchase_orders as po
 		WHERE po.id = ?
 	`
@@ -33,10 +33,11 @@ func TestPurchaseOrderMysql_FindByID(t *testing.T) {
 			TrackingCode:    "tracking_code",
 			BuyerID:         1,
 			ProductRecordID: 1,
+			Status:          internal.PurchaseOrderStatusCreated,
 		}
 
-		rows := sqlmock.NewRows([]string{"id", "order_number", "order_date", "tracking_code", "buyer_id", "product_record_id"}).
-			AddRow(expectedPO.ID, expectedPO.OrderNumber, expectedPO.OrderDate, expectedPO.TrackingCode, expectedPO.BuyerID, expectedPO.ProductRecordID)
+		rows := sqlmock.NewRows([]string{"id", "order_number", "order_date", "tracking_code", "buyer_id", "product_record_id", "status"}).
+			AddRow(expectedPO.ID, expectedPO.OrderNumber, expectedPO.OrderDate, expectedPO.TrackingCode, expectedPO.BuyerID, expectedPO.ProductRecordID, expectedPO.Status)
 
 		mock.ExpectQuery(query).
 			WithArgs(id).
@@ -69,8 +70,8 @@ func TestPurchaseOrderMysql_Save(t *testing.T) {
 	defer db.Close()
 
 	query := `
-		INSERT INTO purchase_orders (order_number, order_date, tracking_code, buyer_id, product_record_id)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO purchase_orders (order_number, order_date, tracking_code, buyer_id, product_record_id, status)
+		VALUES (?, ?, ?, ?, ?, ?)
 	`
 
 	po := internal.PurchaseOrder{
@@ -79,6 +80,7 @@ func TestPurchaseOrderMysql_Save(t *testing.T) {
 		TrackingCode:    "tracking_code",
 		BuyerID:         1,
 		ProductRecordID: 1,
+		Status:          internal.PurchaseOrderStatusCreated,
 	}
 
 	t.Run("case 1: success - Purchase Order saved", func(t *testing.T) {
@@ -87,9 +89,11 @@ func TestPurchaseOrderMysql_Save(t *testing.T) {
 			WithArgs(po.OrderNumber).
 			WillReturnRows(rows)
 
+		mock.ExpectBegin()
 		mock.ExpectExec(query).
-			WithArgs(po.OrderNumber, po.OrderDate, po.TrackingCode, po.BuyerID, po.ProductRecordID).
+			WithArgs(po.OrderNumber, po.OrderDate, po.TrackingCode, po.BuyerID, po.ProductRecordID, po.Status).
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
 
 		rp := repository.NewPurchaseOrderMysqlRepository(db)
 		err := rp.Save(&po)
@@ -116,9 +120,11 @@ func TestPurchaseOrderMysql_Save(t *testing.T) {
 			WithArgs(po.OrderNumber).
 			WillReturnRows(rows)
 
+		mock.ExpectBegin()
 		mock.ExpectExec(query).
-			WithArgs(po.OrderNumber, po.OrderDate, po.TrackingCode, po.BuyerID, po.ProductRecordID).
+			WithArgs(po.OrderNumber, po.OrderDate, po.TrackingCode, po.BuyerID, po.ProductRecordID, po.Status).
 			WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
 
 		rp := repository.NewPurchaseOrderMysqlRepository(db)
 		err := rp.Save(&po)
@@ -132,14 +138,209 @@ func TestPurchaseOrderMysql_Save(t *testing.T) {
 			WithArgs(po.OrderNumber).
 			WillReturnRows(rows)
 
+		mock.ExpectBegin()
 		mock.ExpectExec(query).
-			WithArgs(po.OrderNumber, po.OrderDate, po.TrackingCode, po.BuyerID, po.ProductRecordID).
+			WithArgs(po.OrderNumber, po.OrderDate, po.TrackingCode, po.BuyerID, po.ProductRecordID, po.Status).
 			WillReturnResult(sqlmock.NewErrorResult(fmt.Errorf("error")))
+		mock.ExpectRollback()
 
 		rp := repository.NewPurchaseOrderMysqlRepository(db)
 		err := rp.Save(&po)
 
 		require.Error(t, err)
 	})
+	t.Run("case 5: success - Purchase Order saved with its lines", func(t *testing.T) {
+		p := internal.PurchaseOrder{
+			OrderNumber:  "123ABD",
+			OrderDate:    po.OrderDate,
+			TrackingCode: "tracking_code",
+			BuyerID:      1,
+			Status:       internal.PurchaseOrderStatusCreated,
+			Lines: []internal.PurchaseOrderLine{
+				{ProductID: 1, Quantity: 5, UnitPrice: 10},
+				{ProductID: 2, Quantity: 3, UnitPrice: 7.5},
+			},
+		}
+
+		rows := sqlmock.NewRows([]string{"count"}).AddRow(0)
+		mock.ExpectQuery("SELECT COUNT(*) FROM purchase_orders WHERE order_number = ?").
+			WithArgs(p.OrderNumber).
+			WillReturnRows(rows)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WithArgs(p.OrderNumber, p.OrderDate, p.TrackingCode, p.BuyerID, nil, p.Status).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectExec(repository.InsertPurchaseOrderLineQuery).
+			WithArgs(2, 1, 5, 10.0).
+			WillReturnResult(sqlmock.NewResult(10, 1))
+		mock.ExpectExec(repository.InsertPurchaseOrderLineQuery).
+			WithArgs(2, 2, 3, 7.5).
+			WillReturnResult(sqlmock.NewResult(11, 1))
+		mock.ExpectCommit()
+
+		rp := repository.NewPurchaseOrderMysqlRepository(db)
+		err := rp.Save(&p)
+
+		require.NoError(t, err)
+		require.Equal(t, 2, p.ID)
+		require.Equal(t, 10, p.Lines[0].ID)
+		require.Equal(t, 11, p.Lines[1].ID)
+		require.Equal(t, 2, p.Lines[1].PurchaseOrderID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPurchaseOrderMysql_UpdateStatus(t *testing.T) {
+	changedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Status changed and recorded", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		h := internal.PurchaseOrderStatusHistory{PurchaseOrderID: 1, FromStatus: "created", ToStatus: "confirmed", ChangedBy: "jdoe", ChangedAt: changedAt}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(repository.UpdatePurchaseOrderStatusQuery).WithArgs("confirmed", 1, "created").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.InsertPurchaseOrderStatusHistoryQuery).WithArgs(1, "created", "confirmed", "jdoe", changedAt).
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectCommit()
+
+		rp := repository.NewPurchaseOrderMysqlRepository(db)
+		err = rp.UpdateStatus(&h)
+
+		require.NoError(t, err)
+		require.Equal(t, 3, h.ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: success - Cancelling releases the open reservations", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		h := internal.PurchaseOrderStatusHistory{PurchaseOrderID: 1, FromStatus: "confirmed", ToStatus: "cancelled", ChangedBy: "api", ChangedAt: changedAt}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(repository.UpdatePurchaseOrderStatusQuery).WithArgs("cancelled", 1, "confirmed").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.ReleasePurchaseOrderReservationsQuery).WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(repository.InsertPurchaseOrderStatusHistoryQuery).WithArgs(1, "confirmed", "cancelled", "api", changedAt).
+			WillReturnResult(sqlmock.NewResult(4, 1))
+		mock.ExpectCommit()
+
+		rp := repository.NewPurchaseOrderMysqlRepository(db)
+		err = rp.UpdateStatus(&h)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 3: error - Status changed concurrently", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		h := internal.PurchaseOrderStatusHistory{PurchaseOrderID: 1, FromStatus: "created", ToStatus: "confirmed", ChangedBy: "jdoe", ChangedAt: changedAt}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(repository.UpdatePurchaseOrderStatusQuery).WithArgs("confirmed", 1, "created").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		rp := repository.NewPurchaseOrderMysqlRepository(db)
+		err = rp.UpdateStatus(&h)
+
+		require.ErrorIs(t, err, internal.ErrPurchaseOrderInvalidTransition)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPurchaseOrderMysql_FindHistory(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	changedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - History found", func(t *testing.T) {
+		expected := []internal.PurchaseOrderStatusHistory{
+			{ID: 1, PurchaseOrderID: 1, FromStatus: "created", ToStatus: "confirmed", ChangedBy: "jdoe", ChangedAt: changedAt},
+		}
+
+		rows := sqlmock.NewRows([]string{"id", "purchase_order_id", "from_status", "to_status", "changed_by", "changed_at"}).
+			AddRow(1, 1, "created", "confirmed", "jdoe", changedAt)
+		mock.ExpectQuery(repository.FindPurchaseOrderStatusHistoryQuery).WithArgs(1).WillReturnRows(rows)
+
+		rp := repository.NewPurchaseOrderMysqlRepository(db)
+		history, err := rp.FindHistory(1)
+
+		require.NoError(t, err)
+		require.Equal(t, expected, history)
+	})
+
+	t.Run("case 2: error - Error executing the query", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindPurchaseOrderStatusHistoryQuery).WithArgs(1).WillReturnError(sql.ErrConnDone)
+
+		rp := repository.NewPurchaseOrderMysqlRepository(db)
+		_, err := rp.FindHistory(1)
+
+		require.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
+
+func TestPurchaseOrderMysql_FindAll(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	date := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	columns := []string{"id", "order_number", "order_date", "tracking_code", "buyer_id", "product_record_id", "status"}
+
+	t.Run("case 1: success - All Purchase Orders without filters", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(2, "PO2", date, "T2", 1, nil, "created").
+			AddRow(1, "PO1", date, "T1", 2, 1, "shipped")
+		mock.ExpectQuery(repository.FindPurchaseOrdersQuery + repository.FindPurchaseOrdersOrderBy).WillReturnRows(rows)
+
+		rp := repository.NewPurchaseOrderMysqlRepository(db)
+		purchaseOrders, err := rp.FindAll(internal.PurchaseOrderFilter{})
+
+		require.NoError(t, err)
+		require.Equal(t, []internal.PurchaseOrder{
+			{ID: 2, OrderNumber: "PO2", OrderDate: date, TrackingCode: "T2", BuyerID: 1, Status: "created"},
+			{ID: 1, OrderNumber: "PO1", OrderDate: date, TrackingCode: "T1", BuyerID: 2, ProductRecordID: 1, Status: "shipped"},
+		}, purchaseOrders)
+	})
+
+	t.Run("case 2: success - Filters are combined", func(t *testing.T) {
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+		filter := internal.PurchaseOrderFilter{BuyerID: 1, TrackingCode: "T2", Status: "created", From: from, To: to}
+
+		rows := sqlmock.NewRows(columns).AddRow(2, "PO2", date, "T2", 1, nil, "created")
+		mock.ExpectQuery(repository.FindPurchaseOrdersQuery+
+			" WHERE po.buyer_id = ? AND po.tracking_code = ? AND po.status = ? AND po.order_date >= ? AND po.order_date <= ?"+
+			repository.FindPurchaseOrdersOrderBy).
+			WithArgs(1, "T2", "created", from, to).
+			WillReturnRows(rows)
 
+		rp := repository.NewPurchaseOrderMysqlRepository(db)
+		purchaseOrders, err := rp.FindAll(filter)
+
+		require.NoError(t, err)
+		require.Len(t, purchaseOrders, 1)
+	})
+
+	t.Run("case 3: error - Error executing the query", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindPurchaseOrdersQuery + repository.FindPurchaseOrdersOrderBy).WillReturnError(sql.ErrConnDone)
+
+		rp := repository.NewPurchaseOrderMysqlRepository(db)
+		_, err := rp.FindAll(internal.PurchaseOrderFilter{})
+
+		require.ErrorIs(t, err, sql.ErrConnDone)
+	})
 }
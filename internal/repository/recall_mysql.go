@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+const (
+	FindRecallsQuery = `
+		SELECT r.id, r.product_id, r.reason, r.created_at
+		FROM recalls AS r
+		ORDER BY r.created_at DESC, r.id DESC
+	`
+	FindRecallQuery = `
+		SELECT r.id, r.product_id, r.reason, r.created_at
+		FROM recalls AS r
+		WHERE r.id = ?
+	`
+	FindRecallMatchingBatchesQuery = `
+		SELECT pb.id, pb.batch_number, pb.product_id, pb.current_quantity, pb.due_date, pb.status,
+			s.id, s.section_number, w.id, w.warehouse_code
+		FROM product_batches AS pb
+		INNER JOIN sections AS s ON s.id = pb.section_id
+		INNER JOIN warehouses AS w ON w.id = s.warehouse_id`
+	FindRecallMatchingBatchesOrderBy = " ORDER BY pb.batch_number, pb.id"
+	FindRecallBatchesQuery           = `
+		SELECT pb.id, pb.batch_number, pb.product_id, pb.current_quantity, pb.due_date, pb.status,
+			s.id, s.section_number, w.id, w.warehouse_code
+		FROM recall_batches AS rb
+		INNER JOIN product_batches AS pb ON pb.id = rb.product_batch_id
+		INNER JOIN sections AS s ON s.id = pb.section_id
+		INNER JOIN warehouses AS w ON w.id = s.warehouse_id
+		WHERE rb.recall_id = ?
+		ORDER BY w.id, s.id, pb.batch_number, pb.id
+	`
+	// FindRecallBuyersQuery finds the orders of the recalled products placed once the batch was manufactured,
+	// and the orders that were picked from a recalled batch
+	FindRecallBuyersQuery = `
+		SELECT b.id, b.card_number_id, b.first_name, b.last_name, po.id, po.order_number, po.order_date
+		FROM recall_batches AS rb
+		INNER JOIN product_batches AS pb ON pb.id = rb.product_batch_id
+		INNER JOIN product_records AS pr ON pr.product_id = pb.product_id
+		INNER JOIN purchase_orders AS po ON po.product_record_id = pr.id
+		INNER JOIN buyers AS b ON b.id = po.buyer_id
+		WHERE rb.recall_id = ? AND po.order_date >= pb.manufacturing_date AND po.status <> 'cancelled'
+		UNION
+		SELECT b.id, b.card_number_id, b.first_name, b.last_name, po.id, po.order_number, po.order_date
+		FROM recall_batches AS rb
+		INNER JOIN purchase_order_reservations AS por ON por.product_batch_id = rb.product_batch_id AND por.consumed = 1
+		INNER JOIN purchase_order_lines AS pol ON pol.id = por.purchase_order_line_id
+		INNER JOIN purchase_orders AS po ON po.id = pol.purchase_order_id
+		INNER JOIN buyers AS b ON b.id = po.buyer_id
+		WHERE rb.recall_id = ?
+		ORDER BY 1, 5
+	`
+	InsertRecallQuery      = "INSERT INTO `recalls` (`product_id`, `reason`, `created_at`) VALUES (?, ?, ?)"
+	InsertRecallBatchQuery = "INSERT INTO `recall_batches` (`recall_id`, `product_batch_id`) VALUES (?, ?)"
+)
+
+// NewRecallMysql creates a new instance of the recall repository
+func NewRecallMysql(db *sql.DB) *RecallMysql {
+	return &RecallMysql{db}
+}
+
+// RecallMysql is the mysql implementation of the recall repository
+type RecallMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// FindAll returns the recalls, the most recent first
+func (r *RecallMysql) FindAll() (recalls []internal.Recall, err error) {
+	rows, err := r.db.Query(FindRecallsQuery)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var recall internal.Recall
+
+		recall, err = scanRecall(rows)
+		if err != nil {
+			return
+		}
+
+		recalls = append(recalls, recall)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// FindByID returns the recall with the given id
+func (r *RecallMysql) FindByID(id int) (recall internal.Recall, err error) {
+	recall, err = scanRecall(r.db.QueryRow(FindRecallQuery, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		err = internal.ErrRecallNotFound
+	}
+
+	return
+}
+
+// FindMatchingBatches returns the batches of the recalled product or with the recalled batch numbers
+func (r *RecallMysql) FindMatchingBatches(recall internal.Recall) ([]internal.RecallBatch, error) {
+	var (
+		condition string
+		args      []any
+	)
+
+	if recall.ProductID != 0 {
+		condition = "pb.product_id = ?"
+		args = append(args, recall.ProductID)
+	} else {
+		placeholders := make([]string, 0, len(recall.BatchNumbers))
+		for _, batchNumber := range recall.BatchNumbers {
+			placeholders = append(placeholders, "?")
+			args = append(args, batchNumber)
+		}
+
+		condition = "pb.batch_number IN (" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	query := FindRecallMatchingBatchesQuery + " WHERE " + condition + FindRecallMatchingBatchesOrderBy
+
+	return r.findBatches(query, args...)
+}
+
+// FindBatches returns the batches affected by the recall with their current stock and location
+func (r *RecallMysql) FindBatches(recallID int) ([]internal.RecallBatch, error) {
+	return r.findBatches(FindRecallBatchesQuery, recallID)
+}
+
+// FindBuyers returns the purchase orders that may have delivered a recalled batch
+func (r *RecallMysql) FindBuyers(recallID int) (buyers []internal.RecallBuyer, err error) {
+	rows, err := r.db.Query(FindRecallBuyersQuery, recallID, recallID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b internal.RecallBuyer
+
+		err = rows.Scan(&b.BuyerID, &b.CardNumberID, &b.FirstName, &b.LastName, &b.PurchaseOrderID, &b.OrderNumber, &b.OrderDate)
+		if err != nil {
+			return
+		}
+
+		buyers = append(buyers, b)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// Save stores the recall and links it to the batches it affects
+func (r *RecallMysql) Save(recall *internal.Recall) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	productID := sql.NullInt64{Int64: int64(recall.ProductID), Valid: recall.ProductID != 0}
+
+	result, err := tx.Exec(InsertRecallQuery, productID, recall.Reason, recall.CreatedAt)
+	if err != nil {
+		return
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return
+	}
+
+	recall.ID = int(id)
+
+	for _, batch := range recall.Batches {
+		_, err = tx.Exec(InsertRecallBatchQuery, recall.ID, batch.ProductBatchID)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// findBatches runs a query that selects recall batches
+func (r *RecallMysql) findBatches(query string, args ...any) (batches []internal.RecallBatch, err error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b internal.RecallBatch
+
+		err = rows.Scan(&b.ProductBatchID, &b.BatchNumber, &b.ProductID, &b.CurrentQuantity, &b.DueDate, &b.Status,
+			&b.SectionID, &b.SectionNumber, &b.WarehouseID, &b.WarehouseCode)
+		if err != nil {
+			return
+		}
+
+		batches = append(batches, b)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// scanRecall scans a row selected with the recall columns
+func scanRecall(row interface{ Scan(dest ...any) error }) (recall internal.Recall, err error) {
+	var productID sql.NullInt64
+
+	err = row.Scan(&recall.ID, &productID, &recall.Reason, &recall.CreatedAt)
+	if err != nil {
+		return
+	}
+
+	recall.ProductID = int(productID.Int64)
+
+	return
+}
@@ -0,0 +1,166 @@
+package repository_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+var recallBatchColumns = []string{"id", "batch_number", "product_id", "current_quantity", "due_date", "status", "id", "section_number", "id", "warehouse_code"}
+
+func TestRecallMysql_FindByID(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Batch recall has no product", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindRecallQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "reason", "created_at"}).AddRow(1, nil, "listeria", createdAt))
+
+		rp := repository.NewRecallMysql(db)
+		recall, err := rp.FindByID(1)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.Recall{ID: 1, Reason: "listeria", CreatedAt: createdAt}, recall)
+	})
+
+	t.Run("case 2: error - Recall not found", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindRecallQuery).WithArgs(99).WillReturnError(sql.ErrNoRows)
+
+		rp := repository.NewRecallMysql(db)
+		_, err := rp.FindByID(99)
+
+		require.ErrorIs(t, err, internal.ErrRecallNotFound)
+	})
+}
+
+func TestRecallMysql_FindMatchingBatches(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	expected := []internal.RecallBatch{
+		{ProductBatchID: 3, BatchNumber: 10, ProductID: 1, CurrentQuantity: 50, DueDate: "2025-02-01", Status: "released", SectionID: 2, SectionNumber: 5, WarehouseID: 1, WarehouseCode: "WH1"},
+	}
+
+	rows := func() *sqlmock.Rows {
+		rows := sqlmock.NewRows(recallBatchColumns)
+		for _, b := range expected {
+			rows.AddRow(b.ProductBatchID, b.BatchNumber, b.ProductID, b.CurrentQuantity, b.DueDate, b.Status, b.SectionID, b.SectionNumber, b.WarehouseID, b.WarehouseCode)
+		}
+		return rows
+	}
+
+	t.Run("case 1: success - Batches of the product", func(t *testing.T) {
+		query := repository.FindRecallMatchingBatchesQuery + " WHERE pb.product_id = ?" + repository.FindRecallMatchingBatchesOrderBy
+		mock.ExpectQuery(query).WithArgs(1).WillReturnRows(rows())
+
+		rp := repository.NewRecallMysql(db)
+		batches, err := rp.FindMatchingBatches(internal.Recall{ProductID: 1})
+
+		require.NoError(t, err)
+		require.Equal(t, expected, batches)
+	})
+
+	t.Run("case 2: success - Batches with the batch numbers", func(t *testing.T) {
+		query := repository.FindRecallMatchingBatchesQuery + " WHERE pb.batch_number IN (?, ?)" + repository.FindRecallMatchingBatchesOrderBy
+		mock.ExpectQuery(query).WithArgs(10, 11).WillReturnRows(rows())
+
+		rp := repository.NewRecallMysql(db)
+		batches, err := rp.FindMatchingBatches(internal.Recall{BatchNumbers: []int{10, 11}})
+
+		require.NoError(t, err)
+		require.Equal(t, expected, batches)
+	})
+}
+
+func TestRecallMysql_FindBuyers(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	orderDate := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Buyers found", func(t *testing.T) {
+		expected := []internal.RecallBuyer{
+			{BuyerID: 1, CardNumberID: "C-1", FirstName: "Ana", LastName: "Silva", PurchaseOrderID: 4, OrderNumber: "PO-4", OrderDate: orderDate},
+		}
+
+		mock.ExpectQuery(repository.FindRecallBuyersQuery).WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "card_number_id", "first_name", "last_name", "id", "order_number", "order_date"}).
+				AddRow(1, "C-1", "Ana", "Silva", 4, "PO-4", orderDate))
+
+		rp := repository.NewRecallMysql(db)
+		buyers, err := rp.FindBuyers(1)
+
+		require.NoError(t, err)
+		require.Equal(t, expected, buyers)
+	})
+
+	t.Run("case 2: error - Error executing the query", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindRecallBuyersQuery).WithArgs(1, 1).WillReturnError(sql.ErrConnDone)
+
+		rp := repository.NewRecallMysql(db)
+		_, err := rp.FindBuyers(1)
+
+		require.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
+
+func TestRecallMysql_Save(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Recall and batches stored", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		recall := internal.Recall{
+			BatchNumbers: []int{10},
+			Reason:       "listeria",
+			CreatedAt:    createdAt,
+			Batches:      []internal.RecallBatch{{ProductBatchID: 3}, {ProductBatchID: 4}},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(repository.InsertRecallQuery).WithArgs(sql.NullInt64{}, "listeria", createdAt).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.ExpectExec(repository.InsertRecallBatchQuery).WithArgs(7, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.InsertRecallBatchQuery).WithArgs(7, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		rp := repository.NewRecallMysql(db)
+		err = rp.Save(&recall)
+
+		require.NoError(t, err)
+		require.Equal(t, 7, recall.ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: error - Rollback when a batch cannot be linked", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		recall := internal.Recall{ProductID: 1, Reason: "listeria", CreatedAt: createdAt, Batches: []internal.RecallBatch{{ProductBatchID: 3}}}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(repository.InsertRecallQuery).WithArgs(sql.NullInt64{Int64: 1, Valid: true}, "listeria", createdAt).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.ExpectExec(repository.InsertRecallBatchQuery).WithArgs(7, 3).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		rp := repository.NewRecallMysql(db)
+		err = rp.Save(&recall)
+
+		require.ErrorIs(t, err, sql.ErrConnDone)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -8,6 +8,18 @@ import (
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 )
 
+const (
+	LockSectionCapacityQuery   = "SELECT `current_capacity`, `maximum_capacity` FROM `sections` WHERE `id` = ? FOR UPDATE"
+	UpdateSectionCapacityQuery = "UPDATE `sections` SET `current_capacity` = ? WHERE `id` = ?"
+	FindSectionOccupancyQuery  = `
+		SELECT s.id, s.section_number, s.current_capacity, s.minimum_capacity, s.maximum_capacity, COUNT(pb.id)
+		FROM sections AS s
+		LEFT JOIN product_batches AS pb ON pb.section_id = s.id AND pb.current_quantity > 0
+		WHERE s.id = ?
+		GROUP BY s.id, s.section_number, s.current_capacity, s.minimum_capacity, s.maximum_capacity
+	`
+)
+
 func NewSectionMysql(db *sql.DB) *SectionMysql {
 	return &SectionMysql{db}
 }
@@ -210,11 +222,10 @@ func (r *SectionMysql) Save(section *internal.Section) error {
 
 func (r *SectionMysql) Update(section *internal.Section) error {
 	_, err := r.db.Exec(
-		"UPDATE sections SET section_number = ?, current_temperature = ?, minimum_temperature = ?, current_capacity = ?, minimum_capacity = ?, maximum_capacity = ?, warehouse_id = ?, product_type_id = ? WHERE id = ?",
+		"UPDATE sections SET section_number = ?, current_temperature = ?, minimum_temperature = ?, minimum_capacity = ?, maximum_capacity = ?, warehouse_id = ?, product_type_id = ? WHERE id = ?",
 		section.SectionNumber,
 		section.CurrentTemperature,
 		section.MinimumTemperature,
-		section.CurrentCapacity,
 		section.MinimumCapacity,
 		section.MaximumCapacity,
 		section.WarehouseID,
@@ -241,3 +252,49 @@ func (r *SectionMysql) Delete(id int) error {
 	_, err := r.db.Exec("DELETE FROM sections WHERE id = ?", id)
 	return err
 }
+
+// FindOccupancy returns the capacities of the section and the number of batches with stock stored in it
+func (r *SectionMysql) FindOccupancy(id int) (occupancy internal.SectionOccupancy, err error) {
+	err = r.db.QueryRow(FindSectionOccupancyQuery, id).Scan(
+		&occupancy.SectionID,
+		&occupancy.SectionNumber,
+		&occupancy.CurrentCapacity,
+		&occupancy.MinimumCapacity,
+		&occupancy.MaximumCapacity,
+		&occupancy.BatchesCount,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = internal.ErrSectionNotFound
+	}
+
+	return
+}
+
+// adjustSectionCapacity locks the section row and adds delta units to its current capacity.
+// It is shared by every repository that stores or removes stock inside its own transaction.
+// Additions that go over the maximum capacity are rejected, removals never take it below zero.
+func adjustSectionCapacity(tx *sql.Tx, sectionID int, delta int) error {
+	if delta == 0 {
+		return nil
+	}
+
+	var currentCapacity, maximumCapacity int
+
+	err := tx.QueryRow(LockSectionCapacityQuery, sectionID).Scan(&currentCapacity, &maximumCapacity)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return internal.ErrSectionNotFound
+		}
+
+		return err
+	}
+
+	capacityAfter := currentCapacity + delta
+	if delta > 0 && capacityAfter > maximumCapacity {
+		return internal.NewSectionCapacityExceededError(sectionID, max(maximumCapacity-currentCapacity, 0), delta)
+	}
+
+	_, err = tx.Exec(UpdateSectionCapacityQuery, max(capacityAfter, 0), sectionID)
+
+	return err
+}
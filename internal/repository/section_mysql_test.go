@@ -164,7 +164,7 @@ func (s *MysqlSectionTestSuite) TestRepository_UpdateSectionUnitTest() {
 
 		s.Setup()
 		s.mock.ExpectExec("UPDATE sections SET .* WHERE id = ?").
-			WithArgs(section.SectionNumber, section.CurrentTemperature, section.MinimumTemperature, section.CurrentCapacity, section.MinimumCapacity, section.MaximumCapacity, section.WarehouseID, section.ProductTypeID, section.ID).
+			WithArgs(section.SectionNumber, section.CurrentTemperature, section.MinimumTemperature, section.MinimumCapacity, section.MaximumCapacity, section.WarehouseID, section.ProductTypeID, section.ID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		err := s.rp.Update(&section)
@@ -187,7 +187,7 @@ func (s *MysqlSectionTestSuite) TestRepository_UpdateSectionUnitTest() {
 
 		s.Setup()
 		s.mock.ExpectExec("UPDATE sections SET .* WHERE id = ?").
-			WithArgs(section.SectionNumber, section.CurrentTemperature, section.MinimumTemperature, section.CurrentCapacity, section.MinimumCapacity, section.MaximumCapacity, section.WarehouseID, section.ProductTypeID, section.ID).
+			WithArgs(section.SectionNumber, section.CurrentTemperature, section.MinimumTemperature, section.MinimumCapacity, section.MaximumCapacity, section.WarehouseID, section.ProductTypeID, section.ID).
 			WillReturnError(&mysql.MySQLError{Number: 1064})
 
 		err := s.rp.Update(&section)
@@ -211,7 +211,7 @@ func (s *MysqlSectionTestSuite) TestRepository_UpdateSectionUnitTest() {
 
 		s.Setup()
 		s.mock.ExpectExec("UPDATE sections SET .* WHERE id = ?").
-			WithArgs(section.SectionNumber, section.CurrentTemperature, section.MinimumTemperature, section.CurrentCapacity, section.MinimumCapacity, section.MaximumCapacity, section.WarehouseID, section.ProductTypeID, section.ID).
+			WithArgs(section.SectionNumber, section.CurrentTemperature, section.MinimumTemperature, section.MinimumCapacity, section.MaximumCapacity, section.WarehouseID, section.ProductTypeID, section.ID).
 			WillReturnError(&mysql.MySQLError{Number: 1062})
 
 		err := s.rp.Update(&section)
@@ -337,3 +337,30 @@ func (s *MysqlSectionTestSuite) TestRepository_DeleteSectionUnitTest() {
 func TestRepositoryMysqlSectionTestSuite(t *testing.T) {
 	suite.Run(t, new(MysqlSectionTestSuite))
 }
+
+func TestSectionMysql_FindOccupancy(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	t.Run("case 1: success - Occupancy found", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindSectionOccupancyQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "section_number", "current_capacity", "minimum_capacity", "maximum_capacity", "batches"}).
+				AddRow(1, 10, 150, 20, 200, 3))
+
+		rp := repository.NewSectionMysql(db)
+		occupancy, err := rp.FindOccupancy(1)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.SectionOccupancy{SectionID: 1, SectionNumber: 10, CurrentCapacity: 150, MinimumCapacity: 20, MaximumCapacity: 200, BatchesCount: 3}, occupancy)
+	})
+
+	t.Run("case 2: error - Section not found", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindSectionOccupancyQuery).WithArgs(99).WillReturnError(sql.ErrNoRows)
+
+		rp := repository.NewSectionMysql(db)
+		_, err := rp.FindOccupancy(99)
+
+		require.ErrorIs(t, err, internal.ErrSectionNotFound)
+	})
+}
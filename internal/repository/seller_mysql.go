@@ -3,12 +3,64 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 
 	"github.com/go-sql-driver/mysql"
 )
 
+const (
+	FindSellerProductsQuery = "SELECT `id`, `product_code`, `description`, `height`, `length`, `net_weight`, `expiration_rate`, " +
+		"`recommended_freezing_temperature`, `width`, `freezing_rate`, `product_type_id`, `seller_id`, `status` FROM `products` WHERE `seller_id` = ? ORDER BY `id`"
+	// sellerReportQuery aggregates every metric in its own subquery so the joins of one metric do not multiply the rows of another
+	sellerReportQuery = `
+	SELECT s.id, s.cid, s.company_name,
+		(SELECT COUNT(*) FROM products AS p WHERE p.seller_id = s.id) AS products_count,
+		(SELECT COALESCE(SUM(pb.current_quantity), 0) FROM product_batches AS pb INNER JOIN products AS p ON p.id = pb.product_id
+			WHERE p.seller_id = s.id AND pb.status = 'released' AND pb.due_date >= CURDATE()) AS live_quantity,
+		(SELECT COALESCE(SUM(pol.quantity), 0) FROM purchase_order_lines AS pol
+			INNER JOIN purchase_orders AS po ON po.id = pol.purchase_order_id INNER JOIN products AS p ON p.id = pol.product_id
+			WHERE p.seller_id = s.id AND po.status IN ('shipped', 'delivered')) AS units_sold,
+		(SELECT COALESCE(SUM(pol.quantity * pol.unit_price), 0) FROM purchase_order_lines AS pol
+			INNER JOIN purchase_orders AS po ON po.id = pol.purchase_order_id INNER JOIN products AS p ON p.id = pol.product_id
+			WHERE p.seller_id = s.id AND po.status IN ('shipped', 'delivered')) AS revenue,
+		(SELECT COALESCE(SUM(pb.current_quantity), 0) FROM product_batches AS pb INNER JOIN products AS p ON p.id = pb.product_id
+			WHERE p.seller_id = s.id AND pb.due_date < CURDATE()) AS expired_quantity,
+		(SELECT COALESCE(SUM(sm.quantity), 0) FROM stock_movements AS sm
+			INNER JOIN product_batches AS pb ON pb.id = sm.product_batch_id INNER JOIN products AS p ON p.id = pb.product_id
+			WHERE p.seller_id = s.id AND sm.type = 'write_off') AS written_off_quantity
+	FROM sellers AS s`
+	SellerReportByIDQuery = sellerReportQuery + " WHERE s.id = ?"
+	// SellerReportPageQuery is completed with the sort column and direction, both taken from sellerReportSortColumns
+	SellerReportPageQuery = sellerReportQuery + " ORDER BY %s %s, s.id LIMIT ? OFFSET ?"
+	CountSellersQuery     = "SELECT COUNT(*) FROM `sellers`"
+	// LockSellerProductsQuery locks the products of the seller so no history can be added to them until the delete ends
+	LockSellerProductsQuery = "SELECT COUNT(*) FROM `products` WHERE `seller_id` = ? FOR UPDATE"
+	// SellerHasHistoryQuery looks for a product of the seller with records, batches, order lines or recalls
+	SellerHasHistoryQuery = `
+	SELECT EXISTS (
+		SELECT 1 FROM products AS p WHERE p.seller_id = ? AND (
+			EXISTS (SELECT 1 FROM product_records AS pr WHERE pr.product_id = p.id)
+			OR EXISTS (SELECT 1 FROM product_batches AS pb WHERE pb.product_id = p.id)
+			OR EXISTS (SELECT 1 FROM purchase_order_lines AS pol WHERE pol.product_id = p.id)
+			OR EXISTS (SELECT 1 FROM recalls AS rc WHERE rc.product_id = p.id)))`
+	DeleteSellerProductsQuery = "DELETE FROM `products` WHERE `seller_id` = ?"
+	DeleteSellerQuery         = "DELETE FROM `sellers` WHERE `id` = ?"
+)
+
+// sellerReportSortColumns maps the sort fields of the sellers report to their column
+var sellerReportSortColumns = map[string]string{
+	"id":                   "s.id",
+	"company_name":         "s.company_name",
+	"products_count":       "products_count",
+	"live_quantity":        "live_quantity",
+	"units_sold":           "units_sold",
+	"revenue":              "revenue",
+	"expired_quantity":     "expired_quantity",
+	"written_off_quantity": "written_off_quantity",
+}
+
 // NewSellerMysql creates a new instance of the seller repository
 func NewSellerMysql(db *sql.DB) *SellerMysql {
 	return &SellerMysql{db}
@@ -23,7 +75,7 @@ type SellerMysql struct {
 // FindAll returns all sellers from the database
 func (r *SellerMysql) FindAll() (sellers []internal.Seller, err error) {
 	// execute the query
-	rows, err := r.db.Query("SELECT `s.id`, `s.cid`, `s.company_name`, `s.address`, `s.telephone` FROM `sellers` AS `s`")
+	rows, err := r.db.Query("SELECT `s.id`, `s.cid`, `s.company_name`, `s.address`, `s.telephone`, `s.status` FROM `sellers` AS `s`")
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = internal.ErrSellerNotFound
@@ -37,7 +89,7 @@ func (r *SellerMysql) FindAll() (sellers []internal.Seller, err error) {
 		// create a new seller
 		var seller internal.Seller
 
-		err = rows.Scan(&seller.ID, &seller.CID, &seller.CompanyName, &seller.Address, &seller.Telephone)
+		err = rows.Scan(&seller.ID, &seller.CID, &seller.CompanyName, &seller.Address, &seller.Telephone, &seller.Status)
 		if err != nil {
 			return sellers, err
 		}
@@ -52,10 +104,10 @@ func (r *SellerMysql) FindAll() (sellers []internal.Seller, err error) {
 // FindByID returns a seller from the database by its id
 func (r *SellerMysql) FindByID(id int) (seller internal.Seller, err error) {
 	// execute the query
-	row := r.db.QueryRow("SELECT `id`, `cid`, `company_name`, `address`, `telephone` FROM `sellers`  WHERE `id` = ?", id)
+	row := r.db.QueryRow("SELECT `id`, `cid`, `company_name`, `address`, `telephone`, `locality_id`, `status` FROM `sellers`  WHERE `id` = ?", id)
 
 	// scan the row into the seller
-	err = row.Scan(&seller.ID, &seller.CID, &seller.CompanyName, &seller.Address, &seller.Telephone)
+	err = row.Scan(&seller.ID, &seller.CID, &seller.CompanyName, &seller.Address, &seller.Telephone, &seller.Locality, &seller.Status)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = internal.ErrSellerNotFound
@@ -68,10 +120,10 @@ func (r *SellerMysql) FindByID(id int) (seller internal.Seller, err error) {
 // FindByCID returns a seller from the database by its cid
 func (r *SellerMysql) FindByCID(cid int) (seller internal.Seller, err error) {
 	// execute the query
-	row := r.db.QueryRow("SELECT `id`, `cid`, `company_name`, `address`, `telephone` FROM `sellers` WHERE `cid` = ?", cid)
+	row := r.db.QueryRow("SELECT `id`, `cid`, `company_name`, `address`, `telephone`, `locality_id`, `status` FROM `sellers` WHERE `cid` = ?", cid)
 
 	// scan the row into the seller
-	err = row.Scan(&seller.ID, &seller.CID, &seller.CompanyName, &seller.Address, &seller.Telephone)
+	err = row.Scan(&seller.ID, &seller.CID, &seller.CompanyName, &seller.Address, &seller.Telephone, &seller.Locality, &seller.Status)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = internal.ErrSellerNotFound
@@ -85,8 +137,8 @@ func (r *SellerMysql) FindByCID(cid int) (seller internal.Seller, err error) {
 func (r *SellerMysql) Save(seller *internal.Seller) (err error) {
 	// execute the query
 	result, err := r.db.Exec(
-		"INSERT INTO `sellers` (`cid`, `company_name`, `address`, `telephone`, `locality_id`) VALUES (?, ?, ?, ?, ?)",
-		(*seller).CID, (*seller).CompanyName, (*seller).Address, (*seller).Telephone, (*seller).Locality,
+		"INSERT INTO `sellers` (`cid`, `company_name`, `address`, `telephone`, `locality_id`, `status`) VALUES (?, ?, ?, ?, ?, ?)",
+		(*seller).CID, (*seller).CompanyName, (*seller).Address, (*seller).Telephone, (*seller).Locality, (*seller).Status,
 	)
 	if err != nil {
 		var mysqlErr *mysql.MySQLError
@@ -116,8 +168,8 @@ func (r *SellerMysql) Save(seller *internal.Seller) (err error) {
 func (r *SellerMysql) Update(seller *internal.Seller) (err error) {
 	// execute the query
 	_, err = r.db.Exec(
-		"UPDATE `sellers` SET `cid` = ?, `company_name` = ?, `address` = ?, `telephone` = ?, `locality_id` = ? WHERE `id` = ?",
-		(*seller).CID, (*seller).CompanyName, (*seller).Address, (*seller).Telephone, (*seller).Locality, (*seller).ID,
+		"UPDATE `sellers` SET `cid` = ?, `company_name` = ?, `address` = ?, `telephone` = ?, `locality_id` = ?, `status` = ? WHERE `id` = ?",
+		(*seller).CID, (*seller).CompanyName, (*seller).Address, (*seller).Telephone, (*seller).Locality, (*seller).Status, (*seller).ID,
 	)
 	if err != nil {
 		var mysqlErr *mysql.MySQLError
@@ -134,13 +186,130 @@ func (r *SellerMysql) Update(seller *internal.Seller) (err error) {
 	return
 }
 
-// Delete deletes a seller from the database
+// Delete deletes a seller and its products from the database in the same transaction.
+// The records, batches, order lines and recalls of the products cascade with them, so the products are
+// locked and the delete is refused when any of them has history.
 func (r *SellerMysql) Delete(id int) (err error) {
-	// execute the query
-	_, err = r.db.Exec("DELETE FROM `sellers` WHERE `id` = ?", id)
+	tx, err := r.db.Begin()
 	if err != nil {
 		return
 	}
 
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	var products int
+	err = tx.QueryRow(LockSellerProductsQuery, id).Scan(&products)
+	if err != nil {
+		return
+	}
+
+	var hasHistory bool
+	err = tx.QueryRow(SellerHasHistoryQuery, id).Scan(&hasHistory)
+	if err != nil {
+		return
+	}
+
+	if hasHistory {
+		err = internal.ErrSellerHasHistory
+		return
+	}
+
+	_, err = tx.Exec(DeleteSellerProductsQuery, id)
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec(DeleteSellerQuery, id)
+
+	return
+}
+
+// FindProducts returns the products of a seller from the database
+func (r *SellerMysql) FindProducts(id int) (products []internal.Product, err error) {
+	rows, err := r.db.Query(FindSellerProductsQuery, id)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var product internal.Product
+
+		err = rows.Scan(&product.ID, &product.ProductCode, &product.Description, &product.Height, &product.Length, &product.NetWeight,
+			&product.ExpirationRate, &product.RecommendedFreezingTemperature, &product.Width, &product.FreezingRate, &product.ProductTypeID, &product.SellerID, &product.Status)
+		if err != nil {
+			return
+		}
+
+		products = append(products, product)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// Report returns a page of the report of every seller and the number of sellers
+func (r *SellerMysql) Report(query internal.SellerReportQuery) (reports []internal.SellerReport, total int, err error) {
+	column, ok := sellerReportSortColumns[query.SortBy]
+	if !ok {
+		err = fmt.Errorf("%w: unknown sort field %q", internal.ErrSellerReportBadRequest, query.SortBy)
+		return
+	}
+
+	direction := "ASC"
+	if query.Order == "desc" {
+		direction = "DESC"
+	}
+
+	err = r.db.QueryRow(CountSellersQuery).Scan(&total)
+	if err != nil {
+		return
+	}
+
+	rows, err := r.db.Query(fmt.Sprintf(SellerReportPageQuery, column, direction), query.Limit, query.Offset)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var report internal.SellerReport
+
+		report, err = scanSellerReport(rows)
+		if err != nil {
+			return
+		}
+
+		reports = append(reports, report)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// ReportByID returns the report of a seller from the database by its id
+func (r *SellerMysql) ReportByID(id int) (report internal.SellerReport, err error) {
+	report, err = scanSellerReport(r.db.QueryRow(SellerReportByIDQuery, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		err = internal.ErrSellerNotFound
+	}
+
+	return
+}
+
+// scanSellerReport scans a row of the seller report queries
+func scanSellerReport(row interface{ Scan(dest ...any) error }) (report internal.SellerReport, err error) {
+	err = row.Scan(&report.SellerID, &report.CID, &report.CompanyName, &report.ProductsCount, &report.LiveQuantity,
+		&report.UnitsSold, &report.Revenue, &report.ExpiredQuantity, &report.WrittenOffQuantity)
+
 	return
 }
@@ -3,7 +3,9 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/go-sql-driver/mysql"
+	"strings"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -17,10 +19,10 @@ func TestSellerMysql_FindAll(t *testing.T) {
 	defer db.Close()
 
 	t.Run("Success", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone"}).
-			AddRow(1, 123, "Company 1", "Address 1", "1234567890").
-			AddRow(2, 456, "Company 2", "Address 2", "9876543210")
-		mock.ExpectQuery("SELECT `s.id`, `s.cid`, `s.company_name`, `s.address`, `s.telephone` FROM `sellers` AS `s`").WillReturnRows(rows)
+		rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone", "status"}).
+			AddRow(1, 123, "Company 1", "Address 1", "1234567890", "active").
+			AddRow(2, 456, "Company 2", "Address 2", "9876543210", "suspended")
+		mock.ExpectQuery("SELECT `s.id`, `s.cid`, `s.company_name`, `s.address`, `s.telephone`, `s.status` FROM `sellers` AS `s`").WillReturnRows(rows)
 
 		r := NewSellerMysql(db)
 		sellers, err := r.FindAll()
@@ -32,7 +34,7 @@ func TestSellerMysql_FindAll(t *testing.T) {
 	})
 
 	t.Run("No sellers found", func(t *testing.T) {
-		mock.ExpectQuery("SELECT `s.id`, `s.cid`, `s.company_name`, `s.address`, `s.telephone` FROM `sellers` AS `s`").WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery("SELECT `s.id`, `s.cid`, `s.company_name`, `s.address`, `s.telephone`, `s.status` FROM `sellers` AS `s`").WillReturnError(sql.ErrNoRows)
 
 		r := NewSellerMysql(db)
 		sellers, err := r.FindAll()
@@ -42,7 +44,7 @@ func TestSellerMysql_FindAll(t *testing.T) {
 	})
 
 	t.Run("Database error", func(t *testing.T) {
-		mock.ExpectQuery("SELECT `s.id`, `s.cid`, `s.company_name`, `s.address`, `s.telephone` FROM `sellers` AS `s`").WillReturnError(errors.New("database error"))
+		mock.ExpectQuery("SELECT `s.id`, `s.cid`, `s.company_name`, `s.address`, `s.telephone`, `s.status` FROM `sellers` AS `s`").WillReturnError(errors.New("database error"))
 
 		r := NewSellerMysql(db)
 		sellers, err := r.FindAll()
@@ -52,9 +54,9 @@ func TestSellerMysql_FindAll(t *testing.T) {
 	})
 
 	t.Run("Row Scan error", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone"}).
-			AddRow(1, "Company 1", "Address 1", 1342, "1234567890")
-		mock.ExpectQuery("SELECT `s.id`, `s.cid`, `s.company_name`, `s.address`, `s.telephone` FROM `sellers` AS `s`").WillReturnRows(rows)
+		rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone", "status"}).
+			AddRow(1, "Company 1", "Address 1", 1342, "1234567890", "active")
+		mock.ExpectQuery("SELECT `s.id`, `s.cid`, `s.company_name`, `s.address`, `s.telephone`, `s.status` FROM `sellers` AS `s`").WillReturnRows(rows)
 
 		r := NewSellerMysql(db)
 		sellers, err := r.FindAll()
@@ -71,9 +73,9 @@ func TestSellerMysql_FindByID(t *testing.T) {
 	defer db.Close()
 
 	t.Run("Success", func(t *testing.T) {
-		row := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone"}).
-			AddRow(1, 123, "Company 1", "Address 1", "1234567890")
-		mock.ExpectQuery("SELECT `id`, `cid`, `company_name`, `address`, `telephone` FROM `sellers`  WHERE `id` = ?").WithArgs(1).WillReturnRows(row)
+		row := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone", "locality_id", "status"}).
+			AddRow(1, 123, "Company 1", "Address 1", "1234567890", 1, "active")
+		mock.ExpectQuery("SELECT `id`, `cid`, `company_name`, `address`, `telephone`, `locality_id`, `status` FROM `sellers`  WHERE `id` = ?").WithArgs(1).WillReturnRows(row)
 
 		r := NewSellerMysql(db)
 		seller, err := r.FindByID(1)
@@ -83,7 +85,7 @@ func TestSellerMysql_FindByID(t *testing.T) {
 	})
 
 	t.Run("Seller not found", func(t *testing.T) {
-		mock.ExpectQuery("SELECT `id`, `cid`, `company_name`, `address`, `telephone` FROM `sellers`  WHERE `id` = ?").WithArgs(1).WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery("SELECT `id`, `cid`, `company_name`, `address`, `telephone`, `locality_id`, `status` FROM `sellers`  WHERE `id` = ?").WithArgs(1).WillReturnError(sql.ErrNoRows)
 
 		r := NewSellerMysql(db)
 		seller, err := r.FindByID(1)
@@ -93,7 +95,7 @@ func TestSellerMysql_FindByID(t *testing.T) {
 	})
 
 	t.Run("Database error", func(t *testing.T) {
-		mock.ExpectQuery("SELECT `id`, `cid`, `company_name`, `address`, `telephone` FROM `sellers`  WHERE `id` = ?").WithArgs(1).WillReturnError(errors.New("database error"))
+		mock.ExpectQuery("SELECT `id`, `cid`, `company_name`, `address`, `telephone`, `locality_id`, `status` FROM `sellers`  WHERE `id` = ?").WithArgs(1).WillReturnError(errors.New("database error"))
 
 		r := NewSellerMysql(db)
 		seller, err := r.FindByID(1)
@@ -109,9 +111,9 @@ func TestSellerMysql_FindByCID(t *testing.T) {
 	defer db.Close()
 
 	t.Run("Success", func(t *testing.T) {
-		row := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone"}).
-			AddRow(1, 123, "Company 1", "Address 1", "1234567890")
-		mock.ExpectQuery("SELECT `id`, `cid`, `company_name`, `address`, `telephone` FROM `sellers` WHERE `cid` = ?").WithArgs(123).WillReturnRows(row)
+		row := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone", "locality_id", "status"}).
+			AddRow(1, 123, "Company 1", "Address 1", "1234567890", 1, "active")
+		mock.ExpectQuery("SELECT `id`, `cid`, `company_name`, `address`, `telephone`, `locality_id`, `status` FROM `sellers` WHERE `cid` = ?").WithArgs(123).WillReturnRows(row)
 
 		r := NewSellerMysql(db)
 		seller, err := r.FindByCID(123)
@@ -121,7 +123,7 @@ func TestSellerMysql_FindByCID(t *testing.T) {
 	})
 
 	t.Run("Seller not found", func(t *testing.T) {
-		mock.ExpectQuery("SELECT `id`, `cid`, `company_name`, `address`, `telephone` FROM `sellers` WHERE `cid` = ?").WithArgs(123).WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery("SELECT `id`, `cid`, `company_name`, `address`, `telephone`, `locality_id`, `status` FROM `sellers` WHERE `cid` = ?").WithArgs(123).WillReturnError(sql.ErrNoRows)
 
 		r := NewSellerMysql(db)
 		seller, err := r.FindByCID(123)
@@ -131,7 +133,7 @@ func TestSellerMysql_FindByCID(t *testing.T) {
 	})
 
 	t.Run("Database error", func(t *testing.T) {
-		mock.ExpectQuery("SELECT `id`, `cid`, `company_name`, `address`, `telephone` FROM `sellers` WHERE `cid` = ?").WithArgs(123).WillReturnError(errors.New("database error"))
+		mock.ExpectQuery("SELECT `id`, `cid`, `company_name`, `address`, `telephone`, `locality_id`, `status` FROM `sellers` WHERE `cid` = ?").WithArgs(123).WillReturnError(errors.New("database error"))
 
 		r := NewSellerMysql(db)
 		seller, err := r.FindByCID(123)
@@ -153,10 +155,11 @@ func TestSellerMysql_Save(t *testing.T) {
 			Address:     "Address 1",
 			Telephone:   "1234567890",
 			Locality:    1,
+			Status:      internal.SellerStatusActive,
 		}
 
-		mock.ExpectExec("INSERT INTO `sellers` (`cid`, `company_name`, `address`, `telephone`, `locality_id`) VALUES (?, ?, ?, ?, ?)").
-			WithArgs(seller.CID, seller.CompanyName, seller.Address, seller.Telephone, seller.Locality).
+		mock.ExpectExec("INSERT INTO `sellers` (`cid`, `company_name`, `address`, `telephone`, `locality_id`, `status`) VALUES (?, ?, ?, ?, ?, ?)").
+			WithArgs(seller.CID, seller.CompanyName, seller.Address, seller.Telephone, seller.Locality, seller.Status).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		r := NewSellerMysql(db)
@@ -173,10 +176,11 @@ func TestSellerMysql_Save(t *testing.T) {
 			Address:     "Address 1",
 			Telephone:   "1234567890",
 			Locality:    1,
+			Status:      internal.SellerStatusActive,
 		}
 
-		mock.ExpectExec("INSERT INTO `sellers` (`cid`, `company_name`, `address`, `telephone`, `locality_id`) VALUES (?, ?, ?, ?, ?)").
-			WithArgs(seller.CID, seller.CompanyName, seller.Address, seller.Telephone, seller.Locality).
+		mock.ExpectExec("INSERT INTO `sellers` (`cid`, `company_name`, `address`, `telephone`, `locality_id`, `status`) VALUES (?, ?, ?, ?, ?, ?)").
+			WithArgs(seller.CID, seller.CompanyName, seller.Address, seller.Telephone, seller.Locality, seller.Status).
 			WillReturnError(&mysql.MySQLError{Number: 1062})
 
 		r := NewSellerMysql(db)
@@ -192,10 +196,11 @@ func TestSellerMysql_Save(t *testing.T) {
 			Address:     "Address 1",
 			Telephone:   "1234567890",
 			Locality:    1,
+			Status:      internal.SellerStatusActive,
 		}
 
-		mock.ExpectExec("INSERT INTO `sellers` (`cid`, `company_name`, `address`, `telephone`, `locality_id`) VALUES (?, ?, ?, ?, ?)").
-			WithArgs(seller.CID, seller.CompanyName, seller.Address, seller.Telephone, seller.Locality).
+		mock.ExpectExec("INSERT INTO `sellers` (`cid`, `company_name`, `address`, `telephone`, `locality_id`, `status`) VALUES (?, ?, ?, ?, ?, ?)").
+			WithArgs(seller.CID, seller.CompanyName, seller.Address, seller.Telephone, seller.Locality, seller.Status).
 			WillReturnError(errors.New("database error"))
 
 		r := NewSellerMysql(db)
@@ -218,10 +223,11 @@ func TestSellerMysql_Update(t *testing.T) {
 			Address:     "Address 1",
 			Telephone:   "1234567890",
 			Locality:    1,
+			Status:      internal.SellerStatusActive,
 		}
 
-		mock.ExpectExec("UPDATE `sellers` SET `cid` = ?, `company_name` = ?, `address` = ?, `telephone` = ?, `locality_id` = ? WHERE `id` = ?").
-			WithArgs(seller.CID, seller.CompanyName, seller.Address, seller.Telephone, seller.Locality, seller.ID).
+		mock.ExpectExec("UPDATE `sellers` SET `cid` = ?, `company_name` = ?, `address` = ?, `telephone` = ?, `locality_id` = ?, `status` = ? WHERE `id` = ?").
+			WithArgs(seller.CID, seller.CompanyName, seller.Address, seller.Telephone, seller.Locality, seller.Status, seller.ID).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		r := NewSellerMysql(db)
@@ -238,10 +244,11 @@ func TestSellerMysql_Update(t *testing.T) {
 			Address:     "Address 1",
 			Telephone:   "1234567890",
 			Locality:    1,
+			Status:      internal.SellerStatusActive,
 		}
 
-		mock.ExpectExec("UPDATE `sellers` SET `cid` = ?, `company_name` = ?, `address` = ?, `telephone` = ?, `locality_id` = ? WHERE `id` = ?").
-			WithArgs(seller.CID, seller.CompanyName, seller.Address, seller.Telephone, seller.Locality, seller.ID).
+		mock.ExpectExec("UPDATE `sellers` SET `cid` = ?, `company_name` = ?, `address` = ?, `telephone` = ?, `locality_id` = ?, `status` = ? WHERE `id` = ?").
+			WithArgs(seller.CID, seller.CompanyName, seller.Address, seller.Telephone, seller.Locality, seller.Status, seller.ID).
 			WillReturnError(&mysql.MySQLError{Number: 1000})
 
 		r := NewSellerMysql(db)
@@ -258,10 +265,11 @@ func TestSellerMysql_Update(t *testing.T) {
 			Address:     "Address 1",
 			Telephone:   "1234567890",
 			Locality:    1,
+			Status:      internal.SellerStatusActive,
 		}
 
-		mock.ExpectExec("UPDATE `sellers` SET `cid` = ?, `company_name` = ?, `address` = ?, `telephone` = ?, `locality_id` = ? WHERE `id` = ?").
-			WithArgs(seller.CID, seller.CompanyName, seller.Address, seller.Telephone, seller.Locality, seller.ID).
+		mock.ExpectExec("UPDATE `sellers` SET `cid` = ?, `company_name` = ?, `address` = ?, `telephone` = ?, `locality_id` = ?, `status` = ? WHERE `id` = ?").
+			WithArgs(seller.CID, seller.CompanyName, seller.Address, seller.Telephone, seller.Locality, seller.Status, seller.ID).
 			WillReturnError(&mysql.MySQLError{Number: 1062})
 
 		r := NewSellerMysql(db)
@@ -278,10 +286,11 @@ func TestSellerMysql_Update(t *testing.T) {
 			Address:     "Address 1",
 			Telephone:   "1234567890",
 			Locality:    1,
+			Status:      internal.SellerStatusActive,
 		}
 
-		mock.ExpectExec("UPDATE `sellers` SET `cid` = ?, `company_name` = ?, `address` = ?, `telephone` = ?, `locality_id` = ? WHERE `id` = ?").
-			WithArgs(seller.CID, seller.CompanyName, seller.Address, seller.Telephone, seller.Locality, seller.ID).
+		mock.ExpectExec("UPDATE `sellers` SET `cid` = ?, `company_name` = ?, `address` = ?, `telephone` = ?, `locality_id` = ?, `status` = ? WHERE `id` = ?").
+			WithArgs(seller.CID, seller.CompanyName, seller.Address, seller.Telephone, seller.Locality, seller.Status, seller.ID).
 			WillReturnError(errors.New("database error"))
 
 		r := NewSellerMysql(db)
@@ -292,29 +301,151 @@ func TestSellerMysql_Update(t *testing.T) {
 }
 
 func TestSellerMysql_Delete(t *testing.T) {
-	db, mock, err := sqlmock.New()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	assert.NoError(t, err)
 	defer db.Close()
 
 	t.Run("Success", func(t *testing.T) {
-		mock.ExpectExec("DELETE FROM `sellers` WHERE `id` = ?").
-			WithArgs(1).
-			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectBegin()
+		mock.ExpectQuery(LockSellerProductsQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectQuery(SellerHasHistoryQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectExec(DeleteSellerProductsQuery).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(DeleteSellerQuery).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
 
 		r := NewSellerMysql(db)
 		err := r.Delete(1)
 
 		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Products with history", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(LockSellerProductsQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectQuery(SellerHasHistoryQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+
+		r := NewSellerMysql(db)
+		err := r.Delete(1)
+
+		assert.ErrorIs(t, err, internal.ErrSellerHasHistory)
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("Database error", func(t *testing.T) {
-		mock.ExpectExec("DELETE FROM `sellers` WHERE `id` = ?").
-			WithArgs(1).
-			WillReturnError(errors.New("database error"))
+		mock.ExpectBegin()
+		mock.ExpectQuery(LockSellerProductsQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(SellerHasHistoryQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectExec(DeleteSellerProductsQuery).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(DeleteSellerQuery).WithArgs(1).WillReturnError(errors.New("database error"))
+		mock.ExpectRollback()
 
 		r := NewSellerMysql(db)
 		err := r.Delete(1)
 
 		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+var sellerReportColumns = []string{"seller_id", "cid", "company_name", "products_count", "live_quantity",
+	"units_sold", "revenue", "expired_quantity", "written_off_quantity"}
+
+func TestSellerMysql_FindProducts(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "product_code", "description", "height", "length", "net_weight", "expiration_rate",
+			"recommended_freezing_temperature", "width", "freezing_rate", "product_type_id", "seller_id", "status"}).
+			AddRow(1, "P001", "Apple", 10.0, 5.0, 1.5, 0.1, -5.0, 3.0, 0.2, 1, 1, "suspended")
+		mock.ExpectQuery(FindSellerProductsQuery).WithArgs(1).WillReturnRows(rows)
+
+		r := NewSellerMysql(db)
+		products, err := r.FindProducts(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(products))
+		assert.Equal(t, "P001", products[0].ProductCode)
+		assert.Equal(t, 1, products[0].SellerID)
+		assert.Equal(t, internal.ProductStatusSuspended, products[0].Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mock.ExpectQuery(FindSellerProductsQuery).WithArgs(1).WillReturnError(sql.ErrConnDone)
+
+		r := NewSellerMysql(db)
+		_, err := r.FindProducts(1)
+
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSellerMysql_ReportSalesQuery(t *testing.T) {
+	// units sold and revenue only count the orders that left the warehouse
+	assert.Equal(t, 2, strings.Count(sellerReportQuery, "po.status IN ('shipped', 'delivered')"))
+	assert.NotContains(t, sellerReportQuery, "'cancelled'")
+}
+
+func TestSellerMysql_ReportByID(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		rows := sqlmock.NewRows(sellerReportColumns).AddRow(1, 101, "Fresh Farms", 3, 120, 15, 1050.5, 10, 4)
+		mock.ExpectQuery(SellerReportByIDQuery).WithArgs(1).WillReturnRows(rows)
+
+		r := NewSellerMysql(db)
+		report, err := r.ReportByID(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, internal.SellerReport{SellerID: 1, CID: 101, CompanyName: "Fresh Farms", ProductsCount: 3, LiveQuantity: 120,
+			UnitsSold: 15, Revenue: 1050.5, ExpiredQuantity: 10, WrittenOffQuantity: 4}, report)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mock.ExpectQuery(SellerReportByIDQuery).WithArgs(99).WillReturnError(sql.ErrNoRows)
+
+		r := NewSellerMysql(db)
+		_, err := r.ReportByID(99)
+
+		assert.ErrorIs(t, err, internal.ErrSellerNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSellerMysql_Report(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(CountSellersQuery).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		rows := sqlmock.NewRows(sellerReportColumns).
+			AddRow(2, 102, "Green Valley", 1, 40, 8, 300.0, 0, 0)
+		mock.ExpectQuery(fmt.Sprintf(SellerReportPageQuery, "revenue", "DESC")).WithArgs(1, 1).WillReturnRows(rows)
+
+		r := NewSellerMysql(db)
+		reports, total, err := r.Report(internal.SellerReportQuery{SortBy: "revenue", Order: "desc", Limit: 1, Offset: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, total)
+		assert.Equal(t, 1, len(reports))
+		assert.Equal(t, 2, reports[0].SellerID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Sort Field", func(t *testing.T) {
+		r := NewSellerMysql(db)
+		_, _, err := r.Report(internal.SellerReportQuery{SortBy: "s.id; DROP TABLE sellers", Order: "asc", Limit: 1})
+
+		assert.ErrorIs(t, err, internal.ErrSellerReportBadRequest)
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
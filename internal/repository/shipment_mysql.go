@@ -0,0 +1,328 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+const (
+	FindShipmentsQuery = `
+		SELECT s.id, s.tracking_code, s.carry_id, s.warehouse_id, s.status, s.created_at, s.dispatched_at, s.delivered_at
+		FROM shipments AS s`
+	FindShipmentsOrderBy               = " ORDER BY s.created_at, s.id"
+	FindShipmentByIDQuery              = FindShipmentsQuery + " WHERE s.id = ?"
+	FindShipmentPurchaseOrdersQuery    = "SELECT `purchase_order_id` FROM `shipment_purchase_orders` WHERE `shipment_id` = ? ORDER BY `purchase_order_id`"
+	InsertShipmentQuery                = "INSERT INTO `shipments` (`tracking_code`, `carry_id`, `warehouse_id`, `status`, `created_at`) VALUES (?, ?, ?, ?, ?)"
+	InsertShipmentPurchaseOrderQuery   = "INSERT INTO `shipment_purchase_orders` (`shipment_id`, `purchase_order_id`) VALUES (?, ?)"
+	UpdatePurchaseOrderTrackingQuery   = "UPDATE `purchase_orders` SET `tracking_code` = ? WHERE `id` = ?"
+	LockShipmentQuery                  = "SELECT `tracking_code`, `status` FROM `shipments` WHERE `id` = ? FOR UPDATE"
+	UpdateShipmentStatusQuery          = "UPDATE `shipments` SET `status` = ?, `dispatched_at` = COALESCE(?, `dispatched_at`), `delivered_at` = COALESCE(?, `delivered_at`) WHERE `id` = ?"
+	FindPurchaseOrderWarehouseIDsQuery = `
+		SELECT DISTINCT s.warehouse_id
+		FROM purchase_order_reservations AS r
+		INNER JOIN purchase_order_lines AS l ON l.id = r.purchase_order_line_id
+		INNER JOIN product_batches AS pb ON pb.id = r.product_batch_id
+		INNER JOIN sections AS s ON s.id = pb.section_id
+		WHERE l.purchase_order_id = ?
+		ORDER BY s.warehouse_id`
+	CountPurchaseOrderUnconsumedReservationsQuery = `
+		SELECT COUNT(*)
+		FROM purchase_order_reservations AS r
+		INNER JOIN purchase_order_lines AS l ON l.id = r.purchase_order_line_id
+		WHERE l.purchase_order_id = ? AND r.consumed = 0`
+	CountShipmentUnconsumedReservationsQuery = `
+		SELECT COUNT(*)
+		FROM purchase_order_reservations AS r
+		INNER JOIN purchase_order_lines AS l ON l.id = r.purchase_order_line_id
+		INNER JOIN shipment_purchase_orders AS spo ON spo.purchase_order_id = l.purchase_order_id
+		WHERE spo.shipment_id = ? AND r.consumed = 0`
+)
+
+// NewShipmentMysql creates a new instance of the shipment repository
+func NewShipmentMysql(db *sql.DB) *ShipmentMysql {
+	return &ShipmentMysql{db}
+}
+
+// ShipmentMysql is the mysql implementation of the shipment repository
+type ShipmentMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// FindAll returns the shipments ordered by creation, only the ones in the given status when it is not empty
+func (r *ShipmentMysql) FindAll(status string) (shipments []internal.Shipment, err error) {
+	query := FindShipmentsQuery
+	var args []any
+
+	if status != "" {
+		query += " WHERE s.status = ?"
+		args = append(args, status)
+	}
+
+	rows, err := r.db.Query(query+FindShipmentsOrderBy, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s internal.Shipment
+
+		s, err = scanShipment(rows)
+		if err != nil {
+			return
+		}
+
+		shipments = append(shipments, s)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return
+	}
+
+	for i := range shipments {
+		shipments[i].PurchaseOrderIDs, err = r.findPurchaseOrderIDs(shipments[i].ID)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// FindByID returns the shipment with the given ID and its purchase orders
+func (r *ShipmentMysql) FindByID(id int) (internal.Shipment, error) {
+	s, err := scanShipment(r.db.QueryRow(FindShipmentByIDQuery, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return internal.Shipment{}, internal.ErrShipmentNotFound
+		}
+
+		return internal.Shipment{}, err
+	}
+
+	s.PurchaseOrderIDs, err = r.findPurchaseOrderIDs(id)
+	if err != nil {
+		return internal.Shipment{}, err
+	}
+
+	return s, nil
+}
+
+// FindPurchaseOrderWarehouseIDs returns the warehouses of the sections the stock reserved for the purchase order is stored in
+func (r *ShipmentMysql) FindPurchaseOrderWarehouseIDs(purchaseOrderID int) ([]int, error) {
+	rows, err := r.db.Query(FindPurchaseOrderWarehouseIDsQuery, purchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanIDs(rows)
+}
+
+// Save records the shipment, links its purchase orders and gives them its tracking code in one transaction
+func (r *ShipmentMysql) Save(s *internal.Shipment) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	result, err := tx.Exec(InsertShipmentQuery, s.TrackingCode, s.CarryID, s.WarehouseID, s.Status, s.CreatedAt)
+	if err != nil {
+		return
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return
+	}
+
+	for _, purchaseOrderID := range s.PurchaseOrderIDs {
+		_, err = tx.Exec(InsertShipmentPurchaseOrderQuery, id, purchaseOrderID)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+				err = internal.ErrShipmentPurchaseOrderAssigned
+			}
+
+			return
+		}
+
+		_, err = tx.Exec(UpdatePurchaseOrderTrackingQuery, s.TrackingCode, purchaseOrderID)
+		if err != nil {
+			return
+		}
+	}
+
+	s.ID = int(id)
+
+	return
+}
+
+// UpdateStatus locks the shipment, moves it to s.Status and moves its purchase orders along with it,
+// every purchase order change is recorded in its history
+func (r *ShipmentMysql) UpdateStatus(s *internal.Shipment) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	var current string
+
+	err = tx.QueryRow(LockShipmentQuery, s.ID).Scan(&s.TrackingCode, &current)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = internal.ErrShipmentNotFound
+		}
+
+		return
+	}
+
+	if !internal.CanShipmentTransition(current, s.Status) {
+		err = internal.ErrShipmentInvalidTransition
+		return
+	}
+
+	// the stock of the purchase orders must have left the batches before they are handed over
+	if s.Status == internal.ShipmentStatusDispatched {
+		var unconsumed int
+
+		err = tx.QueryRow(CountShipmentUnconsumedReservationsQuery, s.ID).Scan(&unconsumed)
+		if err != nil {
+			return
+		}
+
+		if unconsumed > 0 {
+			err = internal.ErrShipmentStockNotPicked
+			return
+		}
+	}
+
+	var changedAt time.Time
+
+	switch s.Status {
+	case internal.ShipmentStatusDispatched:
+		changedAt = *s.DispatchedAt
+	case internal.ShipmentStatusDelivered:
+		changedAt = *s.DeliveredAt
+	}
+
+	_, err = tx.Exec(UpdateShipmentStatusQuery, s.Status, s.DispatchedAt, s.DeliveredAt, s.ID)
+	if err != nil {
+		return
+	}
+
+	s.PurchaseOrderIDs, err = findShipmentPurchaseOrderIDs(tx, s.ID)
+	if err != nil {
+		return
+	}
+
+	for _, purchaseOrderID := range s.PurchaseOrderIDs {
+		err = updatePurchaseOrderStatus(tx, &internal.PurchaseOrderStatusHistory{
+			PurchaseOrderID: purchaseOrderID,
+			FromStatus:      internal.ShipmentPurchaseOrderStatus(current),
+			ToStatus:        internal.ShipmentPurchaseOrderStatus(s.Status),
+			ChangedBy:       fmt.Sprintf("shipment %s", s.TrackingCode),
+			ChangedAt:       changedAt,
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// CountUnconsumedReservations returns how many reservations of the purchase order were not picked from their batches yet
+func (r *ShipmentMysql) CountUnconsumedReservations(purchaseOrderID int) (count int, err error) {
+	err = r.db.QueryRow(CountPurchaseOrderUnconsumedReservationsQuery, purchaseOrderID).Scan(&count)
+
+	return
+}
+
+// findPurchaseOrderIDs returns the IDs of the purchase orders of the shipment
+func (r *ShipmentMysql) findPurchaseOrderIDs(shipmentID int) ([]int, error) {
+	rows, err := r.db.Query(FindShipmentPurchaseOrdersQuery, shipmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanIDs(rows)
+}
+
+// findShipmentPurchaseOrderIDs returns the IDs of the purchase orders of the shipment inside a transaction
+func findShipmentPurchaseOrderIDs(tx *sql.Tx, shipmentID int) ([]int, error) {
+	rows, err := tx.Query(FindShipmentPurchaseOrdersQuery, shipmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanIDs(rows)
+}
+
+// scanIDs reads a single ID column from every row
+func scanIDs(rows *sql.Rows) (ids []int, err error) {
+	for rows.Next() {
+		var id int
+
+		err = rows.Scan(&id)
+		if err != nil {
+			return
+		}
+
+		ids = append(ids, id)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// scanShipment reads a shipment from a row of FindShipmentsQuery
+func scanShipment(row interface{ Scan(dest ...any) error }) (s internal.Shipment, err error) {
+	var (
+		dispatchedAt sql.NullTime
+		deliveredAt  sql.NullTime
+	)
+
+	err = row.Scan(&s.ID, &s.TrackingCode, &s.CarryID, &s.WarehouseID, &s.Status, &s.CreatedAt, &dispatchedAt, &deliveredAt)
+	if err != nil {
+		return
+	}
+
+	if dispatchedAt.Valid {
+		s.DispatchedAt = &dispatchedAt.Time
+	}
+
+	if deliveredAt.Valid {
+		s.DeliveredAt = &deliveredAt.Time
+	}
+
+	return
+}
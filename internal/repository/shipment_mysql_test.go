@@ -0,0 +1,198 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+var shipmentColumns = []string{"id", "tracking_code", "carry_id", "warehouse_id", "status", "created_at", "dispatched_at", "delivered_at"}
+
+func TestShipmentMysql_FindByID(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	dispatchedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Shipment with its purchase orders", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(repository.FindShipmentByIDQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(shipmentColumns).AddRow(1, "SHP0A", 2, 3, internal.ShipmentStatusDispatched, createdAt, dispatchedAt, nil))
+		mock.ExpectQuery(repository.FindShipmentPurchaseOrdersQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"purchase_order_id"}).AddRow(4).AddRow(5))
+
+		rp := repository.NewShipmentMysql(db)
+		s, err := rp.FindByID(1)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.Shipment{ID: 1, TrackingCode: "SHP0A", CarryID: 2, WarehouseID: 3, Status: internal.ShipmentStatusDispatched,
+			PurchaseOrderIDs: []int{4, 5}, CreatedAt: createdAt, DispatchedAt: &dispatchedAt}, s)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: error - Shipment not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(repository.FindShipmentByIDQuery).WithArgs(9).WillReturnRows(sqlmock.NewRows(shipmentColumns))
+
+		rp := repository.NewShipmentMysql(db)
+		_, err = rp.FindByID(9)
+
+		require.ErrorIs(t, err, internal.ErrShipmentNotFound)
+	})
+}
+
+func TestShipmentMysql_Save(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Purchase orders get the tracking code", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		s := internal.Shipment{TrackingCode: "SHP0A", CarryID: 2, WarehouseID: 3, Status: internal.ShipmentStatusCreated, PurchaseOrderIDs: []int{4, 5}, CreatedAt: createdAt}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(repository.InsertShipmentQuery).WithArgs("SHP0A", 2, 3, internal.ShipmentStatusCreated, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(repository.InsertShipmentPurchaseOrderQuery).WithArgs(1, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.UpdatePurchaseOrderTrackingQuery).WithArgs("SHP0A", 4).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.InsertShipmentPurchaseOrderQuery).WithArgs(1, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.UpdatePurchaseOrderTrackingQuery).WithArgs("SHP0A", 5).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		rp := repository.NewShipmentMysql(db)
+		err = rp.Save(&s)
+
+		require.NoError(t, err)
+		require.Equal(t, 1, s.ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: error - Purchase order already shipped", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		s := internal.Shipment{TrackingCode: "SHP0A", CarryID: 2, WarehouseID: 3, Status: internal.ShipmentStatusCreated, PurchaseOrderIDs: []int{4}, CreatedAt: createdAt}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(repository.InsertShipmentQuery).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(repository.InsertShipmentPurchaseOrderQuery).WithArgs(1, 4).WillReturnError(&mysql.MySQLError{Number: 1062})
+		mock.ExpectRollback()
+
+		rp := repository.NewShipmentMysql(db)
+		err = rp.Save(&s)
+
+		require.ErrorIs(t, err, internal.ErrShipmentPurchaseOrderAssigned)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestShipmentMysql_UpdateStatus(t *testing.T) {
+	dispatchedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Purchase orders are shipped with the shipment", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		s := internal.Shipment{ID: 1, Status: internal.ShipmentStatusDispatched, DispatchedAt: &dispatchedAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockShipmentQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"tracking_code", "status"}).AddRow("SHP0A", internal.ShipmentStatusCreated))
+		mock.ExpectQuery(repository.CountShipmentUnconsumedReservationsQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectExec(repository.UpdateShipmentStatusQuery).WithArgs(internal.ShipmentStatusDispatched, &dispatchedAt, nil, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(repository.FindShipmentPurchaseOrdersQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"purchase_order_id"}).AddRow(4))
+		mock.ExpectExec(repository.UpdatePurchaseOrderStatusQuery).WithArgs(internal.PurchaseOrderStatusShipped, 4, internal.PurchaseOrderStatusPicking).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.InsertPurchaseOrderStatusHistoryQuery).
+			WithArgs(4, internal.PurchaseOrderStatusPicking, internal.PurchaseOrderStatusShipped, "shipment SHP0A", dispatchedAt).
+			WillReturnResult(sqlmock.NewResult(6, 1))
+		mock.ExpectCommit()
+
+		rp := repository.NewShipmentMysql(db)
+		err = rp.UpdateStatus(&s)
+
+		require.NoError(t, err)
+		require.Equal(t, []int{4}, s.PurchaseOrderIDs)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: error - Purchase order is no longer picked", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		s := internal.Shipment{ID: 1, Status: internal.ShipmentStatusDispatched, DispatchedAt: &dispatchedAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockShipmentQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"tracking_code", "status"}).AddRow("SHP0A", internal.ShipmentStatusCreated))
+		mock.ExpectQuery(repository.CountShipmentUnconsumedReservationsQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectExec(repository.UpdateShipmentStatusQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(repository.FindShipmentPurchaseOrdersQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"purchase_order_id"}).AddRow(4))
+		mock.ExpectExec(repository.UpdatePurchaseOrderStatusQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		rp := repository.NewShipmentMysql(db)
+		err = rp.UpdateStatus(&s)
+
+		require.ErrorIs(t, err, internal.ErrPurchaseOrderInvalidTransition)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 3: error - Shipment already delivered", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		s := internal.Shipment{ID: 1, Status: internal.ShipmentStatusDispatched, DispatchedAt: &dispatchedAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockShipmentQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"tracking_code", "status"}).AddRow("SHP0A", internal.ShipmentStatusDelivered))
+		mock.ExpectRollback()
+
+		rp := repository.NewShipmentMysql(db)
+		err = rp.UpdateStatus(&s)
+
+		require.ErrorIs(t, err, internal.ErrShipmentInvalidTransition)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 4: error - Purchase order stock not picked", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		s := internal.Shipment{ID: 1, Status: internal.ShipmentStatusDispatched, DispatchedAt: &dispatchedAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockShipmentQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"tracking_code", "status"}).AddRow("SHP0A", internal.ShipmentStatusCreated))
+		mock.ExpectQuery(repository.CountShipmentUnconsumedReservationsQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		rp := repository.NewShipmentMysql(db)
+		err = rp.UpdateStatus(&s)
+
+		require.ErrorIs(t, err, internal.ErrShipmentStockNotPicked)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
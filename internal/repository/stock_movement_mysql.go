@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+const (
+	FindStockMovementsByProductBatchQuery = `
+		SELECT sm.id, sm.product_batch_id, sm.type, sm.quantity, sm.reason, sm.quantity_after, sm.created_at
+		FROM stock_movements AS sm
+		WHERE sm.product_batch_id = ?
+		ORDER BY sm.created_at, sm.id
+	`
+	LockProductBatchQuantityQuery   = "SELECT `current_quantity`, `status`, `section_id` FROM `product_batches` WHERE `id` = ? FOR UPDATE"
+	UpdateProductBatchQuantityQuery = "UPDATE `product_batches` SET `current_quantity` = ? WHERE `id` = ?"
+	InsertStockMovementQuery        = "INSERT INTO `stock_movements` (`product_batch_id`, `type`, `quantity`, `reason`, `quantity_after`, `created_at`) VALUES (?, ?, ?, ?, ?, ?)"
+)
+
+// NewStockMovementMysql creates a new instance of the stock movement repository
+func NewStockMovementMysql(db *sql.DB) *StockMovementMysql {
+	return &StockMovementMysql{db}
+}
+
+// StockMovementMysql is the mysql implementation of the stock movement repository
+type StockMovementMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// FindByProductBatchID returns the movements of a product batch ordered by creation
+func (r *StockMovementMysql) FindByProductBatchID(productBatchID int) (movements []internal.StockMovement, err error) {
+	rows, err := r.db.Query(FindStockMovementsByProductBatchQuery, productBatchID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var m internal.StockMovement
+
+		err = rows.Scan(&m.ID, &m.ProductBatchID, &m.Type, &m.Quantity, &m.Reason, &m.QuantityAfter, &m.CreatedAt)
+		if err != nil {
+			return
+		}
+
+		movements = append(movements, m)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// Save applies the movement to the batch current quantity and records it in a single transaction
+func (r *StockMovementMysql) Save(m *internal.StockMovement) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	err = applyStockMovement(tx, m)
+
+	return
+}
+
+// applyStockMovement locks the batch row, updates its current quantity and inserts the movement.
+// It is shared by every repository that moves stock inside its own transaction.
+// Batches on hold or quarantined cannot be picked nor transferred, other movements are still allowed.
+// The capacity of the section holding the batch follows its quantity.
+func applyStockMovement(tx *sql.Tx, m *internal.StockMovement) error {
+	var (
+		currentQuantity int
+		status          string
+		sectionID       int
+	)
+
+	err := tx.QueryRow(LockProductBatchQuantityQuery, m.ProductBatchID).Scan(&currentQuantity, &status, &sectionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return internal.ErrProductBatchNotFound
+		}
+
+		return err
+	}
+
+	if (m.Type == internal.StockMovementPick || m.Type == internal.StockMovementTransferOut) && status != internal.ProductBatchStatusReleased {
+		return internal.ErrProductBatchOnHold
+	}
+
+	quantityAfter := currentQuantity + m.Delta()
+	if quantityAfter < 0 {
+		return internal.ErrStockMovementInsufficientStock
+	}
+
+	err = adjustSectionCapacity(tx, sectionID, m.Delta())
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(UpdateProductBatchQuantityQuery, quantityAfter, m.ProductBatchID)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(InsertStockMovementQuery, m.ProductBatchID, m.Type, m.Quantity, m.Reason, quantityAfter, m.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	m.ID = int(id)
+	m.QuantityAfter = quantityAfter
+
+	return nil
+}
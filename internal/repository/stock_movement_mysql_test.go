@@ -0,0 +1,162 @@
+package repository_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockMovementMysql_FindByProductBatchID(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Movements found", func(t *testing.T) {
+		expected := []internal.StockMovement{
+			{ID: 1, ProductBatchID: 1, Type: internal.StockMovementReceipt, Quantity: 10, QuantityAfter: 110, CreatedAt: createdAt},
+			{ID: 2, ProductBatchID: 1, Type: internal.StockMovementPick, Quantity: 5, Reason: "order PO1001", QuantityAfter: 105, CreatedAt: createdAt},
+		}
+
+		rows := sqlmock.NewRows([]string{"id", "product_batch_id", "type", "quantity", "reason", "quantity_after", "created_at"})
+		for _, m := range expected {
+			rows.AddRow(m.ID, m.ProductBatchID, m.Type, m.Quantity, m.Reason, m.QuantityAfter, m.CreatedAt)
+		}
+
+		mock.ExpectQuery(repository.FindStockMovementsByProductBatchQuery).WithArgs(1).WillReturnRows(rows)
+
+		rp := repository.NewStockMovementMysql(db)
+		movements, err := rp.FindByProductBatchID(1)
+
+		require.NoError(t, err)
+		require.Equal(t, expected, movements)
+	})
+
+	t.Run("case 2: error - Error executing the query", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindStockMovementsByProductBatchQuery).WithArgs(1).WillReturnError(sql.ErrConnDone)
+
+		rp := repository.NewStockMovementMysql(db)
+		_, err := rp.FindByProductBatchID(1)
+
+		require.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
+
+func TestStockMovementMysql_Save(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Pick decrements the batch quantity", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		m := internal.StockMovement{ProductBatchID: 1, Type: internal.StockMovementPick, Quantity: 30, CreatedAt: createdAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "status", "section_id"}).AddRow(100, "released", 2))
+		mock.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(100, 500))
+		mock.ExpectExec(repository.UpdateSectionCapacityQuery).WithArgs(70, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.UpdateProductBatchQuantityQuery).WithArgs(70, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.InsertStockMovementQuery).WithArgs(1, internal.StockMovementPick, 30, "", 70, createdAt).
+			WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectCommit()
+
+		rp := repository.NewStockMovementMysql(db)
+		err = rp.Save(&m)
+
+		require.NoError(t, err)
+		require.Equal(t, 5, m.ID)
+		require.Equal(t, 70, m.QuantityAfter)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: error - Movement would make the quantity negative", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		m := internal.StockMovement{ProductBatchID: 1, Type: internal.StockMovementWriteOff, Quantity: 150, CreatedAt: createdAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "status", "section_id"}).AddRow(100, "released", 2))
+		mock.ExpectRollback()
+
+		rp := repository.NewStockMovementMysql(db)
+		err = rp.Save(&m)
+
+		require.ErrorIs(t, err, internal.ErrStockMovementInsufficientStock)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 3: error - Product batch not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		m := internal.StockMovement{ProductBatchID: 99, Type: internal.StockMovementReceipt, Quantity: 10, CreatedAt: createdAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(99).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		rp := repository.NewStockMovementMysql(db)
+		err = rp.Save(&m)
+
+		require.ErrorIs(t, err, internal.ErrProductBatchNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 4: error - Batch on hold cannot be picked", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		m := internal.StockMovement{ProductBatchID: 1, Type: internal.StockMovementPick, Quantity: 10, CreatedAt: createdAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "status", "section_id"}).AddRow(100, "quarantined", 2))
+		mock.ExpectRollback()
+
+		rp := repository.NewStockMovementMysql(db)
+		err = rp.Save(&m)
+
+		require.ErrorIs(t, err, internal.ErrProductBatchOnHold)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 5: error - Receipt does not fit in the section", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		m := internal.StockMovement{ProductBatchID: 1, Type: internal.StockMovementReceipt, Quantity: 50, CreatedAt: createdAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "status", "section_id"}).AddRow(100, "released", 2))
+		mock.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(480, 500))
+		mock.ExpectRollback()
+
+		rp := repository.NewStockMovementMysql(db)
+		err = rp.Save(&m)
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, err, &domainError)
+		require.Equal(t, internal.ErrSectionCapacityExceeded.Error(), domainError.Message)
+		require.Equal(t, "section 2 has room for 20 units but 50 were requested", domainError.Causes[0].Message)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+const (
+	FindTemperatureExcursionsQuery = `
+		SELECT te.id, te.section_id, te.status, te.started_at, te.ended_at, te.peak_celsius, te.peak_deviation,
+			te.limit_celsius, te.acknowledged_by, te.acknowledged_at, te.closed_by
+		FROM temperature_excursions AS te
+		INNER JOIN sections AS s ON s.id = te.section_id`
+	FindTemperatureExcursionsOrderBy = " ORDER BY te.started_at DESC, te.id DESC"
+	FindTemperatureExcursionQuery    = `
+		SELECT te.id, te.section_id, te.status, te.started_at, te.ended_at, te.peak_celsius, te.peak_deviation,
+			te.limit_celsius, te.acknowledged_by, te.acknowledged_at, te.closed_by
+		FROM temperature_excursions AS te
+		WHERE te.id = ?`
+	FindActiveTemperatureExcursionQuery = `
+		SELECT te.id, te.section_id, te.status, te.started_at, te.ended_at, te.peak_celsius, te.peak_deviation,
+			te.limit_celsius, te.acknowledged_by, te.acknowledged_at, te.closed_by
+		FROM temperature_excursions AS te
+		WHERE te.section_id = ? AND te.ended_at IS NULL
+		ORDER BY te.started_at DESC, te.id DESC
+		LIMIT 1`
+	FindSectionTemperatureLimitsQuery = `
+		SELECT GREATEST(s.minimum_temperature, w.minimum_temperature), MIN(p.recommended_freezing_temperature)
+		FROM sections AS s
+		INNER JOIN warehouses AS w ON w.id = s.warehouse_id
+		LEFT JOIN product_batches AS pb ON pb.section_id = s.id AND pb.current_quantity > 0
+		LEFT JOIN products AS p ON p.id = pb.product_id
+		WHERE s.id = ?
+		GROUP BY s.id, s.minimum_temperature, w.minimum_temperature`
+	InsertTemperatureExcursionQuery = "INSERT INTO `temperature_excursions` (`section_id`, `status`, `started_at`, `ended_at`, `peak_celsius`, `peak_deviation`, `limit_celsius`, `acknowledged_by`, `acknowledged_at`, `closed_by`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	UpdateTemperatureExcursionQuery = "UPDATE `temperature_excursions` SET `status` = ?, `ended_at` = ?, `peak_celsius` = ?, `peak_deviation` = ?, `limit_celsius` = ?, `acknowledged_by` = ?, `acknowledged_at` = ?, `closed_by` = ? WHERE `id` = ?"
+)
+
+// NewTemperatureExcursionMysql creates a new instance of the temperature excursion repository
+func NewTemperatureExcursionMysql(db *sql.DB) *TemperatureExcursionMysql {
+	return &TemperatureExcursionMysql{db}
+}
+
+// TemperatureExcursionMysql is the mysql implementation of the temperature excursion repository
+type TemperatureExcursionMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// FindAll returns the excursions that match the filter, the most recent first
+func (r *TemperatureExcursionMysql) FindAll(filter internal.TemperatureExcursionFilter) (excursions []internal.TemperatureExcursion, err error) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if filter.Status != "" {
+		conditions = append(conditions, "te.status = ?")
+		args = append(args, filter.Status)
+	}
+
+	if filter.SectionID != 0 {
+		conditions = append(conditions, "te.section_id = ?")
+		args = append(args, filter.SectionID)
+	}
+
+	if filter.WarehouseID != 0 {
+		conditions = append(conditions, "s.warehouse_id = ?")
+		args = append(args, filter.WarehouseID)
+	}
+
+	query := FindTemperatureExcursionsQuery
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += FindTemperatureExcursionsOrderBy
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var excursion internal.TemperatureExcursion
+
+		excursion, err = scanTemperatureExcursion(rows)
+		if err != nil {
+			return
+		}
+
+		excursions = append(excursions, excursion)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// FindByID returns the excursion with the given id
+func (r *TemperatureExcursionMysql) FindByID(id int) (excursion internal.TemperatureExcursion, err error) {
+	excursion, err = scanTemperatureExcursion(r.db.QueryRow(FindTemperatureExcursionQuery, id))
+	if err == sql.ErrNoRows {
+		err = internal.ErrTemperatureExcursionNotFound
+	}
+
+	return
+}
+
+// FindActiveBySectionID returns the excursion of the section that has not ended yet
+func (r *TemperatureExcursionMysql) FindActiveBySectionID(sectionID int) (excursion internal.TemperatureExcursion, err error) {
+	excursion, err = scanTemperatureExcursion(r.db.QueryRow(FindActiveTemperatureExcursionQuery, sectionID))
+	if err == sql.ErrNoRows {
+		err = internal.ErrTemperatureExcursionNotFound
+	}
+
+	return
+}
+
+// FindLimitsBySectionID returns the temperature limits of the section
+func (r *TemperatureExcursionMysql) FindLimitsBySectionID(sectionID int) (limits internal.TemperatureLimits, err error) {
+	var maximum sql.NullFloat64
+
+	err = r.db.QueryRow(FindSectionTemperatureLimitsQuery, sectionID).Scan(&limits.Minimum, &maximum)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = internal.ErrSectionNotFound
+		}
+
+		return
+	}
+
+	limits.Maximum = maximum.Float64
+	limits.HasMaximum = maximum.Valid
+
+	// a minimum above the maximum leaves no temperature the section can be kept at
+	if limits.HasMaximum && limits.Minimum > limits.Maximum {
+		err = internal.ErrTemperatureLimitsInvalid
+	}
+
+	return
+}
+
+// Save stores a new excursion
+func (r *TemperatureExcursionMysql) Save(excursion *internal.TemperatureExcursion) error {
+	result, err := r.db.Exec(InsertTemperatureExcursionQuery,
+		excursion.SectionID,
+		excursion.Status,
+		excursion.StartedAt.UTC(),
+		nullTime(excursion.EndedAt),
+		excursion.PeakCelsius,
+		excursion.PeakDeviation,
+		excursion.LimitCelsius,
+		excursion.AcknowledgedBy,
+		nullTime(excursion.AcknowledgedAt),
+		excursion.ClosedBy,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	excursion.ID = int(id)
+
+	return nil
+}
+
+// Update stores the status, peak and closing data of the excursion
+func (r *TemperatureExcursionMysql) Update(excursion *internal.TemperatureExcursion) error {
+	result, err := r.db.Exec(UpdateTemperatureExcursionQuery,
+		excursion.Status,
+		nullTime(excursion.EndedAt),
+		excursion.PeakCelsius,
+		excursion.PeakDeviation,
+		excursion.LimitCelsius,
+		excursion.AcknowledgedBy,
+		nullTime(excursion.AcknowledgedAt),
+		excursion.ClosedBy,
+		excursion.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return internal.ErrTemperatureExcursionNotFound
+	}
+
+	return nil
+}
+
+// scanTemperatureExcursion reads an excursion from a row of the excursion queries
+func scanTemperatureExcursion(row interface{ Scan(dest ...any) error }) (excursion internal.TemperatureExcursion, err error) {
+	var endedAt, acknowledgedAt sql.NullTime
+
+	err = row.Scan(
+		&excursion.ID,
+		&excursion.SectionID,
+		&excursion.Status,
+		&excursion.StartedAt,
+		&endedAt,
+		&excursion.PeakCelsius,
+		&excursion.PeakDeviation,
+		&excursion.LimitCelsius,
+		&excursion.AcknowledgedBy,
+		&acknowledgedAt,
+		&excursion.ClosedBy,
+	)
+	if err != nil {
+		return
+	}
+
+	if endedAt.Valid {
+		excursion.EndedAt = &endedAt.Time
+	}
+
+	if acknowledgedAt.Valid {
+		excursion.AcknowledgedAt = &acknowledgedAt.Time
+	}
+
+	return
+}
+
+// nullTime converts an optional time to a nullable column value in UTC
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
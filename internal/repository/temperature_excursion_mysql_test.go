@@ -0,0 +1,235 @@
+package repository_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+var temperatureExcursionColumns = []string{"id", "section_id", "status", "started_at", "ended_at", "peak_celsius", "peak_deviation", "limit_celsius", "acknowledged_by", "acknowledged_at", "closed_by"}
+
+func TestTemperatureExcursionMysql_FindAll(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	startedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(time.Hour)
+
+	t.Run("case 1: success - Excursions found without filter", func(t *testing.T) {
+		expected := []internal.TemperatureExcursion{
+			{ID: 2, SectionID: 1, Status: internal.TemperatureExcursionStatusOpen, StartedAt: endedAt, PeakCelsius: -10, PeakDeviation: 5, LimitCelsius: -15},
+			{ID: 1, SectionID: 1, Status: internal.TemperatureExcursionStatusClosed, StartedAt: startedAt, EndedAt: &endedAt, PeakCelsius: -30, PeakDeviation: 5, LimitCelsius: -25},
+		}
+
+		rows := sqlmock.NewRows(temperatureExcursionColumns).
+			AddRow(2, 1, "open", endedAt, nil, -10, 5, -15, "", nil, "").
+			AddRow(1, 1, "closed", startedAt, endedAt, -30, 5, -25, "", nil, "")
+
+		mock.ExpectQuery(repository.FindTemperatureExcursionsQuery + repository.FindTemperatureExcursionsOrderBy).WillReturnRows(rows)
+
+		rp := repository.NewTemperatureExcursionMysql(db)
+		excursions, err := rp.FindAll(internal.TemperatureExcursionFilter{})
+
+		require.NoError(t, err)
+		require.Equal(t, expected, excursions)
+	})
+
+	t.Run("case 2: success - Excursions filtered by status, section and warehouse", func(t *testing.T) {
+		query := repository.FindTemperatureExcursionsQuery +
+			" WHERE te.status = ? AND te.section_id = ? AND s.warehouse_id = ?" +
+			repository.FindTemperatureExcursionsOrderBy
+
+		mock.ExpectQuery(query).WithArgs("open", 1, 2).WillReturnRows(sqlmock.NewRows(temperatureExcursionColumns))
+
+		rp := repository.NewTemperatureExcursionMysql(db)
+		excursions, err := rp.FindAll(internal.TemperatureExcursionFilter{Status: "open", SectionID: 1, WarehouseID: 2})
+
+		require.NoError(t, err)
+		require.Empty(t, excursions)
+	})
+
+	t.Run("case 3: error - Error executing the query", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindTemperatureExcursionsQuery + repository.FindTemperatureExcursionsOrderBy).WillReturnError(sql.ErrConnDone)
+
+		rp := repository.NewTemperatureExcursionMysql(db)
+		_, err := rp.FindAll(internal.TemperatureExcursionFilter{})
+
+		require.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
+
+func TestTemperatureExcursionMysql_FindByID(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	startedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	acknowledgedAt := startedAt.Add(time.Minute)
+
+	t.Run("case 1: success - Excursion found", func(t *testing.T) {
+		rows := sqlmock.NewRows(temperatureExcursionColumns).
+			AddRow(1, 1, "acknowledged", startedAt, nil, -10, 5, -15, "qa", acknowledgedAt, "")
+
+		mock.ExpectQuery(repository.FindTemperatureExcursionQuery).WithArgs(1).WillReturnRows(rows)
+
+		rp := repository.NewTemperatureExcursionMysql(db)
+		excursion, err := rp.FindByID(1)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.TemperatureExcursion{
+			ID: 1, SectionID: 1, Status: "acknowledged", StartedAt: startedAt, PeakCelsius: -10, PeakDeviation: 5,
+			LimitCelsius: -15, AcknowledgedBy: "qa", AcknowledgedAt: &acknowledgedAt,
+		}, excursion)
+	})
+
+	t.Run("case 2: error - Excursion not found", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindTemperatureExcursionQuery).WithArgs(99).WillReturnError(sql.ErrNoRows)
+
+		rp := repository.NewTemperatureExcursionMysql(db)
+		_, err := rp.FindByID(99)
+
+		require.ErrorIs(t, err, internal.ErrTemperatureExcursionNotFound)
+	})
+
+	t.Run("case 3: error - Section without an active excursion", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindActiveTemperatureExcursionQuery).WithArgs(1).WillReturnError(sql.ErrNoRows)
+
+		rp := repository.NewTemperatureExcursionMysql(db)
+		_, err := rp.FindActiveBySectionID(1)
+
+		require.ErrorIs(t, err, internal.ErrTemperatureExcursionNotFound)
+	})
+}
+
+func TestTemperatureExcursionMysql_FindLimitsBySectionID(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	t.Run("case 1: success - Limits of a section with products", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindSectionTemperatureLimitsQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"minimum", "maximum"}).AddRow(-25, -15))
+
+		rp := repository.NewTemperatureExcursionMysql(db)
+		limits, err := rp.FindLimitsBySectionID(1)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.TemperatureLimits{Minimum: -25, Maximum: -15, HasMaximum: true}, limits)
+	})
+
+	t.Run("case 2: success - Limits of an empty section", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindSectionTemperatureLimitsQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"minimum", "maximum"}).AddRow(-25, nil))
+
+		rp := repository.NewTemperatureExcursionMysql(db)
+		limits, err := rp.FindLimitsBySectionID(1)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.TemperatureLimits{Minimum: -25}, limits)
+	})
+
+	t.Run("case 3: success - Warmer warehouse minimum and coldest product limit", func(t *testing.T) {
+		// the warehouse keeps -20 over the -25 of the section, the coldest product is recommended at -18
+		mock.ExpectQuery(repository.FindSectionTemperatureLimitsQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"minimum", "maximum"}).AddRow(-20, -18))
+
+		rp := repository.NewTemperatureExcursionMysql(db)
+		limits, err := rp.FindLimitsBySectionID(1)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.TemperatureLimits{Minimum: -20, Maximum: -18, HasMaximum: true}, limits)
+		deviation, _ := limits.Deviation(-19)
+		require.Zero(t, deviation)
+	})
+
+	t.Run("case 4: error - Minimum above the maximum", func(t *testing.T) {
+		// seeded section 1 is in a warehouse kept at 0 and stores a product recommended at -6
+		mock.ExpectQuery(repository.FindSectionTemperatureLimitsQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"minimum", "maximum"}).AddRow(0, -6))
+
+		rp := repository.NewTemperatureExcursionMysql(db)
+		_, err := rp.FindLimitsBySectionID(1)
+
+		require.ErrorIs(t, err, internal.ErrTemperatureLimitsInvalid)
+	})
+
+	t.Run("case 5: error - Section not found", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindSectionTemperatureLimitsQuery).WithArgs(99).WillReturnError(sql.ErrNoRows)
+
+		rp := repository.NewTemperatureExcursionMysql(db)
+		_, err := rp.FindLimitsBySectionID(99)
+
+		require.ErrorIs(t, err, internal.ErrSectionNotFound)
+	})
+}
+
+func TestTemperatureExcursionMysql_Save(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	startedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Excursion saved", func(t *testing.T) {
+		excursion := internal.TemperatureExcursion{SectionID: 1, Status: "open", StartedAt: startedAt, PeakCelsius: -10, PeakDeviation: 5, LimitCelsius: -15}
+
+		mock.ExpectExec(repository.InsertTemperatureExcursionQuery).
+			WithArgs(1, "open", startedAt, sql.NullTime{}, -10.0, 5.0, -15.0, "", sql.NullTime{}, "").
+			WillReturnResult(sqlmock.NewResult(4, 1))
+
+		rp := repository.NewTemperatureExcursionMysql(db)
+		err := rp.Save(&excursion)
+
+		require.NoError(t, err)
+		require.Equal(t, 4, excursion.ID)
+	})
+
+	t.Run("case 2: error - Error executing the query", func(t *testing.T) {
+		excursion := internal.TemperatureExcursion{SectionID: 1, Status: "open", StartedAt: startedAt}
+
+		mock.ExpectExec(repository.InsertTemperatureExcursionQuery).WillReturnError(sql.ErrConnDone)
+
+		rp := repository.NewTemperatureExcursionMysql(db)
+		err := rp.Save(&excursion)
+
+		require.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
+
+func TestTemperatureExcursionMysql_Update(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	endedAt := time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Excursion closed", func(t *testing.T) {
+		excursion := internal.TemperatureExcursion{ID: 1, Status: "closed", EndedAt: &endedAt, PeakCelsius: -10, PeakDeviation: 5, LimitCelsius: -15}
+
+		mock.ExpectExec(repository.UpdateTemperatureExcursionQuery).
+			WithArgs("closed", sql.NullTime{Time: endedAt, Valid: true}, -10.0, 5.0, -15.0, "", sql.NullTime{}, "", 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		rp := repository.NewTemperatureExcursionMysql(db)
+		err := rp.Update(&excursion)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("case 2: error - Excursion not found", func(t *testing.T) {
+		excursion := internal.TemperatureExcursion{ID: 99, Status: "closed"}
+
+		mock.ExpectExec(repository.UpdateTemperatureExcursionQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		rp := repository.NewTemperatureExcursionMysql(db)
+		err := rp.Update(&excursion)
+
+		require.ErrorIs(t, err, internal.ErrTemperatureExcursionNotFound)
+	})
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+const (
+	FindTemperatureReadingsBySectionQuery = `
+		SELECT tr.id, tr.section_id, tr.recorded_at, tr.celsius
+		FROM temperature_readings AS tr
+		WHERE tr.section_id = ?`
+	FindTemperatureReadingsOrderBy     = " ORDER BY tr.recorded_at, tr.id"
+	InsertTemperatureReadingQuery      = "INSERT INTO `temperature_readings` (`section_id`, `recorded_at`, `celsius`) VALUES (?, ?, ?)"
+	UpdateSectionTemperatureQuery      = "UPDATE `sections` SET `current_temperature` = (SELECT tr.celsius FROM temperature_readings AS tr WHERE tr.section_id = ? ORDER BY tr.recorded_at DESC, tr.id DESC LIMIT 1) WHERE `id` = ?"
+	UpdateProductBatchTemperatureQuery = "UPDATE `product_batches` SET `current_temperature` = (SELECT tr.celsius FROM temperature_readings AS tr WHERE tr.section_id = ? ORDER BY tr.recorded_at DESC, tr.id DESC LIMIT 1) WHERE `section_id` = ?"
+)
+
+// NewTemperatureReadingMysql creates a new instance of the temperature reading repository
+func NewTemperatureReadingMysql(db *sql.DB) *TemperatureReadingMysql {
+	return &TemperatureReadingMysql{db}
+}
+
+// TemperatureReadingMysql is the mysql implementation of the temperature reading repository
+type TemperatureReadingMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// FindBySectionID returns the readings of a section ordered by the time they were recorded
+func (r *TemperatureReadingMysql) FindBySectionID(sectionID int, from time.Time, to time.Time) (readings []internal.TemperatureReading, err error) {
+	query := FindTemperatureReadingsBySectionQuery
+	args := []any{sectionID}
+
+	if !from.IsZero() {
+		query += " AND tr.recorded_at >= ?"
+		args = append(args, from.UTC())
+	}
+
+	if !to.IsZero() {
+		query += " AND tr.recorded_at <= ?"
+		args = append(args, to.UTC())
+	}
+
+	query += FindTemperatureReadingsOrderBy
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t internal.TemperatureReading
+
+		err = rows.Scan(&t.ID, &t.SectionID, &t.RecordedAt, &t.Celsius)
+		if err != nil {
+			return
+		}
+
+		readings = append(readings, t)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// SaveBatch stores the readings in a single transaction. The current temperature of every section touched
+// and of its batches is then set from the most recent reading, so late readings do not overwrite newer ones.
+func (r *TemperatureReadingMysql) SaveBatch(readings []internal.TemperatureReading) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	var sectionIDs []int
+	seen := make(map[int]bool)
+
+	for i := range readings {
+		var (
+			result sql.Result
+			id     int64
+		)
+
+		result, err = tx.Exec(InsertTemperatureReadingQuery, readings[i].SectionID, readings[i].RecordedAt.UTC(), readings[i].Celsius)
+		if err != nil {
+			return
+		}
+
+		id, err = result.LastInsertId()
+		if err != nil {
+			return
+		}
+
+		readings[i].ID = int(id)
+
+		if !seen[readings[i].SectionID] {
+			seen[readings[i].SectionID] = true
+			sectionIDs = append(sectionIDs, readings[i].SectionID)
+		}
+	}
+
+	for _, sectionID := range sectionIDs {
+		_, err = tx.Exec(UpdateSectionTemperatureQuery, sectionID, sectionID)
+		if err != nil {
+			return
+		}
+
+		_, err = tx.Exec(UpdateProductBatchTemperatureQuery, sectionID, sectionID)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
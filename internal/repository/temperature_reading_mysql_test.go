@@ -0,0 +1,130 @@
+package repository_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemperatureReadingMysql_FindBySectionID(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	recordedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Readings found without a time range", func(t *testing.T) {
+		expected := []internal.TemperatureReading{
+			{ID: 1, SectionID: 1, RecordedAt: recordedAt, Celsius: 4.5},
+			{ID: 2, SectionID: 1, RecordedAt: recordedAt.Add(time.Minute), Celsius: 5},
+		}
+
+		rows := sqlmock.NewRows([]string{"id", "section_id", "recorded_at", "celsius"})
+		for _, tr := range expected {
+			rows.AddRow(tr.ID, tr.SectionID, tr.RecordedAt, tr.Celsius)
+		}
+
+		mock.ExpectQuery(repository.FindTemperatureReadingsBySectionQuery + repository.FindTemperatureReadingsOrderBy).
+			WithArgs(1).WillReturnRows(rows)
+
+		rp := repository.NewTemperatureReadingMysql(db)
+		readings, err := rp.FindBySectionID(1, time.Time{}, time.Time{})
+
+		require.NoError(t, err)
+		require.Equal(t, expected, readings)
+	})
+
+	t.Run("case 2: success - Readings filtered by time range", func(t *testing.T) {
+		from := recordedAt
+		to := recordedAt.Add(time.Hour)
+
+		query := repository.FindTemperatureReadingsBySectionQuery +
+			" AND tr.recorded_at >= ? AND tr.recorded_at <= ?" +
+			repository.FindTemperatureReadingsOrderBy
+
+		mock.ExpectQuery(query).WithArgs(1, from, to).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "section_id", "recorded_at", "celsius"}))
+
+		rp := repository.NewTemperatureReadingMysql(db)
+		readings, err := rp.FindBySectionID(1, from, to)
+
+		require.NoError(t, err)
+		require.Empty(t, readings)
+	})
+
+	t.Run("case 3: error - Error executing the query", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindTemperatureReadingsBySectionQuery + repository.FindTemperatureReadingsOrderBy).
+			WithArgs(1).WillReturnError(sql.ErrConnDone)
+
+		rp := repository.NewTemperatureReadingMysql(db)
+		_, err := rp.FindBySectionID(1, time.Time{}, time.Time{})
+
+		require.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
+
+func TestTemperatureReadingMysql_SaveBatch(t *testing.T) {
+	recordedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Readings stored and current temperatures refreshed once per section", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		readings := []internal.TemperatureReading{
+			{SectionID: 1, RecordedAt: recordedAt, Celsius: 4.5},
+			{SectionID: 2, RecordedAt: recordedAt, Celsius: -18},
+			{SectionID: 1, RecordedAt: recordedAt.Add(time.Minute), Celsius: 5},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(repository.InsertTemperatureReadingQuery).WithArgs(1, recordedAt, 4.5).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(repository.InsertTemperatureReadingQuery).WithArgs(2, recordedAt, -18.0).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectExec(repository.InsertTemperatureReadingQuery).WithArgs(1, recordedAt.Add(time.Minute), 5.0).
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectExec(repository.UpdateSectionTemperatureQuery).WithArgs(1, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.UpdateProductBatchTemperatureQuery).WithArgs(1, 1).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(repository.UpdateSectionTemperatureQuery).WithArgs(2, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.UpdateProductBatchTemperatureQuery).WithArgs(2, 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		rp := repository.NewTemperatureReadingMysql(db)
+		err = rp.SaveBatch(readings)
+
+		require.NoError(t, err)
+		require.Equal(t, 1, readings[0].ID)
+		require.Equal(t, 2, readings[1].ID)
+		require.Equal(t, 3, readings[2].ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: error - Insert fails and the batch is rolled back", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		readings := []internal.TemperatureReading{{SectionID: 1, RecordedAt: recordedAt, Celsius: 4.5}}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(repository.InsertTemperatureReadingQuery).WithArgs(1, recordedAt, 4.5).
+			WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		rp := repository.NewTemperatureReadingMysql(db)
+		err = rp.SaveBatch(readings)
+
+		require.ErrorIs(t, err, sql.ErrConnDone)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,379 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+const (
+	FindTransfersQuery = `
+		SELECT t.id, t.product_batch_id, t.destination_product_batch_id, t.from_section_id, t.to_section_id, t.quantity, t.status,
+			t.employee_id, t.dispatched_by, t.received_by, t.created_at, t.dispatched_at, t.received_at
+		FROM transfers AS t`
+	FindTransfersOrderBy        = " ORDER BY t.created_at, t.id"
+	FindTransferByIDQuery       = FindTransfersQuery + " WHERE t.id = ?"
+	InsertTransferQuery         = "INSERT INTO `transfers` (`product_batch_id`, `destination_product_batch_id`, `from_section_id`, `to_section_id`, `quantity`, `status`, `employee_id`, `dispatched_by`, `received_by`, `created_at`, `dispatched_at`, `received_at`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	LockTransferQuery           = "SELECT `product_batch_id`, `from_section_id`, `to_section_id`, `quantity`, `status` FROM `transfers` WHERE `id` = ? FOR UPDATE"
+	UpdateTransferDispatchQuery = "UPDATE `transfers` SET `status` = ?, `dispatched_by` = ?, `dispatched_at` = ? WHERE `id` = ?"
+	UpdateTransferReceiptQuery  = "UPDATE `transfers` SET `status` = ?, `destination_product_batch_id` = ?, `received_by` = ?, `received_at` = ? WHERE `id` = ?"
+	// LockProductBatchAvailableQuery locks the source batch and reads the stock not reserved by purchase orders
+	LockProductBatchAvailableQuery = `
+		SELECT pb.current_quantity - COALESCE((SELECT SUM(por.quantity) FROM purchase_order_reservations AS por WHERE por.product_batch_id = pb.id AND por.consumed = 0), 0)
+		FROM product_batches AS pb
+		WHERE pb.id = ?
+		FOR UPDATE`
+	// SplitProductBatchQuery keeps the batch number, the new batch belongs to the same lot so recalls by number reach it.
+	// It also keeps the status, a hold placed while the stock was in transit applies to the split too.
+	SplitProductBatchQuery = `
+		INSERT INTO product_batches (batch_number, current_quantity, current_temperature, due_date, initial_quantity,
+			manufacturing_date, manufacturing_hour, minumum_temperature, product_id, section_id, status)
+		SELECT batch_number, 0, current_temperature, due_date, ?, manufacturing_date, manufacturing_hour, minumum_temperature, product_id, ?, status
+		FROM product_batches WHERE id = ?`
+)
+
+// NewTransferMysql creates a new instance of the transfer repository
+func NewTransferMysql(db *sql.DB) *TransferMysql {
+	return &TransferMysql{db}
+}
+
+// TransferMysql is the mysql implementation of the transfer repository
+type TransferMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// FindAll returns the transfers ordered by creation, only the ones in the given status when it is not empty
+func (r *TransferMysql) FindAll(status string) (transfers []internal.Transfer, err error) {
+	query := FindTransfersQuery
+	var args []any
+
+	if status != "" {
+		query += " WHERE t.status = ?"
+		args = append(args, status)
+	}
+
+	rows, err := r.db.Query(query+FindTransfersOrderBy, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t internal.Transfer
+
+		t, err = scanTransfer(rows)
+		if err != nil {
+			return
+		}
+
+		transfers = append(transfers, t)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// FindByID returns the transfer with the given ID
+func (r *TransferMysql) FindByID(id int) (internal.Transfer, error) {
+	t, err := scanTransfer(r.db.QueryRow(FindTransferByIDQuery, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return internal.Transfer{}, internal.ErrTransferNotFound
+		}
+
+		return internal.Transfer{}, err
+	}
+
+	return t, nil
+}
+
+// Save records the transfer. A received transfer moves its stock in the same transaction,
+// the whole batch is stored in the destination section or it is split when only part of it is moved.
+func (r *TransferMysql) Save(t *internal.Transfer) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	if t.Status == internal.TransferStatusReceived {
+		var (
+			currentQuantity int
+			status          string
+			sectionID       int
+		)
+
+		err = tx.QueryRow(LockProductBatchQuantityQuery, t.ProductBatchID).Scan(&currentQuantity, &status, &sectionID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = internal.ErrProductBatchNotFound
+			}
+
+			return
+		}
+
+		if status != internal.ProductBatchStatusReleased {
+			err = internal.ErrProductBatchOnHold
+			return
+		}
+
+		if t.Quantity == currentQuantity {
+			err = moveProductBatch(tx, t.ProductBatchID, t.ToSectionID)
+			if err != nil {
+				return
+			}
+
+			t.DestinationProductBatchID = t.ProductBatchID
+		} else {
+			err = dispatchTransferStock(tx, t, t.CreatedAt)
+			if err != nil {
+				return
+			}
+
+			t.DestinationProductBatchID, err = receiveTransferStock(tx, t, t.CreatedAt)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	result, err := tx.Exec(InsertTransferQuery,
+		t.ProductBatchID,
+		nullableID(t.DestinationProductBatchID),
+		t.FromSectionID,
+		t.ToSectionID,
+		t.Quantity,
+		t.Status,
+		t.EmployeeID,
+		nullableID(t.DispatchedBy),
+		nullableID(t.ReceivedBy),
+		t.CreatedAt,
+		t.DispatchedAt,
+		t.ReceivedAt,
+	)
+	if err != nil {
+		return
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return
+	}
+
+	t.ID = int(id)
+
+	return
+}
+
+// Dispatch locks the transfer, takes its quantity out of the source batch and puts it in transit
+func (r *TransferMysql) Dispatch(t *internal.Transfer) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	err = lockTransfer(tx, t, internal.TransferStatusInTransit)
+	if err != nil {
+		return
+	}
+
+	err = dispatchTransferStock(tx, t, *t.DispatchedAt)
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec(UpdateTransferDispatchQuery, internal.TransferStatusInTransit, t.DispatchedBy, t.DispatchedAt, t.ID)
+	if err != nil {
+		return
+	}
+
+	t.Status = internal.TransferStatusInTransit
+
+	return
+}
+
+// Receive locks the transfer, stores its quantity in a split of the batch in the destination section and marks it as received
+func (r *TransferMysql) Receive(t *internal.Transfer) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	err = lockTransfer(tx, t, internal.TransferStatusReceived)
+	if err != nil {
+		return
+	}
+
+	t.DestinationProductBatchID, err = receiveTransferStock(tx, t, *t.ReceivedAt)
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec(UpdateTransferReceiptQuery, internal.TransferStatusReceived, t.DestinationProductBatchID, t.ReceivedBy, t.ReceivedAt, t.ID)
+	if err != nil {
+		return
+	}
+
+	t.Status = internal.TransferStatusReceived
+
+	return
+}
+
+// lockTransfer loads the transfer under a lock and checks it can move to the given status
+func lockTransfer(tx *sql.Tx, t *internal.Transfer, to string) error {
+	err := tx.QueryRow(LockTransferQuery, t.ID).Scan(&t.ProductBatchID, &t.FromSectionID, &t.ToSectionID, &t.Quantity, &t.Status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return internal.ErrTransferNotFound
+		}
+
+		return err
+	}
+
+	if !internal.CanTransferTransition(t.Status, to) {
+		return internal.ErrTransferInvalidTransition
+	}
+
+	return nil
+}
+
+// dispatchTransferStock takes the transferred quantity out of the source batch and its section.
+// Stock reserved by purchase orders stays in the batch for them to pick.
+func dispatchTransferStock(tx *sql.Tx, t *internal.Transfer, at time.Time) error {
+	var available int
+
+	err := tx.QueryRow(LockProductBatchAvailableQuery, t.ProductBatchID).Scan(&available)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return internal.ErrProductBatchNotFound
+		}
+
+		return err
+	}
+
+	if available < t.Quantity {
+		return internal.ErrTransferStockReserved
+	}
+
+	return applyStockMovement(tx, &internal.StockMovement{
+		ProductBatchID: t.ProductBatchID,
+		Type:           internal.StockMovementTransferOut,
+		Quantity:       t.Quantity,
+		Reason:         fmt.Sprintf("transfer to section %d", t.ToSectionID),
+		CreatedAt:      at,
+	})
+}
+
+// receiveTransferStock splits the source batch into the destination section and adds the transferred quantity to it.
+// It returns the ID of the new batch.
+func receiveTransferStock(tx *sql.Tx, t *internal.Transfer, at time.Time) (int, error) {
+	var status string
+
+	err := tx.QueryRow(LockProductBatchStatusQuery, t.ProductBatchID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, internal.ErrProductBatchNotFound
+		}
+
+		return 0, err
+	}
+
+	result, err := tx.Exec(SplitProductBatchQuery, t.Quantity, t.ToSectionID, t.ProductBatchID)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	err = applyStockMovement(tx, &internal.StockMovement{
+		ProductBatchID: int(id),
+		Type:           internal.StockMovementTransferIn,
+		Quantity:       t.Quantity,
+		Reason:         fmt.Sprintf("transfer from section %d", t.FromSectionID),
+		CreatedAt:      at,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// the split inherits the hold of the source batch, its history says where it came from
+	if status != internal.ProductBatchStatusReleased {
+		_, err = tx.Exec(InsertProductBatchHoldQuery, id, internal.ProductBatchStatusReleased, status,
+			fmt.Sprintf("held with batch %d while in transit", t.ProductBatchID), t.ReceivedBy, at)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return int(id), nil
+}
+
+// scanTransfer reads a transfer from a row of FindTransfersQuery
+func scanTransfer(row interface{ Scan(dest ...any) error }) (t internal.Transfer, err error) {
+	var (
+		destinationProductBatchID sql.NullInt64
+		dispatchedBy              sql.NullInt64
+		receivedBy                sql.NullInt64
+		dispatchedAt              sql.NullTime
+		receivedAt                sql.NullTime
+	)
+
+	err = row.Scan(&t.ID, &t.ProductBatchID, &destinationProductBatchID, &t.FromSectionID, &t.ToSectionID, &t.Quantity, &t.Status,
+		&t.EmployeeID, &dispatchedBy, &receivedBy, &t.CreatedAt, &dispatchedAt, &receivedAt)
+	if err != nil {
+		return
+	}
+
+	t.DestinationProductBatchID = int(destinationProductBatchID.Int64)
+	t.DispatchedBy = int(dispatchedBy.Int64)
+	t.ReceivedBy = int(receivedBy.Int64)
+
+	if dispatchedAt.Valid {
+		t.DispatchedAt = &dispatchedAt.Time
+	}
+
+	if receivedAt.Valid {
+		t.ReceivedAt = &receivedAt.Time
+	}
+
+	return
+}
+
+// nullableID stores zero IDs as NULL
+func nullableID(id int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(id), Valid: id != 0}
+}
@@ -0,0 +1,279 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+var transferColumns = []string{"id", "product_batch_id", "destination_product_batch_id", "from_section_id", "to_section_id", "quantity", "status",
+	"employee_id", "dispatched_by", "received_by", "created_at", "dispatched_at", "received_at"}
+
+func TestTransferMysql_FindAll(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	dispatchedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Transfers in transit", func(t *testing.T) {
+		mock.ExpectQuery(repository.FindTransfersQuery + " WHERE t.status = ?" + repository.FindTransfersOrderBy).WithArgs(internal.TransferStatusInTransit).
+			WillReturnRows(sqlmock.NewRows(transferColumns).AddRow(1, 1, nil, 3, 9, 40, internal.TransferStatusInTransit, 2, 2, nil, createdAt, dispatchedAt, nil))
+
+		rp := repository.NewTransferMysql(db)
+		transfers, err := rp.FindAll(internal.TransferStatusInTransit)
+
+		require.NoError(t, err)
+		require.Equal(t, []internal.Transfer{
+			{ID: 1, ProductBatchID: 1, FromSectionID: 3, ToSectionID: 9, Quantity: 40, Status: internal.TransferStatusInTransit, EmployeeID: 2, DispatchedBy: 2, CreatedAt: createdAt, DispatchedAt: &dispatchedAt},
+		}, transfers)
+	})
+}
+
+func TestTransferMysql_Save(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Whole batch moved inside a warehouse", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		tr := internal.Transfer{ProductBatchID: 1, FromSectionID: 3, ToSectionID: 5, Quantity: 100, Status: internal.TransferStatusReceived,
+			EmployeeID: 2, DispatchedBy: 2, ReceivedBy: 2, CreatedAt: createdAt, DispatchedAt: &createdAt, ReceivedAt: &createdAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "status", "section_id"}).AddRow(100, "released", 3))
+		mock.ExpectQuery(repository.LockProductBatchSectionQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "section_id"}).AddRow(100, 3))
+		mock.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(100, 500))
+		mock.ExpectExec(repository.UpdateSectionCapacityQuery).WithArgs(0, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(5).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(0, 500))
+		mock.ExpectExec(repository.UpdateSectionCapacityQuery).WithArgs(100, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.UpdateProductBatchSectionQuery).WithArgs(5, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.InsertTransferQuery).WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.ExpectCommit()
+
+		rp := repository.NewTransferMysql(db)
+		err = rp.Save(&tr)
+
+		require.NoError(t, err)
+		require.Equal(t, 7, tr.ID)
+		require.Equal(t, 1, tr.DestinationProductBatchID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: success - Batch split inside a warehouse", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		tr := internal.Transfer{ProductBatchID: 1, FromSectionID: 3, ToSectionID: 5, Quantity: 40, Status: internal.TransferStatusReceived,
+			EmployeeID: 2, DispatchedBy: 2, ReceivedBy: 2, CreatedAt: createdAt, DispatchedAt: &createdAt, ReceivedAt: &createdAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "status", "section_id"}).AddRow(100, "released", 3))
+		// transfer out of the source batch
+		mock.ExpectQuery(repository.LockProductBatchAvailableQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"available_quantity"}).AddRow(100))
+		mock.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "status", "section_id"}).AddRow(100, "released", 3))
+		mock.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(100, 500))
+		mock.ExpectExec(repository.UpdateSectionCapacityQuery).WithArgs(60, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.UpdateProductBatchQuantityQuery).WithArgs(60, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.InsertStockMovementQuery).WithArgs(1, internal.StockMovementTransferOut, 40, "transfer to section 5", 60, createdAt).
+			WillReturnResult(sqlmock.NewResult(10, 1))
+		// transfer in to the split batch
+		mock.ExpectQuery(repository.LockProductBatchStatusQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(internal.ProductBatchStatusReleased))
+		mock.ExpectExec(repository.SplitProductBatchQuery).WithArgs(40, 5, 1).WillReturnResult(sqlmock.NewResult(8, 1))
+		mock.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(8).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "status", "section_id"}).AddRow(0, "released", 5))
+		mock.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(5).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(0, 500))
+		mock.ExpectExec(repository.UpdateSectionCapacityQuery).WithArgs(40, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.UpdateProductBatchQuantityQuery).WithArgs(40, 8).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.InsertStockMovementQuery).WithArgs(8, internal.StockMovementTransferIn, 40, "transfer from section 3", 40, createdAt).
+			WillReturnResult(sqlmock.NewResult(11, 1))
+		mock.ExpectExec(repository.InsertTransferQuery).WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.ExpectCommit()
+
+		rp := repository.NewTransferMysql(db)
+		err = rp.Save(&tr)
+
+		require.NoError(t, err)
+		require.Equal(t, 8, tr.DestinationProductBatchID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 3: success - Pending transfer does not move stock", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		tr := internal.Transfer{ProductBatchID: 1, FromSectionID: 3, ToSectionID: 9, Quantity: 40, Status: internal.TransferStatusPending, EmployeeID: 2, CreatedAt: createdAt}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(repository.InsertTransferQuery).WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.ExpectCommit()
+
+		rp := repository.NewTransferMysql(db)
+		err = rp.Save(&tr)
+
+		require.NoError(t, err)
+		require.Equal(t, 7, tr.ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTransferMysql_Dispatch(t *testing.T) {
+	dispatchedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Stock leaves the source batch", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		tr := internal.Transfer{ID: 7, DispatchedBy: 2, DispatchedAt: &dispatchedAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockTransferQuery).WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"product_batch_id", "from_section_id", "to_section_id", "quantity", "status"}).AddRow(1, 3, 9, 40, internal.TransferStatusPending))
+		mock.ExpectQuery(repository.LockProductBatchAvailableQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"available_quantity"}).AddRow(70))
+		mock.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "status", "section_id"}).AddRow(100, "released", 3))
+		mock.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(100, 500))
+		mock.ExpectExec(repository.UpdateSectionCapacityQuery).WithArgs(60, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.UpdateProductBatchQuantityQuery).WithArgs(60, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.InsertStockMovementQuery).WithArgs(1, internal.StockMovementTransferOut, 40, "transfer to section 9", 60, dispatchedAt).
+			WillReturnResult(sqlmock.NewResult(10, 1))
+		mock.ExpectExec(repository.UpdateTransferDispatchQuery).WithArgs(internal.TransferStatusInTransit, 2, &dispatchedAt, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		rp := repository.NewTransferMysql(db)
+		err = rp.Dispatch(&tr)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.TransferStatusInTransit, tr.Status)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: error - Stock reserved by purchase orders", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		tr := internal.Transfer{ID: 7, DispatchedBy: 2, DispatchedAt: &dispatchedAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockTransferQuery).WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"product_batch_id", "from_section_id", "to_section_id", "quantity", "status"}).AddRow(1, 3, 9, 40, internal.TransferStatusPending))
+		// 100 units in the batch, 70 of them reserved
+		mock.ExpectQuery(repository.LockProductBatchAvailableQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"available_quantity"}).AddRow(30))
+		mock.ExpectRollback()
+
+		rp := repository.NewTransferMysql(db)
+		err = rp.Dispatch(&tr)
+
+		require.ErrorIs(t, err, internal.ErrTransferStockReserved)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 3: error - Transfer already dispatched", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		tr := internal.Transfer{ID: 7, DispatchedBy: 2, DispatchedAt: &dispatchedAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockTransferQuery).WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"product_batch_id", "from_section_id", "to_section_id", "quantity", "status"}).AddRow(1, 3, 9, 40, internal.TransferStatusInTransit))
+		mock.ExpectRollback()
+
+		rp := repository.NewTransferMysql(db)
+		err = rp.Dispatch(&tr)
+
+		require.ErrorIs(t, err, internal.ErrTransferInvalidTransition)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTransferMysql_Receive(t *testing.T) {
+	receivedAt := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: error - Destination section is full", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		tr := internal.Transfer{ID: 7, ReceivedBy: 3, ReceivedAt: &receivedAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockTransferQuery).WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"product_batch_id", "from_section_id", "to_section_id", "quantity", "status"}).AddRow(1, 3, 9, 40, internal.TransferStatusInTransit))
+		mock.ExpectQuery(repository.LockProductBatchStatusQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(internal.ProductBatchStatusReleased))
+		mock.ExpectExec(repository.SplitProductBatchQuery).WithArgs(40, 9, 1).WillReturnResult(sqlmock.NewResult(8, 1))
+		mock.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(8).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "status", "section_id"}).AddRow(0, "released", 9))
+		mock.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(9).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(480, 500))
+		mock.ExpectRollback()
+
+		rp := repository.NewTransferMysql(db)
+		err = rp.Receive(&tr)
+
+		require.ErrorAs(t, err, &internal.DomainError{})
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: success - Split keeps the hold placed while in transit", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		tr := internal.Transfer{ID: 7, ReceivedBy: 3, ReceivedAt: &receivedAt}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockTransferQuery).WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"product_batch_id", "from_section_id", "to_section_id", "quantity", "status"}).AddRow(1, 3, 9, 40, internal.TransferStatusInTransit))
+		mock.ExpectQuery(repository.LockProductBatchStatusQuery).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(internal.ProductBatchStatusQuarantined))
+		mock.ExpectExec(repository.SplitProductBatchQuery).WithArgs(40, 9, 1).WillReturnResult(sqlmock.NewResult(8, 1))
+		mock.ExpectQuery(repository.LockProductBatchQuantityQuery).WithArgs(8).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "status", "section_id"}).AddRow(0, internal.ProductBatchStatusQuarantined, 9))
+		mock.ExpectQuery(repository.LockSectionCapacityQuery).WithArgs(9).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(100, 500))
+		mock.ExpectExec(repository.UpdateSectionCapacityQuery).WithArgs(140, 9).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.UpdateProductBatchQuantityQuery).WithArgs(40, 8).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(repository.InsertStockMovementQuery).WithArgs(8, internal.StockMovementTransferIn, 40, "transfer from section 3", 40, receivedAt).
+			WillReturnResult(sqlmock.NewResult(11, 1))
+		mock.ExpectExec(repository.InsertProductBatchHoldQuery).
+			WithArgs(8, internal.ProductBatchStatusReleased, internal.ProductBatchStatusQuarantined, "held with batch 1 while in transit", 3, receivedAt).
+			WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectExec(repository.UpdateTransferReceiptQuery).WithArgs(internal.TransferStatusReceived, 8, 3, &receivedAt, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		rp := repository.NewTransferMysql(db)
+		err = rp.Receive(&tr)
+
+		require.NoError(t, err)
+		require.Equal(t, 8, tr.DestinationProductBatchID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
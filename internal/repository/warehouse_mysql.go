@@ -4,9 +4,23 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 )
 
+const FindWarehousesWithAvailableStockQuery = `
+	SELECT w.id, w.warehouse_code, w.address, w.telephone, w.minimum_capacity, w.minimum_temperature, w.locality_id, w.latitude, w.longitude,
+		SUM(pb.current_quantity - COALESCE((SELECT SUM(por.quantity) FROM purchase_order_reservations AS por WHERE por.product_batch_id = pb.id AND por.consumed = 0), 0)) AS available_quantity
+	FROM warehouses AS w
+	INNER JOIN sections AS s ON s.warehouse_id = w.id
+	INNER JOIN product_batches AS pb ON pb.section_id = s.id
+	WHERE pb.product_id = ? AND pb.due_date >= CURDATE() AND pb.status = 'released'
+		AND w.latitude IS NOT NULL AND w.longitude IS NOT NULL
+	GROUP BY w.id, w.warehouse_code, w.address, w.telephone, w.minimum_capacity, w.minimum_temperature, w.locality_id, w.latitude, w.longitude
+	HAVING available_quantity >= ?
+	ORDER BY w.id
+`
+
 func NewWarehouseMysqlRepository(db *sql.DB) *WarehouseMysqlRepository {
 	return &WarehouseMysqlRepository{db}
 }
@@ -20,7 +34,7 @@ func (w *WarehouseMysqlRepository) FindAll() ([]internal.Warehouse, error) {
 
 	query := `
 		SELECT
-			id, warehouse_code, address, telephone, minimum_capacity, minimum_temperature
+			id, warehouse_code, address, telephone, minimum_capacity, minimum_temperature, locality_id, latitude, longitude
 		FROM
 			warehouses;
 	`
@@ -34,7 +48,12 @@ func (w *WarehouseMysqlRepository) FindAll() ([]internal.Warehouse, error) {
 
 	// iterating over the rows
 	for rows.Next() {
-		var warehouse internal.Warehouse
+		var (
+			warehouse  internal.Warehouse
+			localityID sql.NullInt64
+			latitude   sql.NullFloat64
+			longitude  sql.NullFloat64
+		)
 		rows.Scan(
 			&warehouse.ID,
 			&warehouse.WarehouseCode,
@@ -42,7 +61,12 @@ func (w *WarehouseMysqlRepository) FindAll() ([]internal.Warehouse, error) {
 			&warehouse.Telephone,
 			&warehouse.MinimumCapacity,
 			&warehouse.MinimumTemperature,
+			&localityID,
+			&latitude,
+			&longitude,
 		)
+		warehouse.LocalityID = int(localityID.Int64)
+		warehouse.Coordinates = newCoordinates(latitude, longitude)
 
 		// appending the warehouse to the slice
 		warehouses = append(warehouses, warehouse)
@@ -58,14 +82,19 @@ func (w *WarehouseMysqlRepository) FindAll() ([]internal.Warehouse, error) {
 func (w *WarehouseMysqlRepository) FindByID(id int) (internal.Warehouse, error) {
 	query := `
 		SELECT
-			id, warehouse_code, address, telephone, minimum_capacity, minimum_temperature
+			id, warehouse_code, address, telephone, minimum_capacity, minimum_temperature, locality_id, latitude, longitude
 		FROM
 			warehouses
 		WHERE
 			id = ?;
 	`
 	// creating a new warehouse
-	var warehouse internal.Warehouse
+	var (
+		warehouse  internal.Warehouse
+		localityID sql.NullInt64
+		latitude   sql.NullFloat64
+		longitude  sql.NullFloat64
+	)
 	// executing the query
 	err := w.db.QueryRow(query, id).Scan(
 		&warehouse.ID,
@@ -74,6 +103,9 @@ func (w *WarehouseMysqlRepository) FindByID(id int) (internal.Warehouse, error)
 		&warehouse.Telephone,
 		&warehouse.MinimumCapacity,
 		&warehouse.MinimumTemperature,
+		&localityID,
+		&latitude,
+		&longitude,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -83,14 +115,18 @@ func (w *WarehouseMysqlRepository) FindByID(id int) (internal.Warehouse, error)
 		return warehouse, err
 	}
 
+	warehouse.LocalityID = int(localityID.Int64)
+	warehouse.Coordinates = newCoordinates(latitude, longitude)
+
 	return warehouse, nil
 }
 
 func (w *WarehouseMysqlRepository) Save(warehouse *internal.Warehouse) error {
 	query := `
-		INSERT INTO warehouses (warehouse_code, address, telephone, minimum_capacity, minimum_temperature)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO warehouses (warehouse_code, address, telephone, minimum_capacity, minimum_temperature, locality_id, latitude, longitude)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
+	latitude, longitude := coordinatesArgs((*warehouse).Coordinates)
 	// executing the query
 	result, err := w.db.Exec(
 		query,
@@ -99,9 +135,12 @@ func (w *WarehouseMysqlRepository) Save(warehouse *internal.Warehouse) error {
 		(*warehouse).Telephone,
 		(*warehouse).MinimumCapacity,
 		(*warehouse).MinimumTemperature,
+		localityIDArg((*warehouse).LocalityID),
+		latitude,
+		longitude,
 	)
 	if err != nil {
-		return err
+		return warehouseMysqlError(err)
 	}
 	// getting the ID of the last inserted warehouse
 	id, err := result.LastInsertId()
@@ -118,10 +157,11 @@ func (w *WarehouseMysqlRepository) Update(warehouse *internal.Warehouse) error {
 	query := `
 		UPDATE warehouses
 		SET
-			warehouse_code = ?, address = ?, telephone = ?, minimum_capacity = ?, minimum_temperature = ?
+			warehouse_code = ?, address = ?, telephone = ?, minimum_capacity = ?, minimum_temperature = ?, locality_id = ?, latitude = ?, longitude = ?
 		WHERE
 			id = ?;
 	`
+	latitude, longitude := coordinatesArgs(warehouse.Coordinates)
 
 	// executing the query
 	_, err := w.db.Exec(
@@ -131,10 +171,13 @@ func (w *WarehouseMysqlRepository) Update(warehouse *internal.Warehouse) error {
 		warehouse.Telephone,
 		warehouse.MinimumCapacity,
 		warehouse.MinimumTemperature,
+		localityIDArg(warehouse.LocalityID),
+		latitude,
+		longitude,
 		warehouse.ID,
 	)
 	if err != nil {
-		return err
+		return warehouseMysqlError(err)
 	}
 
 	return nil
@@ -155,3 +198,83 @@ func (w *WarehouseMysqlRepository) Delete(id int) error {
 
 	return nil
 }
+
+// FindWithAvailableStock returns the warehouses with coordinates holding at least the quantity
+// of released, unexpired and unreserved stock of the product
+func (w *WarehouseMysqlRepository) FindWithAvailableStock(productID int, quantity int) (warehouses []internal.NearestWarehouse, err error) {
+	rows, err := w.db.Query(FindWarehousesWithAvailableStockQuery, productID, quantity)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			nearest    internal.NearestWarehouse
+			localityID sql.NullInt64
+			latitude   sql.NullFloat64
+			longitude  sql.NullFloat64
+		)
+
+		err = rows.Scan(
+			&nearest.Warehouse.ID,
+			&nearest.Warehouse.WarehouseCode,
+			&nearest.Warehouse.Address,
+			&nearest.Warehouse.Telephone,
+			&nearest.Warehouse.MinimumCapacity,
+			&nearest.Warehouse.MinimumTemperature,
+			&localityID,
+			&latitude,
+			&longitude,
+			&nearest.AvailableQuantity,
+		)
+		if err != nil {
+			return
+		}
+
+		nearest.Warehouse.LocalityID = int(localityID.Int64)
+		nearest.Warehouse.Coordinates = newCoordinates(latitude, longitude)
+		warehouses = append(warehouses, nearest)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// newCoordinates returns the coordinates read from nullable latitude and longitude columns, nil when any is missing
+func newCoordinates(latitude, longitude sql.NullFloat64) *internal.Coordinates {
+	if !latitude.Valid || !longitude.Valid {
+		return nil
+	}
+
+	return &internal.Coordinates{Latitude: latitude.Float64, Longitude: longitude.Float64}
+}
+
+// coordinatesArgs returns the latitude and longitude to write in nullable columns
+func coordinatesArgs(c *internal.Coordinates) (latitude, longitude any) {
+	if c == nil {
+		return nil, nil
+	}
+
+	return c.Latitude, c.Longitude
+}
+
+// localityIDArg returns the locality of the warehouse to write in the nullable column
+func localityIDArg(localityID int) any {
+	if localityID == 0 {
+		return nil
+	}
+
+	return localityID
+}
+
+// warehouseMysqlError maps a missing locality reference to ErrLocalityNotFound
+func warehouseMysqlError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == 1452 {
+		return internal.ErrLocalityNotFound
+	}
+
+	return err
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 	"github.com/meli-fresh-products-api-backend-t1/internal/repository"
 	"github.com/stretchr/testify/require"
@@ -17,7 +18,7 @@ func TestWarehouse_FindAll(t *testing.T) {
 
 	query := `
 		SELECT
-			id, warehouse_code, address, telephone, minimum_capacity, minimum_temperature
+			id, warehouse_code, address, telephone, minimum_capacity, minimum_temperature, locality_id, latitude, longitude
 		FROM
 			warehouses;
 	`
@@ -42,9 +43,9 @@ func TestWarehouse_FindAll(t *testing.T) {
 			},
 		}
 
-		rows := sqlmock.NewRows([]string{"id", "warehouse_code", "address", "telephone", "minimum_capacity", "minimum_temperature"}).
-			AddRow(expectedWs[0].ID, expectedWs[0].WarehouseCode, expectedWs[0].Address, expectedWs[0].Telephone, expectedWs[0].MinimumCapacity, expectedWs[0].MinimumTemperature).
-			AddRow(expectedWs[1].ID, expectedWs[1].WarehouseCode, expectedWs[1].Address, expectedWs[1].Telephone, expectedWs[1].MinimumCapacity, expectedWs[1].MinimumTemperature)
+		rows := sqlmock.NewRows([]string{"id", "warehouse_code", "address", "telephone", "minimum_capacity", "minimum_temperature", "locality_id", "latitude", "longitude"}).
+			AddRow(expectedWs[0].ID, expectedWs[0].WarehouseCode, expectedWs[0].Address, expectedWs[0].Telephone, expectedWs[0].MinimumCapacity, expectedWs[0].MinimumTemperature, nil, nil, nil).
+			AddRow(expectedWs[1].ID, expectedWs[1].WarehouseCode, expectedWs[1].Address, expectedWs[1].Telephone, expectedWs[1].MinimumCapacity, expectedWs[1].MinimumTemperature, nil, nil, nil)
 
 		mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -65,8 +66,8 @@ func TestWarehouse_FindAll(t *testing.T) {
 	})
 
 	t.Run("case 3: error - Error iterating over the rows", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "warehouse_code", "address", "telephone", "minimum_capacity", "minimum_temperature"}).
-			AddRow(1, "123ABC", "address", "telephone", 1, 1).
+		rows := sqlmock.NewRows([]string{"id", "warehouse_code", "address", "telephone", "minimum_capacity", "minimum_temperature", "locality_id", "latitude", "longitude"}).
+			AddRow(1, "123ABC", "address", "telephone", 1, 1, nil, nil, nil).
 			RowError(0, sql.ErrConnDone)
 
 		mock.ExpectQuery(query).WillReturnRows(rows)
@@ -85,7 +86,7 @@ func TestWarehouse_FindByID(t *testing.T) {
 
 	query := `
 		SELECT
-			id, warehouse_code, address, telephone, minimum_capacity, minimum_temperature
+			id, warehouse_code, address, telephone, minimum_capacity, minimum_temperature, locality_id, latitude, longitude
 		FROM
 			warehouses
 		WHERE
@@ -101,10 +102,12 @@ func TestWarehouse_FindByID(t *testing.T) {
 			Telephone:          "telephone",
 			MinimumCapacity:    1,
 			MinimumTemperature: 1,
+			LocalityID:         3,
+			Coordinates:        &internal.Coordinates{Latitude: -23.5505, Longitude: -46.6333},
 		}
 
-		rows := sqlmock.NewRows([]string{"id", "warehouse_code", "address", "telephone", "minimum_capacity", "minimum_temperature"}).
-			AddRow(expectedW.ID, expectedW.WarehouseCode, expectedW.Address, expectedW.Telephone, expectedW.MinimumCapacity, expectedW.MinimumTemperature)
+		rows := sqlmock.NewRows([]string{"id", "warehouse_code", "address", "telephone", "minimum_capacity", "minimum_temperature", "locality_id", "latitude", "longitude"}).
+			AddRow(expectedW.ID, expectedW.WarehouseCode, expectedW.Address, expectedW.Telephone, expectedW.MinimumCapacity, expectedW.MinimumTemperature, 3, -23.5505, -46.6333)
 
 		mock.ExpectQuery(query).
 			WithArgs(id).
@@ -149,8 +152,8 @@ func TestWarehouseMysql_Save(t *testing.T) {
 	defer db.Close()
 
 	query := `
-		INSERT INTO warehouses (warehouse_code, address, telephone, minimum_capacity, minimum_temperature)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO warehouses (warehouse_code, address, telephone, minimum_capacity, minimum_temperature, locality_id, latitude, longitude)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	w := internal.Warehouse{
@@ -160,11 +163,12 @@ func TestWarehouseMysql_Save(t *testing.T) {
 		Telephone:          "telephone",
 		MinimumCapacity:    1,
 		MinimumTemperature: 1,
+		LocalityID:         1,
 	}
 
 	t.Run("case 1: success - Warehouse saved", func(t *testing.T) {
 		mock.ExpectExec(query).
-			WithArgs(w.WarehouseCode, w.Address, w.Telephone, w.MinimumCapacity, w.MinimumTemperature).
+			WithArgs(w.WarehouseCode, w.Address, w.Telephone, w.MinimumCapacity, w.MinimumTemperature, w.LocalityID, nil, nil).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		rp := repository.NewWarehouseMysqlRepository(db)
@@ -175,7 +179,7 @@ func TestWarehouseMysql_Save(t *testing.T) {
 
 	t.Run("case 2: error - Error executing the query", func(t *testing.T) {
 		mock.ExpectExec(query).
-			WithArgs(w.WarehouseCode, w.Address, w.Telephone, w.MinimumCapacity, w.MinimumTemperature).
+			WithArgs(w.WarehouseCode, w.Address, w.Telephone, w.MinimumCapacity, w.MinimumTemperature, w.LocalityID, nil, nil).
 			WillReturnError(sql.ErrConnDone)
 
 		rp := repository.NewWarehouseMysqlRepository(db)
@@ -184,9 +188,20 @@ func TestWarehouseMysql_Save(t *testing.T) {
 		require.Error(t, err)
 	})
 
-	t.Run("case 3: error - Error retrieving the last inserted ID", func(t *testing.T) {
+	t.Run("case 3: error - Locality not found", func(t *testing.T) {
 		mock.ExpectExec(query).
-			WithArgs(w.WarehouseCode, w.Address, w.Telephone, w.MinimumCapacity, w.MinimumTemperature).
+			WithArgs(w.WarehouseCode, w.Address, w.Telephone, w.MinimumCapacity, w.MinimumTemperature, w.LocalityID, nil, nil).
+			WillReturnError(&mysql.MySQLError{Number: 1452})
+
+		rp := repository.NewWarehouseMysqlRepository(db)
+		err := rp.Save(&w)
+
+		require.ErrorIs(t, err, internal.ErrLocalityNotFound)
+	})
+
+	t.Run("case 4: error - Error retrieving the last inserted ID", func(t *testing.T) {
+		mock.ExpectExec(query).
+			WithArgs(w.WarehouseCode, w.Address, w.Telephone, w.MinimumCapacity, w.MinimumTemperature, w.LocalityID, nil, nil).
 			WillReturnResult(sqlmock.NewErrorResult(sql.ErrConnDone))
 
 		rp := repository.NewWarehouseMysqlRepository(db)
@@ -205,7 +220,7 @@ func TestWarehouseMysql_Update(t *testing.T) {
 	query := `
 		UPDATE warehouses
 		SET
-			warehouse_code = ?, address = ?, telephone = ?, minimum_capacity = ?, minimum_temperature = ?
+			warehouse_code = ?, address = ?, telephone = ?, minimum_capacity = ?, minimum_temperature = ?, locality_id = ?, latitude = ?, longitude = ?
 		WHERE
 			id = ?;
 	`
@@ -217,11 +232,12 @@ func TestWarehouseMysql_Update(t *testing.T) {
 		Telephone:          "telephone",
 		MinimumCapacity:    1,
 		MinimumTemperature: 1,
+		LocalityID:         1,
 	}
 
 	t.Run("case 1: success - Warehouse updated", func(t *testing.T) {
 		mock.ExpectExec(query).
-			WithArgs(w.WarehouseCode, w.Address, w.Telephone, w.MinimumCapacity, w.MinimumTemperature, w.ID).
+			WithArgs(w.WarehouseCode, w.Address, w.Telephone, w.MinimumCapacity, w.MinimumTemperature, w.LocalityID, nil, nil, w.ID).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		rp := repository.NewWarehouseMysqlRepository(db)
@@ -232,7 +248,7 @@ func TestWarehouseMysql_Update(t *testing.T) {
 
 	t.Run("case 2: error - Error executing the query", func(t *testing.T) {
 		mock.ExpectExec(query).
-			WithArgs(w.WarehouseCode, w.Address, w.Telephone, w.MinimumCapacity, w.MinimumTemperature, w.ID).
+			WithArgs(w.WarehouseCode, w.Address, w.Telephone, w.MinimumCapacity, w.MinimumTemperature, w.LocalityID, nil, nil, w.ID).
 			WillReturnError(sql.ErrConnDone)
 
 		rp := repository.NewWarehouseMysqlRepository(db)
@@ -279,3 +295,51 @@ func TestWarehouseMysql_Delete(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestWarehouseMysql_FindWithAvailableStock(t *testing.T) {
+	columns := []string{"id", "warehouse_code", "address", "telephone", "minimum_capacity", "minimum_temperature", "locality_id", "latitude", "longitude", "available_quantity"}
+
+	t.Run("case 1: success - Warehouses holding the stock found", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(repository.FindWarehousesWithAvailableStockQuery).
+			WithArgs(1, 10).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "DHM", "address", "11 5555-1002", 10, -5, 2, 34.0522, -118.2437, 25))
+
+		rp := repository.NewWarehouseMysqlRepository(db)
+		ws, err := rp.FindWithAvailableStock(1, 10)
+
+		require.NoError(t, err)
+		require.Equal(t, []internal.NearestWarehouse{{
+			Warehouse: internal.Warehouse{
+				ID:                 2,
+				WarehouseCode:      "DHM",
+				Address:            "address",
+				Telephone:          "11 5555-1002",
+				MinimumCapacity:    10,
+				MinimumTemperature: -5,
+				LocalityID:         2,
+				Coordinates:        &internal.Coordinates{Latitude: 34.0522, Longitude: -118.2437},
+			},
+			AvailableQuantity: 25,
+		}}, ws)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("case 2: error - Error executing the query", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(repository.FindWarehousesWithAvailableStockQuery).
+			WithArgs(1, 10).
+			WillReturnError(sql.ErrConnDone)
+
+		rp := repository.NewWarehouseMysqlRepository(db)
+		_, err = rp.FindWithAvailableStock(1, 10)
+
+		require.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
@@ -1,6 +1,10 @@
 package internal
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"math"
+)
 
 var (
 	ErrSectionNotFound            = errors.New("section not found")
@@ -8,6 +12,12 @@ var (
 	ErrSectionNumberAlreadyInUse  = errors.New("section with given section number already registered")
 	ErrSectionUnprocessableEntity = errors.New("couldn't parse section")
 	ErrReportProductNotFound      = errors.New("report product not found")
+	// ErrSectionCapacityExceeded is returned when the stock of a section would go over its maximum capacity
+	ErrSectionCapacityExceeded = errors.New("section capacity exceeded")
+	// ErrSectionCurrentCapacityReadOnly is returned when a patch tries to set the current capacity, which only the stock movements change
+	ErrSectionCurrentCapacityReadOnly = errors.New("section current capacity is kept by its stock and cannot be patched")
+	// ErrSectionMaximumCapacityBelowCurrent is returned when the maximum capacity would not fit the stock already stored in the section
+	ErrSectionMaximumCapacityBelowCurrent = errors.New("section maximum capacity cannot be below its current capacity")
 )
 
 type Section struct {
@@ -33,6 +43,20 @@ type SectionPatch struct {
 	ProductTypeID      *int
 }
 
+// SectionOccupancy is a struct that represents how much of the section capacity is in use.
+// Capacities are counted in product units, the same as the batch quantities.
+type SectionOccupancy struct {
+	SectionID           int     `json:"section_id"`
+	SectionNumber       int     `json:"section_number"`
+	CurrentCapacity     int     `json:"current_capacity"`
+	MinimumCapacity     int     `json:"minimum_capacity"`
+	MaximumCapacity     int     `json:"maximum_capacity"`
+	AvailableCapacity   int     `json:"available_capacity"`
+	OccupancyPercentage float64 `json:"occupancy_percentage"`
+	BelowMinimum        bool    `json:"below_minimum"`
+	BatchesCount        int     `json:"batches_count"`
+}
+
 type ReportProduct struct {
 	SectionID     int `json:"section_id"`
 	SectionNumber int `json:"section_number"`
@@ -48,6 +72,8 @@ type SectionRepository interface {
 	Save(section *Section) error
 	Update(section *Section) error
 	Delete(id int) error
+	// FindOccupancy returns the capacities of the section and the number of batches stored in it
+	FindOccupancy(id int) (SectionOccupancy, error)
 }
 
 type SectionService interface {
@@ -58,6 +84,8 @@ type SectionService interface {
 	Save(section *Section) error
 	Update(id int, updateSection SectionPatch) (Section, error)
 	Delete(id int) error
+	// FindOccupancy returns how much of the section capacity is in use
+	FindOccupancy(id int) (SectionOccupancy, error)
 }
 
 func (s *Section) Ok() bool {
@@ -74,3 +102,24 @@ func (s *Section) Ok() bool {
 
 	return true
 }
+
+// SetUsage computes the available capacity and the occupancy from the section capacities
+func (o *SectionOccupancy) SetUsage() {
+	o.AvailableCapacity = max(o.MaximumCapacity-o.CurrentCapacity, 0)
+	o.BelowMinimum = o.CurrentCapacity < o.MinimumCapacity
+
+	if o.MaximumCapacity > 0 {
+		o.OccupancyPercentage = math.Round(float64(o.CurrentCapacity)/float64(o.MaximumCapacity)*10000) / 100
+	}
+}
+
+// NewSectionCapacityExceededError describes a stock change that does not fit in the section
+func NewSectionCapacityExceededError(sectionID int, available int, requested int) DomainError {
+	return DomainError{
+		Message: ErrSectionCapacityExceeded.Error(),
+		Causes: []Causes{{
+			Field:   "section_id",
+			Message: fmt.Sprintf("section %d has room for %d units but %d were requested", sectionID, available, requested),
+		}},
+	}
+}
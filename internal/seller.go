@@ -2,6 +2,10 @@ package internal
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/meli-fresh-products-api-backend-t1/utils/validator"
 )
 
@@ -19,6 +23,60 @@ type Seller struct {
 	Telephone string `json:"telephone"`
 	// Locality is the id of locality
 	Locality int `json:"locality_id"`
+	// Status is one of SellerStatuses, only active sellers can stock and sell their products
+	Status string `json:"status"`
+}
+
+const (
+	// SellerStatusActive is the status of the sellers that can stock and sell their products
+	SellerStatusActive = "active"
+	// SellerStatusSuspended is the status of the sellers whose products cannot get new batches nor orders
+	SellerStatusSuspended = "suspended"
+	// SellerStatusOffboarded is the status of the sellers that left, deleting a seller offboards it
+	SellerStatusOffboarded = "offboarded"
+)
+
+// SellerStatuses are the statuses a seller can be in
+var SellerStatuses = []string{SellerStatusActive, SellerStatusSuspended, SellerStatusOffboarded}
+
+// SellerReport is the catalogue and sales performance of a seller
+type SellerReport struct {
+	SellerID      int
+	CID           int
+	CompanyName   string
+	ProductsCount int
+	// LiveQuantity is the current quantity of the released and unexpired batches of the seller's products
+	LiveQuantity int
+	// UnitsSold is the quantity ordered in purchase orders that were shipped or delivered
+	UnitsSold int
+	// Revenue is the units sold priced at the product record sale price their order lines were priced with
+	Revenue float64
+	// ExpiredQuantity is the current quantity of the batches past their due date
+	ExpiredQuantity int
+	// WrittenOffQuantity is the quantity removed from the batches by write-off stock movements
+	WrittenOffQuantity int
+}
+
+const (
+	// SellerReportDefaultLimit is the page size of the sellers report when none is given
+	SellerReportDefaultLimit = 20
+	// SellerReportMaxLimit is the largest page size of the sellers report
+	SellerReportMaxLimit = 100
+)
+
+// SellerReportSortFields are the fields the sellers report can be sorted by
+var SellerReportSortFields = []string{
+	"id", "company_name", "products_count", "live_quantity", "units_sold", "revenue", "expired_quantity", "written_off_quantity",
+}
+
+// SellerReportQuery sorts and paginates the report of every seller
+type SellerReportQuery struct {
+	// SortBy is one of SellerReportSortFields
+	SortBy string
+	// Order is asc or desc
+	Order  string
+	Limit  int
+	Offset int
 }
 
 type SellerPatch struct {
@@ -27,6 +85,7 @@ type SellerPatch struct {
 	Address     *string
 	Telephone   *string
 	Locality    *int
+	Status      *string
 }
 
 func (sl *Seller) Validate() (causes []Causes) {
@@ -72,6 +131,46 @@ func (sl *Seller) Validate() (causes []Causes) {
 		})
 	}
 
+	if !slices.Contains(SellerStatuses, sl.Status) {
+		causes = append(causes, Causes{
+			Field:   "status",
+			Message: "Status must be one of " + strings.Join(SellerStatuses, ", "),
+		})
+	}
+
+	return causes
+}
+
+// Validate validates the sort and pagination of the sellers report
+func (q *SellerReportQuery) Validate() (causes []Causes) {
+	if !slices.Contains(SellerReportSortFields, q.SortBy) {
+		causes = append(causes, Causes{
+			Field:   "sort",
+			Message: "sort must be one of " + strings.Join(SellerReportSortFields, ", "),
+		})
+	}
+
+	if q.Order != "asc" && q.Order != "desc" {
+		causes = append(causes, Causes{
+			Field:   "order",
+			Message: "order must be asc or desc",
+		})
+	}
+
+	if !validator.IntBetween(q.Limit, 1, SellerReportMaxLimit) {
+		causes = append(causes, Causes{
+			Field:   "limit",
+			Message: fmt.Sprintf("limit must be between 1 and %d", SellerReportMaxLimit),
+		})
+	}
+
+	if validator.IntIsNegative(q.Offset) {
+		causes = append(causes, Causes{
+			Field:   "offset",
+			Message: "offset cannot be negative",
+		})
+	}
+
 	return causes
 }
 
@@ -82,6 +181,12 @@ var (
 	ErrSellerNotFound = errors.New("seller not found")
 	// ErrSellerConflict is returned when the seller already exists
 	ErrSellerConflict = errors.New("seller already exists")
+	// ErrSellerReportBadRequest is returned when the sort or pagination of the sellers report is invalid
+	ErrSellerReportBadRequest = errors.New("seller report inputs are invalid")
+	// ErrSellerNotActive is returned when the products of a suspended or offboarded seller get new batches or orders
+	ErrSellerNotActive = errors.New("seller is not active")
+	// ErrSellerHasHistory is returned when deleting a seller whose products have batches, records or orders
+	ErrSellerHasHistory = errors.New("seller has products with batches, records or orders")
 )
 
 // SellerRepository is an interface that contains the methods that the seller repository should support
@@ -96,8 +201,15 @@ type SellerRepository interface {
 	Save(seller *Seller) (err error)
 	// Update updates the given seller
 	Update(seller *Seller) (err error)
-	// Delete deletes the seller with the given ID
+	// Delete deletes the seller with the given ID and its products, it returns ErrSellerHasHistory
+	// when the products have batches, records or orders
 	Delete(id int) (err error)
+	// FindProducts returns the products of the seller with the given ID
+	FindProducts(id int) (products []Product, err error)
+	// Report returns a page of the report of every seller and the number of sellers
+	Report(query SellerReportQuery) (reports []SellerReport, total int, err error)
+	// ReportByID returns the report of the seller with the given ID
+	ReportByID(id int) (report SellerReport, err error)
 }
 
 // SellerService is an interface that contains the methods that the seller service should support
@@ -110,6 +222,14 @@ type SellerService interface {
 	Save(seller *Seller) error
 	// Update updates the given seller
 	Update(id int, updateSeller SellerPatch) (Seller, error)
-	// Delete deletes the seller with the given ID
+	// Delete offboards the seller with the given ID, its products are kept
 	Delete(id int) error
+	// HardDelete deletes the seller with the given ID and its products, unless they have history
+	HardDelete(id int) error
+	// FindProducts returns the products of the seller with the given ID
+	FindProducts(id int) ([]Product, error)
+	// Report returns a page of the report of every seller and the number of sellers
+	Report(query SellerReportQuery) ([]SellerReport, int, error)
+	// ReportByID returns the report of the seller with the given ID
+	ReportByID(id int) (SellerReport, error)
 }
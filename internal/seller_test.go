@@ -21,6 +21,7 @@ func TestSellerValidate(t *testing.T) {
 				CompanyName: "Test Company",
 				Address:     "Test Address",
 				Telephone:   "12 34546-7890",
+				Status:      SellerStatusActive,
 				Locality:    1,
 			},
 			wantErr: false,
@@ -34,6 +35,7 @@ func TestSellerValidate(t *testing.T) {
 				CompanyName: "Test Company",
 				Address:     "Test Address",
 				Telephone:   "12 34456-7890",
+				Status:      SellerStatusActive,
 				Locality:    1,
 			},
 			wantErr: true,
@@ -51,6 +53,7 @@ func TestSellerValidate(t *testing.T) {
 				CompanyName: "Test Company",
 				Address:     "Test Address",
 				Telephone:   "12 34456-7890",
+				Status:      SellerStatusActive,
 				Locality:    1,
 			},
 			wantErr: true,
@@ -68,6 +71,7 @@ func TestSellerValidate(t *testing.T) {
 				CompanyName: "Test Company",
 				Address:     "Test Address",
 				Telephone:   "12 34456-7890",
+				Status:      SellerStatusActive,
 				Locality:    1,
 			},
 			wantErr: true,
@@ -85,6 +89,7 @@ func TestSellerValidate(t *testing.T) {
 				CID:       1,
 				Address:   "Test Address",
 				Telephone: "12 34456-7890",
+				Status:    SellerStatusActive,
 				Locality:  1,
 			},
 			wantErr: true,
@@ -102,6 +107,7 @@ func TestSellerValidate(t *testing.T) {
 				CID:         1,
 				CompanyName: "Test Company",
 				Telephone:   "12 34456-7890",
+				Status:      SellerStatusActive,
 				Locality:    1,
 			},
 			wantErr: true,
@@ -120,6 +126,7 @@ func TestSellerValidate(t *testing.T) {
 				CompanyName: "Test Company",
 				Address:     "Test Address",
 				Telephone:   "1234432fds",
+				Status:      SellerStatusActive,
 				Locality:    1,
 			},
 			wantErr: true,
@@ -130,6 +137,25 @@ func TestSellerValidate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "invalid seller - unknown status",
+			seller: Seller{
+				ID:          1,
+				CID:         1,
+				CompanyName: "Test Company",
+				Address:     "Test Address",
+				Telephone:   "21 98888-8888",
+				Locality:    1,
+				Status:      "closed",
+			},
+			wantErr: true,
+			causes: []Causes{
+				{
+					Field:   "status",
+					Message: "Status must be one of active, suspended, offboarded",
+				},
+			},
+		},
 		{
 			name: "invalid seller - missing locality",
 			seller: Seller{
@@ -138,6 +164,7 @@ func TestSellerValidate(t *testing.T) {
 				CompanyName: "Test Company",
 				Address:     "Test Address",
 				Telephone:   "21 98888-8888",
+				Status:      SellerStatusActive,
 			},
 			wantErr: true,
 			causes: []Causes{
@@ -160,3 +187,24 @@ func TestSellerValidate(t *testing.T) {
 		})
 	}
 }
+
+// TestSellerReportQueryValidate tests the Validate method of the SellerReportQuery struct
+func TestSellerReportQueryValidate(t *testing.T) {
+	t.Run("valid query", func(t *testing.T) {
+		query := SellerReportQuery{SortBy: "units_sold", Order: "desc", Limit: SellerReportMaxLimit, Offset: 40}
+
+		assert.Empty(t, query.Validate())
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		query := SellerReportQuery{SortBy: "address", Order: "up", Limit: 0, Offset: -1}
+
+		causes := query.Validate()
+
+		assert.Equal(t, 4, len(causes))
+		assert.Equal(t, "sort", causes[0].Field)
+		assert.Equal(t, Causes{Field: "order", Message: "order must be asc or desc"}, causes[1])
+		assert.Equal(t, Causes{Field: "limit", Message: "limit must be between 1 and 100"}, causes[2])
+		assert.Equal(t, Causes{Field: "offset", Message: "offset cannot be negative"}, causes[3])
+	})
+}
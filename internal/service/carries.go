@@ -1,19 +1,256 @@
 package service
 
-import "github.com/meli-fresh-products-api-backend-t1/internal"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
 
 type CarriesService struct {
-	rp internal.CarriesRepository
+	rp   internal.CarriesRepository
+	rpL  internal.LocalityRepository
+	rpW  internal.WarehouseRepository
+	rpPL internal.PurchaseOrderLineRepository
+	rpP  internal.ProductRepository
 }
 
-func NewCarriesService(rp internal.CarriesRepository) *CarriesService {
-	return &CarriesService{rp}
+func NewCarriesService(
+	rp internal.CarriesRepository,
+	rpLocality internal.LocalityRepository,
+	rpWarehouse internal.WarehouseRepository,
+	rpPurchaseOrderLine internal.PurchaseOrderLineRepository,
+	rpProduct internal.ProductRepository,
+) *CarriesService {
+	return &CarriesService{
+		rp:   rp,
+		rpL:  rpLocality,
+		rpW:  rpWarehouse,
+		rpPL: rpPurchaseOrderLine,
+		rpP:  rpProduct,
+	}
 }
 
 func (sv *CarriesService) FindAll() ([]internal.Carries, error) {
 	return sv.rp.FindAll()
 }
 
+// FindByID returns the carry with the given ID
+func (sv *CarriesService) FindByID(id int) (internal.Carries, error) {
+	return sv.rp.FindByID(id)
+}
+
+// Create validates the carry and checks its locality exists before recording it
 func (sv *CarriesService) Create(carry internal.Carries) (lastID int64, e error) {
+	e = sv.validate(carry, "")
+	if e != nil {
+		return
+	}
+
 	return sv.rp.Create(carry)
 }
+
+// Update applies the given fields to the carry and validates it again
+func (sv *CarriesService) Update(id int, patch internal.CarriesPatchUpdate) (internal.Carries, error) {
+	carry, err := sv.rp.FindByID(id)
+	if err != nil {
+		return internal.Carries{}, err
+	}
+
+	storedPhoneNumber := carry.PhoneNumber
+
+	if patch.Cid != nil {
+		carry.Cid = *patch.Cid
+	}
+
+	if patch.CompanyName != nil {
+		carry.CompanyName = *patch.CompanyName
+	}
+
+	if patch.Address != nil {
+		carry.Address = *patch.Address
+	}
+
+	if patch.PhoneNumber != nil {
+		carry.PhoneNumber = *patch.PhoneNumber
+	}
+
+	if patch.LocalityID != nil {
+		carry.LocalityID = *patch.LocalityID
+	}
+
+	err = sv.validate(carry, storedPhoneNumber)
+	if err != nil {
+		return internal.Carries{}, err
+	}
+
+	err = sv.rp.Update(carry)
+	if err != nil {
+		return internal.Carries{}, err
+	}
+
+	return carry, nil
+}
+
+// Delete removes the carry with the given ID
+func (sv *CarriesService) Delete(id int) error {
+	return sv.rp.Delete(id)
+}
+
+// validate returns a DomainError when the carry breaks a business rule or its locality does not exist.
+// A phone number equal to the stored one is not checked, numbers recorded before the format was enforced are kept until they change.
+func (sv *CarriesService) validate(carry internal.Carries, storedPhoneNumber string) error {
+	var causes []internal.Causes
+	for _, cause := range carry.Validate() {
+		if cause.Field == "phone_number" && storedPhoneNumber != "" && carry.PhoneNumber == storedPhoneNumber {
+			continue
+		}
+
+		causes = append(causes, cause)
+	}
+
+	if carry.LocalityID > 0 {
+		_, err := sv.rpL.FindByID(carry.LocalityID)
+		if err != nil {
+			if !errors.Is(err, internal.ErrLocalityNotFound) {
+				return err
+			}
+
+			causes = append(causes, internal.Causes{
+				Field:   "locality_id",
+				Message: fmt.Sprintf("locality %d not found", carry.LocalityID),
+			})
+		}
+	}
+
+	if len(causes) > 0 {
+		return internal.DomainError{
+			Message: internal.ErrCarryBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	return nil
+}
+
+// FindCoverages returns the localities served by an existing carry
+func (sv *CarriesService) FindCoverages(carryID int) ([]internal.CarryCoverage, error) {
+	_, err := sv.rp.FindByID(carryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sv.rp.FindCoverages(carryID)
+}
+
+// CreateCoverage checks the carry and the locality exist and records the coverage
+func (sv *CarriesService) CreateCoverage(coverage *internal.CarryCoverage) error {
+	causes := coverage.Validate()
+	if len(causes) > 0 {
+		return internal.DomainError{
+			Message: internal.ErrCarryCoverageBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	_, err := sv.rp.FindByID(coverage.CarryID)
+	if err != nil {
+		return err
+	}
+
+	_, err = sv.rpL.FindByID(coverage.LocalityID)
+	if err != nil {
+		return err
+	}
+
+	return sv.rp.CreateCoverage(coverage)
+}
+
+// Quote prices the delivery from the warehouse to the locality with every carry serving it
+// that can pick up in the locality of the warehouse. The quotes are ranked by cost, then by lead time.
+func (sv *CarriesService) Quote(request internal.CarryQuoteRequest) ([]internal.CarryQuote, error) {
+	causes := request.Validate()
+	if len(causes) > 0 {
+		return nil, internal.DomainError{
+			Message: internal.ErrCarryQuoteBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	warehouse, err := sv.rpW.FindByID(request.OriginWarehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = sv.rpL.FindByID(request.DestinationLocalityID)
+	if err != nil {
+		return nil, err
+	}
+
+	weight := request.Weight
+	if request.PurchaseOrderID != 0 {
+		weight, err = sv.purchaseOrderWeight(request.PurchaseOrderID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Warehouses registered before the locality link cannot narrow the carries down
+	var quotes []internal.CarryQuote
+	if warehouse.LocalityID != 0 {
+		quotes, err = sv.rp.FindByRoute(warehouse.LocalityID, request.DestinationLocalityID)
+	} else {
+		quotes, err = sv.rp.FindByLocality(request.DestinationLocalityID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range quotes {
+		quotes[i].Weight = weight
+		quotes[i].Cost = math.Round(quotes[i].CostPerKg*weight*100) / 100
+	}
+
+	sort.SliceStable(quotes, func(i, j int) bool {
+		if quotes[i].Cost != quotes[j].Cost {
+			return quotes[i].Cost < quotes[j].Cost
+		}
+
+		return quotes[i].LeadTimeDays < quotes[j].LeadTimeDays
+	})
+
+	return quotes, nil
+}
+
+// purchaseOrderWeight returns the net weight of the products ordered in the purchase order
+func (sv *CarriesService) purchaseOrderWeight(purchaseOrderID int) (float64, error) {
+	lines, err := sv.rpPL.FindByPurchaseOrderID(purchaseOrderID)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(lines) == 0 {
+		return 0, internal.DomainError{
+			Message: internal.ErrCarryQuoteBadRequest.Error(),
+			Causes: []internal.Causes{{
+				Field:   "purchase_order_id",
+				Message: fmt.Sprintf("purchase order %d has no lines", purchaseOrderID),
+			}},
+		}
+	}
+
+	var weight float64
+
+	for _, line := range lines {
+		product, err := sv.rpP.FindByID(line.ProductID)
+		if err != nil {
+			return 0, err
+		}
+
+		weight += product.NetWeight * float64(line.Quantity)
+	}
+
+	return weight, nil
+}
@@ -17,8 +17,12 @@ type CarriesRepositoryMock struct {
 }
 
 type CarriesServiceTestSuite struct {
-	rp *CarriesRepositoryMock
-	sv *service.CarriesService
+	rp   *CarriesRepositoryMock
+	rpL  *localityRepositoryMock
+	rpW  *WarehouseRepositoryMock
+	rpPL *PurchaseOrderLineRepositoryMock
+	rpP  *RepositoryProductMock
+	sv   *service.CarriesService
 	suite.Suite
 }
 
@@ -31,14 +35,53 @@ func (m *CarriesRepositoryMock) FindAll() ([]internal.Carries, error) {
 	return args.Get(0).([]internal.Carries), args.Error(1)
 }
 
+func (m *CarriesRepositoryMock) FindByID(id int) (internal.Carries, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.Carries), args.Error(1)
+}
+
 func (m *CarriesRepositoryMock) Create(carry internal.Carries) (lastID int64, e error) {
 	args := m.Called(carry)
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *CarriesRepositoryMock) Update(carry internal.Carries) error {
+	args := m.Called(carry)
+	return args.Error(0)
+}
+
+func (m *CarriesRepositoryMock) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *CarriesRepositoryMock) FindCoverages(carryID int) ([]internal.CarryCoverage, error) {
+	args := m.Called(carryID)
+	return args.Get(0).([]internal.CarryCoverage), args.Error(1)
+}
+
+func (m *CarriesRepositoryMock) CreateCoverage(coverage *internal.CarryCoverage) error {
+	args := m.Called(coverage)
+	return args.Error(0)
+}
+
+func (m *CarriesRepositoryMock) FindByLocality(localityID int) ([]internal.CarryQuote, error) {
+	args := m.Called(localityID)
+	return args.Get(0).([]internal.CarryQuote), args.Error(1)
+}
+
+func (m *CarriesRepositoryMock) FindByRoute(originLocalityID int, destinationLocalityID int) ([]internal.CarryQuote, error) {
+	args := m.Called(originLocalityID, destinationLocalityID)
+	return args.Get(0).([]internal.CarryQuote), args.Error(1)
+}
+
 func (s *CarriesServiceTestSuite) SetupTest() {
 	s.rp = NewCarriesRepositoryMock()
-	s.sv = service.NewCarriesService(s.rp)
+	s.rpL = new(localityRepositoryMock)
+	s.rpW = NewWarehouseRepositoryMock()
+	s.rpPL = NewPurchaseOrderLineRepositoryMock()
+	s.rpP = NewRepositoryProductMock()
+	s.sv = service.NewCarriesService(s.rp, s.rpL, s.rpW, s.rpPL, s.rpP)
 }
 
 func (s *CarriesServiceTestSuite) TestFindAll() {
@@ -88,10 +131,11 @@ func (s *CarriesServiceTestSuite) TestCreate() {
 			Cid:         "CID000",
 			CompanyName: "Meli",
 			Address:     "OneTwoThree",
-			PhoneNumber: "119218912",
+			PhoneNumber: "11 91892-1912",
 			LocalityID:  1,
 		}
 		s.SetupTest()
+		s.rpL.On("FindByID", 1).Return(internal.Locality{ID: 1}, nil)
 		s.rp.On("Create", carry).Return(int64(0), nil)
 
 		lastID, e := s.sv.Create(carry)
@@ -104,10 +148,11 @@ func (s *CarriesServiceTestSuite) TestCreate() {
 			Cid:         "CID000",
 			CompanyName: "Meli",
 			Address:     "OneTwoThree",
-			PhoneNumber: "119218912",
+			PhoneNumber: "11 91892-1912",
 			LocalityID:  1,
 		}
 		s.SetupTest()
+		s.rpL.On("FindByID", 1).Return(internal.Locality{ID: 1}, nil)
 		s.rp.On("Create", carry).Return(int64(-1), errors.New("internal server error"))
 
 		lastID, e := s.sv.Create(carry)
@@ -117,6 +162,188 @@ func (s *CarriesServiceTestSuite) TestCreate() {
 	})
 }
 
+func (s *CarriesServiceTestSuite) TestCreateValidation() {
+	s.T().Run("failure - invalid phone number and locality not found", func(t *testing.T) {
+		s.SetupTest()
+		carry := internal.Carries{Cid: "CID000", CompanyName: "Meli", Address: "OneTwoThree", PhoneNumber: "119218912", LocalityID: 9}
+		s.rpL.On("FindByID", 9).Return(internal.Locality{}, internal.ErrLocalityNotFound)
+
+		_, e := s.sv.Create(carry)
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, e, &domainError)
+		require.Equal(t, []internal.Causes{
+			{Field: "phone_number", Message: "phone number is invalid, should be formatted as XX XXXXX-XXXX"},
+			{Field: "locality_id", Message: "locality 9 not found"},
+		}, domainError.Causes)
+		s.rp.AssertNumberOfCalls(t, "Create", 0)
+	})
+}
+
+func (s *CarriesServiceTestSuite) TestUpdate() {
+	carry := internal.Carries{ID: 1, Cid: "CID000", CompanyName: "Meli", Address: "OneTwoThree", PhoneNumber: "11 91892-1912", LocalityID: 1}
+
+	s.T().Run("success", func(t *testing.T) {
+		s.SetupTest()
+		companyName := "Meli Fresh"
+		updated := carry
+		updated.CompanyName = companyName
+		s.rp.On("FindByID", 1).Return(carry, nil)
+		s.rpL.On("FindByID", 1).Return(internal.Locality{ID: 1}, nil)
+		s.rp.On("Update", updated).Return(nil)
+
+		actualCarry, e := s.sv.Update(1, internal.CarriesPatchUpdate{CompanyName: &companyName})
+
+		require.NoError(t, e)
+		require.Equal(t, updated, actualCarry)
+	})
+	s.T().Run("failure - carry not found", func(t *testing.T) {
+		s.SetupTest()
+		s.rp.On("FindByID", 9).Return(internal.Carries{}, internal.ErrCarryNotFound)
+
+		_, e := s.sv.Update(9, internal.CarriesPatchUpdate{})
+
+		require.ErrorIs(t, e, internal.ErrCarryNotFound)
+		s.rp.AssertNumberOfCalls(t, "Update", 0)
+	})
+	s.T().Run("failure - duplicated cid", func(t *testing.T) {
+		s.SetupTest()
+		cid := "CID001"
+		updated := carry
+		updated.Cid = cid
+		s.rp.On("FindByID", 1).Return(carry, nil)
+		s.rpL.On("FindByID", 1).Return(internal.Locality{ID: 1}, nil)
+		s.rp.On("Update", updated).Return(internal.ErrCarryCidConflict)
+
+		_, e := s.sv.Update(1, internal.CarriesPatchUpdate{Cid: &cid})
+
+		require.ErrorIs(t, e, internal.ErrCarryCidConflict)
+	})
+	s.T().Run("success - stored phone number in the old format is kept", func(t *testing.T) {
+		s.SetupTest()
+		legacy := carry
+		legacy.PhoneNumber = "555-1001"
+		companyName := "Meli Fresh"
+		updated := legacy
+		updated.CompanyName = companyName
+		s.rp.On("FindByID", 1).Return(legacy, nil)
+		s.rpL.On("FindByID", 1).Return(internal.Locality{ID: 1}, nil)
+		s.rp.On("Update", updated).Return(nil)
+
+		actualCarry, e := s.sv.Update(1, internal.CarriesPatchUpdate{CompanyName: &companyName})
+
+		require.NoError(t, e)
+		require.Equal(t, updated, actualCarry)
+	})
+	s.T().Run("failure - new phone number in the old format", func(t *testing.T) {
+		s.SetupTest()
+		phoneNumber := "555-1002"
+		s.rp.On("FindByID", 1).Return(carry, nil)
+		s.rpL.On("FindByID", 1).Return(internal.Locality{ID: 1}, nil)
+
+		_, e := s.sv.Update(1, internal.CarriesPatchUpdate{PhoneNumber: &phoneNumber})
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, e, &domainError)
+		require.Equal(t, "phone_number", domainError.Causes[0].Field)
+		s.rp.AssertNumberOfCalls(t, "Update", 0)
+	})
+}
+
+func (s *CarriesServiceTestSuite) TestCreateCoverage() {
+	s.T().Run("success", func(t *testing.T) {
+		s.SetupTest()
+		coverage := internal.CarryCoverage{CarryID: 1, LocalityID: 2, LeadTimeDays: 3, CostPerKg: 1.5}
+		s.rp.On("FindByID", 1).Return(internal.Carries{ID: 1}, nil)
+		s.rpL.On("FindByID", 2).Return(internal.Locality{ID: 2}, nil)
+		s.rp.On("CreateCoverage", &coverage).Return(nil)
+
+		e := s.sv.CreateCoverage(&coverage)
+
+		require.NoError(t, e)
+	})
+	s.T().Run("locality not found", func(t *testing.T) {
+		s.SetupTest()
+		coverage := internal.CarryCoverage{CarryID: 1, LocalityID: 9, LeadTimeDays: 3, CostPerKg: 1.5}
+		s.rp.On("FindByID", 1).Return(internal.Carries{ID: 1}, nil)
+		s.rpL.On("FindByID", 9).Return(internal.Locality{}, internal.ErrLocalityNotFound)
+
+		e := s.sv.CreateCoverage(&coverage)
+
+		require.ErrorIs(t, e, internal.ErrLocalityNotFound)
+		s.rp.AssertNumberOfCalls(t, "CreateCoverage", 0)
+	})
+}
+
+func (s *CarriesServiceTestSuite) TestQuote() {
+	s.T().Run("success - ranked by cost then lead time", func(t *testing.T) {
+		s.SetupTest()
+		s.rpW.On("FindByID", 1).Return(internal.Warehouse{ID: 1}, nil)
+		s.rpL.On("FindByID", 2).Return(internal.Locality{ID: 2}, nil)
+		s.rp.On("FindByLocality", 2).Return([]internal.CarryQuote{
+			{CarryID: 1, LeadTimeDays: 2, CostPerKg: 3},
+			{CarryID: 2, LeadTimeDays: 4, CostPerKg: 1.5},
+			{CarryID: 3, LeadTimeDays: 1, CostPerKg: 1.5},
+		}, nil)
+
+		quotes, e := s.sv.Quote(internal.CarryQuoteRequest{OriginWarehouseID: 1, DestinationLocalityID: 2, Weight: 10})
+
+		require.NoError(t, e)
+		require.Equal(t, []internal.CarryQuote{
+			{CarryID: 3, LeadTimeDays: 1, CostPerKg: 1.5, Weight: 10, Cost: 15},
+			{CarryID: 2, LeadTimeDays: 4, CostPerKg: 1.5, Weight: 10, Cost: 15},
+			{CarryID: 1, LeadTimeDays: 2, CostPerKg: 3, Weight: 10, Cost: 30},
+		}, quotes)
+	})
+	s.T().Run("success - only carries picking up at the origin warehouse", func(t *testing.T) {
+		s.SetupTest()
+		s.rpW.On("FindByID", 1).Return(internal.Warehouse{ID: 1, LocalityID: 3}, nil)
+		s.rpL.On("FindByID", 2).Return(internal.Locality{ID: 2}, nil)
+		s.rp.On("FindByRoute", 3, 2).Return([]internal.CarryQuote{{CarryID: 2, LeadTimeDays: 1, CostPerKg: 1.75}}, nil)
+
+		quotes, e := s.sv.Quote(internal.CarryQuoteRequest{OriginWarehouseID: 1, DestinationLocalityID: 2, Weight: 10})
+
+		require.NoError(t, e)
+		require.Equal(t, []internal.CarryQuote{{CarryID: 2, LeadTimeDays: 1, CostPerKg: 1.75, Weight: 10, Cost: 17.5}}, quotes)
+		s.rp.AssertNumberOfCalls(t, "FindByLocality", 0)
+	})
+	s.T().Run("success - weight of a purchase order", func(t *testing.T) {
+		s.SetupTest()
+		s.rpW.On("FindByID", 1).Return(internal.Warehouse{ID: 1}, nil)
+		s.rpL.On("FindByID", 2).Return(internal.Locality{ID: 2}, nil)
+		s.rpPL.On("FindByPurchaseOrderID", 5).Return([]internal.PurchaseOrderLine{
+			{ID: 1, ProductID: 7, Quantity: 4},
+			{ID: 2, ProductID: 8, Quantity: 2},
+		}, nil)
+		s.rpP.On("FindByID", 7).Return(internal.Product{ID: 7, NetWeight: 0.5}, nil)
+		s.rpP.On("FindByID", 8).Return(internal.Product{ID: 8, NetWeight: 1.25}, nil)
+		s.rp.On("FindByLocality", 2).Return([]internal.CarryQuote{{CarryID: 1, LeadTimeDays: 2, CostPerKg: 2}}, nil)
+
+		quotes, e := s.sv.Quote(internal.CarryQuoteRequest{OriginWarehouseID: 1, DestinationLocalityID: 2, PurchaseOrderID: 5})
+
+		require.NoError(t, e)
+		require.Equal(t, []internal.CarryQuote{{CarryID: 1, LeadTimeDays: 2, CostPerKg: 2, Weight: 4.5, Cost: 9}}, quotes)
+	})
+	s.T().Run("failure - weight and purchase order together", func(t *testing.T) {
+		s.SetupTest()
+
+		_, e := s.sv.Quote(internal.CarryQuoteRequest{OriginWarehouseID: 1, DestinationLocalityID: 2, Weight: 3, PurchaseOrderID: 5})
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, e, &domainError)
+		require.Equal(t, "weight", domainError.Causes[0].Field)
+	})
+	s.T().Run("failure - warehouse not found", func(t *testing.T) {
+		s.SetupTest()
+		s.rpW.On("FindByID", 9).Return(internal.Warehouse{}, internal.ErrWarehouseRepositoryNotFound)
+
+		_, e := s.sv.Quote(internal.CarryQuoteRequest{OriginWarehouseID: 9, DestinationLocalityID: 2, Weight: 3})
+
+		require.ErrorIs(t, e, internal.ErrWarehouseRepositoryNotFound)
+		s.rp.AssertNumberOfCalls(t, "FindByLocality", 0)
+	})
+}
+
 func TestCarriesServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(CarriesServiceTestSuite))
 }
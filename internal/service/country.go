@@ -0,0 +1,54 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+// NewCountryService creates a new instance of the country service
+func NewCountryService(rp internal.CountryRepository) *CountryService {
+	return &CountryService{
+		rp: rp,
+	}
+}
+
+// CountryService is the implementation of the country service
+type CountryService struct {
+	rp internal.CountryRepository
+}
+
+// FindAll returns the countries
+func (s *CountryService) FindAll() ([]internal.Country, error) {
+	return s.rp.FindAll()
+}
+
+// FindByID returns the country with the given ID
+func (s *CountryService) FindByID(id int) (internal.Country, error) {
+	return s.rp.FindByID(id)
+}
+
+// Save trims the name of the country and records it
+func (s *CountryService) Save(c *internal.Country) error {
+	c.Name = strings.TrimSpace(c.Name)
+
+	causes := c.Validate()
+	if len(causes) > 0 {
+		return internal.DomainError{
+			Message: internal.ErrCountryBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	return s.rp.Save(c)
+}
+
+// Report returns the provinces, localities, sellers and carries of every country
+func (s *CountryService) Report() ([]internal.CountryReport, error) {
+	return s.rp.Report()
+}
+
+// ReportByID returns the provinces, localities, sellers and carries of the country with the given ID
+func (s *CountryService) ReportByID(id int) (internal.CountryReport, error) {
+	return s.rp.ReportByID(id)
+}
@@ -0,0 +1,107 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewCountryRepositoryMock() *CountryRepositoryMock {
+	return &CountryRepositoryMock{}
+}
+
+type CountryRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *CountryRepositoryMock) FindAll() ([]internal.Country, error) {
+	args := m.Called()
+	return args.Get(0).([]internal.Country), args.Error(1)
+}
+
+func (m *CountryRepositoryMock) FindByID(id int) (internal.Country, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.Country), args.Error(1)
+}
+
+func (m *CountryRepositoryMock) Save(c *internal.Country) error {
+	args := m.Called(c)
+	return args.Error(0)
+}
+
+func (m *CountryRepositoryMock) Report() ([]internal.CountryReport, error) {
+	args := m.Called()
+	return args.Get(0).([]internal.CountryReport), args.Error(1)
+}
+
+func (m *CountryRepositoryMock) ReportByID(id int) (internal.CountryReport, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.CountryReport), args.Error(1)
+}
+
+func TestCountryService_Save(t *testing.T) {
+	t.Run("case 1: success - Should trim the name and save the country", func(t *testing.T) {
+		rp := NewCountryRepositoryMock()
+		sv := service.NewCountryService(rp)
+
+		c := internal.Country{Name: "  Argentina "}
+		rp.On("Save", &c).Return(nil)
+
+		err := sv.Save(&c)
+
+		require.NoError(t, err)
+		require.Equal(t, "Argentina", c.Name)
+		rp.AssertNumberOfCalls(t, "Save", 1)
+	})
+
+	t.Run("case 2: error - Should reject a blank name", func(t *testing.T) {
+		rp := NewCountryRepositoryMock()
+		sv := service.NewCountryService(rp)
+
+		err := sv.Save(&internal.Country{Name: "   "})
+
+		require.ErrorAs(t, err, &internal.DomainError{})
+		rp.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("case 3: error - Should return the conflict of the repository", func(t *testing.T) {
+		rp := NewCountryRepositoryMock()
+		sv := service.NewCountryService(rp)
+
+		c := internal.Country{Name: "argentina"}
+		rp.On("Save", &c).Return(internal.ErrCountryConflict)
+
+		err := sv.Save(&c)
+
+		require.ErrorIs(t, err, internal.ErrCountryConflict)
+	})
+}
+
+func TestCountryService_Report(t *testing.T) {
+	t.Run("case 1: success - Should return the report of every country", func(t *testing.T) {
+		rp := NewCountryRepositoryMock()
+		sv := service.NewCountryService(rp)
+
+		expected := []internal.CountryReport{{CountryID: 1, CountryName: "United States", ProvincesCount: 2, LocalitiesCount: 3, SellersCount: 4, CarriesCount: 1}}
+		rp.On("Report").Return(expected, nil)
+
+		reports, err := sv.Report()
+
+		require.NoError(t, err)
+		require.Equal(t, expected, reports)
+	})
+
+	t.Run("case 2: error - Should return not found for an unknown country", func(t *testing.T) {
+		rp := NewCountryRepositoryMock()
+		sv := service.NewCountryService(rp)
+
+		rp.On("ReportByID", 99).Return(internal.CountryReport{}, internal.ErrCountryNotFound)
+
+		_, err := sv.ReportByID(99)
+
+		require.ErrorIs(t, err, internal.ErrCountryNotFound)
+	})
+}
@@ -1,6 +1,10 @@
 package service
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
+
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 )
 
@@ -9,21 +13,250 @@ type InboundOrderService struct {
 	rpE internal.EmployeeRepository
 	rpP internal.ProductBatchRepository
 	rpW internal.WarehouseRepository
+	rpS internal.SectionRepository
+	rpD internal.ProductRepository
+	rpC internal.ProductTypeCompatibilityRepository
 }
 
-func NewInboundOrderService(rpInbound internal.InboundOrdersRepository, rpEmployee internal.EmployeeRepository, rpProductBatch internal.ProductBatchRepository, rpWarehouse internal.WarehouseRepository) *InboundOrderService {
+func NewInboundOrderService(
+	rpInbound internal.InboundOrdersRepository,
+	rpEmployee internal.EmployeeRepository,
+	rpProductBatch internal.ProductBatchRepository,
+	rpWarehouse internal.WarehouseRepository,
+	rpSection internal.SectionRepository,
+	rpProduct internal.ProductRepository,
+	rpCompatibility internal.ProductTypeCompatibilityRepository,
+) *InboundOrderService {
 	return &InboundOrderService{
 		rp:  rpInbound,
 		rpE: rpEmployee,
 		rpP: rpProductBatch,
 		rpW: rpWarehouse,
+		rpS: rpSection,
+		rpD: rpProduct,
+		rpC: rpCompatibility,
 	}
 }
 
+// Create checks the inbound order against its employee, batches and warehouse and records the receipt
 func (s *InboundOrderService) Create(inboundOrder internal.InboundOrders) (int64, error) {
+	causes := inboundOrder.Validate()
+
+	warehouseCauses, err := s.validateWarehouse(inboundOrder)
+	if err != nil {
+		return 0, err
+	}
+
+	causes = append(causes, warehouseCauses...)
+
+	var batchCauses []internal.Causes
+	if len(inboundOrder.Lines) == 0 {
+		batchCauses, err = s.validateProductBatch(inboundOrder)
+	} else {
+		batchCauses, err = s.validateLines(inboundOrder)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	causes = append(causes, batchCauses...)
+
+	if len(causes) > 0 {
+		return 0, internal.DomainError{
+			Message: internal.ErrInboundOrderBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	if len(inboundOrder.Lines) > 0 {
+		inboundOrder.Quantity = 0
+		for _, line := range inboundOrder.Lines {
+			inboundOrder.Quantity += line.ReceivedQuantity
+		}
+	}
+
 	return s.rp.Create(inboundOrder)
 }
 
-func (s *InboundOrderService) FindAll() ([]internal.InboundOrders, error) {
-	return s.rp.FindAll()
+// FindAll returns the inbound orders that match the filter
+func (s *InboundOrderService) FindAll(filter internal.InboundOrderFilter) ([]internal.InboundOrders, error) {
+	return s.rp.FindAll(filter)
+}
+
+// FindByID returns the inbound order with the given ID
+func (s *InboundOrderService) FindByID(id int) (internal.InboundOrders, error) {
+	return s.rp.FindByID(id)
+}
+
+// FindSummary returns the daily received quantities per product type of an existing warehouse
+func (s *InboundOrderService) FindSummary(warehouseID int, filter internal.InboundOrderFilter) ([]internal.InboundOrderSummary, error) {
+	_, err := s.rpW.FindByID(warehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.rp.FindSummary(internal.InboundOrderFilter{
+		WarehouseID: warehouseID,
+		From:        filter.From,
+		To:          filter.To,
+	})
+}
+
+// FindDiscrepancies returns the lines of the inbound order that were not received as expected
+func (s *InboundOrderService) FindDiscrepancies(id int) ([]internal.InboundOrderDiscrepancy, error) {
+	inboundOrder, err := s.rp.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return inboundOrder.Discrepancies(), nil
+}
+
+// validateWarehouse returns a cause when the warehouse or the employee of the inbound order are missing
+// or the employee works in another warehouse
+func (s *InboundOrderService) validateWarehouse(inboundOrder internal.InboundOrders) (causes []internal.Causes, err error) {
+	_, err = s.rpW.FindByID(inboundOrder.WarehouseID)
+	if err != nil {
+		if !errors.Is(err, internal.ErrWarehouseRepositoryNotFound) {
+			return nil, err
+		}
+
+		causes = append(causes, internal.Causes{
+			Field:   "warehouse_id",
+			Message: fmt.Sprintf("warehouse %d not found", inboundOrder.WarehouseID),
+		})
+	}
+
+	employee, err := s.rpE.GetByID(inboundOrder.EmployeeID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		causes = append(causes, internal.Causes{
+			Field:   "employee_id",
+			Message: fmt.Sprintf("employee %d not found", inboundOrder.EmployeeID),
+		})
+	case err != nil:
+		return nil, err
+	case employee.WarehouseID != inboundOrder.WarehouseID:
+		causes = append(causes, internal.Causes{
+			Field:   "employee_id",
+			Message: fmt.Sprintf("employee %d works in warehouse %d, not in warehouse %d", inboundOrder.EmployeeID, employee.WarehouseID, inboundOrder.WarehouseID),
+		})
+	}
+
+	return causes, nil
+}
+
+// validateProductBatch returns a cause when the batch of a single batch order is missing or stored in another warehouse
+func (s *InboundOrderService) validateProductBatch(inboundOrder internal.InboundOrders) (causes []internal.Causes, err error) {
+	prodBatch, err := s.rpP.FindByID(inboundOrder.ProductBatchID)
+	if err != nil {
+		if !errors.Is(err, internal.ErrProductBatchNotFound) {
+			return nil, err
+		}
+
+		causes = append(causes, internal.Causes{
+			Field:   "product_batch_id",
+			Message: fmt.Sprintf("product batch %d not found", inboundOrder.ProductBatchID),
+		})
+
+		return causes, nil
+	}
+
+	section, err := s.rpS.FindByID(prodBatch.SectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if section.WarehouseID != inboundOrder.WarehouseID {
+		causes = append(causes, internal.Causes{
+			Field:   "product_batch_id",
+			Message: fmt.Sprintf("product batch %d is stored in warehouse %d, not in warehouse %d", inboundOrder.ProductBatchID, section.WarehouseID, inboundOrder.WarehouseID),
+		})
+	}
+
+	return causes, nil
+}
+
+// validateLines returns a cause for every line whose batch cannot be created in the warehouse of the order
+func (s *InboundOrderService) validateLines(inboundOrder internal.InboundOrders) ([]internal.Causes, error) {
+	var causes []internal.Causes
+
+	for i, line := range inboundOrder.Lines {
+		pb := line.ProductBatch
+		field := fmt.Sprintf("lines[%d].product_batch", i)
+
+		exists, err := s.rpP.ProductBatchNumberExists(pb.BatchNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		if exists {
+			causes = append(causes, internal.Causes{
+				Field:   field + ".batch_number",
+				Message: fmt.Sprintf("batch number %d is already registered", pb.BatchNumber),
+			})
+		}
+
+		product, productErr := s.rpD.FindByID(pb.ProductID)
+		if productErr != nil {
+			if !errors.Is(productErr, internal.ErrProductNotFound) {
+				return nil, productErr
+			}
+
+			causes = append(causes, internal.Causes{
+				Field:   field + ".product_id",
+				Message: fmt.Sprintf("product %d not found", pb.ProductID),
+			})
+		} else if err = product.Sellable(); err != nil {
+			causes = append(causes, internal.Causes{
+				Field:   field + ".product_id",
+				Message: fmt.Sprintf("product %d cannot get new batches, %s", pb.ProductID, err),
+			})
+		}
+
+		section, err := s.rpS.FindByID(pb.SectionID)
+		if err != nil {
+			if !errors.Is(err, internal.ErrSectionNotFound) {
+				return nil, err
+			}
+
+			causes = append(causes, internal.Causes{
+				Field:   field + ".section_id",
+				Message: fmt.Sprintf("section %d not found", pb.SectionID),
+			})
+
+			continue
+		}
+
+		if section.WarehouseID != inboundOrder.WarehouseID {
+			causes = append(causes, internal.Causes{
+				Field:   field + ".section_id",
+				Message: fmt.Sprintf("section %d is in warehouse %d, not in warehouse %d", pb.SectionID, section.WarehouseID, inboundOrder.WarehouseID),
+			})
+
+			continue
+		}
+
+		if productErr != nil {
+			continue
+		}
+
+		err = checkProductTypeCompatibility(s.rpC, product, section)
+		if err != nil {
+			var domainError internal.DomainError
+			if !errors.As(err, &domainError) {
+				return nil, err
+			}
+
+			for _, cause := range domainError.Causes {
+				causes = append(causes, internal.Causes{
+					Field:   field + "." + cause.Field,
+					Message: cause.Message,
+				})
+			}
+		}
+	}
+
+	return causes, nil
 }
@@ -1,8 +1,10 @@
 package service_test
 
 import (
+	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 	"github.com/meli-fresh-products-api-backend-t1/internal/service"
@@ -16,8 +18,14 @@ type InboundOrdersRepositoryMock struct {
 }
 
 type InboundOrderServiceTestSuite struct {
-	rp *InboundOrdersRepositoryMock
-	sv *service.InboundOrderService
+	rp  *InboundOrdersRepositoryMock
+	rpE *EmployeeRepositoryMock
+	rpP *ProductBatchRepositoryMock
+	rpW *WarehouseRepositoryMock
+	rpS *SectionRepositoryMock
+	rpD *RepositoryProductMock
+	rpC *ProductTypeCompatibilityRepositoryMock
+	sv  *service.InboundOrderService
 	suite.Suite
 }
 
@@ -25,11 +33,21 @@ func NewInboundOrdersRepositoryMock() *InboundOrdersRepositoryMock {
 	return &InboundOrdersRepositoryMock{}
 }
 
-func (m *InboundOrdersRepositoryMock) FindAll() ([]internal.InboundOrders, error) {
-	args := m.Called()
+func (m *InboundOrdersRepositoryMock) FindAll(filter internal.InboundOrderFilter) ([]internal.InboundOrders, error) {
+	args := m.Called(filter)
 	return args.Get(0).([]internal.InboundOrders), args.Error(1)
 }
 
+func (m *InboundOrdersRepositoryMock) FindByID(id int) (internal.InboundOrders, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.InboundOrders), args.Error(1)
+}
+
+func (m *InboundOrdersRepositoryMock) FindSummary(filter internal.InboundOrderFilter) ([]internal.InboundOrderSummary, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]internal.InboundOrderSummary), args.Error(1)
+}
+
 func (m *InboundOrdersRepositoryMock) Create(inboundOrder internal.InboundOrders) (int64, error) {
 	args := m.Called(inboundOrder)
 	return args.Get(0).(int64), args.Error(1)
@@ -37,11 +55,20 @@ func (m *InboundOrdersRepositoryMock) Create(inboundOrder internal.InboundOrders
 
 func (s *InboundOrderServiceTestSuite) SetupTest() {
 	s.rp = NewInboundOrdersRepositoryMock()
+	s.rpE = NewEmployeeRepositoryMock()
+	s.rpP = NewProductBatchRepositoryMock()
+	s.rpW = NewWarehouseRepositoryMock()
+	s.rpS = NewSectionRepositoryMock()
+	s.rpD = NewRepositoryProductMock()
+	s.rpC = NewProductTypeCompatibilityRepositoryMock()
 	s.sv = service.NewInboundOrderService(
 		s.rp,
-		nil,
-		nil,
-		nil,
+		s.rpE,
+		s.rpP,
+		s.rpW,
+		s.rpS,
+		s.rpD,
+		s.rpC,
 	)
 }
 
@@ -66,18 +93,18 @@ func (s *InboundOrderServiceTestSuite) TestFindAll() {
 			},
 		}
 		s.SetupTest()
-		s.rp.On("FindAll").Return(expectedInboundOrders, nil)
+		s.rp.On("FindAll", internal.InboundOrderFilter{}).Return(expectedInboundOrders, nil)
 
-		actualInboundOrders, e := s.sv.FindAll()
+		actualInboundOrders, e := s.sv.FindAll(internal.InboundOrderFilter{})
 
 		require.NoError(t, e)
 		require.Equal(t, expectedInboundOrders, actualInboundOrders)
 	})
 	s.T().Run("failure", func(t *testing.T) {
 		s.SetupTest()
-		s.rp.On("FindAll").Return([]internal.InboundOrders{}, errors.New("internal server error"))
+		s.rp.On("FindAll", internal.InboundOrderFilter{}).Return([]internal.InboundOrders{}, errors.New("internal server error"))
 
-		actualInboundOrders, e := s.sv.FindAll()
+		actualInboundOrders, e := s.sv.FindAll(internal.InboundOrderFilter{})
 
 		require.Error(t, e)
 		require.Equal(t, "internal server error", e.Error())
@@ -85,26 +112,186 @@ func (s *InboundOrderServiceTestSuite) TestFindAll() {
 	})
 }
 
+func (s *InboundOrderServiceTestSuite) TestFindByID() {
+	s.T().Run("success", func(t *testing.T) {
+		s.SetupTest()
+		s.rp.On("FindByID", 1).Return(internal.InboundOrders{ID: 1, OrderNumber: "ON1"}, nil)
+
+		inboundOrder, e := s.sv.FindByID(1)
+
+		require.NoError(t, e)
+		require.Equal(t, internal.InboundOrders{ID: 1, OrderNumber: "ON1"}, inboundOrder)
+	})
+	s.T().Run("not found", func(t *testing.T) {
+		s.SetupTest()
+		s.rp.On("FindByID", 99).Return(internal.InboundOrders{}, internal.ErrInboundOrderNotFound)
+
+		_, e := s.sv.FindByID(99)
+
+		require.ErrorIs(t, e, internal.ErrInboundOrderNotFound)
+	})
+}
+
+func (s *InboundOrderServiceTestSuite) TestFindSummary() {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s.T().Run("success", func(t *testing.T) {
+		s.SetupTest()
+		expected := []internal.InboundOrderSummary{
+			{OrderDate: "2025-01-02", ProductTypeID: 1, ProductTypeName: "Dairy", OrdersCount: 2, Quantity: 150},
+		}
+		s.rpW.On("FindByID", 3).Return(internal.Warehouse{ID: 3}, nil)
+		s.rp.On("FindSummary", internal.InboundOrderFilter{WarehouseID: 3, From: from}).Return(expected, nil)
+
+		summary, e := s.sv.FindSummary(3, internal.InboundOrderFilter{WarehouseID: 7, EmployeeID: 1, From: from})
+
+		require.NoError(t, e)
+		require.Equal(t, expected, summary)
+	})
+	s.T().Run("warehouse not found", func(t *testing.T) {
+		s.SetupTest()
+		s.rpW.On("FindByID", 99).Return(internal.Warehouse{}, internal.ErrWarehouseRepositoryNotFound)
+
+		_, e := s.sv.FindSummary(99, internal.InboundOrderFilter{})
+
+		require.ErrorIs(t, e, internal.ErrWarehouseRepositoryNotFound)
+		s.rp.AssertNotCalled(t, "FindSummary", mock.Anything)
+	})
+}
+
+func (s *InboundOrderServiceTestSuite) TestFindDiscrepancies() {
+	s.T().Run("success", func(t *testing.T) {
+		s.SetupTest()
+		s.rp.On("FindByID", 1).Return(internal.InboundOrders{ID: 1, Lines: []internal.InboundOrderLine{
+			{ID: 1, ExpectedQuantity: 10, ReceivedQuantity: 10},
+			{ID: 2, ExpectedQuantity: 10, ReceivedQuantity: 4, ProductBatch: internal.ProductBatch{ID: 8, BatchNumber: 80}},
+		}}, nil)
+
+		discrepancies, e := s.sv.FindDiscrepancies(1)
+
+		require.NoError(t, e)
+		require.Equal(t, []internal.InboundOrderDiscrepancy{
+			{InboundOrderLineID: 2, ProductBatchID: 8, BatchNumber: 80, ExpectedQuantity: 10, ReceivedQuantity: 4, Difference: -6},
+		}, discrepancies)
+	})
+	s.T().Run("not found", func(t *testing.T) {
+		s.SetupTest()
+		s.rp.On("FindByID", 99).Return(internal.InboundOrders{}, internal.ErrInboundOrderNotFound)
+
+		_, e := s.sv.FindDiscrepancies(99)
+
+		require.ErrorIs(t, e, internal.ErrInboundOrderNotFound)
+	})
+}
+
+func (s *InboundOrderServiceTestSuite) TestCreateWithLines() {
+	newOrder := func() internal.InboundOrders {
+		return internal.InboundOrders{
+			OrderDate:   "17/12/2001",
+			OrderNumber: "ASN01",
+			EmployeeID:  1,
+			WarehouseID: 3,
+			Lines: []internal.InboundOrderLine{
+				{ExpectedQuantity: 10, ReceivedQuantity: 8, ProductBatch: internal.ProductBatch{BatchNumber: 100, ProductID: 5, SectionID: 4}},
+				{ExpectedQuantity: 20, ReceivedQuantity: 20, ProductBatch: internal.ProductBatch{BatchNumber: 101, ProductID: 6, SectionID: 4}},
+			},
+		}
+	}
+
+	setupReferences := func() {
+		s.rpW.On("FindByID", 3).Return(internal.Warehouse{ID: 3}, nil)
+		s.rpE.On("GetByID", 1).Return(internal.Employee{ID: 1, WarehouseID: 3}, nil)
+	}
+
+	s.T().Run("success", func(t *testing.T) {
+		s.SetupTest()
+		setupReferences()
+		s.rpP.On("ProductBatchNumberExists", mock.Anything).Return(false, nil)
+		s.rpD.On("FindByID", 5).Return(internal.Product{ID: 5, ProductTypeID: 1, Status: internal.ProductStatusActive, SellerStatus: internal.SellerStatusActive}, nil)
+		s.rpD.On("FindByID", 6).Return(internal.Product{ID: 6, ProductTypeID: 1, Status: internal.ProductStatusActive, SellerStatus: internal.SellerStatusActive}, nil)
+		s.rpS.On("FindByID", 4).Return(internal.Section{ID: 4, WarehouseID: 3, ProductTypeID: 1}, nil)
+
+		expected := newOrder()
+		expected.Quantity = 28
+		s.rp.On("Create", expected).Return(int64(7), nil)
+
+		lastID, e := s.sv.Create(newOrder())
+
+		require.NoError(t, e)
+		require.EqualValues(t, 7, lastID)
+	})
+	s.T().Run("invalid lines", func(t *testing.T) {
+		s.SetupTest()
+		setupReferences()
+		s.rpP.On("ProductBatchNumberExists", 100).Return(true, nil)
+		s.rpP.On("ProductBatchNumberExists", 101).Return(false, nil)
+		s.rpD.On("FindByID", 5).Return(internal.Product{}, internal.ErrProductNotFound)
+		s.rpD.On("FindByID", 6).Return(internal.Product{ID: 6, ProductTypeID: 2, Status: internal.ProductStatusActive, SellerStatus: internal.SellerStatusSuspended}, nil)
+		s.rpS.On("FindByID", 4).Return(internal.Section{ID: 4, WarehouseID: 3, ProductTypeID: 1}, nil)
+		s.rpC.On("IsCompatible", 2, 1).Return(false, nil)
+
+		_, e := s.sv.Create(newOrder())
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, e, &domainError)
+		require.Equal(t, []internal.Causes{
+			{Field: "lines[0].product_batch.batch_number", Message: "batch number 100 is already registered"},
+			{Field: "lines[0].product_batch.product_id", Message: "product 5 not found"},
+			{Field: "lines[1].product_batch.product_id", Message: "product 6 cannot get new batches, seller is not active"},
+			{Field: "lines[1].product_batch.section_id", Message: "section 4 stores product type 1 and cannot hold product type 2"},
+		}, domainError.Causes)
+		s.rp.AssertNotCalled(t, "Create", mock.Anything)
+	})
+	s.T().Run("section in another warehouse", func(t *testing.T) {
+		s.SetupTest()
+		setupReferences()
+		s.rpP.On("ProductBatchNumberExists", mock.Anything).Return(false, nil)
+		s.rpD.On("FindByID", mock.Anything).Return(internal.Product{ID: 5, ProductTypeID: 1, Status: internal.ProductStatusActive, SellerStatus: internal.SellerStatusActive}, nil)
+		s.rpS.On("FindByID", 4).Return(internal.Section{ID: 4, WarehouseID: 9, ProductTypeID: 1}, nil)
+
+		_, e := s.sv.Create(newOrder())
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, e, &domainError)
+		require.Equal(t, []internal.Causes{
+			{Field: "lines[0].product_batch.section_id", Message: "section 4 is in warehouse 9, not in warehouse 3"},
+			{Field: "lines[1].product_batch.section_id", Message: "section 4 is in warehouse 9, not in warehouse 3"},
+		}, domainError.Causes)
+	})
+}
+
 func (s *InboundOrderServiceTestSuite) TestCreate() {
 	inboundOrder := internal.InboundOrders{
 		ID:             0,
 		OrderDate:      "17/12/2001",
 		OrderNumber:    "ON00",
-		EmployeeID:     0,
-		ProductBatchID: 0,
-		WarehouseID:    0,
+		EmployeeID:     1,
+		ProductBatchID: 2,
+		WarehouseID:    3,
+		Quantity:       10,
+	}
+
+	// setupReferences mocks an employee and a batch that belong to the warehouse of the inbound order
+	setupReferences := func() {
+		s.rpW.On("FindByID", 3).Return(internal.Warehouse{ID: 3}, nil)
+		s.rpE.On("GetByID", 1).Return(internal.Employee{ID: 1, WarehouseID: 3}, nil)
+		s.rpP.On("FindByID", 2).Return(internal.ProductBatch{ID: 2, SectionID: 4}, nil)
+		s.rpS.On("FindByID", 4).Return(internal.Section{ID: 4, WarehouseID: 3}, nil)
 	}
+
 	s.T().Run("success", func(t *testing.T) {
 		s.SetupTest()
-		s.rp.On("Create", inboundOrder).Return(int64(0), nil)
+		setupReferences()
+		s.rp.On("Create", inboundOrder).Return(int64(1), nil)
 
 		lastID, e := s.sv.Create(inboundOrder)
 
 		require.NoError(t, e)
-		require.EqualValues(t, 0, lastID)
+		require.EqualValues(t, 1, lastID)
 	})
 	s.T().Run("failure", func(t *testing.T) {
 		s.SetupTest()
+		setupReferences()
 		s.rp.On("Create", inboundOrder).Return(int64(-1), errors.New("internal server error"))
 
 		lastID, e := s.sv.Create(inboundOrder)
@@ -113,6 +300,65 @@ func (s *InboundOrderServiceTestSuite) TestCreate() {
 		require.Equal(t, "internal server error", e.Error())
 		require.EqualValues(t, -1, lastID)
 	})
+	s.T().Run("references not found", func(t *testing.T) {
+		s.SetupTest()
+		s.rpW.On("FindByID", 3).Return(internal.Warehouse{}, internal.ErrWarehouseRepositoryNotFound)
+		s.rpE.On("GetByID", 1).Return(internal.Employee{}, sql.ErrNoRows)
+		s.rpP.On("FindByID", 2).Return(internal.ProductBatch{}, internal.ErrProductBatchNotFound)
+
+		_, e := s.sv.Create(inboundOrder)
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, e, &domainError)
+		require.Equal(t, internal.ErrInboundOrderBadRequest.Error(), domainError.Message)
+		require.Equal(t, []internal.Causes{
+			{Field: "warehouse_id", Message: "warehouse 3 not found"},
+			{Field: "employee_id", Message: "employee 1 not found"},
+			{Field: "product_batch_id", Message: "product batch 2 not found"},
+		}, domainError.Causes)
+		s.rp.AssertNotCalled(t, "Create", mock.Anything)
+	})
+	s.T().Run("references in another warehouse", func(t *testing.T) {
+		s.SetupTest()
+		s.rpW.On("FindByID", 3).Return(internal.Warehouse{ID: 3}, nil)
+		s.rpE.On("GetByID", 1).Return(internal.Employee{ID: 1, WarehouseID: 5}, nil)
+		s.rpP.On("FindByID", 2).Return(internal.ProductBatch{ID: 2, SectionID: 4}, nil)
+		s.rpS.On("FindByID", 4).Return(internal.Section{ID: 4, WarehouseID: 6}, nil)
+
+		_, e := s.sv.Create(inboundOrder)
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, e, &domainError)
+		require.Equal(t, []internal.Causes{
+			{Field: "employee_id", Message: "employee 1 works in warehouse 5, not in warehouse 3"},
+			{Field: "product_batch_id", Message: "product batch 2 is stored in warehouse 6, not in warehouse 3"},
+		}, domainError.Causes)
+		s.rp.AssertNotCalled(t, "Create", mock.Anything)
+	})
+	s.T().Run("invalid quantity", func(t *testing.T) {
+		s.SetupTest()
+		setupReferences()
+
+		order := inboundOrder
+		order.Quantity = -1
+
+		_, e := s.sv.Create(order)
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, e, &domainError)
+		require.Equal(t, []internal.Causes{
+			{Field: "quantity", Message: "quantity must be greater than zero"},
+		}, domainError.Causes)
+	})
+	s.T().Run("employee repository error", func(t *testing.T) {
+		s.SetupTest()
+		s.rpW.On("FindByID", 3).Return(internal.Warehouse{ID: 3}, nil)
+		s.rpE.On("GetByID", 1).Return(internal.Employee{}, errors.New("internal server error"))
+
+		_, e := s.sv.Create(inboundOrder)
+
+		require.EqualError(t, e, "internal server error")
+	})
 }
 
 func TestInboundOrdersServiceTestSuite(t *testing.T) {
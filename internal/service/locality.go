@@ -5,12 +5,14 @@ import (
 )
 
 type LocalityDefault struct {
-	rp internal.LocalityRepository
+	rp  internal.LocalityRepository
+	rpP internal.ProvinceRepository
 }
 
-func NewLocalityDefault(rp internal.LocalityRepository) *LocalityDefault {
+func NewLocalityDefault(rp internal.LocalityRepository, rpProvince internal.ProvinceRepository) *LocalityDefault {
 	return &LocalityDefault{
-		rp,
+		rp:  rp,
+		rpP: rpProvince,
 	}
 }
 
@@ -24,6 +26,11 @@ func (l *LocalityDefault) Save(locality *internal.Locality) (err error) {
 		}
 	}
 
+	_, err = l.rpP.FindByID(locality.ProvinceID)
+	if err != nil {
+		return
+	}
+
 	return l.rp.Save(locality)
 }
 
@@ -45,3 +52,12 @@ func (l *LocalityDefault) ReportCarries(localityID int) (int, error) {
 func (l *LocalityDefault) GetAmountOfCarriesForEveryLocality() ([]internal.CarriesCountPerLocality, error) {
 	return l.rp.GetAmountOfCarriesForEveryLocality()
 }
+
+func (l *LocalityDefault) StockSummary(localityID int) ([]internal.LocalityStockSummary, error) {
+	_, err := l.rp.FindByID(localityID)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.rp.StockSummary(localityID)
+}
@@ -43,20 +43,26 @@ func (l *localityRepositoryMock) GetAmountOfCarriesForEveryLocality() (c []inter
 	return args.Get(0).([]internal.CarriesCountPerLocality), args.Error(1)
 }
 
+func (l *localityRepositoryMock) StockSummary(localityID int) (summary []internal.LocalityStockSummary, err error) {
+	args := l.Called(localityID)
+	return args.Get(0).([]internal.LocalityStockSummary), args.Error(1)
+}
+
 func TestLocalityDefault_Save(t *testing.T) {
 	t.Run("sucesso", func(t *testing.T) {
 		mockRepo := new(localityRepositoryMock)
 		locality := &internal.Locality{
 			ID:           2,
 			LocalityName: "Test",
-			ProvinceName: "Test",
-			CountryName:  "Test",
+			ProvinceID:   1,
 			Sellers:      0,
 		}
 
+		mockProvinceRepo := NewProvinceRepositoryMock()
+		mockProvinceRepo.On("FindByID", 1).Return(internal.Province{ID: 1, Name: "Test", CountryID: 1}, nil)
 		mockRepo.On("Save", locality).Return(nil)
 
-		svc := service.NewLocalityDefault(mockRepo)
+		svc := service.NewLocalityDefault(mockRepo, mockProvinceRepo)
 		err := svc.Save(locality)
 
 		assert.NoError(t, err)
@@ -67,7 +73,7 @@ func TestLocalityDefault_Save(t *testing.T) {
 		mockRepo := new(localityRepositoryMock)
 		locality := &internal.Locality{} // Crie uma instância inválida de Locality que retorne erros de validação
 
-		svc := service.NewLocalityDefault(mockRepo)
+		svc := service.NewLocalityDefault(mockRepo, NewProvinceRepositoryMock())
 		err := svc.Save(locality)
 
 		assert.Error(t, err)
@@ -80,19 +86,38 @@ func TestLocalityDefault_Save(t *testing.T) {
 		locality := &internal.Locality{
 			ID:           2,
 			LocalityName: "Test",
-			ProvinceName: "Test",
-			CountryName:  "Test",
+			ProvinceID:   1,
 			Sellers:      0,
 		}
 
+		mockProvinceRepo := NewProvinceRepositoryMock()
+		mockProvinceRepo.On("FindByID", 1).Return(internal.Province{ID: 1, Name: "Test", CountryID: 1}, nil)
 		mockRepo.On("Save", locality).Return(errors.New("erro ao salvar"))
 
-		svc := service.NewLocalityDefault(mockRepo)
+		svc := service.NewLocalityDefault(mockRepo, mockProvinceRepo)
 		err := svc.Save(locality)
 
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("província inexistente", func(t *testing.T) {
+		mockRepo := new(localityRepositoryMock)
+		mockProvinceRepo := NewProvinceRepositoryMock()
+		locality := &internal.Locality{
+			ID:           2,
+			LocalityName: "Test",
+			ProvinceID:   99,
+		}
+
+		mockProvinceRepo.On("FindByID", 99).Return(internal.Province{}, internal.ErrProvinceNotFound)
+
+		svc := service.NewLocalityDefault(mockRepo, mockProvinceRepo)
+		err := svc.Save(locality)
+
+		assert.ErrorIs(t, err, internal.ErrProvinceNotFound)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
 }
 
 func TestLocalityDefault_ReportSellers(t *testing.T) {
@@ -102,7 +127,7 @@ func TestLocalityDefault_ReportSellers(t *testing.T) {
 
 		mockRepo.On("ReportSellers").Return(expectedLocalities, nil)
 
-		svc := service.NewLocalityDefault(mockRepo)
+		svc := service.NewLocalityDefault(mockRepo, nil)
 		localities, err := svc.ReportSellers()
 
 		assert.NoError(t, err)
@@ -115,7 +140,7 @@ func TestLocalityDefault_ReportSellers(t *testing.T) {
 
 		mockRepo.On("ReportSellers").Return([]internal.Locality{}, errors.New("erro ao buscar"))
 
-		svc := service.NewLocalityDefault(mockRepo)
+		svc := service.NewLocalityDefault(mockRepo, nil)
 		localities, err := svc.ReportSellers()
 
 		assert.Error(t, err)
@@ -132,7 +157,7 @@ func TestLocalityDefault_ReportSellersByID(t *testing.T) {
 
 		mockRepo.On("ReportSellersByID", id).Return(expectedLocalities, nil)
 
-		svc := service.NewLocalityDefault(mockRepo)
+		svc := service.NewLocalityDefault(mockRepo, nil)
 		localities, err := svc.ReportSellersByID(id)
 
 		assert.NoError(t, err)
@@ -146,7 +171,7 @@ func TestLocalityDefault_ReportSellersByID(t *testing.T) {
 
 		mockRepo.On("ReportSellersByID", id).Return([]internal.Locality{}, errors.New("erro ao buscar"))
 
-		svc := service.NewLocalityDefault(mockRepo)
+		svc := service.NewLocalityDefault(mockRepo, nil)
 		localities, err := svc.ReportSellersByID(id)
 
 		assert.Error(t, err)
@@ -163,7 +188,7 @@ func TestLocalityDefault_FindByID(t *testing.T) {
 
 		mockRepo.On("FindByID", id).Return(expectedLocality, nil)
 
-		svc := service.NewLocalityDefault(mockRepo)
+		svc := service.NewLocalityDefault(mockRepo, nil)
 		locality, err := svc.FindByID(id)
 
 		assert.NoError(t, err)
@@ -177,7 +202,7 @@ func TestLocalityDefault_FindByID(t *testing.T) {
 
 		mockRepo.On("FindByID", id).Return(internal.Locality{}, errors.New("erro ao buscar"))
 
-		svc := service.NewLocalityDefault(mockRepo)
+		svc := service.NewLocalityDefault(mockRepo, nil)
 		_, err := svc.FindByID(id)
 
 		assert.Error(t, err)
@@ -193,7 +218,7 @@ func TestLocalityDefault_ReportCarries(t *testing.T) {
 
 		mockRepo.On("ReportCarries", localityId).Return(expectedCount, nil)
 
-		svc := service.NewLocalityDefault(mockRepo)
+		svc := service.NewLocalityDefault(mockRepo, nil)
 		count, err := svc.ReportCarries(localityId)
 
 		assert.NoError(t, err)
@@ -207,7 +232,7 @@ func TestLocalityDefault_ReportCarries(t *testing.T) {
 
 		mockRepo.On("ReportCarries", localityId).Return(0, errors.New("erro ao buscar"))
 
-		svc := service.NewLocalityDefault(mockRepo)
+		svc := service.NewLocalityDefault(mockRepo, nil)
 		_, err := svc.ReportCarries(localityId)
 
 		assert.Error(t, err)
@@ -222,7 +247,7 @@ func TestLocalityDefault_GetAmountOfCarriesForEveryLocality(t *testing.T) {
 
 		mockRepo.On("GetAmountOfCarriesForEveryLocality").Return(expectedCarries, nil)
 
-		svc := service.NewLocalityDefault(mockRepo)
+		svc := service.NewLocalityDefault(mockRepo, nil)
 		carries, err := svc.GetAmountOfCarriesForEveryLocality()
 
 		assert.NoError(t, err)
@@ -235,7 +260,7 @@ func TestLocalityDefault_GetAmountOfCarriesForEveryLocality(t *testing.T) {
 
 		mockRepo.On("GetAmountOfCarriesForEveryLocality").Return([]internal.CarriesCountPerLocality{}, errors.New("erro ao buscar"))
 
-		svc := service.NewLocalityDefault(mockRepo)
+		svc := service.NewLocalityDefault(mockRepo, nil)
 		carries, err := svc.GetAmountOfCarriesForEveryLocality()
 
 		assert.Error(t, err)
@@ -243,3 +268,32 @@ func TestLocalityDefault_GetAmountOfCarriesForEveryLocality(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestLocalityDefault_StockSummary(t *testing.T) {
+	t.Run("sucesso", func(t *testing.T) {
+		mockRepo := new(localityRepositoryMock)
+		expectedSummary := []internal.LocalityStockSummary{{ProductTypeID: 1, ProductTypeName: "Dairy", WarehousesCount: 2, BatchesCount: 3, CurrentQuantity: 150}}
+
+		mockRepo.On("FindByID", 1).Return(internal.Locality{ID: 1}, nil)
+		mockRepo.On("StockSummary", 1).Return(expectedSummary, nil)
+
+		svc := service.NewLocalityDefault(mockRepo, nil)
+		summary, err := svc.StockSummary(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedSummary, summary)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("localidade não encontrada", func(t *testing.T) {
+		mockRepo := new(localityRepositoryMock)
+
+		mockRepo.On("FindByID", 99).Return(internal.Locality{}, internal.ErrLocalityNotFound)
+
+		svc := service.NewLocalityDefault(mockRepo, nil)
+		_, err := svc.StockSummary(99)
+
+		assert.ErrorIs(t, err, internal.ErrLocalityNotFound)
+		mockRepo.AssertNotCalled(t, "StockSummary", 99)
+	})
+}
@@ -1,21 +1,29 @@
 package service
 
 import (
+	"slices"
+
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 )
 
-func NewProductService(prRepo internal.ProductRepository, slRepo internal.SellerRepository, ptRepo internal.ProductTypeRepository) *ProductDefault {
+func NewProductService(prRepo internal.ProductRepository, slRepo internal.SellerRepository, ptRepo internal.ProductTypeRepository, pbRepo internal.ProductBatchRepository, scRepo internal.SectionRepository, ptcRepo internal.ProductTypeCompatibilityRepository) *ProductDefault {
 	return &ProductDefault{
-		productRepo:     prRepo,
-		sellerRepo:      slRepo,
-		productTypeRepo: ptRepo,
+		productRepo:       prRepo,
+		sellerRepo:        slRepo,
+		productTypeRepo:   ptRepo,
+		productBatchRepo:  pbRepo,
+		sectionRepo:       scRepo,
+		compatibilityRepo: ptcRepo,
 	}
 }
 
 type ProductDefault struct {
-	productRepo     internal.ProductRepository
-	sellerRepo      internal.SellerRepository
-	productTypeRepo internal.ProductTypeRepository
+	productRepo       internal.ProductRepository
+	sellerRepo        internal.SellerRepository
+	productTypeRepo   internal.ProductTypeRepository
+	productBatchRepo  internal.ProductBatchRepository
+	sectionRepo       internal.SectionRepository
+	compatibilityRepo internal.ProductTypeCompatibilityRepository
 }
 
 func (s *ProductDefault) GetAll() (v []internal.Product, err error) {
@@ -38,6 +46,11 @@ func (s *ProductDefault) Create(product internal.Product) (internal.Product, err
 		return product, err
 	}
 
+	// products join the catalogue as active unless told otherwise
+	if product.Status == "" {
+		product.Status = internal.ProductStatusActive
+	}
+
 	if err := ValidateProduct(product); err != nil {
 		return product, err
 	}
@@ -121,7 +134,20 @@ func (s *ProductDefault) Update(product internal.Product) (internal.Product, err
 		product.SellerID = existingProduct.SellerID
 	}
 
-	if IsProductCodeExists(existingProducts, product.ProductCode) {
+	if product.Status == "" {
+		product.Status = existingProduct.Status
+	}
+
+	if !product.IsValidStatus() {
+		return product, internal.ErrProductUnprocessableEntity
+	}
+
+	// the product keeps its own code
+	otherProducts := slices.DeleteFunc(existingProducts, func(p internal.Product) bool {
+		return p.ID == product.ID
+	})
+
+	if IsProductCodeExists(otherProducts, product.ProductCode) {
 		return product, internal.ErrProductCodeAlreadyExists
 	}
 
@@ -135,6 +161,14 @@ func (s *ProductDefault) Update(product internal.Product) (internal.Product, err
 		return product, internal.ErrProductTypeNotFound
 	}
 
+	// the batches already stored must still fit their sections under the new type
+	if product.ProductTypeID != existingProduct.ProductTypeID {
+		err = s.checkStoredBatches(product)
+		if err != nil {
+			return product, err
+		}
+	}
+
 	_, err = s.productRepo.Update(product)
 	if err != nil {
 		return internal.Product{}, err
@@ -143,16 +177,52 @@ func (s *ProductDefault) Update(product internal.Product) (internal.Product, err
 	return product, nil
 }
 
-func (s *ProductDefault) Delete(id int) error {
-	err := s.productRepo.Delete(id)
+// checkStoredBatches makes sure the sections holding batches of the product can still store it
+func (s *ProductDefault) checkStoredBatches(product internal.Product) error {
+	prodBatches, err := s.productBatchRepo.FindByProductID(product.ID)
 	if err != nil {
-		print(err)
 		return err
 	}
 
+	checked := make(map[int]bool)
+	for _, pb := range prodBatches {
+		if checked[pb.SectionID] {
+			continue
+		}
+		checked[pb.SectionID] = true
+
+		section, err := s.sectionRepo.FindByID(pb.SectionID)
+		if err != nil {
+			return err
+		}
+
+		err = checkProductTypeCompatibility(s.compatibilityRepo, product, section)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// Delete offboards the product, its records, batches and orders are kept
+func (s *ProductDefault) Delete(id int) error {
+	product, err := s.productRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	if product.Status == internal.ProductStatusOffboarded {
+		return nil
+	}
+
+	product.Status = internal.ProductStatusOffboarded
+
+	_, err = s.productRepo.Update(product)
+
+	return err
+}
+
 func (s *ProductDefault) GetAllRecord() (v []internal.ProductRecordsJSONCount, err error) {
 	v, err = s.productRepo.FindAllRecord()
 	return
@@ -190,7 +260,7 @@ func IsProductCodeExists(existingProducts []internal.Product, productCode string
 func ValidateProduct(product internal.Product) error {
 	if product.ProductCode == "" || product.Description == "" || product.Height <= 0 || product.Length <= 0 ||
 		product.Width <= 0 || product.NetWeight <= 0 || product.ExpirationRate <= 0 || product.RecommendedFreezingTemperature < -273.15 ||
-		product.FreezingRate < -273.15 || product.ProductTypeID <= 0 || product.SellerID <= 0 {
+		product.FreezingRate < -273.15 || product.ProductTypeID <= 0 || product.SellerID <= 0 || !product.IsValidStatus() {
 		return internal.ErrProductUnprocessableEntity
 	}
 
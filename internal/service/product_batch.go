@@ -1,14 +1,17 @@
 package service
 
 import (
+	"time"
+
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 )
 
-func NewServiceProductBatch(rpProductBatch internal.ProductBatchRepository, rpSection internal.SectionRepository, rpProduct internal.ProductRepository) *ProductBatchService {
+func NewServiceProductBatch(rpProductBatch internal.ProductBatchRepository, rpSection internal.SectionRepository, rpProduct internal.ProductRepository, rpCompatibility internal.ProductTypeCompatibilityRepository) *ProductBatchService {
 	return &ProductBatchService{
 		rpB: rpProductBatch,
 		rpS: rpSection,
 		rpP: rpProduct,
+		rpC: rpCompatibility,
 	}
 }
 
@@ -16,6 +19,7 @@ type ProductBatchService struct {
 	rpB internal.ProductBatchRepository
 	rpS internal.SectionRepository
 	rpP internal.ProductRepository
+	rpC internal.ProductTypeCompatibilityRepository
 }
 
 func (s *ProductBatchService) FindByID(id int) (internal.ProductBatch, error) {
@@ -24,6 +28,8 @@ func (s *ProductBatchService) FindByID(id int) (internal.ProductBatch, error) {
 		return internal.ProductBatch{}, internal.ErrProductBatchNotFound
 	}
 
+	prodBatch.SetExpiryStatus(time.Now())
+
 	return prodBatch, nil
 }
 
@@ -37,20 +43,128 @@ func (s *ProductBatchService) Save(prodBatch *internal.ProductBatch) error {
 		return internal.ErrProductBatchNumberAlreadyInUse
 	}
 
-	_, err = s.rpP.FindByID(prodBatch.ProductID)
+	product, err := s.rpP.FindByID(prodBatch.ProductID)
 	if err != nil {
 		return internal.ErrProductNotFound
 	}
 
-	_, err = s.rpS.FindByID(prodBatch.SectionID)
+	err = product.Sellable()
+	if err != nil {
+		return err
+	}
+
+	section, err := s.rpS.FindByID(prodBatch.SectionID)
 	if err != nil {
 		return internal.ErrSectionNotFound
 	}
 
+	err = checkProductTypeCompatibility(s.rpC, product, section)
+	if err != nil {
+		return err
+	}
+
 	err = s.rpB.Save(prodBatch)
 	if err != nil {
 		return err
 	}
 
+	// new batches can be picked until someone holds them
+	prodBatch.Status = internal.ProductBatchStatusReleased
+	prodBatch.SetExpiryStatus(time.Now())
+
 	return nil
 }
+
+// Move stores the batch in another section, the section capacities are checked and updated by the repository
+func (s *ProductBatchService) Move(id int, sectionID int) (internal.ProductBatch, error) {
+	prodBatch, err := s.rpB.FindByID(id)
+	if err != nil {
+		return internal.ProductBatch{}, err
+	}
+
+	product, err := s.rpP.FindByID(prodBatch.ProductID)
+	if err != nil {
+		return internal.ProductBatch{}, err
+	}
+
+	section, err := s.rpS.FindByID(sectionID)
+	if err != nil {
+		return internal.ProductBatch{}, internal.ErrSectionNotFound
+	}
+
+	err = checkProductTypeCompatibility(s.rpC, product, section)
+	if err != nil {
+		return internal.ProductBatch{}, err
+	}
+
+	err = s.rpB.Move(id, sectionID)
+	if err != nil {
+		return internal.ProductBatch{}, err
+	}
+
+	return s.FindByID(id)
+}
+
+// Delete removes the batch and frees its section capacity
+func (s *ProductBatchService) Delete(id int) error {
+	return s.rpB.Delete(id)
+}
+
+func (s *ProductBatchService) FindExpiring(within time.Duration, warehouseID int) ([]internal.ProductBatchExpiryGroup, error) {
+	today := startOfDay(time.Now())
+
+	return s.findByDueDate(internal.ProductBatchDueDateFilter{
+		From:        today,
+		To:          today.Add(within),
+		WarehouseID: warehouseID,
+	})
+}
+
+func (s *ProductBatchService) FindExpired(warehouseID int) ([]internal.ProductBatchExpiryGroup, error) {
+	// batches are still sellable on their due date
+	yesterday := startOfDay(time.Now()).AddDate(0, 0, -1)
+
+	return s.findByDueDate(internal.ProductBatchDueDateFilter{
+		To:          yesterday,
+		WarehouseID: warehouseID,
+	})
+}
+
+func (s *ProductBatchService) findByDueDate(filter internal.ProductBatchDueDateFilter) ([]internal.ProductBatchExpiryGroup, error) {
+	groups, err := s.rpB.FindByDueDate(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range groups {
+		for j := range groups[i].Batches {
+			groups[i].Batches[j].SetExpiryStatus(now)
+		}
+	}
+
+	return groups, nil
+}
+
+// checkProductTypeCompatibility rejects products whose type the section does not store nor accepts through a compatibility rule
+func checkProductTypeCompatibility(rpC internal.ProductTypeCompatibilityRepository, product internal.Product, section internal.Section) error {
+	if product.ProductTypeID == section.ProductTypeID {
+		return nil
+	}
+
+	compatible, err := rpC.IsCompatible(product.ProductTypeID, section.ProductTypeID)
+	if err != nil {
+		return err
+	}
+
+	if !compatible {
+		return internal.NewProductTypeIncompatibleError(section, product.ProductTypeID)
+	}
+
+	return nil
+}
+
+// startOfDay truncates t to midnight UTC of its calendar day
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
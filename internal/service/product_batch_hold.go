@@ -0,0 +1,84 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+// NewProductBatchHoldService creates a new instance of the product batch hold service
+func NewProductBatchHoldService(rpProductBatchHold internal.ProductBatchHoldRepository, rpProductBatch internal.ProductBatchRepository, rpEmployee internal.EmployeeRepository) *ProductBatchHoldService {
+	return &ProductBatchHoldService{
+		rpProductBatchHold: rpProductBatchHold,
+		rpProductBatch:     rpProductBatch,
+		rpEmployee:         rpEmployee,
+	}
+}
+
+// ProductBatchHoldService is the implementation of the product batch hold service
+type ProductBatchHoldService struct {
+	rpProductBatchHold internal.ProductBatchHoldRepository
+	rpProductBatch     internal.ProductBatchRepository
+	rpEmployee         internal.EmployeeRepository
+}
+
+// FindByProductBatchID returns the status changes of a product batch
+func (s *ProductBatchHoldService) FindByProductBatchID(productBatchID int) ([]internal.ProductBatchHold, error) {
+	// Check if the product batch exists
+	_, err := s.rpProductBatch.FindByID(productBatchID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.rpProductBatchHold.FindByProductBatchID(productBatchID)
+}
+
+// Hold puts the batch on hold or in quarantine
+func (s *ProductBatchHoldService) Hold(h *internal.ProductBatchHold) error {
+	if h.ToStatus == internal.ProductBatchStatusReleased {
+		return internal.DomainError{
+			Message: internal.ErrProductBatchHoldBadRequest.Error(),
+			Causes: []internal.Causes{{
+				Field:   "status",
+				Message: "status must be on_hold or quarantined",
+			}},
+		}
+	}
+
+	return s.save(h)
+}
+
+// Release makes the batch available for picking again
+func (s *ProductBatchHoldService) Release(h *internal.ProductBatchHold) error {
+	h.ToStatus = internal.ProductBatchStatusReleased
+
+	return s.save(h)
+}
+
+// save validates the status change, checks the employee and stores it
+func (s *ProductBatchHoldService) save(h *internal.ProductBatchHold) error {
+	// Validate the status change
+	causes := h.Validate()
+	if len(causes) > 0 {
+		return internal.DomainError{
+			Message: internal.ErrProductBatchHoldBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	// Check if the employee exists
+	_, err := s.rpEmployee.GetByID(h.EmployeeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return internal.ErrEmployeeNotFound
+		}
+
+		return err
+	}
+
+	h.CreatedAt = time.Now().UTC()
+
+	return s.rpProductBatchHold.Save(h)
+}
@@ -0,0 +1,153 @@
+package service_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewProductBatchHoldRepositoryMock() *ProductBatchHoldRepositoryMock {
+	return &ProductBatchHoldRepositoryMock{}
+}
+
+type ProductBatchHoldRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *ProductBatchHoldRepositoryMock) FindByProductBatchID(productBatchID int) ([]internal.ProductBatchHold, error) {
+	args := r.Called(productBatchID)
+	return args.Get(0).([]internal.ProductBatchHold), args.Error(1)
+}
+
+func (r *ProductBatchHoldRepositoryMock) Save(h *internal.ProductBatchHold) error {
+	args := r.Called(h)
+	return args.Error(0)
+}
+
+func TestProductBatchHoldService_Hold(t *testing.T) {
+	t.Run("case 1: success - Should quarantine the batch", func(t *testing.T) {
+		rpH := NewProductBatchHoldRepositoryMock()
+		rpE := NewEmployeeRepositoryMock()
+		sv := service.NewProductBatchHoldService(rpH, NewProductBatchRepositoryMock(), rpE)
+
+		h := internal.ProductBatchHold{ProductBatchID: 1, ToStatus: internal.ProductBatchStatusQuarantined, Reason: "excursion 7", EmployeeID: 2}
+
+		rpE.On("GetByID", 2).Return(internal.Employee{ID: 2}, nil)
+		rpH.On("Save", &h).Return(nil)
+
+		err := sv.Hold(&h)
+
+		require.NoError(t, err)
+		require.False(t, h.CreatedAt.IsZero())
+		rpH.AssertNumberOfCalls(t, "Save", 1)
+	})
+
+	t.Run("case 2: error - Should not release through a hold", func(t *testing.T) {
+		rpH := NewProductBatchHoldRepositoryMock()
+		sv := service.NewProductBatchHoldService(rpH, NewProductBatchRepositoryMock(), NewEmployeeRepositoryMock())
+
+		h := internal.ProductBatchHold{ProductBatchID: 1, ToStatus: internal.ProductBatchStatusReleased, Reason: "ok", EmployeeID: 2}
+
+		err := sv.Hold(&h)
+
+		require.ErrorAs(t, err, &internal.DomainError{})
+		rpH.AssertNumberOfCalls(t, "Save", 0)
+	})
+
+	t.Run("case 3: error - Should return a domain error without reason and employee", func(t *testing.T) {
+		rpH := NewProductBatchHoldRepositoryMock()
+		sv := service.NewProductBatchHoldService(rpH, NewProductBatchRepositoryMock(), NewEmployeeRepositoryMock())
+
+		h := internal.ProductBatchHold{ProductBatchID: 1, ToStatus: internal.ProductBatchStatusOnHold}
+
+		err := sv.Hold(&h)
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, err, &domainError)
+		require.Len(t, domainError.Causes, 2)
+		rpH.AssertNumberOfCalls(t, "Save", 0)
+	})
+
+	t.Run("case 4: error - Should return an error when the employee does not exist", func(t *testing.T) {
+		rpH := NewProductBatchHoldRepositoryMock()
+		rpE := NewEmployeeRepositoryMock()
+		sv := service.NewProductBatchHoldService(rpH, NewProductBatchRepositoryMock(), rpE)
+
+		h := internal.ProductBatchHold{ProductBatchID: 1, ToStatus: internal.ProductBatchStatusOnHold, Reason: "recall", EmployeeID: 99}
+
+		rpE.On("GetByID", 99).Return(internal.Employee{}, sql.ErrNoRows)
+
+		err := sv.Hold(&h)
+
+		require.ErrorIs(t, err, internal.ErrEmployeeNotFound)
+		rpH.AssertNumberOfCalls(t, "Save", 0)
+	})
+}
+
+func TestProductBatchHoldService_Release(t *testing.T) {
+	t.Run("case 1: success - Should release the batch", func(t *testing.T) {
+		rpH := NewProductBatchHoldRepositoryMock()
+		rpE := NewEmployeeRepositoryMock()
+		sv := service.NewProductBatchHoldService(rpH, NewProductBatchRepositoryMock(), rpE)
+
+		h := internal.ProductBatchHold{ProductBatchID: 1, Reason: "lab results ok", EmployeeID: 2}
+
+		rpE.On("GetByID", 2).Return(internal.Employee{ID: 2}, nil)
+		rpH.On("Save", &h).Return(nil)
+
+		err := sv.Release(&h)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.ProductBatchStatusReleased, h.ToStatus)
+	})
+
+	t.Run("case 2: error - Should return an error when the batch is already released", func(t *testing.T) {
+		rpH := NewProductBatchHoldRepositoryMock()
+		rpE := NewEmployeeRepositoryMock()
+		sv := service.NewProductBatchHoldService(rpH, NewProductBatchRepositoryMock(), rpE)
+
+		h := internal.ProductBatchHold{ProductBatchID: 1, Reason: "lab results ok", EmployeeID: 2}
+
+		rpE.On("GetByID", 2).Return(internal.Employee{ID: 2}, nil)
+		rpH.On("Save", &h).Return(internal.ErrProductBatchHoldInvalidTransition)
+
+		err := sv.Release(&h)
+
+		require.ErrorIs(t, err, internal.ErrProductBatchHoldInvalidTransition)
+	})
+}
+
+func TestProductBatchHoldService_FindByProductBatchID(t *testing.T) {
+	t.Run("case 1: success - Should return the status changes of the batch", func(t *testing.T) {
+		rpH := NewProductBatchHoldRepositoryMock()
+		rpPb := NewProductBatchRepositoryMock()
+		sv := service.NewProductBatchHoldService(rpH, rpPb, NewEmployeeRepositoryMock())
+
+		expected := []internal.ProductBatchHold{{ID: 1, ProductBatchID: 1, FromStatus: "released", ToStatus: "on_hold", Reason: "recall", EmployeeID: 2}}
+
+		rpPb.On("FindByID", 1).Return(internal.ProductBatch{ID: 1}, nil)
+		rpH.On("FindByProductBatchID", 1).Return(expected, nil)
+
+		holds, err := sv.FindByProductBatchID(1)
+
+		require.NoError(t, err)
+		require.Equal(t, expected, holds)
+	})
+
+	t.Run("case 2: error - Should return an error when the batch does not exist", func(t *testing.T) {
+		rpH := NewProductBatchHoldRepositoryMock()
+		rpPb := NewProductBatchRepositoryMock()
+		sv := service.NewProductBatchHoldService(rpH, rpPb, NewEmployeeRepositoryMock())
+
+		rpPb.On("FindByID", 1).Return(internal.ProductBatch{}, internal.ErrProductBatchNotFound)
+
+		_, err := sv.FindByProductBatchID(1)
+
+		require.ErrorIs(t, err, internal.ErrProductBatchNotFound)
+		rpH.AssertNumberOfCalls(t, "FindByProductBatchID", 0)
+	})
+}
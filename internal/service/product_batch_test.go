@@ -1,7 +1,9 @@
 package service_test
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 	"github.com/meli-fresh-products-api-backend-t1/internal/service"
@@ -42,12 +44,38 @@ func (r *ProductBatchRepositoryMock) ReportProductsByID(id int) (prodBatches []i
 	return args.Get(0).([]internal.ProductBatch), args.Error(1)
 }
 
-func newProductBatchService() (*service.ProductBatchService, *ProductBatchRepositoryMock, *SectionRepositoryMock, *RepositoryProductMock) {
+func (r *ProductBatchRepositoryMock) FindByDueDate(filter internal.ProductBatchDueDateFilter) ([]internal.ProductBatchExpiryGroup, error) {
+	args := r.Called(filter)
+	return args.Get(0).([]internal.ProductBatchExpiryGroup), args.Error(1)
+}
+
+func (r *ProductBatchRepositoryMock) FindBySectionID(sectionID int) ([]internal.ProductBatch, error) {
+	args := r.Called(sectionID)
+	return args.Get(0).([]internal.ProductBatch), args.Error(1)
+}
+
+func (r *ProductBatchRepositoryMock) FindByProductID(productID int) ([]internal.ProductBatch, error) {
+	args := r.Called(productID)
+	return args.Get(0).([]internal.ProductBatch), args.Error(1)
+}
+
+func (r *ProductBatchRepositoryMock) Move(id int, sectionID int) error {
+	args := r.Called(id, sectionID)
+	return args.Error(0)
+}
+
+func (r *ProductBatchRepositoryMock) Delete(id int) error {
+	args := r.Called(id)
+	return args.Error(0)
+}
+
+func newProductBatchService() (*service.ProductBatchService, *ProductBatchRepositoryMock, *SectionRepositoryMock, *RepositoryProductMock, *ProductTypeCompatibilityRepositoryMock) {
 	rpProductBatch := NewProductBatchRepositoryMock()
 	rpSection := NewSectionRepositoryMock()
 	rpProduct := NewRepositoryProductMock()
+	rpCompatibility := NewProductTypeCompatibilityRepositoryMock()
 
-	return service.NewServiceProductBatch(rpProductBatch, rpSection, rpProduct), rpProductBatch, rpSection, rpProduct
+	return service.NewServiceProductBatch(rpProductBatch, rpSection, rpProduct, rpCompatibility), rpProductBatch, rpSection, rpProduct, rpCompatibility
 }
 
 func newTestProductBatch(id int, batchNumber int, productID int, prodBatchID int) internal.ProductBatch {
@@ -68,12 +96,12 @@ func newTestProductBatch(id int, batchNumber int, productID int, prodBatchID int
 
 func TestService_CreateProductBatchUnitTest(t *testing.T) {
 	t.Run("successfully create a new product-batch", func(t *testing.T) {
-		sv, rpProductBatch, rpSection, rpProduct := newProductBatchService()
+		sv, rpProductBatch, rpSection, rpProduct, _ := newProductBatchService()
 
 		prodBatchCreate := newTestProductBatch(0, 101, 4, 3)
 
 		rpProductBatch.On("ProductBatchNumberExists", prodBatchCreate.BatchNumber).Return(false, nil)
-		rpProduct.On("FindByID", prodBatchCreate.ProductID).Return(internal.Product{ID: prodBatchCreate.ProductID}, nil)
+		rpProduct.On("FindByID", prodBatchCreate.ProductID).Return(internal.Product{ID: prodBatchCreate.ProductID, Status: internal.ProductStatusActive, SellerStatus: internal.SellerStatusActive}, nil)
 		rpSection.On("FindByID", prodBatchCreate.SectionID).Return(internal.Section{ID: prodBatchCreate.SectionID}, nil)
 		rpProductBatch.On("Save", &prodBatchCreate).Return(nil)
 
@@ -91,7 +119,7 @@ func TestService_CreateProductBatchUnitTest(t *testing.T) {
 	})
 
 	t.Run("return fail error when required field is missing", func(t *testing.T) {
-		sv, rpProductBatch, rpSection, rpProduct := newProductBatchService()
+		sv, rpProductBatch, rpSection, rpProduct, _ := newProductBatchService()
 
 		prodBatchCreate := newTestProductBatch(0, 101, 0, 2)
 
@@ -110,7 +138,7 @@ func TestService_CreateProductBatchUnitTest(t *testing.T) {
 	})
 
 	t.Run("return conflict error when number is already in use", func(t *testing.T) {
-		sv, rpProductBatch, rpSection, rpProduct := newProductBatchService()
+		sv, rpProductBatch, rpSection, rpProduct, _ := newProductBatchService()
 
 		prodBatchCreate := newTestProductBatch(0, 101, 4, 3)
 
@@ -131,7 +159,7 @@ func TestService_CreateProductBatchUnitTest(t *testing.T) {
 	})
 
 	t.Run("returns error when product does not exist", func(t *testing.T) {
-		sv, rpProductBatch, rpSection, rpProduct := newProductBatchService()
+		sv, rpProductBatch, rpSection, rpProduct, _ := newProductBatchService()
 
 		prodBatchCreate := newTestProductBatch(0, 101, 99, 3)
 
@@ -153,12 +181,12 @@ func TestService_CreateProductBatchUnitTest(t *testing.T) {
 	})
 
 	t.Run("returns error when product type does not exist", func(t *testing.T) {
-		sv, rpProductBatch, rpSection, rpProduct := newProductBatchService()
+		sv, rpProductBatch, rpSection, rpProduct, _ := newProductBatchService()
 
 		prodBatchCreate := newTestProductBatch(0, 101, 3, 99)
 
 		rpProductBatch.On("ProductBatchNumberExists", prodBatchCreate.BatchNumber).Return(false, nil)
-		rpProduct.On("FindByID", prodBatchCreate.ProductID).Return(internal.Product{}, nil)
+		rpProduct.On("FindByID", prodBatchCreate.ProductID).Return(internal.Product{Status: internal.ProductStatusActive, SellerStatus: internal.SellerStatusActive}, nil)
 		rpSection.On("FindByID", prodBatchCreate.SectionID).Return(internal.Section{}, internal.ErrSectionNotFound)
 
 		err := sv.Save(&prodBatchCreate)
@@ -175,13 +203,29 @@ func TestService_CreateProductBatchUnitTest(t *testing.T) {
 		rpProductBatch.AssertNumberOfCalls(t, "Save", 0)
 	})
 
+	t.Run("returns error when the seller of the product is suspended", func(t *testing.T) {
+		sv, rpProductBatch, rpSection, rpProduct, _ := newProductBatchService()
+
+		prodBatchCreate := newTestProductBatch(0, 101, 3, 99)
+
+		rpProductBatch.On("ProductBatchNumberExists", prodBatchCreate.BatchNumber).Return(false, nil)
+		rpProduct.On("FindByID", prodBatchCreate.ProductID).Return(internal.Product{Status: internal.ProductStatusActive, SellerStatus: internal.SellerStatusSuspended}, nil)
+
+		err := sv.Save(&prodBatchCreate)
+
+		require.ErrorIs(t, err, internal.ErrSellerNotActive)
+
+		rpSection.AssertNotCalled(t, "FindByID", mock.Anything)
+		rpProductBatch.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
 	t.Run("returns error when product-batch fails to save", func(t *testing.T) {
-		sv, rpProductBatch, rpSection, rpProduct := newProductBatchService()
+		sv, rpProductBatch, rpSection, rpProduct, _ := newProductBatchService()
 
 		prodBatchCreate := newTestProductBatch(0, 101, 3, 99)
 
 		rpProductBatch.On("ProductBatchNumberExists", prodBatchCreate.BatchNumber).Return(false, nil)
-		rpProduct.On("FindByID", prodBatchCreate.ProductID).Return(internal.Product{}, nil)
+		rpProduct.On("FindByID", prodBatchCreate.ProductID).Return(internal.Product{Status: internal.ProductStatusActive, SellerStatus: internal.SellerStatusActive}, nil)
 		rpSection.On("FindByID", prodBatchCreate.SectionID).Return(internal.Section{}, nil)
 		rpProductBatch.On("Save", &prodBatchCreate).Return(internal.ErrProductBatchUnprocessableEntity)
 
@@ -202,7 +246,7 @@ func TestService_CreateProductBatchUnitTest(t *testing.T) {
 
 func TestService_ReadProductBatchUnitTest(t *testing.T) {
 	t.Run("return error when reading a nonexistent product-batch by ID", func(t *testing.T) {
-		sv, rpProductBatch, _, _ := newProductBatchService()
+		sv, rpProductBatch, _, _, _ := newProductBatchService()
 		expectedError := internal.ErrProductBatchNotFound
 
 		rpProductBatch.On("FindByID", 1).Return(internal.ProductBatch{}, expectedError)
@@ -217,13 +261,15 @@ func TestService_ReadProductBatchUnitTest(t *testing.T) {
 	})
 
 	t.Run("successfully read an existing product-batch by ID", func(t *testing.T) {
-		sv, rpProductBatch, _, _ := newProductBatchService()
+		sv, rpProductBatch, _, _, _ := newProductBatchService()
 		expectedSection := newTestProductBatch(2, 101, 4, 3)
 
 		rpProductBatch.On("FindByID", 2).Return(expectedSection, nil)
 
 		produBatch, err := sv.FindByID(2)
 
+		expectedSection.ExpiryStatus = internal.ProductBatchExpiryExpired
+
 		require.NoError(t, err)
 		require.Equal(t, expectedSection, produBatch)
 
@@ -231,3 +277,161 @@ func TestService_ReadProductBatchUnitTest(t *testing.T) {
 		rpProductBatch.AssertNumberOfCalls(t, "FindByID", 1)
 	})
 }
+
+func TestService_ExpiryReportsProductBatchUnitTest(t *testing.T) {
+	today := time.Now().UTC()
+	midnight := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+
+	t.Run("successfully list the batches expiring within the window", func(t *testing.T) {
+		sv, rpProductBatch, _, _, _ := newProductBatchService()
+		dueDate := midnight.AddDate(0, 0, 2).Format(time.DateOnly)
+
+		rpProductBatch.On("FindByDueDate", internal.ProductBatchDueDateFilter{
+			From:        midnight,
+			To:          midnight.AddDate(0, 0, 3),
+			WarehouseID: 1,
+		}).Return([]internal.ProductBatchExpiryGroup{
+			{WarehouseID: 1, SectionID: 2, RemainingQuantity: 10, Batches: []internal.ProductBatch{{ID: 1, CurrentQuantity: 10, DueDate: dueDate}}},
+		}, nil)
+
+		groups, err := sv.FindExpiring(3*24*time.Hour, 1)
+
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		require.Equal(t, internal.ProductBatchExpiryNearExpiry, groups[0].Batches[0].ExpiryStatus)
+	})
+
+	t.Run("successfully list the expired batches", func(t *testing.T) {
+		sv, rpProductBatch, _, _, _ := newProductBatchService()
+		dueDate := midnight.AddDate(0, 0, -5).Format(time.DateOnly)
+
+		rpProductBatch.On("FindByDueDate", internal.ProductBatchDueDateFilter{
+			To: midnight.AddDate(0, 0, -1),
+		}).Return([]internal.ProductBatchExpiryGroup{
+			{WarehouseID: 1, SectionID: 2, RemainingQuantity: 10, Batches: []internal.ProductBatch{{ID: 1, CurrentQuantity: 10, DueDate: dueDate}}},
+		}, nil)
+
+		groups, err := sv.FindExpired(0)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.ProductBatchExpiryExpired, groups[0].Batches[0].ExpiryStatus)
+	})
+
+	t.Run("return error when the repository fails", func(t *testing.T) {
+		sv, rpProductBatch, _, _, _ := newProductBatchService()
+
+		rpProductBatch.On("FindByDueDate", mock.Anything).Return([]internal.ProductBatchExpiryGroup{}, errors.New("internal server error"))
+
+		_, err := sv.FindExpired(0)
+
+		require.Error(t, err)
+	})
+}
+
+func TestService_MoveProductBatchUnitTest(t *testing.T) {
+	t.Run("move the batch to another section", func(t *testing.T) {
+		sv, rpProductBatch, rpSection, rpProduct, _ := newProductBatchService()
+
+		moved := newTestProductBatch(1, 101, 4, 5)
+
+		rpProductBatch.On("FindByID", 1).Return(moved, nil)
+		rpProduct.On("FindByID", 4).Return(internal.Product{ID: 4, ProductTypeID: 2}, nil)
+		rpSection.On("FindByID", 5).Return(internal.Section{ID: 5, ProductTypeID: 2}, nil)
+		rpProductBatch.On("Move", 1, 5).Return(nil)
+
+		prodBatch, err := sv.Move(1, 5)
+
+		require.NoError(t, err)
+		require.Equal(t, 5, prodBatch.SectionID)
+		rpProductBatch.AssertNumberOfCalls(t, "Move", 1)
+	})
+
+	t.Run("return section not found when the target section does not exist", func(t *testing.T) {
+		sv, rpProductBatch, rpSection, rpProduct, _ := newProductBatchService()
+
+		rpProductBatch.On("FindByID", 1).Return(newTestProductBatch(1, 101, 4, 3), nil)
+		rpProduct.On("FindByID", 4).Return(internal.Product{ID: 4, ProductTypeID: 2}, nil)
+		rpSection.On("FindByID", 99).Return(internal.Section{}, internal.ErrSectionNotFound)
+
+		_, err := sv.Move(1, 99)
+
+		require.ErrorIs(t, err, internal.ErrSectionNotFound)
+		rpProductBatch.AssertNumberOfCalls(t, "Move", 0)
+	})
+
+	t.Run("return product-batch not found", func(t *testing.T) {
+		sv, rpProductBatch, _, _, _ := newProductBatchService()
+
+		rpProductBatch.On("FindByID", 99).Return(internal.ProductBatch{}, internal.ErrProductBatchNotFound)
+
+		_, err := sv.Move(99, 5)
+
+		require.ErrorIs(t, err, internal.ErrProductBatchNotFound)
+		rpProductBatch.AssertNumberOfCalls(t, "Move", 0)
+	})
+
+	t.Run("return the capacity error of the repository", func(t *testing.T) {
+		sv, rpProductBatch, rpSection, rpProduct, _ := newProductBatchService()
+
+		rpProductBatch.On("FindByID", 1).Return(newTestProductBatch(1, 101, 4, 3), nil)
+		rpProduct.On("FindByID", 4).Return(internal.Product{ID: 4, ProductTypeID: 2}, nil)
+		rpSection.On("FindByID", 5).Return(internal.Section{ID: 5, ProductTypeID: 2}, nil)
+		rpProductBatch.On("Move", 1, 5).Return(internal.NewSectionCapacityExceededError(5, 10, 150))
+
+		_, err := sv.Move(1, 5)
+
+		require.ErrorAs(t, err, &internal.DomainError{})
+		rpProductBatch.AssertNumberOfCalls(t, "FindByID", 1)
+	})
+
+	t.Run("reject a section that cannot hold the product type", func(t *testing.T) {
+		sv, rpProductBatch, rpSection, rpProduct, rpCompatibility := newProductBatchService()
+
+		rpProductBatch.On("FindByID", 1).Return(newTestProductBatch(1, 101, 4, 3), nil)
+		rpProduct.On("FindByID", 4).Return(internal.Product{ID: 4, ProductTypeID: 2}, nil)
+		rpSection.On("FindByID", 5).Return(internal.Section{ID: 5, ProductTypeID: 7}, nil)
+		rpCompatibility.On("IsCompatible", 2, 7).Return(false, nil)
+
+		_, err := sv.Move(1, 5)
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, err, &domainError)
+		require.Equal(t, "section 5 stores product type 7 and cannot hold product type 2", domainError.Causes[0].Message)
+		rpProductBatch.AssertNumberOfCalls(t, "Move", 0)
+	})
+}
+
+func TestService_CompatibilityProductBatchUnitTest(t *testing.T) {
+	t.Run("store the batch in a compatible section", func(t *testing.T) {
+		sv, rpProductBatch, rpSection, rpProduct, rpCompatibility := newProductBatchService()
+
+		prodBatchCreate := newTestProductBatch(0, 101, 4, 3)
+
+		rpProductBatch.On("ProductBatchNumberExists", prodBatchCreate.BatchNumber).Return(false, nil)
+		rpProduct.On("FindByID", 4).Return(internal.Product{ID: 4, ProductTypeID: 6, Status: internal.ProductStatusActive, SellerStatus: internal.SellerStatusActive}, nil)
+		rpSection.On("FindByID", 3).Return(internal.Section{ID: 3, ProductTypeID: 10}, nil)
+		rpCompatibility.On("IsCompatible", 6, 10).Return(true, nil)
+		rpProductBatch.On("Save", &prodBatchCreate).Return(nil)
+
+		err := sv.Save(&prodBatchCreate)
+
+		require.NoError(t, err)
+		rpProductBatch.AssertNumberOfCalls(t, "Save", 1)
+	})
+
+	t.Run("reject a section of another product type", func(t *testing.T) {
+		sv, rpProductBatch, rpSection, rpProduct, rpCompatibility := newProductBatchService()
+
+		prodBatchCreate := newTestProductBatch(0, 101, 4, 3)
+
+		rpProductBatch.On("ProductBatchNumberExists", prodBatchCreate.BatchNumber).Return(false, nil)
+		rpProduct.On("FindByID", 4).Return(internal.Product{ID: 4, ProductTypeID: 1, Status: internal.ProductStatusActive, SellerStatus: internal.SellerStatusActive}, nil)
+		rpSection.On("FindByID", 3).Return(internal.Section{ID: 3, ProductTypeID: 10}, nil)
+		rpCompatibility.On("IsCompatible", 1, 10).Return(false, nil)
+
+		err := sv.Save(&prodBatchCreate)
+
+		require.ErrorAs(t, err, &internal.DomainError{})
+		rpProductBatch.AssertNumberOfCalls(t, "Save", 0)
+	})
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"errors"
+	"time"
+
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 )
 
@@ -53,6 +55,43 @@ func (pr *ProductRecordsDefault) GetByID(id int) (internal.ProductRecords, error
 	return productRecord, nil
 }
 
+// GetPrices returns the price timeline of a product, zero times leave the range open
+func (pr *ProductRecordsDefault) GetPrices(productID int, from time.Time, to time.Time) ([]internal.ProductPrice, error) {
+	// Check if the product exists
+	_, err := pr.productRepo.FindByID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := pr.productRecRepo.FindByProductID(productID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make([]internal.ProductPrice, 0, len(records))
+	for _, record := range records {
+		prices = append(prices, internal.NewProductPrice(record))
+	}
+
+	return prices, nil
+}
+
+// GetEffectivePrice returns the price of the product effective at the given time
+func (pr *ProductRecordsDefault) GetEffectivePrice(productID int, at time.Time) (internal.ProductPrice, error) {
+	// Check if the product exists
+	_, err := pr.productRepo.FindByID(productID)
+	if err != nil {
+		return internal.ProductPrice{}, err
+	}
+
+	record, err := pr.productRecRepo.FindEffectiveByProductID(productID, at)
+	if err != nil {
+		return internal.ProductPrice{}, err
+	}
+
+	return internal.NewProductPrice(record), nil
+}
+
 func ValidateProductRec(productRec internal.ProductRecords) error {
 	if productRec.LastUpdateDate.IsZero() || productRec.PurchasePrice <= 0 || productRec.SalePrice <= 0 {
 		return internal.ErrProductUnprocessableEntity
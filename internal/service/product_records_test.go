@@ -30,6 +30,16 @@ func (m *RepositoryProductRecordsMock) FindByID(id int) (internal.ProductRecords
 	return args.Get(0).(internal.ProductRecords), args.Error(1)
 }
 
+func (m *RepositoryProductRecordsMock) FindByProductID(productID int, from time.Time, to time.Time) ([]internal.ProductRecords, error) {
+	args := m.Called(productID, from, to)
+	return args.Get(0).([]internal.ProductRecords), args.Error(1)
+}
+
+func (m *RepositoryProductRecordsMock) FindEffectiveByProductID(productID int, at time.Time) (internal.ProductRecords, error) {
+	args := m.Called(productID, at)
+	return args.Get(0).(internal.ProductRecords), args.Error(1)
+}
+
 func TestProductRecords_Create(t *testing.T) {
 	product := internal.Product{ID: 1, ProductCode: "code-1"}
 	productRec := internal.ProductRecords{
@@ -163,3 +173,75 @@ func TestProductRecords_GetByID(t *testing.T) {
 	})
 
 }
+
+func TestProductRecords_GetPrices(t *testing.T) {
+	product := internal.Product{ID: 1, ProductCode: "code-1"}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	t.Run("successfully retrieve the price timeline with margins", func(t *testing.T) {
+		productRecRepo := new(RepositoryProductRecordsMock)
+		productRepo := new(RepositoryProductMock)
+		serv := service.NewProductRecordsDefault(productRecRepo, productRepo)
+
+		productRepo.On("FindByID", product.ID).Return(product, nil)
+		productRecRepo.On("FindByProductID", product.ID, from, to).Return([]internal.ProductRecords{
+			{ID: 1, ProductID: 1, LastUpdateDate: from, PurchasePrice: 10, SalePrice: 15},
+			{ID: 2, ProductID: 1, LastUpdateDate: to, PurchasePrice: 10, SalePrice: 12.5},
+		}, nil)
+
+		result, err := serv.GetPrices(product.ID, from, to)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []internal.ProductPrice{
+			{RecordID: 1, ProductID: 1, EffectiveFrom: from, PurchasePrice: 10, SalePrice: 15, Margin: 5, MarginPercent: 33.33},
+			{RecordID: 2, ProductID: 1, EffectiveFrom: to, PurchasePrice: 10, SalePrice: 12.5, Margin: 2.5, MarginPercent: 20},
+		}, result)
+	})
+
+	t.Run("error: product not found", func(t *testing.T) {
+		productRecRepo := new(RepositoryProductRecordsMock)
+		productRepo := new(RepositoryProductMock)
+		serv := service.NewProductRecordsDefault(productRecRepo, productRepo)
+
+		productRepo.On("FindByID", 99).Return(internal.Product{}, internal.ErrProductNotFound)
+
+		result, err := serv.GetPrices(99, from, to)
+
+		assert.Equal(t, internal.ErrProductNotFound, err)
+		assert.Nil(t, result)
+		productRecRepo.AssertNotCalled(t, "FindByProductID", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestProductRecords_GetEffectivePrice(t *testing.T) {
+	product := internal.Product{ID: 1, ProductCode: "code-1"}
+	at := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("successfully retrieve the effective price", func(t *testing.T) {
+		productRecRepo := new(RepositoryProductRecordsMock)
+		productRepo := new(RepositoryProductMock)
+		serv := service.NewProductRecordsDefault(productRecRepo, productRepo)
+
+		productRepo.On("FindByID", product.ID).Return(product, nil)
+		productRecRepo.On("FindEffectiveByProductID", product.ID, at).Return(internal.ProductRecords{ID: 2, ProductID: 1, PurchasePrice: 12, SalePrice: 10}, nil)
+
+		result, err := serv.GetEffectivePrice(product.ID, at)
+
+		assert.Nil(t, err)
+		assert.Equal(t, internal.ProductPrice{RecordID: 2, ProductID: 1, PurchasePrice: 12, SalePrice: 10, Margin: -2, MarginPercent: -20}, result)
+	})
+
+	t.Run("error: no price effective at the time", func(t *testing.T) {
+		productRecRepo := new(RepositoryProductRecordsMock)
+		productRepo := new(RepositoryProductMock)
+		serv := service.NewProductRecordsDefault(productRecRepo, productRepo)
+
+		productRepo.On("FindByID", product.ID).Return(product, nil)
+		productRecRepo.On("FindEffectiveByProductID", product.ID, at).Return(internal.ProductRecords{}, internal.ErrProductPriceNotFound)
+
+		_, err := serv.GetEffectivePrice(product.ID, at)
+
+		assert.Equal(t, internal.ErrProductPriceNotFound, err)
+	})
+}
@@ -58,8 +58,11 @@ func TestProductServiceDefault_GetAll(t *testing.T) { //Se a lista tiver "n" ele
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 		// Produtos esperados que o repositório deve retornar
 		expectedProducts := []internal.Product{
 			{ID: 1, ProductCode: "P001"},
@@ -83,8 +86,11 @@ func TestProductServiceDefault_GetByID(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 
 		productRepo.On("FindByID", 1).Return(expectedProduct, nil)
 
@@ -98,8 +104,11 @@ func TestProductServiceDefault_GetByID(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 		productRepo.On("FindByID", 1).Return(internal.Product{}, internal.ErrProductNotFound)
 
 		// Chamada do método que será testado
@@ -123,14 +132,18 @@ func TestProductServiceDefault_Create(t *testing.T) {
 		FreezingRate:                   1,
 		ProductTypeID:                  1,
 		SellerID:                       1,
+		Status:                         internal.ProductStatusActive,
 	}
 
 	t.Run("create_ok", func(t *testing.T) { //Se ele contiver os campos necessários, será criado
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 		// Configura o mock para as chamadas necessárias
 		productRepo.On("FindAll").Return([]internal.Product{}, nil)                               // Configuração para FindAll
 		productRepo.On("Save", product).Return(product, nil)                                      // Configuração para Save
@@ -149,8 +162,11 @@ func TestProductServiceDefault_Create(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 		productRepo.On("FindAll").Return([]internal.Product{product}, nil)
 
 		// Executa o método que será testado
@@ -165,8 +181,11 @@ func TestProductServiceDefault_Create(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 		// Cria um product com seller que não existe
 		productRepo.On("FindAll").Return([]internal.Product{}, nil)
 		productRepo.On("Save", product).Return(product, nil)
@@ -185,8 +204,11 @@ func TestProductServiceDefault_Create(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 		productRepo.On("FindAll").Return([]internal.Product{}, nil)
 		productRepo.On("Save", product).Return(product, nil)
 		sellerRepo.On("FindByID", product.SellerID).Return(internal.Seller{}, nil)
@@ -203,8 +225,11 @@ func TestProductServiceDefault_Create(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 		productRepo.On("FindAll").Return([]internal.Product{}, nil)
 		productRepo.On("Save", product).Return(product, errors.New("repository error"))
 		sellerRepo.On("FindByID", product.SellerID).Return(internal.Seller{}, nil)
@@ -221,8 +246,11 @@ func TestProductServiceDefault_Create(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 		productRepo.On("FindAll").Return([]internal.Product{}, errors.New("repository error"))
 		productRepo.On("Save", product).Return(product, nil)
 		sellerRepo.On("FindByID", product.SellerID).Return(internal.Seller{}, nil)
@@ -236,8 +264,11 @@ func TestProductServiceDefault_Create(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 
 		productRepo.On("FindAll").Return([]internal.Product{}, nil)
 
@@ -260,6 +291,7 @@ func TestProductServiceDefault_Update(t *testing.T) {
 	product := internal.Product{
 		ID:                             1,
 		ProductCode:                    "code 1",
+		Status:                         internal.ProductStatusActive,
 		Description:                    "Example Product",
 		Height:                         1,
 		Length:                         1,
@@ -279,8 +311,11 @@ func TestProductServiceDefault_Update(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 		productRepo.On("FindAll").Return([]internal.Product{}, nil)
 		productRepo.On("FindByID", product.ID).Return(product, nil)                               // Configuração para FindAll
 		productRepo.On("Update", product).Return(product, nil)                                    // Configuração para Save
@@ -301,8 +336,11 @@ func TestProductServiceDefault_Update(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 		productRepo.On("FindAll").Return([]internal.Product{}, nil)
 		productRepo.On("FindByID", product.ID).Return(product, internal.ErrProductNotFound)       // Configuração para FindAll
 		productRepo.On("Update", product).Return(product, nil)                                    // Configuração para Save
@@ -320,8 +358,11 @@ func TestProductServiceDefault_Update(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 		productRepo.On("FindAll").Return([]internal.Product{}, errors.New("repository error"))
 		productRepo.On("Update", product).Return(product, nil)
 		sellerRepo.On("FindByID", product.SellerID).Return(internal.Seller{}, nil)
@@ -336,12 +377,16 @@ func TestProductServiceDefault_Update(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 
 		existingProduct := internal.Product{
 			ID:                             1,
 			ProductCode:                    "code 1",
+			Status:                         internal.ProductStatusActive,
 			Description:                    "Existing Description",
 			Height:                         100,
 			Width:                          50,
@@ -390,20 +435,25 @@ func TestProductServiceDefault_Update(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 
 		// Produto existente com o mesmo código
 		existingProducts := []internal.Product{
 			{
 				ID:          2,
 				ProductCode: "code-1", // Mesmo código do produto a ser atualizado
+				Status:      internal.ProductStatusActive,
 			},
 		}
 
 		product := internal.Product{
 			ID:          1,
 			ProductCode: "code-1", // Produto atualizado com código duplicado
+			Status:      internal.ProductStatusActive,
 		}
 
 		// Configuração do mock para FindAll
@@ -426,13 +476,17 @@ func TestProductServiceDefault_Update(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 
 		// Produto a ser atualizado
 		product := internal.Product{
 			ID:          1,
 			ProductCode: "code-1",
+			Status:      internal.ProductStatusActive,
 			SellerID:    99, // ID do vendedor que não existe
 		}
 
@@ -458,13 +512,17 @@ func TestProductServiceDefault_Update(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 
 		// Produto a ser atualizado
 		product := internal.Product{
 			ID:            1,
 			ProductCode:   "code-1",
+			Status:        internal.ProductStatusActive,
 			ProductTypeID: 99, // ID do tipo de produto que não existe
 			SellerID:      1,  // ID do vendedor válido
 		}
@@ -493,13 +551,17 @@ func TestProductServiceDefault_Update(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 
 		// Produto a ser atualizado
 		product := internal.Product{
 			ID:            1,
 			ProductCode:   "code-1",
+			Status:        internal.ProductStatusActive,
 			ProductTypeID: 1,
 			SellerID:      1,
 		}
@@ -523,22 +585,105 @@ func TestProductServiceDefault_Update(t *testing.T) {
 		assert.Equal(t, "repository update error", err.Error())
 	})
 
+	t.Run("should reject a product type its stored batches cannot keep", func(t *testing.T) {
+		productRepo := new(RepositoryProductMock)
+		sellerRepo := new(sellerRepositoryMock)
+		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
+
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
+
+		retyped := product
+		retyped.ProductTypeID = 2
+
+		productRepo.On("FindAll").Return([]internal.Product{product}, nil)
+		productRepo.On("FindByID", product.ID).Return(product, nil)
+		sellerRepo.On("FindByID", product.SellerID).Return(internal.Seller{}, nil)
+		productTypeRepo.On("FindByID", 2).Return(internal.ProductType{ID: 2}, nil)
+		productBatchRepo.On("FindByProductID", product.ID).Return([]internal.ProductBatch{
+			{ID: 1, ProductID: product.ID, SectionID: 3},
+			{ID: 2, ProductID: product.ID, SectionID: 3},
+		}, nil)
+		sectionRepo.On("FindByID", 3).Return(internal.Section{ID: 3, ProductTypeID: 1}, nil)
+		compatibilityRepo.On("IsCompatible", 2, 1).Return(false, nil)
+
+		_, err := svc.Update(retyped)
+
+		assert.ErrorAs(t, err, &internal.DomainError{})
+		sectionRepo.AssertNumberOfCalls(t, "FindByID", 1)
+		productRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("should accept a product type a rule lets its stored batches keep", func(t *testing.T) {
+		productRepo := new(RepositoryProductMock)
+		sellerRepo := new(sellerRepositoryMock)
+		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
+
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
+
+		retyped := product
+		retyped.ProductTypeID = 2
+
+		productRepo.On("FindAll").Return([]internal.Product{product}, nil)
+		productRepo.On("FindByID", product.ID).Return(product, nil)
+		productRepo.On("Update", retyped).Return(retyped, nil)
+		sellerRepo.On("FindByID", product.SellerID).Return(internal.Seller{}, nil)
+		productTypeRepo.On("FindByID", 2).Return(internal.ProductType{ID: 2}, nil)
+		productBatchRepo.On("FindByProductID", product.ID).Return([]internal.ProductBatch{{ID: 1, ProductID: product.ID, SectionID: 3}}, nil)
+		sectionRepo.On("FindByID", 3).Return(internal.Section{ID: 3, ProductTypeID: 1}, nil)
+		compatibilityRepo.On("IsCompatible", 2, 1).Return(true, nil)
+
+		updated, err := svc.Update(retyped)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, updated.ProductTypeID)
+		productRepo.AssertNumberOfCalls(t, "Update", 1)
+	})
 }
 
 func TestProductServiceDefault_Delete(t *testing.T) {
 	t.Run("delete_ok", func(t *testing.T) {
 
-		//Se a exclusão for bem-sucedida, o item não aparecerá na lista.
+		//A exclusão desativa o produto e mantém o histórico
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
-
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
-		productRepo.On("Delete", 1).Return(nil)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
+
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
+		product := internal.Product{ID: 1, Status: internal.ProductStatusActive}
+		offboarded := internal.Product{ID: 1, Status: internal.ProductStatusOffboarded}
+		productRepo.On("FindByID", 1).Return(product, nil)
+		productRepo.On("Update", offboarded).Return(offboarded, nil)
 		err := svc.Delete(1)
 
 		// Verifica se não houve erro
 		assert.Nil(t, err)
+		productRepo.AssertNotCalled(t, "Delete", 1)
+		productRepo.AssertExpectations(t)
+	})
+
+	t.Run("delete_already_offboarded", func(t *testing.T) {
+		productRepo := new(RepositoryProductMock)
+		sellerRepo := new(sellerRepositoryMock)
+		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
+
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
+		productRepo.On("FindByID", 1).Return(internal.Product{ID: 1, Status: internal.ProductStatusOffboarded}, nil)
+		err := svc.Delete(1)
+
+		assert.Nil(t, err)
+		productRepo.AssertNotCalled(t, "Update", mock.Anything)
 	})
 
 	t.Run("delete_non_existent", func(t *testing.T) {
@@ -547,13 +692,16 @@ func TestProductServiceDefault_Delete(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
-		productRepo.On("Delete", 1).Return(internal.ErrProductNotFound)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
+		productRepo.On("FindByID", 1).Return(internal.Product{}, internal.ErrProductNotFound)
 		err := svc.Delete(1)
 
 		// Verifica se não houve erro
-		assert.NotNil(t, err)
+		assert.ErrorIs(t, err, internal.ErrProductNotFound)
 	})
 }
 
@@ -564,8 +712,11 @@ func TestProductServiceDefault_GetByIDRecord(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 
 		productRepo.On("FindByIDRecord", 1).Return(expectedProduct, nil)
 
@@ -579,8 +730,11 @@ func TestProductServiceDefault_GetByIDRecord(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 		productRepo.On("FindByIDRecord", 1).Return(internal.ProductRecordsJSONCount{}, internal.ErrProductNotFound)
 
 		// Chamada do método que será testado
@@ -595,8 +749,11 @@ func TestProductServiceDefault_GetAllRecord(t *testing.T) {
 		productRepo := new(RepositoryProductMock)
 		sellerRepo := new(sellerRepositoryMock)
 		productTypeRepo := new(ProductTypeRepositoryMock)
+		productBatchRepo := new(ProductBatchRepositoryMock)
+		sectionRepo := new(SectionRepositoryMock)
+		compatibilityRepo := new(ProductTypeCompatibilityRepositoryMock)
 
-		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo)
+		svc := service.NewProductService(productRepo, sellerRepo, productTypeRepo, productBatchRepo, sectionRepo, compatibilityRepo)
 		// Produtos esperados que o repositório deve retornar
 		expectedProducts := []internal.ProductRecordsJSONCount{
 			{ProductID: 1, Description: "P001", RecordsCount: 1},
@@ -626,6 +783,7 @@ func TestValidateProduct(t *testing.T) {
 		FreezingRate:                   -10.0,
 		ProductTypeID:                  1,
 		SellerID:                       100,
+		Status:                         internal.ProductStatusActive,
 	}
 
 	tests := []struct {
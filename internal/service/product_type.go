@@ -0,0 +1,76 @@
+package service
+
+import (
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+// NewProductTypeService creates a new instance of the product type service
+func NewProductTypeService(rp internal.ProductTypeRepository) *ProductTypeService {
+	return &ProductTypeService{
+		rp: rp,
+	}
+}
+
+// ProductTypeService is the implementation of the product type service
+type ProductTypeService struct {
+	rp internal.ProductTypeRepository
+}
+
+// FindAll returns all the product types
+func (s *ProductTypeService) FindAll() ([]internal.ProductType, error) {
+	return s.rp.FindAll()
+}
+
+// FindByID returns the product type with the given ID
+func (s *ProductTypeService) FindByID(id int) (internal.ProductType, error) {
+	return s.rp.FindByID(id)
+}
+
+// Save validates and stores a new product type
+func (s *ProductTypeService) Save(pt *internal.ProductType) error {
+	causes := pt.Validate()
+	if len(causes) > 0 {
+		return internal.DomainError{
+			Message: internal.ErrProductTypeBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	return s.rp.Save(pt)
+}
+
+// Update applies the patch to the product type and stores it
+func (s *ProductTypeService) Update(id int, patch internal.ProductTypePatch) (internal.ProductType, error) {
+	pt, err := s.rp.FindByID(id)
+	if err != nil {
+		return internal.ProductType{}, err
+	}
+
+	if patch.Name != nil {
+		pt.Name = *patch.Name
+	}
+
+	if patch.Description != nil {
+		pt.Description = *patch.Description
+	}
+
+	causes := pt.Validate()
+	if len(causes) > 0 {
+		return internal.ProductType{}, internal.DomainError{
+			Message: internal.ErrProductTypeBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	err = s.rp.Update(&pt)
+	if err != nil {
+		return internal.ProductType{}, err
+	}
+
+	return pt, nil
+}
+
+// Delete removes the product type as long as no section or product references it
+func (s *ProductTypeService) Delete(id int) error {
+	return s.rp.Delete(id)
+}
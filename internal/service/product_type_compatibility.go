@@ -0,0 +1,83 @@
+package service
+
+import (
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+// NewProductTypeCompatibilityService creates a new instance of the product type compatibility service
+func NewProductTypeCompatibilityService(rpCompatibility internal.ProductTypeCompatibilityRepository, rpProductType internal.ProductTypeRepository) *ProductTypeCompatibilityService {
+	return &ProductTypeCompatibilityService{
+		rpCompatibility: rpCompatibility,
+		rpProductType:   rpProductType,
+	}
+}
+
+// ProductTypeCompatibilityService is the implementation of the product type compatibility service
+type ProductTypeCompatibilityService struct {
+	rpCompatibility internal.ProductTypeCompatibilityRepository
+	rpProductType   internal.ProductTypeRepository
+}
+
+// FindCompatibleSections returns the sections that can store products of the type
+func (s *ProductTypeCompatibilityService) FindCompatibleSections(productTypeID int) (compatible internal.ProductTypeCompatibleSections, err error) {
+	// Check if the product type exists
+	_, err = s.rpProductType.FindByID(productTypeID)
+	if err != nil {
+		return
+	}
+
+	ids, err := s.rpCompatibility.FindSectionProductTypeIDs(productTypeID)
+	if err != nil {
+		return
+	}
+
+	compatible.Sections, err = s.rpCompatibility.FindSections(productTypeID)
+	if err != nil {
+		return
+	}
+
+	compatible.ProductTypeID = productTypeID
+	compatible.SectionProductTypeIDs = append([]int{productTypeID}, ids...)
+
+	return
+}
+
+// Save registers a compatibility rule between two existing product types
+func (s *ProductTypeCompatibilityService) Save(c internal.ProductTypeCompatibility) error {
+	// Check if the product type exists
+	_, err := s.rpProductType.FindByID(c.ProductTypeID)
+	if err != nil {
+		return err
+	}
+
+	// Validate the rule
+	causes := c.Validate()
+	if len(causes) > 0 {
+		return internal.DomainError{
+			Message: internal.ErrProductTypeCompatibilityBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	// Check if the section product type exists
+	_, err = s.rpProductType.FindByID(c.SectionProductTypeID)
+	if err != nil {
+		return internal.ErrProductTypeCompatibilitySectionTypeNotFound
+	}
+
+	return s.rpCompatibility.Save(c)
+}
+
+// Delete removes a compatibility rule as long as no stored batch relies on it
+func (s *ProductTypeCompatibilityService) Delete(c internal.ProductTypeCompatibility) error {
+	stored, err := s.rpCompatibility.CountStoredBatches(c)
+	if err != nil {
+		return err
+	}
+
+	if stored > 0 {
+		return internal.ErrProductTypeCompatibilityInUse
+	}
+
+	return s.rpCompatibility.Delete(c)
+}
@@ -0,0 +1,159 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewProductTypeCompatibilityRepositoryMock() *ProductTypeCompatibilityRepositoryMock {
+	return &ProductTypeCompatibilityRepositoryMock{}
+}
+
+type ProductTypeCompatibilityRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *ProductTypeCompatibilityRepositoryMock) FindSectionProductTypeIDs(productTypeID int) ([]int, error) {
+	args := r.Called(productTypeID)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (r *ProductTypeCompatibilityRepositoryMock) FindSections(productTypeID int) ([]internal.Section, error) {
+	args := r.Called(productTypeID)
+	return args.Get(0).([]internal.Section), args.Error(1)
+}
+
+func (r *ProductTypeCompatibilityRepositoryMock) IsCompatible(productTypeID int, sectionProductTypeID int) (bool, error) {
+	args := r.Called(productTypeID, sectionProductTypeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *ProductTypeCompatibilityRepositoryMock) CountStoredBatches(c internal.ProductTypeCompatibility) (int, error) {
+	args := r.Called(c)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *ProductTypeCompatibilityRepositoryMock) Save(c internal.ProductTypeCompatibility) error {
+	args := r.Called(c)
+	return args.Error(0)
+}
+
+func (r *ProductTypeCompatibilityRepositoryMock) Delete(c internal.ProductTypeCompatibility) error {
+	args := r.Called(c)
+	return args.Error(0)
+}
+
+func TestProductTypeCompatibilityService_FindCompatibleSections(t *testing.T) {
+	t.Run("case 1: success - Should list the product type first", func(t *testing.T) {
+		rpC := NewProductTypeCompatibilityRepositoryMock()
+		rpT := NewProductTypeRepositoryMock()
+		sv := service.NewProductTypeCompatibilityService(rpC, rpT)
+
+		sections := []internal.Section{{ID: 1, ProductTypeID: 6}, {ID: 4, ProductTypeID: 10}}
+
+		rpT.On("FindByID", 6).Return(internal.ProductType{ID: 6}, nil)
+		rpC.On("FindSectionProductTypeIDs", 6).Return([]int{10}, nil)
+		rpC.On("FindSections", 6).Return(sections, nil)
+
+		compatible, err := sv.FindCompatibleSections(6)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.ProductTypeCompatibleSections{ProductTypeID: 6, SectionProductTypeIDs: []int{6, 10}, Sections: sections}, compatible)
+	})
+
+	t.Run("case 2: error - Product type not found", func(t *testing.T) {
+		rpC := NewProductTypeCompatibilityRepositoryMock()
+		rpT := NewProductTypeRepositoryMock()
+		sv := service.NewProductTypeCompatibilityService(rpC, rpT)
+
+		rpT.On("FindByID", 99).Return(internal.ProductType{}, internal.ErrProductTypeNotFound)
+
+		_, err := sv.FindCompatibleSections(99)
+
+		require.ErrorIs(t, err, internal.ErrProductTypeNotFound)
+		rpC.AssertNumberOfCalls(t, "FindSections", 0)
+	})
+}
+
+func TestProductTypeCompatibilityService_Save(t *testing.T) {
+	t.Run("case 1: success - Should register the rule", func(t *testing.T) {
+		rpC := NewProductTypeCompatibilityRepositoryMock()
+		rpT := NewProductTypeRepositoryMock()
+		sv := service.NewProductTypeCompatibilityService(rpC, rpT)
+
+		c := internal.ProductTypeCompatibility{ProductTypeID: 6, SectionProductTypeID: 10}
+
+		rpT.On("FindByID", 6).Return(internal.ProductType{ID: 6}, nil)
+		rpT.On("FindByID", 10).Return(internal.ProductType{ID: 10}, nil)
+		rpC.On("Save", c).Return(nil)
+
+		err := sv.Save(c)
+
+		require.NoError(t, err)
+		rpC.AssertNumberOfCalls(t, "Save", 1)
+	})
+
+	t.Run("case 2: error - Should reject a rule with the same type", func(t *testing.T) {
+		rpC := NewProductTypeCompatibilityRepositoryMock()
+		rpT := NewProductTypeRepositoryMock()
+		sv := service.NewProductTypeCompatibilityService(rpC, rpT)
+
+		rpT.On("FindByID", 6).Return(internal.ProductType{ID: 6}, nil)
+
+		err := sv.Save(internal.ProductTypeCompatibility{ProductTypeID: 6, SectionProductTypeID: 6})
+
+		require.ErrorAs(t, err, &internal.DomainError{})
+		rpC.AssertNumberOfCalls(t, "Save", 0)
+	})
+
+	t.Run("case 3: error - Section product type not found", func(t *testing.T) {
+		rpC := NewProductTypeCompatibilityRepositoryMock()
+		rpT := NewProductTypeRepositoryMock()
+		sv := service.NewProductTypeCompatibilityService(rpC, rpT)
+
+		rpT.On("FindByID", 6).Return(internal.ProductType{ID: 6}, nil)
+		rpT.On("FindByID", 99).Return(internal.ProductType{}, internal.ErrProductTypeNotFound)
+
+		err := sv.Save(internal.ProductTypeCompatibility{ProductTypeID: 6, SectionProductTypeID: 99})
+
+		require.ErrorIs(t, err, internal.ErrProductTypeCompatibilitySectionTypeNotFound)
+		rpC.AssertNumberOfCalls(t, "Save", 0)
+	})
+}
+
+func TestProductTypeCompatibilityService_Delete(t *testing.T) {
+	t.Run("case 1: success - Should remove a rule no batch relies on", func(t *testing.T) {
+		rpC := NewProductTypeCompatibilityRepositoryMock()
+		rpT := NewProductTypeRepositoryMock()
+		sv := service.NewProductTypeCompatibilityService(rpC, rpT)
+
+		c := internal.ProductTypeCompatibility{ProductTypeID: 6, SectionProductTypeID: 10}
+
+		rpC.On("CountStoredBatches", c).Return(0, nil)
+		rpC.On("Delete", c).Return(nil)
+
+		err := sv.Delete(c)
+
+		require.NoError(t, err)
+		rpC.AssertNumberOfCalls(t, "Delete", 1)
+	})
+
+	t.Run("case 2: error - Should keep a rule stored batches rely on", func(t *testing.T) {
+		rpC := NewProductTypeCompatibilityRepositoryMock()
+		rpT := NewProductTypeRepositoryMock()
+		sv := service.NewProductTypeCompatibilityService(rpC, rpT)
+
+		c := internal.ProductTypeCompatibility{ProductTypeID: 6, SectionProductTypeID: 10}
+
+		rpC.On("CountStoredBatches", c).Return(2, nil)
+
+		err := sv.Delete(c)
+
+		require.ErrorIs(t, err, internal.ErrProductTypeCompatibilityInUse)
+		rpC.AssertNumberOfCalls(t, "Delete", 0)
+	})
+}
@@ -1,8 +1,12 @@
 package service_test
 
 import (
+	"testing"
+
 	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/service"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func NewProductTypeRepositoryMock() *ProductTypeRepositoryMock {
@@ -13,7 +17,97 @@ type ProductTypeRepositoryMock struct {
 	mock.Mock
 }
 
+func (m *ProductTypeRepositoryMock) FindAll() ([]internal.ProductType, error) {
+	args := m.Called()
+	return args.Get(0).([]internal.ProductType), args.Error(1)
+}
+
 func (m *ProductTypeRepositoryMock) FindByID(id int) (internal.ProductType, error) {
 	args := m.Called(id)
 	return args.Get(0).(internal.ProductType), args.Error(1)
 }
+
+func (m *ProductTypeRepositoryMock) Save(pt *internal.ProductType) error {
+	args := m.Called(pt)
+	return args.Error(0)
+}
+
+func (m *ProductTypeRepositoryMock) Update(pt *internal.ProductType) error {
+	args := m.Called(pt)
+	return args.Error(0)
+}
+
+func (m *ProductTypeRepositoryMock) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestProductTypeService_Save(t *testing.T) {
+	t.Run("case 1: success - Should save the product type", func(t *testing.T) {
+		rp := NewProductTypeRepositoryMock()
+		sv := service.NewProductTypeService(rp)
+
+		pt := internal.ProductType{Name: "Dairy", Description: "Milk and cheese"}
+		rp.On("Save", &pt).Return(nil)
+
+		err := sv.Save(&pt)
+
+		require.NoError(t, err)
+		rp.AssertNumberOfCalls(t, "Save", 1)
+	})
+
+	t.Run("case 2: error - Should reject an empty name", func(t *testing.T) {
+		rp := NewProductTypeRepositoryMock()
+		sv := service.NewProductTypeService(rp)
+
+		err := sv.Save(&internal.ProductType{Description: "Milk and cheese"})
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, err, &domainError)
+		require.Equal(t, "name", domainError.Causes[0].Field)
+		rp.AssertNumberOfCalls(t, "Save", 0)
+	})
+}
+
+func TestProductTypeService_Update(t *testing.T) {
+	t.Run("case 1: success - Should only change the given fields", func(t *testing.T) {
+		rp := NewProductTypeRepositoryMock()
+		sv := service.NewProductTypeService(rp)
+
+		name := "Frozen"
+		expected := internal.ProductType{ID: 10, Name: "Frozen", Description: "Frozen meals"}
+
+		rp.On("FindByID", 10).Return(internal.ProductType{ID: 10, Name: "Frozen Foods", Description: "Frozen meals"}, nil)
+		rp.On("Update", &expected).Return(nil)
+
+		pt, err := sv.Update(10, internal.ProductTypePatch{Name: &name})
+
+		require.NoError(t, err)
+		require.Equal(t, expected, pt)
+	})
+
+	t.Run("case 2: error - Product type not found", func(t *testing.T) {
+		rp := NewProductTypeRepositoryMock()
+		sv := service.NewProductTypeService(rp)
+
+		rp.On("FindByID", 99).Return(internal.ProductType{}, internal.ErrProductTypeNotFound)
+
+		_, err := sv.Update(99, internal.ProductTypePatch{})
+
+		require.ErrorIs(t, err, internal.ErrProductTypeNotFound)
+		rp.AssertNumberOfCalls(t, "Update", 0)
+	})
+}
+
+func TestProductTypeService_Delete(t *testing.T) {
+	t.Run("case 1: error - Product type still in use", func(t *testing.T) {
+		rp := NewProductTypeRepositoryMock()
+		sv := service.NewProductTypeService(rp)
+
+		rp.On("Delete", 1).Return(internal.ErrProductTypeInUse)
+
+		err := sv.Delete(1)
+
+		require.ErrorIs(t, err, internal.ErrProductTypeInUse)
+	})
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+// NewProvinceService creates a new instance of the province service
+func NewProvinceService(rp internal.ProvinceRepository, rpCountry internal.CountryRepository) *ProvinceService {
+	return &ProvinceService{
+		rp:        rp,
+		rpCountry: rpCountry,
+	}
+}
+
+// ProvinceService is the implementation of the province service
+type ProvinceService struct {
+	rp        internal.ProvinceRepository
+	rpCountry internal.CountryRepository
+}
+
+// FindAll returns the provinces, only the ones of the given country when it is not zero
+func (s *ProvinceService) FindAll(countryID int) ([]internal.Province, error) {
+	return s.rp.FindAll(countryID)
+}
+
+// FindByID returns the province with the given ID
+func (s *ProvinceService) FindByID(id int) (internal.Province, error) {
+	return s.rp.FindByID(id)
+}
+
+// Save trims the name of the province, checks its country exists and records it
+func (s *ProvinceService) Save(p *internal.Province) error {
+	p.Name = strings.TrimSpace(p.Name)
+
+	causes := p.Validate()
+	if len(causes) > 0 {
+		return internal.DomainError{
+			Message: internal.ErrProvinceBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	_, err := s.rpCountry.FindByID(p.CountryID)
+	if err != nil {
+		return err
+	}
+
+	return s.rp.Save(p)
+}
+
+// Report returns the localities, sellers and carries of every province
+func (s *ProvinceService) Report() ([]internal.ProvinceReport, error) {
+	return s.rp.Report()
+}
+
+// ReportByID returns the localities, sellers and carries of the province with the given ID
+func (s *ProvinceService) ReportByID(id int) (internal.ProvinceReport, error) {
+	return s.rp.ReportByID(id)
+}
@@ -0,0 +1,103 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewProvinceRepositoryMock() *ProvinceRepositoryMock {
+	return &ProvinceRepositoryMock{}
+}
+
+type ProvinceRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ProvinceRepositoryMock) FindAll(countryID int) ([]internal.Province, error) {
+	args := m.Called(countryID)
+	return args.Get(0).([]internal.Province), args.Error(1)
+}
+
+func (m *ProvinceRepositoryMock) FindByID(id int) (internal.Province, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.Province), args.Error(1)
+}
+
+func (m *ProvinceRepositoryMock) Save(p *internal.Province) error {
+	args := m.Called(p)
+	return args.Error(0)
+}
+
+func (m *ProvinceRepositoryMock) Report() ([]internal.ProvinceReport, error) {
+	args := m.Called()
+	return args.Get(0).([]internal.ProvinceReport), args.Error(1)
+}
+
+func (m *ProvinceRepositoryMock) ReportByID(id int) (internal.ProvinceReport, error) {
+	args := m.Called(id)
+	return args.Get(0).(internal.ProvinceReport), args.Error(1)
+}
+
+func TestProvinceService_Save(t *testing.T) {
+	t.Run("case 1: success - Should trim the name and save the province of an existing country", func(t *testing.T) {
+		rp := NewProvinceRepositoryMock()
+		rpCountry := NewCountryRepositoryMock()
+		sv := service.NewProvinceService(rp, rpCountry)
+
+		p := internal.Province{Name: " Texas ", CountryID: 1}
+		rpCountry.On("FindByID", 1).Return(internal.Country{ID: 1, Name: "United States"}, nil)
+		rp.On("Save", &p).Return(nil)
+
+		err := sv.Save(&p)
+
+		require.NoError(t, err)
+		require.Equal(t, "Texas", p.Name)
+		rp.AssertNumberOfCalls(t, "Save", 1)
+	})
+
+	t.Run("case 2: error - Should reject a province without name and country", func(t *testing.T) {
+		rp := NewProvinceRepositoryMock()
+		rpCountry := NewCountryRepositoryMock()
+		sv := service.NewProvinceService(rp, rpCountry)
+
+		var domainError internal.DomainError
+		err := sv.Save(&internal.Province{})
+
+		require.ErrorAs(t, err, &domainError)
+		require.Len(t, domainError.Causes, 2)
+		rpCountry.AssertNotCalled(t, "FindByID", mock.Anything)
+		rp.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("case 3: error - Should not save the province of an unknown country", func(t *testing.T) {
+		rp := NewProvinceRepositoryMock()
+		rpCountry := NewCountryRepositoryMock()
+		sv := service.NewProvinceService(rp, rpCountry)
+
+		rpCountry.On("FindByID", 99).Return(internal.Country{}, internal.ErrCountryNotFound)
+
+		err := sv.Save(&internal.Province{Name: "Texas", CountryID: 99})
+
+		require.ErrorIs(t, err, internal.ErrCountryNotFound)
+		rp.AssertNotCalled(t, "Save", mock.Anything)
+	})
+}
+
+func TestProvinceService_FindAll(t *testing.T) {
+	t.Run("case 1: success - Should return the provinces of the country", func(t *testing.T) {
+		rp := NewProvinceRepositoryMock()
+		sv := service.NewProvinceService(rp, NewCountryRepositoryMock())
+
+		expected := []internal.Province{{ID: 1, Name: "Texas", CountryID: 1}}
+		rp.On("FindAll", 1).Return(expected, nil)
+
+		provinces, err := sv.FindAll(1)
+
+		require.NoError(t, err)
+		require.Equal(t, expected, provinces)
+	})
+}
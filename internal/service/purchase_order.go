@@ -1,28 +1,60 @@
 package service
 
 import (
+	"time"
+
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 )
 
 // NewPurchaseOrderService creates a new instance of the purchase order service
-func NewPurchaseOrderService(rpPurchaseOrder internal.PurchaseOrderRepository, rpProductRecords internal.ProductRecordsRepository, svBuyer internal.BuyerService) *PurchaseOrderService {
+func NewPurchaseOrderService(rpPurchaseOrder internal.PurchaseOrderRepository, rpPurchaseOrderLine internal.PurchaseOrderLineRepository, rpProductRecords internal.ProductRecordsRepository, rpProduct internal.ProductRepository, svBuyer internal.BuyerService) *PurchaseOrderService {
 	return &PurchaseOrderService{
-		rpPurchaseOrder:  rpPurchaseOrder,
-		rpProductRecords: rpProductRecords,
-		svBuyer:          svBuyer,
+		rpPurchaseOrder:     rpPurchaseOrder,
+		rpPurchaseOrderLine: rpPurchaseOrderLine,
+		rpProductRecords:    rpProductRecords,
+		rpProduct:           rpProduct,
+		svBuyer:             svBuyer,
 	}
 }
 
 // PurchaseOrderService is the implementation of the purchase order service
 type PurchaseOrderService struct {
-	rpPurchaseOrder  internal.PurchaseOrderRepository
-	rpProductRecords internal.ProductRecordsRepository
-	svBuyer          internal.BuyerService
+	rpPurchaseOrder     internal.PurchaseOrderRepository
+	rpPurchaseOrderLine internal.PurchaseOrderLineRepository
+	rpProductRecords    internal.ProductRecordsRepository
+	rpProduct           internal.ProductRepository
+	svBuyer             internal.BuyerService
+}
+
+// FindAll returns the purchase orders that match the filter
+func (s *PurchaseOrderService) FindAll(filter internal.PurchaseOrderFilter) (purchaseOrders []internal.PurchaseOrder, err error) {
+	purchaseOrders, err = s.rpPurchaseOrder.FindAll(filter)
+	return
+}
+
+// FindByBuyerID returns the purchase orders of a buyer that match the filter
+func (s *PurchaseOrderService) FindByBuyerID(buyerID int, filter internal.PurchaseOrderFilter) (purchaseOrders []internal.PurchaseOrder, err error) {
+	// Check if the buyer exists
+	_, err = s.svBuyer.FindByID(buyerID)
+	if err != nil {
+		return
+	}
+
+	filter.BuyerID = buyerID
+	purchaseOrders, err = s.rpPurchaseOrder.FindAll(filter)
+
+	return
 }
 
-// FindByID returns a purchase order
+// FindByID returns a purchase order with its lines
 func (s *PurchaseOrderService) FindByID(id int) (p internal.PurchaseOrder, err error) {
 	p, err = s.rpPurchaseOrder.FindByID(id)
+	if err != nil {
+		return
+	}
+
+	p.Lines, err = s.rpPurchaseOrderLine.FindByPurchaseOrderID(id)
+
 	return
 }
 
@@ -38,10 +70,54 @@ func (s *PurchaseOrderService) Save(p *internal.PurchaseOrder) (err error) {
 		}
 	}
 
-	// Check if the product records exists
-	_, err = s.rpProductRecords.FindByID(p.ProductRecordID)
-	if err != nil {
-		return err
+	effectiveAt := p.OrderDate.Add(24*time.Hour - time.Second)
+
+	// Check if the product record exists and is the price effective on the order date,
+	// an order without lines buys one unit of it
+	if p.ProductRecordID != 0 {
+		productRecord, err := s.rpProductRecords.FindByID(p.ProductRecordID)
+		if err != nil {
+			return err
+		}
+
+		effectiveRecord, err := s.rpProductRecords.FindEffectiveByProductID(productRecord.ProductID, effectiveAt)
+		if err != nil {
+			return err
+		}
+
+		if effectiveRecord.ID != productRecord.ID {
+			return internal.ErrPurchaseOrderProductRecordNotEffective
+		}
+
+		if len(p.Lines) == 0 {
+			p.Lines = []internal.PurchaseOrderLine{{
+				ProductID: productRecord.ProductID,
+				Quantity:  1,
+			}}
+		}
+	}
+
+	// Check if the products exist before pricing them, inactive products and the products of inactive sellers cannot be ordered
+	for _, line := range p.Lines {
+		product, err := s.rpProduct.FindByID(line.ProductID)
+		if err != nil {
+			return err
+		}
+
+		err = product.Sellable()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Price the lines with the sale price effective on the order date
+	for i := range p.Lines {
+		productRecord, err := s.rpProductRecords.FindEffectiveByProductID(p.Lines[i].ProductID, effectiveAt)
+		if err != nil {
+			return err
+		}
+
+		p.Lines[i].UnitPrice = float64(productRecord.SalePrice)
 	}
 
 	// Check if the buyer exists
@@ -50,8 +126,140 @@ func (s *PurchaseOrderService) Save(p *internal.PurchaseOrder) (err error) {
 		return err
 	}
 
+	// Every order starts its lifecycle as created
+	p.Status = internal.PurchaseOrderStatusCreated
+
 	// Save the purchase order
 	err = s.rpPurchaseOrder.Save(p)
 
 	return
 }
+
+// Reserve holds stock from the product batches for the lines of a purchase order
+func (s *PurchaseOrderService) Reserve(id int) (lines []internal.PurchaseOrderLine, err error) {
+	// Check if the purchase order exists and can still take stock
+	p, err := s.rpPurchaseOrder.FindByID(id)
+	if err != nil {
+		return
+	}
+
+	err = checkPurchaseOrderTakesStock(p)
+	if err != nil {
+		return
+	}
+
+	_, err = s.rpPurchaseOrderLine.Reserve(id)
+	if err != nil {
+		return
+	}
+
+	lines, err = s.rpPurchaseOrderLine.FindByPurchaseOrderID(id)
+
+	return
+}
+
+// Fulfil reserves the missing stock of a purchase order and picks it from the product batches
+func (s *PurchaseOrderService) Fulfil(id int) (lines []internal.PurchaseOrderLine, err error) {
+	// Check if the purchase order exists and can still take stock
+	p, err := s.rpPurchaseOrder.FindByID(id)
+	if err != nil {
+		return
+	}
+
+	err = checkPurchaseOrderTakesStock(p)
+	if err != nil {
+		return
+	}
+
+	_, err = s.rpPurchaseOrderLine.Reserve(id)
+	if err != nil {
+		return
+	}
+
+	err = s.rpPurchaseOrderLine.Consume(id)
+	if err != nil {
+		return
+	}
+
+	lines, err = s.rpPurchaseOrderLine.FindByPurchaseOrderID(id)
+
+	return
+}
+
+// checkPurchaseOrderTakesStock rejects purchase orders that are cancelled or already shipped
+func checkPurchaseOrderTakesStock(p internal.PurchaseOrder) error {
+	if p.Status == internal.PurchaseOrderStatusCancelled {
+		return internal.ErrPurchaseOrderCancelled
+	}
+
+	if !p.CanTakeStock() {
+		return internal.ErrPurchaseOrderInvalidTransition
+	}
+
+	return nil
+}
+
+// UpdateStatus moves a purchase order to a new status when the transition is allowed and records who changed it
+func (s *PurchaseOrderService) UpdateStatus(id int, status string, changedBy string) (p internal.PurchaseOrder, err error) {
+	p, err = s.rpPurchaseOrder.FindByID(id)
+	if err != nil {
+		return
+	}
+
+	history := internal.PurchaseOrderStatusHistory{
+		PurchaseOrderID: id,
+		FromStatus:      p.Status,
+		ToStatus:        status,
+		ChangedBy:       changedBy,
+		ChangedAt:       time.Now(),
+	}
+
+	// Validate the status change
+	causes := history.Validate()
+
+	if len(causes) > 0 {
+		err = internal.DomainError{
+			Message: internal.ErrPurchaseOrderBadRequest.Error(),
+			Causes:  causes,
+		}
+		return
+	}
+
+	if internal.IsShipmentPurchaseOrderStatus(status) {
+		err = internal.ErrPurchaseOrderStatusSetByShipment
+		return
+	}
+
+	if !p.CanTransitionTo(status) {
+		err = internal.ErrPurchaseOrderInvalidTransition
+		return
+	}
+
+	err = s.rpPurchaseOrder.UpdateStatus(&history)
+	if err != nil {
+		return
+	}
+
+	p.Status = status
+
+	return
+}
+
+// FindHistory returns the status changes of a purchase order
+func (s *PurchaseOrderService) FindHistory(id int) (history []internal.PurchaseOrderStatusHistory, err error) {
+	// Check if the purchase order exists
+	_, err = s.rpPurchaseOrder.FindByID(id)
+	if err != nil {
+		return
+	}
+
+	history, err = s.rpPurchaseOrder.FindHistory(id)
+
+	return
+}
+
+// Cancel moves a purchase order to cancelled, releasing the stock it still holds
+func (s *PurchaseOrderService) Cancel(id int, changedBy string) (p internal.PurchaseOrder, err error) {
+	p, err = s.UpdateStatus(id, internal.PurchaseOrderStatusCancelled, changedBy)
+	return
+}
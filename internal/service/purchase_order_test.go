@@ -29,6 +29,44 @@ func (r *PurchaseOrderRepositoryMock) FindByID(id int) (internal.PurchaseOrder,
 	return args.Get(0).(internal.PurchaseOrder), args.Error(1)
 }
 
+func (r *PurchaseOrderRepositoryMock) FindAll(filter internal.PurchaseOrderFilter) ([]internal.PurchaseOrder, error) {
+	args := r.Called(filter)
+	return args.Get(0).([]internal.PurchaseOrder), args.Error(1)
+}
+
+func (r *PurchaseOrderRepositoryMock) UpdateStatus(h *internal.PurchaseOrderStatusHistory) error {
+	args := r.Called(h)
+	return args.Error(0)
+}
+
+func (r *PurchaseOrderRepositoryMock) FindHistory(id int) ([]internal.PurchaseOrderStatusHistory, error) {
+	args := r.Called(id)
+	return args.Get(0).([]internal.PurchaseOrderStatusHistory), args.Error(1)
+}
+
+func NewPurchaseOrderLineRepositoryMock() *PurchaseOrderLineRepositoryMock {
+	return &PurchaseOrderLineRepositoryMock{}
+}
+
+type PurchaseOrderLineRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *PurchaseOrderLineRepositoryMock) FindByPurchaseOrderID(purchaseOrderID int) ([]internal.PurchaseOrderLine, error) {
+	args := r.Called(purchaseOrderID)
+	return args.Get(0).([]internal.PurchaseOrderLine), args.Error(1)
+}
+
+func (r *PurchaseOrderLineRepositoryMock) Reserve(purchaseOrderID int) ([]internal.PurchaseOrderReservation, error) {
+	args := r.Called(purchaseOrderID)
+	return args.Get(0).([]internal.PurchaseOrderReservation), args.Error(1)
+}
+
+func (r *PurchaseOrderLineRepositoryMock) Consume(purchaseOrderID int) error {
+	args := r.Called(purchaseOrderID)
+	return args.Error(0)
+}
+
 func NewBuyerServiceMock() *BuyerServiceMock {
 	return &BuyerServiceMock{}
 }
@@ -67,6 +105,17 @@ func (bm *BuyerServiceMock) ReportPurchaseOrdersByID(id int) ([]internal.Purchas
 	return nil, nil
 }
 
+// newActiveProductRepositoryMock returns a product repository whose products and sellers are all active
+func newActiveProductRepositoryMock() *RepositoryProductMock {
+	rpP := NewRepositoryProductMock()
+	rpP.On("FindByID", mock.Anything).Return(internal.Product{
+		Status:       internal.ProductStatusActive,
+		SellerStatus: internal.SellerStatusActive,
+	}, nil)
+
+	return rpP
+}
+
 var (
 	now  = time.Now()
 	date = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
@@ -77,6 +126,7 @@ var (
 		TrackingCode:    "ABC12334",
 		BuyerID:         1,
 		ProductRecordID: 1,
+		Status:          internal.PurchaseOrderStatusCreated,
 	}
 )
 
@@ -85,13 +135,15 @@ func TestPurchaseOrderService_Save(t *testing.T) {
 		rpPo := NewPurchaseOrderRepositoryMock()
 		rpPr := new(RepositoryProductRecordsMock)
 		svBu := NewBuyerServiceMock()
-		sv := service.NewPurchaseOrderService(rpPo, rpPr, svBu)
+		sv := service.NewPurchaseOrderService(rpPo, NewPurchaseOrderLineRepositoryMock(), rpPr, newActiveProductRepositoryMock(), svBu)
+		p := po
 
-		rpPr.On("FindByID", po.ProductRecordID).Return(internal.ProductRecords{}, nil)
+		rpPr.On("FindByID", po.ProductRecordID).Return(internal.ProductRecords{ID: 1, ProductID: 1, SalePrice: 10}, nil)
+		rpPr.On("FindEffectiveByProductID", 1, mock.Anything).Return(internal.ProductRecords{ID: 1, ProductID: 1, SalePrice: 10}, nil)
 		svBu.On("FindByID", po.BuyerID).Return(internal.Buyer{}, nil)
-		rpPo.On("Save", &po).Return(nil)
+		rpPo.On("Save", &p).Return(nil)
 
-		err := sv.Save(&po)
+		err := sv.Save(&p)
 
 		require.NoError(t, err)
 	})
@@ -100,13 +152,15 @@ func TestPurchaseOrderService_Save(t *testing.T) {
 		rpPo := NewPurchaseOrderRepositoryMock()
 		rpPr := new(RepositoryProductRecordsMock)
 		svBu := NewBuyerServiceMock()
-		sv := service.NewPurchaseOrderService(rpPo, rpPr, svBu)
+		sv := service.NewPurchaseOrderService(rpPo, NewPurchaseOrderLineRepositoryMock(), rpPr, newActiveProductRepositoryMock(), svBu)
+		p := po
 
-		rpPr.On("FindByID", po.ProductRecordID).Return(internal.ProductRecords{}, nil)
+		rpPr.On("FindByID", po.ProductRecordID).Return(internal.ProductRecords{ID: 1, ProductID: 1, SalePrice: 10}, nil)
+		rpPr.On("FindEffectiveByProductID", 1, mock.Anything).Return(internal.ProductRecords{ID: 1, ProductID: 1, SalePrice: 10}, nil)
 		svBu.On("FindByID", po.BuyerID).Return(internal.Buyer{}, nil)
-		rpPo.On("Save", &po).Return(internal.ErrPurchaseOrderConflict)
+		rpPo.On("Save", &p).Return(internal.ErrPurchaseOrderConflict)
 
-		err := sv.Save(&po)
+		err := sv.Save(&p)
 
 		require.Error(t, err)
 		require.Equal(t, internal.ErrPurchaseOrderConflict, err)
@@ -116,12 +170,13 @@ func TestPurchaseOrderService_Save(t *testing.T) {
 		rpPo := NewPurchaseOrderRepositoryMock()
 		rpPr := new(RepositoryProductRecordsMock)
 		svBu := NewBuyerServiceMock()
-		sv := service.NewPurchaseOrderService(rpPo, rpPr, svBu)
+		sv := service.NewPurchaseOrderService(rpPo, NewPurchaseOrderLineRepositoryMock(), rpPr, newActiveProductRepositoryMock(), svBu)
+		p := po
 
 		rpPr.On("FindByID", po.ProductRecordID).Return(internal.ProductRecords{}, internal.ErrProductRecordsNotFound)
 		svBu.On("FindByID", po.BuyerID).Return(internal.Buyer{}, nil)
 
-		err := sv.Save(&po)
+		err := sv.Save(&p)
 
 		require.Error(t, err)
 		require.Equal(t, internal.ErrProductRecordsNotFound, err)
@@ -131,12 +186,14 @@ func TestPurchaseOrderService_Save(t *testing.T) {
 		rpPo := NewPurchaseOrderRepositoryMock()
 		rpPr := new(RepositoryProductRecordsMock)
 		svBu := NewBuyerServiceMock()
-		sv := service.NewPurchaseOrderService(rpPo, rpPr, svBu)
+		sv := service.NewPurchaseOrderService(rpPo, NewPurchaseOrderLineRepositoryMock(), rpPr, newActiveProductRepositoryMock(), svBu)
+		p := po
 
-		rpPr.On("FindByID", po.ProductRecordID).Return(internal.ProductRecords{}, nil)
+		rpPr.On("FindByID", po.ProductRecordID).Return(internal.ProductRecords{ID: 1, ProductID: 1, SalePrice: 10}, nil)
+		rpPr.On("FindEffectiveByProductID", 1, mock.Anything).Return(internal.ProductRecords{ID: 1, ProductID: 1, SalePrice: 10}, nil)
 		svBu.On("FindByID", po.BuyerID).Return(internal.Buyer{}, service.ErrBuyerNotFound)
 
-		err := sv.Save(&po)
+		err := sv.Save(&p)
 
 		require.Error(t, err)
 		require.Equal(t, service.ErrBuyerNotFound, err)
@@ -146,7 +203,7 @@ func TestPurchaseOrderService_Save(t *testing.T) {
 		rpPo := NewPurchaseOrderRepositoryMock()
 		rpPr := new(RepositoryProductRecordsMock)
 		svBu := NewBuyerServiceMock()
-		sv := service.NewPurchaseOrderService(rpPo, rpPr, svBu)
+		sv := service.NewPurchaseOrderService(rpPo, NewPurchaseOrderLineRepositoryMock(), rpPr, newActiveProductRepositoryMock(), svBu)
 
 		rpPr.On("FindByID", po.ProductRecordID).Return(internal.ProductRecords{}, nil)
 		svBu.On("FindByID", po.BuyerID).Return(internal.Buyer{}, nil)
@@ -160,11 +217,12 @@ func TestPurchaseOrderService_Save(t *testing.T) {
 		rpPo := NewPurchaseOrderRepositoryMock()
 		rpPr := new(RepositoryProductRecordsMock)
 		svBu := NewBuyerServiceMock()
-		sv := service.NewPurchaseOrderService(rpPo, rpPr, svBu)
+		sv := service.NewPurchaseOrderService(rpPo, NewPurchaseOrderLineRepositoryMock(), rpPr, newActiveProductRepositoryMock(), svBu)
+		p := po
 
 		rpPr.On("FindByID", po.ProductRecordID).Return(internal.ProductRecords{}, errors.New("internal server error"))
 
-		err := sv.Save(&po)
+		err := sv.Save(&p)
 
 		require.Error(t, err)
 		require.EqualError(t, err, "internal server error")
@@ -172,21 +230,27 @@ func TestPurchaseOrderService_Save(t *testing.T) {
 }
 
 func TestPurchaseOrderService_FindByID(t *testing.T) {
-	t.Run("case 1: success - Should return a Purchase Order", func(t *testing.T) {
+	t.Run("case 1: success - Should return a Purchase Order with its lines", func(t *testing.T) {
 		rpPo := NewPurchaseOrderRepositoryMock()
-		sv := service.NewPurchaseOrderService(rpPo, nil, nil)
+		rpPol := NewPurchaseOrderLineRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, rpPol, nil, nil, nil)
+		lines := []internal.PurchaseOrderLine{{ID: 1, PurchaseOrderID: po.ID, ProductID: 1, Quantity: 1}}
 
 		rpPo.On("FindByID", po.ID).Return(po, nil)
+		rpPol.On("FindByPurchaseOrderID", po.ID).Return(lines, nil)
 
 		p, err := sv.FindByID(po.ID)
 
+		expected := po
+		expected.Lines = lines
+
 		require.NoError(t, err)
-		require.Equal(t, po, p)
+		require.Equal(t, expected, p)
 	})
 
 	t.Run("case 2 - error - Should return an error when trying to find a non-existent Purchase Order", func(t *testing.T) {
 		rpPo := NewPurchaseOrderRepositoryMock()
-		sv := service.NewPurchaseOrderService(rpPo, nil, nil)
+		sv := service.NewPurchaseOrderService(rpPo, nil, nil, nil, nil)
 
 		rpPo.On("FindByID", po.ID).Return(internal.PurchaseOrder{}, internal.ErrPurchaseOrderNotFound)
 
@@ -196,3 +260,441 @@ func TestPurchaseOrderService_FindByID(t *testing.T) {
 		require.Equal(t, internal.ErrPurchaseOrderNotFound, err)
 	})
 }
+
+func TestPurchaseOrderService_SaveWithLines(t *testing.T) {
+	t.Run("case 1: success - Should price the lines with the product record effective on the order date", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		rpPr := new(RepositoryProductRecordsMock)
+		svBu := NewBuyerServiceMock()
+		sv := service.NewPurchaseOrderService(rpPo, NewPurchaseOrderLineRepositoryMock(), rpPr, newActiveProductRepositoryMock(), svBu)
+		p := internal.PurchaseOrder{
+			OrderNumber:  "ON004",
+			OrderDate:    po.OrderDate,
+			TrackingCode: "ABC12335",
+			BuyerID:      1,
+			Lines:        []internal.PurchaseOrderLine{{ProductID: 1, Quantity: 5}},
+		}
+
+		effectiveAt := po.OrderDate.Add(24*time.Hour - time.Second)
+		rpPr.On("FindEffectiveByProductID", 1, effectiveAt).Return(internal.ProductRecords{ID: 7, ProductID: 1, SalePrice: 12.5}, nil)
+		svBu.On("FindByID", p.BuyerID).Return(internal.Buyer{}, nil)
+		rpPo.On("Save", &p).Return(nil)
+
+		err := sv.Save(&p)
+
+		require.NoError(t, err)
+		require.Equal(t, 12.5, p.Lines[0].UnitPrice)
+		require.Zero(t, p.ProductRecordID)
+	})
+
+	t.Run("case 2: success - Should turn the product record into a single line", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		rpPr := new(RepositoryProductRecordsMock)
+		svBu := NewBuyerServiceMock()
+		sv := service.NewPurchaseOrderService(rpPo, NewPurchaseOrderLineRepositoryMock(), rpPr, newActiveProductRepositoryMock(), svBu)
+		p := po
+
+		rpPr.On("FindByID", po.ProductRecordID).Return(internal.ProductRecords{ID: 1, ProductID: 2, SalePrice: 10}, nil)
+		rpPr.On("FindEffectiveByProductID", 2, mock.Anything).Return(internal.ProductRecords{ID: 1, ProductID: 2, SalePrice: 10}, nil)
+		svBu.On("FindByID", po.BuyerID).Return(internal.Buyer{}, nil)
+		rpPo.On("Save", &p).Return(nil)
+
+		err := sv.Save(&p)
+
+		require.NoError(t, err)
+		require.Equal(t, []internal.PurchaseOrderLine{{ProductID: 2, Quantity: 1, UnitPrice: 10}}, p.Lines)
+	})
+
+	t.Run("case 3: error - Should return a validation error for an invalid line", func(t *testing.T) {
+		sv := service.NewPurchaseOrderService(NewPurchaseOrderRepositoryMock(), nil, nil, nil, nil)
+		p := internal.PurchaseOrder{
+			OrderNumber:  "ON004",
+			OrderDate:    po.OrderDate,
+			TrackingCode: "ABC12335",
+			BuyerID:      1,
+			Lines:        []internal.PurchaseOrderLine{{ProductID: 1}},
+		}
+
+		err := sv.Save(&p)
+
+		require.ErrorAs(t, err, &internal.DomainError{})
+	})
+
+	t.Run("case 4: error - Should reject the products of a suspended seller", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		rpPr := new(RepositoryProductRecordsMock)
+		rpP := NewRepositoryProductMock()
+		svBu := NewBuyerServiceMock()
+		sv := service.NewPurchaseOrderService(rpPo, NewPurchaseOrderLineRepositoryMock(), rpPr, rpP, svBu)
+		p := internal.PurchaseOrder{
+			OrderNumber:  "ON004",
+			OrderDate:    po.OrderDate,
+			TrackingCode: "ABC12335",
+			BuyerID:      1,
+			Lines:        []internal.PurchaseOrderLine{{ProductID: 1, Quantity: 5}},
+		}
+
+		rpP.On("FindByID", 1).Return(internal.Product{ID: 1, Status: internal.ProductStatusActive, SellerStatus: internal.SellerStatusSuspended}, nil)
+
+		err := sv.Save(&p)
+
+		require.ErrorIs(t, err, internal.ErrSellerNotActive)
+		rpPr.AssertNotCalled(t, "FindEffectiveByProductID", mock.Anything, mock.Anything)
+		rpPo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("case 5: error - Should reject a product without a price effective on the order date", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		rpPr := new(RepositoryProductRecordsMock)
+		sv := service.NewPurchaseOrderService(rpPo, NewPurchaseOrderLineRepositoryMock(), rpPr, newActiveProductRepositoryMock(), NewBuyerServiceMock())
+		p := internal.PurchaseOrder{
+			OrderNumber:  "ON004",
+			OrderDate:    po.OrderDate,
+			TrackingCode: "ABC12335",
+			BuyerID:      1,
+			Lines:        []internal.PurchaseOrderLine{{ProductID: 1, Quantity: 5}},
+		}
+
+		rpPr.On("FindEffectiveByProductID", 1, mock.Anything).Return(internal.ProductRecords{}, internal.ErrProductPriceNotFound)
+
+		err := sv.Save(&p)
+
+		require.ErrorIs(t, err, internal.ErrProductPriceNotFound)
+		rpPo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("case 6: error - Should reject a product record that is not the price effective on the order date", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		rpPr := new(RepositoryProductRecordsMock)
+		sv := service.NewPurchaseOrderService(rpPo, NewPurchaseOrderLineRepositoryMock(), rpPr, newActiveProductRepositoryMock(), NewBuyerServiceMock())
+		p := po
+
+		rpPr.On("FindByID", po.ProductRecordID).Return(internal.ProductRecords{ID: 1, ProductID: 2, SalePrice: 10}, nil)
+		rpPr.On("FindEffectiveByProductID", 2, mock.Anything).Return(internal.ProductRecords{ID: 4, ProductID: 2, SalePrice: 11}, nil)
+
+		err := sv.Save(&p)
+
+		require.ErrorIs(t, err, internal.ErrPurchaseOrderProductRecordNotEffective)
+		rpPo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+	t.Run("case 7: error - Should return not found for an unknown product instead of a missing price", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		rpPr := new(RepositoryProductRecordsMock)
+		rpP := NewRepositoryProductMock()
+		sv := service.NewPurchaseOrderService(rpPo, NewPurchaseOrderLineRepositoryMock(), rpPr, rpP, NewBuyerServiceMock())
+		p := internal.PurchaseOrder{
+			OrderNumber: "ON004",
+			OrderDate:   po.OrderDate,
+			BuyerID:     1,
+			Lines:       []internal.PurchaseOrderLine{{ProductID: 99, Quantity: 5}},
+		}
+
+		rpP.On("FindByID", 99).Return(internal.Product{}, internal.ErrProductNotFound)
+
+		err := sv.Save(&p)
+
+		require.ErrorIs(t, err, internal.ErrProductNotFound)
+		rpPr.AssertNotCalled(t, "FindEffectiveByProductID", mock.Anything, mock.Anything)
+		rpPo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+}
+
+func TestPurchaseOrderService_Reserve(t *testing.T) {
+	t.Run("case 1: success - Should reserve the lines of a Purchase Order", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		rpPol := NewPurchaseOrderLineRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, rpPol, nil, nil, nil)
+		lines := []internal.PurchaseOrderLine{{ID: 1, PurchaseOrderID: 1, ProductID: 1, Quantity: 5, ReservedQuantity: 5}}
+
+		rpPo.On("FindByID", 1).Return(po, nil)
+		rpPol.On("Reserve", 1).Return([]internal.PurchaseOrderReservation{{ID: 1, PurchaseOrderLineID: 1, ProductBatchID: 1, Quantity: 5}}, nil)
+		rpPol.On("FindByPurchaseOrderID", 1).Return(lines, nil)
+
+		result, err := sv.Reserve(1)
+
+		require.NoError(t, err)
+		require.Equal(t, lines, result)
+	})
+
+	t.Run("case 2: error - Should return an error when the stock is not enough", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		rpPol := NewPurchaseOrderLineRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, rpPol, nil, nil, nil)
+
+		rpPo.On("FindByID", 1).Return(po, nil)
+		rpPol.On("Reserve", 1).Return([]internal.PurchaseOrderReservation{}, internal.ErrPurchaseOrderInsufficientStock)
+
+		_, err := sv.Reserve(1)
+
+		require.ErrorIs(t, err, internal.ErrPurchaseOrderInsufficientStock)
+		rpPol.AssertNotCalled(t, "FindByPurchaseOrderID", 1)
+	})
+
+	t.Run("case 3: error - Should return an error when the Purchase Order does not exist", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		rpPol := NewPurchaseOrderLineRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, rpPol, nil, nil, nil)
+
+		rpPo.On("FindByID", 1).Return(internal.PurchaseOrder{}, internal.ErrPurchaseOrderNotFound)
+
+		_, err := sv.Reserve(1)
+
+		require.ErrorIs(t, err, internal.ErrPurchaseOrderNotFound)
+		rpPol.AssertNotCalled(t, "Reserve", 1)
+	})
+}
+
+func TestPurchaseOrderService_Fulfil(t *testing.T) {
+	t.Run("case 1: success - Should consume the reservations of a Purchase Order", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		rpPol := NewPurchaseOrderLineRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, rpPol, nil, nil, nil)
+		lines := []internal.PurchaseOrderLine{{ID: 1, PurchaseOrderID: 1, ProductID: 1, Quantity: 5, ReservedQuantity: 5, FulfilledQuantity: 5}}
+
+		rpPo.On("FindByID", 1).Return(po, nil)
+		rpPol.On("Reserve", 1).Return([]internal.PurchaseOrderReservation{}, nil)
+		rpPol.On("Consume", 1).Return(nil)
+		rpPol.On("FindByPurchaseOrderID", 1).Return(lines, nil)
+
+		result, err := sv.Fulfil(1)
+
+		require.NoError(t, err)
+		require.Equal(t, lines, result)
+	})
+
+	t.Run("case 2: error - Should not consume when the stock is not enough", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		rpPol := NewPurchaseOrderLineRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, rpPol, nil, nil, nil)
+
+		rpPo.On("FindByID", 1).Return(po, nil)
+		rpPol.On("Reserve", 1).Return([]internal.PurchaseOrderReservation{}, internal.ErrPurchaseOrderInsufficientStock)
+
+		_, err := sv.Fulfil(1)
+
+		require.ErrorIs(t, err, internal.ErrPurchaseOrderInsufficientStock)
+		rpPol.AssertNotCalled(t, "Consume", 1)
+	})
+
+	t.Run("case 3: error - Should not pick stock for a shipped Purchase Order", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		rpPol := NewPurchaseOrderLineRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, rpPol, nil, nil, nil)
+		p := po
+		p.Status = internal.PurchaseOrderStatusShipped
+
+		rpPo.On("FindByID", 1).Return(p, nil)
+
+		_, err := sv.Fulfil(1)
+
+		require.ErrorIs(t, err, internal.ErrPurchaseOrderInvalidTransition)
+		rpPol.AssertNotCalled(t, "Reserve", 1)
+		rpPol.AssertNotCalled(t, "Consume", 1)
+	})
+}
+
+func TestPurchaseOrderService_UpdateStatus(t *testing.T) {
+	t.Run("case 1: success - Should move a created Purchase Order to confirmed", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, nil, nil, nil, nil)
+		p := po
+		p.Status = internal.PurchaseOrderStatusCreated
+
+		rpPo.On("FindByID", 1).Return(p, nil)
+		rpPo.On("UpdateStatus", mock.MatchedBy(func(h *internal.PurchaseOrderStatusHistory) bool {
+			return h.PurchaseOrderID == 1 && h.FromStatus == "created" && h.ToStatus == "confirmed" && h.ChangedBy == "jdoe"
+		})).Return(nil)
+
+		result, err := sv.UpdateStatus(1, internal.PurchaseOrderStatusConfirmed, "jdoe")
+
+		require.NoError(t, err)
+		require.Equal(t, internal.PurchaseOrderStatusConfirmed, result.Status)
+	})
+
+	t.Run("case 2: error - Should reject an illegal transition", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, nil, nil, nil, nil)
+		p := po
+		p.Status = internal.PurchaseOrderStatusDelivered
+
+		rpPo.On("FindByID", 1).Return(p, nil)
+
+		_, err := sv.UpdateStatus(1, internal.PurchaseOrderStatusCancelled, "jdoe")
+
+		require.ErrorIs(t, err, internal.ErrPurchaseOrderInvalidTransition)
+		rpPo.AssertNotCalled(t, "UpdateStatus", mock.Anything)
+	})
+
+	t.Run("case 3: error - Should reject an unknown status", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, nil, nil, nil, nil)
+		p := po
+		p.Status = internal.PurchaseOrderStatusCreated
+
+		rpPo.On("FindByID", 1).Return(p, nil)
+
+		_, err := sv.UpdateStatus(1, "lost", "jdoe")
+
+		require.ErrorAs(t, err, &internal.DomainError{})
+	})
+
+	t.Run("case 4: error - Should return an error when the Purchase Order does not exist", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, nil, nil, nil, nil)
+
+		rpPo.On("FindByID", 1).Return(internal.PurchaseOrder{}, internal.ErrPurchaseOrderNotFound)
+
+		_, err := sv.UpdateStatus(1, internal.PurchaseOrderStatusConfirmed, "jdoe")
+
+		require.ErrorIs(t, err, internal.ErrPurchaseOrderNotFound)
+	})
+
+	t.Run("case 5: error - Should leave shipped and delivered to the shipment", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, nil, nil, nil, nil)
+		p := po
+		p.Status = internal.PurchaseOrderStatusPicking
+
+		rpPo.On("FindByID", 1).Return(p, nil)
+
+		_, err := sv.UpdateStatus(1, internal.PurchaseOrderStatusShipped, "jdoe")
+
+		require.ErrorIs(t, err, internal.ErrPurchaseOrderStatusSetByShipment)
+		rpPo.AssertNotCalled(t, "UpdateStatus", mock.Anything)
+	})
+}
+
+func TestPurchaseOrderService_FindHistory(t *testing.T) {
+	t.Run("case 1: success - Should return the status history", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, nil, nil, nil, nil)
+		history := []internal.PurchaseOrderStatusHistory{{ID: 1, PurchaseOrderID: 1, FromStatus: "created", ToStatus: "confirmed", ChangedBy: "jdoe"}}
+
+		rpPo.On("FindByID", 1).Return(po, nil)
+		rpPo.On("FindHistory", 1).Return(history, nil)
+
+		result, err := sv.FindHistory(1)
+
+		require.NoError(t, err)
+		require.Equal(t, history, result)
+	})
+
+	t.Run("case 2: error - Should return an error when the Purchase Order does not exist", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, nil, nil, nil, nil)
+
+		rpPo.On("FindByID", 1).Return(internal.PurchaseOrder{}, internal.ErrPurchaseOrderNotFound)
+
+		_, err := sv.FindHistory(1)
+
+		require.ErrorIs(t, err, internal.ErrPurchaseOrderNotFound)
+		rpPo.AssertNotCalled(t, "FindHistory", 1)
+	})
+}
+
+func TestPurchaseOrderService_FindAll(t *testing.T) {
+	t.Run("case 1: success - Should return the filtered Purchase Orders", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, nil, nil, nil, nil)
+		filter := internal.PurchaseOrderFilter{TrackingCode: "ABC12334"}
+
+		rpPo.On("FindAll", filter).Return([]internal.PurchaseOrder{po}, nil)
+
+		result, err := sv.FindAll(filter)
+
+		require.NoError(t, err)
+		require.Equal(t, []internal.PurchaseOrder{po}, result)
+	})
+}
+
+func TestPurchaseOrderService_FindByBuyerID(t *testing.T) {
+	t.Run("case 1: success - Should return the Purchase Orders of the buyer", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		svBu := NewBuyerServiceMock()
+		sv := service.NewPurchaseOrderService(rpPo, nil, nil, nil, svBu)
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		svBu.On("FindByID", 1).Return(internal.Buyer{}, nil)
+		rpPo.On("FindAll", internal.PurchaseOrderFilter{BuyerID: 1, From: from}).Return([]internal.PurchaseOrder{po}, nil)
+
+		result, err := sv.FindByBuyerID(1, internal.PurchaseOrderFilter{BuyerID: 7, From: from})
+
+		require.NoError(t, err)
+		require.Equal(t, []internal.PurchaseOrder{po}, result)
+	})
+
+	t.Run("case 2: error - Should return an error when the buyer does not exist", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		svBu := NewBuyerServiceMock()
+		sv := service.NewPurchaseOrderService(rpPo, nil, nil, nil, svBu)
+
+		svBu.On("FindByID", 1).Return(internal.Buyer{}, service.ErrBuyerNotFound)
+
+		_, err := sv.FindByBuyerID(1, internal.PurchaseOrderFilter{})
+
+		require.ErrorIs(t, err, service.ErrBuyerNotFound)
+		rpPo.AssertNotCalled(t, "FindAll", mock.Anything)
+	})
+}
+
+func TestPurchaseOrderService_Cancel(t *testing.T) {
+	t.Run("case 1: success - Should cancel a confirmed Purchase Order", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, nil, nil, nil, nil)
+		p := po
+		p.Status = internal.PurchaseOrderStatusConfirmed
+
+		rpPo.On("FindByID", 1).Return(p, nil)
+		rpPo.On("UpdateStatus", mock.MatchedBy(func(h *internal.PurchaseOrderStatusHistory) bool {
+			return h.FromStatus == "confirmed" && h.ToStatus == "cancelled" && h.ChangedBy == "support"
+		})).Return(nil)
+
+		result, err := sv.Cancel(1, "support")
+
+		require.NoError(t, err)
+		require.Equal(t, internal.PurchaseOrderStatusCancelled, result.Status)
+	})
+
+	t.Run("case 2: error - Should not cancel a shipped Purchase Order", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, nil, nil, nil, nil)
+		p := po
+		p.Status = internal.PurchaseOrderStatusShipped
+
+		rpPo.On("FindByID", 1).Return(p, nil)
+
+		_, err := sv.Cancel(1, "support")
+
+		require.ErrorIs(t, err, internal.ErrPurchaseOrderInvalidTransition)
+	})
+
+	t.Run("case 3: error - Should not reserve stock for a cancelled Purchase Order", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		rpPol := NewPurchaseOrderLineRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, rpPol, nil, nil, nil)
+		p := po
+		p.Status = internal.PurchaseOrderStatusCancelled
+
+		rpPo.On("FindByID", 1).Return(p, nil)
+
+		_, err := sv.Reserve(1)
+
+		require.ErrorIs(t, err, internal.ErrPurchaseOrderCancelled)
+		rpPol.AssertNotCalled(t, "Reserve", 1)
+	})
+
+	t.Run("case 4: error - Should not reserve stock for a delivered Purchase Order", func(t *testing.T) {
+		rpPo := NewPurchaseOrderRepositoryMock()
+		rpPol := NewPurchaseOrderLineRepositoryMock()
+		sv := service.NewPurchaseOrderService(rpPo, rpPol, nil, nil, nil)
+		p := po
+		p.Status = internal.PurchaseOrderStatusDelivered
+
+		rpPo.On("FindByID", 1).Return(p, nil)
+
+		_, err := sv.Reserve(1)
+
+		require.ErrorIs(t, err, internal.ErrPurchaseOrderInvalidTransition)
+		rpPol.AssertNotCalled(t, "Reserve", 1)
+	})
+}
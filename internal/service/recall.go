@@ -0,0 +1,129 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+// NewRecallService creates a new instance of the recall service
+func NewRecallService(rpRecall internal.RecallRepository, rpProduct internal.ProductRepository) *RecallService {
+	return &RecallService{
+		rpRecall:  rpRecall,
+		rpProduct: rpProduct,
+	}
+}
+
+// RecallService is the implementation of the recall service
+type RecallService struct {
+	rpRecall  internal.RecallRepository
+	rpProduct internal.ProductRepository
+}
+
+// FindAll returns the recalls
+func (s *RecallService) FindAll() ([]internal.Recall, error) {
+	return s.rpRecall.FindAll()
+}
+
+// FindReport returns the recall with the batches it affects, where they are stored and the buyers who may have received them
+func (s *RecallService) FindReport(id int) (report internal.RecallReport, err error) {
+	report.Recall, err = s.rpRecall.FindByID(id)
+	if err != nil {
+		return
+	}
+
+	report.Batches, err = s.rpRecall.FindBatches(id)
+	if err != nil {
+		return
+	}
+
+	report.Buyers, err = s.rpRecall.FindBuyers(id)
+	if err != nil {
+		return
+	}
+
+	// only product recalls are stored with their product, batch recalls are rebuilt from their batches
+	if report.Recall.ProductID == 0 {
+		report.Recall.BatchNumbers = batchNumbers(report.Batches)
+	}
+
+	report.Recall.Batches = report.Batches
+
+	return
+}
+
+// Save resolves the batches affected by the recall and stores it
+func (s *RecallService) Save(recall *internal.Recall) error {
+	// Validate the recall
+	causes := recall.Validate()
+	if len(causes) > 0 {
+		return internal.DomainError{
+			Message: internal.ErrRecallBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	// Check if the product exists
+	if recall.ProductID != 0 {
+		_, err := s.rpProduct.FindByID(recall.ProductID)
+		if err != nil {
+			return err
+		}
+	}
+
+	batches, err := s.rpRecall.FindMatchingBatches(*recall)
+	if err != nil {
+		return err
+	}
+
+	// every requested batch number must exist
+	if missing := missingBatchNumbers(recall.BatchNumbers, batches); len(missing) > 0 {
+		return internal.DomainError{
+			Message: internal.ErrRecallBadRequest.Error(),
+			Causes: []internal.Causes{{
+				Field:   "batch_numbers",
+				Message: fmt.Sprintf("batch numbers %v do not exist", missing),
+			}},
+		}
+	}
+
+	if len(batches) == 0 {
+		return internal.ErrRecallWithoutBatches
+	}
+
+	recall.Batches = batches
+	recall.CreatedAt = time.Now().UTC()
+
+	return s.rpRecall.Save(recall)
+}
+
+// missingBatchNumbers returns the requested batch numbers that no batch matches
+func missingBatchNumbers(requested []int, batches []internal.RecallBatch) (missing []int) {
+	found := make(map[int]bool, len(batches))
+	for _, batch := range batches {
+		found[batch.BatchNumber] = true
+	}
+
+	for _, batchNumber := range requested {
+		if !found[batchNumber] {
+			missing = append(missing, batchNumber)
+			found[batchNumber] = true
+		}
+	}
+
+	return
+}
+
+// batchNumbers returns the distinct batch numbers of the batches in order of appearance
+func batchNumbers(batches []internal.RecallBatch) (numbers []int) {
+	seen := make(map[int]bool, len(batches))
+	for _, batch := range batches {
+		if !seen[batch.BatchNumber] {
+			seen[batch.BatchNumber] = true
+			numbers = append(numbers, batch.BatchNumber)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,162 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewRecallRepositoryMock() *RecallRepositoryMock {
+	return &RecallRepositoryMock{}
+}
+
+type RecallRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RecallRepositoryMock) FindAll() ([]internal.Recall, error) {
+	args := r.Called()
+	return args.Get(0).([]internal.Recall), args.Error(1)
+}
+
+func (r *RecallRepositoryMock) FindByID(id int) (internal.Recall, error) {
+	args := r.Called(id)
+	return args.Get(0).(internal.Recall), args.Error(1)
+}
+
+func (r *RecallRepositoryMock) FindMatchingBatches(recall internal.Recall) ([]internal.RecallBatch, error) {
+	args := r.Called(recall)
+	return args.Get(0).([]internal.RecallBatch), args.Error(1)
+}
+
+func (r *RecallRepositoryMock) FindBatches(recallID int) ([]internal.RecallBatch, error) {
+	args := r.Called(recallID)
+	return args.Get(0).([]internal.RecallBatch), args.Error(1)
+}
+
+func (r *RecallRepositoryMock) FindBuyers(recallID int) ([]internal.RecallBuyer, error) {
+	args := r.Called(recallID)
+	return args.Get(0).([]internal.RecallBuyer), args.Error(1)
+}
+
+func (r *RecallRepositoryMock) Save(recall *internal.Recall) error {
+	args := r.Called(recall)
+	return args.Error(0)
+}
+
+func TestRecallService_Save(t *testing.T) {
+	t.Run("case 1: success - Should recall every batch of the product", func(t *testing.T) {
+		rpR := NewRecallRepositoryMock()
+		rpP := NewRepositoryProductMock()
+		sv := service.NewRecallService(rpR, rpP)
+
+		recall := internal.Recall{ProductID: 1, Reason: "listeria"}
+		batches := []internal.RecallBatch{{ProductBatchID: 3, BatchNumber: 10}, {ProductBatchID: 4, BatchNumber: 11}}
+
+		rpP.On("FindByID", 1).Return(internal.Product{ID: 1}, nil)
+		rpR.On("FindMatchingBatches", recall).Return(batches, nil)
+		rpR.On("Save", &recall).Return(nil)
+
+		err := sv.Save(&recall)
+
+		require.NoError(t, err)
+		require.Equal(t, batches, recall.Batches)
+		require.False(t, recall.CreatedAt.IsZero())
+		rpR.AssertNumberOfCalls(t, "Save", 1)
+	})
+
+	t.Run("case 2: error - Should reject unknown batch numbers", func(t *testing.T) {
+		rpR := NewRecallRepositoryMock()
+		sv := service.NewRecallService(rpR, NewRepositoryProductMock())
+
+		recall := internal.Recall{BatchNumbers: []int{10, 12}, Reason: "listeria"}
+
+		rpR.On("FindMatchingBatches", recall).Return([]internal.RecallBatch{{ProductBatchID: 3, BatchNumber: 10}}, nil)
+
+		err := sv.Save(&recall)
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, err, &domainError)
+		require.Equal(t, "batch numbers [12] do not exist", domainError.Causes[0].Message)
+		rpR.AssertNumberOfCalls(t, "Save", 0)
+	})
+
+	t.Run("case 3: error - Should reject a product without batches", func(t *testing.T) {
+		rpR := NewRecallRepositoryMock()
+		rpP := NewRepositoryProductMock()
+		sv := service.NewRecallService(rpR, rpP)
+
+		recall := internal.Recall{ProductID: 1, Reason: "listeria"}
+
+		rpP.On("FindByID", 1).Return(internal.Product{ID: 1}, nil)
+		rpR.On("FindMatchingBatches", recall).Return([]internal.RecallBatch(nil), nil)
+
+		err := sv.Save(&recall)
+
+		require.ErrorIs(t, err, internal.ErrRecallWithoutBatches)
+		rpR.AssertNumberOfCalls(t, "Save", 0)
+	})
+
+	t.Run("case 4: error - Should reject an unknown product", func(t *testing.T) {
+		rpR := NewRecallRepositoryMock()
+		rpP := NewRepositoryProductMock()
+		sv := service.NewRecallService(rpR, rpP)
+
+		recall := internal.Recall{ProductID: 99, Reason: "listeria"}
+
+		rpP.On("FindByID", 99).Return(internal.Product{}, internal.ErrProductNotFound)
+
+		err := sv.Save(&recall)
+
+		require.ErrorIs(t, err, internal.ErrProductNotFound)
+		rpR.AssertNumberOfCalls(t, "FindMatchingBatches", 0)
+	})
+
+	t.Run("case 5: error - Should validate the recall", func(t *testing.T) {
+		rpR := NewRecallRepositoryMock()
+		sv := service.NewRecallService(rpR, NewRepositoryProductMock())
+
+		recall := internal.Recall{ProductID: 1, BatchNumbers: []int{10}, Reason: "listeria"}
+
+		err := sv.Save(&recall)
+
+		require.ErrorAs(t, err, &internal.DomainError{})
+		rpR.AssertNumberOfCalls(t, "FindMatchingBatches", 0)
+	})
+}
+
+func TestRecallService_FindReport(t *testing.T) {
+	t.Run("case 1: success - Should rebuild the batch numbers of a batch recall", func(t *testing.T) {
+		rpR := NewRecallRepositoryMock()
+		sv := service.NewRecallService(rpR, NewRepositoryProductMock())
+
+		batches := []internal.RecallBatch{{ProductBatchID: 3, BatchNumber: 10}, {ProductBatchID: 4, BatchNumber: 10}, {ProductBatchID: 5, BatchNumber: 11}}
+		buyers := []internal.RecallBuyer{{BuyerID: 1, PurchaseOrderID: 4}}
+
+		rpR.On("FindByID", 1).Return(internal.Recall{ID: 1, Reason: "listeria"}, nil)
+		rpR.On("FindBatches", 1).Return(batches, nil)
+		rpR.On("FindBuyers", 1).Return(buyers, nil)
+
+		report, err := sv.FindReport(1)
+
+		require.NoError(t, err)
+		require.Equal(t, []int{10, 11}, report.Recall.BatchNumbers)
+		require.Equal(t, batches, report.Batches)
+		require.Equal(t, buyers, report.Buyers)
+	})
+
+	t.Run("case 2: error - Recall not found", func(t *testing.T) {
+		rpR := NewRecallRepositoryMock()
+		sv := service.NewRecallService(rpR, NewRepositoryProductMock())
+
+		rpR.On("FindByID", 99).Return(internal.Recall{}, internal.ErrRecallNotFound)
+
+		_, err := sv.FindReport(99)
+
+		require.ErrorIs(t, err, internal.ErrRecallNotFound)
+		rpR.AssertNumberOfCalls(t, "FindBatches", 0)
+	})
+}
@@ -4,20 +4,24 @@ import (
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 )
 
-func NewServiceSection(rpSection internal.SectionRepository, rpProductType internal.ProductTypeRepository, rpProduct internal.ProductRepository, rpWareHouse internal.WarehouseRepository) *SectionService {
+func NewServiceSection(rpSection internal.SectionRepository, rpProductType internal.ProductTypeRepository, rpProduct internal.ProductRepository, rpWareHouse internal.WarehouseRepository, rpProductBatch internal.ProductBatchRepository, rpCompatibility internal.ProductTypeCompatibilityRepository) *SectionService {
 	return &SectionService{
-		rpS: rpSection,
-		rpP: rpProduct,
-		rpT: rpProductType,
-		rpW: rpWareHouse,
+		rpS:  rpSection,
+		rpP:  rpProduct,
+		rpT:  rpProductType,
+		rpW:  rpWareHouse,
+		rpPB: rpProductBatch,
+		rpC:  rpCompatibility,
 	}
 }
 
 type SectionService struct {
-	rpS internal.SectionRepository
-	rpP internal.ProductRepository
-	rpT internal.ProductTypeRepository
-	rpW internal.WarehouseRepository
+	rpS  internal.SectionRepository
+	rpP  internal.ProductRepository
+	rpT  internal.ProductTypeRepository
+	rpW  internal.WarehouseRepository
+	rpPB internal.ProductBatchRepository
+	rpC  internal.ProductTypeCompatibilityRepository
 }
 
 func (s *SectionService) FindAll() ([]internal.Section, error) {
@@ -38,6 +42,18 @@ func (s *SectionService) FindByID(id int) (internal.Section, error) {
 	return section, nil
 }
 
+// FindOccupancy returns how much of the section capacity is in use
+func (s *SectionService) FindOccupancy(id int) (internal.SectionOccupancy, error) {
+	occupancy, err := s.rpS.FindOccupancy(id)
+	if err != nil {
+		return internal.SectionOccupancy{}, err
+	}
+
+	occupancy.SetUsage()
+
+	return occupancy, nil
+}
+
 func (s *SectionService) ReportProducts() ([]internal.ReportProduct, error) {
 	reportProducts, err := s.rpS.ReportProducts()
 	if err != nil {
@@ -161,11 +177,7 @@ func (s *SectionService) updateTemperature(updateSection *internal.SectionPatch,
 
 func (s *SectionService) updateCapacity(updateSection *internal.SectionPatch, actualSection *internal.Section) error {
 	if updateSection.CurrentCapacity != nil {
-		if *updateSection.CurrentCapacity < 0 {
-			return internal.ErrSectionUnprocessableEntity
-		}
-
-		actualSection.CurrentCapacity = *updateSection.CurrentCapacity
+		return internal.ErrSectionCurrentCapacityReadOnly
 	}
 
 	if updateSection.MinimumCapacity != nil {
@@ -181,6 +193,10 @@ func (s *SectionService) updateCapacity(updateSection *internal.SectionPatch, ac
 			return internal.ErrSectionUnprocessableEntity
 		}
 
+		if *updateSection.MaximumCapacity < actualSection.CurrentCapacity {
+			return internal.ErrSectionMaximumCapacityBelowCurrent
+		}
+
 		actualSection.MaximumCapacity = *updateSection.MaximumCapacity
 	}
 
@@ -203,7 +219,43 @@ func (s *SectionService) updateWarehouseAndProduct(updateSection *internal.Secti
 			return internal.ErrProductTypeNotFound
 		}
 
-		actualSection.ProductTypeID = *updateSection.ProductTypeID
+		// the batches already stored must still fit the section under the new type
+		if *updateSection.ProductTypeID != actualSection.ProductTypeID {
+			actualSection.ProductTypeID = *updateSection.ProductTypeID
+
+			err = s.checkStoredBatches(*actualSection)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkStoredBatches makes sure the section can still hold the products of the batches stored in it
+func (s *SectionService) checkStoredBatches(section internal.Section) error {
+	prodBatches, err := s.rpPB.FindBySectionID(section.ID)
+	if err != nil {
+		return err
+	}
+
+	checked := make(map[int]bool)
+	for _, pb := range prodBatches {
+		if checked[pb.ProductID] {
+			continue
+		}
+		checked[pb.ProductID] = true
+
+		product, err := s.rpP.FindByID(pb.ProductID)
+		if err != nil {
+			return err
+		}
+
+		err = checkProductTypeCompatibility(s.rpC, product, section)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -62,17 +62,33 @@ func (r *SectionRepositoryMock) Delete(id int) error {
 	return args.Error(0)
 }
 
+func (r *SectionRepositoryMock) FindOccupancy(id int) (internal.SectionOccupancy, error) {
+	args := r.Called(id)
+	return args.Get(0).(internal.SectionOccupancy), args.Error(1)
+}
+
 func float64Ptr(f float64) *float64 {
 	return &f
 }
 
 func newSectionService() (*service.SectionService, *SectionRepositoryMock, *ProductTypeRepositoryMock, *WarehouseRepositoryMock) {
+	sv, rpSection, rpProductType, rpWareHouse, _, _, _ := newSectionServiceWithStock()
+
+	return sv, rpSection, rpProductType, rpWareHouse
+}
+
+// newSectionServiceWithStock also hands out the mocks used to look at the batches stored in the section
+func newSectionServiceWithStock() (*service.SectionService, *SectionRepositoryMock, *ProductTypeRepositoryMock, *WarehouseRepositoryMock, *RepositoryProductMock, *ProductBatchRepositoryMock, *ProductTypeCompatibilityRepositoryMock) {
 	rpSection := NewSectionRepositoryMock()
 	rpProductType := NewProductTypeRepositoryMock()
 	rpProduct := NewRepositoryProductMock()
 	rpWareHouse := NewWarehouseRepositoryMock()
+	rpProductBatch := NewProductBatchRepositoryMock()
+	rpCompatibility := NewProductTypeCompatibilityRepositoryMock()
 
-	return service.NewServiceSection(rpSection, rpProductType, rpProduct, rpWareHouse), rpSection, rpProductType, rpWareHouse
+	sv := service.NewServiceSection(rpSection, rpProductType, rpProduct, rpWareHouse, rpProductBatch, rpCompatibility)
+
+	return sv, rpSection, rpProductType, rpWareHouse, rpProduct, rpProductBatch, rpCompatibility
 }
 
 func newTestSection(id int, sectionNumber int, warehouseID int, productTypeID int) internal.Section {
@@ -405,7 +421,7 @@ func TestService_UpdateSectionUnitTest(t *testing.T) {
 		sv, rpSection, rpWareHouse, rpProductType := newSectionService()
 
 		updates := internal.SectionPatch{
-			CurrentCapacity: intPtr(150),
+			MaximumCapacity: intPtr(150),
 		}
 
 		rpSection.On("FindByID", 1).Return(internal.Section{}, internal.ErrSectionNotFound)
@@ -435,7 +451,6 @@ func TestService_UpdateSectionUnitTest(t *testing.T) {
 			SectionNumber:      intPtr(456),
 			CurrentTemperature: float64Ptr(22.5),
 			MinimumTemperature: float64Ptr(15.0),
-			CurrentCapacity:    intPtr(502),
 			MinimumCapacity:    intPtr(302),
 			MaximumCapacity:    intPtr(150),
 			WarehouseID:        intPtr(7),
@@ -574,16 +589,16 @@ func TestService_UpdateSectionUnitTest(t *testing.T) {
 		rpSection.AssertNumberOfCalls(t, "Update", 0)
 	})
 
-	t.Run("returns error when current capacity is less than zero", func(t *testing.T) {
+	t.Run("returns error when current capacity is patched", func(t *testing.T) {
 		sv, rpSection, rpWareHouse, rpProductType := newSectionService()
 
 		rpSection.On("FindByID", 1).Return(internal.Section{}, nil)
 		rpSection.On("SectionNumberExists", 123).Return(false, nil)
 
-		updatedSection, err := sv.Update(1, internal.SectionPatch{SectionNumber: intPtr(123), CurrentCapacity: intPtr(-2)})
+		updatedSection, err := sv.Update(1, internal.SectionPatch{SectionNumber: intPtr(123), CurrentCapacity: intPtr(20)})
 
 		require.Error(t, err)
-		require.Equal(t, internal.ErrSectionUnprocessableEntity, err)
+		require.Equal(t, internal.ErrSectionCurrentCapacityReadOnly, err)
 		require.Empty(t, updatedSection)
 
 		rpSection.AssertExpectations(t)
@@ -640,6 +655,28 @@ func TestService_UpdateSectionUnitTest(t *testing.T) {
 		rpSection.AssertNumberOfCalls(t, "Update", 0)
 	})
 
+	t.Run("returns error when maximum capacity is below the current capacity", func(t *testing.T) {
+		sv, rpSection, rpWareHouse, rpProductType := newSectionService()
+
+		rpSection.On("FindByID", 1).Return(newTestSection(1, 100, 6, 7), nil)
+		rpSection.On("SectionNumberExists", 123).Return(false, nil)
+
+		updatedSection, err := sv.Update(1, internal.SectionPatch{SectionNumber: intPtr(123), MaximumCapacity: intPtr(40)})
+
+		require.Error(t, err)
+		require.Equal(t, internal.ErrSectionMaximumCapacityBelowCurrent, err)
+		require.Empty(t, updatedSection)
+
+		rpSection.AssertExpectations(t)
+		rpSection.AssertNumberOfCalls(t, "FindByID", 1)
+		rpSection.AssertNumberOfCalls(t, "SectionNumberExists", 1)
+		rpWareHouse.AssertExpectations(t)
+		rpWareHouse.AssertNumberOfCalls(t, "FindByID", 0)
+		rpProductType.AssertExpectations(t)
+		rpProductType.AssertNumberOfCalls(t, "FindByID", 0)
+		rpSection.AssertNumberOfCalls(t, "Update", 0)
+	})
+
 	t.Run("returns error when warehouse does not exist", func(t *testing.T) {
 		sv, rpSection, rpProductType, rpWareHouse := newSectionService()
 
@@ -649,7 +686,6 @@ func TestService_UpdateSectionUnitTest(t *testing.T) {
 			SectionNumber:      intPtr(456),
 			CurrentTemperature: float64Ptr(22.5),
 			MinimumTemperature: float64Ptr(15.0),
-			CurrentCapacity:    intPtr(502),
 			MinimumCapacity:    intPtr(302),
 			MaximumCapacity:    intPtr(150),
 			WarehouseID:        intPtr(7),
@@ -685,7 +721,6 @@ func TestService_UpdateSectionUnitTest(t *testing.T) {
 			SectionNumber:      intPtr(456),
 			CurrentTemperature: float64Ptr(22.5),
 			MinimumTemperature: float64Ptr(15.0),
-			CurrentCapacity:    intPtr(502),
 			MinimumCapacity:    intPtr(302),
 			MaximumCapacity:    intPtr(150),
 			WarehouseID:        intPtr(7),
@@ -722,7 +757,6 @@ func TestService_UpdateSectionUnitTest(t *testing.T) {
 			SectionNumber:      intPtr(456),
 			CurrentTemperature: float64Ptr(22.5),
 			MinimumTemperature: float64Ptr(15.0),
-			CurrentCapacity:    intPtr(502),
 			MinimumCapacity:    intPtr(302),
 			MaximumCapacity:    intPtr(150),
 			WarehouseID:        intPtr(7),
@@ -751,6 +785,45 @@ func TestService_UpdateSectionUnitTest(t *testing.T) {
 		rpProductType.AssertNumberOfCalls(t, "FindByID", 1)
 		rpSection.AssertNumberOfCalls(t, "Update", 1)
 	})
+
+	t.Run("returns error when the stored batches cannot stay under the new product type", func(t *testing.T) {
+		sv, rpSection, rpProductType, _, rpProduct, rpProductBatch, rpCompatibility := newSectionServiceWithStock()
+
+		rpSection.On("FindByID", 1).Return(newTestSection(1, 100, 6, 7), nil)
+		rpProductType.On("FindByID", 8).Return(internal.ProductType{ID: 8}, nil)
+		rpProductBatch.On("FindBySectionID", 1).Return([]internal.ProductBatch{
+			{ID: 1, ProductID: 4, SectionID: 1},
+			{ID: 2, ProductID: 4, SectionID: 1},
+		}, nil)
+		rpProduct.On("FindByID", 4).Return(internal.Product{ID: 4, ProductTypeID: 7}, nil)
+		rpCompatibility.On("IsCompatible", 7, 8).Return(false, nil)
+
+		updatedSection, err := sv.Update(1, internal.SectionPatch{ProductTypeID: intPtr(8)})
+
+		require.ErrorAs(t, err, &internal.DomainError{})
+		require.Empty(t, updatedSection)
+
+		rpProduct.AssertNumberOfCalls(t, "FindByID", 1)
+		rpSection.AssertNumberOfCalls(t, "Update", 0)
+	})
+
+	t.Run("successfully update the product type when a rule keeps the stored batches", func(t *testing.T) {
+		sv, rpSection, rpProductType, _, rpProduct, rpProductBatch, rpCompatibility := newSectionServiceWithStock()
+
+		rpSection.On("FindByID", 1).Return(newTestSection(1, 100, 6, 7), nil)
+		rpProductType.On("FindByID", 8).Return(internal.ProductType{ID: 8}, nil)
+		rpProductBatch.On("FindBySectionID", 1).Return([]internal.ProductBatch{{ID: 1, ProductID: 4, SectionID: 1}}, nil)
+		rpProduct.On("FindByID", 4).Return(internal.Product{ID: 4, ProductTypeID: 7}, nil)
+		rpCompatibility.On("IsCompatible", 7, 8).Return(true, nil)
+		rpSection.On("Update", mock.AnythingOfType("*internal.Section")).Return(nil)
+
+		updatedSection, err := sv.Update(1, internal.SectionPatch{ProductTypeID: intPtr(8)})
+
+		require.NoError(t, err)
+		require.Equal(t, 8, updatedSection.ProductTypeID)
+
+		rpSection.AssertNumberOfCalls(t, "Update", 1)
+	})
 }
 
 func TestService_DeleteSectionUnitTest(t *testing.T) {
@@ -802,3 +875,35 @@ func TestService_DeleteSectionUnitTest(t *testing.T) {
 		rpSection.AssertNumberOfCalls(t, "Delete", 1)
 	})
 }
+
+func TestService_FindOccupancySectionUnitTest(t *testing.T) {
+	t.Run("compute the usage of the section", func(t *testing.T) {
+		sv, rpSection, _, _ := newSectionService()
+
+		rpSection.On("FindOccupancy", 1).Return(internal.SectionOccupancy{SectionID: 1, CurrentCapacity: 15, MinimumCapacity: 20, MaximumCapacity: 200, BatchesCount: 2}, nil)
+
+		occupancy, err := sv.FindOccupancy(1)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.SectionOccupancy{
+			SectionID:           1,
+			CurrentCapacity:     15,
+			MinimumCapacity:     20,
+			MaximumCapacity:     200,
+			AvailableCapacity:   185,
+			OccupancyPercentage: 7.5,
+			BelowMinimum:        true,
+			BatchesCount:        2,
+		}, occupancy)
+	})
+
+	t.Run("return section not found", func(t *testing.T) {
+		sv, rpSection, _, _ := newSectionService()
+
+		rpSection.On("FindOccupancy", 99).Return(internal.SectionOccupancy{}, internal.ErrSectionNotFound)
+
+		_, err := sv.FindOccupancy(99)
+
+		require.ErrorIs(t, err, internal.ErrSectionNotFound)
+	})
+}
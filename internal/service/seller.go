@@ -2,6 +2,8 @@ package service
 
 import (
 	"errors"
+	"slices"
+	"strings"
 
 	"github.com/meli-fresh-products-api-backend-t1/internal"
 )
@@ -37,6 +39,11 @@ func (s *SellerServiceDefault) FindByID(id int) (internal.Seller, error) {
 }
 
 func (s *SellerServiceDefault) Save(seller *internal.Seller) error {
+	// sellers are onboarded as active unless told otherwise
+	if seller.Status == "" {
+		seller.Status = internal.SellerStatusActive
+	}
+
 	causes := seller.Validate()
 	if len(causes) > 0 {
 		return internal.DomainError{
@@ -107,11 +114,68 @@ func (s *SellerServiceDefault) Update(id int, updatedSeller internal.SellerPatch
 		actualSeller.Locality = *updatedSeller.Locality
 	}
 
+	if updatedSeller.Status != nil {
+		if !slices.Contains(internal.SellerStatuses, *updatedSeller.Status) {
+			return internal.Seller{}, internal.DomainError{
+				Message: "Seller fields invalid",
+				Causes: []internal.Causes{{
+					Field:   "status",
+					Message: "Status must be one of " + strings.Join(internal.SellerStatuses, ", "),
+				}},
+			}
+		}
+
+		actualSeller.Status = *updatedSeller.Status
+	}
+
 	err = s.rp.Update(&actualSeller)
 
 	return actualSeller, err
 }
 
+// Delete offboards the seller, its products and their history are kept
 func (s *SellerServiceDefault) Delete(id int) error {
+	seller, err := s.rp.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	seller.Status = internal.SellerStatusOffboarded
+
+	return s.rp.Update(&seller)
+}
+
+// HardDelete deletes the seller and its products, it refuses when the products have history
+func (s *SellerServiceDefault) HardDelete(id int) error {
+	_, err := s.rp.FindByID(id)
+	if err != nil {
+		return err
+	}
+
 	return s.rp.Delete(id)
 }
+
+func (s *SellerServiceDefault) FindProducts(id int) ([]internal.Product, error) {
+	_, err := s.rp.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.rp.FindProducts(id)
+}
+
+func (s *SellerServiceDefault) Report(query internal.SellerReportQuery) ([]internal.SellerReport, int, error) {
+	causes := query.Validate()
+	if len(causes) > 0 {
+		return nil, 0, internal.DomainError{
+			Message: internal.ErrSellerReportBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	return s.rp.Report(query)
+}
+
+func (s *SellerServiceDefault) ReportByID(id int) (internal.SellerReport, error) {
+	return s.rp.ReportByID(id)
+}
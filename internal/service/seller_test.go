@@ -44,6 +44,21 @@ func (r *sellerRepositoryMock) Delete(id int) error {
 	return args.Error(0)
 }
 
+func (r *sellerRepositoryMock) FindProducts(id int) ([]internal.Product, error) {
+	args := r.Called(id)
+	return args.Get(0).([]internal.Product), args.Error(1)
+}
+
+func (r *sellerRepositoryMock) Report(query internal.SellerReportQuery) ([]internal.SellerReport, int, error) {
+	args := r.Called(query)
+	return args.Get(0).([]internal.SellerReport), args.Int(1), args.Error(2)
+}
+
+func (r *sellerRepositoryMock) ReportByID(id int) (internal.SellerReport, error) {
+	args := r.Called(id)
+	return args.Get(0).(internal.SellerReport), args.Error(1)
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -299,6 +314,21 @@ func TestSellerServiceDefault_Update(t *testing.T) {
 		assert.Equal(t, expectedSeller, seller)
 	})
 
+	t.Run("should return a domain error for an unknown status", func(t *testing.T) {
+		repo := new(sellerRepositoryMock)
+		localityRepo := new(localityRepositoryMock)
+		svc := service.NewSellerServiceDefault(repo, localityRepo)
+
+		repo.On("FindByID", 1).Return(internal.Seller{ID: 1, Status: internal.SellerStatusActive}, nil)
+
+		_, err := svc.Update(1, internal.SellerPatch{Status: stringPtr("closed")})
+
+		var domainError internal.DomainError
+		assert.ErrorAs(t, err, &domainError)
+		assert.Equal(t, "status", domainError.Causes[0].Field)
+		repo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
 	t.Run("should return error if seller CID exists", func(t *testing.T) {
 		repo := new(sellerRepositoryMock)
 		localityRepo := new(localityRepositoryMock)
@@ -404,27 +434,141 @@ func TestSellerServiceDefault_Update(t *testing.T) {
 }
 
 func TestSellerServiceDefault_Delete(t *testing.T) {
-	t.Run("should delete seller successfully", func(t *testing.T) {
+	t.Run("should offboard the seller", func(t *testing.T) {
 		repo := new(sellerRepositoryMock)
 		localityRepo := new(localityRepositoryMock)
 		svc := service.NewSellerServiceDefault(repo, localityRepo)
 
-		repo.On("Delete", 1).Return(nil)
+		seller := internal.Seller{ID: 1, CID: 1, Locality: 1, Status: internal.SellerStatusActive}
+		offboarded := seller
+		offboarded.Status = internal.SellerStatusOffboarded
+		repo.On("FindByID", 1).Return(seller, nil)
+		repo.On("Update", &offboarded).Return(nil)
 
 		err := svc.Delete(1)
 
 		assert.Nil(t, err)
+		repo.AssertNotCalled(t, "Delete", 1)
 	})
 
-	t.Run("should return error if repository fails to delete", func(t *testing.T) {
+	t.Run("should return not found for an unknown seller", func(t *testing.T) {
 		repo := new(sellerRepositoryMock)
 		localityRepo := new(localityRepositoryMock)
 		svc := service.NewSellerServiceDefault(repo, localityRepo)
 
-		repo.On("Delete", 1).Return(errors.New("repository error"))
+		repo.On("FindByID", 1).Return(internal.Seller{}, internal.ErrSellerNotFound)
+
+		err := svc.Delete(1)
+
+		assert.ErrorIs(t, err, internal.ErrSellerNotFound)
+	})
+
+	t.Run("should return error if repository fails to update", func(t *testing.T) {
+		repo := new(sellerRepositoryMock)
+		localityRepo := new(localityRepositoryMock)
+		svc := service.NewSellerServiceDefault(repo, localityRepo)
+
+		repo.On("FindByID", 1).Return(internal.Seller{ID: 1}, nil)
+		repo.On("Update", mock.Anything).Return(errors.New("repository error"))
 
 		err := svc.Delete(1)
 
 		assert.NotNil(t, err)
 	})
 }
+
+func TestSellerServiceDefault_HardDelete(t *testing.T) {
+	t.Run("should delete a seller without history", func(t *testing.T) {
+		repo := new(sellerRepositoryMock)
+		svc := service.NewSellerServiceDefault(repo, nil)
+
+		repo.On("FindByID", 1).Return(internal.Seller{ID: 1}, nil)
+		repo.On("Delete", 1).Return(nil)
+
+		err := svc.HardDelete(1)
+
+		assert.Nil(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should refuse to delete a seller with history", func(t *testing.T) {
+		repo := new(sellerRepositoryMock)
+		svc := service.NewSellerServiceDefault(repo, nil)
+
+		repo.On("FindByID", 1).Return(internal.Seller{ID: 1}, nil)
+		repo.On("Delete", 1).Return(internal.ErrSellerHasHistory)
+
+		err := svc.HardDelete(1)
+
+		assert.ErrorIs(t, err, internal.ErrSellerHasHistory)
+	})
+
+	t.Run("should return not found for an unknown seller", func(t *testing.T) {
+		repo := new(sellerRepositoryMock)
+		svc := service.NewSellerServiceDefault(repo, nil)
+
+		repo.On("FindByID", 1).Return(internal.Seller{}, internal.ErrSellerNotFound)
+
+		err := svc.HardDelete(1)
+
+		assert.ErrorIs(t, err, internal.ErrSellerNotFound)
+		repo.AssertNotCalled(t, "Delete", 1)
+	})
+}
+
+func TestSellerServiceDefault_FindProducts(t *testing.T) {
+	t.Run("should return the products of the seller", func(t *testing.T) {
+		repo := new(sellerRepositoryMock)
+		svc := service.NewSellerServiceDefault(repo, nil)
+
+		expectedProducts := []internal.Product{{ID: 1, SellerID: 1}}
+		repo.On("FindByID", 1).Return(internal.Seller{ID: 1}, nil)
+		repo.On("FindProducts", 1).Return(expectedProducts, nil)
+
+		products, err := svc.FindProducts(1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedProducts, products)
+	})
+
+	t.Run("should return not found for an unknown seller", func(t *testing.T) {
+		repo := new(sellerRepositoryMock)
+		svc := service.NewSellerServiceDefault(repo, nil)
+
+		repo.On("FindByID", 99).Return(internal.Seller{}, internal.ErrSellerNotFound)
+
+		_, err := svc.FindProducts(99)
+
+		assert.ErrorIs(t, err, internal.ErrSellerNotFound)
+		repo.AssertNotCalled(t, "FindProducts", 99)
+	})
+}
+
+func TestSellerServiceDefault_Report(t *testing.T) {
+	t.Run("should return a page of the report", func(t *testing.T) {
+		repo := new(sellerRepositoryMock)
+		svc := service.NewSellerServiceDefault(repo, nil)
+
+		query := internal.SellerReportQuery{SortBy: "revenue", Order: "desc", Limit: 2, Offset: 0}
+		expectedReports := []internal.SellerReport{{SellerID: 3, Revenue: 500}, {SellerID: 1, Revenue: 200}}
+		repo.On("Report", query).Return(expectedReports, 7, nil)
+
+		reports, total, err := svc.Report(query)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedReports, reports)
+		assert.Equal(t, 7, total)
+	})
+
+	t.Run("should return a domain error for an invalid sort", func(t *testing.T) {
+		repo := new(sellerRepositoryMock)
+		svc := service.NewSellerServiceDefault(repo, nil)
+
+		_, _, err := svc.Report(internal.SellerReportQuery{SortBy: "cid", Order: "asc", Limit: 20})
+
+		var domainError internal.DomainError
+		assert.ErrorAs(t, err, &domainError)
+		assert.Equal(t, "sort", domainError.Causes[0].Field)
+		repo.AssertNotCalled(t, "Report", mock.Anything)
+	})
+}
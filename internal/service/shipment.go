@@ -0,0 +1,191 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+// shipmentTrackingCodePrefix is the prefix of the tracking codes generated for shipments
+const shipmentTrackingCodePrefix = "SHP"
+
+// NewShipmentService creates a new instance of the shipment service
+func NewShipmentService(
+	rpShipment internal.ShipmentRepository,
+	rpCarries internal.CarriesRepository,
+	rpWarehouse internal.WarehouseRepository,
+	rpPurchaseOrder internal.PurchaseOrderRepository,
+) *ShipmentService {
+	return &ShipmentService{
+		rpShipment:      rpShipment,
+		rpCarries:       rpCarries,
+		rpWarehouse:     rpWarehouse,
+		rpPurchaseOrder: rpPurchaseOrder,
+	}
+}
+
+// ShipmentService is the implementation of the shipment service
+type ShipmentService struct {
+	rpShipment      internal.ShipmentRepository
+	rpCarries       internal.CarriesRepository
+	rpWarehouse     internal.WarehouseRepository
+	rpPurchaseOrder internal.PurchaseOrderRepository
+}
+
+// FindAll returns the shipments, only the ones in the given status when it is not empty
+func (s *ShipmentService) FindAll(status string) ([]internal.Shipment, error) {
+	return s.rpShipment.FindAll(status)
+}
+
+// FindByID returns the shipment with the given ID
+func (s *ShipmentService) FindByID(id int) (internal.Shipment, error) {
+	return s.rpShipment.FindByID(id)
+}
+
+// Save checks the carry, the warehouse and the purchase orders of the shipment and records it with a new tracking code.
+// Only picked purchase orders whose stock is stored in the warehouse can be shipped.
+func (s *ShipmentService) Save(sh *internal.Shipment) error {
+	causes := sh.Validate()
+	if len(causes) > 0 {
+		return internal.DomainError{
+			Message: internal.ErrShipmentBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	_, err := s.rpCarries.FindByID(sh.CarryID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.rpWarehouse.FindByID(sh.WarehouseID)
+	if err != nil {
+		return err
+	}
+
+	causes, err = s.validatePurchaseOrders(sh)
+	if err != nil {
+		return err
+	}
+
+	if len(causes) > 0 {
+		return internal.DomainError{
+			Message: internal.ErrShipmentBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	sh.TrackingCode, err = newTrackingCode()
+	if err != nil {
+		return err
+	}
+
+	sh.Status = internal.ShipmentStatusCreated
+	sh.CreatedAt = time.Now().UTC()
+
+	return s.rpShipment.Save(sh)
+}
+
+// Dispatch hands the shipment over to its carry and marks its purchase orders as shipped
+func (s *ShipmentService) Dispatch(id int) (internal.Shipment, error) {
+	now := time.Now().UTC()
+
+	return s.updateStatus(internal.Shipment{ID: id, Status: internal.ShipmentStatusDispatched, DispatchedAt: &now})
+}
+
+// Deliver marks the shipment and its purchase orders as delivered
+func (s *ShipmentService) Deliver(id int) (internal.Shipment, error) {
+	now := time.Now().UTC()
+
+	return s.updateStatus(internal.Shipment{ID: id, Status: internal.ShipmentStatusDelivered, DeliveredAt: &now})
+}
+
+// updateStatus moves the shipment to its new status and returns it updated
+func (s *ShipmentService) updateStatus(sh internal.Shipment) (internal.Shipment, error) {
+	err := s.rpShipment.UpdateStatus(&sh)
+	if err != nil {
+		return internal.Shipment{}, err
+	}
+
+	return s.rpShipment.FindByID(sh.ID)
+}
+
+// validatePurchaseOrders returns a cause for every purchase order that is not picked, whose reservations
+// were not consumed yet or whose stock is not stored in the warehouse of the shipment
+func (s *ShipmentService) validatePurchaseOrders(sh *internal.Shipment) ([]internal.Causes, error) {
+	var causes []internal.Causes
+
+	for i, id := range sh.PurchaseOrderIDs {
+		field := fmt.Sprintf("purchase_order_ids[%d]", i)
+
+		purchaseOrder, err := s.rpPurchaseOrder.FindByID(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if purchaseOrder.Status != internal.PurchaseOrderStatusPicking {
+			causes = append(causes, internal.Causes{
+				Field:   field,
+				Message: fmt.Sprintf("purchase order %d is %s, only %s orders can be shipped", id, purchaseOrder.Status, internal.PurchaseOrderStatusPicking),
+			})
+
+			continue
+		}
+
+		warehouseIDs, err := s.rpShipment.FindPurchaseOrderWarehouseIDs(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(warehouseIDs) == 0 {
+			causes = append(causes, internal.Causes{
+				Field:   field,
+				Message: fmt.Sprintf("purchase order %d has no reserved stock", id),
+			})
+
+			continue
+		}
+
+		unconsumed, err := s.rpShipment.CountUnconsumedReservations(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if unconsumed > 0 {
+			causes = append(causes, internal.Causes{
+				Field:   field,
+				Message: fmt.Sprintf("purchase order %d has %d reservations not picked yet, fulfil it first", id, unconsumed),
+			})
+
+			continue
+		}
+
+		for _, warehouseID := range warehouseIDs {
+			if warehouseID != sh.WarehouseID {
+				causes = append(causes, internal.Causes{
+					Field:   field,
+					Message: fmt.Sprintf("purchase order %d has stock in warehouse %d, not in warehouse %d", id, warehouseID, sh.WarehouseID),
+				})
+			}
+		}
+	}
+
+	return causes, nil
+}
+
+// newTrackingCode returns a random tracking code for a shipment
+func newTrackingCode() (string, error) {
+	b := make([]byte, 8)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", errors.Join(errors.New("generating tracking code"), err)
+	}
+
+	return shipmentTrackingCodePrefix + strings.ToUpper(hex.EncodeToString(b)), nil
+}
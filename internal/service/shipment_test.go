@@ -0,0 +1,193 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewShipmentRepositoryMock() *ShipmentRepositoryMock {
+	return &ShipmentRepositoryMock{}
+}
+
+type ShipmentRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *ShipmentRepositoryMock) FindAll(status string) ([]internal.Shipment, error) {
+	args := r.Called(status)
+	return args.Get(0).([]internal.Shipment), args.Error(1)
+}
+
+func (r *ShipmentRepositoryMock) FindByID(id int) (internal.Shipment, error) {
+	args := r.Called(id)
+	return args.Get(0).(internal.Shipment), args.Error(1)
+}
+
+func (r *ShipmentRepositoryMock) FindPurchaseOrderWarehouseIDs(purchaseOrderID int) ([]int, error) {
+	args := r.Called(purchaseOrderID)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (r *ShipmentRepositoryMock) CountUnconsumedReservations(purchaseOrderID int) (int, error) {
+	args := r.Called(purchaseOrderID)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *ShipmentRepositoryMock) Save(s *internal.Shipment) error {
+	args := r.Called(s)
+	return args.Error(0)
+}
+
+func (r *ShipmentRepositoryMock) UpdateStatus(s *internal.Shipment) error {
+	args := r.Called(s)
+	return args.Error(0)
+}
+
+type shipmentServiceMocks struct {
+	rpShipment      *ShipmentRepositoryMock
+	rpCarries       *CarriesRepositoryMock
+	rpWarehouse     *WarehouseRepositoryMock
+	rpPurchaseOrder *PurchaseOrderRepositoryMock
+}
+
+func newShipmentService() (*service.ShipmentService, shipmentServiceMocks) {
+	mk := shipmentServiceMocks{
+		rpShipment:      NewShipmentRepositoryMock(),
+		rpCarries:       NewCarriesRepositoryMock(),
+		rpWarehouse:     NewWarehouseRepositoryMock(),
+		rpPurchaseOrder: NewPurchaseOrderRepositoryMock(),
+	}
+
+	sv := service.NewShipmentService(mk.rpShipment, mk.rpCarries, mk.rpWarehouse, mk.rpPurchaseOrder)
+
+	return sv, mk
+}
+
+func TestShipmentService_Save(t *testing.T) {
+	t.Run("case 1: success - Picked orders are shipped with a new tracking code", func(t *testing.T) {
+		sv, mk := newShipmentService()
+
+		sh := internal.Shipment{CarryID: 1, WarehouseID: 2, PurchaseOrderIDs: []int{3}}
+
+		mk.rpCarries.On("FindByID", 1).Return(internal.Carries{ID: 1}, nil)
+		mk.rpWarehouse.On("FindByID", 2).Return(internal.Warehouse{ID: 2}, nil)
+		mk.rpPurchaseOrder.On("FindByID", 3).Return(internal.PurchaseOrder{ID: 3, Status: internal.PurchaseOrderStatusPicking}, nil)
+		mk.rpShipment.On("FindPurchaseOrderWarehouseIDs", 3).Return([]int{2}, nil)
+		mk.rpShipment.On("CountUnconsumedReservations", 3).Return(0, nil)
+		mk.rpShipment.On("Save", &sh).Return(nil)
+
+		err := sv.Save(&sh)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.ShipmentStatusCreated, sh.Status)
+		require.Regexp(t, "^SHP[0-9A-F]{16}$", sh.TrackingCode)
+		require.False(t, sh.CreatedAt.IsZero())
+	})
+
+	t.Run("case 2: error - Carry not found", func(t *testing.T) {
+		sv, mk := newShipmentService()
+
+		sh := internal.Shipment{CarryID: 9, WarehouseID: 2, PurchaseOrderIDs: []int{3}}
+
+		mk.rpCarries.On("FindByID", 9).Return(internal.Carries{}, internal.ErrCarryNotFound)
+
+		err := sv.Save(&sh)
+
+		require.ErrorIs(t, err, internal.ErrCarryNotFound)
+		mk.rpShipment.AssertNumberOfCalls(t, "Save", 0)
+	})
+
+	t.Run("case 3: error - Orders not picked or stored in another warehouse", func(t *testing.T) {
+		sv, mk := newShipmentService()
+
+		sh := internal.Shipment{CarryID: 1, WarehouseID: 2, PurchaseOrderIDs: []int{3, 4, 5}}
+
+		mk.rpCarries.On("FindByID", 1).Return(internal.Carries{ID: 1}, nil)
+		mk.rpWarehouse.On("FindByID", 2).Return(internal.Warehouse{ID: 2}, nil)
+		mk.rpPurchaseOrder.On("FindByID", 3).Return(internal.PurchaseOrder{ID: 3, Status: internal.PurchaseOrderStatusConfirmed}, nil)
+		mk.rpPurchaseOrder.On("FindByID", 4).Return(internal.PurchaseOrder{ID: 4, Status: internal.PurchaseOrderStatusPicking}, nil)
+		mk.rpPurchaseOrder.On("FindByID", 5).Return(internal.PurchaseOrder{ID: 5, Status: internal.PurchaseOrderStatusPicking}, nil)
+		mk.rpShipment.On("FindPurchaseOrderWarehouseIDs", 4).Return([]int{1, 2}, nil)
+		mk.rpShipment.On("CountUnconsumedReservations", 4).Return(0, nil)
+		mk.rpShipment.On("FindPurchaseOrderWarehouseIDs", 5).Return([]int(nil), nil)
+
+		err := sv.Save(&sh)
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, err, &domainError)
+		require.Equal(t, []internal.Causes{
+			{Field: "purchase_order_ids[0]", Message: "purchase order 3 is confirmed, only picking orders can be shipped"},
+			{Field: "purchase_order_ids[1]", Message: "purchase order 4 has stock in warehouse 1, not in warehouse 2"},
+			{Field: "purchase_order_ids[2]", Message: "purchase order 5 has no reserved stock"},
+		}, domainError.Causes)
+		mk.rpShipment.AssertNumberOfCalls(t, "Save", 0)
+	})
+
+	t.Run("case 4: error - Orders reserved but not fulfilled", func(t *testing.T) {
+		sv, mk := newShipmentService()
+
+		sh := internal.Shipment{CarryID: 1, WarehouseID: 2, PurchaseOrderIDs: []int{3}}
+
+		mk.rpCarries.On("FindByID", 1).Return(internal.Carries{ID: 1}, nil)
+		mk.rpWarehouse.On("FindByID", 2).Return(internal.Warehouse{ID: 2}, nil)
+		mk.rpPurchaseOrder.On("FindByID", 3).Return(internal.PurchaseOrder{ID: 3, Status: internal.PurchaseOrderStatusPicking}, nil)
+		mk.rpShipment.On("FindPurchaseOrderWarehouseIDs", 3).Return([]int{2}, nil)
+		mk.rpShipment.On("CountUnconsumedReservations", 3).Return(2, nil)
+
+		err := sv.Save(&sh)
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, err, &domainError)
+		require.Equal(t, []internal.Causes{
+			{Field: "purchase_order_ids[0]", Message: "purchase order 3 has 2 reservations not picked yet, fulfil it first"},
+		}, domainError.Causes)
+		mk.rpShipment.AssertNumberOfCalls(t, "Save", 0)
+	})
+}
+
+func TestShipmentService_Dispatch(t *testing.T) {
+	t.Run("case 1: success - Shipment dispatched", func(t *testing.T) {
+		sv, mk := newShipmentService()
+
+		mk.rpShipment.On("UpdateStatus", mock.MatchedBy(func(sh *internal.Shipment) bool {
+			return sh.ID == 1 && sh.Status == internal.ShipmentStatusDispatched && sh.DispatchedAt != nil && sh.DeliveredAt == nil
+		})).Return(nil)
+		mk.rpShipment.On("FindByID", 1).Return(internal.Shipment{ID: 1, Status: internal.ShipmentStatusDispatched}, nil)
+
+		sh, err := sv.Dispatch(1)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.ShipmentStatusDispatched, sh.Status)
+	})
+
+	t.Run("case 2: error - Shipment already dispatched", func(t *testing.T) {
+		sv, mk := newShipmentService()
+
+		mk.rpShipment.On("UpdateStatus", mock.Anything).Return(internal.ErrShipmentInvalidTransition)
+
+		_, err := sv.Dispatch(1)
+
+		require.ErrorIs(t, err, internal.ErrShipmentInvalidTransition)
+		mk.rpShipment.AssertNumberOfCalls(t, "FindByID", 0)
+	})
+}
+
+func TestShipmentService_Deliver(t *testing.T) {
+	t.Run("case 1: success - Shipment delivered", func(t *testing.T) {
+		sv, mk := newShipmentService()
+
+		mk.rpShipment.On("UpdateStatus", mock.MatchedBy(func(sh *internal.Shipment) bool {
+			return sh.ID == 1 && sh.Status == internal.ShipmentStatusDelivered && sh.DeliveredAt != nil
+		})).Return(nil)
+		mk.rpShipment.On("FindByID", 1).Return(internal.Shipment{ID: 1, Status: internal.ShipmentStatusDelivered}, nil)
+
+		sh, err := sv.Deliver(1)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.ShipmentStatusDelivered, sh.Status)
+	})
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+// NewStockMovementService creates a new instance of the stock movement service
+func NewStockMovementService(rpStockMovement internal.StockMovementRepository, rpProductBatch internal.ProductBatchRepository) *StockMovementService {
+	return &StockMovementService{
+		rpStockMovement: rpStockMovement,
+		rpProductBatch:  rpProductBatch,
+	}
+}
+
+// StockMovementService is the implementation of the stock movement service
+type StockMovementService struct {
+	rpStockMovement internal.StockMovementRepository
+	rpProductBatch  internal.ProductBatchRepository
+}
+
+// FindByProductBatchID returns the movements of a product batch
+func (s *StockMovementService) FindByProductBatchID(productBatchID int) ([]internal.StockMovement, error) {
+	// Check if the product batch exists
+	_, err := s.rpProductBatch.FindByID(productBatchID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.rpStockMovement.FindByProductBatchID(productBatchID)
+}
+
+// Save applies a new movement to a product batch
+func (s *StockMovementService) Save(m *internal.StockMovement) error {
+	// Validate the stock movement entity
+	causes := m.Validate()
+
+	if len(causes) > 0 {
+		return internal.DomainError{
+			Message: internal.ErrStockMovementBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	// Check if the product batch exists
+	_, err := s.rpProductBatch.FindByID(m.ProductBatchID)
+	if err != nil {
+		return err
+	}
+
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+
+	// Save the movement, the repository updates the batch quantity
+	return s.rpStockMovement.Save(m)
+}
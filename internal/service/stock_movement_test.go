@@ -0,0 +1,120 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewStockMovementRepositoryMock() *StockMovementRepositoryMock {
+	return &StockMovementRepositoryMock{}
+}
+
+type StockMovementRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *StockMovementRepositoryMock) FindByProductBatchID(productBatchID int) ([]internal.StockMovement, error) {
+	args := r.Called(productBatchID)
+	return args.Get(0).([]internal.StockMovement), args.Error(1)
+}
+
+func (r *StockMovementRepositoryMock) Save(m *internal.StockMovement) error {
+	args := r.Called(m)
+	return args.Error(0)
+}
+
+func TestStockMovementService_Save(t *testing.T) {
+	t.Run("case 1: success - Should apply a movement to the batch", func(t *testing.T) {
+		rpSm := NewStockMovementRepositoryMock()
+		rpPb := NewProductBatchRepositoryMock()
+		sv := service.NewStockMovementService(rpSm, rpPb)
+
+		m := internal.StockMovement{ProductBatchID: 1, Type: internal.StockMovementPick, Quantity: 10}
+
+		rpPb.On("FindByID", 1).Return(internal.ProductBatch{ID: 1}, nil)
+		rpSm.On("Save", &m).Return(nil)
+
+		err := sv.Save(&m)
+
+		require.NoError(t, err)
+		require.False(t, m.CreatedAt.IsZero())
+		rpSm.AssertNumberOfCalls(t, "Save", 1)
+	})
+
+	t.Run("case 2: error - Should return a domain error for an invalid movement", func(t *testing.T) {
+		rpSm := NewStockMovementRepositoryMock()
+		rpPb := NewProductBatchRepositoryMock()
+		sv := service.NewStockMovementService(rpSm, rpPb)
+
+		m := internal.StockMovement{ProductBatchID: 1, Type: "transfer", Quantity: 10}
+
+		err := sv.Save(&m)
+
+		require.ErrorAs(t, err, &internal.DomainError{})
+		rpSm.AssertNumberOfCalls(t, "Save", 0)
+	})
+
+	t.Run("case 3: error - Should return an error when the batch does not exist", func(t *testing.T) {
+		rpSm := NewStockMovementRepositoryMock()
+		rpPb := NewProductBatchRepositoryMock()
+		sv := service.NewStockMovementService(rpSm, rpPb)
+
+		m := internal.StockMovement{ProductBatchID: 1, Type: internal.StockMovementAdjustment, Quantity: -3}
+
+		rpPb.On("FindByID", 1).Return(internal.ProductBatch{}, internal.ErrProductBatchNotFound)
+
+		err := sv.Save(&m)
+
+		require.ErrorIs(t, err, internal.ErrProductBatchNotFound)
+		rpSm.AssertNumberOfCalls(t, "Save", 0)
+	})
+
+	t.Run("case 4: error - Should return an error when the stock is insufficient", func(t *testing.T) {
+		rpSm := NewStockMovementRepositoryMock()
+		rpPb := NewProductBatchRepositoryMock()
+		sv := service.NewStockMovementService(rpSm, rpPb)
+
+		m := internal.StockMovement{ProductBatchID: 1, Type: internal.StockMovementWriteOff, Quantity: 1000}
+
+		rpPb.On("FindByID", 1).Return(internal.ProductBatch{ID: 1}, nil)
+		rpSm.On("Save", &m).Return(internal.ErrStockMovementInsufficientStock)
+
+		err := sv.Save(&m)
+
+		require.ErrorIs(t, err, internal.ErrStockMovementInsufficientStock)
+	})
+}
+
+func TestStockMovementService_FindByProductBatchID(t *testing.T) {
+	t.Run("case 1: success - Should return the movements of the batch", func(t *testing.T) {
+		rpSm := NewStockMovementRepositoryMock()
+		rpPb := NewProductBatchRepositoryMock()
+		sv := service.NewStockMovementService(rpSm, rpPb)
+
+		expected := []internal.StockMovement{{ID: 1, ProductBatchID: 1, Type: internal.StockMovementReceipt, Quantity: 5}}
+
+		rpPb.On("FindByID", 1).Return(internal.ProductBatch{ID: 1}, nil)
+		rpSm.On("FindByProductBatchID", 1).Return(expected, nil)
+
+		movements, err := sv.FindByProductBatchID(1)
+
+		require.NoError(t, err)
+		require.Equal(t, expected, movements)
+	})
+
+	t.Run("case 2: error - Should return an error when the batch does not exist", func(t *testing.T) {
+		rpSm := NewStockMovementRepositoryMock()
+		rpPb := NewProductBatchRepositoryMock()
+		sv := service.NewStockMovementService(rpSm, rpPb)
+
+		rpPb.On("FindByID", 1).Return(internal.ProductBatch{}, internal.ErrProductBatchNotFound)
+
+		_, err := sv.FindByProductBatchID(1)
+
+		require.ErrorIs(t, err, internal.ErrProductBatchNotFound)
+	})
+}
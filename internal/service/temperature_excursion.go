@@ -0,0 +1,223 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+// NewTemperatureExcursionService creates a new instance of the temperature excursion service
+func NewTemperatureExcursionService(rpTemperatureExcursion internal.TemperatureExcursionRepository, rpProductBatch internal.ProductBatchRepository) *TemperatureExcursionService {
+	return &TemperatureExcursionService{
+		rpTemperatureExcursion: rpTemperatureExcursion,
+		rpProductBatch:         rpProductBatch,
+	}
+}
+
+// TemperatureExcursionService is the implementation of the temperature excursion service
+type TemperatureExcursionService struct {
+	rpTemperatureExcursion internal.TemperatureExcursionRepository
+	rpProductBatch         internal.ProductBatchRepository
+}
+
+// FindAll returns the excursions that match the filter
+func (s *TemperatureExcursionService) FindAll(filter internal.TemperatureExcursionFilter) ([]internal.TemperatureExcursion, error) {
+	return s.rpTemperatureExcursion.FindAll(filter)
+}
+
+// FindByID returns the excursion with the given id
+func (s *TemperatureExcursionService) FindByID(id int) (internal.TemperatureExcursion, error) {
+	return s.rpTemperatureExcursion.FindByID(id)
+}
+
+// FindProductBatches returns the batches with stock left in the section of the excursion
+func (s *TemperatureExcursionService) FindProductBatches(id int) ([]internal.ProductBatch, error) {
+	excursion, err := s.rpTemperatureExcursion.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	prodBatches, err := s.rpProductBatch.FindBySectionID(excursion.SectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range prodBatches {
+		prodBatches[i].SetExpiryStatus(now)
+	}
+
+	return prodBatches, nil
+}
+
+// Evaluate checks the readings of every section against its limits.
+// A reading outside the limits opens an excursion or raises the peak of the active one,
+// a reading back inside the limits ends the active excursion, which stays open until QA closes it.
+// Sections whose limits do not form a range are reported with ErrTemperatureLimitsInvalid.
+func (s *TemperatureExcursionService) Evaluate(readings []internal.TemperatureReading) error {
+	var sectionIDs []int
+	bySection := make(map[int][]internal.TemperatureReading)
+
+	for _, reading := range readings {
+		if _, ok := bySection[reading.SectionID]; !ok {
+			sectionIDs = append(sectionIDs, reading.SectionID)
+		}
+		bySection[reading.SectionID] = append(bySection[reading.SectionID], reading)
+	}
+
+	var invalidLimits []error
+
+	for _, sectionID := range sectionIDs {
+		err := s.evaluateSection(sectionID, bySection[sectionID])
+		if errors.Is(err, internal.ErrTemperatureLimitsInvalid) {
+			// the other sections are still evaluated, the misconfigured ones are reported together
+			invalidLimits = append(invalidLimits, fmt.Errorf("%w: section %d", err, sectionID))
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return errors.Join(invalidLimits...)
+}
+
+// evaluateSection applies the readings of a single section in the order they were recorded
+func (s *TemperatureExcursionService) evaluateSection(sectionID int, readings []internal.TemperatureReading) error {
+	limits, err := s.rpTemperatureExcursion.FindLimitsBySectionID(sectionID)
+	if err != nil {
+		return err
+	}
+
+	var active *internal.TemperatureExcursion
+
+	excursion, err := s.rpTemperatureExcursion.FindActiveBySectionID(sectionID)
+	switch {
+	case err == nil:
+		active = &excursion
+	case !errors.Is(err, internal.ErrTemperatureExcursionNotFound):
+		return err
+	}
+
+	sort.SliceStable(readings, func(i, j int) bool {
+		return readings[i].RecordedAt.Before(readings[j].RecordedAt)
+	})
+
+	changed := false
+
+	for _, reading := range readings {
+		// readings sent late cannot rewrite an incident that started after them
+		if active != nil && reading.RecordedAt.Before(active.StartedAt) {
+			continue
+		}
+
+		deviation, limit := limits.Deviation(reading.Celsius)
+
+		if deviation > 0 {
+			if active == nil {
+				active = &internal.TemperatureExcursion{
+					SectionID: sectionID,
+					Status:    internal.TemperatureExcursionStatusOpen,
+					StartedAt: reading.RecordedAt,
+				}
+			}
+
+			if deviation > active.PeakDeviation {
+				active.PeakCelsius = reading.Celsius
+				active.PeakDeviation = deviation
+				active.LimitCelsius = limit
+				changed = true
+			}
+
+			continue
+		}
+
+		if active != nil {
+			endedAt := reading.RecordedAt
+			active.EndedAt = &endedAt
+
+			if err := s.saveExcursion(active); err != nil {
+				return err
+			}
+
+			active = nil
+			changed = false
+		}
+	}
+
+	if active != nil && changed {
+		return s.saveExcursion(active)
+	}
+
+	return nil
+}
+
+// saveExcursion stores a new excursion or updates an existing one
+func (s *TemperatureExcursionService) saveExcursion(excursion *internal.TemperatureExcursion) error {
+	if excursion.ID == 0 {
+		return s.rpTemperatureExcursion.Save(excursion)
+	}
+
+	return s.rpTemperatureExcursion.Update(excursion)
+}
+
+// UpdateStatus acknowledges or closes the excursion on behalf of changedBy
+func (s *TemperatureExcursionService) UpdateStatus(id int, status string, changedBy string) (internal.TemperatureExcursion, error) {
+	var causes []internal.Causes
+
+	if status != internal.TemperatureExcursionStatusAcknowledged && status != internal.TemperatureExcursionStatusClosed {
+		causes = append(causes, internal.Causes{
+			Field:   "status",
+			Message: "status must be acknowledged or closed",
+		})
+	}
+
+	if len(changedBy) == 0 || len(changedBy) > 255 {
+		causes = append(causes, internal.Causes{
+			Field:   "changed_by",
+			Message: "changed by is required and must have at most 255 characters",
+		})
+	}
+
+	if len(causes) > 0 {
+		return internal.TemperatureExcursion{}, internal.DomainError{
+			Message: internal.ErrTemperatureExcursionBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	excursion, err := s.rpTemperatureExcursion.FindByID(id)
+	if err != nil {
+		return internal.TemperatureExcursion{}, err
+	}
+
+	if !excursion.CanTransitionTo(status) {
+		return internal.TemperatureExcursion{}, internal.ErrTemperatureExcursionInvalidTransition
+	}
+
+	now := time.Now().UTC()
+
+	switch status {
+	case internal.TemperatureExcursionStatusAcknowledged:
+		excursion.AcknowledgedBy = changedBy
+		excursion.AcknowledgedAt = &now
+	case internal.TemperatureExcursionStatusClosed:
+		excursion.ClosedBy = changedBy
+		if excursion.EndedAt == nil {
+			excursion.EndedAt = &now
+		}
+	}
+
+	excursion.Status = status
+
+	err = s.rpTemperatureExcursion.Update(&excursion)
+	if err != nil {
+		return internal.TemperatureExcursion{}, err
+	}
+
+	return excursion, nil
+}
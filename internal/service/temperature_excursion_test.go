@@ -0,0 +1,259 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewTemperatureExcursionRepositoryMock() *TemperatureExcursionRepositoryMock {
+	return &TemperatureExcursionRepositoryMock{}
+}
+
+type TemperatureExcursionRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *TemperatureExcursionRepositoryMock) FindAll(filter internal.TemperatureExcursionFilter) ([]internal.TemperatureExcursion, error) {
+	args := r.Called(filter)
+	return args.Get(0).([]internal.TemperatureExcursion), args.Error(1)
+}
+
+func (r *TemperatureExcursionRepositoryMock) FindByID(id int) (internal.TemperatureExcursion, error) {
+	args := r.Called(id)
+	return args.Get(0).(internal.TemperatureExcursion), args.Error(1)
+}
+
+func (r *TemperatureExcursionRepositoryMock) FindActiveBySectionID(sectionID int) (internal.TemperatureExcursion, error) {
+	args := r.Called(sectionID)
+	return args.Get(0).(internal.TemperatureExcursion), args.Error(1)
+}
+
+func (r *TemperatureExcursionRepositoryMock) FindLimitsBySectionID(sectionID int) (internal.TemperatureLimits, error) {
+	args := r.Called(sectionID)
+	return args.Get(0).(internal.TemperatureLimits), args.Error(1)
+}
+
+func (r *TemperatureExcursionRepositoryMock) Save(excursion *internal.TemperatureExcursion) error {
+	args := r.Called(excursion)
+	return args.Error(0)
+}
+
+func (r *TemperatureExcursionRepositoryMock) Update(excursion *internal.TemperatureExcursion) error {
+	args := r.Called(excursion)
+	return args.Error(0)
+}
+
+func TestTemperatureExcursionService_Evaluate(t *testing.T) {
+	recordedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	limits := internal.TemperatureLimits{Minimum: -25, Maximum: -15, HasMaximum: true}
+
+	t.Run("case 1: success - Should open an excursion at the first reading out of the limits and keep the peak", func(t *testing.T) {
+		rpEx := NewTemperatureExcursionRepositoryMock()
+		sv := service.NewTemperatureExcursionService(rpEx, NewProductBatchRepositoryMock())
+
+		readings := []internal.TemperatureReading{
+			{SectionID: 1, RecordedAt: recordedAt.Add(2 * time.Minute), Celsius: -12},
+			{SectionID: 1, RecordedAt: recordedAt, Celsius: -18},
+			{SectionID: 1, RecordedAt: recordedAt.Add(time.Minute), Celsius: -10},
+		}
+
+		rpEx.On("FindLimitsBySectionID", 1).Return(limits, nil)
+		rpEx.On("FindActiveBySectionID", 1).Return(internal.TemperatureExcursion{}, internal.ErrTemperatureExcursionNotFound)
+		rpEx.On("Save", mock.AnythingOfType("*internal.TemperatureExcursion")).Return(nil)
+
+		err := sv.Evaluate(readings)
+
+		require.NoError(t, err)
+		rpEx.AssertNumberOfCalls(t, "Save", 1)
+		saved := rpEx.Calls[2].Arguments.Get(0).(*internal.TemperatureExcursion)
+		require.Equal(t, internal.TemperatureExcursion{
+			SectionID:     1,
+			Status:        internal.TemperatureExcursionStatusOpen,
+			StartedAt:     recordedAt.Add(time.Minute),
+			PeakCelsius:   -10,
+			PeakDeviation: 5,
+			LimitCelsius:  -15,
+		}, *saved)
+	})
+
+	t.Run("case 2: success - Should end the active excursion when the section is back inside the limits and leave it to QA", func(t *testing.T) {
+		rpEx := NewTemperatureExcursionRepositoryMock()
+		sv := service.NewTemperatureExcursionService(rpEx, NewProductBatchRepositoryMock())
+
+		active := internal.TemperatureExcursion{
+			ID:            7,
+			SectionID:     1,
+			Status:        internal.TemperatureExcursionStatusAcknowledged,
+			StartedAt:     recordedAt.Add(-time.Hour),
+			PeakCelsius:   -10,
+			PeakDeviation: 5,
+			LimitCelsius:  -15,
+		}
+
+		rpEx.On("FindLimitsBySectionID", 1).Return(limits, nil)
+		rpEx.On("FindActiveBySectionID", 1).Return(active, nil)
+		rpEx.On("Update", mock.AnythingOfType("*internal.TemperatureExcursion")).Return(nil)
+
+		err := sv.Evaluate([]internal.TemperatureReading{{SectionID: 1, RecordedAt: recordedAt, Celsius: -18}})
+
+		require.NoError(t, err)
+		rpEx.AssertNumberOfCalls(t, "Update", 1)
+		updated := rpEx.Calls[2].Arguments.Get(0).(*internal.TemperatureExcursion)
+		require.Equal(t, internal.TemperatureExcursionStatusAcknowledged, updated.Status)
+		require.Equal(t, recordedAt, *updated.EndedAt)
+	})
+
+	t.Run("case 3: success - Should not touch the active excursion when the peak is not exceeded", func(t *testing.T) {
+		rpEx := NewTemperatureExcursionRepositoryMock()
+		sv := service.NewTemperatureExcursionService(rpEx, NewProductBatchRepositoryMock())
+
+		active := internal.TemperatureExcursion{ID: 7, SectionID: 1, Status: internal.TemperatureExcursionStatusOpen, StartedAt: recordedAt.Add(-time.Hour), PeakCelsius: -10, PeakDeviation: 5, LimitCelsius: -15}
+
+		rpEx.On("FindLimitsBySectionID", 1).Return(limits, nil)
+		rpEx.On("FindActiveBySectionID", 1).Return(active, nil)
+
+		err := sv.Evaluate([]internal.TemperatureReading{{SectionID: 1, RecordedAt: recordedAt, Celsius: -12}})
+
+		require.NoError(t, err)
+		rpEx.AssertNumberOfCalls(t, "Update", 0)
+		rpEx.AssertNumberOfCalls(t, "Save", 0)
+	})
+
+	t.Run("case 4: error - Should return an error when the limits cannot be read", func(t *testing.T) {
+		rpEx := NewTemperatureExcursionRepositoryMock()
+		sv := service.NewTemperatureExcursionService(rpEx, NewProductBatchRepositoryMock())
+
+		rpEx.On("FindLimitsBySectionID", 1).Return(internal.TemperatureLimits{}, internal.ErrSectionNotFound)
+
+		err := sv.Evaluate([]internal.TemperatureReading{{SectionID: 1, RecordedAt: recordedAt, Celsius: -12}})
+
+		require.ErrorIs(t, err, internal.ErrSectionNotFound)
+	})
+
+	t.Run("case 5: error - Should report a section whose limits do not form a range and evaluate the others", func(t *testing.T) {
+		rpEx := NewTemperatureExcursionRepositoryMock()
+		sv := service.NewTemperatureExcursionService(rpEx, NewProductBatchRepositoryMock())
+
+		rpEx.On("FindLimitsBySectionID", 1).Return(internal.TemperatureLimits{}, internal.ErrTemperatureLimitsInvalid)
+		rpEx.On("FindLimitsBySectionID", 2).Return(internal.TemperatureLimits{Minimum: -20, Maximum: -15, HasMaximum: true}, nil)
+		rpEx.On("FindActiveBySectionID", 2).Return(internal.TemperatureExcursion{}, internal.ErrTemperatureExcursionNotFound)
+		rpEx.On("Save", mock.AnythingOfType("*internal.TemperatureExcursion")).Return(nil)
+
+		err := sv.Evaluate([]internal.TemperatureReading{
+			{SectionID: 1, RecordedAt: recordedAt, Celsius: 0},
+			{SectionID: 2, RecordedAt: recordedAt, Celsius: -10},
+		})
+
+		require.ErrorIs(t, err, internal.ErrTemperatureLimitsInvalid)
+		require.ErrorContains(t, err, "section 1")
+		rpEx.AssertNotCalled(t, "FindActiveBySectionID", 1)
+		rpEx.AssertNumberOfCalls(t, "Save", 1)
+	})
+
+	t.Run("case 6: success - Should keep the readings of a seeded section inside its limits", func(t *testing.T) {
+		rpEx := NewTemperatureExcursionRepositoryMock()
+		sv := service.NewTemperatureExcursionService(rpEx, NewProductBatchRepositoryMock())
+
+		rpEx.On("FindLimitsBySectionID", 1).Return(internal.TemperatureLimits{Minimum: -5, Maximum: -5, HasMaximum: true}, nil)
+		rpEx.On("FindActiveBySectionID", 1).Return(internal.TemperatureExcursion{}, internal.ErrTemperatureExcursionNotFound)
+
+		err := sv.Evaluate([]internal.TemperatureReading{{SectionID: 1, RecordedAt: recordedAt, Celsius: -5}})
+
+		require.NoError(t, err)
+		rpEx.AssertNumberOfCalls(t, "Save", 0)
+	})
+}
+
+func TestTemperatureExcursionService_UpdateStatus(t *testing.T) {
+	startedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Should acknowledge an open excursion", func(t *testing.T) {
+		rpEx := NewTemperatureExcursionRepositoryMock()
+		sv := service.NewTemperatureExcursionService(rpEx, NewProductBatchRepositoryMock())
+
+		rpEx.On("FindByID", 1).Return(internal.TemperatureExcursion{ID: 1, Status: internal.TemperatureExcursionStatusOpen, StartedAt: startedAt}, nil)
+		rpEx.On("Update", mock.AnythingOfType("*internal.TemperatureExcursion")).Return(nil)
+
+		excursion, err := sv.UpdateStatus(1, internal.TemperatureExcursionStatusAcknowledged, "qa")
+
+		require.NoError(t, err)
+		require.Equal(t, internal.TemperatureExcursionStatusAcknowledged, excursion.Status)
+		require.Equal(t, "qa", excursion.AcknowledgedBy)
+		require.NotNil(t, excursion.AcknowledgedAt)
+		require.Nil(t, excursion.EndedAt)
+	})
+
+	t.Run("case 2: success - Should close an excursion setting its end", func(t *testing.T) {
+		rpEx := NewTemperatureExcursionRepositoryMock()
+		sv := service.NewTemperatureExcursionService(rpEx, NewProductBatchRepositoryMock())
+
+		rpEx.On("FindByID", 1).Return(internal.TemperatureExcursion{ID: 1, Status: internal.TemperatureExcursionStatusAcknowledged, StartedAt: startedAt}, nil)
+		rpEx.On("Update", mock.AnythingOfType("*internal.TemperatureExcursion")).Return(nil)
+
+		excursion, err := sv.UpdateStatus(1, internal.TemperatureExcursionStatusClosed, "qa")
+
+		require.NoError(t, err)
+		require.Equal(t, internal.TemperatureExcursionStatusClosed, excursion.Status)
+		require.Equal(t, "qa", excursion.ClosedBy)
+		require.NotNil(t, excursion.EndedAt)
+	})
+
+	t.Run("case 3: error - Should not change a closed excursion", func(t *testing.T) {
+		rpEx := NewTemperatureExcursionRepositoryMock()
+		sv := service.NewTemperatureExcursionService(rpEx, NewProductBatchRepositoryMock())
+
+		rpEx.On("FindByID", 1).Return(internal.TemperatureExcursion{ID: 1, Status: internal.TemperatureExcursionStatusClosed}, nil)
+
+		_, err := sv.UpdateStatus(1, internal.TemperatureExcursionStatusAcknowledged, "qa")
+
+		require.ErrorIs(t, err, internal.ErrTemperatureExcursionInvalidTransition)
+		rpEx.AssertNumberOfCalls(t, "Update", 0)
+	})
+
+	t.Run("case 4: error - Should reject an excursion reopened by hand", func(t *testing.T) {
+		rpEx := NewTemperatureExcursionRepositoryMock()
+		sv := service.NewTemperatureExcursionService(rpEx, NewProductBatchRepositoryMock())
+
+		_, err := sv.UpdateStatus(1, internal.TemperatureExcursionStatusOpen, "")
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, err, &domainError)
+		require.Len(t, domainError.Causes, 2)
+		rpEx.AssertNumberOfCalls(t, "FindByID", 0)
+	})
+}
+
+func TestTemperatureExcursionService_FindProductBatches(t *testing.T) {
+	t.Run("case 1: success - Should return the batches of the section of the excursion", func(t *testing.T) {
+		rpEx := NewTemperatureExcursionRepositoryMock()
+		rpPb := NewProductBatchRepositoryMock()
+		sv := service.NewTemperatureExcursionService(rpEx, rpPb)
+
+		rpEx.On("FindByID", 1).Return(internal.TemperatureExcursion{ID: 1, SectionID: 3}, nil)
+		rpPb.On("FindBySectionID", 3).Return([]internal.ProductBatch{{ID: 10, SectionID: 3, DueDate: "2000-01-01"}}, nil)
+
+		prodBatches, err := sv.FindProductBatches(1)
+
+		require.NoError(t, err)
+		require.Len(t, prodBatches, 1)
+		require.Equal(t, internal.ProductBatchExpiryExpired, prodBatches[0].ExpiryStatus)
+	})
+
+	t.Run("case 2: error - Should return an error when the excursion does not exist", func(t *testing.T) {
+		rpEx := NewTemperatureExcursionRepositoryMock()
+		rpPb := NewProductBatchRepositoryMock()
+		sv := service.NewTemperatureExcursionService(rpEx, rpPb)
+
+		rpEx.On("FindByID", 99).Return(internal.TemperatureExcursion{}, internal.ErrTemperatureExcursionNotFound)
+
+		_, err := sv.FindProductBatches(99)
+
+		require.ErrorIs(t, err, internal.ErrTemperatureExcursionNotFound)
+		rpPb.AssertNumberOfCalls(t, "FindBySectionID", 0)
+	})
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+// NewTemperatureReadingService creates a new instance of the temperature reading service
+func NewTemperatureReadingService(rpTemperatureReading internal.TemperatureReadingRepository, rpSection internal.SectionRepository, svTemperatureExcursion internal.TemperatureExcursionService) *TemperatureReadingService {
+	return &TemperatureReadingService{
+		rpTemperatureReading:   rpTemperatureReading,
+		rpSection:              rpSection,
+		svTemperatureExcursion: svTemperatureExcursion,
+	}
+}
+
+// TemperatureReadingService is the implementation of the temperature reading service
+type TemperatureReadingService struct {
+	rpTemperatureReading   internal.TemperatureReadingRepository
+	rpSection              internal.SectionRepository
+	svTemperatureExcursion internal.TemperatureExcursionService
+}
+
+// FindBySectionID returns the readings of a section in a time range
+func (s *TemperatureReadingService) FindBySectionID(sectionID int, from time.Time, to time.Time) ([]internal.TemperatureReading, error) {
+	// Check if the section exists
+	_, err := s.rpSection.FindByID(sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.rpTemperatureReading.FindBySectionID(sectionID, from, to)
+}
+
+// Ingest stores a batch of readings, the whole batch is rejected when any reading is invalid
+func (s *TemperatureReadingService) Ingest(readings []internal.TemperatureReading) error {
+	// Validate the readings
+	var causes []internal.Causes
+
+	if len(readings) == 0 {
+		causes = append(causes, internal.Causes{
+			Field:   "readings",
+			Message: "at least one reading is required",
+		})
+	}
+
+	if len(readings) > internal.TemperatureReadingMaxBatch {
+		causes = append(causes, internal.Causes{
+			Field:   "readings",
+			Message: fmt.Sprintf("at most %d readings can be sent at once", internal.TemperatureReadingMaxBatch),
+		})
+	}
+
+	for i := range readings {
+		causes = append(causes, readings[i].Validate(i)...)
+	}
+
+	if len(causes) > 0 {
+		return internal.DomainError{
+			Message: internal.ErrTemperatureReadingBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	// Check if the sections exist
+	checked := make(map[int]bool)
+	for _, reading := range readings {
+		if checked[reading.SectionID] {
+			continue
+		}
+
+		_, err := s.rpSection.FindByID(reading.SectionID)
+		if err != nil {
+			return err
+		}
+
+		checked[reading.SectionID] = true
+	}
+
+	// Save the readings, the repository refreshes the current temperatures
+	err := s.rpTemperatureReading.SaveBatch(readings)
+	if err != nil {
+		return err
+	}
+
+	// Open, update or close the excursions of the sections
+	return s.svTemperatureExcursion.Evaluate(readings)
+}
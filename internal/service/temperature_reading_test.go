@@ -0,0 +1,177 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewTemperatureReadingRepositoryMock() *TemperatureReadingRepositoryMock {
+	return &TemperatureReadingRepositoryMock{}
+}
+
+type TemperatureReadingRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *TemperatureReadingRepositoryMock) FindBySectionID(sectionID int, from time.Time, to time.Time) ([]internal.TemperatureReading, error) {
+	args := r.Called(sectionID, from, to)
+	return args.Get(0).([]internal.TemperatureReading), args.Error(1)
+}
+
+func (r *TemperatureReadingRepositoryMock) SaveBatch(readings []internal.TemperatureReading) error {
+	args := r.Called(readings)
+	return args.Error(0)
+}
+
+func NewTemperatureExcursionServiceMock() *TemperatureExcursionServiceMock {
+	return &TemperatureExcursionServiceMock{}
+}
+
+type TemperatureExcursionServiceMock struct {
+	mock.Mock
+}
+
+func (s *TemperatureExcursionServiceMock) FindAll(filter internal.TemperatureExcursionFilter) ([]internal.TemperatureExcursion, error) {
+	args := s.Called(filter)
+	return args.Get(0).([]internal.TemperatureExcursion), args.Error(1)
+}
+
+func (s *TemperatureExcursionServiceMock) FindByID(id int) (internal.TemperatureExcursion, error) {
+	args := s.Called(id)
+	return args.Get(0).(internal.TemperatureExcursion), args.Error(1)
+}
+
+func (s *TemperatureExcursionServiceMock) FindProductBatches(id int) ([]internal.ProductBatch, error) {
+	args := s.Called(id)
+	return args.Get(0).([]internal.ProductBatch), args.Error(1)
+}
+
+func (s *TemperatureExcursionServiceMock) Evaluate(readings []internal.TemperatureReading) error {
+	args := s.Called(readings)
+	return args.Error(0)
+}
+
+func (s *TemperatureExcursionServiceMock) UpdateStatus(id int, status string, changedBy string) (internal.TemperatureExcursion, error) {
+	args := s.Called(id, status, changedBy)
+	return args.Get(0).(internal.TemperatureExcursion), args.Error(1)
+}
+
+func TestTemperatureReadingService_Ingest(t *testing.T) {
+	recordedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("case 1: success - Should store the readings checking every section once", func(t *testing.T) {
+		rpTr := NewTemperatureReadingRepositoryMock()
+		rpSc := NewSectionRepositoryMock()
+		svEx := NewTemperatureExcursionServiceMock()
+		sv := service.NewTemperatureReadingService(rpTr, rpSc, svEx)
+
+		readings := []internal.TemperatureReading{
+			{SectionID: 1, RecordedAt: recordedAt, Celsius: 4.5},
+			{SectionID: 1, RecordedAt: recordedAt.Add(time.Minute), Celsius: 5},
+			{SectionID: 2, RecordedAt: recordedAt, Celsius: -18},
+		}
+
+		rpSc.On("FindByID", 1).Return(internal.Section{ID: 1}, nil)
+		rpSc.On("FindByID", 2).Return(internal.Section{ID: 2}, nil)
+		rpTr.On("SaveBatch", readings).Return(nil)
+		svEx.On("Evaluate", readings).Return(nil)
+
+		err := sv.Ingest(readings)
+
+		require.NoError(t, err)
+		rpSc.AssertNumberOfCalls(t, "FindByID", 2)
+		rpTr.AssertNumberOfCalls(t, "SaveBatch", 1)
+		svEx.AssertNumberOfCalls(t, "Evaluate", 1)
+	})
+
+	t.Run("case 2: error - Should reject the whole batch when a reading is invalid", func(t *testing.T) {
+		rpTr := NewTemperatureReadingRepositoryMock()
+		rpSc := NewSectionRepositoryMock()
+		svEx := NewTemperatureExcursionServiceMock()
+		sv := service.NewTemperatureReadingService(rpTr, rpSc, svEx)
+
+		readings := []internal.TemperatureReading{
+			{SectionID: 1, RecordedAt: recordedAt, Celsius: 4.5},
+			{SectionID: 0, RecordedAt: recordedAt, Celsius: -300},
+		}
+
+		err := sv.Ingest(readings)
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, err, &domainError)
+		require.Equal(t, []internal.Causes{
+			{Field: "[1].section_id", Message: "section ID must be greater than zero"},
+			{Field: "[1].celsius", Message: "celsius cannot be below absolute zero"},
+		}, domainError.Causes)
+		rpTr.AssertNumberOfCalls(t, "SaveBatch", 0)
+	})
+
+	t.Run("case 3: error - Should reject an empty batch", func(t *testing.T) {
+		rpTr := NewTemperatureReadingRepositoryMock()
+		rpSc := NewSectionRepositoryMock()
+		svEx := NewTemperatureExcursionServiceMock()
+		sv := service.NewTemperatureReadingService(rpTr, rpSc, svEx)
+
+		err := sv.Ingest(nil)
+
+		require.ErrorAs(t, err, &internal.DomainError{})
+		rpTr.AssertNumberOfCalls(t, "SaveBatch", 0)
+	})
+
+	t.Run("case 4: error - Should return an error when a section does not exist", func(t *testing.T) {
+		rpTr := NewTemperatureReadingRepositoryMock()
+		rpSc := NewSectionRepositoryMock()
+		svEx := NewTemperatureExcursionServiceMock()
+		sv := service.NewTemperatureReadingService(rpTr, rpSc, svEx)
+
+		readings := []internal.TemperatureReading{{SectionID: 99, RecordedAt: recordedAt, Celsius: 4.5}}
+
+		rpSc.On("FindByID", 99).Return(internal.Section{}, internal.ErrSectionNotFound)
+
+		err := sv.Ingest(readings)
+
+		require.ErrorIs(t, err, internal.ErrSectionNotFound)
+		rpTr.AssertNumberOfCalls(t, "SaveBatch", 0)
+	})
+}
+
+func TestTemperatureReadingService_FindBySectionID(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	t.Run("case 1: success - Should return the readings of the section", func(t *testing.T) {
+		rpTr := NewTemperatureReadingRepositoryMock()
+		rpSc := NewSectionRepositoryMock()
+		svEx := NewTemperatureExcursionServiceMock()
+		sv := service.NewTemperatureReadingService(rpTr, rpSc, svEx)
+
+		expected := []internal.TemperatureReading{{ID: 1, SectionID: 1, RecordedAt: from, Celsius: 4.5}}
+
+		rpSc.On("FindByID", 1).Return(internal.Section{ID: 1}, nil)
+		rpTr.On("FindBySectionID", 1, from, to).Return(expected, nil)
+
+		readings, err := sv.FindBySectionID(1, from, to)
+
+		require.NoError(t, err)
+		require.Equal(t, expected, readings)
+	})
+
+	t.Run("case 2: error - Should return an error when the section does not exist", func(t *testing.T) {
+		rpTr := NewTemperatureReadingRepositoryMock()
+		rpSc := NewSectionRepositoryMock()
+		svEx := NewTemperatureExcursionServiceMock()
+		sv := service.NewTemperatureReadingService(rpTr, rpSc, svEx)
+
+		rpSc.On("FindByID", 99).Return(internal.Section{}, internal.ErrSectionNotFound)
+
+		_, err := sv.FindBySectionID(99, from, to)
+
+		require.ErrorIs(t, err, internal.ErrSectionNotFound)
+		rpTr.AssertNumberOfCalls(t, "FindBySectionID", 0)
+	})
+}
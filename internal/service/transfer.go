@@ -0,0 +1,181 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+)
+
+// NewTransferService creates a new instance of the transfer service
+func NewTransferService(
+	rpTransfer internal.TransferRepository,
+	rpProductBatch internal.ProductBatchRepository,
+	rpSection internal.SectionRepository,
+	rpProduct internal.ProductRepository,
+	rpCompatibility internal.ProductTypeCompatibilityRepository,
+	rpEmployee internal.EmployeeRepository,
+) *TransferService {
+	return &TransferService{
+		rpTransfer:      rpTransfer,
+		rpProductBatch:  rpProductBatch,
+		rpSection:       rpSection,
+		rpProduct:       rpProduct,
+		rpCompatibility: rpCompatibility,
+		rpEmployee:      rpEmployee,
+	}
+}
+
+// TransferService is the implementation of the transfer service
+type TransferService struct {
+	rpTransfer      internal.TransferRepository
+	rpProductBatch  internal.ProductBatchRepository
+	rpSection       internal.SectionRepository
+	rpProduct       internal.ProductRepository
+	rpCompatibility internal.ProductTypeCompatibilityRepository
+	rpEmployee      internal.EmployeeRepository
+}
+
+// FindAll returns the transfers, only the ones in the given status when it is not empty
+func (s *TransferService) FindAll(status string) ([]internal.Transfer, error) {
+	return s.rpTransfer.FindAll(status)
+}
+
+// FindByID returns the transfer with the given ID
+func (s *TransferService) FindByID(id int) (internal.Transfer, error) {
+	return s.rpTransfer.FindByID(id)
+}
+
+// Save checks the transfer against its batch and sections and records it.
+// Transfers inside a warehouse are received right away, the others wait to be dispatched.
+func (s *TransferService) Save(t *internal.Transfer) error {
+	// Validate the transfer
+	causes := t.Validate()
+	if len(causes) > 0 {
+		return internal.DomainError{
+			Message: internal.ErrTransferBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	err := s.checkEmployee(t.EmployeeID)
+	if err != nil {
+		return err
+	}
+
+	prodBatch, err := s.rpProductBatch.FindByID(t.ProductBatchID)
+	if err != nil {
+		return err
+	}
+
+	causes = t.ValidateBatch(prodBatch)
+	if len(causes) > 0 {
+		return internal.DomainError{
+			Message: internal.ErrTransferBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	if prodBatch.Status != internal.ProductBatchStatusReleased {
+		return internal.ErrProductBatchOnHold
+	}
+
+	from, err := s.rpSection.FindByID(prodBatch.SectionID)
+	if err != nil {
+		return err
+	}
+
+	to, err := s.rpSection.FindByID(t.ToSectionID)
+	if err != nil {
+		return internal.ErrSectionNotFound
+	}
+
+	product, err := s.rpProduct.FindByID(prodBatch.ProductID)
+	if err != nil {
+		return err
+	}
+
+	err = checkProductTypeCompatibility(s.rpCompatibility, product, to)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	t.FromSectionID = from.ID
+	t.CreatedAt = now
+	t.Status = internal.TransferStatusPending
+
+	if from.WarehouseID == to.WarehouseID {
+		t.Status = internal.TransferStatusReceived
+		t.DispatchedBy = t.EmployeeID
+		t.ReceivedBy = t.EmployeeID
+		t.DispatchedAt = &now
+		t.ReceivedAt = &now
+	}
+
+	return s.rpTransfer.Save(t)
+}
+
+// Dispatch sends the stock of a pending transfer, the source batch is checked again by the repository
+func (s *TransferService) Dispatch(id int, employeeID int) (internal.Transfer, error) {
+	err := s.checkEmployee(employeeID)
+	if err != nil {
+		return internal.Transfer{}, err
+	}
+
+	now := time.Now().UTC()
+
+	t := internal.Transfer{ID: id, DispatchedBy: employeeID, DispatchedAt: &now}
+
+	err = s.rpTransfer.Dispatch(&t)
+	if err != nil {
+		return internal.Transfer{}, err
+	}
+
+	return s.rpTransfer.FindByID(id)
+}
+
+// Receive stores the stock of a transfer in transit in its destination section
+func (s *TransferService) Receive(id int, employeeID int) (internal.Transfer, error) {
+	err := s.checkEmployee(employeeID)
+	if err != nil {
+		return internal.Transfer{}, err
+	}
+
+	now := time.Now().UTC()
+
+	t := internal.Transfer{ID: id, ReceivedBy: employeeID, ReceivedAt: &now}
+
+	err = s.rpTransfer.Receive(&t)
+	if err != nil {
+		return internal.Transfer{}, err
+	}
+
+	return s.rpTransfer.FindByID(id)
+}
+
+// checkEmployee returns ErrEmployeeNotFound when the employee moving the stock does not exist
+func (s *TransferService) checkEmployee(employeeID int) error {
+	if employeeID <= 0 {
+		return internal.DomainError{
+			Message: internal.ErrTransferBadRequest.Error(),
+			Causes: []internal.Causes{{
+				Field:   "employee_id",
+				Message: "employee ID must be greater than zero",
+			}},
+		}
+	}
+
+	_, err := s.rpEmployee.GetByID(employeeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return internal.ErrEmployeeNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
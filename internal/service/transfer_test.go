@@ -0,0 +1,221 @@
+package service_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func NewTransferRepositoryMock() *TransferRepositoryMock {
+	return &TransferRepositoryMock{}
+}
+
+type TransferRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *TransferRepositoryMock) FindAll(status string) ([]internal.Transfer, error) {
+	args := r.Called(status)
+	return args.Get(0).([]internal.Transfer), args.Error(1)
+}
+
+func (r *TransferRepositoryMock) FindByID(id int) (internal.Transfer, error) {
+	args := r.Called(id)
+	return args.Get(0).(internal.Transfer), args.Error(1)
+}
+
+func (r *TransferRepositoryMock) Save(t *internal.Transfer) error {
+	args := r.Called(t)
+	return args.Error(0)
+}
+
+func (r *TransferRepositoryMock) Dispatch(t *internal.Transfer) error {
+	args := r.Called(t)
+	return args.Error(0)
+}
+
+func (r *TransferRepositoryMock) Receive(t *internal.Transfer) error {
+	args := r.Called(t)
+	return args.Error(0)
+}
+
+type transferServiceMocks struct {
+	rpTransfer      *TransferRepositoryMock
+	rpProductBatch  *ProductBatchRepositoryMock
+	rpSection       *SectionRepositoryMock
+	rpProduct       *RepositoryProductMock
+	rpCompatibility *ProductTypeCompatibilityRepositoryMock
+	rpEmployee      *EmployeeRepositoryMock
+}
+
+func newTransferService() (*service.TransferService, transferServiceMocks) {
+	mk := transferServiceMocks{
+		rpTransfer:      NewTransferRepositoryMock(),
+		rpProductBatch:  NewProductBatchRepositoryMock(),
+		rpSection:       NewSectionRepositoryMock(),
+		rpProduct:       NewRepositoryProductMock(),
+		rpCompatibility: NewProductTypeCompatibilityRepositoryMock(),
+		rpEmployee:      NewEmployeeRepositoryMock(),
+	}
+
+	sv := service.NewTransferService(mk.rpTransfer, mk.rpProductBatch, mk.rpSection, mk.rpProduct, mk.rpCompatibility, mk.rpEmployee)
+
+	return sv, mk
+}
+
+func TestTransferService_Save(t *testing.T) {
+	prodBatch := internal.ProductBatch{ID: 1, CurrentQuantity: 100, ProductID: 4, SectionID: 3, Status: internal.ProductBatchStatusReleased}
+
+	t.Run("case 1: success - Transfer inside a warehouse is received right away", func(t *testing.T) {
+		sv, mk := newTransferService()
+
+		tr := internal.Transfer{ProductBatchID: 1, ToSectionID: 5, Quantity: 40, EmployeeID: 2}
+
+		mk.rpEmployee.On("GetByID", 2).Return(internal.Employee{ID: 2}, nil)
+		mk.rpProductBatch.On("FindByID", 1).Return(prodBatch, nil)
+		mk.rpSection.On("FindByID", 3).Return(internal.Section{ID: 3, WarehouseID: 1, ProductTypeID: 2}, nil)
+		mk.rpSection.On("FindByID", 5).Return(internal.Section{ID: 5, WarehouseID: 1, ProductTypeID: 2}, nil)
+		mk.rpProduct.On("FindByID", 4).Return(internal.Product{ID: 4, ProductTypeID: 2}, nil)
+		mk.rpTransfer.On("Save", &tr).Return(nil)
+
+		err := sv.Save(&tr)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.TransferStatusReceived, tr.Status)
+		require.Equal(t, 3, tr.FromSectionID)
+		require.Equal(t, 2, tr.ReceivedBy)
+		require.NotNil(t, tr.ReceivedAt)
+	})
+
+	t.Run("case 2: success - Transfer between warehouses starts as pending", func(t *testing.T) {
+		sv, mk := newTransferService()
+
+		tr := internal.Transfer{ProductBatchID: 1, ToSectionID: 9, Quantity: 100, EmployeeID: 2}
+
+		mk.rpEmployee.On("GetByID", 2).Return(internal.Employee{ID: 2}, nil)
+		mk.rpProductBatch.On("FindByID", 1).Return(prodBatch, nil)
+		mk.rpSection.On("FindByID", 3).Return(internal.Section{ID: 3, WarehouseID: 1, ProductTypeID: 2}, nil)
+		mk.rpSection.On("FindByID", 9).Return(internal.Section{ID: 9, WarehouseID: 2, ProductTypeID: 2}, nil)
+		mk.rpProduct.On("FindByID", 4).Return(internal.Product{ID: 4, ProductTypeID: 2}, nil)
+		mk.rpTransfer.On("Save", &tr).Return(nil)
+
+		err := sv.Save(&tr)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.TransferStatusPending, tr.Status)
+		require.Nil(t, tr.DispatchedAt)
+		require.Zero(t, tr.ReceivedBy)
+	})
+
+	t.Run("case 3: error - Quantity over the batch stock", func(t *testing.T) {
+		sv, mk := newTransferService()
+
+		tr := internal.Transfer{ProductBatchID: 1, ToSectionID: 5, Quantity: 101, EmployeeID: 2}
+
+		mk.rpEmployee.On("GetByID", 2).Return(internal.Employee{ID: 2}, nil)
+		mk.rpProductBatch.On("FindByID", 1).Return(prodBatch, nil)
+
+		err := sv.Save(&tr)
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, err, &domainError)
+		require.Equal(t, "quantity", domainError.Causes[0].Field)
+		mk.rpTransfer.AssertNumberOfCalls(t, "Save", 0)
+	})
+
+	t.Run("case 4: error - Employee not found", func(t *testing.T) {
+		sv, mk := newTransferService()
+
+		tr := internal.Transfer{ProductBatchID: 1, ToSectionID: 5, Quantity: 10, EmployeeID: 99}
+
+		mk.rpEmployee.On("GetByID", 99).Return(internal.Employee{}, sql.ErrNoRows)
+
+		err := sv.Save(&tr)
+
+		require.ErrorIs(t, err, internal.ErrEmployeeNotFound)
+		mk.rpTransfer.AssertNumberOfCalls(t, "Save", 0)
+	})
+
+	t.Run("case 5: error - Destination section cannot hold the product type", func(t *testing.T) {
+		sv, mk := newTransferService()
+
+		tr := internal.Transfer{ProductBatchID: 1, ToSectionID: 5, Quantity: 10, EmployeeID: 2}
+
+		mk.rpEmployee.On("GetByID", 2).Return(internal.Employee{ID: 2}, nil)
+		mk.rpProductBatch.On("FindByID", 1).Return(prodBatch, nil)
+		mk.rpSection.On("FindByID", 3).Return(internal.Section{ID: 3, WarehouseID: 1, ProductTypeID: 2}, nil)
+		mk.rpSection.On("FindByID", 5).Return(internal.Section{ID: 5, WarehouseID: 1, ProductTypeID: 7}, nil)
+		mk.rpProduct.On("FindByID", 4).Return(internal.Product{ID: 4, ProductTypeID: 2}, nil)
+		mk.rpCompatibility.On("IsCompatible", 2, 7).Return(false, nil)
+
+		err := sv.Save(&tr)
+
+		require.ErrorAs(t, err, &internal.DomainError{})
+		mk.rpTransfer.AssertNumberOfCalls(t, "Save", 0)
+	})
+
+	t.Run("case 6: error - Batch on hold", func(t *testing.T) {
+		sv, mk := newTransferService()
+
+		held := prodBatch
+		held.Status = internal.ProductBatchStatusOnHold
+		tr := internal.Transfer{ProductBatchID: 1, ToSectionID: 5, Quantity: 10, EmployeeID: 2}
+
+		mk.rpEmployee.On("GetByID", 2).Return(internal.Employee{ID: 2}, nil)
+		mk.rpProductBatch.On("FindByID", 1).Return(held, nil)
+
+		err := sv.Save(&tr)
+
+		require.ErrorIs(t, err, internal.ErrProductBatchOnHold)
+	})
+}
+
+func TestTransferService_Dispatch(t *testing.T) {
+	t.Run("case 1: success - Transfer in transit", func(t *testing.T) {
+		sv, mk := newTransferService()
+
+		mk.rpEmployee.On("GetByID", 2).Return(internal.Employee{ID: 2}, nil)
+		mk.rpTransfer.On("Dispatch", mock.MatchedBy(func(tr *internal.Transfer) bool {
+			return tr.ID == 1 && tr.DispatchedBy == 2 && tr.DispatchedAt != nil
+		})).Return(nil)
+		mk.rpTransfer.On("FindByID", 1).Return(internal.Transfer{ID: 1, Status: internal.TransferStatusInTransit, DispatchedBy: 2}, nil)
+
+		tr, err := sv.Dispatch(1, 2)
+
+		require.NoError(t, err)
+		require.Equal(t, internal.TransferStatusInTransit, tr.Status)
+	})
+
+	t.Run("case 2: error - Transfer is not pending", func(t *testing.T) {
+		sv, mk := newTransferService()
+
+		mk.rpEmployee.On("GetByID", 2).Return(internal.Employee{ID: 2}, nil)
+		mk.rpTransfer.On("Dispatch", mock.Anything).Return(internal.ErrTransferInvalidTransition)
+
+		_, err := sv.Dispatch(1, 2)
+
+		require.ErrorIs(t, err, internal.ErrTransferInvalidTransition)
+		mk.rpTransfer.AssertNumberOfCalls(t, "FindByID", 0)
+	})
+}
+
+func TestTransferService_Receive(t *testing.T) {
+	t.Run("case 1: success - Transfer received", func(t *testing.T) {
+		sv, mk := newTransferService()
+
+		mk.rpEmployee.On("GetByID", 3).Return(internal.Employee{ID: 3}, nil)
+		mk.rpTransfer.On("Receive", mock.MatchedBy(func(tr *internal.Transfer) bool {
+			return tr.ID == 1 && tr.ReceivedBy == 3 && tr.ReceivedAt != nil
+		})).Return(nil)
+		mk.rpTransfer.On("FindByID", 1).Return(internal.Transfer{ID: 1, Status: internal.TransferStatusReceived, DestinationProductBatchID: 8}, nil)
+
+		tr, err := sv.Receive(1, 3)
+
+		require.NoError(t, err)
+		require.Equal(t, 8, tr.DestinationProductBatchID)
+	})
+}
@@ -1,13 +1,19 @@
 package service
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/meli-fresh-products-api-backend-t1/utils/validator"
 )
 
 // NewWarehouseDefault creates a new instance of the warehouse service
-func NewWarehouseDefault(rp internal.WarehouseRepository) *WarehouseDefault {
+func NewWarehouseDefault(rp internal.WarehouseRepository, rpLocality internal.LocalityRepository, rpProduct internal.ProductRepository) *WarehouseDefault {
 	return &WarehouseDefault{
-		rp: rp,
+		rp:  rp,
+		rpL: rpLocality,
+		rpP: rpProduct,
 	}
 }
 
@@ -15,6 +21,10 @@ func NewWarehouseDefault(rp internal.WarehouseRepository) *WarehouseDefault {
 type WarehouseDefault struct {
 	// rp is the repository used by the service
 	rp internal.WarehouseRepository
+	// rpL is the repository of the localities warehouses are in and deliver to
+	rpL internal.LocalityRepository
+	// rpP is the repository of the products stored in the warehouses
+	rpP internal.ProductRepository
 }
 
 // Method to check if a warehouse code already exists
@@ -58,6 +68,12 @@ func (s *WarehouseDefault) Save(warehouse *internal.Warehouse) (err error) {
 		}
 	}
 
+	// The warehouse must be in an existing locality
+	_, err = s.rpL.FindByID(warehouse.LocalityID)
+	if err != nil {
+		return
+	}
+
 	// We`re gonna check if there is a warehouse with the same code
 	err = s.checkWarehouseCodeExists(warehouse.WarehouseCode)
 	if err != nil {
@@ -113,6 +129,35 @@ func (s *WarehouseDefault) Update(id int, warehousePatch *internal.WarehousePatc
 		warehouse.MinimumTemperature = *warehousePatch.MinimumTemperature
 	}
 
+	if warehousePatch.LocalityID != nil {
+		if !validator.IntIsPositive(*warehousePatch.LocalityID) {
+			return internal.Warehouse{}, internal.DomainError{
+				Message: internal.ErrWarehouseBadRequest.Error(),
+				Causes: []internal.Causes{{
+					Field:   "locality_id",
+					Message: "locality id must be positive",
+				}},
+			}
+		}
+
+		_, err = s.rpL.FindByID(*warehousePatch.LocalityID)
+		if err != nil {
+			return internal.Warehouse{}, err
+		}
+
+		warehouse.LocalityID = *warehousePatch.LocalityID
+	}
+
+	if warehousePatch.Latitude != nil || warehousePatch.Longitude != nil {
+		causes := patchCoordinates(&warehouse, warehousePatch)
+		if len(causes) > 0 {
+			return internal.Warehouse{}, internal.DomainError{
+				Message: internal.ErrWarehouseBadRequest.Error(),
+				Causes:  causes,
+			}
+		}
+	}
+
 	// Save the updated warehouse
 	err = s.rp.Update(&warehouse)
 
@@ -130,3 +175,75 @@ func (s *WarehouseDefault) Delete(id int) (err error) {
 
 	return
 }
+
+// FindNearest returns the warehouses holding enough released, unexpired and unreserved stock of the product,
+// ordered by great-circle distance to the locality
+func (s *WarehouseDefault) FindNearest(request internal.NearestWarehouseRequest) ([]internal.NearestWarehouse, error) {
+	causes := request.Validate()
+	if len(causes) > 0 {
+		return nil, internal.DomainError{
+			Message: internal.ErrNearestWarehouseBadRequest.Error(),
+			Causes:  causes,
+		}
+	}
+
+	locality, err := s.rpL.FindByID(request.LocalityID)
+	if err != nil {
+		return nil, err
+	}
+
+	if locality.Coordinates == nil {
+		return nil, internal.DomainError{
+			Message: internal.ErrNearestWarehouseBadRequest.Error(),
+			Causes: []internal.Causes{{
+				Field:   "locality_id",
+				Message: fmt.Sprintf("locality %d has no coordinates", request.LocalityID),
+			}},
+		}
+	}
+
+	_, err = s.rpP.FindByID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	warehouses, err := s.rp.FindWithAvailableStock(request.ProductID, request.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range warehouses {
+		warehouses[i].DistanceKm = locality.Coordinates.DistanceTo(*warehouses[i].Warehouse.Coordinates)
+	}
+
+	sort.SliceStable(warehouses, func(i, j int) bool {
+		return warehouses[i].DistanceKm < warehouses[j].DistanceKm
+	})
+
+	return warehouses, nil
+}
+
+// patchCoordinates applies the latitude and longitude of the patch to the warehouse.
+// A warehouse without coordinates needs both of them.
+func patchCoordinates(warehouse *internal.Warehouse, warehousePatch *internal.WarehousePatchUpdate) []internal.Causes {
+	if warehouse.Coordinates == nil {
+		if warehousePatch.Latitude == nil || warehousePatch.Longitude == nil {
+			return []internal.Causes{{
+				Field:   "coordinates",
+				Message: "latitude and longitude are required together",
+			}}
+		}
+
+		warehouse.Coordinates = &internal.Coordinates{}
+	}
+
+	if warehousePatch.Latitude != nil {
+		warehouse.Coordinates.Latitude = *warehousePatch.Latitude
+	}
+
+	if warehousePatch.Longitude != nil {
+		warehouse.Coordinates.Longitude = *warehousePatch.Longitude
+	}
+
+	return warehouse.Coordinates.Validate()
+}
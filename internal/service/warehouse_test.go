@@ -44,21 +44,29 @@ func (r *WarehouseRepositoryMock) Delete(id int) error {
 	return args.Error(0)
 }
 
+func (r *WarehouseRepositoryMock) FindWithAvailableStock(productID int, quantity int) ([]internal.NearestWarehouse, error) {
+	args := r.Called(productID, quantity)
+	return args.Get(0).([]internal.NearestWarehouse), args.Error(1)
+}
+
 func TestWarehouseServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(WarehouseServiceTestSuite))
 }
 
 type WarehouseServiceTestSuite struct {
-	rp *WarehouseRepositoryMock
-	sv *service.WarehouseDefault
+	rp  *WarehouseRepositoryMock
+	rpL *localityRepositoryMock
+	sv  *service.WarehouseDefault
 	suite.Suite
 }
 
 func (s *WarehouseServiceTestSuite) SetupTest() {
 	rp := NewWarehouseRepositoryMock()
-	sv := service.NewWarehouseDefault(rp)
+	rpL := new(localityRepositoryMock)
+	sv := service.NewWarehouseDefault(rp, rpL, nil)
 
 	s.rp = rp
+	s.rpL = rpL
 	s.sv = sv
 }
 
@@ -73,7 +81,9 @@ func (w *WarehouseServiceTestSuite) TestWarehouseService_Save() {
 			Telephone:          "11 12345-6789",
 			MinimumCapacity:    1,
 			MinimumTemperature: 1,
+			LocalityID:         1,
 		}
+		w.rpL.On("FindByID", 1).Return(internal.Locality{ID: 1}, nil)
 		w.rp.On("FindAll").Return([]internal.Warehouse{}, nil)
 		w.rp.On("Save", &warehouse).Return(nil)
 
@@ -94,7 +104,9 @@ func (w *WarehouseServiceTestSuite) TestWarehouseService_Save() {
 			Telephone:          "11 12345-6789",
 			MinimumCapacity:    1,
 			MinimumTemperature: 1,
+			LocalityID:         1,
 		}
+		w.rpL.On("FindByID", 1).Return(internal.Locality{ID: 1}, nil)
 		w.rp.On("FindAll").Return([]internal.Warehouse{warehouse}, nil)
 
 		err := w.sv.Save(&warehouse)
@@ -114,6 +126,7 @@ func (w *WarehouseServiceTestSuite) TestWarehouseService_Save() {
 			Telephone:          "11 12345-6789",
 			MinimumCapacity:    0,
 			MinimumTemperature: 1,
+			LocalityID:         1,
 		}
 
 		err := w.sv.Save(&warehouse)
@@ -133,7 +146,9 @@ func (w *WarehouseServiceTestSuite) TestWarehouseService_Save() {
 			Telephone:          "11 12345-6789",
 			MinimumCapacity:    1,
 			MinimumTemperature: 1,
+			LocalityID:         1,
 		}
+		w.rpL.On("FindByID", 1).Return(internal.Locality{ID: 1}, nil)
 		w.rp.On("FindAll").Return([]internal.Warehouse{}, errors.New("internal server error"))
 
 		err := w.sv.Save(&warehouse)
@@ -145,6 +160,24 @@ func (w *WarehouseServiceTestSuite) TestWarehouseService_Save() {
 
 	})
 
+	w.T().Run("case 5 - error: Should return an error when the locality does not exist", func(t *testing.T) {
+		w.SetupTest()
+		warehouse := internal.Warehouse{
+			WarehouseCode:      "warehouse_code",
+			Address:            "address",
+			Telephone:          "11 12345-6789",
+			MinimumCapacity:    1,
+			MinimumTemperature: 1,
+			LocalityID:         99,
+		}
+		w.rpL.On("FindByID", 99).Return(internal.Locality{}, internal.ErrLocalityNotFound)
+
+		err := w.sv.Save(&warehouse)
+
+		w.rp.AssertNumberOfCalls(w.T(), "FindAll", 0)
+		w.rp.AssertNumberOfCalls(w.T(), "Save", 0)
+		w.ErrorIs(err, internal.ErrLocalityNotFound)
+	})
 }
 
 func (w *WarehouseServiceTestSuite) TestWarehouseService_FindAll() {
@@ -353,6 +386,22 @@ func (w *WarehouseServiceTestSuite) TestWarehouseService_Update() {
 		w.rp.AssertNumberOfCalls(w.T(), "Update", 0)
 		require.Error(w.T(), err)
 	})
+
+	w.T().Run("case 5 - error: Should return an error when moving a warehouse to a non existent locality", func(t *testing.T) {
+		w.SetupTest()
+		localityID := 99
+		warehousePatch := internal.WarehousePatchUpdate{
+			LocalityID: &localityID,
+		}
+
+		w.rp.On("FindByID", 1).Return(internal.Warehouse{ID: 1, LocalityID: 1}, nil)
+		w.rpL.On("FindByID", 99).Return(internal.Locality{}, internal.ErrLocalityNotFound)
+
+		_, err := w.sv.Update(1, &warehousePatch)
+
+		w.rp.AssertNumberOfCalls(w.T(), "Update", 0)
+		w.ErrorIs(err, internal.ErrLocalityNotFound)
+	})
 }
 
 func (w *WarehouseServiceTestSuite) TestWarehouseService_Delete() {
@@ -384,3 +433,68 @@ func (w *WarehouseServiceTestSuite) TestWarehouseService_Delete() {
 		w.Equal(internal.ErrWarehouseRepositoryNotFound, err)
 	})
 }
+
+func TestWarehouseService_FindNearest(t *testing.T) {
+	newYork := &internal.Coordinates{Latitude: 40.7128, Longitude: -74.0060}
+	request := internal.NearestWarehouseRequest{LocalityID: 1, ProductID: 2, Quantity: 10}
+
+	t.Run("case 1 - success: Should return the warehouses ordered by distance", func(t *testing.T) {
+		rp := NewWarehouseRepositoryMock()
+		rpL := new(localityRepositoryMock)
+		rpP := NewRepositoryProductMock()
+		rpL.On("FindByID", 1).Return(internal.Locality{ID: 1, Coordinates: newYork}, nil)
+		rpP.On("FindByID", 2).Return(internal.Product{ID: 2}, nil)
+		rp.On("FindWithAvailableStock", 2, 10).Return([]internal.NearestWarehouse{
+			{Warehouse: internal.Warehouse{ID: 1, Coordinates: &internal.Coordinates{Latitude: 34.0522, Longitude: -118.2437}}, AvailableQuantity: 50},
+			{Warehouse: internal.Warehouse{ID: 2, Coordinates: &internal.Coordinates{Latitude: 39.9526, Longitude: -75.1652}}, AvailableQuantity: 10},
+		}, nil)
+		sv := service.NewWarehouseDefault(rp, rpL, rpP)
+
+		warehouses, err := sv.FindNearest(request)
+
+		require.NoError(t, err)
+		require.Len(t, warehouses, 2)
+		require.Equal(t, 2, warehouses[0].Warehouse.ID)
+		require.Equal(t, 1, warehouses[1].Warehouse.ID)
+		require.InDelta(t, 129.6, warehouses[0].DistanceKm, 1)
+	})
+
+	t.Run("case 2 - error: Should return a domain error for an invalid request", func(t *testing.T) {
+		rp := NewWarehouseRepositoryMock()
+		sv := service.NewWarehouseDefault(rp, nil, nil)
+
+		_, err := sv.FindNearest(internal.NearestWarehouseRequest{LocalityID: 1, ProductID: 2})
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, err, &domainError)
+		require.Equal(t, "qty", domainError.Causes[0].Field)
+		rp.AssertNotCalled(t, "FindWithAvailableStock", mock.Anything, mock.Anything)
+	})
+
+	t.Run("case 3 - error: Should return a domain error for a locality without coordinates", func(t *testing.T) {
+		rp := NewWarehouseRepositoryMock()
+		rpL := new(localityRepositoryMock)
+		rpL.On("FindByID", 1).Return(internal.Locality{ID: 1}, nil)
+		sv := service.NewWarehouseDefault(rp, rpL, nil)
+
+		_, err := sv.FindNearest(request)
+
+		var domainError internal.DomainError
+		require.ErrorAs(t, err, &domainError)
+		require.Equal(t, "locality 1 has no coordinates", domainError.Causes[0].Message)
+	})
+
+	t.Run("case 4 - error: Should return not found for an unknown product", func(t *testing.T) {
+		rp := NewWarehouseRepositoryMock()
+		rpL := new(localityRepositoryMock)
+		rpP := NewRepositoryProductMock()
+		rpL.On("FindByID", 1).Return(internal.Locality{ID: 1, Coordinates: newYork}, nil)
+		rpP.On("FindByID", 2).Return(internal.Product{}, internal.ErrProductNotFound)
+		sv := service.NewWarehouseDefault(rp, rpL, rpP)
+
+		_, err := sv.FindNearest(request)
+
+		require.ErrorIs(t, err, internal.ErrProductNotFound)
+		rp.AssertNotCalled(t, "FindWithAvailableStock", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/utils/validator"
+)
+
+const (
+	// ShipmentStatusCreated is the status of a shipment whose orders are waiting for the carry
+	ShipmentStatusCreated = "created"
+	// ShipmentStatusDispatched is the status of a shipment that left the warehouse, its orders are shipped
+	ShipmentStatusDispatched = "dispatched"
+	// ShipmentStatusDelivered is the status of a shipment whose orders reached their buyers
+	ShipmentStatusDelivered = "delivered"
+)
+
+// shipmentTransitions maps every shipment status to the status it can move to
+var shipmentTransitions = map[string]string{
+	ShipmentStatusCreated:    ShipmentStatusDispatched,
+	ShipmentStatusDispatched: ShipmentStatusDelivered,
+}
+
+// shipmentPurchaseOrderStatuses maps every shipment status to the status its purchase orders move to
+var shipmentPurchaseOrderStatuses = map[string]string{
+	ShipmentStatusCreated:    PurchaseOrderStatusPicking,
+	ShipmentStatusDispatched: PurchaseOrderStatusShipped,
+	ShipmentStatusDelivered:  PurchaseOrderStatusDelivered,
+}
+
+// Shipment is a struct that represents purchase orders of a warehouse handed over to a carry
+type Shipment struct {
+	ID int
+	// TrackingCode is generated when the shipment is created and copied to its purchase orders
+	TrackingCode     string
+	CarryID          int
+	WarehouseID      int
+	Status           string
+	PurchaseOrderIDs []int
+	CreatedAt        time.Time
+	DispatchedAt     *time.Time
+	DeliveredAt      *time.Time
+}
+
+var (
+	// ErrShipmentNotFound is returned when the shipment does not exist
+	ErrShipmentNotFound = errors.New("shipment not found")
+	// ErrShipmentInvalidTransition is returned when the shipment cannot move to the requested status
+	ErrShipmentInvalidTransition = errors.New("shipment status transition is not allowed")
+	// ErrShipmentPurchaseOrderAssigned is returned when a purchase order already belongs to another shipment
+	ErrShipmentPurchaseOrderAssigned = errors.New("purchase order already belongs to a shipment")
+	// ErrShipmentBadRequest is returned when the shipment breaks a business rule
+	ErrShipmentBadRequest = errors.New("shipment inputs are invalid")
+	// ErrShipmentUnprocessableEntity is returned when the shipment inputs are missing
+	ErrShipmentUnprocessableEntity = errors.New("shipment inputs are missing")
+	// ErrShipmentStockNotPicked is returned when a purchase order of the shipment still has reserved stock in the batches
+	ErrShipmentStockNotPicked = errors.New("purchase order stock of the shipment was not picked")
+)
+
+// CanShipmentTransition reports whether a shipment can move between the given statuses
+func CanShipmentTransition(from string, to string) bool {
+	return shipmentTransitions[from] == to
+}
+
+// ShipmentPurchaseOrderStatus returns the status the purchase orders of a shipment have in the given shipment status
+func ShipmentPurchaseOrderStatus(status string) string {
+	return shipmentPurchaseOrderStatuses[status]
+}
+
+// Validate validates the business rules of the shipment
+func (s *Shipment) Validate() (causes []Causes) {
+	if !validator.IntIsPositive(s.CarryID) {
+		causes = append(causes, Causes{
+			Field:   "carry_id",
+			Message: "carry ID must be greater than zero",
+		})
+	}
+
+	if !validator.IntIsPositive(s.WarehouseID) {
+		causes = append(causes, Causes{
+			Field:   "warehouse_id",
+			Message: "warehouse ID must be greater than zero",
+		})
+	}
+
+	if len(s.PurchaseOrderIDs) == 0 {
+		causes = append(causes, Causes{
+			Field:   "purchase_order_ids",
+			Message: "at least one purchase order is required",
+		})
+	}
+
+	seen := make(map[int]bool, len(s.PurchaseOrderIDs))
+
+	for i, id := range s.PurchaseOrderIDs {
+		if !validator.IntIsPositive(id) {
+			causes = append(causes, Causes{
+				Field:   fmt.Sprintf("purchase_order_ids[%d]", i),
+				Message: "purchase order ID must be greater than zero",
+			})
+		}
+
+		if seen[id] {
+			causes = append(causes, Causes{
+				Field:   fmt.Sprintf("purchase_order_ids[%d]", i),
+				Message: fmt.Sprintf("purchase order %d is repeated", id),
+			})
+		}
+
+		seen[id] = true
+	}
+
+	return causes
+}
+
+// ShipmentRepository is an interface that contains the methods that the shipment repository should support
+type ShipmentRepository interface {
+	// FindAll returns the shipments, only the ones in the given status when it is not empty
+	FindAll(status string) ([]Shipment, error)
+	FindByID(id int) (Shipment, error)
+	// FindPurchaseOrderWarehouseIDs returns the warehouses the stock reserved for the purchase order is stored in
+	FindPurchaseOrderWarehouseIDs(purchaseOrderID int) ([]int, error)
+	// CountUnconsumedReservations returns how many reservations of the purchase order were not picked from their batches yet
+	CountUnconsumedReservations(purchaseOrderID int) (int, error)
+	// Save records the shipment, links its purchase orders and gives them its tracking code
+	Save(s *Shipment) error
+	// UpdateStatus moves the shipment to s.Status and its purchase orders along with it.
+	// The timestamp of the new status must be set in s. A shipment whose purchase orders still have
+	// unconsumed reservations cannot be dispatched.
+	UpdateStatus(s *Shipment) error
+}
+
+// ShipmentService is an interface that contains the methods that the shipment service should support
+type ShipmentService interface {
+	// FindAll returns the shipments, only the ones in the given status when it is not empty
+	FindAll(status string) ([]Shipment, error)
+	FindByID(id int) (Shipment, error)
+	// Save assigns the purchase orders to the carry and generates the tracking code
+	Save(s *Shipment) error
+	// Dispatch hands the shipment over to the carry and marks its purchase orders as shipped
+	Dispatch(id int) (Shipment, error)
+	// Deliver marks the shipment and its purchase orders as delivered
+	Deliver(id int) (Shipment, error)
+}
@@ -0,0 +1,53 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanShipmentTransition(t *testing.T) {
+	tests := []struct {
+		from     string
+		to       string
+		expected bool
+	}{
+		{from: internal.ShipmentStatusCreated, to: internal.ShipmentStatusDispatched, expected: true},
+		{from: internal.ShipmentStatusDispatched, to: internal.ShipmentStatusDelivered, expected: true},
+		{from: internal.ShipmentStatusCreated, to: internal.ShipmentStatusDelivered, expected: false},
+		{from: internal.ShipmentStatusDelivered, to: internal.ShipmentStatusDispatched, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			assert.Equal(t, tt.expected, internal.CanShipmentTransition(tt.from, tt.to))
+		})
+	}
+}
+
+func TestShipment_Validate(t *testing.T) {
+	t.Run("valid shipment", func(t *testing.T) {
+		s := internal.Shipment{CarryID: 1, WarehouseID: 2, PurchaseOrderIDs: []int{3, 4}}
+
+		assert.Empty(t, s.Validate())
+	})
+
+	t.Run("missing purchase orders", func(t *testing.T) {
+		s := internal.Shipment{CarryID: 0, WarehouseID: 2}
+
+		assert.Equal(t, []internal.Causes{
+			{Field: "carry_id", Message: "carry ID must be greater than zero"},
+			{Field: "purchase_order_ids", Message: "at least one purchase order is required"},
+		}, s.Validate())
+	})
+
+	t.Run("invalid and repeated purchase orders", func(t *testing.T) {
+		s := internal.Shipment{CarryID: 1, WarehouseID: 2, PurchaseOrderIDs: []int{3, -1, 3}}
+
+		assert.Equal(t, []internal.Causes{
+			{Field: "purchase_order_ids[1]", Message: "purchase order ID must be greater than zero"},
+			{Field: "purchase_order_ids[2]", Message: "purchase order 3 is repeated"},
+		}, s.Validate())
+	})
+}
@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"errors"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/utils/validator"
+)
+
+const (
+	// StockMovementReceipt adds the quantity to the batch
+	StockMovementReceipt = "receipt"
+	// StockMovementPick removes the quantity from the batch
+	StockMovementPick = "pick"
+	// StockMovementAdjustment adds or removes the quantity depending on its sign
+	StockMovementAdjustment = "adjustment"
+	// StockMovementWriteOff removes the quantity from the batch as a loss
+	StockMovementWriteOff = "write_off"
+	// StockMovementTransferOut removes the quantity sent to another section, it is only recorded by transfers
+	StockMovementTransferOut = "transfer_out"
+	// StockMovementTransferIn adds the quantity received from another section, it is only recorded by transfers
+	StockMovementTransferIn = "transfer_in"
+)
+
+// StockMovement is a struct that represents a change of quantity in a product batch
+type StockMovement struct {
+	ID             int
+	ProductBatchID int
+	Type           string
+	Quantity       int
+	Reason         string
+	// QuantityAfter is the current quantity of the batch once the movement is applied
+	QuantityAfter int
+	CreatedAt     time.Time
+}
+
+var (
+	// ErrStockMovementBadRequest is returned when the stock movement request is bad
+	ErrStockMovementBadRequest = errors.New("stock movement inputs are invalid")
+	// ErrStockMovementUnprocessableEntity is returned when the stock movement is unprocessable
+	ErrStockMovementUnprocessableEntity = errors.New("stock movement inputs are missing")
+	// ErrStockMovementInsufficientStock is returned when the movement would make the batch quantity negative
+	ErrStockMovementInsufficientStock = errors.New("stock movement would make the batch quantity negative")
+)
+
+// Delta returns the signed quantity the movement applies to the batch
+func (m *StockMovement) Delta() int {
+	switch m.Type {
+	case StockMovementPick, StockMovementWriteOff, StockMovementTransferOut:
+		return -m.Quantity
+	default:
+		return m.Quantity
+	}
+}
+
+// Validate validates the business rules of the stock movement
+func (m *StockMovement) Validate() (causes []Causes) {
+	if validator.IntIsZero(m.ProductBatchID) || validator.IntIsNegative(m.ProductBatchID) {
+		causes = append(causes, Causes{
+			Field:   "product_batch_id",
+			Message: "product batch ID is required",
+		})
+	}
+
+	switch m.Type {
+	case StockMovementReceipt, StockMovementPick, StockMovementWriteOff:
+		if !validator.IntIsPositive(m.Quantity) {
+			causes = append(causes, Causes{
+				Field:   "quantity",
+				Message: "quantity must be greater than zero",
+			})
+		}
+	case StockMovementAdjustment:
+		if validator.IntIsZero(m.Quantity) {
+			causes = append(causes, Causes{
+				Field:   "quantity",
+				Message: "quantity cannot be zero",
+			})
+		}
+	default:
+		causes = append(causes, Causes{
+			Field:   "type",
+			Message: "type must be one of receipt, pick, adjustment or write_off",
+		})
+	}
+
+	if len(m.Reason) > 255 {
+		causes = append(causes, Causes{
+			Field:   "reason",
+			Message: "reason is out of range",
+		})
+	}
+
+	return causes
+}
+
+// StockMovementRepository is an interface that contains the methods that the stock movement repository should support
+type StockMovementRepository interface {
+	// FindByProductBatchID returns the movements of the given product batch
+	FindByProductBatchID(productBatchID int) ([]StockMovement, error)
+	// Save applies the movement to its product batch and records it
+	Save(m *StockMovement) error
+}
+
+// StockMovementService is an interface that contains the methods that the stock movement service should support
+type StockMovementService interface {
+	// FindByProductBatchID returns the movements of the given product batch
+	FindByProductBatchID(productBatchID int) ([]StockMovement, error)
+	// Save applies the movement to its product batch and records it
+	Save(m *StockMovement) error
+}
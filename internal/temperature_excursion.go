@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	TemperatureExcursionStatusOpen         = "open"
+	TemperatureExcursionStatusAcknowledged = "acknowledged"
+	TemperatureExcursionStatusClosed       = "closed"
+)
+
+// temperatureExcursionTransitions maps every status to the statuses it can move to. Closed excursions are final.
+var temperatureExcursionTransitions = map[string][]string{
+	TemperatureExcursionStatusOpen:         {TemperatureExcursionStatusAcknowledged, TemperatureExcursionStatusClosed},
+	TemperatureExcursionStatusAcknowledged: {TemperatureExcursionStatusClosed},
+	TemperatureExcursionStatusClosed:       {},
+}
+
+// TemperatureExcursion is a struct that represents an incident where a section was kept outside its temperature limits
+type TemperatureExcursion struct {
+	ID        int
+	SectionID int
+	Status    string
+	StartedAt time.Time
+	// EndedAt is nil while the section is still outside its limits
+	EndedAt *time.Time
+	// PeakCelsius is the reading that deviated the most from the limits
+	PeakCelsius   float64
+	PeakDeviation float64
+	// LimitCelsius is the limit broken by the peak reading
+	LimitCelsius   float64
+	AcknowledgedBy string
+	AcknowledgedAt *time.Time
+	ClosedBy       string
+}
+
+// TemperatureExcursionFilter narrows the excursions listed. Zero values are ignored.
+type TemperatureExcursionFilter struct {
+	Status      string
+	SectionID   int
+	WarehouseID int
+}
+
+// TemperatureLimits is the range a section must be kept in.
+// The minimum is the warmer of the minimum temperatures of the section and its warehouse, the maximum
+// is the coldest recommended freezing temperature of the products stored in the section.
+type TemperatureLimits struct {
+	Minimum float64
+	Maximum float64
+	// HasMaximum is false when the section stores no products
+	HasMaximum bool
+}
+
+var (
+	// ErrTemperatureExcursionNotFound is returned when the excursion does not exist
+	ErrTemperatureExcursionNotFound = errors.New("temperature excursion not found")
+	// ErrTemperatureExcursionInvalidTransition is returned when the excursion cannot move to the requested status
+	ErrTemperatureExcursionInvalidTransition = errors.New("temperature excursion status transition is not allowed")
+	// ErrTemperatureExcursionBadRequest is returned when the status change breaks a business rule
+	ErrTemperatureExcursionBadRequest = errors.New("temperature excursion status inputs are invalid")
+	// ErrTemperatureExcursionUnprocessableEntity is returned when the status change inputs are missing
+	ErrTemperatureExcursionUnprocessableEntity = errors.New("temperature excursion status inputs are missing")
+	// ErrTemperatureLimitsInvalid is returned when the minimum of a section or its warehouse is above the maximum of its products
+	ErrTemperatureLimitsInvalid = errors.New("temperature limits of the section do not form a range")
+)
+
+// IsTemperatureExcursionStatus reports whether the given status is a known excursion status
+func IsTemperatureExcursionStatus(status string) bool {
+	_, ok := temperatureExcursionTransitions[status]
+	return ok
+}
+
+// CanTransitionTo reports whether the excursion can move from its current status to the given one
+func (e *TemperatureExcursion) CanTransitionTo(status string) bool {
+	for _, next := range temperatureExcursionTransitions[e.Status] {
+		if next == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Deviation returns how far the reading is from the limits and the limit it breaks.
+// A reading inside the limits has no deviation.
+func (l TemperatureLimits) Deviation(celsius float64) (deviation float64, limit float64) {
+	if celsius < l.Minimum {
+		return l.Minimum - celsius, l.Minimum
+	}
+
+	if l.HasMaximum && celsius > l.Maximum {
+		return celsius - l.Maximum, l.Maximum
+	}
+
+	return 0, 0
+}
+
+// TemperatureExcursionRepository is an interface that contains the methods that the temperature excursion repository should support
+type TemperatureExcursionRepository interface {
+	// FindAll returns the excursions that match the filter, the most recent first
+	FindAll(filter TemperatureExcursionFilter) ([]TemperatureExcursion, error)
+	FindByID(id int) (TemperatureExcursion, error)
+	// FindActiveBySectionID returns the excursion of the section that has not ended yet
+	FindActiveBySectionID(sectionID int) (TemperatureExcursion, error)
+	// FindLimitsBySectionID returns the temperature limits of the section
+	FindLimitsBySectionID(sectionID int) (TemperatureLimits, error)
+	Save(excursion *TemperatureExcursion) error
+	Update(excursion *TemperatureExcursion) error
+}
+
+// TemperatureExcursionService is an interface that contains the methods that the temperature excursion service should support
+type TemperatureExcursionService interface {
+	FindAll(filter TemperatureExcursionFilter) ([]TemperatureExcursion, error)
+	FindByID(id int) (TemperatureExcursion, error)
+	// FindProductBatches returns the batches with stock left in the section of the excursion
+	FindProductBatches(id int) ([]ProductBatch, error)
+	// Evaluate checks the readings against the limits of their sections, opening, updating and closing excursions
+	Evaluate(readings []TemperatureReading) error
+	// UpdateStatus acknowledges or closes the excursion
+	UpdateStatus(id int, status string, changedBy string) (TemperatureExcursion, error)
+}
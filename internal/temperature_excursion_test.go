@@ -0,0 +1,52 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemperatureLimits_Deviation(t *testing.T) {
+	tests := []struct {
+		name              string
+		limits            internal.TemperatureLimits
+		celsius           float64
+		expectedDeviation float64
+		expectedLimit     float64
+	}{
+		{name: "inside the limits", limits: internal.TemperatureLimits{Minimum: -20, Maximum: -15, HasMaximum: true}, celsius: -18},
+		{name: "below the minimum", limits: internal.TemperatureLimits{Minimum: -20, Maximum: -15, HasMaximum: true}, celsius: -23, expectedDeviation: 3, expectedLimit: -20},
+		{name: "above the maximum", limits: internal.TemperatureLimits{Minimum: -20, Maximum: -15, HasMaximum: true}, celsius: -10, expectedDeviation: 5, expectedLimit: -15},
+		{name: "no maximum without products", limits: internal.TemperatureLimits{Minimum: -20}, celsius: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deviation, limit := tt.limits.Deviation(tt.celsius)
+			assert.Equal(t, tt.expectedDeviation, deviation)
+			assert.Equal(t, tt.expectedLimit, limit)
+		})
+	}
+}
+
+func TestTemperatureExcursion_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from     string
+		to       string
+		expected bool
+	}{
+		{from: internal.TemperatureExcursionStatusOpen, to: internal.TemperatureExcursionStatusAcknowledged, expected: true},
+		{from: internal.TemperatureExcursionStatusOpen, to: internal.TemperatureExcursionStatusClosed, expected: true},
+		{from: internal.TemperatureExcursionStatusAcknowledged, to: internal.TemperatureExcursionStatusClosed, expected: true},
+		{from: internal.TemperatureExcursionStatusAcknowledged, to: internal.TemperatureExcursionStatusOpen, expected: false},
+		{from: internal.TemperatureExcursionStatusClosed, to: internal.TemperatureExcursionStatusAcknowledged, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			e := internal.TemperatureExcursion{Status: tt.from}
+			assert.Equal(t, tt.expected, e.CanTransitionTo(tt.to))
+		})
+	}
+}
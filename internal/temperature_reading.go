@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TemperatureReadingMaxBatch is the maximum number of readings accepted in a single ingestion
+const TemperatureReadingMaxBatch = 1000
+
+// TemperatureReading is a struct that represents a temperature measured in a section at a point in time
+type TemperatureReading struct {
+	ID         int
+	SectionID  int
+	RecordedAt time.Time
+	Celsius    float64
+}
+
+var (
+	// ErrTemperatureReadingBadRequest is returned when the readings break a business rule
+	ErrTemperatureReadingBadRequest = errors.New("temperature reading inputs are invalid")
+	// ErrTemperatureReadingUnprocessableEntity is returned when the readings are missing required fields
+	ErrTemperatureReadingUnprocessableEntity = errors.New("temperature reading inputs are missing")
+)
+
+// Validate validates the business rules of the reading at the given position of the ingested batch
+func (t *TemperatureReading) Validate(index int) (causes []Causes) {
+	if t.SectionID <= 0 {
+		causes = append(causes, Causes{
+			Field:   fmt.Sprintf("[%d].section_id", index),
+			Message: "section ID must be greater than zero",
+		})
+	}
+
+	if t.RecordedAt.IsZero() {
+		causes = append(causes, Causes{
+			Field:   fmt.Sprintf("[%d].recorded_at", index),
+			Message: "recorded at is required",
+		})
+	}
+
+	if t.Celsius < -273.15 {
+		causes = append(causes, Causes{
+			Field:   fmt.Sprintf("[%d].celsius", index),
+			Message: "celsius cannot be below absolute zero",
+		})
+	}
+
+	return causes
+}
+
+// TemperatureReadingRepository is an interface that contains the methods that the temperature reading repository should support
+type TemperatureReadingRepository interface {
+	// FindBySectionID returns the readings of a section recorded between from and to, zero bounds are ignored
+	FindBySectionID(sectionID int, from time.Time, to time.Time) ([]TemperatureReading, error)
+	// SaveBatch stores the readings and refreshes the current temperature of the sections and their batches
+	SaveBatch(readings []TemperatureReading) error
+}
+
+// TemperatureReadingService is an interface that contains the methods that the temperature reading service should support
+type TemperatureReadingService interface {
+	// FindBySectionID returns the readings of a section recorded between from and to, zero bounds are ignored
+	FindBySectionID(sectionID int, from time.Time, to time.Time) ([]TemperatureReading, error)
+	// Ingest validates and stores a batch of readings
+	Ingest(readings []TemperatureReading) error
+}
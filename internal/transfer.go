@@ -0,0 +1,145 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/meli-fresh-products-api-backend-t1/utils/validator"
+)
+
+const (
+	// TransferStatusPending is the status of a transfer between warehouses that has not left its section yet
+	TransferStatusPending = "pending"
+	// TransferStatusInTransit is the status of a transfer whose stock left the source section and is on its way
+	TransferStatusInTransit = "in_transit"
+	// TransferStatusReceived is the status of a transfer whose stock is stored in the destination section.
+	// Transfers inside a warehouse are received as soon as they are created.
+	TransferStatusReceived = "received"
+)
+
+// transferTransitions maps every transfer status to the status it can move to
+var transferTransitions = map[string]string{
+	TransferStatusPending:   TransferStatusInTransit,
+	TransferStatusInTransit: TransferStatusReceived,
+}
+
+// Transfer is a struct that represents a quantity of a product batch moved from one section to another
+type Transfer struct {
+	ID int
+	// ProductBatchID is the batch the quantity is taken from
+	ProductBatchID int
+	// DestinationProductBatchID is the batch holding the quantity in the destination section.
+	// It is the source batch when the whole batch is moved and a split of it otherwise, zero until received.
+	DestinationProductBatchID int
+	FromSectionID             int
+	ToSectionID               int
+	Quantity                  int
+	Status                    string
+	// EmployeeID is the employee who requested the transfer
+	EmployeeID int
+	// DispatchedBy is the employee who sent the stock, zero until dispatched
+	DispatchedBy int
+	// ReceivedBy is the employee who stored the stock in the destination section, zero until received
+	ReceivedBy   int
+	CreatedAt    time.Time
+	DispatchedAt *time.Time
+	ReceivedAt   *time.Time
+}
+
+var (
+	// ErrTransferNotFound is returned when the transfer does not exist
+	ErrTransferNotFound = errors.New("transfer not found")
+	// ErrTransferInvalidTransition is returned when the transfer cannot move to the requested status
+	ErrTransferInvalidTransition = errors.New("transfer status transition is not allowed")
+	// ErrTransferBadRequest is returned when the transfer breaks a business rule
+	ErrTransferBadRequest = errors.New("transfer inputs are invalid")
+	// ErrTransferUnprocessableEntity is returned when the transfer inputs are missing
+	ErrTransferUnprocessableEntity = errors.New("transfer inputs are missing")
+	// ErrTransferStockReserved is returned when the stock left in the batch once its reservations are kept is not enough
+	ErrTransferStockReserved = errors.New("batch stock is reserved by purchase orders")
+)
+
+// CanTransferTransition reports whether a transfer can move between the given statuses
+func CanTransferTransition(from string, to string) bool {
+	return transferTransitions[from] == to
+}
+
+// Validate validates the business rules of the transfer
+func (t *Transfer) Validate() (causes []Causes) {
+	if !validator.IntIsPositive(t.ProductBatchID) {
+		causes = append(causes, Causes{
+			Field:   "product_batch_id",
+			Message: "product batch ID must be greater than zero",
+		})
+	}
+
+	if !validator.IntIsPositive(t.ToSectionID) {
+		causes = append(causes, Causes{
+			Field:   "section_id",
+			Message: "section ID must be greater than zero",
+		})
+	}
+
+	if !validator.IntIsPositive(t.Quantity) {
+		causes = append(causes, Causes{
+			Field:   "quantity",
+			Message: "quantity must be greater than zero",
+		})
+	}
+
+	if !validator.IntIsPositive(t.EmployeeID) {
+		causes = append(causes, Causes{
+			Field:   "employee_id",
+			Message: "employee ID must be greater than zero",
+		})
+	}
+
+	return causes
+}
+
+// ValidateBatch validates the transfer against the batch it takes the quantity from
+func (t *Transfer) ValidateBatch(prodBatch ProductBatch) (causes []Causes) {
+	if prodBatch.SectionID == t.ToSectionID {
+		causes = append(causes, Causes{
+			Field:   "section_id",
+			Message: fmt.Sprintf("product batch is already stored in section %d", t.ToSectionID),
+		})
+	}
+
+	if t.Quantity > prodBatch.CurrentQuantity {
+		causes = append(causes, Causes{
+			Field:   "quantity",
+			Message: fmt.Sprintf("quantity must be at most the %d units left in the batch", prodBatch.CurrentQuantity),
+		})
+	}
+
+	return causes
+}
+
+// TransferRepository is an interface that contains the methods that the transfer repository should support
+type TransferRepository interface {
+	// FindAll returns the transfers, only the ones in the given status when it is not empty
+	FindAll(status string) ([]Transfer, error)
+	FindByID(id int) (Transfer, error)
+	// Save records the transfer. Received transfers move their stock in the same transaction.
+	Save(t *Transfer) error
+	// Dispatch takes the quantity out of the source batch and puts the transfer in transit.
+	// Stock reserved by purchase orders cannot be transferred.
+	Dispatch(t *Transfer) error
+	// Receive stores the quantity in the destination section and marks the transfer as received
+	Receive(t *Transfer) error
+}
+
+// TransferService is an interface that contains the methods that the transfer service should support
+type TransferService interface {
+	// FindAll returns the transfers, only the ones in the given status when it is not empty
+	FindAll(status string) ([]Transfer, error)
+	FindByID(id int) (Transfer, error)
+	// Save moves the stock right away inside a warehouse and opens a pending transfer between warehouses
+	Save(t *Transfer) error
+	// Dispatch sends the stock of a pending transfer
+	Dispatch(id int, employeeID int) (Transfer, error)
+	// Receive stores the stock of a transfer in transit in its destination section
+	Receive(id int, employeeID int) (Transfer, error)
+}
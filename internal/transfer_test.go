@@ -0,0 +1,46 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/meli-fresh-products-api-backend-t1/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransferTransition(t *testing.T) {
+	tests := []struct {
+		from     string
+		to       string
+		expected bool
+	}{
+		{from: internal.TransferStatusPending, to: internal.TransferStatusInTransit, expected: true},
+		{from: internal.TransferStatusInTransit, to: internal.TransferStatusReceived, expected: true},
+		{from: internal.TransferStatusPending, to: internal.TransferStatusReceived, expected: false},
+		{from: internal.TransferStatusReceived, to: internal.TransferStatusInTransit, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			assert.Equal(t, tt.expected, internal.CanTransferTransition(tt.from, tt.to))
+		})
+	}
+}
+
+func TestTransfer_ValidateBatch(t *testing.T) {
+	prodBatch := internal.ProductBatch{ID: 1, CurrentQuantity: 100, SectionID: 3}
+
+	t.Run("quantity within the batch", func(t *testing.T) {
+		tr := internal.Transfer{ProductBatchID: 1, ToSectionID: 5, Quantity: 100, EmployeeID: 2}
+
+		assert.Empty(t, tr.ValidateBatch(prodBatch))
+	})
+
+	t.Run("same section and quantity over the batch", func(t *testing.T) {
+		tr := internal.Transfer{ProductBatchID: 1, ToSectionID: 3, Quantity: 101, EmployeeID: 2}
+
+		assert.Equal(t, []internal.Causes{
+			{Field: "section_id", Message: "product batch is already stored in section 3"},
+			{Field: "quantity", Message: "quantity must be at most the 100 units left in the batch"},
+		}, tr.ValidateBatch(prodBatch))
+	})
+}
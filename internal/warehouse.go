@@ -14,6 +14,10 @@ type Warehouse struct {
 	Telephone          string
 	MinimumCapacity    int
 	MinimumTemperature float64
+	// LocalityID is the locality the warehouse is in, zero for warehouses registered before the link
+	LocalityID int
+	// Coordinates locate the warehouse, warehouses without them are left out of the nearest lookup
+	Coordinates *Coordinates
 }
 
 // WarehousePatchUpdate is a struct to use in a patch request
@@ -23,6 +27,24 @@ type WarehousePatchUpdate struct {
 	Telephone          *string  `json:"telephone"`
 	MinimumCapacity    *int     `json:"minimum_capacity"`
 	MinimumTemperature *float64 `json:"minimum_temperature"`
+	LocalityID         *int     `json:"locality_id"`
+	Latitude           *float64 `json:"latitude"`
+	Longitude          *float64 `json:"longitude"`
+}
+
+// NearestWarehouseRequest is the product and quantity to deliver to a locality from the nearest warehouse
+type NearestWarehouseRequest struct {
+	LocalityID int
+	ProductID  int
+	Quantity   int
+}
+
+// NearestWarehouse is a warehouse holding enough stock of a product and its distance to the locality
+type NearestWarehouse struct {
+	Warehouse Warehouse
+	// AvailableQuantity is the quantity of released, unexpired and unreserved stock of the product
+	AvailableQuantity int
+	DistanceKm        float64
 }
 
 var (
@@ -34,6 +56,8 @@ var (
 	ErrWarehouseUnprocessableEntity = errors.New("warehouse inputs are missing")
 	// ErrWarehouseBadRequest is returned when the warehouse request is bad
 	ErrWarehouseBadRequest = errors.New("warehouse inputs are invalid")
+	// ErrNearestWarehouseBadRequest is returned when the nearest warehouse request is bad
+	ErrNearestWarehouseBadRequest = errors.New("nearest warehouse inputs are invalid")
 )
 
 // Validate validates the business rules of the warehouse
@@ -101,6 +125,43 @@ func (w *Warehouse) Validate() (causes []Causes) {
 		})
 	}
 
+	if !validator.IntIsPositive(w.LocalityID) {
+		causes = append(causes, Causes{
+			Field:   "locality_id",
+			Message: "locality id must be positive",
+		})
+	}
+
+	if w.Coordinates != nil {
+		causes = append(causes, w.Coordinates.Validate()...)
+	}
+
+	return causes
+}
+
+// Validate validates the business rules of the nearest warehouse request
+func (r *NearestWarehouseRequest) Validate() (causes []Causes) {
+	if !validator.IntIsPositive(r.LocalityID) {
+		causes = append(causes, Causes{
+			Field:   "locality_id",
+			Message: "locality id must be positive",
+		})
+	}
+
+	if !validator.IntIsPositive(r.ProductID) {
+		causes = append(causes, Causes{
+			Field:   "product_id",
+			Message: "product id must be positive",
+		})
+	}
+
+	if !validator.IntIsPositive(r.Quantity) {
+		causes = append(causes, Causes{
+			Field:   "qty",
+			Message: "quantity must be positive",
+		})
+	}
+
 	return causes
 }
 
@@ -116,6 +177,9 @@ type WarehouseRepository interface {
 	Update(warehouse *Warehouse) error
 	// Delete deletes the warehouse with the given ID
 	Delete(id int) error
+	// FindWithAvailableStock returns the warehouses with coordinates holding at least the quantity
+	// of released, unexpired and unreserved stock of the product
+	FindWithAvailableStock(productID int, quantity int) ([]NearestWarehouse, error)
 }
 
 // WarehouseService is an interface that contains the methods that the warehouse service should support
@@ -130,4 +194,6 @@ type WarehouseService interface {
 	Update(id int, warehousePatch *WarehousePatchUpdate) (Warehouse, error)
 	// Delete deletes the warehouse with the given ID
 	Delete(id int) error
+	// FindNearest returns the warehouses able to deliver the quantity of the product ordered by distance to the locality
+	FindNearest(request NearestWarehouseRequest) ([]NearestWarehouse, error)
 }
@@ -21,6 +21,7 @@ func TestWarehouseValidate(t *testing.T) {
 				Telephone:          "12 34567-8901",
 				MinimumCapacity:    100,
 				MinimumTemperature: 20.0,
+				LocalityID:         1,
 			},
 			wantErr: false,
 			causes:  nil,
@@ -32,6 +33,7 @@ func TestWarehouseValidate(t *testing.T) {
 				Telephone:          "12 34567-8901",
 				MinimumCapacity:    100,
 				MinimumTemperature: 20.0,
+				LocalityID:         1,
 			},
 			wantErr: true,
 			causes: []internal.Causes{
@@ -49,6 +51,7 @@ func TestWarehouseValidate(t *testing.T) {
 				Telephone:          "12 34567-8901",
 				MinimumCapacity:    100,
 				MinimumTemperature: 20.0,
+				LocalityID:         1,
 			},
 			wantErr: true,
 			causes: []internal.Causes{
@@ -65,6 +68,7 @@ func TestWarehouseValidate(t *testing.T) {
 				Telephone:          "12 34567-8901",
 				MinimumCapacity:    100,
 				MinimumTemperature: 20.0,
+				LocalityID:         1,
 			},
 			wantErr: true,
 			causes: []internal.Causes{
@@ -82,6 +86,7 @@ func TestWarehouseValidate(t *testing.T) {
 				Telephone:          "12 34567-8901",
 				MinimumCapacity:    100,
 				MinimumTemperature: 20.0,
+				LocalityID:         1,
 			},
 			wantErr: true,
 			causes: []internal.Causes{
@@ -99,6 +104,7 @@ func TestWarehouseValidate(t *testing.T) {
 				Address:            "Test Address",
 				MinimumCapacity:    100,
 				MinimumTemperature: 20.0,
+				LocalityID:         1,
 			},
 			wantErr: true,
 			causes: []internal.Causes{
@@ -120,6 +126,7 @@ func TestWarehouseValidate(t *testing.T) {
 				Telephone:          "12345678901", // Invalid format
 				MinimumCapacity:    100,
 				MinimumTemperature: 20.0,
+				LocalityID:         1,
 			},
 			wantErr: true,
 			causes: []internal.Causes{
@@ -137,6 +144,7 @@ func TestWarehouseValidate(t *testing.T) {
 				Telephone:          "12 34567-890111", // Invalid format
 				MinimumCapacity:    100,
 				MinimumTemperature: 20.0,
+				LocalityID:         1,
 			},
 			wantErr: true,
 			causes: []internal.Causes{
@@ -154,6 +162,7 @@ func TestWarehouseValidate(t *testing.T) {
 				Telephone:          "12 34567-8901",
 				MinimumCapacity:    0,
 				MinimumTemperature: 20.0,
+				LocalityID:         1,
 			},
 			wantErr: true,
 			causes: []internal.Causes{
@@ -171,6 +180,7 @@ func TestWarehouseValidate(t *testing.T) {
 				Telephone:          "12 34567-8901",
 				MinimumCapacity:    -10,
 				MinimumTemperature: 20.0,
+				LocalityID:         1,
 			},
 			wantErr: true,
 			causes: []internal.Causes{
@@ -188,6 +198,7 @@ func TestWarehouseValidate(t *testing.T) {
 				Telephone:          "12 34567-8901",
 				MinimumCapacity:    100,
 				MinimumTemperature: -300.0,
+				LocalityID:         1,
 			},
 			wantErr: true,
 			causes: []internal.Causes{
@@ -205,6 +216,7 @@ func TestWarehouseValidate(t *testing.T) {
 				Telephone:          "12 34567-8901",
 				MinimumCapacity:    100,
 				MinimumTemperature: 1200.0,
+				LocalityID:         1,
 			},
 			wantErr: true,
 			causes: []internal.Causes{
@@ -214,6 +226,23 @@ func TestWarehouseValidate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "invalid warehouse - missing locality",
+			warehouse: internal.Warehouse{
+				WarehouseCode:      "WH-123",
+				Address:            "Test Address",
+				Telephone:          "12 34567-8901",
+				MinimumCapacity:    100,
+				MinimumTemperature: 20.0,
+			},
+			wantErr: true,
+			causes: []internal.Causes{
+				{
+					Field:   "locality_id",
+					Message: "locality id must be positive",
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
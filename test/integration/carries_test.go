@@ -44,7 +44,13 @@ func (c *CarriesTestSuite) SetupTest() {
 	c.db, err = sql.Open("txdb", "identier")
 	require.NoError(c.T(), err)
 	rp := repository.NewCarriesMysql(c.db)
-	sv := service.NewCarriesService(rp)
+	sv := service.NewCarriesService(
+		rp,
+		repository.NewLocalityMysql(c.db),
+		repository.NewWarehouseMysqlRepository(c.db),
+		repository.NewPurchaseOrderLineMysql(c.db),
+		repository.NewProductSQL(c.db),
+	)
 	c.hd = handler.NewCarriesHandlerDefault(sv)
 }
 
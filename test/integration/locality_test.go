@@ -44,7 +44,7 @@ func (l *LocalityTestSuite) SetupTest() {
 	l.db, err = sql.Open(name, "")
 	require.NoError(l.T(), err)
 	rp := repository.NewLocalityMysql(l.db)
-	sv := service.NewLocalityDefault(rp)
+	sv := service.NewLocalityDefault(rp, repository.NewProvinceMysql(l.db))
 	l.hd = handler.NewLocalityDefault(sv)
 }
 
@@ -56,7 +56,9 @@ func (p *PurchaseOrdersTestSuite) SetupTest() {
 	rpBuyer := repository.NewBuyerMysqlRepository(p.db)
 	rpProductRecord := repository.NewProductRecordsSQL(p.db)
 	svBuyer := service.NewBuyerService(rpBuyer)
-	sv := service.NewPurchaseOrderService(rpPurchaseOrder, rpProductRecord, svBuyer)
+	rpPurchaseOrderLine := repository.NewPurchaseOrderLineMysql(p.db)
+	rpProduct := repository.NewProductSQL(p.db)
+	sv := service.NewPurchaseOrderService(rpPurchaseOrder, rpPurchaseOrderLine, rpProductRecord, rpProduct, svBuyer)
 
 	p.rp = rpPurchaseOrder
 	p.hd = handler.NewPurchaseOrderHandler(sv)
@@ -73,7 +75,6 @@ func (p *PurchaseOrdersTestSuite) TestPurchaseOrders_Create() {
 				`{
 					"order_number": "123ABC",
 					"order_date": "2023-10-05",
-					"tracking_code": "123",
 					"buyer_id": 1,
 					"product_record_id": 2
 				}`,
@@ -98,7 +99,8 @@ func (p *PurchaseOrdersTestSuite) TestPurchaseOrders_Create() {
 
 		// then
 		expectedCode := http.StatusCreated
-		expectedBody := fmt.Sprintf(`{"data":{"id":%d,"order_number":"123ABC","order_date":"2023-10-05","tracking_code":"123","buyer_id":1,"product_record_id":2}}`, po.ID)
+		require.Len(t, data.PurchaseOrderCreated.Lines, 1)
+		expectedBody := fmt.Sprintf(`{"data":{"id":%d,"order_number":"123ABC","order_date":"2023-10-05","tracking_code":"","buyer_id":1,"product_record_id":2,"status":"created","lines":[{"id":%d,"product_id":2,"quantity":1,"unit_price":45,"reserved_quantity":0,"fulfilled_quantity":0}]}}`, po.ID, data.PurchaseOrderCreated.Lines[0].ID)
 		expectedHeader := http.Header{"Content-Type": []string{"application/json"}}
 		require.Equal(t, expectedCode, response.Code)
 		require.JSONEq(t, expectedBody, response.Body.String())
@@ -114,7 +116,6 @@ func (p *PurchaseOrdersTestSuite) TestPurchaseOrders_Create() {
 				`{
 					"order_number": "PO1001",
 					"order_date": "2023-10-05",
-					"tracking_code": "123",
 					"buyer_id": 1,
 					"product_record_id": 2
 				}`,
@@ -149,7 +150,6 @@ func (p *PurchaseOrdersTestSuite) TestPurchaseOrders_Create() {
 				`{
 					"order_number": "PO1001",
 					"order_date": "2023-10-05asdf",
-					"tracking_code": "123",
 					"buyer_id": 1,
 					"product_record_id": 2
 				}`,
@@ -189,7 +189,6 @@ func (p *PurchaseOrdersTestSuite) TestPurchaseOrders_Create() {
 				`{
 					"order_number": "PO1001",
 					"order_date": "2023-10-05",
-					"tracking_code": "123",
 					"buyer_id": 100,
 					"product_record_id": 2
 				}`,
@@ -224,7 +223,6 @@ func (p *PurchaseOrdersTestSuite) TestPurchaseOrders_Create() {
 				`{
 					"order_number": "PO1001",
 					"order_date": "2023-10-05",
-					"tracking_code": "123",
 					"buyer_id": 1,
 					"product_record_id": 100
 				}`,
@@ -276,10 +274,6 @@ func (p *PurchaseOrdersTestSuite) TestPurchaseOrders_Create() {
 					"field": "order_number",
 					"message": "Order number is required"
 				},
-				{
-					"field": "tracking_code",
-					"message": "Tracking code is required"
-				},
 				{
 					"field": "buyer_id",
 					"message": "Buyer ID is required"